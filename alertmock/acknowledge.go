@@ -0,0 +1,101 @@
+package alertmock
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/opsorch/opsorch-core/orcherr"
+	"github.com/opsorch/opsorch-core/schema"
+	"github.com/opsorch/opsorch-mock-adapters/internal/mockutil"
+)
+
+// Acknowledge marks a firing alert acknowledged by actor. A manual
+// acknowledgement takes over from the alert's lifecycle: its remaining steps
+// are dropped, so the alert stays acknowledged rather than being moved on by
+// the clock.
+func (p *Provider) Acknowledge(ctx context.Context, id, actor string) (schema.Alert, error) {
+	_ = ctx
+	actor = strings.TrimSpace(actor)
+	if actor == "" {
+		return schema.Alert{}, orcherr.New("bad_request", "actor is required", nil)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now().UTC()
+	p.refreshLifecycleLocked(now)
+	al, ok := p.alerts[id]
+	if !ok {
+		return schema.Alert{}, orcherr.New("not_found", "alert not found", nil)
+	}
+	if al.Status != "firing" {
+		return schema.Alert{}, orcherr.New("bad_request", fmt.Sprintf("alert %s is %s, not firing", id, al.Status), nil)
+	}
+	delete(p.lifecycle, id)
+
+	al.Status = "acknowledged"
+	al.UpdatedAt = now
+	al.Fields = mockutil.CloneMap(al.Fields)
+	if al.Fields == nil {
+		al.Fields = map[string]any{}
+	}
+	al.Fields["acknowledgedBy"] = actor
+	al.Fields["acknowledgedAt"] = now.Format(time.RFC3339)
+	al.Fields["notes"] = fmt.Sprintf("Acknowledged by %s", actor)
+	applyIntegration(&al)
+	p.alerts[id] = al
+	p.publishLocked()
+
+	out := cloneAlert(al)
+	p.applyPriority(&out, now)
+	return out, nil
+}
+
+// RegisterOperatorTasks registers the provider's operator tasks on op.
+func (p *Provider) RegisterOperatorTasks(op *mockutil.Operator) {
+	op.Register("alert.acknowledge", p.operatorAcknowledge)
+}
+
+// operatorAcknowledge is the simulated operator's alert task: acknowledge the
+// oldest firing alert, leaving noise and load-test alerts alone as a
+// responder triaging the queue would.
+func (p *Provider) operatorAcknowledge(ctx context.Context, actor string) (mockutil.OperatorAction, bool, error) {
+	p.mu.Lock()
+	p.refreshLifecycleLocked(time.Now().UTC())
+	candidates := make([]schema.Alert, 0)
+	for _, al := range p.alerts {
+		if al.Status != "firing" {
+			continue
+		}
+		if noise, _ := al.Fields["noise"].(bool); noise {
+			continue
+		}
+		if loadTest, _ := al.Fields["loadTest"].(bool); loadTest {
+			continue
+		}
+		candidates = append(candidates, al)
+	}
+	p.mu.Unlock()
+	if len(candidates) == 0 {
+		return mockutil.OperatorAction{}, false, nil
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if !candidates[i].CreatedAt.Equal(candidates[j].CreatedAt) {
+			return candidates[i].CreatedAt.Before(candidates[j].CreatedAt)
+		}
+		return candidates[i].ID < candidates[j].ID
+	})
+
+	al, err := p.Acknowledge(ctx, candidates[0].ID, actor)
+	if err != nil {
+		return mockutil.OperatorAction{}, false, err
+	}
+	return mockutil.OperatorAction{
+		Ref:     mockutil.FormatRef(mockutil.RefAlert, al.ID),
+		Summary: fmt.Sprintf("Acknowledged %s: %s", al.ID, al.Title),
+	}, true, nil
+}
@@ -0,0 +1,110 @@
+package alertmock
+
+import (
+	"hash/fnv"
+	"math"
+	"time"
+
+	"github.com/opsorch/opsorch-core/schema"
+)
+
+// ETA bounds: the spread around a prediction starts at etaSpread of the time
+// left and halves with each lifecycle step the alert passes; clients are
+// told to refresh after a sixth of the time left, within these limits.
+const (
+	etaSpread     = 0.6
+	etaMinRefresh = 15 * time.Second
+	etaMaxRefresh = 5 * time.Minute
+)
+
+// etaBias is how far off the first prediction is, as a fraction of the time
+// left: from etaBias early to etaBias late, fixed per alert.
+const etaBias = 0.25
+
+// resolutionETA is a predicted resolve time and the window around it.
+type resolutionETA struct {
+	Predicted  time.Time
+	Earliest   time.Time
+	Latest     time.Time
+	Confidence float64
+	// Refresh is how long a client should wait before asking again.
+	Refresh time.Duration
+}
+
+// predictResolution predicts when an alert with a scripted lifecycle
+// resolves. The prediction starts off by a fixed per-alert bias and converges
+// on the scripted resolve time as it nears, and the window around it narrows
+// both with the clock and with each step the alert passes, so a client
+// polling it sees the ETA settle. It returns false for alerts without a
+// pending resolve step.
+func predictResolution(al schema.Alert, plan *alertLifecycle, now time.Time) (resolutionETA, bool) {
+	if plan == nil || !activeStatuses[al.Status] {
+		return resolutionETA{}, false
+	}
+	resolveAfter, ok := time.Duration(0), false
+	for _, step := range plan.steps[plan.applied:] {
+		if step.Status == "resolved" {
+			resolveAfter, ok = step.After, true
+			break
+		}
+	}
+	if !ok {
+		return resolutionETA{}, false
+	}
+	due := al.CreatedAt.Add(resolveAfter)
+	remaining := due.Sub(now)
+	if remaining < 0 {
+		remaining = 0
+	}
+
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(al.ID))
+	bias := (float64(h.Sum32()%101)/50 - 1) * etaBias
+	eta := resolutionETA{Predicted: due.Add(time.Duration(float64(remaining) * bias)).Truncate(time.Second)}
+	if eta.Predicted.Before(now) {
+		eta.Predicted = now.Truncate(time.Second)
+	}
+
+	spread := time.Duration(float64(remaining) * etaSpread / math.Pow(2, float64(plan.applied)))
+	eta.Earliest = eta.Predicted.Add(-spread)
+	if eta.Earliest.Before(now) {
+		eta.Earliest = now.Truncate(time.Second)
+	}
+	eta.Latest = eta.Predicted.Add(spread)
+	eta.Confidence = 1
+	if resolveAfter > 0 {
+		eta.Confidence = math.Round((1-float64(spread)/float64(resolveAfter))*100) / 100
+	}
+
+	eta.Refresh = remaining / 6
+	if eta.Refresh < etaMinRefresh {
+		eta.Refresh = etaMinRefresh
+	}
+	if eta.Refresh > etaMaxRefresh {
+		eta.Refresh = etaMaxRefresh
+	}
+	return eta, true
+}
+
+// applyResolutionETA stamps Fields["predictedResolveAt"] and the window
+// behind it in Fields["predictedResolve"] on an outgoing copy of a
+// lifecycled alert. Callers must hold p.mu.
+func (p *Provider) applyResolutionETA(al *schema.Alert, now time.Time) {
+	if !p.cfg.Features.Lifecycle {
+		return
+	}
+	eta, ok := predictResolution(*al, p.lifecycle[al.ID], now)
+	if !ok {
+		return
+	}
+	if al.Fields == nil {
+		al.Fields = map[string]any{}
+	}
+	al.Fields["predictedResolveAt"] = eta.Predicted.Format(time.RFC3339)
+	al.Fields["predictedResolve"] = map[string]any{
+		"earliest":            eta.Earliest.Format(time.RFC3339),
+		"latest":              eta.Latest.Format(time.RFC3339),
+		"confidence":          eta.Confidence,
+		"refreshAfterSeconds": int(eta.Refresh.Seconds()),
+	}
+}
@@ -0,0 +1,169 @@
+package alertmock
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/opsorch/opsorch-core/orcherr"
+	"github.com/opsorch/opsorch-core/schema"
+	"github.com/opsorch/opsorch-mock-adapters/internal/mockutil"
+)
+
+// AlertRule is a monitor definition Fire can raise an alert from. Title and
+// Description are formats taking the service name.
+type AlertRule struct {
+	Name        string `json:"name"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Severity    string `json:"severity"`
+	Metric      string `json:"metric"`
+	Threshold   string `json:"threshold"`
+	// Runbook is the runbookBaseURL slug linked from fired alerts, so they
+	// resolve to an orchestration plan like the seeded ones.
+	Runbook string `json:"runbook,omitempty"`
+	// Thresholds are the warning and critical lines metricmock draws on the
+	// series the rule watches.
+	Thresholds *mockutil.AlertThreshold `json:"thresholds,omitempty"`
+}
+
+// alertRules are keyed by name.
+var alertRules = map[string]AlertRule{
+	"high-latency":    {Name: "high-latency", Title: "High p95 latency on %s", Description: "p95 latency on %s is above its SLO threshold", Severity: "critical", Metric: "http_request_duration_seconds:p95", Threshold: "1.2s", Runbook: "high-latency"},
+	"error-rate":      {Name: "error-rate", Title: "Elevated 5xx error rate on %s", Description: "More than 5%% of requests to %s are failing", Severity: "error", Metric: "http_requests_total:5xx_rate", Threshold: "5%", Runbook: "service-degradation"},
+	"cpu-saturation":  {Name: "cpu-saturation", Title: "CPU saturation on %s", Description: "Pods for %s are running at their CPU limit and being throttled", Severity: "warning", Metric: "container_cpu_usage_seconds_total", Threshold: "90%", Runbook: "pod-restart"},
+	"memory-pressure": {Name: "memory-pressure", Title: "Memory pressure on %s", Description: "Working set for %s is close to its memory limit", Severity: "warning", Metric: "container_memory_working_set_bytes", Threshold: "90%", Runbook: "pod-restart"},
+	"connection-pool": {Name: "connection-pool", Title: "Connection pool near capacity on %s", Description: "%s has checked out nearly every pooled database connection", Severity: "critical", Metric: "db_pool_connections_in_use", Threshold: "95%", Runbook: "db-connection-pool"},
+	"queue-backlog":   {Name: "queue-backlog", Title: "Queue backlog growing on %s", Description: "Consumers for %s are falling behind producers", Severity: "warning", Metric: "queue_depth", Threshold: "5000"},
+	"pod-restarts":    {Name: "pod-restarts", Title: "Pod restarts detected on %s", Description: "Containers for %s restarted repeatedly in the last 10 minutes", Severity: "error", Metric: "kube_pod_container_status_restarts_total", Threshold: "3", Runbook: "pod-restart"},
+}
+
+// AlertRules lists the rule templates Fire accepts, ordered by name.
+func AlertRules() []AlertRule {
+	out := make([]AlertRule, 0, len(alertRules))
+	for _, rule := range alertRules {
+		if th, ok := mockutil.AlertThresholdForRule(rule.Name); ok {
+			rule.Thresholds = &th
+		}
+		out = append(out, rule)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+// tierSeverities are the severities alerts default to by service tier when
+// neither the request nor its rule sets one.
+var tierSeverities = map[int]string{1: "critical", 2: "error", 3: "warning"}
+
+// FireRequest describes an alert to raise at runtime. With Rule set, the rule
+// supplies the title, description, severity, metric, and runbook, and any
+// other field set here overrides it.
+type FireRequest struct {
+	Rule        string         `json:"rule,omitempty"`
+	Service     string         `json:"service"`
+	Title       string         `json:"title,omitempty"`
+	Description string         `json:"description,omitempty"`
+	Severity    string         `json:"severity,omitempty"`
+	Environment string         `json:"environment,omitempty"`
+	Metric      string         `json:"metric,omitempty"`
+	Runbook     string         `json:"runbook,omitempty"`
+	Fields      map[string]any `json:"fields,omitempty"`
+}
+
+// Fire creates a firing alert for a demo. The team, region, Slack channel, and
+// dependencies come from the shared topology for the service; the environment
+// defaults to prod, and the severity, when neither the request nor the rule
+// sets one, to the service tier's. The alert is published to the shared alert snapshot, so
+// metric and incident providers in the same process correlate with it.
+func (p *Provider) Fire(ctx context.Context, req FireRequest) (schema.Alert, error) {
+	_ = ctx
+	service := ingestService(req.Service)
+	if service == "" {
+		return schema.Alert{}, orcherr.New("bad_request", "service is required", nil)
+	}
+	var rule AlertRule
+	if req.Rule != "" {
+		var ok bool
+		if rule, ok = alertRules[req.Rule]; !ok {
+			return schema.Alert{}, orcherr.New("not_found", fmt.Sprintf("alert rule %q not found", req.Rule), nil)
+		}
+	}
+	title := firstNonEmpty(req.Title, formatRule(rule.Title, service))
+	if title == "" {
+		return schema.Alert{}, orcherr.New("bad_request", "title or rule is required", nil)
+	}
+	severity := firstNonEmpty(req.Severity, rule.Severity, tierSeverities[p.serviceTier(service)])
+	switch severity {
+	case "critical", "error", "warning", "info":
+	default:
+		return schema.Alert{}, orcherr.New("bad_request", fmt.Sprintf("unknown severity %q", severity), nil)
+	}
+	now := time.Now().UTC()
+
+	team := mockutil.GetTeamForService(service)
+	fields := map[string]any{
+		"environment": firstNonEmpty(req.Environment, "prod"),
+		"team":        team,
+		"region":      mockutil.ServiceRegion(service),
+		"fired":       true,
+	}
+	if deps := mockutil.ServiceDependencies(service); len(deps) > 0 {
+		fields["dependencies"] = deps
+	}
+	if metric := firstNonEmpty(req.Metric, rule.Metric); metric != "" {
+		fields["metric"] = metric
+	}
+	if rule.Threshold != "" {
+		fields["threshold"] = rule.Threshold
+	}
+	for k, v := range req.Fields {
+		fields[k] = v
+	}
+	metadata := map[string]any{
+		"source":  p.cfg.Source,
+		"channel": mockutil.GetChannelForTeam(team),
+	}
+	if rule.Name != "" {
+		metadata["ruleId"] = "rule-" + rule.Name
+	}
+	if runbook := firstNonEmpty(req.Runbook, rule.Runbook); runbook != "" {
+		if !strings.HasPrefix(runbook, "https://") {
+			runbook = runbookBaseURL + runbook
+		}
+		metadata["runbook"] = runbook
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.fired++
+	al := schema.Alert{
+		ID:          fmt.Sprintf("al-fired-%03d", p.fired),
+		Title:       title,
+		Description: firstNonEmpty(req.Description, formatRule(rule.Description, service), title),
+		Status:      "firing",
+		Severity:    severity,
+		Service:     service,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+		Fields:      fields,
+		Metadata:    metadata,
+	}
+	al.URL = generateAlertURL(al.ID, service, false)
+	enrichAlertMetadata(&al)
+	applyIntegration(&al)
+	linkRunbookPlan(&al)
+	// A fired alert starts its own chain unless it names one.
+	stampCorrelation(&al, mockutil.CorrelationID("alert", al.ID))
+	p.alerts[al.ID] = al
+	p.publishLocked()
+	return cloneAlert(al), nil
+}
+
+func formatRule(format, service string) string {
+	if format == "" {
+		return ""
+	}
+	return fmt.Sprintf(format, service)
+}
@@ -0,0 +1,231 @@
+package alertmock
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/opsorch/opsorch-core/schema"
+	"github.com/opsorch/opsorch-mock-adapters/internal/mockutil"
+)
+
+// GeneratorConfig enables bulk synthetic alerts for load testing alert ingestion.
+// It is read from the "generator" config map; a zero Count disables generation.
+type GeneratorConfig struct {
+	Count       int
+	Services    []string
+	SeverityMix map[string]float64
+	FlapRate    float64
+	Seed        int64
+}
+
+// tierSeverityMixes weigh generated severities by service tier, so tier-1
+// services mostly fire critical and tier-3 services mostly fire warning. A
+// configured SeverityMix applies to every service instead.
+var tierSeverityMixes = map[int]map[string]float64{
+	1: {"critical": 0.45, "error": 0.30, "warning": 0.20, "info": 0.05},
+	2: {"critical": 0.10, "error": 0.35, "warning": 0.40, "info": 0.15},
+	3: {"error": 0.10, "warning": 0.60, "info": 0.30},
+}
+
+var defaultGeneratorServices = []string{
+	"svc-checkout", "svc-search", "svc-payments", "svc-notifications", "svc-identity",
+	"svc-catalog", "svc-shipping", "svc-realtime", "svc-database", "svc-cache",
+	"svc-api-gateway", "svc-order", "svc-analytics", "svc-recommendation",
+}
+
+var generatorSignals = []struct {
+	title  string
+	metric string
+}{
+	{"High p95 latency", "http_request_duration_seconds:p95"},
+	{"Elevated 5xx error rate", "http_requests_total:5xx_rate"},
+	{"CPU saturation", "container_cpu_usage_seconds_total"},
+	{"Memory pressure", "container_memory_working_set_bytes"},
+	{"Queue backlog growing", "queue_depth"},
+	{"Pod restarts detected", "kube_pod_container_status_restarts_total"},
+	{"Connection pool near capacity", "db_pool_connections_in_use"},
+	{"Disk usage high", "node_filesystem_avail_bytes"},
+}
+
+var generatorRegions = []string{"use1", "usw2", "euw1", "apse1"}
+
+func parseGeneratorConfig(raw any) GeneratorConfig {
+	out := GeneratorConfig{Seed: 1}
+	cfg, ok := raw.(map[string]any)
+	if !ok {
+		return out
+	}
+	if v, ok := toInt(cfg["count"]); ok && v > 0 {
+		out.Count = v
+	}
+	switch services := cfg["services"].(type) {
+	case []string:
+		out.Services = append(out.Services, services...)
+	case []any:
+		for _, s := range services {
+			if str, ok := s.(string); ok && str != "" {
+				out.Services = append(out.Services, str)
+			}
+		}
+	}
+	if mix, ok := cfg["severityMix"].(map[string]any); ok {
+		out.SeverityMix = map[string]float64{}
+		for severity, weight := range mix {
+			if w, ok := toFloat(weight); ok && w > 0 {
+				out.SeverityMix[severity] = w
+			}
+		}
+	}
+	if v, ok := toFloat(cfg["flapRate"]); ok && v >= 0 && v <= 1 {
+		out.FlapRate = v
+	}
+	if v, ok := toInt(cfg["seed"]); ok {
+		out.Seed = int64(v)
+	}
+	return out
+}
+
+// generateLoadAlerts builds gen.Count synthetic alerts. Output is deterministic for a given seed.
+func generateLoadAlerts(gen GeneratorConfig, source string, now time.Time) []schema.Alert {
+	if gen.Count <= 0 {
+		return nil
+	}
+	rng := rand.New(rand.NewSource(gen.Seed))
+
+	services := gen.Services
+	if len(services) == 0 {
+		services = defaultGeneratorServices
+	}
+	type severityTable struct {
+		severities []string
+		cumulative []float64
+	}
+	tables := map[int]severityTable{}
+	tableFor := func(service string) severityTable {
+		tier := 0
+		mix := gen.SeverityMix
+		if len(mix) == 0 {
+			tier = mockutil.ServiceTier(service)
+			mix = tierSeverityMixes[tier]
+		}
+		table, ok := tables[tier]
+		if !ok {
+			table.severities, table.cumulative = weightedTable(mix)
+			tables[tier] = table
+		}
+		return table
+	}
+
+	alerts := make([]schema.Alert, 0, gen.Count)
+	for i := 0; i < gen.Count; i++ {
+		id := fmt.Sprintf("al-load-%05d", i+1)
+		service := services[rng.Intn(len(services))]
+		table := tableFor(service)
+		severity := pickWeighted(rng, table.severities, table.cumulative)
+		signal := generatorSignals[rng.Intn(len(generatorSignals))]
+		region := generatorRegions[rng.Intn(len(generatorRegions))]
+
+		// Skew ages toward recent alerts, spread across the last 24 hours.
+		age := time.Duration(rng.ExpFloat64()*float64(2*time.Hour)) % (24 * time.Hour)
+		createdAt := now.Add(-age)
+
+		status := "firing"
+		switch r := rng.Float64(); {
+		case r < 0.15:
+			status = "resolved"
+		case r < 0.30:
+			status = "acknowledged"
+		}
+
+		fields := map[string]any{
+			"environment": "prod",
+			"team":        mockutil.GetTeamForService(service),
+			"region":      region,
+			"metric":      signal.metric,
+			"generated":   true,
+			"loadTest":    true,
+		}
+		if gen.FlapRate > 0 && rng.Float64() < gen.FlapRate {
+			flaps := 2 + rng.Intn(8)
+			fields["flapping"] = true
+			fields["flapCount"] = flaps
+			if flaps%2 == 0 {
+				status = "resolved"
+			} else {
+				status = "firing"
+			}
+		}
+
+		alerts = append(alerts, schema.Alert{
+			ID:          id,
+			Title:       fmt.Sprintf("%s on %s", signal.title, service),
+			Description: fmt.Sprintf("Synthetic %s alert for %s in %s (load test)", severity, service, region),
+			Status:      status,
+			Severity:    severity,
+			Service:     service,
+			CreatedAt:   createdAt,
+			UpdatedAt:   createdAt.Add(time.Duration(rng.Int63n(int64(age) + 1))),
+			Fields:      fields,
+			Metadata: map[string]any{
+				"source":    source,
+				"generated": true,
+				"generator": map[string]any{"seed": gen.Seed, "index": i},
+			},
+		})
+	}
+	return alerts
+}
+
+func weightedTable(weights map[string]float64) ([]string, []float64) {
+	keys := make([]string, 0, len(weights))
+	for k := range weights {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	cumulative := make([]float64, len(keys))
+	total := 0.0
+	for i, k := range keys {
+		total += weights[k]
+		cumulative[i] = total
+	}
+	for i := range cumulative {
+		cumulative[i] /= total
+	}
+	return keys, cumulative
+}
+
+func pickWeighted(rng *rand.Rand, keys []string, cumulative []float64) string {
+	r := rng.Float64()
+	for i, c := range cumulative {
+		if r < c {
+			return keys[i]
+		}
+	}
+	return keys[len(keys)-1]
+}
+
+func toInt(v any) (int, bool) {
+	switch n := v.(type) {
+	case int:
+		return n, true
+	case int64:
+		return int(n), true
+	case float64:
+		return int(n), true
+	}
+	return 0, false
+}
+
+func toFloat(v any) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	}
+	return 0, false
+}
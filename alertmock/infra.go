@@ -0,0 +1,281 @@
+package alertmock
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/opsorch/opsorch-core/schema"
+)
+
+// Infrastructure entity types an alert can be scoped to instead of a service.
+const (
+	EntityNode          = "node"
+	EntityCluster       = "cluster"
+	EntityLoadBalancer  = "load_balancer"
+	EntityNetworkDevice = "network_device"
+)
+
+// IntegrationSNMP marks alerts raised from SNMP traps sent by network gear.
+const IntegrationSNMP = "snmp"
+
+// WithEntity restricts Query to alerts scoped to an infrastructure entity of
+// the given type, and to one entity when id is set. Service alerts never match.
+func WithEntity(ctx context.Context, entityType, id string) context.Context {
+	return context.WithValue(ctx, entityKey{}, entityScope{entityType: entityType, id: id})
+}
+
+type entityKey struct{}
+
+type entityScope struct {
+	entityType string
+	id         string
+}
+
+func entityFilter(ctx context.Context) (entityScope, bool) {
+	if ctx == nil {
+		return entityScope{}, false
+	}
+	v, ok := ctx.Value(entityKey{}).(entityScope)
+	return v, ok && (v.entityType != "" || v.id != "")
+}
+
+func matchesEntity(scope entityScope, al schema.Alert) bool {
+	entityType, _ := al.Fields["entity_type"].(string)
+	if entityType == "" {
+		return false
+	}
+	if scope.entityType != "" && entityType != scope.entityType {
+		return false
+	}
+	if scope.id != "" {
+		id, _ := al.Fields["entity_id"].(string)
+		return id == scope.id
+	}
+	return true
+}
+
+// isInfraAlert reports whether an alert is scoped to an entity rather than a
+// service.
+func isInfraAlert(al schema.Alert) bool {
+	entityType, _ := al.Fields["entity_type"].(string)
+	return entityType != ""
+}
+
+// infraSeed describes one seeded infrastructure alert.
+type infraSeed struct {
+	id          string
+	title       string
+	description string
+	status      string
+	severity    string
+	age         time.Duration
+	entityType  string
+	entityID    string
+	entityName  string
+	cluster     string
+	region      string
+	integration string
+	fields      map[string]any
+	// affects lists services running on the entity, for context only; the
+	// alert is not scoped to them.
+	affects []string
+}
+
+var infraSeeds = []infraSeed{
+	{
+		id:          "al-infra-001",
+		title:       "Node NotReady",
+		description: "Kubelet on ip-10-12-4-37 stopped posting status; 14 pods are being evicted",
+		status:      "firing",
+		severity:    "critical",
+		age:         12 * time.Minute,
+		entityType:  EntityNode,
+		entityID:    "node/ip-10-12-4-37.ec2.internal",
+		entityName:  "ip-10-12-4-37.ec2.internal",
+		cluster:     "prod-use1",
+		region:      "us-east-1",
+		integration: IntegrationPrometheus,
+		fields:      map[string]any{"metric": "kube_node_status_condition{condition=\"Ready\",status=\"true\"} == 0", "instanceType": "m6i.2xlarge", "podsEvicted": 14},
+		affects:     []string{"svc-checkout", "svc-search"},
+	},
+	{
+		id:          "al-infra-002",
+		title:       "Node disk pressure",
+		description: "Root volume on ip-10-12-7-118 is 91% full; kubelet image garbage collection is failing",
+		status:      "firing",
+		severity:    "warning",
+		age:         48 * time.Minute,
+		entityType:  EntityNode,
+		entityID:    "node/ip-10-12-7-118.ec2.internal",
+		entityName:  "ip-10-12-7-118.ec2.internal",
+		cluster:     "prod-use1",
+		region:      "us-east-1",
+		integration: IntegrationPrometheus,
+		fields:      map[string]any{"metric": "node_filesystem_avail_bytes{mountpoint=\"/\"}", "usedPercent": 91, "mountpoint": "/"},
+		affects:     []string{"svc-logging"},
+	},
+	{
+		id:          "al-infra-003",
+		title:       "Cluster etcd commit latency high",
+		description: "etcd p99 backend commit latency is 310ms on prod-euw1; API server writes are slowing down",
+		status:      "firing",
+		severity:    "error",
+		age:         26 * time.Minute,
+		entityType:  EntityCluster,
+		entityID:    "cluster/prod-euw1",
+		entityName:  "prod-euw1",
+		cluster:     "prod-euw1",
+		region:      "eu-west-1",
+		integration: IntegrationPrometheus,
+		fields:      map[string]any{"metric": "histogram_quantile(0.99, etcd_disk_backend_commit_duration_seconds_bucket)", "p99Ms": 310, "controlPlane": "eks"},
+	},
+	{
+		id:          "al-infra-004",
+		title:       "Load balancer unhealthy targets",
+		description: "2 of 6 targets behind public-web-alb are failing health checks",
+		status:      "acknowledged",
+		severity:    "warning",
+		age:         35 * time.Minute,
+		entityType:  EntityLoadBalancer,
+		entityID:    "lb/public-web-alb",
+		entityName:  "public-web-alb",
+		region:      "us-east-1",
+		integration: IntegrationCloudWatch,
+		fields:      map[string]any{"metric": "UnHealthyHostCount", "unhealthyTargets": 2, "totalTargets": 6, "targetGroup": "tg-web-443", "acknowledgedBy": "infra-oncall@demo.com", "notes": "Draining the two instances; replacements are launching"},
+		affects:     []string{"svc-web"},
+	},
+	{
+		id:          "al-infra-005",
+		title:       "Interface down on core switch",
+		description: "SNMP linkDown trap: TenGigabitEthernet1/0/24 on core-sw-use1-a went down (uplink to rack B7)",
+		status:      "firing",
+		severity:    "warning",
+		age:         9 * time.Minute,
+		entityType:  EntityNetworkDevice,
+		entityID:    "netdev/core-sw-use1-a",
+		entityName:  "core-sw-use1-a",
+		region:      "us-east-1",
+		integration: IntegrationSNMP,
+		fields:      map[string]any{"interface": "TenGigabitEthernet1/0/24", "ifIndex": 24, "trapOID": "1.3.6.1.6.3.1.1.5.3", "trapName": "linkDown"},
+	},
+	{
+		id:          "al-infra-006",
+		title:       "BGP peer session flapped",
+		description: "SNMP bgpBackwardTransition trap from edge-rtr-euw1-b; peer 169.254.12.1 re-established after 40s",
+		status:      "resolved",
+		severity:    "info",
+		age:         3 * time.Hour,
+		entityType:  EntityNetworkDevice,
+		entityID:    "netdev/edge-rtr-euw1-b",
+		entityName:  "edge-rtr-euw1-b",
+		region:      "eu-west-1",
+		integration: IntegrationSNMP,
+		fields:      map[string]any{"peer": "169.254.12.1", "trapOID": "1.3.6.1.2.1.15.7.2", "trapName": "bgpBackwardTransition", "downSeconds": 40},
+	},
+}
+
+// infraAlerts builds the seeded infrastructure alerts. They carry no Service;
+// Fields["entity_type"] and Fields["entity_id"] identify what they are about.
+func infraAlerts(source string, now time.Time) []schema.Alert {
+	out := make([]schema.Alert, 0, len(infraSeeds))
+	for _, seed := range infraSeeds {
+		createdAt := now.Add(-seed.age)
+		updatedAt := createdAt
+		if seed.status != "firing" {
+			updatedAt = createdAt.Add(seed.age / 2)
+		}
+		fields := map[string]any{
+			"entity_type": seed.entityType,
+			"entity_id":   seed.entityID,
+			"entity_name": seed.entityName,
+			"environment": "prod",
+			"team":        "team-infra",
+			"region":      seed.region,
+		}
+		if seed.cluster != "" {
+			fields["cluster"] = seed.cluster
+		}
+		if len(seed.affects) > 0 {
+			fields["affected_services"] = append([]string(nil), seed.affects...)
+		}
+		for k, v := range seed.fields {
+			fields[k] = v
+		}
+		al := schema.Alert{
+			ID:          seed.id,
+			Title:       fmt.Sprintf("%s: %s", seed.title, seed.entityName),
+			Description: seed.description,
+			Status:      seed.status,
+			Severity:    seed.severity,
+			CreatedAt:   createdAt,
+			UpdatedAt:   updatedAt,
+			Fields:      fields,
+			Metadata: map[string]any{
+				"source":      source,
+				"scope":       "infrastructure",
+				"integration": seed.integration,
+				"runbook":     fmt.Sprintf("https://runbooks.demo.com/infra/%s", strings.ReplaceAll(seed.entityType, "_", "-")),
+				"dashboard":   fmt.Sprintf("https://grafana.demo.com/d/infra-%s?var-entity=%s", strings.ReplaceAll(seed.entityType, "_", "-"), seed.entityName),
+				"channel":     "#infra-oncall",
+			},
+		}
+		al.Fields[seed.integration] = infraIntegrationPayload(seed, al)
+		out = append(out, al)
+	}
+	return out
+}
+
+// infraIntegrationPayload mirrors what the raising tool would send for an
+// entity rather than a service.
+func infraIntegrationPayload(seed infraSeed, al schema.Alert) map[string]any {
+	switch seed.integration {
+	case IntegrationCloudWatch:
+		alarmName := fmt.Sprintf("%s-%s", seed.entityName, alertName(al))
+		state := "ALARM"
+		if al.Status == "resolved" {
+			state = "OK"
+		}
+		return map[string]any{
+			"AlarmName":    alarmName,
+			"AlarmArn":     fmt.Sprintf("arn:aws:cloudwatch:%s:%s:alarm:%s", seed.region, awsAccountID, alarmName),
+			"Namespace":    "AWS/ApplicationELB",
+			"MetricName":   stringField(al, "metric", alertName(al)),
+			"Dimensions":   []map[string]any{{"name": "LoadBalancer", "value": "app/" + seed.entityName}},
+			"StateValue":   state,
+			"StateReason":  al.Description,
+			"Region":       seed.region,
+			"AWSAccountId": awsAccountID,
+		}
+	case IntegrationSNMP:
+		return map[string]any{
+			"agentAddress": fmt.Sprintf("10.255.%d.%d", stableHash(seed.entityName)%250, 1+stableHash(seed.id)%250),
+			"sysName":      seed.entityName,
+			"community":    "monitoring",
+			"version":      "v2c",
+			"trapOID":      seed.fields["trapOID"],
+			"receivedAt":   al.CreatedAt.Format(time.RFC3339),
+		}
+	default:
+		labels := map[string]any{
+			"alertname": alertName(al),
+			"severity":  al.Severity,
+			"env":       "prod",
+			"cluster":   seed.cluster,
+		}
+		if seed.entityType == EntityNode {
+			labels["node"] = seed.entityName
+			labels["instance"] = seed.entityName + ":9100"
+		}
+		return map[string]any{
+			"labels": labels,
+			"annotations": map[string]any{
+				"summary":     al.Title,
+				"description": al.Description,
+			},
+			"startsAt":     al.CreatedAt.Format(time.RFC3339),
+			"generatorURL": "https://prometheus.demo.com/graph?g0.expr=" + stringField(al, "metric", alertName(al)),
+		}
+	}
+}
@@ -0,0 +1,438 @@
+package alertmock
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/opsorch/opsorch-core/orcherr"
+	"github.com/opsorch/opsorch-core/schema"
+	"github.com/opsorch/opsorch-mock-adapters/internal/mockutil"
+)
+
+// Paths served by IngestHandler.
+const (
+	IngestAlertmanagerPath = "/ingest/alertmanager"
+	IngestDatadogPath      = "/ingest/datadog"
+)
+
+// maxIngestBody bounds a single webhook delivery.
+const maxIngestBody = 1 << 20
+
+// AlertmanagerWebhook is the Prometheus Alertmanager webhook body (version 4).
+type AlertmanagerWebhook struct {
+	Version           string              `json:"version"`
+	GroupKey          string              `json:"groupKey"`
+	Status            string              `json:"status"`
+	Receiver          string              `json:"receiver"`
+	GroupLabels       map[string]string   `json:"groupLabels"`
+	CommonLabels      map[string]string   `json:"commonLabels"`
+	CommonAnnotations map[string]string   `json:"commonAnnotations"`
+	ExternalURL       string              `json:"externalURL"`
+	Alerts            []AlertmanagerAlert `json:"alerts"`
+}
+
+// AlertmanagerAlert is one alert in an Alertmanager notification.
+type AlertmanagerAlert struct {
+	Status       string            `json:"status"`
+	Labels       map[string]string `json:"labels"`
+	Annotations  map[string]string `json:"annotations"`
+	StartsAt     time.Time         `json:"startsAt"`
+	EndsAt       time.Time         `json:"endsAt"`
+	GeneratorURL string            `json:"generatorURL"`
+	Fingerprint  string            `json:"fingerprint"`
+}
+
+// DatadogWebhook is the body of a Datadog webhook integration using the
+// standard template variables ($ID, $ALERT_ID, $EVENT_TITLE, $TAGS, ...).
+// Datadog renders every variable as a string, so numeric ones are accepted in
+// either form and tags as a comma-separated string or a list.
+type DatadogWebhook struct {
+	ID         flexString  `json:"id"`
+	AlertID    flexString  `json:"alert_id"`
+	Title      string      `json:"title"`
+	Body       string      `json:"body"`
+	Transition string      `json:"alert_transition"`
+	Priority   string      `json:"alert_priority"`
+	AlertType  string      `json:"alert_type"`
+	Tags       datadogTags `json:"tags"`
+	Date       flexString  `json:"date"`
+	Link       string      `json:"link"`
+	Hostname   string      `json:"hostname"`
+}
+
+// IngestHandler serves the webhook receiver endpoints, converting external
+// monitor notifications into mock alerts so real monitors can feed a demo.
+// Set "ingestAddr" in the config to serve it from New.
+func (p *Provider) IngestHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(IngestAlertmanagerPath, func(w http.ResponseWriter, r *http.Request) {
+		var payload AlertmanagerWebhook
+		if !decodeIngest(w, r, &payload) {
+			return
+		}
+		alerts, err := p.IngestAlertmanager(r.Context(), payload)
+		writeIngestResult(w, alerts, err)
+	})
+	mux.HandleFunc(IngestDatadogPath, func(w http.ResponseWriter, r *http.Request) {
+		var payload DatadogWebhook
+		if !decodeIngest(w, r, &payload) {
+			return
+		}
+		al, err := p.IngestDatadog(r.Context(), payload)
+		writeIngestResult(w, []schema.Alert{al}, err)
+	})
+	return mux
+}
+
+// serveIngest starts the webhook receiver on addr in the background.
+func (p *Provider) serveIngest(addr string) error {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("alertmock: listen on ingestAddr %s: %w", addr, err)
+	}
+	srv := &http.Server{Handler: p.IngestHandler(), ReadHeaderTimeout: 10 * time.Second}
+	go srv.Serve(ln)
+	return nil
+}
+
+// IngestAlertmanager upserts one alert per entry in an Alertmanager
+// notification. Alerts are keyed by fingerprint, so a later "resolved"
+// notification resolves the alert the "firing" one created.
+func (p *Provider) IngestAlertmanager(ctx context.Context, payload AlertmanagerWebhook) ([]schema.Alert, error) {
+	if len(payload.Alerts) == 0 {
+		return nil, orcherr.New("bad_request", "alertmanager payload has no alerts", nil)
+	}
+	for i, am := range payload.Alerts {
+		if am.Labels["alertname"] == "" && payload.CommonLabels["alertname"] == "" {
+			return nil, orcherr.New("bad_request", fmt.Sprintf("alertmanager alert %d is missing the alertname label", i), nil)
+		}
+	}
+	now := time.Now().UTC()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	out := make([]schema.Alert, 0, len(payload.Alerts))
+	for _, am := range payload.Alerts {
+		labels := mergeStrings(payload.CommonLabels, am.Labels)
+		annotations := mergeStrings(payload.CommonAnnotations, am.Annotations)
+		name := labels["alertname"]
+		fingerprint := am.Fingerprint
+		if fingerprint == "" {
+			fingerprint = labelFingerprint(labels)
+		}
+
+		service := ingestService(firstNonEmpty(labels["service"], labels["job"], labels["app"]))
+		title := firstNonEmpty(annotations["summary"], annotations["title"], name)
+		status := "firing"
+		if firstNonEmpty(am.Status, payload.Status) == "resolved" {
+			status = "resolved"
+		}
+		createdAt := am.StartsAt.UTC()
+		if createdAt.IsZero() {
+			createdAt = now
+		}
+
+		fields := map[string]any{
+			"alertname":   name,
+			"environment": firstNonEmpty(labels["env"], labels["environment"], "prod"),
+			"team":        firstNonEmpty(labels["team"], mockutil.GetTeamForService(service)),
+			"ingested":    true,
+		}
+		if region := labels["region"]; region != "" {
+			fields["region"] = region
+		}
+		raw := map[string]any{
+			"labels":       stringsToAny(labels),
+			"annotations":  stringsToAny(annotations),
+			"startsAt":     createdAt.Format(time.RFC3339),
+			"fingerprint":  fingerprint,
+			"generatorURL": am.GeneratorURL,
+			"receiver":     payload.Receiver,
+			"groupKey":     payload.GroupKey,
+		}
+		if !am.EndsAt.IsZero() {
+			raw["endsAt"] = am.EndsAt.UTC().Format(time.RFC3339)
+		}
+		fields[IntegrationPrometheus] = raw
+
+		al := p.upsertIngestedLocked(schema.Alert{
+			ID:          "al-am-" + fingerprint,
+			Title:       title,
+			Description: firstNonEmpty(annotations["description"], annotations["message"], title),
+			Status:      status,
+			Severity:    ingestSeverity(labels["severity"]),
+			Service:     service,
+			URL:         am.GeneratorURL,
+			CreatedAt:   createdAt,
+			Fields:      fields,
+			Metadata: map[string]any{
+				"runbook":     annotations["runbook_url"],
+				"externalURL": payload.ExternalURL,
+			},
+		}, IntegrationPrometheus, now)
+		if status == "resolved" && !am.EndsAt.IsZero() {
+			al.Metadata["resolvedAt"] = am.EndsAt.UTC().Format(time.RFC3339)
+			p.alerts[al.ID] = al
+		}
+		out = append(out, cloneAlert(al))
+	}
+	p.publishLocked()
+	return out, nil
+}
+
+// IngestDatadog upserts the alert for a Datadog monitor notification. Alerts
+// are keyed by monitor, so a "Recovered" transition resolves the alert the
+// "Triggered" one created.
+func (p *Provider) IngestDatadog(ctx context.Context, payload DatadogWebhook) (schema.Alert, error) {
+	monitorID := firstNonEmpty(string(payload.AlertID), string(payload.ID))
+	if monitorID == "" || payload.Title == "" {
+		return schema.Alert{}, orcherr.New("bad_request", "datadog payload requires alert_id and title", nil)
+	}
+	now := time.Now().UTC()
+
+	tags := map[string]string{}
+	for _, tag := range payload.Tags {
+		key, value, _ := strings.Cut(tag, ":")
+		if _, seen := tags[key]; !seen {
+			tags[key] = value
+		}
+	}
+	service := ingestService(tags["service"])
+
+	status := "firing"
+	switch strings.ToLower(payload.Transition) {
+	case "recovered":
+		status = "resolved"
+	case "no data":
+		status = "mitigating"
+	}
+	severity := ingestSeverity(payload.AlertType)
+	if strings.ToLower(payload.Transition) == "warn" {
+		severity = "warning"
+	}
+	createdAt := now
+	if ms, err := strconv.ParseInt(string(payload.Date), 10, 64); err == nil && ms > 0 {
+		createdAt = time.UnixMilli(ms).UTC()
+	}
+
+	fields := map[string]any{
+		"alertname":   alertNameFromTitle(payload.Title),
+		"environment": firstNonEmpty(tags["env"], "prod"),
+		"team":        firstNonEmpty(tags["team"], mockutil.GetTeamForService(service)),
+		"ingested":    true,
+	}
+	if payload.Hostname != "" {
+		fields["host"] = payload.Hostname
+	}
+	fields[IntegrationDatadog] = map[string]any{
+		"monitor_id":       monitorID,
+		"event_id":         string(payload.ID),
+		"monitor_name":     payload.Title,
+		"priority":         payload.Priority,
+		"alert_transition": payload.Transition,
+		"alert_type":       payload.AlertType,
+		"tags":             []string(payload.Tags),
+		"link":             payload.Link,
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	al := p.upsertIngestedLocked(schema.Alert{
+		ID:          "al-dd-" + monitorID,
+		Title:       payload.Title,
+		Description: firstNonEmpty(payload.Body, payload.Title),
+		Status:      status,
+		Severity:    severity,
+		Service:     service,
+		URL:         payload.Link,
+		CreatedAt:   createdAt,
+		Fields:      fields,
+		Metadata:    map[string]any{},
+	}, IntegrationDatadog, now)
+	if status == "resolved" {
+		al.Metadata["resolvedAt"] = now.Format(time.RFC3339)
+		p.alerts[al.ID] = al
+	}
+	p.publishLocked()
+	return cloneAlert(al), nil
+}
+
+// upsertIngestedLocked stores an ingested alert, keeping the creation time and
+// ingest count of an earlier delivery for the same ID. Callers must hold p.mu.
+func (p *Provider) upsertIngestedLocked(al schema.Alert, integration string, now time.Time) schema.Alert {
+	count := 1
+	if prev, ok := p.alerts[al.ID]; ok {
+		al.CreatedAt = prev.CreatedAt
+		if n, ok := toInt(prev.Metadata["ingestCount"]); ok {
+			count = n + 1
+		}
+	}
+	for k, v := range al.Metadata {
+		if s, ok := v.(string); ok && s == "" {
+			delete(al.Metadata, k)
+		}
+	}
+	al.Metadata["source"] = p.cfg.Source
+	al.Metadata["integration"] = integration
+	al.Metadata["ingestedAt"] = now.Format(time.RFC3339)
+	al.Metadata["ingestCount"] = count
+	al.UpdatedAt = now
+	linkRunbookPlan(&al)
+	p.alerts[al.ID] = al
+	return al
+}
+
+// ingestSeverity maps external severity labels onto the mock's severities.
+func ingestSeverity(raw string) string {
+	switch strings.ToLower(strings.TrimSpace(raw)) {
+	case "critical", "page", "p1", "fatal":
+		return "critical"
+	case "error", "high", "p2":
+		return "error"
+	case "info", "informational", "success", "low", "none":
+		return "info"
+	default:
+		return "warning"
+	}
+}
+
+// ingestService maps an external service name onto the shared "svc-" IDs so
+// ingested alerts correlate with the seeded topology.
+func ingestService(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return ""
+	}
+	if !strings.HasPrefix(name, "svc-") {
+		name = "svc-" + name
+	}
+	return name
+}
+
+// alertNameFromTitle strips Datadog's "[Triggered on {...}]" style prefix.
+func alertNameFromTitle(title string) string {
+	if strings.HasPrefix(title, "[") {
+		if i := strings.Index(title, "]"); i >= 0 {
+			title = strings.TrimSpace(title[i+1:])
+		}
+	}
+	return title
+}
+
+// labelFingerprint stands in for Alertmanager's fingerprint when a sender
+// omits it: a stable hash of the sorted label set.
+func labelFingerprint(labels map[string]string) string {
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	h := fnv.New64a()
+	for _, k := range keys {
+		fmt.Fprintf(h, "%s=%s;", k, labels[k])
+	}
+	return fmt.Sprintf("%016x", h.Sum64())
+}
+
+func mergeStrings(base, over map[string]string) map[string]string {
+	out := make(map[string]string, len(base)+len(over))
+	for k, v := range base {
+		out[k] = v
+	}
+	for k, v := range over {
+		out[k] = v
+	}
+	return out
+}
+
+func stringsToAny(in map[string]string) map[string]any {
+	out := make(map[string]any, len(in))
+	for k, v := range in {
+		out[k] = v
+	}
+	return out
+}
+
+func firstNonEmpty(vals ...string) string {
+	for _, v := range vals {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+func decodeIngest(w http.ResponseWriter, r *http.Request, into any) bool {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return false
+	}
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxIngestBody)).Decode(into); err != nil {
+		http.Error(w, "invalid webhook payload: "+err.Error(), http.StatusBadRequest)
+		return false
+	}
+	return true
+}
+
+func writeIngestResult(w http.ResponseWriter, alerts []schema.Alert, err error) {
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	ids := make([]string, 0, len(alerts))
+	for _, al := range alerts {
+		ids = append(ids, al.ID)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]any{"ingested": len(ids), "alertIds": ids})
+}
+
+// flexString accepts a JSON string or number.
+type flexString string
+
+func (s *flexString) UnmarshalJSON(data []byte) error {
+	var str string
+	if err := json.Unmarshal(data, &str); err == nil {
+		*s = flexString(str)
+		return nil
+	}
+	var num json.Number
+	if err := json.Unmarshal(data, &num); err != nil {
+		return err
+	}
+	*s = flexString(num.String())
+	return nil
+}
+
+// datadogTags accepts "$TAGS" rendered as "a:b,c:d" or as a JSON list.
+type datadogTags []string
+
+func (t *datadogTags) UnmarshalJSON(data []byte) error {
+	var list []string
+	if err := json.Unmarshal(data, &list); err == nil {
+		*t = list
+		return nil
+	}
+	var joined string
+	if err := json.Unmarshal(data, &joined); err != nil {
+		return err
+	}
+	var out []string
+	for _, tag := range strings.Split(joined, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			out = append(out, tag)
+		}
+	}
+	*t = out
+	return nil
+}
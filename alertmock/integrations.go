@@ -0,0 +1,290 @@
+package alertmock
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"strings"
+	"time"
+
+	"github.com/opsorch/opsorch-core/schema"
+	"github.com/opsorch/opsorch-mock-adapters/internal/mockutil"
+)
+
+// Simulated monitoring integrations an alert can originate from.
+const (
+	IntegrationPrometheus = "prometheus"
+	IntegrationDatadog    = "datadog"
+	IntegrationCloudWatch = "cloudwatch"
+	IntegrationSynthetic  = "synthetic"
+)
+
+var integrations = []string{IntegrationPrometheus, IntegrationDatadog, IntegrationCloudWatch, IntegrationSynthetic}
+
+// awsAccountID matches the account cloudresourcemock uses for ARNs.
+const awsAccountID = "123456789012"
+
+// seededIntegrations pins each seeded alert to the tool that would realistically
+// raise it. Alerts not listed here (load and generated alerts) are spread
+// deterministically by ID.
+var seededIntegrations = map[string]string{
+	"al-001":              IntegrationPrometheus,
+	"al-002":              IntegrationCloudWatch,
+	"al-003":              IntegrationSynthetic,
+	"al-004":              IntegrationPrometheus,
+	"al-005":              IntegrationDatadog,
+	"al-006":              IntegrationDatadog,
+	"al-007":              IntegrationPrometheus,
+	"al-008":              IntegrationCloudWatch,
+	"al-009":              IntegrationSynthetic,
+	"al-010":              IntegrationCloudWatch,
+	"al-011":              IntegrationPrometheus,
+	"al-012":              IntegrationDatadog,
+	"al-013":              IntegrationSynthetic,
+	"al-014":              IntegrationDatadog,
+	"al-015":              IntegrationDatadog,
+	"al-016":              IntegrationPrometheus,
+	"al-017":              IntegrationCloudWatch,
+	"al-018":              IntegrationCloudWatch,
+	"al-019":              IntegrationPrometheus,
+	"al-020":              IntegrationDatadog,
+	"al-021":              IntegrationPrometheus,
+	"al-022":              IntegrationPrometheus,
+	"al-023":              IntegrationDatadog,
+	"al-024":              IntegrationDatadog,
+	"al-025":              IntegrationDatadog,
+	"al-026":              IntegrationDatadog,
+	"al-027":              IntegrationCloudWatch,
+	"al-028":              IntegrationDatadog,
+	"al-029":              IntegrationDatadog,
+	"al-030":              IntegrationPrometheus,
+	"al-031":              IntegrationPrometheus,
+	"al-032":              IntegrationPrometheus,
+	"al-033":              IntegrationPrometheus,
+	"al-scenario-001":     IntegrationPrometheus,
+	"al-scenario-002":     IntegrationPrometheus,
+	"al-scenario-003":     IntegrationDatadog,
+	"al-scenario-004":     IntegrationSynthetic,
+	"al-scenario-005":     IntegrationDatadog,
+	"al-scenario-006":     IntegrationPrometheus,
+	"alert-analytics-001": IntegrationPrometheus,
+	"alert-payment-001":   IntegrationDatadog,
+}
+
+// cloudWatchNamespaces maps services to the AWS namespace their alarms live in.
+var cloudWatchNamespaces = map[string]string{
+	"svc-database":     "AWS/RDS",
+	"svc-api-gateway":  "AWS/ApiGateway",
+	"svc-dns":          "AWS/Route53",
+	"svc-logging":      "AWS/EBS",
+	"svc-loadbalancer": "AWS/ApplicationELB",
+}
+
+// WithIntegrations restricts Query to alerts raised by the given integrations.
+func WithIntegrations(ctx context.Context, names ...string) context.Context {
+	return context.WithValue(ctx, integrationsKey{}, toSet(names))
+}
+
+type integrationsKey struct{}
+
+func integrationFilter(ctx context.Context) map[string]bool {
+	if ctx == nil {
+		return nil
+	}
+	v, _ := ctx.Value(integrationsKey{}).(map[string]bool)
+	return v
+}
+
+// matchesIntegration reads Metadata["integration"]; alerts published by other
+// sources (e.g. budget alerts) carry none and never match a filter.
+func matchesIntegration(filter map[string]bool, al schema.Alert) bool {
+	if len(filter) == 0 {
+		return true
+	}
+	name, _ := al.Metadata["integration"].(string)
+	return filter[name]
+}
+
+// integrationFor returns the integration that raised an alert.
+func integrationFor(id string) string {
+	if name, ok := seededIntegrations[id]; ok {
+		return name
+	}
+	return integrations[stableHash(id)%uint32(len(integrations))]
+}
+
+// applyIntegration tags an alert with its integration and attaches the payload
+// that integration would have sent under Fields[<integration>].
+func applyIntegration(al *schema.Alert) {
+	if al.Fields == nil {
+		al.Fields = map[string]any{}
+	}
+	if al.Metadata == nil {
+		al.Metadata = map[string]any{}
+	}
+	name := integrationFor(al.ID)
+	al.Metadata["integration"] = name
+
+	switch name {
+	case IntegrationPrometheus:
+		al.Fields[name] = prometheusPayload(*al)
+	case IntegrationDatadog:
+		al.Fields[name] = datadogPayload(*al)
+	case IntegrationCloudWatch:
+		al.Fields[name] = cloudWatchPayload(*al)
+	case IntegrationSynthetic:
+		al.Fields[name] = syntheticPayload(*al)
+	}
+}
+
+func prometheusPayload(al schema.Alert) map[string]any {
+	labels := map[string]any{
+		"alertname": alertName(al),
+		"service":   al.Service,
+		"severity":  al.Severity,
+		"env":       stringField(al, "environment", "prod"),
+	}
+	if region := stringField(al, "region", ""); region != "" {
+		labels["region"] = region
+	}
+	expr := stringField(al, "metric", "")
+	if expr == "" {
+		expr = fmt.Sprintf("%s{service=%q}", alertName(al), al.Service)
+	}
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(al.ID))
+	return map[string]any{
+		"labels": labels,
+		"annotations": map[string]any{
+			"summary":     al.Title,
+			"description": al.Description,
+		},
+		"startsAt":     al.CreatedAt.Format(time.RFC3339),
+		"fingerprint":  fmt.Sprintf("%016x", h.Sum64()),
+		"generatorURL": fmt.Sprintf("https://prometheus.demo.com/graph?g0.expr=%s", expr),
+	}
+}
+
+func datadogPayload(al schema.Alert) map[string]any {
+	monitorID := 4100000 + int(stableHash(al.ID)%100000)
+	title := strings.ToLower(al.Title)
+	monitorType := "metric alert"
+	switch {
+	case strings.Contains(title, "anomaly") || strings.Contains(title, "unusual"):
+		monitorType = "query alert"
+	case strings.Contains(title, "deployment") || strings.Contains(title, "rollback"):
+		monitorType = "event-v2 alert"
+	}
+	transition := "Triggered"
+	if al.Status == "resolved" {
+		transition = "Recovered"
+	}
+	return map[string]any{
+		"monitor_id":       monitorID,
+		"monitor_name":     al.Title,
+		"monitor_type":     monitorType,
+		"query":            fmt.Sprintf("avg(last_5m):avg:%s{service:%s} > threshold", alertName(al), strings.TrimPrefix(al.Service, "svc-")),
+		"priority":         datadogPriority(al.Severity),
+		"alert_transition": transition,
+		"tags": []string{
+			"service:" + al.Service,
+			"env:" + stringField(al, "environment", "prod"),
+			"team:" + stringField(al, "team", mockutil.GetTeamForService(al.Service)),
+		},
+		"link": fmt.Sprintf("https://app.datadoghq.com/monitors/%d", monitorID),
+	}
+}
+
+func cloudWatchPayload(al schema.Alert) map[string]any {
+	namespace, ok := cloudWatchNamespaces[al.Service]
+	if !ok {
+		namespace = "AWS/EC2"
+	}
+	region := stringField(al, "region", "")
+	if !strings.Contains(region, "-") || region == "global" {
+		region = mockutil.ServiceRegion(al.Service)
+	}
+	alarmName := fmt.Sprintf("%s-%s", strings.TrimPrefix(al.Service, "svc-"), alertName(al))
+	state := "ALARM"
+	if al.Status == "resolved" {
+		state = "OK"
+	}
+	return map[string]any{
+		"AlarmName":    alarmName,
+		"AlarmArn":     fmt.Sprintf("arn:aws:cloudwatch:%s:%s:alarm:%s", region, awsAccountID, alarmName),
+		"Namespace":    namespace,
+		"MetricName":   alertName(al),
+		"Dimensions":   []map[string]any{{"name": "Service", "value": al.Service}},
+		"StateValue":   state,
+		"StateReason":  al.Description,
+		"Region":       region,
+		"AWSAccountId": awsAccountID,
+	}
+}
+
+func syntheticPayload(al schema.Alert) map[string]any {
+	checkType := "api"
+	if al.Service == "svc-web" {
+		checkType = "browser"
+	}
+	if strings.Contains(strings.ToLower(al.Title), "certificate") {
+		checkType = "ssl"
+	}
+	locations := []string{"us-east-1", "eu-west-1", "ap-southeast-1"}
+	failed := locations
+	if al.Status == "resolved" {
+		failed = []string{}
+	}
+	return map[string]any{
+		"checkId":         fmt.Sprintf("chk-%s", strings.TrimPrefix(al.ID, "al-")),
+		"checkType":       checkType,
+		"target":          fmt.Sprintf("https://%s.demo.com/health", strings.TrimPrefix(al.Service, "svc-")),
+		"locations":       locations,
+		"failedLocations": failed,
+		"lastRunAt":       al.UpdatedAt.Format(time.RFC3339),
+		"assertion":       al.Title,
+	}
+}
+
+// alertName derives a snake_case rule name when the seed does not carry one.
+func alertName(al schema.Alert) string {
+	if name := stringField(al, "alert_name", ""); name != "" {
+		return name
+	}
+	var b strings.Builder
+	for _, r := range strings.ToLower(al.Title) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			b.WriteRune(r)
+		case b.Len() > 0 && !strings.HasSuffix(b.String(), "_"):
+			b.WriteByte('_')
+		}
+	}
+	return strings.TrimSuffix(b.String(), "_")
+}
+
+func datadogPriority(severity string) string {
+	switch severity {
+	case "critical", "sev1":
+		return "P1"
+	case "error", "sev2":
+		return "P2"
+	case "warning", "sev3":
+		return "P3"
+	default:
+		return "P4"
+	}
+}
+
+func stringField(al schema.Alert, key, fallback string) string {
+	if v, ok := al.Fields[key].(string); ok && v != "" {
+		return v
+	}
+	return fallback
+}
+
+func stableHash(s string) uint32 {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(s))
+	return h.Sum32()
+}
@@ -0,0 +1,168 @@
+package alertmock
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/opsorch/opsorch-core/schema"
+	"github.com/opsorch/opsorch-mock-adapters/internal/mockutil"
+)
+
+// NoiseConfig enables a trickle of low-value info/warning alerts so triage
+// features have realistic noise to filter. It is read from the "noise" config
+// map and is off unless Enabled is set.
+type NoiseConfig struct {
+	Enabled  bool
+	Interval time.Duration
+	Lookback time.Duration
+	Services []string
+}
+
+const (
+	defaultNoiseInterval = 15 * time.Minute
+	defaultNoiseLookback = 6 * time.Hour
+)
+
+var defaultNoiseServices = []string{
+	"svc-logging", "svc-cache", "svc-search", "svc-notifications", "svc-identity",
+	"svc-catalog", "svc-shipping", "svc-analytics", "svc-web", "svc-api-gateway",
+}
+
+// noiseSignals are the low-value conditions every fleet fires constantly.
+var noiseSignals = []struct {
+	title    string
+	severity string
+	metric   string
+	fields   map[string]any
+}{
+	{"Disk usage at 70%", "warning", "node_filesystem_avail_bytes", map[string]any{"usedPercent": 70, "threshold": "70%"}},
+	{"TLS certificate expires in 60 days", "info", "probe_ssl_earliest_cert_expiry", map[string]any{"daysUntilExpiry": 60}},
+	{"Memory usage above 65%", "info", "container_memory_working_set_bytes", map[string]any{"usedPercent": 65, "threshold": "65%"}},
+	{"Inode usage at 72%", "warning", "node_filesystem_files_free", map[string]any{"usedPercent": 72, "threshold": "70%"}},
+	{"Clock drift 120ms from NTP", "info", "node_timex_offset_seconds", map[string]any{"driftMs": 120}},
+	{"Deprecated API version still receiving traffic", "info", "http_requests_total:deprecated", map[string]any{"requestsPerMinute": 14}},
+	{"Nightly backup took 20% longer than usual", "warning", "backup_duration_seconds", map[string]any{"slowdownPercent": 20}},
+	{"Log volume 30% above baseline", "info", "log_lines_total:rate1h", map[string]any{"increasePercent": 30}},
+}
+
+func parseNoiseConfig(raw any) NoiseConfig {
+	out := NoiseConfig{Interval: defaultNoiseInterval, Lookback: defaultNoiseLookback}
+	cfg, ok := raw.(map[string]any)
+	if !ok {
+		return out
+	}
+	if v, ok := cfg["enabled"].(bool); ok {
+		out.Enabled = v
+	}
+	if d, ok := parseDuration(cfg["interval"]); ok {
+		out.Interval = d
+	}
+	if d, ok := parseDuration(cfg["lookback"]); ok {
+		out.Lookback = d
+	}
+	switch services := cfg["services"].(type) {
+	case []string:
+		out.Services = append(out.Services, services...)
+	case []any:
+		for _, s := range services {
+			if str, ok := s.(string); ok && str != "" {
+				out.Services = append(out.Services, str)
+			}
+		}
+	}
+	return out
+}
+
+func parseDuration(v any) (time.Duration, bool) {
+	s, ok := v.(string)
+	if !ok {
+		return 0, false
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d <= 0 {
+		return 0, false
+	}
+	return d, true
+}
+
+// noiseAlerts returns one alert per Interval over the Lookback window ending at
+// now. Alerts are keyed by their time slot, so the same slot always yields the
+// same alert and new ones appear as time passes.
+func noiseAlerts(cfg NoiseConfig, source string, now time.Time) []schema.Alert {
+	if !cfg.Enabled || cfg.Interval <= 0 {
+		return nil
+	}
+	services := cfg.Services
+	if len(services) == 0 {
+		services = defaultNoiseServices
+	}
+
+	last := now.UnixNano() / int64(cfg.Interval)
+	first := now.Add(-cfg.Lookback).UnixNano()/int64(cfg.Interval) + 1
+	alerts := make([]schema.Alert, 0, last-first+1)
+	for slot := first; slot <= last; slot++ {
+		id := fmt.Sprintf("al-noise-%d", slot)
+		h := stableHash(id)
+		signal := noiseSignals[h%uint32(len(noiseSignals))]
+		service := services[(h/7)%uint32(len(services))]
+		createdAt := time.Unix(0, slot*int64(cfg.Interval)).UTC()
+
+		// Most noise lingers; about a quarter clears itself after one interval.
+		status := "firing"
+		updatedAt := createdAt
+		if h%4 == 0 && now.Sub(createdAt) >= cfg.Interval {
+			status = "resolved"
+			updatedAt = createdAt.Add(cfg.Interval)
+		}
+
+		fields := map[string]any{
+			"environment": "prod",
+			"team":        mockutil.GetTeamForService(service),
+			"region":      mockutil.ServiceRegion(service),
+			"metric":      signal.metric,
+			"noise":       true,
+		}
+		for k, v := range signal.fields {
+			fields[k] = v
+		}
+		alerts = append(alerts, schema.Alert{
+			ID:          id,
+			Title:       fmt.Sprintf("%s on %s", signal.title, service),
+			Description: fmt.Sprintf("Low-priority %s signal for %s; no customer impact expected", signal.severity, service),
+			Status:      status,
+			Severity:    signal.severity,
+			Service:     service,
+			CreatedAt:   createdAt,
+			UpdatedAt:   updatedAt,
+			Fields:      fields,
+			Metadata: map[string]any{
+				"source": source,
+				"noise":  true,
+			},
+		})
+	}
+	return alerts
+}
+
+// refreshNoiseLocked adds noise alerts for slots that have opened since the
+// last call and drops those that aged out of the lookback window.
+func (p *Provider) refreshNoiseLocked(now time.Time) {
+	if !p.cfg.Noise.Enabled {
+		return
+	}
+	current := map[string]bool{}
+	for _, al := range noiseAlerts(p.cfg.Noise, p.cfg.Source, now) {
+		current[al.ID] = true
+		if existing, ok := p.alerts[al.ID]; ok && existing.Status == al.Status {
+			continue
+		}
+		applyIntegration(&al)
+		p.renameServices(&al)
+		p.alerts[al.ID] = al
+	}
+	for id, al := range p.alerts {
+		if noise, _ := al.Metadata["noise"].(bool); noise && !current[id] {
+			delete(p.alerts, id)
+		}
+	}
+}
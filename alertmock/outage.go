@@ -0,0 +1,202 @@
+package alertmock
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/opsorch/opsorch-core/orcherr"
+	"github.com/opsorch/opsorch-core/schema"
+	"github.com/opsorch/opsorch-mock-adapters/internal/mockutil"
+)
+
+// regionEvacuationRunbook is the runbook region outage alerts link to; it
+// resolves to the Region Evacuation plan.
+const regionEvacuationRunbook = "dr/region-evacuation"
+
+// OutageRequest names the failure domain to take down: a region, by name
+// ("eu-west-1") or short name ("euw1"), or one availability zone
+// ("eu-west-1a").
+type OutageRequest struct {
+	Region string `json:"region,omitempty"`
+	AZ     string `json:"az,omitempty"`
+}
+
+// OutageResult is what a simulated outage raised.
+type OutageResult struct {
+	Component     string `json:"component"`
+	Region        string `json:"region"`
+	CorrelationID string `json:"correlationId"`
+	// Services are the services inside the failure domain and their direct
+	// callers, the ones alerted on.
+	Services []mockutil.ServiceImpact `json:"services"`
+	Alerts   []schema.Alert           `json:"alerts"`
+	// IncidentID is empty when no incident provider was wired in.
+	IncidentID string `json:"incidentId,omitempty"`
+	PlanID     string `json:"planId,omitempty"`
+}
+
+// SetIncidentOpener sets how the incident for a simulated outage is opened,
+// normally the OpenLinked of the host's incident provider.
+func (p *Provider) SetIncidentOpener(opener mockutil.IncidentOpener) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.opener = opener
+}
+
+// SimulateOutage fires the alerts a region or zone outage would raise, all
+// sharing one correlation ID. Services in a lost region alert on critical
+// error rates, services that lose one of their zones on latency, and their
+// direct callers elsewhere on latency as a warning. The alerts go to the
+// shared snapshot, so metricmock degrades the same services' series, and an
+// incident is opened for the outage through the opener set with
+// SetIncidentOpener. Region outage alerts and the incident link the Region
+// Evacuation plan.
+func (p *Provider) SimulateOutage(ctx context.Context, req OutageRequest) (OutageResult, error) {
+	var component, region, planID string
+	switch {
+	case req.Region != "" && req.AZ != "":
+		return OutageResult{}, orcherr.New("bad_request", "set region or az, not both", nil)
+	case req.Region != "":
+		var ok bool
+		if region, ok = mockutil.ResolveRegion(req.Region); !ok {
+			return OutageResult{}, orcherr.New("not_found", fmt.Sprintf("region %q not found", req.Region), nil)
+		}
+		component = mockutil.DomainRegion + ":" + region
+		planID = runbookPlans[regionEvacuationRunbook]
+	case req.AZ != "":
+		az := strings.ToLower(strings.TrimSpace(req.AZ))
+		var ok bool
+		if region, ok = mockutil.ResolveRegion(strings.TrimRight(az, "abcdef")); !ok || region == az {
+			return OutageResult{}, orcherr.New("not_found", fmt.Sprintf("availability zone %q not found", req.AZ), nil)
+		}
+		component = mockutil.DomainAZ + ":" + az
+	default:
+		return OutageResult{}, orcherr.New("bad_request", "region or az is required", nil)
+	}
+	report, err := mockutil.BlastRadius(component)
+	if err != nil {
+		return OutageResult{}, err
+	}
+
+	result := OutageResult{
+		Component:     component,
+		Region:        region,
+		CorrelationID: mockutil.CorrelationID("outage", component),
+		PlanID:        planID,
+	}
+	alertIDs := make([]string, 0)
+	for _, impact := range report.Services {
+		if impact.Depth > 1 || impact.Impact == mockutil.ImpactAtRisk {
+			continue
+		}
+		fire := FireRequest{
+			Service:     impact.Service,
+			Description: fmt.Sprintf("%s %s", impact.Service, impact.Reason),
+			Fields: map[string]any{
+				"outage":                component,
+				"impact":                impact.Impact,
+				mockutil.CorrelationKey: result.CorrelationID,
+			},
+		}
+		switch {
+		case impact.Depth == 1:
+			fire.Rule, fire.Severity = "high-latency", "warning"
+		case impact.Impact == mockutil.ImpactDown:
+			fire.Rule, fire.Severity = "error-rate", "critical"
+		default:
+			fire.Rule, fire.Severity = "high-latency", "error"
+		}
+		if planID != "" {
+			fire.Runbook = regionEvacuationRunbook
+		}
+		al, err := p.Fire(ctx, fire)
+		if err != nil {
+			return OutageResult{}, err
+		}
+		result.Services = append(result.Services, impact)
+		result.Alerts = append(result.Alerts, al)
+		alertIDs = append(alertIDs, al.ID)
+	}
+
+	p.mu.Lock()
+	opener := p.opener
+	p.mu.Unlock()
+	id, err := opener.Open(ctx, outageIncident(result, alertIDs))
+	var oe orcherr.OpsOrchError
+	switch {
+	case err == nil:
+		result.IncidentID = id
+		result.Alerts = p.linkOutageIncident(alertIDs, id)
+	case errors.As(err, &oe) && oe.Code == "not_found":
+		// No incident provider wired in; the alerts stand alone.
+	default:
+		return OutageResult{}, err
+	}
+	return result, nil
+}
+
+// outageIncident is the incident opened for a simulated outage, owned by the
+// most critical service that went down.
+func outageIncident(result OutageResult, alertIDs []string) mockutil.LinkedIncident {
+	inside := make([]string, 0)
+	for _, impact := range result.Services {
+		if impact.Depth == 0 {
+			inside = append(inside, impact.Service)
+		}
+	}
+	sort.SliceStable(inside, func(i, j int) bool {
+		return mockutil.ServiceTier(inside[i]) < mockutil.ServiceTier(inside[j])
+	})
+	title := fmt.Sprintf("Region outage: %s", result.Region)
+	severity := "sev1"
+	if strings.HasPrefix(result.Component, mockutil.DomainAZ+":") {
+		title = fmt.Sprintf("Availability zone outage: %s", strings.TrimPrefix(result.Component, mockutil.DomainAZ+":"))
+		severity = "sev2"
+	}
+	metadata := map[string]any{
+		"outage":   result.Component,
+		"alertIds": alertIDs,
+	}
+	if result.PlanID != "" {
+		metadata["planId"] = result.PlanID
+		metadata["runbook"] = runbookBaseURL + regionEvacuationRunbook
+	}
+	return mockutil.LinkedIncident{
+		Title:       title,
+		Description: fmt.Sprintf("%s affects %s", result.Component, strings.Join(inside, ", ")),
+		Service:     inside[0],
+		Severity:    severity,
+		Fields: map[string]any{
+			"region":                result.Region,
+			"tags":                  []string{"cause:infrastructure"},
+			mockutil.CorrelationKey: result.CorrelationID,
+		},
+		Metadata: metadata,
+		Note:     fmt.Sprintf("Opened automatically: %s fired %d alerts across %d services", result.Component, len(alertIDs), len(result.Services)),
+		Actor:    mockutil.ActorRef("pd-bot"),
+	}
+}
+
+// linkOutageIncident stamps the outage incident on its alerts and returns
+// them as updated.
+func (p *Provider) linkOutageIncident(alertIDs []string, incidentID string) []schema.Alert {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	out := make([]schema.Alert, 0, len(alertIDs))
+	for _, id := range alertIDs {
+		al, ok := p.alerts[id]
+		if !ok {
+			continue
+		}
+		al.Metadata = mockutil.CloneMap(al.Metadata)
+		al.Metadata["incident_id"] = incidentID
+		mockutil.LinkRefs(al.Metadata, al.Fields)
+		p.alerts[id] = al
+		out = append(out, cloneAlert(al))
+	}
+	p.publishLocked()
+	return out
+}
@@ -0,0 +1,173 @@
+package alertmock
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/opsorch/opsorch-core/schema"
+	"github.com/opsorch/opsorch-mock-adapters/internal/mockutil"
+)
+
+// Priority scores run from 0 to 100: up to 40 points for severity, 25 for
+// the service tier, 20 for customer impact, and 15 for how long the alert has
+// been firing.
+var severityPoints = map[string]int{"critical": 40, "error": 30, "warning": 20, "info": 5}
+
+// tierPoints score the service tier from mockutil.ServiceTier.
+var tierPoints = map[int]int{1: 25, 2: 15, 3: 5}
+
+const (
+	maxImpactPoints   = 20
+	maxDurationPoints = 15
+	// durationPointEvery earns one duration point per interval firing, so an
+	// alert reaches maxDurationPoints after four hours.
+	durationPointEvery = 16 * time.Minute
+)
+
+// activeStatuses are still firing for the duration factor.
+var activeStatuses = map[string]bool{"firing": true, "acknowledged": true, "mitigating": true}
+
+// WithSortByPriority orders Query results by priority score, highest first.
+// Limit then keeps the top-scoring alerts.
+func WithSortByPriority(ctx context.Context) context.Context {
+	return context.WithValue(ctx, sortByPriorityKey{}, true)
+}
+
+type sortByPriorityKey struct{}
+
+func sortByPriority(ctx context.Context) bool {
+	if ctx == nil {
+		return false
+	}
+	v, _ := ctx.Value(sortByPriorityKey{}).(bool)
+	return v
+}
+
+// applyPriority stamps Fields["priorityScore"] and the points behind it in
+// Fields["priorityFactors"] on an outgoing alert copy.
+func (p *Provider) applyPriority(al *schema.Alert, now time.Time) {
+	tier := p.serviceTier(al.Service)
+	factors := map[string]int{
+		"severity":       severityPoints[al.Severity],
+		"serviceTier":    tierPoints[tier],
+		"customerImpact": customerImpactPoints(al.Fields),
+		"duration":       durationPoints(*al, now),
+	}
+	score := 0
+	for _, points := range factors {
+		score += points
+	}
+	if al.Fields == nil {
+		al.Fields = map[string]any{}
+	}
+	al.Fields["priorityScore"] = score
+	al.Fields["priorityFactors"] = factors
+	al.Fields["serviceTier"] = tier
+}
+
+// serviceTier looks a service up by its seeded name, so services renamed by
+// cfg.ServiceMap keep their tier.
+func (p *Provider) serviceTier(service string) int {
+	for from, to := range p.cfg.ServiceMap {
+		if to == service {
+			return mockutil.ServiceTier(from)
+		}
+	}
+	return mockutil.ServiceTier(service)
+}
+
+// customerImpactPoints reads the affected user count, the share of affected
+// traffic, and the spread across services and regions.
+func customerImpactPoints(fields map[string]any) int {
+	points := 0
+	raw, _ := fields["affectedUsers"].(string)
+	if raw == "" {
+		raw, _ = fields["affected_users"].(string)
+	}
+	if raw != "" {
+		users, counted := userCount(raw)
+		switch {
+		case !counted && strings.Contains(strings.ToLower(raw), "all"):
+			points += 14
+		case users >= 10000:
+			points += 14
+		case users >= 1000:
+			points += 10
+		case users > 0:
+			points += 6
+		}
+	}
+	switch pct := numberField(fields["impactPercent"]); {
+	case pct >= 25:
+		points += 6
+	case pct > 0:
+		points += 3
+	}
+	for _, key := range []string{"affectedServices", "affects", "impactedRegions"} {
+		if list, ok := fields[key].([]string); ok {
+			points += 2 * len(list)
+		}
+	}
+	if points > maxImpactPoints {
+		return maxImpactPoints
+	}
+	return points
+}
+
+// userCount reads the number in values like "~12,500 users".
+func userCount(raw string) (int, bool) {
+	digits := strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, raw)
+	n, err := strconv.Atoi(digits)
+	return n, err == nil
+}
+
+func numberField(v any) float64 {
+	switch n := v.(type) {
+	case int:
+		return float64(n)
+	case float64:
+		return n
+	default:
+		return 0
+	}
+}
+
+// durationPoints grows with time firing; resolved and silenced alerts earn
+// none.
+func durationPoints(al schema.Alert, now time.Time) int {
+	if !activeStatuses[al.Status] || al.CreatedAt.IsZero() {
+		return 0
+	}
+	points := int(now.Sub(al.CreatedAt) / durationPointEvery)
+	switch {
+	case points < 0:
+		return 0
+	case points > maxDurationPoints:
+		return maxDurationPoints
+	}
+	return points
+}
+
+// sortAlertsByPriority orders scored alerts by score, breaking ties by the
+// longest firing and then by ID.
+func sortAlertsByPriority(alerts []schema.Alert) {
+	sort.SliceStable(alerts, func(i, j int) bool {
+		si, _ := alerts[i].Fields["priorityScore"].(int)
+		sj, _ := alerts[j].Fields["priorityScore"].(int)
+		if si != sj {
+			return si > sj
+		}
+		if !alerts[i].CreatedAt.Equal(alerts[j].CreatedAt) {
+			return alerts[i].CreatedAt.Before(alerts[j].CreatedAt)
+		}
+		return alerts[i].ID < alerts[j].ID
+	})
+}
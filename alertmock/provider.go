@@ -13,6 +13,7 @@ import (
 	"github.com/opsorch/opsorch-core/alert"
 	"github.com/opsorch/opsorch-core/orcherr"
 	"github.com/opsorch/opsorch-core/schema"
+	"github.com/opsorch/opsorch-mock-adapters/internal/bundle"
 	"github.com/opsorch/opsorch-mock-adapters/internal/mockutil"
 )
 
@@ -21,7 +22,23 @@ const ProviderName = "mock"
 
 // Config controls mock alert behavior.
 type Config struct {
-	Source string
+	Source    string
+	Generator GeneratorConfig
+	Noise     NoiseConfig
+	// IngestAddr, when set, serves IngestHandler on this address (e.g. ":9095").
+	IngestAddr string
+	// ServiceMap renames seeded services to the caller's own names, e.g.
+	// svc-checkout to payments-api.
+	ServiceMap map[string]string
+	// Locale, when set to a translated language such as "de" or "ja",
+	// localizes seeded alert titles and descriptions.
+	Locale string
+	// Features switches realism behaviours off individually; see
+	// mockutil.Features.
+	Features mockutil.Features
+	// Sources holds per-source config for lazy secondary alert sources, such
+	// as {"budget": {"threshold": 0.5}}.
+	Sources map[string]any
 }
 
 // Provider serves seeded alerts for demo purposes.
@@ -30,6 +47,11 @@ type Provider struct {
 	mu        sync.Mutex
 	alerts    map[string]schema.Alert
 	lifecycle map[string]*alertLifecycle
+	// fired numbers the alerts raised by Fire.
+	fired int
+	// opener opens the incident for a simulated outage; nil until a host
+	// wires one with SetIncidentOpener.
+	opener mockutil.IncidentOpener
 }
 
 // New constructs the provider with seeded demo alerts.
@@ -37,6 +59,18 @@ func New(cfg map[string]any) (alert.Provider, error) {
 	parsed := parseConfig(cfg)
 	p := &Provider{cfg: parsed, alerts: map[string]schema.Alert{}, lifecycle: map[string]*alertLifecycle{}}
 	p.seed()
+	snapshot, err := bundle.FromConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	p.restore(snapshot)
+	mockutil.RegisterResolver(mockutil.RefAlert, func(ctx context.Context, id string) (any, error) { return p.Get(ctx, id) })
+	mockutil.RegisterSearchSource(mockutil.RefAlert, p.SearchDocs)
+	if parsed.IngestAddr != "" {
+		if err := p.serveIngest(parsed.IngestAddr); err != nil {
+			return nil, err
+		}
+	}
 	return p, nil
 }
 
@@ -95,16 +129,30 @@ type scopeKey struct{}
 
 // Query returns alerts filtered by status/severity/scope/query.
 func (p *Provider) Query(ctx context.Context, query schema.AlertQuery) ([]schema.Alert, error) {
+	filter, err := mockutil.FilterFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
 	now := time.Now().UTC()
 	p.refreshLifecycleLocked(now)
+	p.refreshNoiseLocked(now)
 
 	combinedScope := mergeScope(extractScope(ctx), query.Scope)
 	statusFilter := toSet(query.Statuses)
 	severityFilter := toSet(query.Severities)
+	integrationSet := integrationFilter(ctx)
+	entity, byEntity := entityFilter(ctx)
 	needle := strings.ToLower(strings.TrimSpace(query.Query))
+	// Sorting needs every match before Limit picks the top ones.
+	byPriority := sortByPriority(ctx)
+	limit := query.Limit
+	if byPriority {
+		limit = 0
+	}
 
 	// Parse the search query
 	parsedQuery := mockutil.ParseSearchQuery(query.Query)
@@ -123,23 +171,74 @@ func (p *Provider) Query(ctx context.Context, query schema.AlertQuery) ([]schema
 		if needle != "" && !matchesQuery(needle, al) {
 			continue
 		}
+		if !matchesIntegration(integrationSet, al) {
+			continue
+		}
+		if byEntity && !matchesEntity(entity, al) {
+			continue
+		}
+		if !filter.Match(al) {
+			continue
+		}
 
 		out = append(out, cloneAlert(al))
-		if query.Limit > 0 && len(out) >= query.Limit {
+		if limit > 0 && len(out) >= limit {
+			break
+		}
+	}
+	// Alerts from secondary sources (e.g. cost monitoring) share the triage view.
+	for _, al := range mockutil.SourceAlertsFor(p.cfg.Sources) {
+		if limit > 0 && len(out) >= limit {
 			break
 		}
+		if !matchesScope(combinedScope, al) {
+			continue
+		}
+		if len(statusFilter) > 0 && !statusFilter[al.Status] {
+			continue
+		}
+		if len(severityFilter) > 0 && !severityFilter[al.Severity] {
+			continue
+		}
+		if needle != "" && !matchesQuery(needle, al) {
+			continue
+		}
+		if !matchesIntegration(integrationSet, al) {
+			continue
+		}
+		if byEntity && !matchesEntity(entity, al) {
+			continue
+		}
+		if !filter.Match(al) {
+			continue
+		}
+		al.Fields = mockutil.CloneMap(al.Fields)
+		out = append(out, al)
 	}
 
 	// If we have a search query but no results, generate mock alerts that match
-	if query.Query != "" && len(out) == 0 {
+	if query.Query != "" && len(out) == 0 && !byEntity {
 		limit := query.Limit
 		if limit <= 0 {
 			limit = 5
 		}
-		generated := p.generateAlertsForQuery(parsedQuery, combinedScope, statusFilter, severityFilter, limit, now)
-		out = append(out, generated...)
+		for _, al := range p.generateAlertsForQuery(parsedQuery, combinedScope, statusFilter, severityFilter, limit, now) {
+			if matchesIntegration(integrationSet, al) && filter.Match(al) {
+				out = append(out, al)
+			}
+		}
 	}
 
+	for i := range out {
+		p.applyPriority(&out[i], now)
+		p.applyResolutionETA(&out[i], now)
+	}
+	if byPriority {
+		sortAlertsByPriority(out)
+		if query.Limit > 0 && len(out) > query.Limit {
+			out = out[:query.Limit]
+		}
+	}
 	return out, nil
 }
 
@@ -148,13 +247,25 @@ func (p *Provider) Get(ctx context.Context, id string) (schema.Alert, error) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
-	p.refreshLifecycleLocked(time.Now().UTC())
+	now := time.Now().UTC()
+	p.refreshLifecycleLocked(now)
+	p.refreshNoiseLocked(now)
 
 	al, ok := p.alerts[id]
 	if !ok {
+		for _, sourced := range mockutil.SourceAlertsFor(p.cfg.Sources) {
+			if sourced.ID == id {
+				sourced.Fields = mockutil.CloneMap(sourced.Fields)
+				p.applyPriority(&sourced, now)
+				return sourced, nil
+			}
+		}
 		return schema.Alert{}, orcherr.New("not_found", "alert not found", nil)
 	}
-	return cloneAlert(al), nil
+	out := cloneAlert(al)
+	p.applyPriority(&out, now)
+	p.applyResolutionETA(&out, now)
+	return out, nil
 }
 
 func (p *Provider) seed() {
@@ -1040,6 +1151,7 @@ func (p *Provider) seed() {
 			Metadata: map[string]any{
 				"root_cause":   "connection leak in checkout service",
 				"is_scenario":  true,
+				"runbook":      "https://runbook.demo/db-connection-pool",
 				"affects":      []string{"svc-checkout", "svc-order", "svc-catalog"},
 				"is_cascading": true,
 			},
@@ -1098,6 +1210,7 @@ func (p *Provider) seed() {
 			Metadata: map[string]any{
 				"root_cause":           "stripe infrastructure issue",
 				"is_scenario":          true,
+				"runbook":              "https://runbook.demo/service-degradation",
 				"external_status_page": "https://status.stripe.com",
 			},
 		},
@@ -1158,20 +1271,28 @@ func (p *Provider) seed() {
 	}
 
 	for _, al := range seed {
+		if !p.cfg.Features.ScenarioEffects && isScenarioAlert(al.Metadata, al.Fields) {
+			continue
+		}
 		alertCopy := al
 		if alertCopy.Metadata == nil {
 			alertCopy.Metadata = map[string]any{}
 		}
 		alertCopy.Metadata["source"] = p.cfg.Source
 
-		// Enrich with metadata fields (runbook, dashboard, channel, escalation)
-		enrichAlertMetadata(&alertCopy)
+		if p.cfg.Features.Flair {
+			// Enrich with metadata fields (runbook, dashboard, channel, escalation)
+			enrichAlertMetadata(&alertCopy)
+
+			// Enrich with contextual information (deployment, config, user impact)
+			enrichWithContextualInfo(&alertCopy, now)
 
-		// Enrich with contextual information (deployment, config, user impact)
-		enrichWithContextualInfo(&alertCopy, now)
+			// Enrich with multi-region fields for infrastructure alerts
+			enrichWithMultiRegionFields(&alertCopy)
+		}
+		linkRunbookPlan(&alertCopy)
 
-		// Enrich with multi-region fields for infrastructure alerts
-		enrichWithMultiRegionFields(&alertCopy)
+		applyIntegration(&alertCopy)
 
 		p.alerts[alertCopy.ID] = alertCopy
 		if steps, ok := lifecycleScenarios[alertCopy.ID]; ok {
@@ -1198,9 +1319,14 @@ func (p *Provider) seed() {
 			"alert_name":  "correlation_lag_high",
 		},
 		Metadata: map[string]any{
-			"source": p.cfg.Source,
+			"source":  p.cfg.Source,
+			"runbook": "https://runbook.demo/analytics-correlation",
 		},
 	}
+	analyticsAlert := p.alerts[analyticsAlertID]
+	applyIntegration(&analyticsAlert)
+	linkRunbookPlan(&analyticsAlert)
+	p.alerts[analyticsAlertID] = analyticsAlert
 	p.lifecycle["alert-analytics-001"] = &alertLifecycle{steps: lifecycleScenarios["al-013"]}
 
 	// Add payment latency alert
@@ -1222,14 +1348,92 @@ func (p *Provider) seed() {
 			"alert_name":  "payment_latency_high",
 		},
 		Metadata: map[string]any{
-			"source": p.cfg.Source,
+			"source":  p.cfg.Source,
+			"runbook": "https://runbook.demo/payment-latency",
 		},
 	}
+	paymentAlert := p.alerts[paymentAlertID]
+	applyIntegration(&paymentAlert)
+	linkRunbookPlan(&paymentAlert)
+	p.alerts[paymentAlertID] = paymentAlert
 	p.lifecycle[paymentAlertID] = &alertLifecycle{steps: lifecycleScenarios["al-001"]}
 
+	for _, al := range infraAlerts(p.cfg.Source, now) {
+		p.alerts[al.ID] = al
+	}
+
+	for _, al := range generateLoadAlerts(p.cfg.Generator, p.cfg.Source, now) {
+		applyIntegration(&al)
+		p.alerts[al.ID] = al
+	}
+
+	for id, al := range p.alerts {
+		stampCorrelation(&al, "")
+		p.alerts[id] = al
+	}
+
+	p.localizeLocked()
+	p.renameServicesLocked()
 	p.publishLocked()
 }
 
+// stampCorrelation sets Fields["correlation_id"] from the scenario or incident
+// the alert belongs to, or to fallback when it belongs to neither.
+func stampCorrelation(al *schema.Alert, fallback string) {
+	id := mockutil.CorrelationFor(al.Fields, al.Metadata)
+	if id == "" {
+		id = fallback
+	}
+	if id == "" {
+		return
+	}
+	if al.Fields == nil {
+		al.Fields = map[string]any{}
+	}
+	al.Fields[mockutil.CorrelationKey] = id
+}
+
+// localizeLocked translates seeded alert titles and descriptions into
+// cfg.Locale. Callers must hold p.mu.
+func (p *Provider) localizeLocked() {
+	if p.cfg.Locale == "" {
+		return
+	}
+	for id, al := range p.alerts {
+		al.Title = mockutil.Localize(p.cfg.Locale, al.Title)
+		al.Description = mockutil.Localize(p.cfg.Locale, al.Description)
+		p.alerts[id] = al
+	}
+}
+
+// renameServicesLocked applies cfg.ServiceMap to the seeded alerts. Callers
+// must hold p.mu.
+func (p *Provider) renameServicesLocked() {
+	if len(p.cfg.ServiceMap) == 0 {
+		return
+	}
+	for id, al := range p.alerts {
+		p.renameServices(&al)
+		p.alerts[id] = al
+	}
+}
+
+// renameServices rewrites whole service names from cfg.ServiceMap wherever
+// they appear in an alert: the service, title, description, and every string
+// in fields and metadata, including integration labels and affected-service
+// lists.
+func (p *Provider) renameServices(al *schema.Alert) {
+	if len(p.cfg.ServiceMap) == 0 {
+		return
+	}
+	rename := func(s string) string { return mockutil.RenameTokens(s, p.cfg.ServiceMap) }
+	al.Service = rename(al.Service)
+	al.Title = rename(al.Title)
+	al.Description = rename(al.Description)
+	al.Fields = mockutil.RewriteMap(al.Fields, rename)
+	al.Metadata = mockutil.RewriteMap(al.Metadata, rename)
+}
+
 type lifecycleStep struct {
 	After    time.Duration
 	Status   string
@@ -1242,7 +1446,7 @@ type alertLifecycle struct {
 }
 
 func (p *Provider) refreshLifecycleLocked(now time.Time) {
-	if len(p.lifecycle) == 0 {
+	if len(p.lifecycle) == 0 || !p.cfg.Features.Lifecycle {
 		return
 	}
 	changed := false
@@ -1252,6 +1456,8 @@ func (p *Provider) refreshLifecycleLocked(now time.Time) {
 			continue
 		}
 		if plan.advance(now, &alertState) {
+			// Keep the integration payload's state in step with the alert.
+			applyIntegration(&alertState)
 			p.alerts[id] = alertState
 			changed = true
 		}
@@ -1307,6 +1513,13 @@ func (plan *alertLifecycle) advance(now time.Time, alertState *schema.Alert) boo
 func (p *Provider) publishLocked() {
 	snapshot := make([]schema.Alert, 0, len(p.alerts))
 	for _, al := range p.alerts {
+		// Load-test and noise alerts stay out of the shared store so they do not skew cross-provider correlation.
+		if loadTest, _ := al.Fields["loadTest"].(bool); loadTest {
+			continue
+		}
+		if noise, _ := al.Fields["noise"].(bool); noise {
+			continue
+		}
 		snapshot = append(snapshot, cloneAlert(al))
 	}
 	mockutil.PublishAlerts(snapshot)
@@ -1351,6 +1564,15 @@ func parseConfig(cfg map[string]any) Config {
 	if v, ok := cfg["source"].(string); ok && v != "" {
 		out.Source = v
 	}
+	out.Generator = parseGeneratorConfig(cfg["generator"])
+	out.Noise = parseNoiseConfig(cfg["noise"])
+	if v, ok := cfg["ingestAddr"].(string); ok {
+		out.IngestAddr = strings.TrimSpace(v)
+	}
+	out.ServiceMap = mockutil.ParseRenames(cfg["serviceMap"])
+	out.Locale = mockutil.ParseLocale(cfg)
+	out.Features = mockutil.ParseFeatures(cfg)
+	out.Sources, _ = cfg["sources"].(map[string]any)
 	return out
 }
 
@@ -1540,6 +1762,7 @@ func (p *Provider) generateAlertsForQuery(parsed mockutil.ParsedQuery, scope sch
 				alert.Fields[fmt.Sprintf("term_%d", idx)] = term
 			}
 		}
+		applyIntegration(&alert)
 
 		alerts = append(alerts, alert)
 	}
@@ -1597,3 +1820,22 @@ func toSet(vals []string) map[string]bool {
 	}
 	return out
 }
+
+// SearchDocs indexes alert titles and descriptions for global search.
+func (p *Provider) SearchDocs(ctx context.Context) []mockutil.SearchDoc {
+	_ = ctx
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now().UTC()
+	p.refreshLifecycleLocked(now)
+	p.refreshNoiseLocked(now)
+	docs := make([]mockutil.SearchDoc, 0, len(p.alerts))
+	for _, al := range p.alerts {
+		docs = append(docs, mockutil.SearchDoc{
+			Kind: mockutil.RefAlert, ID: al.ID, Title: al.Title, Body: al.Description,
+			Service: al.Service, Status: al.Status, UpdatedAt: al.UpdatedAt,
+		})
+	}
+	return docs
+}
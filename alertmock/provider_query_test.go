@@ -225,3 +225,55 @@ func toLower(s string) string {
 	}
 	return string(result)
 }
+
+func TestQuery_SortByPriority_ReturnsHighestScoresFirst(t *testing.T) {
+	provAny, err := New(map[string]any{})
+	if err != nil {
+		t.Fatalf("Failed to create provider: %v", err)
+	}
+	prov := provAny.(*Provider)
+
+	all, err := prov.Query(WithSortByPriority(context.Background()), schema.AlertQuery{})
+	if err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	if len(all) < 2 {
+		t.Fatalf("expected several alerts, got %d", len(all))
+	}
+	prev := 101
+	for _, al := range all {
+		score, ok := al.Fields["priorityScore"].(int)
+		if !ok || score < 0 || score > 100 {
+			t.Fatalf("alert %s has invalid priorityScore %v", al.ID, al.Fields["priorityScore"])
+		}
+		if score > prev {
+			t.Fatalf("alert %s scored %d after a score of %d", al.ID, score, prev)
+		}
+		prev = score
+		factors, ok := al.Fields["priorityFactors"].(map[string]int)
+		if !ok || factors["severity"]+factors["serviceTier"]+factors["customerImpact"]+factors["duration"] != score {
+			t.Fatalf("alert %s factors %v do not add up to %d", al.ID, factors, score)
+		}
+	}
+
+	top, err := prov.Query(WithSortByPriority(context.Background()), schema.AlertQuery{Limit: 3})
+	if err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	if len(top) != 3 {
+		t.Fatalf("expected 3 alerts, got %d", len(top))
+	}
+	for i := range top {
+		if top[i].ID != all[i].ID {
+			t.Fatalf("limited query returned %s at %d, want %s", top[i].ID, i, all[i].ID)
+		}
+	}
+
+	checkout, err := prov.Get(context.Background(), "al-001")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if factors := checkout.Fields["priorityFactors"].(map[string]int); factors["severity"] != 40 || factors["serviceTier"] != 25 || factors["customerImpact"] == 0 {
+		t.Fatalf("unexpected factors for critical checkout alert: %v", factors)
+	}
+}
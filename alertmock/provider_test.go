@@ -2,12 +2,19 @@ package alertmock
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/opsorch/opsorch-core/schema"
 	"github.com/opsorch/opsorch-mock-adapters/internal/mockutil"
+	"github.com/opsorch/opsorch-mock-adapters/internal/pluginrpc"
+	"github.com/opsorch/opsorch-mock-adapters/orchestrationmock"
 )
 
 func TestSeededAlertsAndGet(t *testing.T) {
@@ -24,7 +31,7 @@ func TestSeededAlertsAndGet(t *testing.T) {
 	if len(list) == 0 {
 		t.Fatalf("expected seeded alerts, got %d", len(list))
 	}
-	if list[0].Service == "" {
+	if list[0].Service == "" && !isInfraAlert(list[0]) {
 		t.Fatalf("expected service populated, got %+v", list[0])
 	}
 	if list[0].Description == "" {
@@ -405,8 +412,12 @@ func TestProperty_ServiceAndEnvironmentMetadata(t *testing.T) {
 	}
 
 	for _, al := range alerts {
-		// Check service name
-		if al.Service == "" {
+		// Check service name, or the entity for infrastructure-scoped alerts
+		if isInfraAlert(al) {
+			if id, ok := al.Fields["entity_id"].(string); !ok || id == "" {
+				t.Errorf("infra alert %s: missing entity_id", al.ID)
+			}
+		} else if al.Service == "" {
 			t.Errorf("alert %s: missing service name", al.ID)
 		}
 
@@ -1017,3 +1028,845 @@ func TestAlertURLGeneration(t *testing.T) {
 		t.Errorf("scenario alert %s should have scenario parameter: %s", scenarioAlert.ID, scenarioAlert.URL)
 	}
 }
+
+func TestGeneratorMode(t *testing.T) {
+	cfg := map[string]any{
+		"generator": map[string]any{
+			"count":       2000.0,
+			"services":    []any{"svc-checkout", "svc-search"},
+			"severityMix": map[string]any{"critical": 1.0, "warning": 3.0},
+			"flapRate":    0.2,
+			"seed":        7.0,
+		},
+	}
+	provAny, err := New(cfg)
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	prov := provAny.(*Provider)
+
+	list, err := prov.Query(context.Background(), schema.AlertQuery{})
+	if err != nil {
+		t.Fatalf("Query returned error: %v", err)
+	}
+
+	generated, flapping := 0, 0
+	severities := map[string]int{}
+	for _, al := range list {
+		if loadTest, _ := al.Fields["loadTest"].(bool); !loadTest {
+			continue
+		}
+		generated++
+		severities[al.Severity]++
+		if al.Service != "svc-checkout" && al.Service != "svc-search" {
+			t.Fatalf("unexpected service %q in generated alert", al.Service)
+		}
+		if f, _ := al.Fields["flapping"].(bool); f {
+			flapping++
+		}
+	}
+	if generated != 2000 {
+		t.Fatalf("expected 2000 generated alerts, got %d", generated)
+	}
+	if len(severities) != 2 || severities["warning"] <= severities["critical"] {
+		t.Fatalf("expected warning-heavy two-severity mix, got %v", severities)
+	}
+	if flapping < 200 || flapping > 600 {
+		t.Fatalf("expected roughly 20%% flapping alerts, got %d", flapping)
+	}
+
+	for _, al := range mockutil.SnapshotAlerts() {
+		if loadTest, _ := al.Fields["loadTest"].(bool); loadTest {
+			t.Fatalf("load-test alert %s leaked into shared snapshot", al.ID)
+		}
+	}
+}
+
+func TestGeneratorDeterministic(t *testing.T) {
+	gen := GeneratorConfig{Count: 50, Seed: 42}
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	first := generateLoadAlerts(gen, "mock", now)
+	second := generateLoadAlerts(gen, "mock", now)
+	for i := range first {
+		if first[i].Title != second[i].Title || first[i].Severity != second[i].Severity || !first[i].CreatedAt.Equal(second[i].CreatedAt) {
+			t.Fatalf("generated alert %d differs between runs", i)
+		}
+	}
+}
+
+func TestGeneratorSeverityFollowsServiceTier(t *testing.T) {
+	gen := GeneratorConfig{Count: 600, Seed: 3, Services: []string{"svc-checkout", "svc-analytics"}}
+	bySeverity := map[string]map[string]int{"svc-checkout": {}, "svc-analytics": {}}
+	for _, al := range generateLoadAlerts(gen, "mock", time.Now().UTC()) {
+		bySeverity[al.Service][al.Severity]++
+	}
+	tier1, tier3 := bySeverity["svc-checkout"], bySeverity["svc-analytics"]
+	if tier1["critical"] <= tier1["warning"] || tier1["critical"] <= tier1["info"] {
+		t.Fatalf("expected tier-1 svc-checkout to fire mostly critical, got %v", tier1)
+	}
+	if tier3["critical"] != 0 || tier3["warning"] <= tier3["error"]+tier3["info"] {
+		t.Fatalf("expected tier-3 svc-analytics to fire mostly warning, got %v", tier3)
+	}
+}
+
+func TestNoiseAlerts(t *testing.T) {
+	cfg := NoiseConfig{Enabled: true, Interval: 10 * time.Minute, Lookback: 2 * time.Hour}
+	now := time.Date(2024, 1, 1, 12, 5, 0, 0, time.UTC)
+	alerts := noiseAlerts(cfg, "mock", now)
+	if len(alerts) != 12 {
+		t.Fatalf("expected 12 noise alerts over 2h at 10m intervals, got %d", len(alerts))
+	}
+	for _, al := range alerts {
+		if al.Severity != "info" && al.Severity != "warning" {
+			t.Errorf("noise alert %s has severity %q", al.ID, al.Severity)
+		}
+		if noise, _ := al.Fields["noise"].(bool); !noise {
+			t.Errorf("noise alert %s missing noise marker", al.ID)
+		}
+	}
+
+	// Ten minutes later the oldest slot ages out and one new alert appears.
+	later := noiseAlerts(cfg, "mock", now.Add(10*time.Minute))
+	if len(later) != 12 || later[0].ID != alerts[1].ID || later[10].Title != alerts[11].Title {
+		t.Errorf("expected window to slide by one slot, got first %s", later[0].ID)
+	}
+	if noiseAlerts(NoiseConfig{Interval: time.Minute, Lookback: time.Hour}, "mock", now) != nil {
+		t.Error("disabled noise config should not generate alerts")
+	}
+}
+
+func TestNoiseToggle(t *testing.T) {
+	countNoise := func(cfg map[string]any) int {
+		provAny, err := New(cfg)
+		if err != nil {
+			t.Fatalf("New returned error: %v", err)
+		}
+		list, err := provAny.Query(context.Background(), schema.AlertQuery{})
+		if err != nil {
+			t.Fatalf("Query returned error: %v", err)
+		}
+		n := 0
+		for _, al := range list {
+			if noise, _ := al.Metadata["noise"].(bool); noise {
+				n++
+				if _, ok := al.Metadata["integration"].(string); !ok {
+					t.Errorf("noise alert %s missing integration", al.ID)
+				}
+			}
+		}
+		return n
+	}
+
+	if n := countNoise(nil); n != 0 {
+		t.Fatalf("expected no noise by default, got %d", n)
+	}
+	if n := countNoise(map[string]any{"noise": map[string]any{"enabled": true, "interval": "30m", "lookback": "3h"}}); n != 6 {
+		t.Fatalf("expected 6 noise alerts, got %d", n)
+	}
+	for _, al := range mockutil.SnapshotAlerts() {
+		if noise, _ := al.Fields["noise"].(bool); noise {
+			t.Fatalf("noise alert %s leaked into shared snapshot", al.ID)
+		}
+	}
+}
+
+func TestQueryRecomputesLazySourceAlerts(t *testing.T) {
+	calls := 0
+	mockutil.RegisterAlertSource("test-lazy", func(cfg map[string]any) []schema.Alert {
+		calls++
+		severity, _ := cfg["severity"].(string)
+		return []schema.Alert{{
+			ID:        "lazy-test-alert",
+			Title:     "Lazy source alert",
+			Status:    "firing",
+			Severity:  severity,
+			Service:   "svc-search",
+			CreatedAt: time.Now().Add(-time.Hour),
+			UpdatedAt: time.Now(),
+			Fields:    map[string]any{"category": mockutil.AlertCategoryCost},
+		}}
+	})
+	defer mockutil.RegisterAlertSource("test-lazy", nil)
+
+	provAny, _ := New(map[string]any{"sources": map[string]any{"test-lazy": map[string]any{"severity": "warning"}}})
+	prov := provAny.(*Provider)
+	if calls != 0 {
+		t.Fatalf("lazy source computed %d times before any query", calls)
+	}
+	for i := 1; i <= 2; i++ {
+		got, err := prov.Get(context.Background(), "lazy-test-alert")
+		if err != nil || got.Severity != "warning" {
+			t.Fatalf("Get = %+v, %v; want the lazy alert with the configured severity", got, err)
+		}
+		if calls != i {
+			t.Fatalf("lazy source computed %d times after %d queries", calls, i)
+		}
+	}
+}
+
+func TestQueryIncludesSourceAlerts(t *testing.T) {
+	mockutil.PublishSourceAlerts("test-cost", []schema.Alert{{
+		ID:        "cost-test-anomaly",
+		Title:     "Cost anomaly: svc-search compute spend up 55%",
+		Status:    "firing",
+		Severity:  "warning",
+		Service:   "svc-search",
+		CreatedAt: time.Now().Add(-time.Hour),
+		UpdatedAt: time.Now(),
+		Fields:    map[string]any{"category": mockutil.AlertCategoryCost},
+	}})
+	defer mockutil.PublishSourceAlerts("test-cost", nil)
+
+	provAny, _ := New(nil)
+	prov := provAny.(*Provider)
+
+	list, err := prov.Query(context.Background(), schema.AlertQuery{Scope: schema.QueryScope{Service: "svc-search"}})
+	if err != nil {
+		t.Fatalf("Query returned error: %v", err)
+	}
+	found := false
+	for _, al := range list {
+		if al.ID == "cost-test-anomaly" {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("expected source alert alongside operational alerts, got %d alerts", len(list))
+	}
+
+	got, err := prov.Get(context.Background(), "cost-test-anomaly")
+	if err != nil || got.Service != "svc-search" {
+		t.Fatalf("expected Get to find source alert, got %+v, %v", got, err)
+	}
+
+	filtered, _ := prov.Query(context.Background(), schema.AlertQuery{Severities: []string{"critical"}})
+	for _, al := range filtered {
+		if al.ID == "cost-test-anomaly" {
+			t.Fatalf("severity filter should apply to source alerts")
+		}
+	}
+}
+
+func TestAlertIntegrations(t *testing.T) {
+	provAny, _ := New(nil)
+	prov := provAny.(*Provider)
+
+	all, err := prov.Query(context.Background(), schema.AlertQuery{})
+	if err != nil {
+		t.Fatalf("Query returned error: %v", err)
+	}
+	seen := map[string]bool{}
+	for _, al := range all {
+		name, _ := al.Metadata["integration"].(string)
+		if name == "" {
+			continue
+		}
+		seen[name] = true
+		if _, ok := al.Fields[name].(map[string]any); !ok {
+			t.Fatalf("alert %s from %s missing payload in Fields[%q]", al.ID, name, name)
+		}
+	}
+	for _, name := range []string{IntegrationPrometheus, IntegrationDatadog, IntegrationCloudWatch, IntegrationSynthetic} {
+		if !seen[name] {
+			t.Fatalf("expected seeded alerts from %s", name)
+		}
+	}
+
+	failover, _ := prov.Get(context.Background(), "al-002")
+	alarm, ok := failover.Fields[IntegrationCloudWatch].(map[string]any)
+	if !ok || alarm["Namespace"] != "AWS/RDS" || alarm["Region"] != "us-east-1" {
+		t.Fatalf("expected RDS CloudWatch alarm for al-002, got %v", failover.Fields[IntegrationCloudWatch])
+	}
+	latency, _ := prov.Get(context.Background(), "al-001")
+	prom, ok := latency.Fields[IntegrationPrometheus].(map[string]any)
+	if !ok || prom["labels"].(map[string]any)["service"] != "svc-checkout" {
+		t.Fatalf("expected Prometheus labels for al-001, got %v", latency.Fields[IntegrationPrometheus])
+	}
+
+	ctx := WithIntegrations(context.Background(), IntegrationDatadog, IntegrationSynthetic)
+	filtered, err := prov.Query(ctx, schema.AlertQuery{})
+	if err != nil {
+		t.Fatalf("Query returned error: %v", err)
+	}
+	if len(filtered) == 0 || len(filtered) >= len(all) {
+		t.Fatalf("expected integration filter to return a strict subset, got %d of %d", len(filtered), len(all))
+	}
+	for _, al := range filtered {
+		if name := al.Metadata["integration"]; name != IntegrationDatadog && name != IntegrationSynthetic {
+			t.Fatalf("alert %s from %v returned by integration filter", al.ID, name)
+		}
+	}
+}
+
+func TestInfraAlertsScopedToEntities(t *testing.T) {
+	provAny, _ := New(nil)
+	prov := provAny.(*Provider)
+	ctx := context.Background()
+
+	nodes, err := prov.Query(WithEntity(ctx, EntityNode, ""), schema.AlertQuery{})
+	if err != nil {
+		t.Fatalf("Query returned error: %v", err)
+	}
+	if len(nodes) != 2 {
+		t.Fatalf("expected 2 node alerts, got %d", len(nodes))
+	}
+	for _, al := range nodes {
+		if al.Service != "" || al.Fields["entity_type"] != EntityNode || al.Fields["cluster"] != "prod-use1" {
+			t.Fatalf("unexpected node alert %+v", al)
+		}
+	}
+
+	one, _ := prov.Query(WithEntity(ctx, "", "lb/public-web-alb"), schema.AlertQuery{})
+	if len(one) != 1 || one[0].ID != "al-infra-004" {
+		t.Fatalf("expected the load balancer alert, got %+v", one)
+	}
+	alarm := one[0].Fields[IntegrationCloudWatch].(map[string]any)
+	if alarm["Namespace"] != "AWS/ApplicationELB" {
+		t.Fatalf("expected ELB CloudWatch alarm, got %v", alarm)
+	}
+
+	trap, _ := prov.Get(ctx, "al-infra-005")
+	if trap.Metadata["integration"] != IntegrationSNMP || trap.Fields[IntegrationSNMP].(map[string]any)["trapOID"] != "1.3.6.1.6.3.1.1.5.3" {
+		t.Fatalf("expected SNMP linkDown trap payload, got %+v", trap)
+	}
+
+	// Service scopes never pick up infrastructure alerts, even for affected services.
+	scoped, _ := prov.Query(ctx, schema.AlertQuery{Scope: schema.QueryScope{Service: "svc-web"}})
+	for _, al := range scoped {
+		if isInfraAlert(al) {
+			t.Fatalf("service scope returned infra alert %s", al.ID)
+		}
+	}
+	teamScoped, _ := prov.Query(ctx, schema.AlertQuery{Scope: schema.QueryScope{Team: "team-infra"}})
+	if len(teamScoped) < len(infraSeeds) {
+		t.Fatalf("expected team-infra scope to include the infra alerts, got %d", len(teamScoped))
+	}
+}
+
+func TestIngestWebhooks(t *testing.T) {
+	provAny, _ := New(nil)
+	prov := provAny.(*Provider)
+	srv := httptest.NewServer(prov.IngestHandler())
+	defer srv.Close()
+
+	post := func(path, body string) *http.Response {
+		t.Helper()
+		resp, err := http.Post(srv.URL+path, "application/json", strings.NewReader(body))
+		if err != nil {
+			t.Fatalf("POST %s: %v", path, err)
+		}
+		resp.Body.Close()
+		return resp
+	}
+
+	firing := `{"version":"4","status":"firing","receiver":"opsorch","commonLabels":{"env":"staging"},"alerts":[
+		{"status":"firing","labels":{"alertname":"HighErrorRate","service":"checkout","severity":"critical"},
+		 "annotations":{"summary":"Checkout 5xx above 5%","description":"5xx ratio 7.1% over 5m"},
+		 "startsAt":"2026-10-16T09:00:00Z","fingerprint":"abc123"}]}`
+	if resp := post(IngestAlertmanagerPath, firing); resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200 from alertmanager ingest, got %d", resp.StatusCode)
+	}
+	al, err := prov.Get(context.Background(), "al-am-abc123")
+	if err != nil {
+		t.Fatalf("expected ingested alert: %v", err)
+	}
+	if al.Status != "firing" || al.Severity != "critical" || al.Service != "svc-checkout" || al.Fields["environment"] != "staging" {
+		t.Fatalf("unexpected ingested alert: %+v", al)
+	}
+	if al.Metadata["integration"] != IntegrationPrometheus || al.Fields["ingested"] != true {
+		t.Fatalf("expected ingested prometheus alert, got %v / %v", al.Metadata, al.Fields["ingested"])
+	}
+	scoped, _ := prov.Query(context.Background(), schema.AlertQuery{Scope: schema.QueryScope{Service: "svc-checkout"}})
+	found := false
+	for _, a := range scoped {
+		found = found || a.ID == al.ID
+	}
+	if !found {
+		t.Fatal("expected ingested alert in service-scoped query")
+	}
+
+	post(IngestAlertmanagerPath, strings.Replace(strings.Replace(firing, `"status":"firing"`, `"status":"resolved"`, -1), `"fingerprint"`, `"endsAt":"2026-10-16T09:20:00Z","fingerprint"`, 1))
+	resolved, _ := prov.Get(context.Background(), "al-am-abc123")
+	if resolved.Status != "resolved" || resolved.Metadata["resolvedAt"] != "2026-10-16T09:20:00Z" || !resolved.CreatedAt.Equal(al.CreatedAt) {
+		t.Fatalf("expected resolved notification to resolve the same alert, got %+v", resolved)
+	}
+
+	dd := `{"id":"7000001","alert_id":4242,"title":"[Triggered] Payment gateway latency","body":"p99 above 2s","alert_transition":"Triggered","alert_type":"error","tags":"service:payments,env:prod,team:team-revenue","date":"1792141200000"}`
+	if resp := post(IngestDatadogPath, dd); resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200 from datadog ingest, got %d", resp.StatusCode)
+	}
+	ddAlert, err := prov.Get(context.Background(), "al-dd-4242")
+	if err != nil {
+		t.Fatalf("expected ingested datadog alert: %v", err)
+	}
+	if ddAlert.Service != "svc-payments" || ddAlert.Severity != "error" || ddAlert.Fields["team"] != "team-revenue" || ddAlert.Fields["alertname"] != "Payment gateway latency" {
+		t.Fatalf("unexpected datadog alert: %+v", ddAlert)
+	}
+
+	if resp := post(IngestAlertmanagerPath, `{"alerts":[{"labels":{}}]}`); resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected 400 for alert without alertname, got %d", resp.StatusCode)
+	}
+	if resp := post(IngestDatadogPath, `not json`); resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected 400 for invalid JSON, got %d", resp.StatusCode)
+	}
+	resp, err := http.Get(srv.URL + IngestDatadogPath)
+	if err != nil {
+		t.Fatalf("GET: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusMethodNotAllowed {
+		t.Fatalf("expected 405 for GET, got %d", resp.StatusCode)
+	}
+}
+
+func TestRunbookPlanLinks(t *testing.T) {
+	provAny, err := New(map[string]any{})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	prov := provAny.(*Provider)
+	ctx := context.Background()
+
+	al, err := prov.Get(ctx, "al-002")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if al.Metadata["planId"] != "plan-runbook-001" {
+		t.Errorf("al-002 planId = %v, want plan-runbook-001", al.Metadata["planId"])
+	}
+	if refs, _ := al.Metadata["refs"].([]string); !strings.Contains(strings.Join(refs, " "), "plan:plan-runbook-001") {
+		t.Errorf("al-002 refs = %v, want plan ref", al.Metadata["refs"])
+	}
+
+	link, err := prov.RunbookPlan(ctx, "al-scenario-002")
+	if err != nil {
+		t.Fatalf("RunbookPlan: %v", err)
+	}
+	if link.PlanID != "plan-playbook-001" || link.Ref != "plan:plan-playbook-001" || link.Runbook != "https://runbook.demo/db-connection-pool" {
+		t.Errorf("unexpected link %+v", link)
+	}
+
+	if _, err := prov.RunbookPlan(ctx, "al-010"); err == nil || !strings.Contains(err.Error(), "not_found") {
+		t.Errorf("al-010 has no plan, got %v", err)
+	}
+	if _, err := prov.RunbookPlan(ctx, "al-missing"); err == nil || !strings.Contains(err.Error(), "not_found") {
+		t.Errorf("missing alert, got %v", err)
+	}
+
+	plans, err := orchestrationmock.New(map[string]any{})
+	if err != nil {
+		t.Fatalf("orchestrationmock.New: %v", err)
+	}
+	for slug, plan := range runbookPlans {
+		if got, ok := planForRunbook("https://runbook.demo/runbooks/" + slug); !ok || got != plan {
+			t.Errorf("planForRunbook(%s) = %q, want %q", slug, got, plan)
+		}
+		if _, err := plans.GetPlan(ctx, plan); err != nil {
+			t.Errorf("runbook %s links to unknown plan %s: %v", slug, plan, err)
+		}
+	}
+}
+
+func TestFireAlert(t *testing.T) {
+	provAny, err := New(nil)
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	prov := provAny.(*Provider)
+	ctx := context.Background()
+
+	if _, err := prov.Fire(ctx, FireRequest{Rule: "no-such-rule", Service: "svc-order"}); err == nil || !strings.Contains(err.Error(), "not_found") {
+		t.Fatalf("expected not_found for an unknown rule, got %v", err)
+	}
+	if _, err := prov.Fire(ctx, FireRequest{Service: "svc-order"}); err == nil || !strings.Contains(err.Error(), "bad_request") {
+		t.Fatalf("expected bad_request without a title or rule, got %v", err)
+	}
+
+	for service, want := range map[string]string{"svc-checkout": "critical", "svc-search": "error", "svc-analytics": "warning"} {
+		al, err := prov.Fire(ctx, FireRequest{Title: "Manual page", Service: service})
+		if err != nil || al.Severity != want {
+			t.Fatalf("expected %s to default to %s, got %q (%v)", service, want, al.Severity, err)
+		}
+	}
+
+	al, err := prov.Fire(ctx, FireRequest{Rule: "connection-pool", Service: "order"})
+	if err != nil {
+		t.Fatalf("Fire returned error: %v", err)
+	}
+	if al.Service != "svc-order" || al.Status != "firing" || al.Severity != "critical" {
+		t.Fatalf("unexpected fired alert %+v", al)
+	}
+	if al.Fields["team"] != mockutil.GetTeamForService("svc-order") || al.Fields["region"] != mockutil.ServiceRegion("svc-order") || al.Fields["environment"] != "prod" {
+		t.Fatalf("expected topology fields, got %+v", al.Fields)
+	}
+	if al.Metadata["planId"] != "plan-playbook-001" {
+		t.Fatalf("expected the rule runbook to link its plan, got %v", al.Metadata["planId"])
+	}
+	if got, err := prov.Get(ctx, al.ID); err != nil || got.Title != al.Title {
+		t.Fatalf("Get(%s) = %+v, %v", al.ID, got, err)
+	}
+	found := false
+	for _, published := range mockutil.SnapshotAlerts() {
+		found = found || published.ID == al.ID
+	}
+	if !found {
+		t.Fatal("fired alert missing from the shared alert snapshot")
+	}
+
+	custom, err := prov.Fire(ctx, FireRequest{Service: "svc-search", Title: "Index build stuck", Severity: "info", Environment: "staging"})
+	if err != nil {
+		t.Fatalf("Fire returned error: %v", err)
+	}
+	if custom.ID == al.ID || custom.Fields["environment"] != "staging" || custom.Severity != "info" {
+		t.Fatalf("unexpected custom alert %+v", custom)
+	}
+}
+
+func TestServiceMapRenamesSeededAlerts(t *testing.T) {
+	provAny, err := New(map[string]any{"serviceMap": map[string]any{"svc-checkout": "payments-api", "svc-order": "orders-api"}})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	prov := provAny.(*Provider)
+	ctx := context.Background()
+
+	alerts, err := prov.Query(ctx, schema.AlertQuery{Scope: schema.QueryScope{Service: "payments-api"}})
+	if err != nil || len(alerts) == 0 {
+		t.Fatalf("expected alerts for payments-api, got %d, err %v", len(alerts), err)
+	}
+	leftover := regexp.MustCompile(`svc-checkout([^A-Za-z0-9_-]|$)`)
+	for _, al := range prov.Snapshot() {
+		raw, _ := json.Marshal(al)
+		if leftover.Match(raw) {
+			t.Fatalf("alert %s still mentions svc-checkout: %s", al.ID, raw)
+		}
+	}
+	failover, _ := prov.Get(ctx, "al-002")
+	affected, _ := failover.Fields["affectedServices"].([]string)
+	if len(affected) != 3 || affected[0] != "payments-api" || affected[2] != "svc-orders" {
+		t.Fatalf("expected whole names renamed in affectedServices, got %v", affected)
+	}
+	latency, _ := prov.Get(ctx, "al-001")
+	if latency.Service != "payments-api" || latency.Fields["team"] != "team-velocity" {
+		t.Fatalf("unexpected renamed alert %+v", latency)
+	}
+}
+
+func TestStatsCountsQueryResults(t *testing.T) {
+	provAny, err := New(map[string]any{})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	prov := provAny.(*Provider)
+	ctx := context.Background()
+
+	query := schema.AlertQuery{Severities: []string{"critical"}, Limit: 1}
+	stats, err := prov.Stats(ctx, query)
+	if err != nil {
+		t.Fatalf("Stats returned error: %v", err)
+	}
+	query.Limit = 0
+	alerts, err := prov.Query(ctx, query)
+	if err != nil {
+		t.Fatalf("Query returned error: %v", err)
+	}
+	if stats.Total != len(alerts) || stats.Total < 2 {
+		t.Fatalf("expected stats over all %d critical alerts regardless of limit, got %d", len(alerts), stats.Total)
+	}
+	if stats.BySeverity["critical"] != stats.Total {
+		t.Fatalf("expected only critical alerts counted, got %v", stats.BySeverity)
+	}
+	byService := 0
+	for _, n := range stats.ByService {
+		byService += n
+	}
+	if byService != stats.Total {
+		t.Fatalf("service counts add up to %d, want %d", byService, stats.Total)
+	}
+}
+
+func TestAlertRulesListThresholds(t *testing.T) {
+	for _, rule := range AlertRules() {
+		if rule.Thresholds == nil || rule.Thresholds.Rule != rule.Name {
+			t.Fatalf("rule %s has no threshold lines: %+v", rule.Name, rule.Thresholds)
+		}
+	}
+	latency := alertRules["high-latency"]
+	th, _ := mockutil.AlertThresholdForRule(latency.Name)
+	if latency.Threshold != fmt.Sprintf("%gs", th.Critical) {
+		t.Fatalf("expected the critical line to match the rule threshold %s, got %v", latency.Threshold, th.Critical)
+	}
+}
+
+func TestLocaleTranslatesSeededAlerts(t *testing.T) {
+	provAny, err := New(map[string]any{"locale": "de-DE"})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	al, err := provAny.(*Provider).Get(context.Background(), "al-001")
+	if err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+	if al.Title != "Checkout-Latenz-SLO verletzt" {
+		t.Fatalf("expected German title, got %q", al.Title)
+	}
+	if !strings.Contains(al.Description, "Transaktionen") {
+		t.Fatalf("expected German description, got %q", al.Description)
+	}
+}
+
+func TestLifecycleDisabled(t *testing.T) {
+	provAny, err := New(map[string]any{"lifecycle": false})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	prov := provAny.(*Provider)
+
+	prov.mu.Lock()
+	state := prov.alerts["al-001"]
+	seeded := state.Status
+	state.CreatedAt = time.Now().UTC().Add(-2 * time.Hour)
+	prov.alerts["al-001"] = state
+	prov.lifecycle["al-001"].applied = 0
+	prov.mu.Unlock()
+
+	got, err := prov.Get(context.Background(), "al-001")
+	if err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+	if got.Status != seeded {
+		t.Fatalf("expected %s to stay %s with lifecycle off, got %s", got.ID, seeded, got.Status)
+	}
+}
+
+func TestMinimalSeed(t *testing.T) {
+	provAny, err := New(map[string]any{"minimal": true})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	prov := provAny.(*Provider)
+
+	alerts, err := prov.Query(context.Background(), schema.AlertQuery{})
+	if err != nil {
+		t.Fatalf("Query returned error: %v", err)
+	}
+	for _, al := range alerts {
+		if isScenarioAlert(al.Metadata, al.Fields) {
+			t.Fatalf("expected no scenario alerts with scenarioEffects off, got %s", al.ID)
+		}
+	}
+	got, err := prov.Get(context.Background(), "al-001")
+	if err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+	for _, key := range []string{"runbook", "channel"} {
+		if _, ok := got.Metadata[key]; ok {
+			t.Fatalf("expected no %s metadata with flair off, got %v", key, got.Metadata[key])
+		}
+	}
+}
+
+func TestResolutionETANarrows(t *testing.T) {
+	provAny, err := New(map[string]any{})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	prov := provAny.(*Provider)
+	ctx := context.Background()
+
+	// al-009 is scripted to resolve 95 minutes after it fires.
+	type reading struct {
+		miss, width time.Duration
+		confidence  float64
+		refresh     int
+	}
+	read := func(createdAgo time.Duration) reading {
+		t.Helper()
+		prov.mu.Lock()
+		state := prov.alerts["al-009"]
+		state.Status = "firing"
+		state.CreatedAt = time.Now().UTC().Add(-createdAgo)
+		prov.alerts["al-009"] = state
+		prov.lifecycle["al-009"].applied = 0
+		prov.mu.Unlock()
+
+		got, err := prov.Get(ctx, "al-009")
+		if err != nil {
+			t.Fatalf("Get returned error: %v", err)
+		}
+		eta, ok := got.Fields["predictedResolve"].(map[string]any)
+		if !ok {
+			t.Fatalf("expected an ETA on %s (%s)", got.ID, got.Status)
+		}
+		predicted, _ := time.Parse(time.RFC3339, got.Fields["predictedResolveAt"].(string))
+		earliest, _ := time.Parse(time.RFC3339, eta["earliest"].(string))
+		latest, _ := time.Parse(time.RFC3339, eta["latest"].(string))
+		if predicted.Before(earliest) || predicted.After(latest) {
+			t.Fatalf("expected %s within [%s, %s]", predicted, earliest, latest)
+		}
+		miss := predicted.Sub(got.CreatedAt.Add(95 * time.Minute))
+		if miss < 0 {
+			miss = -miss
+		}
+		return reading{miss: miss, width: latest.Sub(earliest), confidence: eta["confidence"].(float64), refresh: eta["refreshAfterSeconds"].(int)}
+	}
+
+	early, late := read(5*time.Minute), read(80*time.Minute)
+	if late.width >= early.width || late.confidence <= early.confidence {
+		t.Fatalf("expected the ETA window to narrow, got %+v then %+v", early, late)
+	}
+	if late.miss > early.miss {
+		t.Fatalf("expected the prediction to converge, missed by %s then %s", early.miss, late.miss)
+	}
+	if late.refresh >= early.refresh {
+		t.Fatalf("expected shorter refresh intervals near the ETA, got %ds then %ds", early.refresh, late.refresh)
+	}
+
+	// Alerts with no resolve step ahead, or whose lifecycle an acknowledgement
+	// took over, carry no ETA.
+	prov.mu.Lock()
+	state := prov.alerts["al-001"]
+	state.Status = "firing"
+	state.CreatedAt = time.Now().UTC()
+	prov.alerts["al-001"] = state
+	prov.lifecycle["al-001"].applied = 0
+	prov.mu.Unlock()
+	if _, err := prov.Acknowledge(ctx, "al-001", "sre-alex"); err != nil {
+		t.Fatalf("Acknowledge returned error: %v", err)
+	}
+	for _, id := range []string{"al-001", "al-004"} {
+		got, err := prov.Get(ctx, id)
+		if err != nil {
+			t.Fatalf("Get returned error: %v", err)
+		}
+		if _, ok := got.Fields["predictedResolveAt"]; ok {
+			t.Fatalf("expected no ETA on %s (%s)", id, got.Status)
+		}
+	}
+}
+
+// BenchmarkQuery lists 1k, 10k, and 100k generated alerts and encodes them
+// the way a plugin returns them.
+func BenchmarkQuery(b *testing.B) {
+	for _, n := range []int{1000, 10000, 100000} {
+		b.Run(fmt.Sprintf("alerts=%d", n), func(b *testing.B) {
+			prov, err := New(map[string]any{"generator": map[string]any{"count": n}})
+			if err != nil {
+				b.Fatalf("New returned error: %v", err)
+			}
+			ctx := context.Background()
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				out, err := prov.Query(ctx, schema.AlertQuery{})
+				if err != nil {
+					b.Fatalf("Query returned error: %v", err)
+				}
+				if _, err := json.Marshal(pluginrpc.Response{Result: out}); err != nil {
+					b.Fatalf("marshal: %v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestAcknowledge(t *testing.T) {
+	provAny, err := New(nil)
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	prov := provAny.(*Provider)
+	ctx := context.Background()
+
+	fired, err := prov.Fire(ctx, FireRequest{Rule: "high-latency", Service: "svc-checkout"})
+	if err != nil {
+		t.Fatalf("Fire returned error: %v", err)
+	}
+	if _, err := prov.Acknowledge(ctx, fired.ID, " "); err == nil || !strings.Contains(err.Error(), "bad_request") {
+		t.Fatalf("expected bad_request without an actor, got %v", err)
+	}
+	if _, err := prov.Acknowledge(ctx, "al-missing", "sam"); err == nil || !strings.Contains(err.Error(), "not_found") {
+		t.Fatalf("expected not_found for an unknown alert, got %v", err)
+	}
+
+	al, err := prov.Acknowledge(ctx, fired.ID, "sam")
+	if err != nil {
+		t.Fatalf("Acknowledge returned error: %v", err)
+	}
+	if al.Status != "acknowledged" || al.Fields["acknowledgedBy"] != "sam" || al.Fields["acknowledgedAt"] == nil {
+		t.Fatalf("unexpected acknowledged alert %+v", al)
+	}
+	if got, _ := prov.Get(ctx, fired.ID); got.Status != "acknowledged" {
+		t.Fatalf("expected the acknowledgement to stick, got %q", got.Status)
+	}
+	if _, err := prov.Acknowledge(ctx, fired.ID, "sam"); err == nil || !strings.Contains(err.Error(), "bad_request") {
+		t.Fatalf("expected bad_request acknowledging twice, got %v", err)
+	}
+
+	// A lifecycle alert stops advancing once someone acknowledges it.
+	if _, err := prov.Acknowledge(ctx, "al-001", "alex"); err != nil {
+		t.Fatalf("Acknowledge(al-001) returned error: %v", err)
+	}
+	prov.mu.Lock()
+	_, tracked := prov.lifecycle["al-001"]
+	prov.mu.Unlock()
+	if tracked {
+		t.Fatal("expected the acknowledged alert's lifecycle to be dropped")
+	}
+}
+
+func TestSimulateOutage(t *testing.T) {
+	provAny, err := New(nil)
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	prov := provAny.(*Provider)
+	ctx := context.Background()
+
+	result, err := prov.SimulateOutage(ctx, OutageRequest{Region: "euw1"})
+	if err != nil {
+		t.Fatalf("SimulateOutage returned error: %v", err)
+	}
+	if result.Component != "region:eu-west-1" || result.PlanID != "plan-complex-006" || len(result.Alerts) == 0 {
+		t.Fatalf("unexpected outage %+v", result)
+	}
+	// No incident provider is wired in.
+	if result.IncidentID != "" {
+		t.Fatalf("expected no incident without an incident provider, got %q", result.IncidentID)
+	}
+	for i, al := range result.Alerts {
+		impact := result.Services[i]
+		if al.Fields[mockutil.CorrelationKey] != result.CorrelationID || al.Metadata["planId"] != "plan-complex-006" {
+			t.Fatalf("expected every alert to share the outage chain and plan, got %+v", al)
+		}
+		if impact.Depth == 0 && (mockutil.ServiceRegion(al.Service) != "eu-west-1" || al.Severity != "critical") {
+			t.Fatalf("expected critical alerts inside the region, got %s %s", al.Service, al.Severity)
+		}
+		if impact.Depth == 1 && al.Severity != "warning" {
+			t.Fatalf("expected warnings on callers, got %s %s", al.Service, al.Severity)
+		}
+	}
+	// The alerts join the shared snapshot metricmock shapes series from.
+	shared := map[string]bool{}
+	for _, al := range mockutil.SnapshotAlerts() {
+		shared[al.ID] = true
+	}
+	if !shared[result.Alerts[0].ID] {
+		t.Fatalf("expected %s in the shared snapshot", result.Alerts[0].ID)
+	}
+
+	zone, err := prov.SimulateOutage(ctx, OutageRequest{AZ: "eu-west-1a"})
+	if err != nil {
+		t.Fatalf("SimulateOutage(az) returned error: %v", err)
+	}
+	if zone.PlanID != "" || zone.Alerts[0].Severity != "error" {
+		t.Fatalf("expected a zone outage to degrade without evacuating, got %+v", zone)
+	}
+
+	if _, err := prov.SimulateOutage(ctx, OutageRequest{Region: "mars-1"}); err == nil || !strings.Contains(err.Error(), "not_found") {
+		t.Fatalf("expected not_found for an unknown region, got %v", err)
+	}
+	if _, err := prov.SimulateOutage(ctx, OutageRequest{}); err == nil || !strings.Contains(err.Error(), "bad_request") {
+		t.Fatalf("expected bad_request without a failure domain, got %v", err)
+	}
+}
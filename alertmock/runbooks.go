@@ -0,0 +1,123 @@
+package alertmock
+
+import (
+	"context"
+	"strings"
+
+	"github.com/opsorch/opsorch-core/orcherr"
+	"github.com/opsorch/opsorch-core/schema"
+	"github.com/opsorch/opsorch-mock-adapters/internal/mockutil"
+)
+
+// runbookBaseURL prefixes the runbook links alerts and plans share.
+const runbookBaseURL = "https://runbook.demo/"
+
+// runbookPlans maps runbook slugs (the path after runbookBaseURL) to the
+// orchestrationmock plan that automates the same procedure. Each plan's own
+// runbook_url tag is listed, plus the older slugs alerts still link to.
+var runbookPlans = map[string]string{
+	"db-connection-pool":    "plan-playbook-001",
+	"db-connections":        "plan-playbook-001",
+	"high-latency":          "plan-playbook-002",
+	"checkout-latency":      "plan-playbook-002",
+	"service-degradation":   "plan-playbook-003",
+	"payment-outage":        "plan-playbook-003",
+	"security-incident":     "plan-playbook-004",
+	"analytics-correlation": "plan-playbook-005",
+	"db-failover":           "plan-runbook-001",
+	"cert-rotation":         "plan-runbook-002",
+	"cert-renewal":          "plan-runbook-002",
+	"cache-flush":           "plan-runbook-003",
+	"cache-degradation":     "plan-runbook-003",
+	"pod-restart":           "plan-runbook-004",
+	"rate-limits":           "plan-runbook-005",
+	"catalog-sync":          "plan-runbook-006",
+	"payment-latency":       "plan-runbook-007",
+	"dr/region-evacuation":  "plan-complex-006",
+}
+
+// RunbookLink is the orchestration plan behind an alert's runbook.
+type RunbookLink struct {
+	AlertID string `json:"alertId"`
+	Runbook string `json:"runbook"`
+	PlanID  string `json:"planId"`
+	// Ref is the plan as a canonical reference, e.g. "plan:plan-runbook-001".
+	Ref string `json:"ref"`
+}
+
+// planForRunbook returns the plan automating the runbook at url, accepting
+// both the short form and the /runbooks/ and /playbooks/ paths plans use.
+func planForRunbook(url string) (string, bool) {
+	slug, ok := strings.CutPrefix(url, runbookBaseURL)
+	if !ok {
+		return "", false
+	}
+	slug = strings.TrimPrefix(strings.TrimPrefix(slug, "runbooks/"), "playbooks/")
+	plan, ok := runbookPlans[strings.Trim(slug, "/")]
+	return plan, ok
+}
+
+// alertRunbooks returns the runbook URLs an alert mentions: its runbook
+// metadata first, then any runbook links.
+func alertRunbooks(al schema.Alert) []string {
+	var urls []string
+	if runbook, ok := al.Metadata["runbook"].(string); ok && runbook != "" {
+		urls = append(urls, runbook)
+	}
+	for _, link := range stringList(al.Metadata["links"]) {
+		if strings.HasPrefix(link, runbookBaseURL) {
+			urls = append(urls, link)
+		}
+	}
+	return urls
+}
+
+// linkRunbookPlan sets Metadata["planId"] (and the matching plan ref) when
+// one of the alert's runbooks has an orchestration plan.
+func linkRunbookPlan(al *schema.Alert) {
+	for _, url := range alertRunbooks(*al) {
+		if plan, ok := planForRunbook(url); ok {
+			if al.Metadata == nil {
+				al.Metadata = map[string]any{}
+			}
+			al.Metadata["planId"] = plan
+			mockutil.LinkRefs(al.Metadata, al.Fields)
+			return
+		}
+	}
+}
+
+// RunbookPlan resolves the alert's runbook to the orchestration plan that runs
+// it, so a "run the linked runbook" action can start the plan directly.
+func (p *Provider) RunbookPlan(ctx context.Context, alertID string) (RunbookLink, error) {
+	al, err := p.Get(ctx, alertID)
+	if err != nil {
+		return RunbookLink{}, err
+	}
+	urls := alertRunbooks(al)
+	for _, url := range urls {
+		if plan, ok := planForRunbook(url); ok {
+			return RunbookLink{AlertID: al.ID, Runbook: url, PlanID: plan, Ref: mockutil.FormatRef(mockutil.RefPlan, plan)}, nil
+		}
+	}
+	if len(urls) == 0 {
+		return RunbookLink{}, orcherr.New("not_found", "alert has no runbook", nil)
+	}
+	return RunbookLink{}, orcherr.New("not_found", "no orchestration plan for runbook "+urls[0], nil)
+}
+
+func stringList(v any) []string {
+	switch v := v.(type) {
+	case []string:
+		return v
+	case []any:
+		out := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				out = append(out, s)
+			}
+		}
+		return out
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package alertmock
+
+import (
+	"sort"
+	"time"
+
+	"github.com/opsorch/opsorch-core/schema"
+	"github.com/opsorch/opsorch-mock-adapters/internal/bundle"
+)
+
+// Snapshot returns the provider's alerts sorted by ID for export. Noise alerts
+// are left out because they are regenerated from the clock.
+func (p *Provider) Snapshot() []schema.Alert {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.refreshLifecycleLocked(time.Now().UTC())
+	out := make([]schema.Alert, 0, len(p.alerts))
+	for _, al := range p.alerts {
+		if noise, _ := al.Metadata["noise"].(bool); noise {
+			continue
+		}
+		out = append(out, cloneAlert(al))
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return out
+}
+
+// Restore replaces the provider's alerts with a bundle's while it is
+// running, as the snapshot config does at startup. Sections the bundle leaves
+// empty keep their current records.
+func (p *Provider) Restore(b *bundle.Bundle) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.restore(b)
+}
+
+// restore replaces the seeded alerts with a bundle's.
+func (p *Provider) restore(b *bundle.Bundle) {
+	if b == nil || len(b.Alerts) == 0 {
+		return
+	}
+	p.alerts = make(map[string]schema.Alert, len(b.Alerts))
+	for _, al := range b.Alerts {
+		p.alerts[al.ID] = cloneAlert(al)
+	}
+	// Restored alerts are authoritative; replaying seeded lifecycles would
+	// overwrite the state the bundle captured.
+	p.lifecycle = map[string]*alertLifecycle{}
+	p.publishLocked()
+}
@@ -0,0 +1,28 @@
+package alertmock
+
+import (
+	"context"
+
+	"github.com/opsorch/opsorch-core/schema"
+	"github.com/opsorch/opsorch-mock-adapters/internal/mockutil"
+)
+
+// Stats counts the alerts Query matches, ignoring query.Limit, by status,
+// severity, service, and team.
+func (p *Provider) Stats(ctx context.Context, query schema.AlertQuery) (mockutil.Counts, error) {
+	query.Limit = 0
+	alerts, err := p.Query(ctx, query)
+	if err != nil {
+		return mockutil.Counts{}, err
+	}
+	counts := mockutil.NewCounts()
+	for _, al := range alerts {
+		service := al.Service
+		if service == "" {
+			service, _ = al.Fields["service"].(string)
+		}
+		team, _ := al.Fields["team"].(string)
+		counts.Add(al.Status, al.Severity, service, team)
+	}
+	return counts, nil
+}
@@ -0,0 +1,154 @@
+package auditmock
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/opsorch/opsorch-core/orcherr"
+	"github.com/opsorch/opsorch-mock-adapters/internal/mockutil"
+)
+
+// ProviderName identifies the mock audit provider.
+const ProviderName = "mock"
+
+// Event categories.
+const (
+	CategoryConfig     = "config"
+	CategoryPermission = "permission"
+	CategoryConsole    = "console"
+)
+
+// defaultQueryWindow bounds queries that do not set a start time.
+const defaultQueryWindow = 24 * time.Hour
+
+// Config controls mock audit data.
+type Config struct {
+	Source string
+}
+
+// Provider serves seeded change and audit events. Scenario-linked events land
+// shortly before the matching scenario incident so "what changed before this
+// started?" questions have an answer.
+type Provider struct {
+	cfg    Config
+	events []Event
+}
+
+// Event is one audited change or action.
+type Event struct {
+	ID        string         `json:"id"`
+	Time      time.Time      `json:"time"`
+	Actor     string         `json:"actor"`
+	ActorType string         `json:"actorType"`
+	Category  string         `json:"category"`
+	Action    string         `json:"action"`
+	Service   string         `json:"service,omitempty"`
+	Resource  string         `json:"resource"`
+	Summary   string         `json:"summary"`
+	Changes   []Change       `json:"changes,omitempty"`
+	Origin    string         `json:"origin"`
+	Metadata  map[string]any `json:"metadata,omitempty"`
+}
+
+// Change is one field changed by an event.
+type Change struct {
+	Field  string `json:"field"`
+	Before string `json:"before,omitempty"`
+	After  string `json:"after,omitempty"`
+}
+
+// Query filters audit events. Empty fields match everything; Start defaults to
+// 24 hours before End, and End defaults to now.
+type Query struct {
+	Start      time.Time `json:"start,omitempty"`
+	End        time.Time `json:"end,omitempty"`
+	Actor      string    `json:"actor,omitempty"`
+	Service    string    `json:"service,omitempty"`
+	Category   string    `json:"category,omitempty"`
+	Action     string    `json:"action,omitempty"`
+	IncidentID string    `json:"incidentId,omitempty"`
+	Limit      int       `json:"limit,omitempty"`
+}
+
+// New constructs the mock audit provider.
+func New(cfg map[string]any) (*Provider, error) {
+	p := &Provider{cfg: parseConfig(cfg)}
+	p.events = seedEvents(time.Now().UTC(), p.cfg.Source)
+	return p, nil
+}
+
+// Query returns matching events, newest first. Filtering by IncidentID returns
+// the events correlated with that scenario incident regardless of time window.
+func (p *Provider) Query(ctx context.Context, query Query) ([]Event, error) {
+	_ = ctx
+
+	end := query.End
+	if end.IsZero() {
+		end = time.Now().UTC()
+	}
+	start := query.Start
+	if start.IsZero() {
+		start = end.Add(-defaultQueryWindow)
+	}
+	if start.After(end) {
+		return nil, orcherr.New("bad_request", "start must be before end", nil)
+	}
+
+	out := make([]Event, 0)
+	for _, ev := range p.events {
+		if query.IncidentID != "" {
+			if id, _ := ev.Metadata["incident_id"].(string); id != query.IncidentID {
+				continue
+			}
+		} else if ev.Time.Before(start) || ev.Time.After(end) {
+			continue
+		}
+		if query.Actor != "" && !strings.EqualFold(ev.Actor, query.Actor) {
+			continue
+		}
+		if query.Service != "" && ev.Service != query.Service {
+			continue
+		}
+		if query.Category != "" && ev.Category != query.Category {
+			continue
+		}
+		if query.Action != "" && ev.Action != query.Action {
+			continue
+		}
+		out = append(out, cloneEvent(ev))
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Time.After(out[j].Time) })
+	if query.Limit > 0 && len(out) > query.Limit {
+		out = out[:query.Limit]
+	}
+	return out, nil
+}
+
+// Get returns a single audit event by ID.
+func (p *Provider) Get(ctx context.Context, id string) (Event, error) {
+	_ = ctx
+
+	for _, ev := range p.events {
+		if ev.ID == id {
+			return cloneEvent(ev), nil
+		}
+	}
+	return Event{}, orcherr.New("not_found", "audit event not found", nil)
+}
+
+func cloneEvent(in Event) Event {
+	out := in
+	out.Changes = append([]Change(nil), in.Changes...)
+	out.Metadata = mockutil.CloneMap(in.Metadata)
+	return out
+}
+
+func parseConfig(cfg map[string]any) Config {
+	out := Config{Source: "mock"}
+	if v, ok := cfg["source"].(string); ok && v != "" {
+		out.Source = v
+	}
+	return out
+}
@@ -0,0 +1,126 @@
+package auditmock
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/opsorch/opsorch-mock-adapters/internal/pluginrpc"
+)
+
+func TestQueryCorrelatesScenarioIncidents(t *testing.T) {
+	prov, err := New(map[string]any{"source": "test"})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+
+	events, err := prov.Query(context.Background(), Query{IncidentID: "inc-scenario-002"})
+	if err != nil {
+		t.Fatalf("Query returned error: %v", err)
+	}
+	if len(events) != 1 {
+		t.Fatalf("expected one correlated event, got %d", len(events))
+	}
+	ev := events[0]
+	if ev.Service != "svc-database" || ev.Metadata["minutes_before_incident"] != 10 {
+		t.Fatalf("unexpected correlated event: %+v", ev)
+	}
+	if ev.Metadata["source"] != "test" {
+		t.Fatalf("expected source metadata, got %v", ev.Metadata)
+	}
+	incidentAt := time.Now().UTC().Add(-30 * time.Minute)
+	if lead := incidentAt.Sub(ev.Time); lead < 9*time.Minute || lead > 11*time.Minute {
+		t.Fatalf("expected change ~10 minutes before the incident, got %s", lead)
+	}
+}
+
+func TestQueryFiltersByTimeActorAndCategory(t *testing.T) {
+	prov, _ := New(nil)
+
+	recent, _ := prov.Query(context.Background(), Query{Start: time.Now().Add(-2 * time.Hour)})
+	if len(recent) == 0 {
+		t.Fatalf("expected recent scenario changes")
+	}
+	for i, ev := range recent {
+		if ev.Metadata["is_scenario"] != true {
+			t.Fatalf("expected only scenario changes in the last two hours, got %+v", ev)
+		}
+		if i > 0 && ev.Time.After(recent[i-1].Time) {
+			t.Fatalf("expected newest first")
+		}
+	}
+
+	week := time.Now().Add(-8 * 24 * time.Hour)
+	grants, _ := prov.Query(context.Background(), Query{Start: week, Category: CategoryPermission})
+	if len(grants) == 0 {
+		t.Fatalf("expected seeded permission events")
+	}
+	for _, ev := range grants {
+		if ev.Category != CategoryPermission || !strings.HasPrefix(ev.Action, "iam.") {
+			t.Fatalf("category filter ignored: %+v", ev)
+		}
+	}
+
+	byActor, _ := prov.Query(context.Background(), Query{Start: week, Actor: "devon"})
+	for _, ev := range byActor {
+		if ev.Actor != "devon" {
+			t.Fatalf("actor filter ignored: %+v", ev)
+		}
+	}
+
+	if _, err := prov.Query(context.Background(), Query{Start: time.Now(), End: time.Now().Add(-time.Hour)}); err == nil {
+		t.Fatalf("expected error for inverted range")
+	}
+}
+
+func TestGet(t *testing.T) {
+	prov, _ := New(nil)
+
+	events, _ := prov.Query(context.Background(), Query{IncidentID: "inc-scenario-004"})
+	if len(events) != 1 {
+		t.Fatalf("expected one event, got %d", len(events))
+	}
+	got, err := prov.Get(context.Background(), events[0].ID)
+	if err != nil || got.Action != "secret.rotate" {
+		t.Fatalf("Get returned %+v, %v", got, err)
+	}
+	if _, err := prov.Get(context.Background(), "audit-missing"); err == nil || !strings.Contains(err.Error(), "not_found") {
+		t.Fatalf("expected not_found, got %v", err)
+	}
+}
+
+// BenchmarkQuery lists 1k, 10k, and 100k audit events cloned from the seed
+// and encodes them the way a plugin returns them.
+func BenchmarkQuery(b *testing.B) {
+	for _, n := range []int{1000, 10000, 100000} {
+		b.Run(fmt.Sprintf("events=%d", n), func(b *testing.B) {
+			prov, _ := New(nil)
+			now := time.Now().UTC()
+			seeded := prov.events
+			events := make([]Event, 0, n)
+			for i := 0; i < n; i++ {
+				ev := cloneEvent(seeded[i%len(seeded)])
+				ev.ID = fmt.Sprintf("audit-bench-%06d", i)
+				ev.Time = now.Add(-time.Duration(i) * time.Second)
+				events = append(events, ev)
+			}
+			prov.events = events
+			ctx := context.Background()
+			query := Query{Start: now.Add(-time.Duration(n+1) * time.Second), End: now}
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				out, err := prov.Query(ctx, query)
+				if err != nil {
+					b.Fatalf("Query returned error: %v", err)
+				}
+				if _, err := json.Marshal(pluginrpc.Response{Result: out}); err != nil {
+					b.Fatalf("marshal: %v", err)
+				}
+			}
+		})
+	}
+}
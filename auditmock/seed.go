@@ -0,0 +1,177 @@
+package auditmock
+
+import (
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/opsorch/opsorch-mock-adapters/internal/mockutil"
+)
+
+// scenarioLeadTime is how long before each scenario incident its triggering change lands.
+const scenarioLeadTime = 10 * time.Minute
+
+// scenarioChange ties a seeded change to the scenario incident it precedes.
+// incidentAgo mirrors the incident's CreatedAt offset in incidentmock.
+type scenarioChange struct {
+	incidentID   string
+	scenarioID   string
+	scenarioName string
+	incidentAgo  time.Duration
+	event        Event
+}
+
+var scenarioChanges = []scenarioChange{
+	{
+		incidentID: "inc-scenario-001", scenarioID: "slo-exhaustion", scenarioName: "SLO Budget Exhaustion",
+		incidentAgo: 45 * time.Minute,
+		event: Event{
+			Actor: "jordan", ActorType: "user", Category: CategoryConfig, Action: "config.update",
+			Service: "svc-checkout", Resource: "configmap/checkout-api",
+			Summary: "Lowered checkout upstream timeout",
+			Changes: []Change{{Field: "upstream.timeoutMs", Before: "2000", After: "800"}},
+			Origin:  "console",
+		},
+	},
+	{
+		incidentID: "inc-scenario-002", scenarioID: "cascading-failure", scenarioName: "Cascading Failure",
+		incidentAgo: 30 * time.Minute,
+		event: Event{
+			Actor: "terraform-ci", ActorType: "service_account", Category: CategoryConfig, Action: "config.update",
+			Service: "svc-database", Resource: "rds/orders-primary/parameter-group",
+			Summary: "Reduced max_connections on the primary parameter group",
+			Changes: []Change{{Field: "max_connections", Before: "500", After: "200"}},
+			Origin:  "terraform",
+		},
+	},
+	{
+		incidentID: "inc-scenario-003", scenarioID: "deployment-rollback", scenarioName: "Deployment Rollback",
+		incidentAgo: 90 * time.Minute,
+		event: Event{
+			Actor: "devon", ActorType: "user", Category: CategoryConfig, Action: "feature_flag.update",
+			Service: "svc-payments", Resource: "flag/payments.v2-retry-policy",
+			Summary: "Enabled v2 retry policy for all merchants",
+			Changes: []Change{{Field: "rollout", Before: "10%", After: "100%"}},
+			Origin:  "console",
+		},
+	},
+	{
+		incidentID: "inc-scenario-004", scenarioID: "external-dependency-failure", scenarioName: "External Dependency Failure - Stripe",
+		incidentAgo: 15 * time.Minute,
+		event: Event{
+			Actor: "secrets-rotator", ActorType: "service_account", Category: CategoryConfig, Action: "secret.rotate",
+			Service: "svc-checkout", Resource: "secret/stripe-api-key",
+			Summary: "Rotated Stripe API key",
+			Changes: []Change{{Field: "version", Before: "41", After: "42"}},
+			Origin:  "automation",
+		},
+	},
+	{
+		incidentID: "inc-scenario-005", scenarioID: "autoscaling-lag", scenarioName: "Autoscaling Lag",
+		incidentAgo: 12 * time.Minute,
+		event: Event{
+			Actor: "morgan", ActorType: "user", Category: CategoryConfig, Action: "quota.update",
+			Service: "svc-search", Resource: "resourcequota/search/prod-usw2",
+			Summary: "Tightened search namespace CPU quota during cost review",
+			Changes: []Change{{Field: "limits.cpu", Before: "8", After: "5"}},
+			Origin:  "cli",
+		},
+	},
+	{
+		incidentID: "inc-scenario-006", scenarioID: "circuit-breaker-cascade", scenarioName: "Circuit Breaker Cascade",
+		incidentAgo: 8 * time.Minute,
+		event: Event{
+			Actor: "casey", ActorType: "user", Category: CategoryConfig, Action: "config.update",
+			Service: "svc-recommendation", Resource: "configmap/recommendation-engine",
+			Summary: "Raised model candidate count",
+			Changes: []Change{{Field: "candidates.max", Before: "200", After: "1000"}},
+			Origin:  "console",
+		},
+	},
+}
+
+// backgroundActions are the routine events spread across every service.
+var backgroundActions = []struct {
+	category string
+	action   string
+	resource string
+	summary  string
+	origin   string
+}{
+	{CategoryConfig, "config.update", "configmap/%s", "Updated runtime configuration", "gitops"},
+	{CategoryConfig, "feature_flag.update", "flag/%s.experiment", "Adjusted experiment rollout", "console"},
+	{CategoryPermission, "iam.grant", "role/%s-operator", "Granted operator role", "console"},
+	{CategoryPermission, "iam.revoke", "role/%s-operator", "Revoked operator role", "console"},
+	{CategoryConsole, "console.login", "console/%s", "Signed in to cloud console", "console"},
+	{CategoryConsole, "console.exec", "pod/%s", "Opened shell in production pod", "cli"},
+	{CategoryConsole, "console.scale", "deployment/%s", "Manually scaled deployment", "console"},
+}
+
+var actors = []string{"alex", "jordan", "priya", "sam", "devon", "rosa", "morgan", "casey", "taylor", "riley"}
+
+// seedEvents builds scenario-correlated changes plus a week of deterministic
+// background activity per service.
+func seedEvents(now time.Time, source string) []Event {
+	out := make([]Event, 0, len(scenarioChanges)+len(mockutil.Services())*4)
+	for _, sc := range scenarioChanges {
+		ev := sc.event
+		ev.Time = now.Add(-sc.incidentAgo - scenarioLeadTime)
+		ev.Changes = append([]Change(nil), sc.event.Changes...)
+		ev.Metadata = map[string]any{
+			"incident_id":             sc.incidentID,
+			"scenario_id":             sc.scenarioID,
+			"scenario_name":           sc.scenarioName,
+			"minutes_before_incident": int(scenarioLeadTime / time.Minute),
+			"is_scenario":             true,
+		}
+		out = append(out, ev)
+	}
+
+	for _, service := range mockutil.Services() {
+		h := fnv.New32a()
+		h.Write([]byte(service))
+		seed := h.Sum32()
+		count := 2 + int(seed%3)
+		short := strings.TrimPrefix(service, "svc-")
+		for i := 0; i < count; i++ {
+			roll := seed>>uint(i*3) + uint32(i)*2654435761
+			action := backgroundActions[int(roll%uint32(len(backgroundActions)))]
+			actor := actors[int((roll>>4)%uint32(len(actors)))]
+			// Background events stay more than two hours old so they never crowd
+			// out the scenario changes in the default window.
+			ago := 2*time.Hour + time.Duration(roll%(7*24*60))*time.Minute
+			ev := Event{
+				Time:      now.Add(-ago).Truncate(time.Minute),
+				Actor:     actor,
+				ActorType: "user",
+				Category:  action.category,
+				Action:    action.action,
+				Service:   service,
+				Resource:  fmt.Sprintf(action.resource, short),
+				Summary:   action.summary,
+				Origin:    action.origin,
+				Metadata:  map[string]any{},
+			}
+			if action.origin == "gitops" {
+				ev.Actor, ev.ActorType = "argocd", "service_account"
+			}
+			if action.category == CategoryPermission {
+				ev.Changes = []Change{{Field: "grantee", After: actors[int((roll>>9)%uint32(len(actors)))]}}
+				if action.action == "iam.revoke" {
+					ev.Changes[0] = Change{Field: "grantee", Before: ev.Changes[0].After}
+				}
+			}
+			out = append(out, ev)
+		}
+	}
+
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Time.Before(out[j].Time) })
+	for i := range out {
+		out[i].ID = fmt.Sprintf("audit-%04d", i+1)
+		out[i].Metadata["team"] = mockutil.GetTeamForService(out[i].Service)
+		out[i].Metadata["source"] = source
+	}
+	return out
+}
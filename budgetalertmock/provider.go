@@ -0,0 +1,221 @@
+package budgetalertmock
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/opsorch/opsorch-core/schema"
+	"github.com/opsorch/opsorch-mock-adapters/internal/mockutil"
+)
+
+// AlertSource is the key cost alerts are published under in the shared alert store.
+const AlertSource = "budget"
+
+const (
+	// defaultThreshold is the fractional increase over baseline that raises an anomaly.
+	defaultThreshold = 0.3
+	// defaultBudgetWarning is the forecast share of monthly budget that raises an alert.
+	defaultBudgetWarning = 1.1
+	// baselineDays is how many days before today form the spend baseline.
+	baselineDays = 7
+)
+
+// Config controls cost anomaly detection.
+type Config struct {
+	Source        string
+	Threshold     float64
+	BudgetWarning float64
+}
+
+// Provider derives daily spend per service and turns spend anomalies and
+// budget overruns into alerts in the shared alert snapshot.
+type Provider struct {
+	cfg   Config
+	clock func() time.Time
+}
+
+// DailyCost is one day of spend.
+type DailyCost struct {
+	Date   time.Time `json:"date"`
+	Amount float64   `json:"amount"`
+}
+
+// ServiceSpend is a service's recent spend against its baseline and budget.
+type ServiceSpend struct {
+	Service       string      `json:"service"`
+	Team          string      `json:"team"`
+	CostType      string      `json:"costType"`
+	Currency      string      `json:"currency"`
+	Daily         []DailyCost `json:"daily"`
+	Baseline      float64     `json:"baseline"`
+	Today         float64     `json:"today"`
+	MonthlyBudget float64     `json:"monthlyBudget"`
+	MonthToDate   float64     `json:"monthToDate"`
+	Forecast      float64     `json:"forecast"`
+}
+
+// New constructs the provider and publishes its alerts.
+func New(cfg map[string]any) (*Provider, error) {
+	p := &Provider{cfg: parseConfig(cfg)}
+	p.publish()
+	return p, nil
+}
+
+func init() {
+	// Importing the package registers cost alerts as a lazy source: the alert
+	// provider recomputes them on each query with its "sources.budget" config.
+	mockutil.RegisterAlertSource(AlertSource, func(cfg map[string]any) []schema.Alert {
+		p := &Provider{cfg: parseConfig(cfg)}
+		return p.detect(p.now())
+	})
+}
+
+// Spend returns recent spend for one service, or for every service when empty.
+func (p *Provider) Spend(ctx context.Context, service string) ([]ServiceSpend, error) {
+	_ = ctx
+
+	today := p.now().Truncate(24 * time.Hour)
+	out := make([]ServiceSpend, 0)
+	for _, svc := range mockutil.Services() {
+		if service != "" && svc != service {
+			continue
+		}
+		out = append(out, serviceSpend(svc, today))
+	}
+	return out, nil
+}
+
+// Alerts recomputes cost alerts, republishes them, and returns them.
+func (p *Provider) Alerts(ctx context.Context) ([]schema.Alert, error) {
+	_ = ctx
+	return p.publish(), nil
+}
+
+func (p *Provider) publish() []schema.Alert {
+	alerts := p.detect(p.now())
+	mockutil.PublishSourceAlerts(AlertSource, alerts)
+	return mockutil.CloneAlerts(alerts)
+}
+
+// detect raises a spend anomaly when today's spend exceeds the baseline by the
+// threshold, and a budget alert when the month-end forecast passes the warning share.
+func (p *Provider) detect(now time.Time) []schema.Alert {
+	today := now.Truncate(24 * time.Hour)
+	out := make([]schema.Alert, 0)
+	for _, service := range mockutil.Services() {
+		spend := serviceSpend(service, today)
+		if spend.Baseline > 0 {
+			deviation := spend.Today/spend.Baseline - 1
+			if deviation >= p.cfg.Threshold {
+				out = append(out, p.anomalyAlert(spend, deviation, now))
+			}
+		}
+		if spend.MonthlyBudget > 0 && spend.Forecast >= spend.MonthlyBudget*p.cfg.BudgetWarning {
+			out = append(out, p.budgetAlert(spend, now))
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return out
+}
+
+func (p *Provider) anomalyAlert(spend ServiceSpend, deviation float64, now time.Time) schema.Alert {
+	severity := "info"
+	switch {
+	case deviation >= 1:
+		severity = "error"
+	case deviation >= 0.5:
+		severity = "warning"
+	}
+	pct := int(math.Round(deviation * 100))
+	overlay := spendOverlays[spend.Service]
+	description := fmt.Sprintf("%s %s spend is $%.0f today vs $%.0f 7-day baseline (+%d%%)", spend.Service, spend.CostType, spend.Today, spend.Baseline, pct)
+	if overlay.reason != "" {
+		description += ": " + overlay.reason
+	}
+	al := schema.Alert{
+		ID:          "cost-" + strings.TrimPrefix(spend.Service, "svc-") + "-anomaly",
+		Title:       fmt.Sprintf("Cost anomaly: %s %s spend up %d%%", spend.Service, spend.CostType, pct),
+		Description: description,
+		Status:      "firing",
+		Severity:    severity,
+		Service:     spend.Service,
+		CreatedAt:   now.Add(-overlay.since),
+		UpdatedAt:   now,
+		Fields: map[string]any{
+			"category":     mockutil.AlertCategoryCost,
+			"costType":     spend.CostType,
+			"currency":     spend.Currency,
+			"actual":       spend.Today,
+			"expected":     spend.Baseline,
+			"deviationPct": pct,
+			"environment":  "prod",
+			"team":         spend.Team,
+		},
+		Metadata: map[string]any{
+			"source":    p.cfg.Source,
+			"ruleId":    "cost-daily-anomaly",
+			"alertType": "cost_anomaly",
+			"channel":   "#finops",
+		},
+	}
+	if overlay.incidentID != "" {
+		al.Metadata["incident_id"] = overlay.incidentID
+	}
+	return al
+}
+
+func (p *Provider) budgetAlert(spend ServiceSpend, now time.Time) schema.Alert {
+	pct := int(math.Round(spend.Forecast / spend.MonthlyBudget * 100))
+	return schema.Alert{
+		ID:          "cost-" + strings.TrimPrefix(spend.Service, "svc-") + "-budget",
+		Title:       fmt.Sprintf("Budget forecast: %s at %d%% of monthly budget", spend.Service, pct),
+		Description: fmt.Sprintf("Month-end forecast $%.0f exceeds the $%.0f budget for %s", spend.Forecast, spend.MonthlyBudget, spend.Service),
+		Status:      "firing",
+		Severity:    "warning",
+		Service:     spend.Service,
+		CreatedAt:   now.Add(-6 * time.Hour),
+		UpdatedAt:   now,
+		Fields: map[string]any{
+			"category":      mockutil.AlertCategoryCost,
+			"costType":      spend.CostType,
+			"currency":      spend.Currency,
+			"monthToDate":   spend.MonthToDate,
+			"forecast":      spend.Forecast,
+			"monthlyBudget": spend.MonthlyBudget,
+			"forecastPct":   pct,
+			"environment":   "prod",
+			"team":          spend.Team,
+		},
+		Metadata: map[string]any{
+			"source":    p.cfg.Source,
+			"ruleId":    "cost-budget-forecast",
+			"alertType": "budget_forecast",
+			"channel":   "#finops",
+		},
+	}
+}
+
+func (p *Provider) now() time.Time {
+	if p.clock != nil {
+		return p.clock().UTC()
+	}
+	return time.Now().UTC()
+}
+
+func parseConfig(cfg map[string]any) Config {
+	out := Config{Source: "mock-budget", Threshold: defaultThreshold, BudgetWarning: defaultBudgetWarning}
+	if v, ok := cfg["source"].(string); ok && v != "" {
+		out.Source = v
+	}
+	if v, ok := cfg["threshold"].(float64); ok && v > 0 {
+		out.Threshold = v
+	}
+	if v, ok := cfg["budgetWarning"].(float64); ok && v > 0 {
+		out.BudgetWarning = v
+	}
+	return out
+}
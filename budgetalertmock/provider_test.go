@@ -0,0 +1,102 @@
+package budgetalertmock
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/opsorch/opsorch-mock-adapters/internal/mockutil"
+)
+
+// TestImportRegistersLazySource runs first, before any New publishes.
+func TestImportRegistersLazySource(t *testing.T) {
+	for _, al := range mockutil.SourceAlerts() {
+		if mockutil.IsCostAlert(al) {
+			t.Fatalf("importing the package published %s", al.ID)
+		}
+	}
+
+	costAlerts := func(configs map[string]any) []string {
+		var ids []string
+		for _, al := range mockutil.SourceAlertsFor(configs) {
+			if mockutil.IsCostAlert(al) {
+				ids = append(ids, al.ID)
+			}
+		}
+		return ids
+	}
+	if ids := costAlerts(nil); len(ids) == 0 {
+		t.Fatal("expected cost alerts from the default config")
+	}
+	quiet := map[string]any{AlertSource: map[string]any{"threshold": 100.0, "budgetWarning": 100.0}}
+	if ids := costAlerts(quiet); len(ids) != 0 {
+		t.Fatalf("expected the alert provider's budget config to apply, got %v", ids)
+	}
+}
+
+func TestAlertsPublishCostAnomaliesToSnapshot(t *testing.T) {
+	prov, err := New(map[string]any{"source": "test"})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	prov.clock = func() time.Time { return time.Date(2026, 3, 18, 12, 0, 0, 0, time.UTC) }
+
+	alerts, err := prov.Alerts(context.Background())
+	if err != nil {
+		t.Fatalf("Alerts returned error: %v", err)
+	}
+	byID := map[string]bool{}
+	for _, al := range alerts {
+		byID[al.ID] = true
+		if !mockutil.IsCostAlert(al) || al.Metadata["source"] != "test" {
+			t.Fatalf("expected cost category and source, got %+v", al)
+		}
+	}
+	for _, want := range []string{"cost-search-anomaly", "cost-analytics-anomaly", "cost-logging-anomaly", "cost-warehouse-budget"} {
+		if !byID[want] {
+			t.Fatalf("expected %s, got %v", want, byID)
+		}
+	}
+	if len(alerts) != 4 {
+		t.Fatalf("expected only the seeded anomalies, got %d", len(alerts))
+	}
+
+	inSnapshot := 0
+	for _, al := range mockutil.SnapshotAlerts() {
+		if mockutil.IsCostAlert(al) {
+			inSnapshot++
+		}
+	}
+	if inSnapshot != len(alerts) {
+		t.Fatalf("expected %d cost alerts in the shared snapshot, got %d", len(alerts), inSnapshot)
+	}
+}
+
+func TestSpend(t *testing.T) {
+	prov, _ := New(nil)
+	prov.clock = func() time.Time { return time.Date(2026, 3, 18, 12, 0, 0, 0, time.UTC) }
+
+	spend, err := prov.Spend(context.Background(), "svc-search")
+	if err != nil {
+		t.Fatalf("Spend returned error: %v", err)
+	}
+	if len(spend) != 1 || len(spend[0].Daily) != 14 {
+		t.Fatalf("expected 14 days for svc-search, got %+v", spend)
+	}
+	s := spend[0]
+	if s.Today < s.Baseline*1.3 {
+		t.Fatalf("expected today's spike over baseline: today=%v baseline=%v", s.Today, s.Baseline)
+	}
+	if s.MonthToDate <= 0 || s.Forecast < s.MonthToDate || s.MonthlyBudget <= 0 {
+		t.Fatalf("unexpected month figures: %+v", s)
+	}
+}
+
+func TestCostAlertsDoNotShapeMetrics(t *testing.T) {
+	prov, _ := New(nil)
+	alerts, _ := prov.Alerts(context.Background())
+	factor, _ := mockutil.StrongestAlertFactor("svc-search", time.Now().UTC(), alerts)
+	if factor != 1 {
+		t.Fatalf("expected cost alerts to be ignored for anomaly factors, got %v", factor)
+	}
+}
@@ -0,0 +1,114 @@
+package budgetalertmock
+
+import (
+	"hash/fnv"
+	"math"
+	"time"
+
+	"github.com/opsorch/opsorch-mock-adapters/internal/mockutil"
+)
+
+// spendOverlay shapes a service's spend beyond its hashed baseline.
+type spendOverlay struct {
+	costType string
+	// todayFactor multiplies today's spend.
+	todayFactor float64
+	// trend grows daily spend by this fraction per day across the month.
+	trend float64
+	// budgetFactor sizes the monthly budget against steady-state spend.
+	budgetFactor float64
+	since        time.Duration
+	reason       string
+	incidentID   string
+}
+
+// spendOverlays are the services with notable spend. Everything else spends
+// steadily on compute.
+var spendOverlays = map[string]spendOverlay{
+	"svc-search": {
+		costType: "compute", todayFactor: 1.55, since: 40 * time.Minute,
+		reason:     "on-demand nodes launched to absorb the traffic spike",
+		incidentID: "inc-scenario-005",
+	},
+	"svc-analytics": {
+		costType: "egress", todayFactor: 2.1, since: 5 * time.Hour,
+		reason: "cross-region export to the warehouse bucket",
+	},
+	"svc-logging": {
+		costType: "logging", todayFactor: 1.7, since: 9 * time.Hour,
+		reason: "debug log level left enabled after the last release",
+	},
+	"svc-warehouse": {
+		// Retention grew faster than the budget was planned for.
+		costType: "storage", trend: 0.02, budgetFactor: 0.8,
+	},
+}
+
+// serviceSpend builds 14 days of spend ending today plus month-to-date and a
+// linear month-end forecast.
+func serviceSpend(service string, today time.Time) ServiceSpend {
+	h := fnv.New32a()
+	h.Write([]byte(service))
+	seed := h.Sum32()
+	base := 120 + float64(seed%900)
+	overlay := spendOverlays[service]
+	costType := overlay.costType
+	if costType == "" {
+		costType = "compute"
+	}
+
+	dayAmount := func(day time.Time) float64 {
+		dh := fnv.New32a()
+		dh.Write([]byte(service + day.Format("2006-01-02")))
+		noise := 0.96 + float64(dh.Sum32()%80)/1000
+		amount := base * noise
+		if overlay.trend > 0 {
+			amount *= 1 + overlay.trend*float64(day.Day())
+		}
+		if day.Equal(today) && overlay.todayFactor > 0 {
+			amount *= overlay.todayFactor
+		}
+		return round2(amount)
+	}
+
+	out := ServiceSpend{
+		Service:  service,
+		Team:     mockutil.GetTeamForService(service),
+		CostType: costType,
+		Currency: "USD",
+	}
+	for i := 13; i >= 0; i-- {
+		day := today.AddDate(0, 0, -i)
+		out.Daily = append(out.Daily, DailyCost{Date: day, Amount: dayAmount(day)})
+	}
+	out.Today = out.Daily[len(out.Daily)-1].Amount
+	sum := 0.0
+	for _, d := range out.Daily[len(out.Daily)-1-baselineDays : len(out.Daily)-1] {
+		sum += d.Amount
+	}
+	out.Baseline = round2(sum / baselineDays)
+
+	monthStart := time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, time.UTC)
+	daysInMonth := monthStart.AddDate(0, 1, -1).Day()
+	for day := monthStart; !day.After(today); day = day.AddDate(0, 0, 1) {
+		out.MonthToDate += dayAmount(day)
+	}
+	out.MonthToDate = round2(out.MonthToDate)
+	// Forecast the rest of the month at today's run rate, ignoring one-off spikes.
+	runRate := out.Today
+	if overlay.todayFactor > 0 {
+		runRate = out.Baseline
+	}
+	out.Forecast = round2(out.MonthToDate + runRate*float64(daysInMonth-today.Day()))
+	// Budgets are set with 10% headroom over the steady-state month by default.
+	budgetFactor := overlay.budgetFactor
+	if budgetFactor == 0 {
+		budgetFactor = 1.1
+	}
+	out.MonthlyBudget = math.Round(base * float64(daysInMonth) * budgetFactor)
+	return out
+}
+
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
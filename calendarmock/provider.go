@@ -0,0 +1,260 @@
+package calendarmock
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/opsorch/opsorch-core/orcherr"
+	"github.com/opsorch/opsorch-core/schema"
+	"github.com/opsorch/opsorch-mock-adapters/internal/mockutil"
+	"github.com/opsorch/opsorch-mock-adapters/teammock"
+)
+
+// ProviderName identifies the mock calendar provider.
+const ProviderName = "mock"
+
+// Event types.
+const (
+	TypeMaintenance = "maintenance"
+	TypeFreeze      = "freeze"
+	TypeOnCall      = "oncall"
+)
+
+// Event statuses relative to now.
+const (
+	StatusScheduled = "scheduled"
+	StatusActive    = "active"
+	StatusCompleted = "completed"
+)
+
+const (
+	// defaultLookback and defaultLookahead bound queries without an explicit range.
+	defaultLookback  = 7 * 24 * time.Hour
+	defaultLookahead = 14 * 24 * time.Hour
+	// maxRange keeps on-call expansion bounded.
+	maxRange = 90 * 24 * time.Hour
+)
+
+// Config controls mock calendar data.
+type Config struct {
+	Source string
+}
+
+// Provider serves maintenance windows, release freezes, and on-call shifts.
+// Shifts come from teammock's rotation, overrides, and out-of-office entries.
+type Provider struct {
+	cfg    Config
+	teams  *teammock.Provider
+	events []Event
+	clock  func() time.Time
+}
+
+// Event is a calendar entry. Services lists what it covers; an empty list on a
+// freeze means it applies everywhere.
+type Event struct {
+	ID          string         `json:"id"`
+	Type        string         `json:"type"`
+	Title       string         `json:"title"`
+	Description string         `json:"description,omitempty"`
+	Start       time.Time      `json:"start"`
+	End         time.Time      `json:"end"`
+	Status      string         `json:"status"`
+	Services    []string       `json:"services,omitempty"`
+	Team        string         `json:"team,omitempty"`
+	Region      string         `json:"region,omitempty"`
+	Assignee    string         `json:"assignee,omitempty"`
+	Recurrence  string         `json:"recurrence,omitempty"`
+	Metadata    map[string]any `json:"metadata,omitempty"`
+}
+
+// Query selects events overlapping [Start, End). A zero range defaults to the
+// past week through the next two weeks.
+type Query struct {
+	Start   time.Time `json:"start,omitempty"`
+	End     time.Time `json:"end,omitempty"`
+	Types   []string  `json:"types,omitempty"`
+	Service string    `json:"service,omitempty"`
+	Team    string    `json:"team,omitempty"`
+	Limit   int       `json:"limit,omitempty"`
+}
+
+// New constructs the mock calendar provider.
+func New(cfg map[string]any) (*Provider, error) {
+	teamProv, err := teammock.New(nil)
+	if err != nil {
+		return nil, err
+	}
+	p := &Provider{cfg: parseConfig(cfg), teams: teamProv.(*teammock.Provider)}
+	p.events = p.seed(p.now())
+	return p, nil
+}
+
+// Query returns events overlapping the range, ordered by start time.
+func (p *Provider) Query(ctx context.Context, query Query) ([]Event, error) {
+	now := p.now()
+	start, end := query.Start, query.End
+	if start.IsZero() {
+		start = now.Add(-defaultLookback)
+	}
+	if end.IsZero() {
+		end = now.Add(defaultLookahead)
+	}
+	if !end.After(start) {
+		return nil, orcherr.New("bad_request", "end must be after start", nil)
+	}
+	if end.Sub(start) > maxRange {
+		return nil, orcherr.New("bad_request", "range must be at most 90 days", nil)
+	}
+
+	candidates := make([]Event, 0, len(p.events))
+	candidates = append(candidates, p.events...)
+	if wants(query.Types, TypeOnCall) {
+		shifts, err := p.onCallEvents(ctx, query.Team, start, end)
+		if err != nil {
+			return nil, err
+		}
+		candidates = append(candidates, shifts...)
+	}
+
+	out := make([]Event, 0)
+	for _, ev := range candidates {
+		if !ev.Start.Before(end) || !ev.End.After(start) {
+			continue
+		}
+		if !wants(query.Types, ev.Type) {
+			continue
+		}
+		if query.Team != "" && ev.Team != query.Team {
+			continue
+		}
+		if query.Service != "" && !coversService(ev, query.Service) {
+			continue
+		}
+		ev.Status = statusAt(ev, now)
+		out = append(out, cloneEvent(ev))
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		if !out[i].Start.Equal(out[j].Start) {
+			return out[i].Start.Before(out[j].Start)
+		}
+		return out[i].ID < out[j].ID
+	})
+	if query.Limit > 0 && len(out) > query.Limit {
+		out = out[:query.Limit]
+	}
+	return out, nil
+}
+
+// Get returns a maintenance window or freeze by ID.
+func (p *Provider) Get(ctx context.Context, id string) (Event, error) {
+	_ = ctx
+
+	for _, ev := range p.events {
+		if ev.ID == id {
+			ev.Status = statusAt(ev, p.now())
+			return cloneEvent(ev), nil
+		}
+	}
+	return Event{}, orcherr.New("not_found", fmt.Sprintf("event not found: %s", id), nil)
+}
+
+// onCallEvents turns teammock shifts into calendar events for one team or all.
+func (p *Provider) onCallEvents(ctx context.Context, teamID string, start, end time.Time) ([]Event, error) {
+	teams, err := p.teams.Query(ctx, schema.TeamQuery{})
+	if err != nil {
+		return nil, err
+	}
+	out := make([]Event, 0)
+	for _, team := range teams {
+		if teamID != "" && team.ID != teamID {
+			continue
+		}
+		shifts, err := p.teams.Shifts(ctx, team.ID, start, end)
+		if err != nil {
+			return nil, err
+		}
+		services, _ := team.Metadata["services"].([]string)
+		for _, shift := range shifts {
+			ev := Event{
+				ID:       fmt.Sprintf("oncall-%s-%d", team.ID, shift.Start.Unix()),
+				Type:     TypeOnCall,
+				Title:    fmt.Sprintf("On call: %s - %s", team.Name, shift.Responder.Name),
+				Start:    shift.Start,
+				End:      shift.End,
+				Services: mockutil.CloneStringSlice(services),
+				Team:     team.ID,
+				Assignee: shift.Responder.ID,
+				Metadata: map[string]any{
+					"source":           p.cfg.Source,
+					"shift_source":     shift.Source,
+					"scheduled_member": shift.Scheduled,
+				},
+			}
+			if shift.OverrideID != "" {
+				ev.Metadata["override_id"] = shift.OverrideID
+			}
+			out = append(out, ev)
+		}
+	}
+	return out, nil
+}
+
+func statusAt(ev Event, now time.Time) string {
+	switch {
+	case now.Before(ev.Start):
+		return StatusScheduled
+	case now.Before(ev.End):
+		return StatusActive
+	default:
+		return StatusCompleted
+	}
+}
+
+// coversService matches events listing the service and org-wide freezes.
+func coversService(ev Event, service string) bool {
+	if len(ev.Services) == 0 {
+		return ev.Type == TypeFreeze
+	}
+	for _, s := range ev.Services {
+		if s == service {
+			return true
+		}
+	}
+	return false
+}
+
+func wants(types []string, t string) bool {
+	if len(types) == 0 {
+		return true
+	}
+	for _, want := range types {
+		if want == t {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *Provider) now() time.Time {
+	if p.clock != nil {
+		return p.clock().UTC()
+	}
+	return time.Now().UTC()
+}
+
+func cloneEvent(in Event) Event {
+	out := in
+	out.Services = mockutil.CloneStringSlice(in.Services)
+	out.Metadata = mockutil.CloneMap(in.Metadata)
+	return out
+}
+
+func parseConfig(cfg map[string]any) Config {
+	out := Config{Source: "mock"}
+	if v, ok := cfg["source"].(string); ok && v != "" {
+		out.Source = v
+	}
+	return out
+}
@@ -0,0 +1,95 @@
+package calendarmock
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestQueryDefaultRangeIncludesEveryType(t *testing.T) {
+	prov, err := New(map[string]any{"source": "test"})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+
+	events, err := prov.Query(context.Background(), Query{})
+	if err != nil {
+		t.Fatalf("Query returned error: %v", err)
+	}
+	byType := map[string]int{}
+	for i, ev := range events {
+		byType[ev.Type]++
+		if i > 0 && ev.Start.Before(events[i-1].Start) {
+			t.Fatalf("expected events ordered by start")
+		}
+		if ev.Metadata["source"] != "test" {
+			t.Fatalf("expected source metadata, got %+v", ev)
+		}
+	}
+	for _, typ := range []string{TypeMaintenance, TypeFreeze, TypeOnCall} {
+		if byType[typ] == 0 {
+			t.Fatalf("expected %s events, got %v", typ, byType)
+		}
+	}
+
+	evac, err := prov.Get(context.Background(), "maint-region-evacuation")
+	if err != nil || evac.Status != StatusActive || evac.Metadata["plan_id"] != "plan-complex-006" {
+		t.Fatalf("expected active region evacuation, got %+v, %v", evac, err)
+	}
+	if _, err := prov.Get(context.Background(), "missing"); err == nil || !strings.Contains(err.Error(), "not_found") {
+		t.Fatalf("expected not_found, got %v", err)
+	}
+}
+
+func TestFreezesAvoidRecentDeploysAndCoverServices(t *testing.T) {
+	prov, _ := New(nil)
+	now := time.Now().UTC()
+
+	// deploymentmock seeds prod deploys over the last 12 hours.
+	recent, _ := prov.Query(context.Background(), Query{Start: now.Add(-12 * time.Hour), End: now, Types: []string{TypeFreeze}})
+	if len(recent) != 0 {
+		t.Fatalf("expected no freeze overlapping recent deploys, got %+v", recent)
+	}
+
+	upcoming, _ := prov.Query(context.Background(), Query{Start: now, End: now.Add(14 * 24 * time.Hour), Types: []string{TypeFreeze}, Service: "svc-payments"})
+	if len(upcoming) != 1 || upcoming[0].ID != "freeze-eng-offsite" {
+		t.Fatalf("expected only the org-wide freeze for payments, got %+v", upcoming)
+	}
+	checkout, _ := prov.Query(context.Background(), Query{Types: []string{TypeFreeze}, Service: "svc-checkout"})
+	found := false
+	for _, ev := range checkout {
+		if ev.ID == "freeze-checkout-error-budget" {
+			found = ev.Metadata["incident_id"] == "inc-scenario-001"
+		}
+	}
+	if !found {
+		t.Fatalf("expected checkout error budget freeze, got %+v", checkout)
+	}
+}
+
+func TestOnCallShiftsMatchTeamSchedule(t *testing.T) {
+	prov, _ := New(nil)
+	now := time.Now().UTC()
+
+	shifts, err := prov.Query(context.Background(), Query{Start: now, End: now.Add(3 * 24 * time.Hour), Types: []string{TypeOnCall}, Team: "team-aurora"})
+	if err != nil {
+		t.Fatalf("Query returned error: %v", err)
+	}
+	override := false
+	for _, ev := range shifts {
+		if ev.Team != "team-aurora" || ev.Assignee == "" {
+			t.Fatalf("unexpected shift: %+v", ev)
+		}
+		if ev.Metadata["override_id"] == "ovr-seed-001" && ev.Assignee == "alice.johnson@opsorch.com" {
+			override = true
+		}
+	}
+	if !override {
+		t.Fatalf("expected the seeded teammock override, got %+v", shifts)
+	}
+
+	if _, err := prov.Query(context.Background(), Query{Start: now, End: now.Add(100 * 24 * time.Hour)}); err == nil || !strings.Contains(err.Error(), "bad_request") {
+		t.Fatalf("expected bad_request for oversized range, got %v", err)
+	}
+}
@@ -0,0 +1,176 @@
+package calendarmock
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/opsorch/opsorch-mock-adapters/internal/mockutil"
+)
+
+// recurringWindow is a weekly maintenance slot. Slots sit outside the business
+// hours deploymentmock uses for prod deploys.
+type recurringWindow struct {
+	id          string
+	title       string
+	description string
+	weekday     time.Weekday
+	hour        int
+	duration    time.Duration
+	services    []string
+}
+
+var recurringWindows = []recurringWindow{
+	{
+		id:          "maint-db-weekly",
+		title:       "Weekly database maintenance",
+		description: "Vacuum, index rebuilds, and minor version patches on the orders cluster",
+		weekday:     time.Sunday,
+		hour:        2,
+		duration:    2 * time.Hour,
+		services:    []string{"svc-database"},
+	},
+	{
+		id:          "maint-platform-patching",
+		title:       "Node image patching",
+		description: "Rolling kernel and node image updates for shared platform fleets",
+		weekday:     time.Tuesday,
+		hour:        5,
+		duration:    2 * time.Hour,
+		services:    []string{"svc-cache", "svc-ingress", "svc-api-gateway"},
+	},
+}
+
+// recurringBefore and recurringAfter bound how far seeded weekly windows reach
+// either side of now.
+const (
+	recurringBefore = 5 * 7 * 24 * time.Hour
+	recurringAfter  = 9 * 7 * 24 * time.Hour
+)
+
+func (p *Provider) seed(now time.Time) []Event {
+	day := now.Truncate(24 * time.Hour)
+	events := make([]Event, 0)
+
+	for _, w := range recurringWindows {
+		first := day.Add(-recurringBefore)
+		for first.Weekday() != w.weekday {
+			first = first.AddDate(0, 0, 1)
+		}
+		for start := first.Add(time.Duration(w.hour) * time.Hour); start.Before(now.Add(recurringAfter)); start = start.AddDate(0, 0, 7) {
+			events = append(events, Event{
+				ID:          fmt.Sprintf("%s-%s", w.id, start.Format("20060102")),
+				Type:        TypeMaintenance,
+				Title:       w.title,
+				Description: w.description,
+				Start:       start,
+				End:         start.Add(w.duration),
+				Services:    w.services,
+				Team:        mockutil.GetTeamForService(w.services[0]),
+				Recurrence:  "weekly",
+			})
+		}
+	}
+
+	evacuating := make([]string, 0)
+	for _, service := range mockutil.Services() {
+		if mockutil.ServiceRegion(service) == "us-east-1" {
+			evacuating = append(evacuating, service)
+		}
+	}
+	certWindow := day.AddDate(0, 0, 5).Add(3 * time.Hour)
+	upgradeNight := day.AddDate(0, 0, 9)
+	for upgradeNight.Weekday() != time.Saturday {
+		upgradeNight = upgradeNight.AddDate(0, 0, 1)
+	}
+	upgradeNight = upgradeNight.Add(22 * time.Hour)
+	offsiteFreeze := day.AddDate(0, 0, 10)
+
+	events = append(events,
+		Event{
+			// Network degradations in networkmock come from this evacuation.
+			ID:          "maint-region-evacuation",
+			Type:        TypeMaintenance,
+			Title:       "us-east-1 region evacuation",
+			Description: "Draining traffic and replicas out of us-east-1 ahead of the provider's AZ maintenance",
+			Start:       now.Add(-time.Hour).Truncate(time.Minute),
+			End:         now.Add(3 * time.Hour).Truncate(time.Minute),
+			Services:    evacuating,
+			Team:        "team-platform",
+			Region:      "us-east-1",
+			Metadata:    map[string]any{"plan_id": "plan-complex-006"},
+		},
+		Event{
+			// Renews api.demo.com before the expiry flagged by the certificate alert.
+			ID:          "maint-cert-rotation-api",
+			Type:        TypeMaintenance,
+			Title:       "Rotate api.demo.com certificate",
+			Description: "Manual renewal and ALB listener swap while ACME renewal is disabled",
+			Start:       certWindow,
+			End:         certWindow.Add(time.Hour),
+			Services:    []string{"svc-ingress"},
+			Team:        "team-platform",
+			Metadata: map[string]any{
+				"alert_id":       "al-009",
+				"certificate_id": "cert-api-demo-com",
+				"runbook":        "https://runbook.demo/runbooks/cert-rotation",
+			},
+		},
+		Event{
+			ID:          "maint-monolith-upgrade",
+			Type:        TypeMaintenance,
+			Title:       "Legacy monolith upgrade",
+			Description: "Maintenance mode, database patches, and binary upgrade for the legacy API",
+			Start:       upgradeNight,
+			End:         upgradeNight.Add(4 * time.Hour),
+			Services:    []string{"svc-api"},
+			Team:        "team-platform",
+			Metadata: map[string]any{
+				"plan_id": "plan-complex-003",
+				"runbook": "https://runbook.demo/maintenance/monolith-upgrade",
+			},
+		},
+		Event{
+			// Starts after the rollbacks and hotfixes already in flight for the
+			// SLO exhaustion incident so they are not inside the freeze.
+			ID:          "freeze-checkout-error-budget",
+			Type:        TypeFreeze,
+			Title:       "Checkout error budget freeze",
+			Description: "Feature releases to checkout paused until the 30-day error budget recovers; rollbacks and fixes allowed",
+			Start:       now.Add(time.Hour).Truncate(time.Hour),
+			End:         day.AddDate(0, 0, 7),
+			Services:    []string{"svc-checkout"},
+			Team:        "team-velocity",
+			Metadata: map[string]any{
+				"incident_id": "inc-scenario-001",
+				"allowed":     []string{"rollback", "hotfix"},
+			},
+		},
+		Event{
+			ID:          "freeze-eng-offsite",
+			Type:        TypeFreeze,
+			Title:       "Engineering offsite release freeze",
+			Description: "Org-wide production change freeze while most engineers are at the offsite",
+			Start:       offsiteFreeze,
+			End:         offsiteFreeze.AddDate(0, 0, 4),
+			Metadata:    map[string]any{"allowed": []string{"hotfix"}, "approver": "alice.johnson"},
+		},
+		Event{
+			ID:          "freeze-campaign-launch",
+			Type:        TypeFreeze,
+			Title:       "Campaign launch freeze",
+			Description: "Storefront change freeze during the seasonal campaign launch",
+			Start:       day.AddDate(0, 0, -12),
+			End:         day.AddDate(0, 0, -9),
+			Services:    []string{"svc-checkout", "svc-web", "svc-search", "svc-catalog"},
+			Team:        "team-velocity",
+		},
+	)
+
+	for i := range events {
+		if events[i].Metadata == nil {
+			events[i].Metadata = map[string]any{}
+		}
+		events[i].Metadata["source"] = p.cfg.Source
+	}
+	return events
+}
@@ -0,0 +1,309 @@
+package capacitymock
+
+import (
+	"context"
+	"hash/fnv"
+	"math"
+	"time"
+
+	"github.com/opsorch/opsorch-mock-adapters/internal/mockutil"
+)
+
+// ProviderName identifies the mock capacity provider.
+const ProviderName = "mock"
+
+// Config controls mock capacity data.
+type Config struct {
+	Source      string
+	Environment string
+}
+
+// Provider computes deterministic cluster, node pool, and per-service capacity
+// from the shared service topology.
+type Provider struct {
+	cfg Config
+}
+
+// Query filters capacity results. Empty fields match everything.
+type Query struct {
+	Service string `json:"service,omitempty"`
+	Team    string `json:"team,omitempty"`
+	Cluster string `json:"cluster,omitempty"`
+}
+
+// ResourceUsage compares what is requested and used against what is allowed.
+type ResourceUsage struct {
+	Unit        string  `json:"unit"`
+	Requested   float64 `json:"requested"`
+	Used        float64 `json:"used"`
+	Limit       float64 `json:"limit"`
+	Utilization float64 `json:"utilization"`
+	Headroom    float64 `json:"headroom"`
+}
+
+// NodePool is a group of identical nodes in a cluster.
+type NodePool struct {
+	Name         string        `json:"name"`
+	Cluster      string        `json:"cluster"`
+	InstanceType string        `json:"instanceType"`
+	Nodes        int           `json:"nodes"`
+	MaxNodes     int           `json:"maxNodes"`
+	PendingPods  int           `json:"pendingPods"`
+	CPU          ResourceUsage `json:"cpu"`
+	Memory       ResourceUsage `json:"memory"`
+}
+
+// Cluster summarizes a Kubernetes cluster and its node pools.
+type Cluster struct {
+	ID        string        `json:"id"`
+	Region    string        `json:"region"`
+	NodePools []NodePool    `json:"nodePools"`
+	CPU       ResourceUsage `json:"cpu"`
+	Memory    ResourceUsage `json:"memory"`
+}
+
+// ServiceCapacity is a service's replica count, resource usage, and namespace quota.
+type ServiceCapacity struct {
+	Service         string         `json:"service"`
+	Team            string         `json:"team"`
+	Environment     string         `json:"environment"`
+	Cluster         string         `json:"cluster"`
+	NodePool        string         `json:"nodePool"`
+	Replicas        int            `json:"replicas"`
+	DesiredReplicas int            `json:"desiredReplicas"`
+	MaxReplicas     int            `json:"maxReplicas"`
+	CPU             ResourceUsage  `json:"cpu"`
+	Memory          ResourceUsage  `json:"memory"`
+	Status          string         `json:"status"`
+	Metadata        map[string]any `json:"metadata,omitempty"`
+}
+
+// Report is the capacity.query result.
+type Report struct {
+	GeneratedAt time.Time         `json:"generatedAt"`
+	Clusters    []Cluster         `json:"clusters"`
+	Services    []ServiceCapacity `json:"services"`
+}
+
+type clusterSpec struct {
+	id     string
+	region string
+}
+
+type poolSpec struct {
+	name         string
+	instanceType string
+	cpu          float64
+	memoryGiB    float64
+}
+
+var clusters = []clusterSpec{
+	{id: "prod-use1", region: "us-east-1"},
+	{id: "prod-usw2", region: "us-west-2"},
+	{id: "prod-euw1", region: "eu-west-1"},
+}
+
+var nodePools = []poolSpec{
+	{name: "general", instanceType: "m6i.2xlarge", cpu: 8, memoryGiB: 32},
+	{name: "compute", instanceType: "c6i.4xlarge", cpu: 16, memoryGiB: 32},
+}
+
+// computeServices run on the compute pool; everything else uses general.
+var computeServices = map[string]bool{
+	"svc-search":         true,
+	"svc-recommendation": true,
+	"svc-analytics":      true,
+	"svc-warehouse":      true,
+}
+
+// New constructs the mock capacity provider.
+func New(cfg map[string]any) (*Provider, error) {
+	return &Provider{cfg: parseConfig(cfg)}, nil
+}
+
+// Query returns cluster, node pool, and per-service capacity.
+func (p *Provider) Query(ctx context.Context, query Query) (Report, error) {
+	_ = ctx
+
+	all := p.serviceCapacities()
+	pools := buildNodePools(all)
+
+	report := Report{GeneratedAt: time.Now().UTC(), Clusters: make([]Cluster, 0, len(clusters)), Services: make([]ServiceCapacity, 0, len(all))}
+	for _, spec := range clusters {
+		if query.Cluster != "" && spec.id != query.Cluster {
+			continue
+		}
+		cluster := Cluster{ID: spec.id, Region: spec.region, CPU: ResourceUsage{Unit: "cores"}, Memory: ResourceUsage{Unit: "GiB"}}
+		for _, pool := range pools {
+			if pool.Cluster != spec.id {
+				continue
+			}
+			cluster.NodePools = append(cluster.NodePools, pool)
+			cluster.CPU = addUsage(cluster.CPU, pool.CPU)
+			cluster.Memory = addUsage(cluster.Memory, pool.Memory)
+		}
+		report.Clusters = append(report.Clusters, cluster)
+	}
+	for _, svc := range all {
+		if query.Service != "" && svc.Service != query.Service {
+			continue
+		}
+		if query.Team != "" && svc.Team != query.Team {
+			continue
+		}
+		if query.Cluster != "" && svc.Cluster != query.Cluster {
+			continue
+		}
+		report.Services = append(report.Services, svc)
+	}
+	return report, nil
+}
+
+// serviceCapacities derives stable per-service capacity from a hash of the
+// service ID, then overlays the autoscaling-lag scenario on svc-search.
+func (p *Provider) serviceCapacities() []ServiceCapacity {
+	services := mockutil.Services()
+	out := make([]ServiceCapacity, 0, len(services))
+	for _, service := range services {
+		h := fnv.New32a()
+		h.Write([]byte(service))
+		seed := h.Sum32()
+
+		replicas := 2 + int(seed%5)
+		cpuPerReplica := 0.5 + float64((seed>>3)%4)*0.25
+		memPerReplica := 1 + float64((seed>>5)%4)
+		utilization := 0.35 + float64((seed>>7)%40)/100
+
+		pool := "general"
+		if computeServices[service] {
+			pool = "compute"
+		}
+		svc := ServiceCapacity{
+			Service:         service,
+			Team:            mockutil.GetTeamForService(service),
+			Environment:     p.cfg.Environment,
+			Cluster:         clusters[int(seed>>11)%len(clusters)].id,
+			NodePool:        pool,
+			Replicas:        replicas,
+			DesiredReplicas: replicas,
+			MaxReplicas:     replicas*2 + 2,
+		}
+		cpuQuota := math.Ceil(cpuPerReplica * float64(replicas) * 1.5)
+		memQuota := math.Ceil(memPerReplica * float64(replicas) * 1.5)
+
+		if service == "svc-search" {
+			// Autoscaling lag: traffic spiked, the HPA wants 8 pods, but the namespace
+			// quota only fits 5 and the running 3 are saturated.
+			svc.Cluster = "prod-usw2"
+			svc.Replicas = 3
+			svc.DesiredReplicas = 8
+			svc.MaxReplicas = 12
+			utilization = 0.94
+			cpuPerReplica = 1.0
+			memPerReplica = 2
+			cpuQuota = 5
+			memQuota = 12
+			svc.Metadata = map[string]any{
+				"scenario_id":        "autoscaling-lag",
+				"scenario_name":      "Autoscaling Lag",
+				"incident_id":        "inc-scenario-005",
+				"autoscaling_status": "lagging",
+				"is_scenario":        true,
+			}
+		}
+
+		svc.CPU = usage("cores", cpuPerReplica*float64(svc.Replicas), cpuPerReplica*float64(svc.Replicas)*utilization, cpuQuota)
+		svc.Memory = usage("GiB", memPerReplica*float64(svc.Replicas), memPerReplica*float64(svc.Replicas)*(utilization*0.9), memQuota)
+		svc.Status = capacityStatus(svc)
+		if svc.Metadata == nil {
+			svc.Metadata = map[string]any{}
+		}
+		svc.Metadata["source"] = p.cfg.Source
+		out = append(out, svc)
+	}
+	return out
+}
+
+// buildNodePools sizes each cluster's pools from the services scheduled on them,
+// leaving modest spare capacity. Pods a service wants but cannot schedule count
+// as pending.
+func buildNodePools(services []ServiceCapacity) []NodePool {
+	out := make([]NodePool, 0, len(clusters)*len(nodePools))
+	for _, cluster := range clusters {
+		for _, spec := range nodePools {
+			pool := NodePool{Name: spec.name, Cluster: cluster.id, InstanceType: spec.instanceType}
+			requestedCPU, usedCPU, requestedMem, usedMem := 0.0, 0.0, 0.0, 0.0
+			for _, svc := range services {
+				if svc.Cluster != cluster.id || svc.NodePool != spec.name {
+					continue
+				}
+				requestedCPU += svc.CPU.Requested
+				usedCPU += svc.CPU.Used
+				requestedMem += svc.Memory.Requested
+				usedMem += svc.Memory.Used
+				if svc.DesiredReplicas > svc.Replicas {
+					pool.PendingPods += svc.DesiredReplicas - svc.Replicas
+				}
+			}
+			if requestedCPU == 0 {
+				continue
+			}
+			pool.Nodes = int(math.Ceil(requestedCPU/(spec.cpu*0.75))) + 1
+			if pool.PendingPods > 0 {
+				// Saturated pools have already hit their node ceiling.
+				pool.Nodes = int(math.Ceil(requestedCPU / (spec.cpu * 0.9)))
+			}
+			pool.MaxNodes = pool.Nodes + 3
+			if pool.PendingPods > 0 {
+				pool.MaxNodes = pool.Nodes
+			}
+			pool.CPU = usage("cores", requestedCPU, usedCPU, float64(pool.Nodes)*spec.cpu)
+			pool.Memory = usage("GiB", requestedMem, usedMem, float64(pool.Nodes)*spec.memoryGiB)
+			out = append(out, pool)
+		}
+	}
+	return out
+}
+
+func parseConfig(cfg map[string]any) Config {
+	out := Config{Source: "mock", Environment: "prod"}
+	if v, ok := cfg["source"].(string); ok && v != "" {
+		out.Source = v
+	}
+	if v, ok := cfg["environment"].(string); ok && v != "" {
+		out.Environment = v
+	}
+	return out
+}
+
+func usage(unit string, requested, used, limit float64) ResourceUsage {
+	out := ResourceUsage{Unit: unit, Requested: round2(requested), Used: round2(used), Limit: round2(limit)}
+	if limit > 0 {
+		out.Utilization = round2(used / limit)
+		out.Headroom = round2(limit - requested)
+	}
+	return out
+}
+
+func addUsage(a, b ResourceUsage) ResourceUsage {
+	return usage(a.Unit, a.Requested+b.Requested, a.Used+b.Used, a.Limit+b.Limit)
+}
+
+func capacityStatus(svc ServiceCapacity) string {
+	perReplicaUtil := 0.0
+	if svc.CPU.Requested > 0 {
+		perReplicaUtil = svc.CPU.Used / svc.CPU.Requested
+	}
+	switch {
+	case perReplicaUtil >= 0.9 || svc.DesiredReplicas > svc.Replicas:
+		return "critical"
+	case perReplicaUtil >= 0.75 || svc.CPU.Headroom <= 0:
+		return "warning"
+	default:
+		return "ok"
+	}
+}
+
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
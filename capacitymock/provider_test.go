@@ -0,0 +1,87 @@
+package capacitymock
+
+import (
+	"context"
+	"testing"
+)
+
+func TestQueryCorrelatesAutoscalingLag(t *testing.T) {
+	prov, err := New(map[string]any{"source": "test"})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+
+	report, err := prov.Query(context.Background(), Query{Service: "svc-search"})
+	if err != nil {
+		t.Fatalf("Query returned error: %v", err)
+	}
+	if len(report.Services) != 1 {
+		t.Fatalf("expected one service, got %d", len(report.Services))
+	}
+	search := report.Services[0]
+	if search.Status != "critical" || search.DesiredReplicas <= search.Replicas {
+		t.Fatalf("expected lagging svc-search, got %+v", search)
+	}
+	if search.Metadata["incident_id"] != "inc-scenario-005" {
+		t.Fatalf("expected scenario incident link, got %v", search.Metadata)
+	}
+
+	var pending int
+	for _, cluster := range report.Clusters {
+		for _, pool := range cluster.NodePools {
+			if cluster.ID == "prod-usw2" && pool.Name == "compute" {
+				pending = pool.PendingPods
+			}
+		}
+	}
+	if pending == 0 {
+		t.Fatalf("expected pending pods in prod-usw2/compute")
+	}
+}
+
+func TestQueryIsDeterministicAndFiltered(t *testing.T) {
+	prov, _ := New(nil)
+
+	first, _ := prov.Query(context.Background(), Query{Team: "team-platform"})
+	second, _ := prov.Query(context.Background(), Query{Team: "team-platform"})
+	if len(first.Services) == 0 || len(first.Services) != len(second.Services) {
+		t.Fatalf("expected stable non-empty results, got %d and %d", len(first.Services), len(second.Services))
+	}
+	for i, svc := range first.Services {
+		if svc.Team != "team-platform" {
+			t.Fatalf("team filter ignored: %+v", svc)
+		}
+		if svc.CPU != second.Services[i].CPU || svc.Replicas != second.Services[i].Replicas {
+			t.Fatalf("expected deterministic capacity for %s", svc.Service)
+		}
+		if svc.CPU.Utilization < 0 || svc.CPU.Utilization > 1 {
+			t.Fatalf("utilization out of range: %+v", svc.CPU)
+		}
+	}
+}
+
+func TestRecommendations(t *testing.T) {
+	prov, _ := New(nil)
+
+	recs, err := prov.Recommendations(context.Background(), Query{})
+	if err != nil {
+		t.Fatalf("Recommendations returned error: %v", err)
+	}
+	kinds := map[string]bool{}
+	for i, rec := range recs {
+		if i > 0 && priorityRank[rec.Priority] < priorityRank[recs[i-1].Priority] {
+			t.Fatalf("recommendations not ordered by priority: %+v", recs)
+		}
+		if rec.Service == "svc-search" || rec.Cluster == "prod-usw2" {
+			kinds[rec.Kind] = true
+		}
+	}
+	for _, want := range []string{RecommendScaleOut, RecommendRaiseQuota, RecommendAddNodes} {
+		if !kinds[want] {
+			t.Fatalf("expected %s recommendation for the autoscaling-lag scenario, got %+v", want, recs)
+		}
+	}
+	if recs[0].Priority != "high" {
+		t.Fatalf("expected high-priority recommendation first, got %+v", recs[0])
+	}
+}
@@ -0,0 +1,145 @@
+package capacitymock
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Recommendation kinds.
+const (
+	RecommendScaleOut   = "scale_out"
+	RecommendRaiseQuota = "raise_quota"
+	RecommendAddNodes   = "add_nodes"
+	RecommendRightSize  = "right_size"
+)
+
+// targetUtilization is the per-replica CPU utilization recommendations size for.
+const targetUtilization = 0.6
+
+// Recommendation is a suggested capacity change.
+type Recommendation struct {
+	ID        string `json:"id"`
+	Kind      string `json:"kind"`
+	Priority  string `json:"priority"`
+	Service   string `json:"service,omitempty"`
+	Cluster   string `json:"cluster"`
+	NodePool  string `json:"nodePool,omitempty"`
+	Summary   string `json:"summary"`
+	Reason    string `json:"reason"`
+	Current   string `json:"current"`
+	Suggested string `json:"suggested"`
+}
+
+var priorityRank = map[string]int{"high": 0, "medium": 1, "low": 2}
+
+// Recommendations suggests scale-outs for saturated services, quota raises when
+// desired replicas do not fit, node pool growth for pending pods or tight pools,
+// and right-sizing for over-provisioned services. High priority comes first.
+func (p *Provider) Recommendations(ctx context.Context, query Query) ([]Recommendation, error) {
+	report, err := p.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]Recommendation, 0)
+	for _, svc := range report.Services {
+		perReplicaUtil := 0.0
+		if svc.CPU.Requested > 0 {
+			perReplicaUtil = svc.CPU.Used / svc.CPU.Requested
+		}
+		cpuPerReplica := svc.CPU.Requested / float64(svc.Replicas)
+
+		if perReplicaUtil >= 0.85 {
+			suggested := int(math.Ceil(float64(svc.Replicas) * perReplicaUtil / targetUtilization))
+			if svc.DesiredReplicas > suggested {
+				suggested = svc.DesiredReplicas
+			}
+			priority := "medium"
+			if perReplicaUtil >= 0.9 {
+				priority = "high"
+			}
+			out = append(out, Recommendation{
+				Kind:      RecommendScaleOut,
+				Priority:  priority,
+				Service:   svc.Service,
+				Cluster:   svc.Cluster,
+				NodePool:  svc.NodePool,
+				Summary:   fmt.Sprintf("Scale %s out to %d replicas", svc.Service, suggested),
+				Reason:    fmt.Sprintf("CPU at %.0f%% of requests per replica", perReplicaUtil*100),
+				Current:   fmt.Sprintf("%d replicas", svc.Replicas),
+				Suggested: fmt.Sprintf("%d replicas", suggested),
+			})
+		}
+
+		if needed := cpuPerReplica * float64(svc.DesiredReplicas); needed > svc.CPU.Limit {
+			out = append(out, Recommendation{
+				Kind:      RecommendRaiseQuota,
+				Priority:  "high",
+				Service:   svc.Service,
+				Cluster:   svc.Cluster,
+				Summary:   fmt.Sprintf("Raise %s CPU quota to %.0f cores", svc.Service, math.Ceil(needed*1.2)),
+				Reason:    fmt.Sprintf("%d desired replicas need %.1f cores but the namespace quota is %.1f", svc.DesiredReplicas, needed, svc.CPU.Limit),
+				Current:   fmt.Sprintf("%.1f cores", svc.CPU.Limit),
+				Suggested: fmt.Sprintf("%.0f cores", math.Ceil(needed*1.2)),
+			})
+		}
+
+		if perReplicaUtil > 0 && perReplicaUtil < 0.4 && svc.Replicas > 2 {
+			suggested := int(math.Max(2, math.Ceil(float64(svc.Replicas)*perReplicaUtil/targetUtilization)))
+			out = append(out, Recommendation{
+				Kind:      RecommendRightSize,
+				Priority:  "low",
+				Service:   svc.Service,
+				Cluster:   svc.Cluster,
+				NodePool:  svc.NodePool,
+				Summary:   fmt.Sprintf("Reduce %s to %d replicas", svc.Service, suggested),
+				Reason:    fmt.Sprintf("CPU at %.0f%% of requests per replica", perReplicaUtil*100),
+				Current:   fmt.Sprintf("%d replicas", svc.Replicas),
+				Suggested: fmt.Sprintf("%d replicas", suggested),
+			})
+		}
+	}
+
+	for _, cluster := range report.Clusters {
+		for _, pool := range cluster.NodePools {
+			requestRatio := pool.CPU.Requested / pool.CPU.Limit
+			if pool.PendingPods == 0 && requestRatio < 0.85 {
+				continue
+			}
+			add := 2
+			priority := "medium"
+			reason := fmt.Sprintf("CPU requests at %.0f%% of allocatable", requestRatio*100)
+			if pool.PendingPods > 0 {
+				add = pool.PendingPods
+				priority = "high"
+				reason = fmt.Sprintf("%d pods pending; pool is at its %d-node ceiling", pool.PendingPods, pool.MaxNodes)
+			}
+			out = append(out, Recommendation{
+				Kind:      RecommendAddNodes,
+				Priority:  priority,
+				Cluster:   cluster.ID,
+				NodePool:  pool.Name,
+				Summary:   fmt.Sprintf("Raise %s/%s max nodes to %d", cluster.ID, pool.Name, pool.MaxNodes+add),
+				Reason:    reason,
+				Current:   fmt.Sprintf("%d max nodes", pool.MaxNodes),
+				Suggested: fmt.Sprintf("%d max nodes", pool.MaxNodes+add),
+			})
+		}
+	}
+
+	sort.SliceStable(out, func(i, j int) bool {
+		if priorityRank[out[i].Priority] != priorityRank[out[j].Priority] {
+			return priorityRank[out[i].Priority] < priorityRank[out[j].Priority]
+		}
+		if out[i].Service != out[j].Service {
+			return out[i].Service < out[j].Service
+		}
+		return out[i].Kind < out[j].Kind
+	})
+	for i := range out {
+		out[i].ID = fmt.Sprintf("rec-%03d", i+1)
+	}
+	return out, nil
+}
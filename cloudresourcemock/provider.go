@@ -0,0 +1,319 @@
+package cloudresourcemock
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/opsorch/opsorch-core/orcherr"
+	"github.com/opsorch/opsorch-mock-adapters/internal/mockutil"
+)
+
+// ProviderName identifies the mock cloud resource provider.
+const ProviderName = "mock"
+
+// Resource types.
+const (
+	TypeInstance     = "instance"
+	TypeLoadBalancer = "load_balancer"
+	TypeDatabase     = "database"
+	TypeBucket       = "bucket"
+	TypeCache        = "cache"
+	TypeQueue        = "queue"
+)
+
+// Config controls mock inventory data.
+type Config struct {
+	Source      string
+	Environment string
+	Account     string
+	// Features switches realism behaviours off individually; see
+	// mockutil.Features.
+	Features mockutil.Features
+}
+
+// Provider lists a deterministic cloud inventory tagged back to the services
+// in the shared topology.
+type Provider struct {
+	cfg       Config
+	resources []Resource
+}
+
+// Resource is one piece of cloud infrastructure.
+type Resource struct {
+	ID         string            `json:"id"`
+	ARN        string            `json:"arn"`
+	Type       string            `json:"type"`
+	Name       string            `json:"name"`
+	Region     string            `json:"region"`
+	Status     string            `json:"status"`
+	Service    string            `json:"service"`
+	Team       string            `json:"team"`
+	Tags       map[string]string `json:"tags"`
+	Attributes map[string]any    `json:"attributes,omitempty"`
+	CreatedAt  time.Time         `json:"createdAt"`
+	Metadata   map[string]any    `json:"metadata,omitempty"`
+}
+
+// Query filters the inventory. Empty fields match everything; every entry in
+// Tags must match the resource's tag exactly.
+type Query struct {
+	Service string            `json:"service,omitempty"`
+	Team    string            `json:"team,omitempty"`
+	Type    string            `json:"type,omitempty"`
+	Region  string            `json:"region,omitempty"`
+	Status  string            `json:"status,omitempty"`
+	Tags    map[string]string `json:"tags,omitempty"`
+	Limit   int               `json:"limit,omitempty"`
+}
+
+// statefulResources lists the data stores each service owns beyond its
+// instances and load balancer.
+var statefulResources = map[string][]struct {
+	typ  string
+	name string
+}{
+	"svc-database":      {{TypeDatabase, "orders-primary"}, {TypeDatabase, "orders-replica"}},
+	"svc-payments":      {{TypeDatabase, "payments-ledger"}, {TypeQueue, "payments-webhooks"}},
+	"svc-identity":      {{TypeDatabase, "identity-users"}, {TypeCache, "identity-sessions"}},
+	"svc-catalog":       {{TypeDatabase, "catalog-products"}, {TypeBucket, "catalog-images"}},
+	"svc-cache":         {{TypeCache, "shared-redis"}},
+	"svc-analytics":     {{TypeBucket, "analytics-events"}, {TypeQueue, "analytics-ingest"}},
+	"svc-warehouse":     {{TypeBucket, "warehouse-exports"}},
+	"svc-web":           {{TypeBucket, "web-static-assets"}},
+	"svc-logging":       {{TypeBucket, "log-archive"}},
+	"svc-notifications": {{TypeQueue, "notifications-fanout"}},
+	"svc-workers":       {{TypeQueue, "workers-jobs"}},
+}
+
+// New constructs the mock cloud resource provider.
+func New(cfg map[string]any) (*Provider, error) {
+	p := &Provider{cfg: parseConfig(cfg)}
+	p.resources = p.buildInventory(time.Now().UTC())
+	return p, nil
+}
+
+// Query returns matching resources ordered by service, type, and name.
+func (p *Provider) Query(ctx context.Context, query Query) ([]Resource, error) {
+	_ = ctx
+
+	out := make([]Resource, 0)
+	for _, res := range p.resources {
+		if query.Service != "" && res.Service != query.Service {
+			continue
+		}
+		if query.Team != "" && res.Team != query.Team {
+			continue
+		}
+		if query.Type != "" && res.Type != query.Type {
+			continue
+		}
+		if query.Region != "" && res.Region != query.Region {
+			continue
+		}
+		if query.Status != "" && res.Status != query.Status {
+			continue
+		}
+		if !matchesTags(res.Tags, query.Tags) {
+			continue
+		}
+		out = append(out, cloneResource(res))
+		if query.Limit > 0 && len(out) == query.Limit {
+			break
+		}
+	}
+	return out, nil
+}
+
+// Get returns a resource by ID or ARN.
+func (p *Provider) Get(ctx context.Context, id string) (Resource, error) {
+	_ = ctx
+
+	for _, res := range p.resources {
+		if res.ID == id || res.ARN == id {
+			return cloneResource(res), nil
+		}
+	}
+	return Resource{}, orcherr.New("not_found", "resource not found", nil)
+}
+
+func (p *Provider) buildInventory(now time.Time) []Resource {
+	out := make([]Resource, 0)
+	for _, service := range mockutil.Services() {
+		seed := hash(service)
+		region := mockutil.ServiceRegion(service)
+		short := strings.TrimPrefix(service, "svc-")
+
+		out = append(out, p.newResource(service, TypeLoadBalancer, short+"-alb", region, now, map[string]any{
+			"scheme":   "internet-facing",
+			"dnsName":  fmt.Sprintf("%s-alb-%d.%s.elb.amazonaws.com", short, seed%100000, region),
+			"listener": "HTTPS:443",
+		}))
+		instances := 2 + int(seed%3)
+		for i := 1; i <= instances; i++ {
+			out = append(out, p.newResource(service, TypeInstance, fmt.Sprintf("%s-%d", short, i), region, now, map[string]any{
+				"instanceType":     []string{"m6i.large", "m6i.xlarge", "c6i.xlarge"}[int(seed>>5)%3],
+				"availabilityZone": fmt.Sprintf("%s%c", region, 'a'+rune((i-1)%3)),
+				"privateIp":        fmt.Sprintf("10.%d.%d.%d", seed%200, (seed>>8)%250, 10+i),
+			}))
+		}
+		for _, extra := range statefulResources[service] {
+			out = append(out, p.newResource(service, extra.typ, extra.name, region, now, statefulAttributes(extra.typ, extra.name)))
+		}
+	}
+	if p.cfg.Features.ScenarioEffects {
+		applyScenarioState(out)
+	}
+
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].Service != out[j].Service {
+			return out[i].Service < out[j].Service
+		}
+		if out[i].Type != out[j].Type {
+			return out[i].Type < out[j].Type
+		}
+		return out[i].Name < out[j].Name
+	})
+	return out
+}
+
+func (p *Provider) newResource(service, typ, name, region string, now time.Time, attrs map[string]any) Resource {
+	seed := hash(typ + "/" + name)
+	team := mockutil.GetTeamForService(service)
+	return Resource{
+		ID:      resourceID(typ, name, seed),
+		ARN:     resourceARN(typ, name, region, p.cfg.Account),
+		Type:    typ,
+		Name:    name,
+		Region:  region,
+		Status:  "running",
+		Service: service,
+		Team:    team,
+		Tags: map[string]string{
+			"service":     service,
+			"team":        team,
+			"environment": p.cfg.Environment,
+			"managed-by":  "terraform",
+		},
+		Attributes: attrs,
+		CreatedAt:  now.Truncate(24 * time.Hour).Add(-time.Duration(30+seed%700) * 24 * time.Hour),
+		Metadata:   map[string]any{"source": p.cfg.Source},
+	}
+}
+
+// applyScenarioState marks resources involved in the scenario incidents.
+func applyScenarioState(resources []Resource) {
+	for i := range resources {
+		res := &resources[i]
+		switch {
+		case res.Name == "orders-primary":
+			res.Status = "degraded"
+			res.Attributes["maxConnections"] = 200
+			res.Attributes["activeConnections"] = 200
+			res.Metadata["scenario_id"] = "cascading-failure"
+			res.Metadata["incident_id"] = "inc-scenario-002"
+			res.Metadata["is_scenario"] = true
+		case res.Service == "svc-search" && res.Type == TypeInstance:
+			res.Status = "impaired"
+			res.Attributes["cpuUtilization"] = 0.94
+			res.Metadata["scenario_id"] = "autoscaling-lag"
+			res.Metadata["incident_id"] = "inc-scenario-005"
+			res.Metadata["is_scenario"] = true
+		}
+	}
+}
+
+func statefulAttributes(typ, name string) map[string]any {
+	switch typ {
+	case TypeDatabase:
+		attrs := map[string]any{"engine": "postgres", "engineVersion": "15.4", "instanceClass": "db.r6g.xlarge", "multiAZ": true, "role": "primary"}
+		if strings.HasSuffix(name, "-replica") {
+			attrs["role"] = "replica"
+			attrs["multiAZ"] = false
+		}
+		return attrs
+	case TypeCache:
+		return map[string]any{"engine": "redis", "engineVersion": "7.0", "nodeType": "cache.r6g.large", "nodes": 3}
+	case TypeBucket:
+		return map[string]any{"versioning": true, "encryption": "aws:kms", "publicAccessBlocked": name != "web-static-assets"}
+	case TypeQueue:
+		return map[string]any{"fifo": false, "visibilityTimeoutSeconds": 30, "deadLetterQueue": name + "-dlq"}
+	default:
+		return map[string]any{}
+	}
+}
+
+func resourceID(typ, name string, seed uint32) string {
+	switch typ {
+	case TypeInstance:
+		return fmt.Sprintf("i-%08x%04x", seed, hash(name)&0xffff)
+	case TypeLoadBalancer:
+		return "alb-" + name
+	case TypeDatabase:
+		return "db-" + name
+	case TypeCache:
+		return "cache-" + name
+	case TypeQueue:
+		return "sqs-" + name
+	default:
+		return "s3-" + name
+	}
+}
+
+func resourceARN(typ, name, region, account string) string {
+	switch typ {
+	case TypeInstance:
+		return fmt.Sprintf("arn:aws:ec2:%s:%s:instance/%s", region, account, name)
+	case TypeLoadBalancer:
+		return fmt.Sprintf("arn:aws:elasticloadbalancing:%s:%s:loadbalancer/app/%s", region, account, name)
+	case TypeDatabase:
+		return fmt.Sprintf("arn:aws:rds:%s:%s:db:%s", region, account, name)
+	case TypeCache:
+		return fmt.Sprintf("arn:aws:elasticache:%s:%s:cluster:%s", region, account, name)
+	case TypeQueue:
+		return fmt.Sprintf("arn:aws:sqs:%s:%s:%s", region, account, name)
+	default:
+		return "arn:aws:s3:::" + name
+	}
+}
+
+func matchesTags(have, want map[string]string) bool {
+	for k, v := range want {
+		if have[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+func hash(s string) uint32 {
+	h := fnv.New32a()
+	h.Write([]byte(s))
+	return h.Sum32()
+}
+
+func cloneResource(in Resource) Resource {
+	out := in
+	out.Tags = mockutil.CloneStringMap(in.Tags)
+	out.Attributes = mockutil.CloneMap(in.Attributes)
+	out.Metadata = mockutil.CloneMap(in.Metadata)
+	return out
+}
+
+func parseConfig(cfg map[string]any) Config {
+	out := Config{Source: "mock", Environment: "prod", Account: "123456789012"}
+	if v, ok := cfg["source"].(string); ok && v != "" {
+		out.Source = v
+	}
+	if v, ok := cfg["environment"].(string); ok && v != "" {
+		out.Environment = v
+	}
+	if v, ok := cfg["account"].(string); ok && v != "" {
+		out.Account = v
+	}
+	out.Features = mockutil.ParseFeatures(cfg)
+	return out
+}
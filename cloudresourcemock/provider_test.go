@@ -0,0 +1,84 @@
+package cloudresourcemock
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestQueryByServiceListsBackingInfra(t *testing.T) {
+	prov, err := New(map[string]any{"source": "test"})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+
+	resources, err := prov.Query(context.Background(), Query{Service: "svc-catalog"})
+	if err != nil {
+		t.Fatalf("Query returned error: %v", err)
+	}
+	types := map[string]int{}
+	for _, res := range resources {
+		if res.Service != "svc-catalog" || res.Tags["service"] != "svc-catalog" || res.Tags["team"] != "team-atlas" {
+			t.Fatalf("expected svc-catalog tags, got %+v", res)
+		}
+		if res.Metadata["source"] != "test" {
+			t.Fatalf("expected source metadata, got %v", res.Metadata)
+		}
+		types[res.Type]++
+	}
+	for _, want := range []string{TypeLoadBalancer, TypeInstance, TypeDatabase, TypeBucket} {
+		if types[want] == 0 {
+			t.Fatalf("expected a %s for svc-catalog, got %v", want, types)
+		}
+	}
+
+	again, _ := prov.Query(context.Background(), Query{Service: "svc-catalog"})
+	if len(again) != len(resources) || again[0].ID != resources[0].ID {
+		t.Fatalf("expected deterministic inventory")
+	}
+}
+
+func TestQueryFiltersAndScenarioState(t *testing.T) {
+	prov, _ := New(nil)
+
+	dbs, _ := prov.Query(context.Background(), Query{Type: TypeDatabase, Tags: map[string]string{"team": "team-data"}})
+	if len(dbs) != 2 {
+		t.Fatalf("expected primary and replica for team-data, got %d", len(dbs))
+	}
+	primary, err := prov.Get(context.Background(), "db-orders-primary")
+	if err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+	if primary.Status != "degraded" || primary.Metadata["incident_id"] != "inc-scenario-002" {
+		t.Fatalf("expected degraded primary linked to the cascading failure, got %+v", primary)
+	}
+	byARN, err := prov.Get(context.Background(), primary.ARN)
+	if err != nil || byARN.ID != primary.ID {
+		t.Fatalf("expected lookup by ARN, got %+v, %v", byARN, err)
+	}
+
+	search, _ := prov.Query(context.Background(), Query{Service: "svc-search", Type: TypeInstance, Region: "us-west-2"})
+	if len(search) == 0 || search[0].Status != "impaired" {
+		t.Fatalf("expected impaired svc-search instances in us-west-2, got %+v", search)
+	}
+
+	limited, _ := prov.Query(context.Background(), Query{Limit: 3})
+	if len(limited) != 3 {
+		t.Fatalf("expected limit to apply, got %d", len(limited))
+	}
+
+	if _, err := prov.Get(context.Background(), "i-missing"); err == nil || !strings.Contains(err.Error(), "not_found") {
+		t.Fatalf("expected not_found, got %v", err)
+	}
+}
+
+func TestScenarioEffectsDisabled(t *testing.T) {
+	prov, _ := New(map[string]any{"scenarioEffects": false})
+	primary, err := prov.Get(context.Background(), "db-orders-primary")
+	if err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+	if primary.Status != "running" || primary.Metadata["incident_id"] != nil {
+		t.Fatalf("expected a healthy primary with scenario effects off, got %+v", primary)
+	}
+}
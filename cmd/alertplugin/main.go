@@ -1,14 +1,20 @@
 package main
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/opsorch/opsorch-core/alert"
 	"github.com/opsorch/opsorch-core/schema"
 	"github.com/opsorch/opsorch-mock-adapters/alertmock"
+	// Publishes cost anomaly alerts alongside the operational ones.
+	_ "github.com/opsorch/opsorch-mock-adapters/budgetalertmock"
+	"github.com/opsorch/opsorch-mock-adapters/internal/bundle"
+	"github.com/opsorch/opsorch-mock-adapters/internal/mockutil"
 	"github.com/opsorch/opsorch-mock-adapters/internal/pluginrpc"
 )
 
@@ -17,11 +23,21 @@ func main() {
 		prov     alert.Provider
 		provOnce sync.Once
 		provErr  error
+		// persist is the bundle file the provider's state is flushed to
+		// on shutdown, if any.
+		persist string
+		// operator is this process's simulated operator, working prov.
+		operator = mockutil.NewOperator()
 	)
 
 	pluginrpc.Run(func(req pluginrpc.Request) (any, error) {
 		provOnce.Do(func() {
 			prov, provErr = alertmock.New(req.Config)
+			persist = bundle.PersistPath(req.Config)
+			if mock, ok := prov.(*alertmock.Provider); ok {
+				mock.RegisterOperatorTasks(operator)
+				operator.Start(mockutil.ParseOperatorConfig(req.Config))
+			}
 		})
 		if provErr != nil {
 			return nil, provErr
@@ -33,9 +49,35 @@ func main() {
 			if err := json.Unmarshal(req.Payload, &q); err != nil {
 				return nil, err
 			}
-			return prov.Query(context.Background(), q)
+			var opts queryOptions
+			if err := json.Unmarshal(req.Payload, &opts); err != nil {
+				return nil, err
+			}
+			return prov.Query(opts.context(), q)
 		case "alert.list":
-			return prov.Query(context.Background(), schema.AlertQuery{})
+			var opts queryOptions
+			if len(req.Payload) > 0 {
+				if err := json.Unmarshal(req.Payload, &opts); err != nil {
+					return nil, err
+				}
+			}
+			return prov.Query(opts.context(), schema.AlertQuery{})
+		case "alert.stats":
+			mock, ok := prov.(*alertmock.Provider)
+			if !ok {
+				return nil, errUnknownMethod(req.Method)
+			}
+			var q schema.AlertQuery
+			var opts queryOptions
+			if len(req.Payload) > 0 {
+				if err := json.Unmarshal(req.Payload, &q); err != nil {
+					return nil, err
+				}
+				if err := json.Unmarshal(req.Payload, &opts); err != nil {
+					return nil, err
+				}
+			}
+			return mock.Stats(opts.context(), q)
 		case "alert.get":
 			var payload struct {
 				ID string `json:"id"`
@@ -44,10 +86,146 @@ func main() {
 				return nil, err
 			}
 			return prov.Get(context.Background(), payload.ID)
+		case "alert.runbookPlan":
+			mock, ok := prov.(*alertmock.Provider)
+			if !ok {
+				return nil, errUnknownMethod(req.Method)
+			}
+			var payload struct {
+				ID string `json:"id"`
+			}
+			if err := json.Unmarshal(req.Payload, &payload); err != nil {
+				return nil, err
+			}
+			return mock.RunbookPlan(context.Background(), payload.ID)
+		case "alert.rules":
+			return alertmock.AlertRules(), nil
+		case "alert.fire":
+			mock, ok := prov.(*alertmock.Provider)
+			if !ok {
+				return nil, errUnknownMethod(req.Method)
+			}
+			var payload alertmock.FireRequest
+			if err := json.Unmarshal(req.Payload, &payload); err != nil {
+				return nil, err
+			}
+			return mock.Fire(context.Background(), payload)
+		case "alert.simulateOutage":
+			mock, ok := prov.(*alertmock.Provider)
+			if !ok {
+				return nil, errUnknownMethod(req.Method)
+			}
+			var payload alertmock.OutageRequest
+			if err := json.Unmarshal(req.Payload, &payload); err != nil {
+				return nil, err
+			}
+			return mock.SimulateOutage(context.Background(), payload)
+		case "operator.step":
+			var payload struct {
+				Actor string `json:"actor"`
+			}
+			if len(req.Payload) > 0 {
+				if err := json.Unmarshal(req.Payload, &payload); err != nil {
+					return nil, err
+				}
+			}
+			if payload.Actor == "" {
+				payload.Actor = mockutil.ParseOperatorConfig(req.Config).Actor
+			}
+			action, acted, err := operator.Step(context.Background(), payload.Actor)
+			if err != nil || !acted {
+				return nil, err
+			}
+			return action, nil
+		case "operator.actions":
+			var payload struct {
+				After int64 `json:"after"`
+			}
+			if len(req.Payload) > 0 {
+				if err := json.Unmarshal(req.Payload, &payload); err != nil {
+					return nil, err
+				}
+			}
+			return mockutil.OperatorActions(payload.After), nil
+		case "alert.acknowledge":
+			mock, ok := prov.(*alertmock.Provider)
+			if !ok {
+				return nil, errUnknownMethod(req.Method)
+			}
+			var payload struct {
+				ID    string `json:"id"`
+				Actor string `json:"actor"`
+			}
+			if err := json.Unmarshal(req.Payload, &payload); err != nil {
+				return nil, err
+			}
+			return mock.Acknowledge(context.Background(), payload.ID, payload.Actor)
+		case "alert.snapshot":
+			mock, ok := prov.(*alertmock.Provider)
+			if !ok {
+				return nil, errUnknownMethod(req.Method)
+			}
+			return snapshotBundle(mock), nil
+		case "alert.restore":
+			mock, ok := prov.(*alertmock.Provider)
+			if !ok {
+				return nil, errUnknownMethod(req.Method)
+			}
+			b, err := bundle.Decode(bytes.NewReader(req.Payload), bundle.FormatJSON)
+			if err != nil {
+				return nil, err
+			}
+			mock.Restore(b)
+			return snapshotBundle(mock), nil
+		case "ref.resolve":
+			return pluginrpc.ResolveRef(context.Background(), req.Payload)
+		case "search.global":
+			return pluginrpc.Search(context.Background(), req.Payload)
+		case "search.docs":
+			return pluginrpc.SearchDocs(context.Background(), req.Payload)
 		default:
 			return nil, errUnknownMethod(req.Method)
 		}
-	})
+	}, pluginrpc.WithPayloadSchema("alert.query", queryOptions{}), pluginrpc.WithShutdownHook(func() error {
+		operator.Stop()
+		mock, ok := prov.(*alertmock.Provider)
+		if !ok || persist == "" {
+			return nil
+		}
+		return bundle.WriteFile(persist, snapshotBundle(mock))
+	}))
+}
+
+// queryOptions are plugin-level query extensions that are not part of schema.AlertQuery.
+type queryOptions struct {
+	mockutil.FilterOptions
+	Integrations []string `json:"integrations"`
+	EntityType   string   `json:"entityType"`
+	EntityID     string   `json:"entityId"`
+	// SortBy "priority" orders results by priority score, highest first.
+	SortBy string `json:"sortBy"`
+}
+
+func (o queryOptions) context() context.Context {
+	ctx := mockutil.WithFilter(context.Background(), o.Filter)
+	if len(o.Integrations) > 0 {
+		ctx = alertmock.WithIntegrations(ctx, o.Integrations...)
+	}
+	if o.EntityType != "" || o.EntityID != "" {
+		ctx = alertmock.WithEntity(ctx, o.EntityType, o.EntityID)
+	}
+	if o.SortBy == "priority" {
+		ctx = alertmock.WithSortByPriority(ctx)
+	}
+	return ctx
+}
+
+// snapshotBundle returns the provider's alerts as a bundle with only
+// that section set, the shape mockexport merges.
+func snapshotBundle(mock *alertmock.Provider) *bundle.Bundle {
+	b := &bundle.Bundle{Version: bundle.Version, GeneratedAt: time.Now().UTC()}
+	b.Alerts = mock.Snapshot()
+	return b
 }
 
 func errUnknownMethod(method string) error {
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/opsorch/opsorch-mock-adapters/auditmock"
+	"github.com/opsorch/opsorch-mock-adapters/internal/pluginrpc"
+)
+
+func main() {
+	var (
+		prov     *auditmock.Provider
+		provOnce sync.Once
+		provErr  error
+	)
+
+	pluginrpc.Run(func(req pluginrpc.Request) (any, error) {
+		provOnce.Do(func() {
+			prov, provErr = auditmock.New(req.Config)
+		})
+		if provErr != nil {
+			return nil, provErr
+		}
+
+		switch req.Method {
+		case "audit.query":
+			var q auditmock.Query
+			if len(req.Payload) > 0 {
+				if err := json.Unmarshal(req.Payload, &q); err != nil {
+					return nil, err
+				}
+			}
+			return prov.Query(context.Background(), q)
+		case "audit.get":
+			var payload struct {
+				ID string `json:"id"`
+			}
+			if err := json.Unmarshal(req.Payload, &payload); err != nil {
+				return nil, err
+			}
+			return prov.Get(context.Background(), payload.ID)
+		default:
+			return nil, errUnknownMethod(req.Method)
+		}
+	})
+}
+
+func errUnknownMethod(method string) error {
+	return fmt.Errorf("unknown method %s", method)
+}
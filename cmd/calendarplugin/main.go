@@ -0,0 +1,53 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/opsorch/opsorch-mock-adapters/calendarmock"
+	"github.com/opsorch/opsorch-mock-adapters/internal/pluginrpc"
+)
+
+func main() {
+	var (
+		prov     *calendarmock.Provider
+		provOnce sync.Once
+		provErr  error
+	)
+
+	pluginrpc.Run(func(req pluginrpc.Request) (any, error) {
+		provOnce.Do(func() {
+			prov, provErr = calendarmock.New(req.Config)
+		})
+		if provErr != nil {
+			return nil, provErr
+		}
+
+		switch req.Method {
+		case "calendar.query":
+			var q calendarmock.Query
+			if len(req.Payload) > 0 {
+				if err := json.Unmarshal(req.Payload, &q); err != nil {
+					return nil, err
+				}
+			}
+			return prov.Query(context.Background(), q)
+		case "calendar.get":
+			var payload struct {
+				ID string `json:"id"`
+			}
+			if err := json.Unmarshal(req.Payload, &payload); err != nil {
+				return nil, err
+			}
+			return prov.Get(context.Background(), payload.ID)
+		default:
+			return nil, errUnknownMethod(req.Method)
+		}
+	})
+}
+
+func errUnknownMethod(method string) error {
+	return fmt.Errorf("unknown method %s", method)
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/opsorch/opsorch-mock-adapters/capacitymock"
+	"github.com/opsorch/opsorch-mock-adapters/internal/pluginrpc"
+)
+
+func main() {
+	var (
+		prov     *capacitymock.Provider
+		provOnce sync.Once
+		provErr  error
+	)
+
+	pluginrpc.Run(func(req pluginrpc.Request) (any, error) {
+		provOnce.Do(func() {
+			prov, provErr = capacitymock.New(req.Config)
+		})
+		if provErr != nil {
+			return nil, provErr
+		}
+
+		switch req.Method {
+		case "capacity.query":
+			var q capacitymock.Query
+			if len(req.Payload) > 0 {
+				if err := json.Unmarshal(req.Payload, &q); err != nil {
+					return nil, err
+				}
+			}
+			return prov.Query(context.Background(), q)
+		case "capacity.recommendations":
+			var q capacitymock.Query
+			if len(req.Payload) > 0 {
+				if err := json.Unmarshal(req.Payload, &q); err != nil {
+					return nil, err
+				}
+			}
+			return prov.Recommendations(context.Background(), q)
+		default:
+			return nil, errUnknownMethod(req.Method)
+		}
+	})
+}
+
+func errUnknownMethod(method string) error {
+	return fmt.Errorf("unknown method %s", method)
+}
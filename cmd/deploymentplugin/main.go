@@ -1,14 +1,18 @@
 package main
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/opsorch/opsorch-core/deployment"
 	"github.com/opsorch/opsorch-core/schema"
 	"github.com/opsorch/opsorch-mock-adapters/deploymentmock"
+	"github.com/opsorch/opsorch-mock-adapters/internal/bundle"
+	"github.com/opsorch/opsorch-mock-adapters/internal/mockutil"
 	"github.com/opsorch/opsorch-mock-adapters/internal/pluginrpc"
 )
 
@@ -17,18 +21,28 @@ func main() {
 		prov     deployment.Provider
 		provOnce sync.Once
 		provErr  error
+		// persist is the bundle file the provider's state is flushed to
+		// on shutdown, if any.
+		persist string
 	)
 
 	pluginrpc.Run(func(req pluginrpc.Request) (any, error) {
 		provOnce.Do(func() {
 			prov, provErr = deploymentmock.New(req.Config)
+			persist = bundle.PersistPath(req.Config)
 		})
 		if provErr != nil {
 			return nil, provErr
 		}
 
 		return handleRequest(prov, req)
-	})
+	}, pluginrpc.WithShutdownHook(func() error {
+		mock, ok := prov.(*deploymentmock.Provider)
+		if !ok || persist == "" {
+			return nil
+		}
+		return bundle.WriteFile(persist, snapshotBundle(mock))
+	}))
 }
 
 func handleRequest(prov deployment.Provider, req pluginrpc.Request) (any, error) {
@@ -38,7 +52,15 @@ func handleRequest(prov deployment.Provider, req pluginrpc.Request) (any, error)
 		if err := json.Unmarshal(req.Payload, &query); err != nil {
 			return nil, err
 		}
-		return prov.Query(context.Background(), query)
+		var opts queryOptions
+		if err := json.Unmarshal(req.Payload, &opts); err != nil {
+			return nil, err
+		}
+		deployments, err := prov.Query(opts.context(), query)
+		if err != nil {
+			return nil, err
+		}
+		return mockutil.ProjectFields(deployments, opts.Fields)
 	case "deployment.get":
 		var payload struct {
 			ID string `json:"id"`
@@ -47,11 +69,120 @@ func handleRequest(prov deployment.Provider, req pluginrpc.Request) (any, error)
 			return nil, err
 		}
 		return prov.Get(context.Background(), payload.ID)
+	case "deployment.drift":
+		mock, ok := prov.(*deploymentmock.Provider)
+		if !ok {
+			return nil, errUnknownMethod(req.Method)
+		}
+		var query deploymentmock.DriftQuery
+		if err := json.Unmarshal(req.Payload, &query); err != nil {
+			return nil, err
+		}
+		return mock.Drift(context.Background(), query)
+	case "deployment.regions.get":
+		mock, ok := prov.(*deploymentmock.Provider)
+		if !ok {
+			return nil, errUnknownMethod(req.Method)
+		}
+		var payload struct {
+			ID string `json:"id"`
+		}
+		if err := json.Unmarshal(req.Payload, &payload); err != nil {
+			return nil, err
+		}
+		return mock.Regions(context.Background(), payload.ID)
+	case "deployment.history":
+		mock, ok := prov.(*deploymentmock.Provider)
+		if !ok {
+			return nil, errUnknownMethod(req.Method)
+		}
+		var query deploymentmock.HistoryQuery
+		if err := json.Unmarshal(req.Payload, &query); err != nil {
+			return nil, err
+		}
+		return mock.History(context.Background(), query)
+	case "deployment.failures":
+		mock, ok := prov.(*deploymentmock.Provider)
+		if !ok {
+			return nil, errUnknownMethod(req.Method)
+		}
+		var query deploymentmock.HistoryQuery
+		if err := json.Unmarshal(req.Payload, &query); err != nil {
+			return nil, err
+		}
+		return mock.Failures(context.Background(), query)
+	case "deployment.create":
+		mock, ok := prov.(*deploymentmock.Provider)
+		if !ok {
+			return nil, errUnknownMethod(req.Method)
+		}
+		var in deploymentmock.CreateRequest
+		if err := json.Unmarshal(req.Payload, &in); err != nil {
+			return nil, err
+		}
+		return mock.Create(context.Background(), in)
+	case "deployment.approve":
+		mock, ok := prov.(*deploymentmock.Provider)
+		if !ok {
+			return nil, errUnknownMethod(req.Method)
+		}
+		var in deploymentmock.ApproveRequest
+		if err := json.Unmarshal(req.Payload, &in); err != nil {
+			return nil, err
+		}
+		return mock.Approve(context.Background(), in)
+	case "deployment.freezes":
+		mock, ok := prov.(*deploymentmock.Provider)
+		if !ok {
+			return nil, errUnknownMethod(req.Method)
+		}
+		return mock.Freezes(context.Background()), nil
+	case "deployment.snapshot":
+		mock, ok := prov.(*deploymentmock.Provider)
+		if !ok {
+			return nil, errUnknownMethod(req.Method)
+		}
+		return snapshotBundle(mock), nil
+	case "deployment.restore":
+		mock, ok := prov.(*deploymentmock.Provider)
+		if !ok {
+			return nil, errUnknownMethod(req.Method)
+		}
+		b, err := bundle.Decode(bytes.NewReader(req.Payload), bundle.FormatJSON)
+		if err != nil {
+			return nil, err
+		}
+		mock.Restore(b)
+		return snapshotBundle(mock), nil
+	case "ref.resolve":
+		return pluginrpc.ResolveRef(context.Background(), req.Payload)
+	case "search.global":
+		return pluginrpc.Search(context.Background(), req.Payload)
+	case "search.docs":
+		return pluginrpc.SearchDocs(context.Background(), req.Payload)
 	default:
 		return nil, errUnknownMethod(req.Method)
 	}
 }
 
+// queryOptions are plugin-level query extensions that are not part of schema.DeploymentQuery.
+type queryOptions struct {
+	mockutil.ProjectionOptions
+	mockutil.FilterOptions
+}
+
+func (o queryOptions) context() context.Context {
+	return mockutil.WithFilter(context.Background(), o.Filter)
+}
+
+// snapshotBundle returns the provider's deployments as a bundle with only
+// that section set, the shape mockexport merges.
+func snapshotBundle(mock *deploymentmock.Provider) *bundle.Bundle {
+	b := &bundle.Bundle{Version: bundle.Version, GeneratedAt: time.Now().UTC()}
+	b.Deployments = mock.Snapshot()
+	return b
+}
+
 func errUnknownMethod(method string) error {
 	return fmt.Errorf("unknown method %s", method)
 }
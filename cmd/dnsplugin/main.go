@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/opsorch/opsorch-mock-adapters/dnsmock"
+	"github.com/opsorch/opsorch-mock-adapters/internal/pluginrpc"
+)
+
+func main() {
+	var (
+		prov     *dnsmock.Provider
+		provOnce sync.Once
+		provErr  error
+	)
+
+	pluginrpc.Run(func(req pluginrpc.Request) (any, error) {
+		provOnce.Do(func() {
+			prov, provErr = dnsmock.New(req.Config)
+		})
+		if provErr != nil {
+			return nil, provErr
+		}
+
+		switch req.Method {
+		case "cert.list":
+			var q dnsmock.CertQuery
+			if len(req.Payload) > 0 {
+				if err := json.Unmarshal(req.Payload, &q); err != nil {
+					return nil, err
+				}
+			}
+			return prov.ListCertificates(context.Background(), q)
+		case "cert.get":
+			var payload struct {
+				ID     string `json:"id"`
+				Domain string `json:"domain"`
+			}
+			if err := json.Unmarshal(req.Payload, &payload); err != nil {
+				return nil, err
+			}
+			ref := payload.ID
+			if ref == "" {
+				ref = payload.Domain
+			}
+			return prov.GetCertificate(context.Background(), ref)
+		case "dns.records.query":
+			var q dnsmock.RecordQuery
+			if len(req.Payload) > 0 {
+				if err := json.Unmarshal(req.Payload, &q); err != nil {
+					return nil, err
+				}
+			}
+			return prov.QueryRecords(context.Background(), q)
+		default:
+			return nil, errUnknownMethod(req.Method)
+		}
+	})
+}
+
+func errUnknownMethod(method string) error {
+	return fmt.Errorf("unknown method %s", method)
+}
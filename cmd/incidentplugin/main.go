@@ -1,14 +1,18 @@
 package main
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/opsorch/opsorch-core/incident"
 	"github.com/opsorch/opsorch-core/schema"
 	"github.com/opsorch/opsorch-mock-adapters/incidentmock"
+	"github.com/opsorch/opsorch-mock-adapters/internal/bundle"
+	"github.com/opsorch/opsorch-mock-adapters/internal/mockutil"
 	"github.com/opsorch/opsorch-mock-adapters/internal/pluginrpc"
 )
 
@@ -17,25 +21,70 @@ func main() {
 		prov     incident.Provider
 		provOnce sync.Once
 		provErr  error
+		// persist is the bundle file the provider's state is flushed to
+		// on shutdown, if any.
+		persist string
+		// operator is this process's simulated operator, working prov.
+		operator = mockutil.NewOperator()
 	)
 
 	pluginrpc.Run(func(req pluginrpc.Request) (any, error) {
 		provOnce.Do(func() {
 			prov, provErr = incidentmock.New(req.Config)
+			persist = bundle.PersistPath(req.Config)
+			if mock, ok := prov.(*incidentmock.Provider); ok {
+				mock.RegisterOperatorTasks(operator)
+				operator.Start(mockutil.ParseOperatorConfig(req.Config))
+			}
 		})
 		if provErr != nil {
 			return nil, provErr
 		}
 
+		mock, isMock := prov.(*incidentmock.Provider)
+
 		switch req.Method {
 		case "incident.query":
 			var q schema.IncidentQuery
 			if err := json.Unmarshal(req.Payload, &q); err != nil {
 				return nil, err
 			}
-			return prov.Query(context.Background(), q)
+			var opts queryOptions
+			if err := json.Unmarshal(req.Payload, &opts); err != nil {
+				return nil, err
+			}
+			incidents, err := prov.Query(opts.context(), q)
+			if err != nil {
+				return nil, err
+			}
+			return mockutil.ProjectFields(incidents, opts.Fields)
 		case "incident.list":
-			return prov.Query(context.Background(), schema.IncidentQuery{})
+			var opts queryOptions
+			if len(req.Payload) > 0 {
+				if err := json.Unmarshal(req.Payload, &opts); err != nil {
+					return nil, err
+				}
+			}
+			incidents, err := prov.Query(opts.context(), schema.IncidentQuery{})
+			if err != nil {
+				return nil, err
+			}
+			return mockutil.ProjectFields(incidents, opts.Fields)
+		case "incident.stats":
+			if !isMock {
+				return nil, errUnknownMethod(req.Method)
+			}
+			var q schema.IncidentQuery
+			var opts queryOptions
+			if len(req.Payload) > 0 {
+				if err := json.Unmarshal(req.Payload, &q); err != nil {
+					return nil, err
+				}
+				if err := json.Unmarshal(req.Payload, &opts); err != nil {
+					return nil, err
+				}
+			}
+			return mock.Stats(opts.context(), q)
 		case "incident.get":
 			var payload struct {
 				ID string `json:"id"`
@@ -54,11 +103,17 @@ func main() {
 			var payload struct {
 				ID    string                     `json:"id"`
 				Input schema.UpdateIncidentInput `json:"input"`
+				// Actor, when set, enables the mock's role-based permission checks.
+				Actor string `json:"actor"`
 			}
 			if err := json.Unmarshal(req.Payload, &payload); err != nil {
 				return nil, err
 			}
-			return prov.Update(context.Background(), payload.ID, payload.Input)
+			ctx := context.Background()
+			if payload.Actor != "" {
+				ctx = incidentmock.WithActor(ctx, payload.Actor)
+			}
+			return prov.Update(ctx, payload.ID, payload.Input)
 		case "incident.timeline.get":
 			var payload struct {
 				ID string `json:"id"`
@@ -76,10 +131,255 @@ func main() {
 				return nil, err
 			}
 			return nil, prov.AppendTimeline(context.Background(), payload.ID, payload.Entry)
+		case "incident.export":
+			var payload struct {
+				ID     string `json:"id"`
+				Format string `json:"format"`
+			}
+			if err := json.Unmarshal(req.Payload, &payload); err != nil {
+				return nil, err
+			}
+			if !isMock {
+				return nil, errUnknownMethod(req.Method)
+			}
+			return mock.Export(context.Background(), payload.ID, payload.Format)
+		case "incident.participants.list":
+			var payload struct {
+				ID         string `json:"id"`
+				ActiveOnly bool   `json:"activeOnly"`
+			}
+			if err := json.Unmarshal(req.Payload, &payload); err != nil {
+				return nil, err
+			}
+			if !isMock {
+				return nil, errUnknownMethod(req.Method)
+			}
+			return mock.Participants(context.Background(), payload.ID, payload.ActiveOnly)
+		case "incident.participants.join":
+			var payload struct {
+				ID   string `json:"id"`
+				Name string `json:"name"`
+				Role string `json:"role"`
+			}
+			if err := json.Unmarshal(req.Payload, &payload); err != nil {
+				return nil, err
+			}
+			if !isMock {
+				return nil, errUnknownMethod(req.Method)
+			}
+			return mock.JoinIncident(context.Background(), payload.ID, payload.Name, payload.Role)
+		case "incident.participants.leave":
+			var payload struct {
+				ID   string `json:"id"`
+				Name string `json:"name"`
+			}
+			if err := json.Unmarshal(req.Payload, &payload); err != nil {
+				return nil, err
+			}
+			if !isMock {
+				return nil, errUnknownMethod(req.Method)
+			}
+			return mock.LeaveIncident(context.Background(), payload.ID, payload.Name)
+		case "incident.handoff.create":
+			var note incidentmock.HandoffNote
+			if err := json.Unmarshal(req.Payload, &note); err != nil {
+				return nil, err
+			}
+			if !isMock {
+				return nil, errUnknownMethod(req.Method)
+			}
+			return mock.CreateHandoff(context.Background(), note)
+		case "incident.handoff.list":
+			var payload struct {
+				ID string `json:"id"`
+			}
+			if err := json.Unmarshal(req.Payload, &payload); err != nil {
+				return nil, err
+			}
+			if !isMock {
+				return nil, errUnknownMethod(req.Method)
+			}
+			return mock.Handoffs(context.Background(), payload.ID)
+		case "incident.impact":
+			var payload struct {
+				ID string `json:"id"`
+			}
+			if err := json.Unmarshal(req.Payload, &payload); err != nil {
+				return nil, err
+			}
+			if !isMock {
+				return nil, errUnknownMethod(req.Method)
+			}
+			return mock.Impact(context.Background(), payload.ID)
+		case "incident.similar":
+			var payload struct {
+				ID    string `json:"id"`
+				Limit int    `json:"limit"`
+			}
+			if err := json.Unmarshal(req.Payload, &payload); err != nil {
+				return nil, err
+			}
+			if !isMock {
+				return nil, errUnknownMethod(req.Method)
+			}
+			return mock.Similar(context.Background(), payload.ID, payload.Limit)
+		case "incident.actionItems":
+			var in incidentmock.ActionItemsInput
+			if err := json.Unmarshal(req.Payload, &in); err != nil {
+				return nil, err
+			}
+			if !isMock {
+				return nil, errUnknownMethod(req.Method)
+			}
+			return mock.ActionItems(context.Background(), in)
+		case "incident.declare":
+			var in incidentmock.DeclareInput
+			if err := json.Unmarshal(req.Payload, &in); err != nil {
+				return nil, err
+			}
+			if !isMock {
+				return nil, errUnknownMethod(req.Method)
+			}
+			return mock.Declare(context.Background(), in)
+		case "incident.tags.taxonomy":
+			if !isMock {
+				return nil, errUnknownMethod(req.Method)
+			}
+			return mock.TagTaxonomy(context.Background()), nil
+		case "incident.tags.stats":
+			if !isMock {
+				return nil, errUnknownMethod(req.Method)
+			}
+			var q schema.IncidentQuery
+			var opts queryOptions
+			if len(req.Payload) > 0 {
+				if err := json.Unmarshal(req.Payload, &q); err != nil {
+					return nil, err
+				}
+				if err := json.Unmarshal(req.Payload, &opts); err != nil {
+					return nil, err
+				}
+			}
+			return mock.TagStats(opts.context(), q)
+		case "incident.resolution.taxonomy":
+			if !isMock {
+				return nil, errUnknownMethod(req.Method)
+			}
+			return mock.ResolutionTaxonomy(context.Background()), nil
+		case "incident.resolution.stats":
+			if !isMock {
+				return nil, errUnknownMethod(req.Method)
+			}
+			var q schema.IncidentQuery
+			var opts queryOptions
+			if len(req.Payload) > 0 {
+				if err := json.Unmarshal(req.Payload, &q); err != nil {
+					return nil, err
+				}
+				if err := json.Unmarshal(req.Payload, &opts); err != nil {
+					return nil, err
+				}
+			}
+			return mock.ResolutionStats(opts.context(), q)
+		case "incident.severities":
+			if !isMock {
+				return nil, errUnknownMethod(req.Method)
+			}
+			return mock.Severities(context.Background()), nil
+		case "operator.step":
+			var payload struct {
+				Actor string `json:"actor"`
+			}
+			if len(req.Payload) > 0 {
+				if err := json.Unmarshal(req.Payload, &payload); err != nil {
+					return nil, err
+				}
+			}
+			if payload.Actor == "" {
+				payload.Actor = mockutil.ParseOperatorConfig(req.Config).Actor
+			}
+			action, acted, err := operator.Step(context.Background(), payload.Actor)
+			if err != nil || !acted {
+				return nil, err
+			}
+			return action, nil
+		case "operator.actions":
+			var payload struct {
+				After int64 `json:"after"`
+			}
+			if len(req.Payload) > 0 {
+				if err := json.Unmarshal(req.Payload, &payload); err != nil {
+					return nil, err
+				}
+			}
+			return mockutil.OperatorActions(payload.After), nil
+		case "incident.snapshot":
+			mock, ok := prov.(*incidentmock.Provider)
+			if !ok {
+				return nil, errUnknownMethod(req.Method)
+			}
+			return snapshotBundle(mock), nil
+		case "incident.restore":
+			mock, ok := prov.(*incidentmock.Provider)
+			if !ok {
+				return nil, errUnknownMethod(req.Method)
+			}
+			b, err := bundle.Decode(bytes.NewReader(req.Payload), bundle.FormatJSON)
+			if err != nil {
+				return nil, err
+			}
+			mock.Restore(b)
+			return snapshotBundle(mock), nil
+		case "ref.resolve":
+			return pluginrpc.ResolveRef(context.Background(), req.Payload)
+		case "search.global":
+			return pluginrpc.Search(context.Background(), req.Payload)
+		case "search.docs":
+			return pluginrpc.SearchDocs(context.Background(), req.Payload)
 		default:
 			return nil, errUnknownMethod(req.Method)
 		}
-	})
+	},
+		pluginrpc.WithPayloadSchema("incident.query", queryOptions{}),
+		pluginrpc.WithPayloadSchema("incident.update", struct {
+			Actor string `json:"actor"`
+		}{}),
+		pluginrpc.WithShutdownHook(func() error {
+			operator.Stop()
+			mock, ok := prov.(*incidentmock.Provider)
+			if !ok || persist == "" {
+				return nil
+			}
+			return bundle.WriteFile(persist, snapshotBundle(mock))
+		}),
+	)
+}
+
+// queryOptions are plugin-level query extensions that are not part of schema.IncidentQuery.
+type queryOptions struct {
+	mockutil.ProjectionOptions
+	mockutil.FilterOptions
+	BreachedOnly bool     `json:"breachedOnly"`
+	Tags         []string `json:"tags"`
+}
+
+func (o queryOptions) context() context.Context {
+	ctx := mockutil.WithFilter(context.Background(), o.Filter)
+	if o.BreachedOnly {
+		ctx = incidentmock.WithBreachedOnly(ctx)
+	}
+	if len(o.Tags) > 0 {
+		ctx = incidentmock.WithTags(ctx, o.Tags...)
+	}
+	return ctx
+}
+
+// snapshotBundle returns the provider's incidents and timelines as a bundle with only
+// that section set, the shape mockexport merges.
+func snapshotBundle(mock *incidentmock.Provider) *bundle.Bundle {
+	b := &bundle.Bundle{Version: bundle.Version, GeneratedAt: time.Now().UTC()}
+	b.Incidents, b.Timelines = mock.Snapshot()
+	return b
 }
 
 func errUnknownMethod(method string) error {
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/opsorch/opsorch-mock-adapters/internal/pluginrpc"
+	"github.com/opsorch/opsorch-mock-adapters/runbookdocmock"
+)
+
+func main() {
+	var (
+		prov     *runbookdocmock.Provider
+		provOnce sync.Once
+		provErr  error
+	)
+
+	pluginrpc.Run(func(req pluginrpc.Request) (any, error) {
+		provOnce.Do(func() {
+			prov, provErr = runbookdocmock.New(req.Config)
+		})
+		if provErr != nil {
+			return nil, provErr
+		}
+
+		switch req.Method {
+		case "kb.search":
+			var q runbookdocmock.SearchQuery
+			if len(req.Payload) > 0 {
+				if err := json.Unmarshal(req.Payload, &q); err != nil {
+					return nil, err
+				}
+			}
+			return prov.Search(context.Background(), q)
+		case "kb.get":
+			var payload struct {
+				ID  string `json:"id"`
+				URL string `json:"url"`
+			}
+			if err := json.Unmarshal(req.Payload, &payload); err != nil {
+				return nil, err
+			}
+			ref := payload.ID
+			if ref == "" {
+				ref = payload.URL
+			}
+			return prov.Get(context.Background(), ref)
+		default:
+			return nil, errUnknownMethod(req.Method)
+		}
+	})
+}
+
+func errUnknownMethod(method string) error {
+	return fmt.Errorf("unknown method %s", method)
+}
@@ -27,6 +27,8 @@ func main() {
 			return nil, provErr
 		}
 
+		mock, isMock := prov.(*messagingmock.Provider)
+
 		switch req.Method {
 		case "messaging.send":
 			var msg schema.Message
@@ -34,6 +36,46 @@ func main() {
 				return nil, err
 			}
 			return prov.Send(context.Background(), msg)
+		case "messaging.commands.inject":
+			var payload struct {
+				Text    string `json:"text"`
+				Channel string `json:"channel"`
+				User    string `json:"user"`
+			}
+			if err := json.Unmarshal(req.Payload, &payload); err != nil {
+				return nil, err
+			}
+			if !isMock {
+				return nil, errUnknownMethod(req.Method)
+			}
+			return mock.InjectCommand(context.Background(), payload.Text, payload.Channel, payload.User)
+		case "messaging.commands.poll":
+			var payload struct {
+				After int64 `json:"after"`
+			}
+			if len(req.Payload) > 0 {
+				if err := json.Unmarshal(req.Payload, &payload); err != nil {
+					return nil, err
+				}
+			}
+			if !isMock {
+				return nil, errUnknownMethod(req.Method)
+			}
+			return mock.Commands(payload.After), nil
+		case "messaging.templates.list":
+			if !isMock {
+				return nil, errUnknownMethod(req.Method)
+			}
+			return mock.Templates(context.Background()), nil
+		case "messaging.template.render":
+			var payload messagingmock.RenderRequest
+			if err := json.Unmarshal(req.Payload, &payload); err != nil {
+				return nil, err
+			}
+			if !isMock {
+				return nil, errUnknownMethod(req.Method)
+			}
+			return mock.RenderTemplate(context.Background(), payload)
 		default:
 			return nil, errUnknownMethod(req.Method)
 		}
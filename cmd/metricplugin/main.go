@@ -14,14 +14,18 @@ import (
 
 func main() {
 	var (
-		prov     metric.Provider
+		prov     *metricmock.Provider
 		provOnce sync.Once
 		provErr  error
 	)
 
 	pluginrpc.Run(func(req pluginrpc.Request) (any, error) {
 		provOnce.Do(func() {
-			prov, provErr = metricmock.New(req.Config)
+			var p metric.Provider
+			p, provErr = metricmock.New(req.Config)
+			if provErr == nil {
+				prov = p.(*metricmock.Provider)
+			}
 		})
 		if provErr != nil {
 			return nil, provErr
@@ -33,17 +37,81 @@ func main() {
 			if err := json.Unmarshal(req.Payload, &q); err != nil {
 				return nil, err
 			}
-			return prov.Query(context.Background(), q)
+			var opts queryOptions
+			if err := json.Unmarshal(req.Payload, &opts); err != nil {
+				return nil, err
+			}
+			return prov.Query(opts.context(), q)
 		case "metric.describe":
 			var scope schema.QueryScope
 			if err := json.Unmarshal(req.Payload, &scope); err != nil {
 				return nil, err
 			}
 			return prov.Describe(context.Background(), scope)
+		case "metric.aggregate":
+			var q metricmock.AggregateQuery
+			if err := json.Unmarshal(req.Payload, &q); err != nil {
+				return nil, err
+			}
+			var opts queryOptions
+			if err := json.Unmarshal(req.Payload, &opts); err != nil {
+				return nil, err
+			}
+			return prov.Aggregate(opts.context(), q)
+		case "metric.anomalyTemplates":
+			return metricmock.AnomalyTemplates(), nil
+		case "metric.applyTemplate":
+			var payload struct {
+				Template string `json:"template"`
+				metricmock.TemplateTarget
+			}
+			if err := json.Unmarshal(req.Payload, &payload); err != nil {
+				return nil, err
+			}
+			return prov.ApplyTemplate(context.Background(), payload.Template, payload.TemplateTarget)
+		case "metric.injectAnomaly":
+			var in metricmock.AnomalyInjection
+			if err := json.Unmarshal(req.Payload, &in); err != nil {
+				return nil, err
+			}
+			return prov.InjectAnomaly(context.Background(), in)
+		case "metric.endpoints":
+			var q metricmock.EndpointQuery
+			if err := json.Unmarshal(req.Payload, &q); err != nil {
+				return nil, err
+			}
+			var opts queryOptions
+			if err := json.Unmarshal(req.Payload, &opts); err != nil {
+				return nil, err
+			}
+			return prov.EndpointSeries(opts.context(), q)
+		case "metric.edges":
+			var q metricmock.EdgeQuery
+			if err := json.Unmarshal(req.Payload, &q); err != nil {
+				return nil, err
+			}
+			var opts queryOptions
+			if err := json.Unmarshal(req.Payload, &opts); err != nil {
+				return nil, err
+			}
+			return prov.EdgeSeries(opts.context(), q)
 		default:
 			return nil, errUnknownMethod(req.Method)
 		}
-	})
+	}, pluginrpc.WithPayloadSchema("metric.query", queryOptions{}))
+}
+
+// queryOptions are plugin-level query extensions that are not part of schema.MetricQuery.
+type queryOptions struct {
+	NormalizeUnits bool `json:"normalizeUnits"`
+}
+
+func (o queryOptions) context() context.Context {
+	ctx := context.Background()
+	if o.NormalizeUnits {
+		ctx = metricmock.WithNormalizedUnits(ctx)
+	}
+	return ctx
 }
 
 func errUnknownMethod(method string) error {
@@ -0,0 +1,208 @@
+// Command mockexport dumps the state of the stateful mock providers into a
+// single JSON or YAML bundle, and reloads bundles to verify or convert them.
+//
+//	mockexport export [-plugins dir] [-config providers.json] [-o bundle.yaml] [-format yaml]
+//	mockexport load -i bundle.json [-plugins dir] [-o bundle.yaml]
+//
+// With -plugins, both commands go through the running plugins' control
+// sockets in dir (see pluginrpc.ControlDirEnvVar): export asks each plugin for
+// its live state and load restores the bundle into them. Without it, export
+// builds freshly seeded providers from -config and load only decodes the
+// bundle. Plugins also start from a bundle when their config sets "snapshot"
+// to its path.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/opsorch/opsorch-mock-adapters/alertmock"
+	"github.com/opsorch/opsorch-mock-adapters/deploymentmock"
+	"github.com/opsorch/opsorch-mock-adapters/incidentmock"
+	"github.com/opsorch/opsorch-mock-adapters/internal/bundle"
+	"github.com/opsorch/opsorch-mock-adapters/internal/pluginrpc"
+	"github.com/opsorch/opsorch-mock-adapters/orchestrationmock"
+	"github.com/opsorch/opsorch-mock-adapters/ticketmock"
+)
+
+// providerNames are the config sections read from the -config file.
+var providerNames = []string{"alert", "incident", "ticket", "deployment", "orchestration"}
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout, os.Stderr); err != nil {
+		fmt.Fprintln(os.Stderr, "mockexport:", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string, stdout, stderr io.Writer) error {
+	if len(args) == 0 {
+		return fmt.Errorf("usage: mockexport export|load [flags]")
+	}
+	cmd, args := args[0], args[1:]
+
+	fs := flag.NewFlagSet("mockexport "+cmd, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	configPath := fs.String("config", "", "JSON file with per-provider config sections (alert, incident, ticket, deployment, orchestration)")
+	out := fs.String("o", "", "write the bundle to this file instead of stdout")
+	format := fs.String("format", "", "bundle format: json or yaml (default from -o extension, else json)")
+	in := fs.String("i", "", "bundle to load (load only)")
+	plugins := fs.String("plugins", "", "control socket directory of the running plugins (OPSORCH_PLUGIN_CONTROL_DIR)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	configs, err := readConfigs(*configPath)
+	if err != nil {
+		return err
+	}
+	now := time.Now().UTC()
+	var b *bundle.Bundle
+	switch cmd {
+	case "export":
+		if *plugins != "" {
+			b, err = callPlugins(*plugins, configs, "snapshot", nil, now)
+		} else {
+			b, err = collect(configs, now)
+		}
+	case "load":
+		if *in == "" {
+			return fmt.Errorf("load requires -i <bundle>")
+		}
+		if *plugins != "" {
+			var loaded *bundle.Bundle
+			if loaded, err = bundle.ReadFile(*in); err == nil {
+				b, err = callPlugins(*plugins, configs, "restore", loaded, now)
+			}
+			break
+		}
+		for _, name := range providerNames {
+			configs[name][bundle.ConfigKey] = *in
+		}
+		b, err = collect(configs, now)
+	default:
+		return fmt.Errorf("unknown command %q", cmd)
+	}
+	if err != nil {
+		return err
+	}
+	if cmd == "load" {
+		fmt.Fprintf(stderr, "loaded %s: %d alerts, %d incidents, %d timelines, %d tickets, %d deployments, %d plans, %d runs\n",
+			*in, len(b.Alerts), len(b.Incidents), len(b.Timelines), len(b.Tickets), len(b.Deployments), len(b.Plans), len(b.Runs))
+		if *out == "" {
+			return nil
+		}
+	}
+
+	if *format == "" {
+		*format = bundle.FormatForPath(*out)
+	}
+	if *out == "" {
+		return bundle.Encode(stdout, b, *format)
+	}
+	f, err := os.Create(*out)
+	if err != nil {
+		return err
+	}
+	if err := bundle.Encode(f, b, *format); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func readConfigs(path string) (map[string]map[string]any, error) {
+	configs := map[string]map[string]any{}
+	if path != "" {
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(raw, &configs); err != nil {
+			return nil, fmt.Errorf("parse %s: %w", path, err)
+		}
+	}
+	for _, name := range providerNames {
+		if configs[name] == nil {
+			configs[name] = map[string]any{}
+		}
+	}
+	return configs, nil
+}
+
+// callPlugins sends <capability>.<method> with payload to each running plugin
+// through its control socket in dir and merges the sections they answer with
+// into one bundle. snapshot returns a plugin's state; restore loads payload
+// first and returns the state it left.
+func callPlugins(dir string, configs map[string]map[string]any, method string, payload any, now time.Time) (*bundle.Bundle, error) {
+	b := &bundle.Bundle{Version: bundle.Version, GeneratedAt: now}
+	for _, name := range providerNames {
+		client, err := pluginrpc.Dial(pluginrpc.ControlSocket(dir, name+"plugin"))
+		if err != nil {
+			return nil, fmt.Errorf("%s plugin: %w", name, err)
+		}
+		var part bundle.Bundle
+		err = client.Call(name+"."+method, configs[name], payload, &part)
+		client.Close()
+		if err != nil {
+			return nil, fmt.Errorf("%s.%s: %w", name, method, err)
+		}
+		if len(part.Alerts) > 0 {
+			b.Alerts = part.Alerts
+		}
+		if len(part.Incidents) > 0 {
+			b.Incidents, b.Timelines = part.Incidents, part.Timelines
+		}
+		if len(part.Tickets) > 0 {
+			b.Tickets = part.Tickets
+		}
+		if len(part.Deployments) > 0 {
+			b.Deployments = part.Deployments
+		}
+		if len(part.Plans) > 0 || len(part.Runs) > 0 {
+			b.Plans, b.Runs = part.Plans, part.Runs
+		}
+	}
+	return b, nil
+}
+
+// collect builds each provider from its config and snapshots it into a bundle.
+func collect(configs map[string]map[string]any, now time.Time) (*bundle.Bundle, error) {
+	b := &bundle.Bundle{Version: bundle.Version, GeneratedAt: now}
+
+	alerts, err := alertmock.New(configs["alert"])
+	if err != nil {
+		return nil, fmt.Errorf("alert provider: %w", err)
+	}
+	b.Alerts = alerts.(*alertmock.Provider).Snapshot()
+
+	incidents, err := incidentmock.New(configs["incident"])
+	if err != nil {
+		return nil, fmt.Errorf("incident provider: %w", err)
+	}
+	b.Incidents, b.Timelines = incidents.(*incidentmock.Provider).Snapshot()
+
+	tickets, err := ticketmock.New(configs["ticket"])
+	if err != nil {
+		return nil, fmt.Errorf("ticket provider: %w", err)
+	}
+	b.Tickets = tickets.(*ticketmock.Provider).Snapshot()
+
+	deployments, err := deploymentmock.New(configs["deployment"])
+	if err != nil {
+		return nil, fmt.Errorf("deployment provider: %w", err)
+	}
+	b.Deployments = deployments.(*deploymentmock.Provider).Snapshot()
+
+	orchestration, err := orchestrationmock.New(configs["orchestration"])
+	if err != nil {
+		return nil, fmt.Errorf("orchestration provider: %w", err)
+	}
+	b.Plans, b.Runs = orchestration.(*orchestrationmock.Provider).Snapshot()
+
+	return b, nil
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/opsorch/opsorch-mock-adapters/internal/pluginrpc"
+	"github.com/opsorch/opsorch-mock-adapters/networkmock"
+)
+
+func main() {
+	var (
+		prov     *networkmock.Provider
+		provOnce sync.Once
+		provErr  error
+	)
+
+	pluginrpc.Run(func(req pluginrpc.Request) (any, error) {
+		provOnce.Do(func() {
+			prov, provErr = networkmock.New(req.Config)
+		})
+		if provErr != nil {
+			return nil, provErr
+		}
+
+		switch req.Method {
+		case "network.pathcheck":
+			var q networkmock.PathQuery
+			if err := json.Unmarshal(req.Payload, &q); err != nil {
+				return nil, err
+			}
+			return prov.PathCheck(context.Background(), q)
+		case "network.matrix":
+			var q networkmock.MatrixQuery
+			if len(req.Payload) > 0 {
+				if err := json.Unmarshal(req.Payload, &q); err != nil {
+					return nil, err
+				}
+			}
+			return prov.Matrix(context.Background(), q)
+		default:
+			return nil, errUnknownMethod(req.Method)
+		}
+	})
+}
+
+func errUnknownMethod(method string) error {
+	return fmt.Errorf("unknown method %s", method)
+}
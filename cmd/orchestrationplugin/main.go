@@ -1,27 +1,43 @@
 package main
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/opsorch/opsorch-core/orchestration"
 	"github.com/opsorch/opsorch-core/schema"
+	"github.com/opsorch/opsorch-mock-adapters/internal/bundle"
+	"github.com/opsorch/opsorch-mock-adapters/internal/mockutil"
 	"github.com/opsorch/opsorch-mock-adapters/internal/pluginrpc"
 	"github.com/opsorch/opsorch-mock-adapters/orchestrationmock"
 )
 
 func main() {
 	var (
-		prov     orchestration.Provider
+		prov     *orchestrationmock.Provider
 		provOnce sync.Once
 		provErr  error
+		// persist is the bundle file the provider's state is flushed to
+		// on shutdown, if any.
+		persist string
+		// operator is this process's simulated operator, working prov.
+		operator = mockutil.NewOperator()
 	)
 
 	pluginrpc.Run(func(req pluginrpc.Request) (any, error) {
 		provOnce.Do(func() {
-			prov, provErr = orchestrationmock.New(req.Config)
+			var p orchestration.Provider
+			p, provErr = orchestrationmock.New(req.Config)
+			persist = bundle.PersistPath(req.Config)
+			if provErr == nil {
+				prov = p.(*orchestrationmock.Provider)
+				prov.RegisterOperatorTasks(operator)
+				operator.Start(mockutil.ParseOperatorConfig(req.Config))
+			}
 		})
 		if provErr != nil {
 			return nil, provErr
@@ -44,6 +60,22 @@ func main() {
 			}
 			return prov.GetPlan(context.Background(), payload.PlanID)
 
+		case "orchestration.plans.analyze":
+			var payload struct {
+				PlanID string `json:"planId"`
+			}
+			if err := json.Unmarshal(req.Payload, &payload); err != nil {
+				return nil, err
+			}
+			return prov.AnalyzePlan(context.Background(), payload.PlanID)
+
+		case "orchestration.plans.recommend":
+			var in orchestrationmock.RecommendInput
+			if err := json.Unmarshal(req.Payload, &in); err != nil {
+				return nil, err
+			}
+			return prov.RecommendPlans(context.Background(), in)
+
 		case "orchestration.runs.query":
 			var q schema.OrchestrationRunQuery
 			if err := json.Unmarshal(req.Payload, &q); err != nil {
@@ -60,14 +92,52 @@ func main() {
 			}
 			return prov.GetRun(context.Background(), payload.RunID)
 
-		case "orchestration.runs.start":
+		case "orchestration.runs.export":
+			var payload struct {
+				RunID string `json:"runId"`
+			}
+			if err := json.Unmarshal(req.Payload, &payload); err != nil {
+				return nil, err
+			}
+			return prov.ExportRun(context.Background(), payload.RunID)
+
+		case "orchestration.runs.simulate":
 			var payload struct {
 				PlanID string `json:"planId"`
 			}
 			if err := json.Unmarshal(req.Payload, &payload); err != nil {
 				return nil, err
 			}
-			return prov.StartRun(context.Background(), payload.PlanID)
+			return prov.SimulateRun(context.Background(), payload.PlanID)
+
+		case "orchestration.runs.start":
+			var payload struct {
+				PlanID     string `json:"planId"`
+				IncidentID string `json:"incidentId"`
+			}
+			if err := json.Unmarshal(req.Payload, &payload); err != nil {
+				return nil, err
+			}
+			return prov.StartRunForIncident(context.Background(), payload.PlanID, payload.IncidentID)
+
+		case "orchestration.runs.resume":
+			var payload struct {
+				RunID string `json:"runId"`
+				Actor string `json:"actor"`
+			}
+			if err := json.Unmarshal(req.Payload, &payload); err != nil {
+				return nil, err
+			}
+			return prov.ResumeRun(context.Background(), payload.RunID, payload.Actor)
+
+		case "orchestration.runs.forIncident":
+			var payload struct {
+				IncidentID string `json:"incidentId"`
+			}
+			if err := json.Unmarshal(req.Payload, &payload); err != nil {
+				return nil, err
+			}
+			return prov.RunsForIncident(context.Background(), payload.IncidentID)
 
 		case "orchestration.runs.steps.complete":
 			var payload struct {
@@ -85,10 +155,83 @@ func main() {
 			}
 			return nil, nil
 
+		case "orchestration.runs.steps.callback":
+			var payload struct {
+				RunID  string `json:"runId"`
+				StepID string `json:"stepId"`
+				Status string `json:"status"`
+				Note   string `json:"note"`
+			}
+			if err := json.Unmarshal(req.Payload, &payload); err != nil {
+				return nil, err
+			}
+			if err := prov.StepCallback(context.Background(), payload.RunID, payload.StepID, payload.Status, payload.Note); err != nil {
+				return nil, err
+			}
+			return prov.GetRun(context.Background(), payload.RunID)
+
+		case "operator.step":
+			var payload struct {
+				Actor string `json:"actor"`
+			}
+			if len(req.Payload) > 0 {
+				if err := json.Unmarshal(req.Payload, &payload); err != nil {
+					return nil, err
+				}
+			}
+			if payload.Actor == "" {
+				payload.Actor = mockutil.ParseOperatorConfig(req.Config).Actor
+			}
+			action, acted, err := operator.Step(context.Background(), payload.Actor)
+			if err != nil || !acted {
+				return nil, err
+			}
+			return action, nil
+		case "operator.actions":
+			var payload struct {
+				After int64 `json:"after"`
+			}
+			if len(req.Payload) > 0 {
+				if err := json.Unmarshal(req.Payload, &payload); err != nil {
+					return nil, err
+				}
+			}
+			return mockutil.OperatorActions(payload.After), nil
+		case "orchestration.snapshot":
+			return snapshotBundle(prov), nil
+		case "orchestration.restore":
+			b, err := bundle.Decode(bytes.NewReader(req.Payload), bundle.FormatJSON)
+			if err != nil {
+				return nil, err
+			}
+			prov.Restore(b)
+			return snapshotBundle(prov), nil
+		case "ref.resolve":
+			return pluginrpc.ResolveRef(context.Background(), req.Payload)
+
+		case "search.global":
+			return pluginrpc.Search(context.Background(), req.Payload)
+		case "search.docs":
+			return pluginrpc.SearchDocs(context.Background(), req.Payload)
+
 		default:
 			return nil, errUnknownMethod(req.Method)
 		}
-	})
+	}, pluginrpc.WithShutdownHook(func() error {
+		operator.Stop()
+		if prov == nil || persist == "" {
+			return nil
+		}
+		return bundle.WriteFile(persist, snapshotBundle(prov))
+	}))
+}
+
+// snapshotBundle returns the provider's plans and runs as a bundle with only
+// that section set, the shape mockexport merges.
+func snapshotBundle(mock *orchestrationmock.Provider) *bundle.Bundle {
+	b := &bundle.Bundle{Version: bundle.Version, GeneratedAt: time.Now().UTC()}
+	b.Plans, b.Runs = mock.Snapshot()
+	return b
 }
 
 func errUnknownMethod(method string) error {
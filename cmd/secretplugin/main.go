@@ -47,7 +47,17 @@ func main() {
 		default:
 			return nil, errUnknownMethod(req.Method)
 		}
-	})
+	}, pluginrpc.WithRedactor(redactSecretValues))
+}
+
+// redactSecretValues keeps secret values written via secret.put out of request logs.
+func redactSecretValues(method string, payload any) any {
+	if fields, ok := payload.(map[string]any); ok {
+		if _, ok := fields["value"]; ok {
+			fields["value"] = pluginrpc.Redacted
+		}
+	}
+	return payload
 }
 
 func errUnknownMethod(method string) error {
@@ -34,6 +34,47 @@ func main() {
 				return nil, err
 			}
 			return prov.Query(context.Background(), q)
+		case "topology.blastRadius":
+			var payload struct {
+				Component string `json:"component"`
+			}
+			if err := json.Unmarshal(req.Payload, &payload); err != nil {
+				return nil, err
+			}
+			mock, ok := prov.(*servicemock.Provider)
+			if !ok {
+				return nil, errUnknownMethod(req.Method)
+			}
+			return mock.BlastRadius(context.Background(), payload.Component)
+		case "service.endpoints":
+			var payload struct {
+				Service string `json:"service"`
+			}
+			if err := json.Unmarshal(req.Payload, &payload); err != nil {
+				return nil, err
+			}
+			mock, ok := prov.(*servicemock.Provider)
+			if !ok {
+				return nil, errUnknownMethod(req.Method)
+			}
+			return mock.Endpoints(context.Background(), payload.Service)
+		case "service.scorecard":
+			var payload struct {
+				Service string `json:"service"`
+			}
+			if len(req.Payload) > 0 {
+				if err := json.Unmarshal(req.Payload, &payload); err != nil {
+					return nil, err
+				}
+			}
+			mock, ok := prov.(*servicemock.Provider)
+			if !ok {
+				return nil, errUnknownMethod(req.Method)
+			}
+			if payload.Service == "" {
+				return mock.Scorecards(context.Background())
+			}
+			return mock.Scorecard(context.Background(), payload.Service)
 		default:
 			return nil, errUnknownMethod(req.Method)
 		}
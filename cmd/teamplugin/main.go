@@ -5,23 +5,34 @@ import (
 	"encoding/json"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/opsorch/opsorch-core/schema"
 	"github.com/opsorch/opsorch-core/team"
+	"github.com/opsorch/opsorch-mock-adapters/alertmock"
+	"github.com/opsorch/opsorch-mock-adapters/incidentmock"
 	"github.com/opsorch/opsorch-mock-adapters/internal/pluginrpc"
 	"github.com/opsorch/opsorch-mock-adapters/teammock"
+	"github.com/opsorch/opsorch-mock-adapters/ticketmock"
 )
 
 func main() {
 	var (
-		prov     team.Provider
+		prov     *teammock.Provider
 		provOnce sync.Once
 		provErr  error
+
+		workloadOnce sync.Once
+		workloadErr  error
 	)
 
 	pluginrpc.Run(func(req pluginrpc.Request) (any, error) {
 		provOnce.Do(func() {
-			prov, provErr = teammock.New(req.Config)
+			var p team.Provider
+			p, provErr = teammock.New(req.Config)
+			if provErr == nil {
+				prov = p.(*teammock.Provider)
+			}
 		})
 		if provErr != nil {
 			return nil, provErr
@@ -50,12 +61,91 @@ func main() {
 				return nil, err
 			}
 			return prov.Members(context.Background(), params.TeamID)
+		case "team.oncall.get":
+			var params struct {
+				TeamID string    `json:"teamID"`
+				At     time.Time `json:"at"`
+			}
+			if err := json.Unmarshal(req.Payload, &params); err != nil {
+				return nil, err
+			}
+			if params.At.IsZero() {
+				return prov.OnCall(context.Background(), params.TeamID)
+			}
+			return prov.OnCallAt(context.Background(), params.TeamID, params.At)
+		case "team.oncall.overrides.list":
+			var params struct {
+				TeamID string `json:"teamID"`
+			}
+			if err := json.Unmarshal(req.Payload, &params); err != nil {
+				return nil, err
+			}
+			return prov.Overrides(context.Background(), params.TeamID)
+		case "team.oncall.overrides.create":
+			var in teammock.OnCallOverride
+			if err := json.Unmarshal(req.Payload, &in); err != nil {
+				return nil, err
+			}
+			return prov.CreateOverride(context.Background(), in)
+		case "team.oncall.outOfOffice.create":
+			var in teammock.OutOfOffice
+			if err := json.Unmarshal(req.Payload, &in); err != nil {
+				return nil, err
+			}
+			return prov.MarkOutOfOffice(context.Background(), in)
+		case "team.recommendResponder":
+			var in teammock.RecommendRequest
+			if err := json.Unmarshal(req.Payload, &in); err != nil {
+				return nil, err
+			}
+			return prov.RecommendResponders(context.Background(), in)
+		case "team.workload":
+			var params struct {
+				TeamID string `json:"teamID"`
+			}
+			if len(req.Payload) > 0 {
+				if err := json.Unmarshal(req.Payload, &params); err != nil {
+					return nil, err
+				}
+			}
+			// Workload reads the other mocks; this process only holds the
+			// team provider, so build fresh ones on first use.
+			workloadOnce.Do(func() { workloadErr = setWorkloadDeps(prov) })
+			if workloadErr != nil {
+				return nil, workloadErr
+			}
+			return prov.Workload(context.Background(), params.TeamID)
+		case "ref.resolve":
+			return pluginrpc.ResolveRef(context.Background(), req.Payload)
+		case "search.global":
+			return pluginrpc.Search(context.Background(), req.Payload)
+		case "search.docs":
+			return pluginrpc.SearchDocs(context.Background(), req.Payload)
 		default:
 			return nil, errUnknownMethod(req.Method)
 		}
 	})
 }
 
+// setWorkloadDeps gives prov fresh incident, alert, and ticket mocks to
+// compute workload from.
+func setWorkloadDeps(prov *teammock.Provider) error {
+	incidents, err := incidentmock.New(nil)
+	if err != nil {
+		return err
+	}
+	alerts, err := alertmock.New(nil)
+	if err != nil {
+		return err
+	}
+	tickets, err := ticketmock.New(nil)
+	if err != nil {
+		return err
+	}
+	prov.SetWorkloadDeps(teammock.WorkloadDeps{Incidents: incidents, Alerts: alerts, Tickets: tickets})
+	return nil
+}
+
 func errUnknownMethod(method string) error {
 	return fmt.Errorf("unknown method %s", method)
 }
@@ -1,13 +1,17 @@
 package main
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/opsorch/opsorch-core/schema"
 	"github.com/opsorch/opsorch-core/ticket"
+	"github.com/opsorch/opsorch-mock-adapters/internal/bundle"
+	"github.com/opsorch/opsorch-mock-adapters/internal/mockutil"
 	"github.com/opsorch/opsorch-mock-adapters/internal/pluginrpc"
 	"github.com/opsorch/opsorch-mock-adapters/ticketmock"
 )
@@ -17,28 +21,69 @@ func main() {
 		prov     ticket.Provider
 		provOnce sync.Once
 		provErr  error
+		// persist is the bundle file the provider's state is flushed to
+		// on shutdown, if any.
+		persist string
+		// operator is this process's simulated operator, working prov.
+		operator = mockutil.NewOperator()
 	)
 
 	pluginrpc.Run(func(req pluginrpc.Request) (any, error) {
 		provOnce.Do(func() {
 			prov, provErr = ticketmock.New(req.Config)
+			persist = bundle.PersistPath(req.Config)
+			if mock, ok := prov.(*ticketmock.Provider); ok {
+				mock.RegisterOperatorTasks(operator)
+				operator.Start(mockutil.ParseOperatorConfig(req.Config))
+			}
 		})
 		if provErr != nil {
 			return nil, provErr
 		}
 
-		return handleRequest(prov, req)
-	})
+		return handleRequest(prov, operator, req)
+	}, pluginrpc.WithPayloadSchema("ticket.query", queryOptions{}), pluginrpc.WithShutdownHook(func() error {
+		operator.Stop()
+		mock, ok := prov.(*ticketmock.Provider)
+		if !ok || persist == "" {
+			return nil
+		}
+		return bundle.WriteFile(persist, snapshotBundle(mock))
+	}))
 }
 
-func handleRequest(prov ticket.Provider, req pluginrpc.Request) (any, error) {
+func handleRequest(prov ticket.Provider, operator *mockutil.Operator, req pluginrpc.Request) (any, error) {
 	switch req.Method {
 	case "ticket.query":
 		var query schema.TicketQuery
 		if err := json.Unmarshal(req.Payload, &query); err != nil {
 			return nil, err
 		}
-		return prov.Query(context.Background(), query)
+		var opts queryOptions
+		if err := json.Unmarshal(req.Payload, &opts); err != nil {
+			return nil, err
+		}
+		tickets, err := prov.Query(opts.context(), query)
+		if err != nil {
+			return nil, err
+		}
+		return mockutil.ProjectFields(tickets, opts.Fields)
+	case "ticket.stats":
+		mock, ok := prov.(*ticketmock.Provider)
+		if !ok {
+			return nil, errUnknownMethod(req.Method)
+		}
+		var query schema.TicketQuery
+		var opts queryOptions
+		if len(req.Payload) > 0 {
+			if err := json.Unmarshal(req.Payload, &query); err != nil {
+				return nil, err
+			}
+			if err := json.Unmarshal(req.Payload, &opts); err != nil {
+				return nil, err
+			}
+		}
+		return mock.Stats(opts.context(), query)
 	case "ticket.get":
 		var payload struct {
 			ID string `json:"id"`
@@ -62,11 +107,111 @@ func handleRequest(prov ticket.Provider, req pluginrpc.Request) (any, error) {
 			return nil, err
 		}
 		return prov.Update(context.Background(), payload.ID, payload.Input)
+	case "ticket.templates":
+		return ticketmock.Templates(), nil
+	case "ticket.createFromIncident":
+		mock, ok := prov.(*ticketmock.Provider)
+		if !ok {
+			return nil, errUnknownMethod(req.Method)
+		}
+		var payload struct {
+			Template string          `json:"template"`
+			Incident schema.Incident `json:"incident"`
+		}
+		if err := json.Unmarshal(req.Payload, &payload); err != nil {
+			return nil, err
+		}
+		return mock.CreateFromIncident(context.Background(), payload.Template, payload.Incident)
+	case "ticket.sync":
+		mock, ok := prov.(*ticketmock.Provider)
+		if !ok {
+			return nil, errUnknownMethod(req.Method)
+		}
+		var payload ticketmock.SyncRequest
+		if len(req.Payload) > 0 {
+			if err := json.Unmarshal(req.Payload, &payload); err != nil {
+				return nil, err
+			}
+		}
+		return mock.Sync(context.Background(), payload)
+	case "operator.step":
+		var payload struct {
+			Actor string `json:"actor"`
+		}
+		if len(req.Payload) > 0 {
+			if err := json.Unmarshal(req.Payload, &payload); err != nil {
+				return nil, err
+			}
+		}
+		if payload.Actor == "" {
+			payload.Actor = mockutil.ParseOperatorConfig(req.Config).Actor
+		}
+		action, acted, err := operator.Step(context.Background(), payload.Actor)
+		if err != nil || !acted {
+			return nil, err
+		}
+		return action, nil
+	case "operator.actions":
+		var payload struct {
+			After int64 `json:"after"`
+		}
+		if len(req.Payload) > 0 {
+			if err := json.Unmarshal(req.Payload, &payload); err != nil {
+				return nil, err
+			}
+		}
+		return mockutil.OperatorActions(payload.After), nil
+	case "ticket.snapshot":
+		mock, ok := prov.(*ticketmock.Provider)
+		if !ok {
+			return nil, errUnknownMethod(req.Method)
+		}
+		return snapshotBundle(mock), nil
+	case "ticket.restore":
+		mock, ok := prov.(*ticketmock.Provider)
+		if !ok {
+			return nil, errUnknownMethod(req.Method)
+		}
+		b, err := bundle.Decode(bytes.NewReader(req.Payload), bundle.FormatJSON)
+		if err != nil {
+			return nil, err
+		}
+		mock.Restore(b)
+		return snapshotBundle(mock), nil
+	case "ref.resolve":
+		return pluginrpc.ResolveRef(context.Background(), req.Payload)
+	case "search.global":
+		return pluginrpc.Search(context.Background(), req.Payload)
+	case "search.docs":
+		return pluginrpc.SearchDocs(context.Background(), req.Payload)
 	default:
 		return nil, errUnknownMethod(req.Method)
 	}
 }
 
+// queryOptions are plugin-level query extensions that are not part of schema.TicketQuery.
+type queryOptions struct {
+	mockutil.ProjectionOptions
+	mockutil.FilterOptions
+	OverdueOnly bool `json:"overdueOnly"`
+}
+
+func (o queryOptions) context() context.Context {
+	ctx := mockutil.WithFilter(context.Background(), o.Filter)
+	if o.OverdueOnly {
+		ctx = ticketmock.WithOverdueOnly(ctx)
+	}
+	return ctx
+}
+
+// snapshotBundle returns the provider's tickets as a bundle with only
+// that section set, the shape mockexport merges.
+func snapshotBundle(mock *ticketmock.Provider) *bundle.Bundle {
+	b := &bundle.Bundle{Version: bundle.Version, GeneratedAt: time.Now().UTC()}
+	b.Tickets = mock.Snapshot()
+	return b
+}
+
 func errUnknownMethod(method string) error {
 	return fmt.Errorf("unknown method %s", method)
 }
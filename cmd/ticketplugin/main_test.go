@@ -1,10 +1,13 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"testing"
 
 	"github.com/opsorch/opsorch-core/schema"
+	"github.com/opsorch/opsorch-mock-adapters/internal/bundle"
+	"github.com/opsorch/opsorch-mock-adapters/internal/mockutil"
 	"github.com/opsorch/opsorch-mock-adapters/internal/pluginrpc"
 	"github.com/opsorch/opsorch-mock-adapters/ticketmock"
 )
@@ -20,7 +23,7 @@ func TestHandleRequestQuery(t *testing.T) {
 		t.Fatalf("failed to marshal query: %v", err)
 	}
 
-	res, err := handleRequest(prov, pluginrpc.Request{Method: "ticket.query", Payload: payload})
+	res, err := handleRequest(prov, mockutil.NewOperator(), pluginrpc.Request{Method: "ticket.query", Payload: payload})
 	if err != nil {
 		t.Fatalf("handleRequest returned error: %v", err)
 	}
@@ -40,7 +43,120 @@ func TestHandleRequestUnknownMethod(t *testing.T) {
 		t.Fatalf("failed to init provider: %v", err)
 	}
 
-	if _, err := handleRequest(prov, pluginrpc.Request{Method: "ticket.invalid"}); err == nil {
+	if _, err := handleRequest(prov, mockutil.NewOperator(), pluginrpc.Request{Method: "ticket.invalid"}); err == nil {
 		t.Fatalf("expected error for unknown method")
 	}
 }
+
+func TestHandleRequestQueryProjection(t *testing.T) {
+	prov, err := ticketmock.New(map[string]any{})
+	if err != nil {
+		t.Fatalf("failed to init provider: %v", err)
+	}
+
+	payload := []byte(`{"query":"checkout","fields":["title"]}`)
+	res, err := handleRequest(prov, mockutil.NewOperator(), pluginrpc.Request{Method: "ticket.query", Payload: payload})
+	if err != nil {
+		t.Fatalf("handleRequest returned error: %v", err)
+	}
+
+	rows, ok := res.([]map[string]any)
+	if !ok {
+		t.Fatalf("expected []map[string]any response, got %T", res)
+	}
+	if len(rows) == 0 {
+		t.Fatalf("expected projected tickets in response")
+	}
+	for _, row := range rows {
+		if _, ok := row["description"]; ok {
+			t.Fatalf("expected description to be projected out, got %v", row)
+		}
+		if row["id"] == nil || row["title"] == nil {
+			t.Fatalf("expected id and title in projected row, got %v", row)
+		}
+	}
+}
+
+func TestHandleRequestCreateFromIncident(t *testing.T) {
+	prov, err := ticketmock.New(map[string]any{})
+	if err != nil {
+		t.Fatalf("failed to init provider: %v", err)
+	}
+
+	payload := []byte(`{"template":"postmortem","incident":{"id":"inc-scenario-002","title":"Cascading Failure","severity":"sev1","service":"svc-database"}}`)
+	res, err := handleRequest(prov, mockutil.NewOperator(), pluginrpc.Request{Method: "ticket.createFromIncident", Payload: payload})
+	if err != nil {
+		t.Fatalf("handleRequest returned error: %v", err)
+	}
+
+	tk, ok := res.(schema.Ticket)
+	if !ok {
+		t.Fatalf("expected schema.Ticket response, got %T", res)
+	}
+	if tk.Fields["incident_id"] != "inc-scenario-002" {
+		t.Fatalf("expected incident link, got %v", tk.Fields["incident_id"])
+	}
+}
+
+func TestHandleRequestStats(t *testing.T) {
+	prov, err := ticketmock.New(map[string]any{})
+	if err != nil {
+		t.Fatalf("failed to init provider: %v", err)
+	}
+
+	payload := []byte(`{"filter":"fields.priority == \"P1\""}`)
+	res, err := handleRequest(prov, mockutil.NewOperator(), pluginrpc.Request{Method: "ticket.stats", Payload: payload})
+	if err != nil {
+		t.Fatalf("handleRequest returned error: %v", err)
+	}
+	stats, ok := res.(mockutil.Counts)
+	if !ok {
+		t.Fatalf("expected mockutil.Counts response, got %T", res)
+	}
+	if stats.Total == 0 || stats.BySeverity["P1"] != stats.Total {
+		t.Fatalf("expected only P1 tickets counted, got %+v", stats)
+	}
+}
+
+func TestHandleRequestSnapshotRestore(t *testing.T) {
+	prov, err := ticketmock.New(map[string]any{})
+	if err != nil {
+		t.Fatalf("failed to init provider: %v", err)
+	}
+	if _, err := prov.Create(context.Background(), schema.CreateTicketInput{Title: "Live demo ticket"}); err != nil {
+		t.Fatalf("create ticket: %v", err)
+	}
+
+	res, err := handleRequest(prov, mockutil.NewOperator(), pluginrpc.Request{Method: "ticket.snapshot"})
+	if err != nil {
+		t.Fatalf("snapshot returned error: %v", err)
+	}
+	snap, ok := res.(*bundle.Bundle)
+	if !ok {
+		t.Fatalf("expected *bundle.Bundle response, got %T", res)
+	}
+	var live *schema.Ticket
+	for i := range snap.Tickets {
+		if snap.Tickets[i].Title == "Live demo ticket" {
+			live = &snap.Tickets[i]
+		}
+	}
+	if live == nil || len(snap.Alerts) != 0 {
+		t.Fatalf("expected the created ticket and no other sections, got %d tickets and %d alerts", len(snap.Tickets), len(snap.Alerts))
+	}
+
+	payload, err := json.Marshal(bundle.Bundle{Version: bundle.Version, Tickets: []schema.Ticket{*live}})
+	if err != nil {
+		t.Fatalf("failed to marshal bundle: %v", err)
+	}
+	res, err = handleRequest(prov, mockutil.NewOperator(), pluginrpc.Request{Method: "ticket.restore", Payload: payload})
+	if err != nil {
+		t.Fatalf("restore returned error: %v", err)
+	}
+	if restored := res.(*bundle.Bundle); len(restored.Tickets) != 1 || restored.Tickets[0].ID != live.ID {
+		t.Fatalf("expected only %s after restore, got %d tickets", live.ID, len(restored.Tickets))
+	}
+	if _, err := handleRequest(prov, mockutil.NewOperator(), pluginrpc.Request{Method: "ticket.restore", Payload: []byte(`{"version":1,"bogus":true}`)}); err == nil {
+		t.Fatal("expected error for unknown bundle field")
+	}
+}
@@ -0,0 +1,159 @@
+package deploymentmock
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/opsorch/opsorch-core/schema"
+	"github.com/opsorch/opsorch-mock-adapters/internal/mockutil"
+)
+
+// AlertSource is the shared alert snapshot source for alerts raised by failed
+// deployments.
+const AlertSource = "deployment"
+
+// failureLink is the incident and alert raised for one failed deployment.
+type failureLink struct {
+	incidentID string
+	alertID    string
+}
+
+// SetIncidentOpener sets how incidents for correlated failures are opened,
+// normally the OpenLinked of the host's incident provider.
+func (p *Provider) SetIncidentOpener(opener mockutil.IncidentOpener) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.opener = opener
+}
+
+// correlatedFailure reports whether a failed deployment raises its own alert
+// and incident: it must have failed in prod on a high-impact service.
+func correlatedFailure(dep schema.Deployment) bool {
+	return dep.Status == "failed" && dep.Environment == "prod" && getEstimatedImpact(dep.Service) == "high"
+}
+
+// correlateFailures raises an alert and opens an incident for each scenario
+// deployment that correlatedFailure selects, once per deployment. The alert
+// joins the shared snapshot under AlertSource; the incident is opened through
+// the opener set with SetIncidentOpener, and is retried on later queries
+// while there is none. It must be called without p.mu held, since
+// opening the incident calls into another provider.
+func (p *Provider) correlateFailures(ctx context.Context, now time.Time) {
+	if !p.cfg.Features.ScenarioEffects {
+		return
+	}
+	p.correlateMu.Lock()
+	defer p.correlateMu.Unlock()
+
+	alerts := make([]schema.Alert, 0)
+	for _, dep := range getScenarioDeployments(now) {
+		if !correlatedFailure(dep) {
+			continue
+		}
+		p.mu.Lock()
+		link, opener := p.linked[dep.ID], p.opener
+		p.mu.Unlock()
+
+		link.alertID = "al-deploy-" + dep.ID
+		if link.incidentID == "" {
+			id, err := opener.Open(ctx, failureIncident(dep, link.alertID))
+			if err == nil {
+				link.incidentID = id
+			}
+		}
+		alerts = append(alerts, p.failureAlert(dep, link))
+
+		p.mu.Lock()
+		p.linked[dep.ID] = link
+		p.mu.Unlock()
+	}
+	mockutil.PublishSourceAlerts(AlertSource, alerts)
+}
+
+// deploymentCorrelationID is the correlation ID of the scenario a deployment
+// belongs to, or one of its own, which the alert and incident raised for its
+// failure share.
+func deploymentCorrelationID(dep schema.Deployment) string {
+	if id := mockutil.CorrelationFor(nil, dep.Metadata); id != "" {
+		return id
+	}
+	return mockutil.CorrelationID("deployment", dep.ID)
+}
+
+// linkFailureLocked stamps the incident and alert raised for dep, if any, on
+// its Metadata. Callers must hold p.mu.
+func (p *Provider) linkFailureLocked(dep *schema.Deployment) {
+	link, ok := p.linked[dep.ID]
+	if !ok {
+		return
+	}
+	dep.Metadata = mockutil.CloneMap(dep.Metadata)
+	if dep.Metadata == nil {
+		dep.Metadata = map[string]any{}
+	}
+	dep.Metadata["alert_id"] = link.alertID
+	if link.incidentID != "" {
+		dep.Metadata["incident_id"] = link.incidentID
+	}
+	mockutil.LinkRefs(dep.Metadata, nil)
+}
+
+func (p *Provider) failureAlert(dep schema.Deployment, link failureLink) schema.Alert {
+	severity := "error"
+	if mockutil.ServiceTier(dep.Service) == 1 {
+		severity = "critical"
+	}
+	reason, _ := dep.Metadata["failure_reason"].(string)
+	message, _ := dep.Metadata["error"].(string)
+	al := schema.Alert{
+		ID:          link.alertID,
+		Title:       fmt.Sprintf("Deployment failed: %s %s in %s", dep.Service, dep.Version, dep.Environment),
+		Description: message,
+		Status:      "firing",
+		Severity:    severity,
+		Service:     dep.Service,
+		CreatedAt:   dep.FinishedAt,
+		UpdatedAt:   dep.FinishedAt,
+		Fields: map[string]any{
+			"service":               dep.Service,
+			"environment":           dep.Environment,
+			"version":               dep.Version,
+			"deployment_id":         dep.ID,
+			"failure_reason":        reason,
+			mockutil.CorrelationKey: deploymentCorrelationID(dep),
+		},
+		Metadata: map[string]any{
+			"source":    p.cfg.Source,
+			"ruleId":    "deploy-failed-" + dep.Environment,
+			"alertType": "deployment_failed",
+		},
+	}
+	if link.incidentID != "" {
+		al.Metadata["incident_id"] = link.incidentID
+	}
+	mockutil.LinkRefs(al.Metadata, al.Fields)
+	return al
+}
+
+// failureIncident describes the incident opened for a failed deployment. Its
+// severity is left to the incident provider's service tier default.
+func failureIncident(dep schema.Deployment, alertID string) mockutil.LinkedIncident {
+	message, _ := dep.Metadata["error"].(string)
+	return mockutil.LinkedIncident{
+		Title:       fmt.Sprintf("Failed deploy of %s %s in %s", dep.Service, dep.Version, dep.Environment),
+		Description: fmt.Sprintf("Deployment %s failed: %s", dep.ID, message),
+		Service:     dep.Service,
+		Fields: map[string]any{
+			"environment":           dep.Environment,
+			"tags":                  []string{"cause:deployment"},
+			mockutil.CorrelationKey: deploymentCorrelationID(dep),
+		},
+		Metadata: map[string]any{
+			"deployment_id": dep.ID,
+			"alert_id":      alertID,
+		},
+		Note:  fmt.Sprintf("Opened automatically: deployment %s of %s failed (%s)", dep.ID, dep.Version, message),
+		Actor: mockutil.ActorRef("deploy-bot"),
+	}
+}
@@ -0,0 +1,159 @@
+package deploymentmock
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"time"
+
+	"github.com/opsorch/opsorch-core/schema"
+)
+
+// Sync states reported for each service/environment, following GitOps controller wording.
+const (
+	SyncStatusSynced      = "Synced"
+	SyncStatusOutOfSync   = "OutOfSync"
+	SyncStatusProgressing = "Progressing"
+)
+
+// defaultDriftRate is the share of in-sync targets given injected drift.
+const defaultDriftRate = 0.25
+
+// DriftQuery filters drift results.
+type DriftQuery struct {
+	Service     string `json:"service,omitempty"`
+	Environment string `json:"environment,omitempty"`
+	DriftedOnly bool   `json:"driftedOnly,omitempty"`
+}
+
+// DriftStatus compares the version declared in Git (desired) with what is running (live).
+type DriftStatus struct {
+	Service        string    `json:"service"`
+	Environment    string    `json:"environment"`
+	DesiredVersion string    `json:"desiredVersion"`
+	LiveVersion    string    `json:"liveVersion"`
+	SyncStatus     string    `json:"syncStatus"`
+	Drifted        bool      `json:"drifted"`
+	DriftType      string    `json:"driftType,omitempty"`
+	Reason         string    `json:"reason,omitempty"`
+	DeploymentID   string    `json:"deploymentId"`
+	DesiredSince   time.Time `json:"desiredSince"`
+	DetectedAt     time.Time `json:"detectedAt"`
+}
+
+// Drift reports desired vs. live versions per service and environment. The most
+// recent deployment is the desired state; live lags behind when that deployment
+// failed or is still running, and a deterministic share of the remaining targets
+// get injected drift such as an uncommitted hotfix or manual rollback.
+func (p *Provider) Drift(ctx context.Context, query DriftQuery) ([]DriftStatus, error) {
+	_ = ctx
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now().UTC()
+	for _, sd := range getScenarioDeployments(now) {
+		p.deployments[sd.ID] = sd
+	}
+
+	history := map[string][]schema.Deployment{}
+	for _, dep := range p.deployments {
+		if query.Service != "" && dep.Service != query.Service {
+			continue
+		}
+		if query.Environment != "" && dep.Environment != query.Environment {
+			continue
+		}
+		key := dep.Service + "|" + dep.Environment
+		history[key] = append(history[key], dep)
+	}
+
+	keys := make([]string, 0, len(history))
+	for key := range history {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	out := make([]DriftStatus, 0, len(keys))
+	for _, key := range keys {
+		deps := history[key]
+		sort.Slice(deps, func(i, j int) bool { return deps[i].StartedAt.After(deps[j].StartedAt) })
+		status := p.driftStatus(deps, now)
+		if query.DriftedOnly && !status.Drifted {
+			continue
+		}
+		out = append(out, status)
+	}
+	return out, nil
+}
+
+// driftStatus derives the status for one target from its deployments, newest first.
+func (p *Provider) driftStatus(deps []schema.Deployment, now time.Time) DriftStatus {
+	desired := deps[0]
+	status := DriftStatus{
+		Service:        desired.Service,
+		Environment:    desired.Environment,
+		DesiredVersion: desired.Version,
+		LiveVersion:    desired.Version,
+		SyncStatus:     SyncStatusSynced,
+		DeploymentID:   desired.ID,
+		DesiredSince:   desired.StartedAt,
+	}
+	previous := ""
+	for _, dep := range deps[1:] {
+		if dep.Status == "success" && dep.Version != desired.Version {
+			previous = dep.Version
+			break
+		}
+	}
+
+	switch desired.Status {
+	case "running":
+		status.SyncStatus = SyncStatusProgressing
+		if previous != "" {
+			status.LiveVersion = previous
+		}
+		status.Reason = "sync in progress"
+		return status
+	case "failed":
+		status.SyncStatus = SyncStatusOutOfSync
+		status.Drifted = true
+		status.DriftType = "sync-failed"
+		if previous != "" {
+			status.LiveVersion = previous
+		}
+		status.Reason = "last sync failed; cluster still runs the previous release"
+		status.DetectedAt = desired.FinishedAt
+		return status
+	}
+
+	h := fnv.New32a()
+	h.Write([]byte(desired.Service + "|" + desired.Environment))
+	roll := h.Sum32()
+	if float64(roll%1000)/1000 >= p.cfg.DriftRate {
+		return status
+	}
+
+	status.SyncStatus = SyncStatusOutOfSync
+	status.Drifted = true
+	// Drift is detected a stable few minutes to hours after the last sync.
+	synced := desired.FinishedAt
+	if synced.IsZero() {
+		synced = desired.StartedAt
+	}
+	status.DetectedAt = synced.Add(time.Duration(5+roll%240) * time.Minute)
+	if status.DetectedAt.After(now) {
+		status.DetectedAt = now
+	}
+	if previous != "" && roll%2 == 0 {
+		status.DriftType = "manual-rollback"
+		status.LiveVersion = previous
+		status.Reason = fmt.Sprintf("rolled back to %s outside Git", previous)
+		return status
+	}
+	status.DriftType = "manual-hotfix"
+	status.LiveVersion = desired.Version + "-hotfix.1"
+	status.Reason = "image patched in cluster with kubectl; change not committed to Git"
+	return status
+}
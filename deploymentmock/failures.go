@@ -0,0 +1,163 @@
+package deploymentmock
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strings"
+)
+
+// Failure reasons recorded in Metadata["failure_reason"] of failed deployments.
+const (
+	FailureHealthcheck = "healthcheck"
+	FailureMigration   = "migration"
+	FailureImagePull   = "image-pull"
+	FailureQuota       = "quota"
+	FailureUnknown     = "unknown"
+)
+
+// failureErrors are the error messages recorded for each failure reason.
+var failureErrors = map[string][]string{
+	FailureHealthcheck: {
+		"health check failed: readiness probe timeout",
+		"health check failed: database connection timeout",
+		"canary analysis failed: error rate above threshold",
+	},
+	FailureMigration: {
+		"migration failed: lock wait timeout exceeded",
+		"migration failed: column already exists",
+	},
+	FailureImagePull: {
+		"image pull failed: manifest unknown",
+		"image pull failed: registry rate limit exceeded",
+	},
+	FailureQuota: {
+		"insufficient quota: namespace cpu limit exceeded",
+		"insufficient quota: node pool at max pods",
+	},
+}
+
+// defaultFailureMix weighs the failure reasons of generated history when the
+// config does not set failureMix.
+var defaultFailureMix = map[string]float64{
+	FailureHealthcheck: 4,
+	FailureMigration:   2,
+	FailureImagePull:   2,
+	FailureQuota:       1,
+}
+
+// defaultFlakyRate is the share of failed deployments that succeed when the
+// same build is retried.
+const defaultFlakyRate = 0.5
+
+// parseFailureMix reads failureMix weights, keeping known reasons with a
+// positive weight. It returns nil, for the default mix, when none remain.
+func parseFailureMix(raw any) map[string]float64 {
+	m, ok := raw.(map[string]any)
+	if !ok {
+		return nil
+	}
+	out := map[string]float64{}
+	for reason, weight := range m {
+		w, ok := weight.(float64)
+		if n, isInt := weight.(int); isInt {
+			w, ok = float64(n), true
+		}
+		if _, known := failureErrors[reason]; known && ok && w > 0 {
+			out[reason] = w
+		}
+	}
+	if len(out) == 0 {
+		return nil
+	}
+	return out
+}
+
+// pickFailure chooses a failure reason from mix (the default when nil) and
+// one of its error messages, deterministically from h.
+func pickFailure(mix map[string]float64, h uint32) (string, string) {
+	if len(mix) == 0 {
+		mix = defaultFailureMix
+	}
+	reasons := make([]string, 0, len(mix))
+	var total float64
+	for reason, w := range mix {
+		reasons = append(reasons, reason)
+		total += w
+	}
+	sort.Strings(reasons)
+	target := float64(h%10000) / 10000 * total
+	reason := reasons[len(reasons)-1]
+	for _, r := range reasons {
+		if target < mix[r] {
+			reason = r
+			break
+		}
+		target -= mix[r]
+	}
+	messages := failureErrors[reason]
+	return reason, messages[(h>>16)%uint32(len(messages))]
+}
+
+// classifyFailure maps a deployment error message to its failure reason.
+func classifyFailure(message string) string {
+	msg := strings.ToLower(message)
+	switch {
+	case strings.Contains(msg, "health check"), strings.Contains(msg, "canary"), strings.Contains(msg, "readiness"):
+		return FailureHealthcheck
+	case strings.Contains(msg, "migration"):
+		return FailureMigration
+	case strings.Contains(msg, "image pull"), strings.Contains(msg, "imagepull"):
+		return FailureImagePull
+	case strings.Contains(msg, "quota"):
+		return FailureQuota
+	default:
+		return FailureUnknown
+	}
+}
+
+// FailureReport breaks the failed deployments in a history window down by
+// reason for failure-analysis dashboards.
+type FailureReport struct {
+	Service     string  `json:"service,omitempty"`
+	Environment string  `json:"environment,omitempty"`
+	Days        int     `json:"days"`
+	Total       int     `json:"total"`
+	Failed      int     `json:"failed"`
+	FailureRate float64 `json:"failureRate"`
+	// ByReason counts failures per reason; every reason is listed.
+	ByReason map[string]int `json:"byReason"`
+	// Flaky counts failures whose retry of the same build succeeded.
+	Flaky int `json:"flaky"`
+}
+
+// Failures summarizes the failed deployments History returns for query.
+func (p *Provider) Failures(ctx context.Context, query HistoryQuery) (FailureReport, error) {
+	deployments, err := p.History(ctx, query)
+	if err != nil {
+		return FailureReport{}, err
+	}
+	days := query.Days
+	if days <= 0 {
+		days = historyDays + 1
+	}
+	report := FailureReport{Service: query.Service, Environment: query.Environment, Days: days, Total: len(deployments), ByReason: map[string]int{}}
+	for reason := range failureErrors {
+		report.ByReason[reason] = 0
+	}
+	for _, dep := range deployments {
+		if dep.Status != "failed" {
+			continue
+		}
+		report.Failed++
+		reason, _ := dep.Metadata["failure_reason"].(string)
+		report.ByReason[reason]++
+		if flaky, _ := dep.Metadata["flaky"].(bool); flaky {
+			report.Flaky++
+		}
+	}
+	if report.Total > 0 {
+		report.FailureRate = math.Round(float64(report.Failed)/float64(report.Total)*1000) / 1000
+	}
+	return report, nil
+}
@@ -0,0 +1,363 @@
+package deploymentmock
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/opsorch/opsorch-core/orcherr"
+	"github.com/opsorch/opsorch-core/schema"
+	"github.com/opsorch/opsorch-mock-adapters/internal/mockutil"
+)
+
+// Statuses of deployments created through Create.
+const (
+	StatusPendingApproval = "pending_approval"
+	StatusScheduled       = "scheduled"
+)
+
+// Change types a deployment request can declare. Freezes name the ones they
+// still let through.
+const (
+	ChangeFeature  = "feature"
+	ChangeHotfix   = "hotfix"
+	ChangeRollback = "rollback"
+)
+
+// PolicyChangeFreeze names the policy a FreezeViolation breaks.
+const PolicyChangeFreeze = "change_freeze"
+
+// FreezeWindow is a change freeze. Services and Environments list what it
+// covers; empty Services covers every service and empty Environments covers
+// prod. Allowed change types go through without an override.
+type FreezeWindow struct {
+	ID           string    `json:"id"`
+	Title        string    `json:"title"`
+	Start        time.Time `json:"start"`
+	End          time.Time `json:"end"`
+	Services     []string  `json:"services,omitempty"`
+	Environments []string  `json:"environments,omitempty"`
+	Allowed      []string  `json:"allowed,omitempty"`
+}
+
+// covers reports whether the freeze applies to a change of this type to the
+// service and environment at t.
+func (w FreezeWindow) covers(service, environment, changeType string, t time.Time) bool {
+	if t.Before(w.Start) || !t.Before(w.End) {
+		return false
+	}
+	if len(w.Services) > 0 && !containsString(w.Services, service) {
+		return false
+	}
+	environments := w.Environments
+	if len(environments) == 0 {
+		environments = []string{"prod"}
+	}
+	return containsString(environments, environment) && !containsString(w.Allowed, changeType)
+}
+
+// defaultFreezeWindows are the freezes enforced when none are configured; they
+// match the freezes calendarmock seeds.
+func defaultFreezeWindows(now time.Time) []FreezeWindow {
+	day := now.Truncate(24 * time.Hour)
+	offsite := day.AddDate(0, 0, 10)
+	return []FreezeWindow{
+		{
+			ID:       "freeze-checkout-error-budget",
+			Title:    "Checkout error budget freeze",
+			Start:    now.Add(time.Hour).Truncate(time.Hour),
+			End:      day.AddDate(0, 0, 7),
+			Services: []string{"svc-checkout"},
+			Allowed:  []string{ChangeRollback, ChangeHotfix},
+		},
+		{
+			ID:      "freeze-eng-offsite",
+			Title:   "Engineering offsite release freeze",
+			Start:   offsite,
+			End:     offsite.AddDate(0, 0, 4),
+			Allowed: []string{ChangeHotfix},
+		},
+	}
+}
+
+// parseFreezeWindows reads the freezeWindows config list. Entries without an
+// ID or a valid RFC3339 start before their end are skipped.
+func parseFreezeWindows(raw any) ([]FreezeWindow, bool) {
+	list, ok := raw.([]any)
+	if !ok {
+		return nil, false
+	}
+	out := make([]FreezeWindow, 0, len(list))
+	for _, item := range list {
+		m, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+		w := FreezeWindow{
+			ID:           stringValue(m["id"]),
+			Title:        stringValue(m["title"]),
+			Services:     stringList(m["services"]),
+			Environments: stringList(m["environments"]),
+			Allowed:      stringList(m["allowed"]),
+		}
+		start, err := time.Parse(time.RFC3339, stringValue(m["start"]))
+		if err != nil {
+			continue
+		}
+		end, err := time.Parse(time.RFC3339, stringValue(m["end"]))
+		if err != nil || !end.After(start) || w.ID == "" {
+			continue
+		}
+		w.Start, w.End = start.UTC(), end.UTC()
+		out = append(out, w)
+	}
+	return out, true
+}
+
+// FreezeViolation is the policy violation behind a forbidden Create or
+// Approve, carried as the Err of the orcherr error.
+type FreezeViolation struct {
+	Policy      string    `json:"policy"`
+	Action      string    `json:"action"`
+	FreezeID    string    `json:"freezeId"`
+	FreezeTitle string    `json:"freezeTitle"`
+	FreezeStart time.Time `json:"freezeStart"`
+	FreezeEnd   time.Time `json:"freezeEnd"`
+	Service     string    `json:"service"`
+	Environment string    `json:"environment"`
+	ChangeType  string    `json:"changeType"`
+	At          time.Time `json:"at"`
+	Allowed     []string  `json:"allowed,omitempty"`
+}
+
+func (v *FreezeViolation) Error() string {
+	msg := fmt.Sprintf("%s %s (%s) in %s blocked by change freeze %s (%s until %s); set override to proceed",
+		v.Action, v.Service, v.ChangeType, v.Environment, v.FreezeID, v.FreezeTitle, v.FreezeEnd.Format(time.RFC3339))
+	if len(v.Allowed) > 0 {
+		msg += fmt.Sprintf(" or use an allowed change type (%s)", strings.Join(v.Allowed, ", "))
+	}
+	return msg
+}
+
+// FreezeOverride records a change pushed through a freeze, in the
+// deployment's Metadata["freezeOverrides"].
+type FreezeOverride struct {
+	FreezeID string    `json:"freezeId"`
+	Action   string    `json:"action"`
+	Actor    string    `json:"actor"`
+	Reason   string    `json:"reason"`
+	At       time.Time `json:"at"`
+}
+
+// CreateRequest asks for a new deployment. Environment defaults to prod,
+// ChangeType to feature, and ScheduledAt to now.
+type CreateRequest struct {
+	Service     string    `json:"service"`
+	Environment string    `json:"environment,omitempty"`
+	Version     string    `json:"version"`
+	ChangeType  string    `json:"changeType,omitempty"`
+	ScheduledAt time.Time `json:"scheduledAt,omitempty"`
+	Actor       string    `json:"actor,omitempty"`
+	// Override pushes the request through an active freeze; the override is
+	// recorded with OverrideReason.
+	Override       bool   `json:"override,omitempty"`
+	OverrideReason string `json:"overrideReason,omitempty"`
+}
+
+// ApproveRequest approves a pending deployment.
+type ApproveRequest struct {
+	ID             string `json:"id"`
+	Actor          string `json:"actor,omitempty"`
+	Override       bool   `json:"override,omitempty"`
+	OverrideReason string `json:"overrideReason,omitempty"`
+}
+
+// Freezes returns the change freezes the provider enforces.
+func (p *Provider) Freezes(ctx context.Context) []FreezeWindow {
+	_ = ctx
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	out := make([]FreezeWindow, len(p.freezes))
+	copy(out, p.freezes)
+	return out
+}
+
+// Create records a deployment request awaiting approval. A request landing
+// in a freeze that covers its service, environment, and change type is
+// rejected with a forbidden error wrapping a FreezeViolation, unless Override
+// is set, in which case the override is recorded in Metadata.
+func (p *Provider) Create(ctx context.Context, req CreateRequest) (schema.Deployment, error) {
+	_ = ctx
+	if strings.TrimSpace(req.Service) == "" || strings.TrimSpace(req.Version) == "" {
+		return schema.Deployment{}, orcherr.New("bad_request", "service and version are required", nil)
+	}
+	now := time.Now().UTC()
+	environment := fallbackString(req.Environment, "prod")
+	changeType := fallbackString(req.ChangeType, ChangeFeature)
+	actor := fallbackString(req.Actor, "deploy-bot")
+	at := req.ScheduledAt.UTC()
+	if req.ScheduledAt.IsZero() {
+		at = now
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	metadata := map[string]any{
+		"source":     p.cfg.Source,
+		"changeType": changeType,
+		"window":     getDeploymentWindow(environment),
+	}
+	if err := p.enforceFreezeLocked("create", req.Service, environment, changeType, at, now, actor, req.Override, req.OverrideReason, metadata); err != nil {
+		return schema.Deployment{}, err
+	}
+
+	p.nextID++
+	dep := schema.Deployment{
+		ID:          fmt.Sprintf("deploy-%03d", p.nextID),
+		Service:     req.Service,
+		Environment: environment,
+		Version:     req.Version,
+		Status:      StatusPendingApproval,
+		StartedAt:   at,
+		Actor:       mockutil.ActorRef(actor),
+		Fields:      map[string]any{"scheduledAt": at},
+		Metadata:    metadata,
+	}
+	p.deployments[dep.ID] = dep
+	return cloneDeployment(dep), nil
+}
+
+// Approve moves a pending deployment to running, or to scheduled when it is
+// set to start later. The freeze check runs again for when it will start, so
+// a freeze declared after the request still blocks it; an override is
+// recorded as for Create.
+func (p *Provider) Approve(ctx context.Context, req ApproveRequest) (schema.Deployment, error) {
+	_ = ctx
+	now := time.Now().UTC()
+	actor := fallbackString(req.Actor, "release-manager")
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	dep, ok := p.deploymentLocked(req.ID)
+	if !ok {
+		return schema.Deployment{}, orcherr.New("not_found", "deployment not found", nil)
+	}
+	if dep.Status != StatusPendingApproval {
+		return schema.Deployment{}, orcherr.New("bad_request", fmt.Sprintf("deployment %s is %s, not %s", dep.ID, dep.Status, StatusPendingApproval), nil)
+	}
+	at := dep.StartedAt
+	if at.Before(now) {
+		at = now
+	}
+	changeType := fallbackString(stringValue(dep.Metadata["changeType"]), ChangeFeature)
+	metadata := mockutil.CloneMap(dep.Metadata)
+	if err := p.enforceFreezeLocked("approve", dep.Service, dep.Environment, changeType, at, now, actor, req.Override, req.OverrideReason, metadata); err != nil {
+		return schema.Deployment{}, err
+	}
+
+	dep.Metadata = metadata
+	dep.Fields = mockutil.CloneMap(dep.Fields)
+	if dep.Fields == nil {
+		dep.Fields = map[string]any{}
+	}
+	dep.Fields["approvedBy"] = actor
+	dep.Fields["approvedAt"] = now
+	dep.StartedAt = at
+	dep.Status = "running"
+	if at.After(now) {
+		dep.Status = StatusScheduled
+	}
+	p.deployments[dep.ID] = dep
+	return cloneDeployment(dep), nil
+}
+
+// enforceFreezeLocked checks a change against the freezes at t. Blocked
+// changes return a forbidden error unless override is set, which appends a
+// FreezeOverride to metadata instead. Callers must hold p.mu.
+func (p *Provider) enforceFreezeLocked(action, service, environment, changeType string, t, now time.Time, actor string, override bool, reason string, metadata map[string]any) error {
+	for _, w := range p.freezes {
+		if !w.covers(service, environment, changeType, t) {
+			continue
+		}
+		if !override {
+			violation := &FreezeViolation{
+				Policy:      PolicyChangeFreeze,
+				Action:      action,
+				FreezeID:    w.ID,
+				FreezeTitle: w.Title,
+				FreezeStart: w.Start,
+				FreezeEnd:   w.End,
+				Service:     service,
+				Environment: environment,
+				ChangeType:  changeType,
+				At:          t,
+				Allowed:     w.Allowed,
+			}
+			return orcherr.New("forbidden", violation.Error(), violation)
+		}
+		metadata["freezeOverrides"] = append(freezeOverrides(metadata["freezeOverrides"]), FreezeOverride{
+			FreezeID: w.ID,
+			Action:   action,
+			Actor:    actor,
+			Reason:   fallbackString(reason, "not given"),
+			At:       now,
+		})
+	}
+	return nil
+}
+
+// freezeOverrides decodes Metadata["freezeOverrides"] into a fresh slice. It
+// holds []FreezeOverride as recorded and []any once restored from a bundle.
+func freezeOverrides(v any) []FreezeOverride {
+	if v == nil {
+		return nil
+	}
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+	var out []FreezeOverride
+	if err := json.Unmarshal(raw, &out); err != nil {
+		return nil
+	}
+	return out
+}
+
+func fallbackString(val, def string) string {
+	if strings.TrimSpace(val) != "" {
+		return val
+	}
+	return def
+}
+
+func containsString(list []string, want string) bool {
+	for _, s := range list {
+		if s == want {
+			return true
+		}
+	}
+	return false
+}
+
+func stringValue(v any) string {
+	s, _ := v.(string)
+	return s
+}
+
+func stringList(v any) []string {
+	switch list := v.(type) {
+	case []string:
+		return append([]string(nil), list...)
+	case []any:
+		out := make([]string, 0, len(list))
+		for _, item := range list {
+			if s, ok := item.(string); ok {
+				out = append(out, s)
+			}
+		}
+		return out
+	}
+	return nil
+}
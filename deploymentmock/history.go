@@ -0,0 +1,240 @@
+package deploymentmock
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/opsorch/opsorch-core/schema"
+	"github.com/opsorch/opsorch-mock-adapters/internal/mockutil"
+)
+
+// historyDays is how far back the generated production deployment history
+// reaches, ending the day before the provider was created.
+const historyDays = 42
+
+// historyService drives generated history for one service. version is the
+// release just before the oldest seeded one, so the history leads into the
+// seeds; perWeek is the usual number of releases per working week.
+type historyService struct {
+	service string
+	version semver
+	perWeek int
+	actors  []string
+}
+
+var historyServices = []historyService{
+	{service: "svc-checkout", version: semver{2, 31, 0}, perWeek: 6, actors: []string{"alex", "kim", "deploy-bot"}},
+	{service: "svc-search", version: semver{1, 8, 0}, perWeek: 4, actors: []string{"jamie", "taylor"}},
+	{service: "svc-payments", version: semver{5, 12, 4}, perWeek: 5, actors: []string{"sam", "casey", "deploy-bot"}},
+	{service: "svc-notifications", version: semver{3, 2, 0}, perWeek: 3, actors: []string{"lee"}},
+	{service: "svc-identity", version: semver{1, 4, 6}, perWeek: 2, actors: []string{"devon"}},
+	{service: "svc-recommendation", version: semver{7, 2, 3}, perWeek: 4, actors: []string{"riley", "milo"}},
+	{service: "svc-analytics", version: semver{2, 8, 2}, perWeek: 3, actors: []string{"maya", "deploy-bot"}},
+	{service: "svc-realtime", version: semver{0, 9, 7}, perWeek: 2, actors: []string{"samir"}},
+	{service: "svc-warehouse", version: semver{4, 1, 1}, perWeek: 2, actors: []string{"morgan"}},
+	{service: "svc-order", version: semver{3, 4, 2}, perWeek: 3, actors: []string{"kim", "jordan"}},
+	{service: "svc-web", version: semver{12, 3, 5}, perWeek: 5, actors: []string{"casey", "alex", "deploy-bot"}},
+}
+
+type semver struct{ major, minor, patch int }
+
+func (v semver) String() string { return fmt.Sprintf("v%d.%d.%d", v.major, v.minor, v.patch) }
+
+// prev returns the release before v, assuming ten patches per minor release.
+func (v semver) prev() semver {
+	switch {
+	case v.patch > 0:
+		v.patch--
+	case v.minor > 0:
+		v.minor, v.patch = v.minor-1, 9
+	default:
+		v.major, v.minor, v.patch = v.major-1, 9, 9
+	}
+	return v
+}
+
+// HistoryQuery selects deployments for History.
+type HistoryQuery struct {
+	Service     string `json:"service"`
+	Environment string `json:"environment"`
+	// Days limits the history to deployments started in the last Days days;
+	// zero means the full generated history.
+	Days int `json:"days"`
+}
+
+// History returns deployments oldest first, including the generated history
+// that Query leaves out, for deployment-frequency charts and for lining
+// deployments up against incident timelines.
+func (p *Provider) History(ctx context.Context, query HistoryQuery) ([]schema.Deployment, error) {
+	_ = ctx
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.history.Ensure()
+	now := time.Now().UTC()
+	for _, sd := range getScenarioDeployments(now) {
+		p.deployments[sd.ID] = sd
+	}
+	days := query.Days
+	if days <= 0 {
+		days = historyDays + 1
+	}
+	since := now.AddDate(0, 0, -days)
+
+	out := []schema.Deployment{}
+	for _, dep := range p.deployments {
+		if query.Service != "" && dep.Service != query.Service {
+			continue
+		}
+		if query.Environment != "" && dep.Environment != query.Environment {
+			continue
+		}
+		if dep.StartedAt.Before(since) {
+			continue
+		}
+		out = append(out, cloneDeployment(dep))
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if !out[i].StartedAt.Equal(out[j].StartedAt) {
+			return out[i].StartedAt.Before(out[j].StartedAt)
+		}
+		return out[i].ID < out[j].ID
+	})
+	return out, nil
+}
+
+// historicalDeployments generates production deployments for the weekdays of
+// the last historyDays days, within business hours in cfg.Location (UTC when
+// nil). About one release in twelve fails, for a reason drawn from
+// cfg.FailureMix, and is retried; one in twenty-five is rolled back shortly
+// after going out. A cfg.FlakyRate share of the failures are flaky: the
+// retry redeploys the same build and succeeds. The others are retried with a
+// fix on a new commit.
+func historicalDeployments(cfg Config, now time.Time) []schema.Deployment {
+	loc := cfg.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+	var all []schema.Deployment
+	today := now.In(loc)
+	for _, svc := range historyServices {
+		version := svc.version
+		// Walk backwards so versions count down from the seeded releases.
+		for d := 1; d <= historyDays; d++ {
+			day := today.AddDate(0, 0, -d)
+			if day.Weekday() == time.Saturday || day.Weekday() == time.Sunday {
+				continue
+			}
+			dayKey := fmt.Sprintf("%s|%s", svc.service, day.Format("2006-01-02"))
+			count := svc.perWeek / 5
+			if int(historyHash(dayKey)%5) < svc.perWeek%5 {
+				count++
+			}
+			for slot := count - 1; slot >= 0; slot-- {
+				slotKey := fmt.Sprintf("%s|%d", dayKey, slot)
+				h := historyHash(slotKey)
+				start := time.Date(day.Year(), day.Month(), day.Day(), 10+slot*2, int(h%50), int(h%60), 0, loc).UTC()
+				dep := historyDeployment(svc, version, start, h)
+				switch r := h % 100; {
+				case r < 8:
+					// The first attempt failed; the retry of the same build went out.
+					failed := dep
+					failed.Status = "failed"
+					failed.FinishedAt = start.Add(time.Duration(60+h%60) * time.Second)
+					failed.Metadata = mockutil.CloneMap(dep.Metadata)
+					reason, message := pickFailure(cfg.FailureMix, historyHash(slotKey+"|failure"))
+					failed.Metadata["error"] = message
+					failed.Metadata["failure_reason"] = reason
+					failed.Metadata["duration"] = failed.FinishedAt.Sub(start).String()
+					retry := historyDeployment(svc, version, start.Add(90*time.Minute), h>>8)
+					retry.Metadata["retry"] = true
+					if float64(historyHash(slotKey+"|flaky")%1000) < cfg.FlakyRate*1000 {
+						// Nothing changed but the attempt: same build, now green.
+						failed.Metadata["flaky"] = true
+						retry.Metadata["flaky"] = true
+						retry.StartedAt = start.Add(10 * time.Minute)
+						retry.FinishedAt = retry.StartedAt.Add(dep.FinishedAt.Sub(dep.StartedAt))
+						retry.Metadata["commit"] = failed.Metadata["commit"]
+					}
+					all = append(all, failed, retry)
+				case r < 12:
+					// The release went out and was rolled back to the one before.
+					rollback := historyDeployment(svc, version.prev(), start.Add(40*time.Minute), h>>8)
+					rollback.Metadata["rollback"] = true
+					rollback.Metadata["rolled_back_from"] = version.String()
+					all = append(all, dep, rollback)
+				default:
+					all = append(all, dep)
+				}
+				version = version.prev()
+			}
+		}
+	}
+
+	sort.Slice(all, func(i, j int) bool {
+		if !all[i].StartedAt.Equal(all[j].StartedAt) {
+			return all[i].StartedAt.Before(all[j].StartedAt)
+		}
+		return all[i].Service < all[j].Service
+	})
+	lastFailed := map[string]string{}
+	for i := range all {
+		dep := &all[i]
+		dep.ID = fmt.Sprintf("deploy-hist-%04d", i+1)
+		dep.URL = fmt.Sprintf("https://github.com/company/%s/actions/runs/%d", strings.TrimPrefix(dep.Service, "svc-"), 11000+i)
+		dep.Metadata["source"] = cfg.Source
+		if dep.Status == "failed" {
+			lastFailed[dep.Service] = dep.ID
+		} else if retry, _ := dep.Metadata["retry"].(bool); retry {
+			dep.Metadata["retry_of"] = lastFailed[dep.Service]
+		}
+		if cfg.Features.Flair {
+			applyDeploymentFlair(dep, now)
+		}
+	}
+	return all
+}
+
+// historyDeployment is a successful production release of version at start.
+func historyDeployment(svc historyService, version semver, start time.Time, h uint32) schema.Deployment {
+	actor := svc.actors[h%uint32(len(svc.actors))]
+	duration := time.Duration(120+h%240) * time.Second
+	return schema.Deployment{
+		Service:     svc.service,
+		Environment: "prod",
+		Version:     version.String(),
+		Status:      "success",
+		StartedAt:   start,
+		FinishedAt:  start.Add(duration),
+		Actor:       mockutil.ActorRef(actor),
+		Metadata: map[string]any{
+			"commit":     fmt.Sprintf("%08x%04x", historyHash(svc.service+version.String()), h&0xffff),
+			"branch":     "main",
+			"duration":   duration.String(),
+			"region":     "use1",
+			"rollback":   false,
+			"canary":     h%3 == 0,
+			"blue_green": h%3 == 1,
+			"rolling":    h%3 == 2,
+			"historical": true,
+		},
+	}
+}
+
+func historyHash(key string) uint32 {
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	return h.Sum32()
+}
+
+// isHistoricalDeployment reports whether dep was generated by
+// historicalDeployments.
+func isHistoricalDeployment(dep schema.Deployment) bool {
+	historical, _ := dep.Metadata["historical"].(bool)
+	return historical
+}
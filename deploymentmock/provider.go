@@ -3,7 +3,6 @@ package deploymentmock
 import (
 	"context"
 	"fmt"
-	"reflect"
 	"sort"
 	"strings"
 	"sync"
@@ -12,6 +11,7 @@ import (
 	"github.com/opsorch/opsorch-core/deployment"
 	"github.com/opsorch/opsorch-core/orcherr"
 	"github.com/opsorch/opsorch-core/schema"
+	"github.com/opsorch/opsorch-mock-adapters/internal/bundle"
 	"github.com/opsorch/opsorch-mock-adapters/internal/mockutil"
 )
 
@@ -21,6 +21,24 @@ const ProviderName = "mock"
 // Config controls mock deployment metadata.
 type Config struct {
 	Source string
+	// Location moves seeded deploy windows into business hours in this timezone when set.
+	Location *time.Location
+	// DriftRate is the share (0-1) of in-sync targets reported as drifted by Drift.
+	DriftRate float64
+	// Warmup generates the deployment history in New instead of on first access.
+	Warmup bool
+	// FailureMix weighs the failure reasons of generated history; nil uses
+	// defaultFailureMix.
+	FailureMix map[string]float64
+	// FlakyRate is the share (0-1) of failed history deployments that succeed
+	// when the same build is retried.
+	FlakyRate float64
+	// Features switches realism behaviours off individually; see
+	// mockutil.Features.
+	Features mockutil.Features
+	// FreezeWindows are the change freezes Create and Approve enforce; nil
+	// uses defaultFreezeWindows.
+	FreezeWindows []FreezeWindow
 }
 
 // Provider holds in-memory deployments to support demo flows.
@@ -29,13 +47,46 @@ type Provider struct {
 	mu          sync.Mutex
 	nextID      int
 	deployments map[string]schema.Deployment
+	rollouts    map[string]seededRollout
+	// linked holds the incident and alert raised for each correlated failed
+	// deployment; correlateMu serializes raising them.
+	linked      map[string]failureLink
+	correlateMu sync.Mutex
+	// opener opens the incident for a correlated failure; nil until a host
+	// wires one with SetIncidentOpener.
+	opener mockutil.IncidentOpener
+
+	// history generates the production deployment history on first access,
+	// relative to seededAt.
+	history  *mockutil.LazySeed
+	seededAt time.Time
+
+	freezes []FreezeWindow
 }
 
 // New constructs the mock deployment provider with seeded deployment history.
 func New(cfg map[string]any) (deployment.Provider, error) {
 	parsed := parseConfig(cfg)
-	p := &Provider{cfg: parsed, deployments: map[string]schema.Deployment{}}
-	p.seed()
+	p := &Provider{cfg: parsed, deployments: map[string]schema.Deployment{}, rollouts: map[string]seededRollout{}, linked: map[string]failureLink{}}
+	p.history = mockutil.NewLazySeed("deployment", "history", p.seedHistoryLocked)
+	mockutil.TimeSeed("deployment", "deployments", false, func() int {
+		p.seed()
+		return len(p.deployments)
+	})
+	snapshot, err := bundle.FromConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	p.restore(snapshot)
+	p.freezes = parsed.FreezeWindows
+	if p.freezes == nil {
+		p.freezes = defaultFreezeWindows(time.Now().UTC())
+	}
+	if parsed.Warmup {
+		p.history.Warm()
+	}
+	mockutil.RegisterResolver(mockutil.RefDeployment, func(ctx context.Context, id string) (any, error) { return p.Get(ctx, id) })
+	mockutil.RegisterSearchSource(mockutil.RefDeployment, p.SearchDocs)
 	return p, nil
 }
 
@@ -45,23 +96,40 @@ func init() {
 
 // Query returns deployments that match the provided filters.
 func (p *Provider) Query(ctx context.Context, query schema.DeploymentQuery) ([]schema.Deployment, error) {
-	_ = ctx
+	filter, err := mockutil.FilterFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	filter = filter.And(mockutil.MetadataFilter(query.Metadata))
+
+	now := time.Now().UTC()
+	p.correlateFailures(ctx, now)
 
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
 	// Add static scenario-themed deployments
-	now := time.Now().UTC()
 	scenarioDeployments := getScenarioDeployments(now)
 	for _, sd := range scenarioDeployments {
+		sd.Metadata[mockutil.CorrelationKey] = deploymentCorrelationID(sd)
+		p.linkFailureLocked(&sd)
 		p.deployments[sd.ID] = sd
 	}
 
+	// Generated history is served by History; Query only includes (and
+	// generates) it when the caller filters on Metadata["historical"].
+	_, wantHistory := query.Metadata["historical"]
+	if wantHistory {
+		p.history.Ensure()
+	}
 	ids := sortedDeploymentIDs(p.deployments)
 	results := make([]schema.Deployment, 0, len(p.deployments))
 	for _, id := range ids {
 		dep := p.deployments[id]
-		if !matchesDeployment(query, dep) {
+		if isHistoricalDeployment(dep) && !wantHistory {
+			continue
+		}
+		if !matchesDeployment(query, dep) || !filter.Match(dep) {
 			continue
 		}
 		results = append(results, cloneDeployment(dep))
@@ -78,13 +146,24 @@ func (p *Provider) Get(ctx context.Context, id string) (schema.Deployment, error
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
-	dep, ok := p.deployments[id]
+	dep, ok := p.deploymentLocked(id)
 	if !ok {
 		return schema.Deployment{}, orcherr.New("not_found", "deployment not found", nil)
 	}
 	return cloneDeployment(dep), nil
 }
 
+// deploymentLocked looks up a deployment, generating the history first when id
+// is not among the eagerly seeded deployments. Callers must hold p.mu.
+func (p *Provider) deploymentLocked(id string) (schema.Deployment, bool) {
+	dep, ok := p.deployments[id]
+	if !ok {
+		p.history.Ensure()
+		dep, ok = p.deployments[id]
+	}
+	return dep, ok
+}
+
 func (p *Provider) seed() {
 	now := time.Now().UTC()
 	seed := []schema.Deployment{
@@ -97,7 +176,7 @@ func (p *Provider) seed() {
 			StartedAt:   now.Add(-4 * time.Hour),
 			FinishedAt:  now.Add(-4 * time.Hour).Add(3*time.Minute + 45*time.Second),
 			URL:         "https://github.com/company/checkout/actions/runs/12345",
-			Actor:       map[string]any{"name": "alex", "type": "user"},
+			Actor:       mockutil.ActorRef("alex"),
 			Metadata: map[string]any{
 				"source":        p.cfg.Source,
 				"commit":        "abc123def456",
@@ -119,7 +198,7 @@ func (p *Provider) seed() {
 			StartedAt:   now.Add(-6 * time.Hour),
 			FinishedAt:  now.Add(-6 * time.Hour).Add(2*time.Minute + 15*time.Second),
 			URL:         "https://github.com/company/search/actions/runs/12346",
-			Actor:       map[string]any{"name": "jamie", "type": "user"},
+			Actor:       mockutil.ActorRef("jamie"),
 			Metadata: map[string]any{
 				"source":        p.cfg.Source,
 				"commit":        "def456ghi789",
@@ -141,7 +220,7 @@ func (p *Provider) seed() {
 			StartedAt:   now.Add(-2 * time.Hour),
 			FinishedAt:  now.Add(-2 * time.Hour).Add(1*time.Minute + 30*time.Second),
 			URL:         "https://github.com/company/checkout/actions/runs/12347",
-			Actor:       map[string]any{"name": "deploy-bot", "type": "automation"},
+			Actor:       mockutil.ActorRef("deploy-bot"),
 			Metadata: map[string]any{
 				"source":        p.cfg.Source,
 				"commit":        "ghi789jkl012",
@@ -164,7 +243,7 @@ func (p *Provider) seed() {
 			StartedAt:   now.Add(-1 * time.Hour),
 			FinishedAt:  now.Add(-1 * time.Hour).Add(4*time.Minute + 12*time.Second),
 			URL:         "https://github.com/company/checkout/actions/runs/12348",
-			Actor:       map[string]any{"name": "deploy-bot", "type": "automation"},
+			Actor:       mockutil.ActorRef("deploy-bot"),
 			Metadata: map[string]any{
 				"source":        p.cfg.Source,
 				"commit":        "abc123def456",
@@ -187,7 +266,7 @@ func (p *Provider) seed() {
 			StartedAt:   now.Add(-8 * time.Hour),
 			FinishedAt:  now.Add(-8 * time.Hour).Add(5*time.Minute + 30*time.Second),
 			URL:         "https://github.com/company/notifications/actions/runs/12349",
-			Actor:       map[string]any{"name": "taylor", "type": "user"},
+			Actor:       mockutil.ActorRef("taylor"),
 			Metadata: map[string]any{
 				"source":        p.cfg.Source,
 				"commit":        "jkl012mno345",
@@ -209,7 +288,7 @@ func (p *Provider) seed() {
 			StartedAt:   now.Add(-3 * time.Hour),
 			FinishedAt:  now.Add(-3 * time.Hour).Add(2*time.Minute + 45*time.Second),
 			URL:         "https://github.com/company/identity/actions/runs/12350",
-			Actor:       map[string]any{"name": "devon", "type": "user"},
+			Actor:       mockutil.ActorRef("devon"),
 			Metadata: map[string]any{
 				"source":        p.cfg.Source,
 				"commit":        "mno345pqr678",
@@ -231,7 +310,7 @@ func (p *Provider) seed() {
 			StartedAt:   now.Add(-15 * time.Minute),
 			FinishedAt:  time.Time{}, // Still running
 			URL:         "https://github.com/company/analytics/actions/runs/12351",
-			Actor:       map[string]any{"name": "maya", "type": "user"},
+			Actor:       mockutil.ActorRef("maya"),
 			Metadata: map[string]any{
 				"source":        p.cfg.Source,
 				"commit":        "pqr678stu901",
@@ -254,7 +333,7 @@ func (p *Provider) seed() {
 			StartedAt:   now.Add(-5 * time.Hour),
 			FinishedAt:  now.Add(-5 * time.Hour).Add(3*time.Minute + 20*time.Second),
 			URL:         "https://github.com/company/search/actions/runs/12352",
-			Actor:       map[string]any{"name": "riley", "type": "user"},
+			Actor:       mockutil.ActorRef("riley"),
 			Metadata: map[string]any{
 				"source":        p.cfg.Source,
 				"commit":        "stu901vwx234",
@@ -276,7 +355,7 @@ func (p *Provider) seed() {
 			StartedAt:   now.Add(-7 * time.Hour),
 			FinishedAt:  now.Add(-7 * time.Hour).Add(1*time.Minute + 55*time.Second),
 			URL:         "https://github.com/company/realtime/actions/runs/12353",
-			Actor:       map[string]any{"name": "samir", "type": "user"},
+			Actor:       mockutil.ActorRef("samir"),
 			Metadata: map[string]any{
 				"source":        p.cfg.Source,
 				"commit":        "vwx234yza567",
@@ -299,7 +378,7 @@ func (p *Provider) seed() {
 			StartedAt:   now.Add(-12 * time.Hour),
 			FinishedAt:  now.Add(-12 * time.Hour).Add(8*time.Minute + 45*time.Second),
 			URL:         "https://github.com/company/warehouse/actions/runs/12354",
-			Actor:       map[string]any{"name": "morgan", "type": "user"},
+			Actor:       mockutil.ActorRef("morgan"),
 			Metadata: map[string]any{
 				"source":        p.cfg.Source,
 				"commit":        "yza567bcd890",
@@ -313,25 +392,102 @@ func (p *Provider) seed() {
 				"health_checks": []string{"http", "database", "s3"},
 			},
 		},
+		{
+			ID:          "deploy-011",
+			Service:     "svc-feature-flags",
+			Environment: "prod",
+			Version:     "config-v2024.11.3",
+			Status:      "running",
+			StartedAt:   now.Add(-30 * time.Minute),
+			FinishedAt:  time.Time{}, // Still fanning out
+			URL:         "https://github.com/company/feature-flags/actions/runs/12355",
+			Actor:       mockutil.ActorRef("config-bot"),
+			Metadata: map[string]any{
+				"source":        p.cfg.Source,
+				"commit":        "bcd890efg123",
+				"branch":        "main",
+				"duration":      "ongoing",
+				"region":        "use1",
+				"rollback":      false,
+				"canary":        false,
+				"blue_green":    false,
+				"rolling":       true,
+				"runbook":       "Global Configuration Update",
+				"health_checks": []string{"http", "flag-evaluation"},
+			},
+		},
 	}
 
 	for _, dep := range seed {
-		applyDeploymentFlair(&dep, now)
+		alignDeploymentWindow(&dep, p.cfg.Location)
+		if p.cfg.Features.Flair {
+			applyDeploymentFlair(&dep, now)
+		}
 		p.deployments[dep.ID] = dep
 		if n, err := fmt.Sscanf(dep.ID, "deploy-%d", &p.nextID); n == 1 && err == nil {
 			// keep last parsed id
 		}
 	}
+	p.rollouts["deploy-011"] = globalConfigRollout(p.deployments["deploy-011"].StartedAt)
+	p.seededAt = now
+}
+
+// seedHistoryLocked adds the generated production history and returns how many
+// deployments it added. Callers must hold p.mu once the provider is shared.
+func (p *Provider) seedHistoryLocked() int {
+	history := historicalDeployments(p.cfg, p.seededAt)
+	for _, dep := range history {
+		p.deployments[dep.ID] = dep
+	}
+	return len(history)
 }
 
 func parseConfig(cfg map[string]any) Config {
-	out := Config{Source: "mock"}
+	out := Config{Source: "mock", DriftRate: defaultDriftRate, FlakyRate: defaultFlakyRate}
 	if v, ok := cfg["source"].(string); ok && v != "" {
 		out.Source = v
 	}
+	switch v := cfg["driftRate"].(type) {
+	case float64:
+		if v >= 0 && v <= 1 {
+			out.DriftRate = v
+		}
+	case int:
+		if v == 0 || v == 1 {
+			out.DriftRate = float64(v)
+		}
+	}
+	switch v := cfg["flakyRate"].(type) {
+	case float64:
+		if v >= 0 && v <= 1 {
+			out.FlakyRate = v
+		}
+	case int:
+		if v == 0 || v == 1 {
+			out.FlakyRate = float64(v)
+		}
+	}
+	out.FailureMix = parseFailureMix(cfg["failureMix"])
+	if windows, ok := parseFreezeWindows(cfg["freezeWindows"]); ok {
+		out.FreezeWindows = windows
+	}
+	out.Location = mockutil.ParseLocation(cfg)
+	out.Warmup = mockutil.ParseWarmup(cfg)
+	out.Features = mockutil.ParseFeatures(cfg)
 	return out
 }
 
+// alignDeploymentWindow moves a finished deployment so it started during business
+// hours in loc, preserving its duration. Running deployments are left as-is.
+func alignDeploymentWindow(dep *schema.Deployment, loc *time.Location) {
+	if loc == nil || dep.FinishedAt.IsZero() {
+		return
+	}
+	shift := mockutil.AlignToBusinessHours(dep.StartedAt, loc).Sub(dep.StartedAt)
+	dep.StartedAt = dep.StartedAt.Add(shift)
+	dep.FinishedAt = dep.FinishedAt.Add(shift)
+}
+
 func applyDeploymentFlair(dep *schema.Deployment, now time.Time) {
 	if dep.Metadata == nil {
 		dep.Metadata = map[string]any{}
@@ -352,10 +508,15 @@ func applyDeploymentFlair(dep *schema.Deployment, now time.Time) {
 	} else if dep.Status == "failed" {
 		dep.Metadata["success_rate"] = "0%"
 		dep.Metadata["error_rate"] = "100%"
+		if _, ok := dep.Metadata["failure_reason"]; !ok {
+			message, _ := dep.Metadata["error"].(string)
+			dep.Metadata["failure_reason"] = classifyFailure(message)
+		}
 	} else {
 		dep.Metadata["success_rate"] = "pending"
 		dep.Metadata["error_rate"] = "pending"
 	}
+	mockutil.LinkRefs(dep.Metadata, dep.Fields)
 }
 
 func getDeploymentType(dep *schema.Deployment) string {
@@ -477,9 +638,6 @@ func matchesDeployment(query schema.DeploymentQuery, dep schema.Deployment) bool
 	if len(query.Versions) > 0 && !matchesVersions(query.Versions, dep.Version) {
 		return false
 	}
-	if len(query.Metadata) > 0 && !matchesMetadata(query.Metadata, dep.Metadata) {
-		return false
-	}
 
 	return true
 }
@@ -518,21 +676,6 @@ func matchesScope(scope schema.QueryScope, dep schema.Deployment) bool {
 	return true
 }
 
-func matchesMetadata(filter map[string]any, metadata map[string]any) bool {
-	if len(filter) == 0 {
-		return true
-	}
-	if len(metadata) == 0 {
-		return false
-	}
-	for k, v := range filter {
-		if !reflect.DeepEqual(metadata[k], v) {
-			return false
-		}
-	}
-	return true
-}
-
 func matchesQuery(filter string, dep schema.Deployment) bool {
 	if filter == "" {
 		return true
@@ -584,7 +727,7 @@ func getScenarioDeployments(now time.Time) []schema.Deployment {
 			StartedAt:   now.Add(-30 * time.Minute),
 			FinishedAt:  now.Add(-25 * time.Minute),
 			URL:         "https://github.com/company/checkout/actions/runs/scenario-001",
-			Actor:       map[string]any{"name": "deploy-bot", "type": "automation"},
+			Actor:       mockutil.ActorRef("deploy-bot"),
 			Metadata: map[string]any{
 				"source":         "mock",
 				"commit":         "scenario001abc",
@@ -612,7 +755,7 @@ func getScenarioDeployments(now time.Time) []schema.Deployment {
 			StartedAt:   now.Add(-45 * time.Minute),
 			FinishedAt:  now.Add(-40 * time.Minute),
 			URL:         "https://github.com/company/search/actions/runs/scenario-002",
-			Actor:       map[string]any{"name": "jamie", "type": "user"},
+			Actor:       mockutil.ActorRef("jamie"),
 			Metadata: map[string]any{
 				"source":         "mock",
 				"commit":         "scenario002def",
@@ -640,7 +783,7 @@ func getScenarioDeployments(now time.Time) []schema.Deployment {
 			StartedAt:   now.Add(-20 * time.Minute),
 			FinishedAt:  now.Add(-15 * time.Minute),
 			URL:         "https://github.com/company/checkout/actions/runs/scenario-003",
-			Actor:       map[string]any{"name": "deploy-bot", "type": "automation"},
+			Actor:       mockutil.ActorRef("deploy-bot"),
 			Metadata: map[string]any{
 				"source":         "mock",
 				"commit":         "scenario003ghi",
@@ -655,6 +798,7 @@ func getScenarioDeployments(now time.Time) []schema.Deployment {
 				"scenario_stage": "incident-trigger",
 				"is_scenario":    true,
 				"error":          "health check failed: elevated error rate detected",
+				"failure_reason": FailureHealthcheck,
 				"health_checks":  []string{"http", "database", "redis"},
 				"success_rate":   "0%",
 				"error_rate":     "100%",
@@ -669,7 +813,7 @@ func getScenarioDeployments(now time.Time) []schema.Deployment {
 			StartedAt:   now.Add(-10 * time.Minute),
 			FinishedAt:  now.Add(-5 * time.Minute),
 			URL:         "https://github.com/company/checkout/actions/runs/scenario-004",
-			Actor:       map[string]any{"name": "deploy-bot", "type": "automation"},
+			Actor:       mockutil.ActorRef("deploy-bot"),
 			Metadata: map[string]any{
 				"source":         "mock",
 				"commit":         "scenario001abc",
@@ -698,7 +842,7 @@ func getScenarioDeployments(now time.Time) []schema.Deployment {
 			StartedAt:   now.Add(-3 * time.Minute),
 			FinishedAt:  time.Time{}, // Still running
 			URL:         "https://github.com/company/search/actions/runs/scenario-005",
-			Actor:       map[string]any{"name": "kim", "type": "user"},
+			Actor:       mockutil.ActorRef("kim"),
 			Metadata: map[string]any{
 				"source":         "mock",
 				"commit":         "scenario005jkl",
@@ -727,7 +871,7 @@ func getScenarioDeployments(now time.Time) []schema.Deployment {
 			StartedAt:   now.Add(-35 * time.Minute),
 			FinishedAt:  now.Add(-30 * time.Minute),
 			URL:         "https://github.com/company/checkout/actions/runs/scenario-006",
-			Actor:       map[string]any{"name": "samir", "type": "user"},
+			Actor:       mockutil.ActorRef("samir"),
 			Metadata: map[string]any{
 				"source":         "mock",
 				"commit":         "scenario006mno",
@@ -750,3 +894,37 @@ func getScenarioDeployments(now time.Time) []schema.Deployment {
 }
 
 var _ deployment.Provider = (*Provider)(nil)
+
+// SearchDocs indexes deployments for global search. Deployments have no
+// title, so one is made from the service, version, and environment; the body
+// holds the status, failure reason, and scenario name.
+func (p *Provider) SearchDocs(ctx context.Context) []mockutil.SearchDoc {
+	_ = ctx
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.history.Ensure()
+	docs := make([]mockutil.SearchDoc, 0, len(p.deployments))
+	for _, dep := range p.deployments {
+		body := []string{dep.Status}
+		for _, key := range []string{"error", "scenario_name"} {
+			if v, ok := dep.Metadata[key].(string); ok {
+				body = append(body, v)
+			}
+		}
+		updated := dep.FinishedAt
+		if updated.IsZero() {
+			updated = dep.StartedAt
+		}
+		docs = append(docs, mockutil.SearchDoc{
+			Kind:      mockutil.RefDeployment,
+			ID:        dep.ID,
+			Title:     fmt.Sprintf("%s %s to %s", dep.Service, dep.Version, dep.Environment),
+			Body:      strings.Join(body, "\n"),
+			Service:   dep.Service,
+			Status:    dep.Status,
+			UpdatedAt: updated,
+		})
+	}
+	return docs
+}
@@ -2,10 +2,18 @@ package deploymentmock
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/opsorch/opsorch-core/orcherr"
 	"github.com/opsorch/opsorch-core/schema"
+	"github.com/opsorch/opsorch-mock-adapters/internal/bundle"
+	"github.com/opsorch/opsorch-mock-adapters/internal/mockutil"
+	"github.com/opsorch/opsorch-mock-adapters/internal/pluginrpc"
 )
 
 func TestProvider_Query(t *testing.T) {
@@ -22,7 +30,7 @@ func TestProvider_Query(t *testing.T) {
 		{
 			name:    "empty query returns all",
 			query:   schema.DeploymentQuery{},
-			wantLen: 16, // 10 seed + 6 scenario deployments
+			wantLen: 17, // 11 seed + 6 scenario deployments
 		},
 		{
 			name: "filter by service",
@@ -36,7 +44,7 @@ func TestProvider_Query(t *testing.T) {
 			query: schema.DeploymentQuery{
 				Scope: schema.QueryScope{Environment: "prod"},
 			},
-			wantLen: 14, // prod deployments
+			wantLen: 15, // prod deployments
 		},
 		{
 			name: "filter by status",
@@ -274,3 +282,481 @@ func TestDeploymentURLGeneration(t *testing.T) {
 		}
 	}
 }
+
+func TestTimezoneAlignsDeployWindows(t *testing.T) {
+	provAny, err := New(map[string]any{"timezone": "America/New_York"})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	prov := provAny.(*Provider)
+	if prov.cfg.Location == nil {
+		t.Skip("timezone data unavailable")
+	}
+
+	for id, dep := range prov.deployments {
+		if dep.FinishedAt.IsZero() {
+			continue
+		}
+		if !mockutil.IsBusinessHour(dep.StartedAt, prov.cfg.Location) {
+			t.Fatalf("deployment %s started outside business hours: %v", id, dep.StartedAt.In(prov.cfg.Location))
+		}
+		if !dep.FinishedAt.After(dep.StartedAt) {
+			t.Fatalf("deployment %s lost its duration", id)
+		}
+	}
+}
+
+func TestProvider_Drift(t *testing.T) {
+	provAny, err := New(map[string]any{"driftRate": 0.0})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	prov := provAny.(*Provider)
+
+	statuses, err := prov.Drift(context.Background(), DriftQuery{})
+	if err != nil {
+		t.Fatalf("Drift() error = %v", err)
+	}
+	if len(statuses) == 0 {
+		t.Fatal("expected drift statuses")
+	}
+	for _, st := range statuses {
+		if st.DriftType == "manual-hotfix" || st.DriftType == "manual-rollback" {
+			t.Errorf("driftRate 0 should not inject drift, got %+v", st)
+		}
+		if st.SyncStatus == SyncStatusSynced && st.LiveVersion != st.DesiredVersion {
+			t.Errorf("synced target has mismatched versions: %+v", st)
+		}
+	}
+
+	drifted, err := prov.Drift(context.Background(), DriftQuery{DriftedOnly: true})
+	if err != nil {
+		t.Fatalf("Drift() error = %v", err)
+	}
+	for _, st := range drifted {
+		if !st.Drifted || st.DriftType != "sync-failed" {
+			t.Errorf("expected only failed syncs as drift, got %+v", st)
+		}
+	}
+
+	allAny, _ := New(map[string]any{"driftRate": 1.0})
+	all, err := allAny.(*Provider).Drift(context.Background(), DriftQuery{Environment: "prod"})
+	if err != nil {
+		t.Fatalf("Drift() error = %v", err)
+	}
+	for _, st := range all {
+		if st.Environment != "prod" {
+			t.Errorf("environment filter ignored: %+v", st)
+		}
+		if st.SyncStatus == SyncStatusSynced {
+			t.Errorf("driftRate 1 should drift every settled target, got %+v", st)
+		}
+		if st.Drifted && st.LiveVersion == st.DesiredVersion && st.DriftType != "sync-failed" {
+			t.Errorf("drifted target should differ from desired: %+v", st)
+		}
+	}
+}
+
+func TestFreezeWindowEnforcement(t *testing.T) {
+	now := time.Now().UTC()
+	provAny, err := New(map[string]any{"freezeWindows": []any{
+		map[string]any{
+			"id":       "freeze-payments",
+			"title":    "Payments audit freeze",
+			"start":    now.Add(-time.Hour).Format(time.RFC3339),
+			"end":      now.Add(24 * time.Hour).Format(time.RFC3339),
+			"services": []any{"svc-payments"},
+			"allowed":  []any{"hotfix"},
+		},
+	}})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	prov := provAny.(*Provider)
+	ctx := context.Background()
+
+	_, err = prov.Create(ctx, CreateRequest{Service: "svc-payments", Version: "v5.13.0", Actor: "sam"})
+	var oe orcherr.OpsOrchError
+	if !errors.As(err, &oe) || oe.Code != "forbidden" {
+		t.Fatalf("expected forbidden during the freeze, got %v", err)
+	}
+	violation, ok := oe.Err.(*FreezeViolation)
+	if !ok || violation.Policy != PolicyChangeFreeze || violation.FreezeID != "freeze-payments" || violation.Action != "create" {
+		t.Fatalf("expected a change freeze violation, got %+v", oe.Err)
+	}
+
+	if _, err := prov.Create(ctx, CreateRequest{Service: "svc-payments", Version: "v5.12.5", ChangeType: ChangeHotfix}); err != nil {
+		t.Errorf("expected hotfix allowed through the freeze, got %v", err)
+	}
+	if _, err := prov.Create(ctx, CreateRequest{Service: "svc-payments", Environment: "staging", Version: "v5.13.0"}); err != nil {
+		t.Errorf("expected staging outside the freeze, got %v", err)
+	}
+
+	dep, err := prov.Create(ctx, CreateRequest{Service: "svc-payments", Version: "v5.13.0", Actor: "sam", Override: true, OverrideReason: "PCI fix"})
+	if err != nil {
+		t.Fatalf("expected override to go through, got %v", err)
+	}
+	overrides, _ := dep.Metadata["freezeOverrides"].([]FreezeOverride)
+	if dep.Status != StatusPendingApproval || len(overrides) != 1 || overrides[0].Actor != "sam" || overrides[0].Reason != "PCI fix" {
+		t.Fatalf("expected a pending deployment recording the override, got %+v", dep)
+	}
+
+	if _, err := prov.Approve(ctx, ApproveRequest{ID: dep.ID, Actor: "casey"}); !errors.As(err, &oe) || oe.Code != "forbidden" {
+		t.Fatalf("expected approval blocked during the freeze, got %v", err)
+	}
+	approved, err := prov.Approve(ctx, ApproveRequest{ID: dep.ID, Actor: "casey", Override: true})
+	if err != nil {
+		t.Fatalf("Approve returned error: %v", err)
+	}
+	overrides, _ = approved.Metadata["freezeOverrides"].([]FreezeOverride)
+	if approved.Status != "running" || approved.Fields["approvedBy"] != "casey" || len(overrides) != 2 || overrides[1].Action != "approve" {
+		t.Fatalf("expected a running deployment with both overrides, got %+v", approved)
+	}
+	if _, err := prov.Approve(ctx, ApproveRequest{ID: dep.ID}); err == nil || !strings.Contains(err.Error(), "bad_request") {
+		t.Errorf("expected bad_request approving twice, got %v", err)
+	}
+
+	// Without configured windows the calendar's checkout freeze applies.
+	defaults, _ := New(nil)
+	later := now.Add(48 * time.Hour)
+	if _, err := defaults.(*Provider).Create(ctx, CreateRequest{Service: "svc-checkout", Version: "v2.32.0", ScheduledAt: later}); err == nil || !strings.Contains(err.Error(), "freeze-checkout-error-budget") {
+		t.Errorf("expected the checkout error budget freeze, got %v", err)
+	}
+	scheduled, err := defaults.(*Provider).Create(ctx, CreateRequest{Service: "svc-checkout", Version: "v2.31.2", ChangeType: ChangeRollback, ScheduledAt: later})
+	if err != nil {
+		t.Fatalf("expected rollback allowed, got %v", err)
+	}
+	if scheduled, err = defaults.(*Provider).Approve(ctx, ApproveRequest{ID: scheduled.ID}); err != nil || scheduled.Status != StatusScheduled {
+		t.Errorf("expected approved rollback scheduled, got %+v (%v)", scheduled, err)
+	}
+}
+
+func TestFreezeOverridesSurviveRestore(t *testing.T) {
+	now := time.Now().UTC()
+	cfg := map[string]any{"freezeWindows": []any{
+		map[string]any{
+			"id":       "freeze-payments",
+			"start":    now.Add(-time.Hour).Format(time.RFC3339),
+			"end":      now.Add(24 * time.Hour).Format(time.RFC3339),
+			"services": []any{"svc-payments"},
+		},
+	}}
+	ctx := context.Background()
+	firstAny, _ := New(cfg)
+	first := firstAny.(*Provider)
+	dep, err := first.Create(ctx, CreateRequest{Service: "svc-payments", Version: "v5.13.0", Actor: "sam", Override: true, OverrideReason: "PCI fix"})
+	if err != nil {
+		t.Fatalf("Create returned error: %v", err)
+	}
+
+	raw, err := json.Marshal(bundle.Bundle{Version: bundle.Version, Deployments: first.Snapshot()})
+	if err != nil {
+		t.Fatalf("marshal bundle: %v", err)
+	}
+	var b bundle.Bundle
+	if err := json.Unmarshal(raw, &b); err != nil {
+		t.Fatalf("unmarshal bundle: %v", err)
+	}
+	secondAny, _ := New(cfg)
+	second := secondAny.(*Provider)
+	second.Restore(&b)
+
+	approved, err := second.Approve(ctx, ApproveRequest{ID: dep.ID, Actor: "casey", Override: true})
+	if err != nil {
+		t.Fatalf("Approve returned error: %v", err)
+	}
+	overrides, _ := approved.Metadata["freezeOverrides"].([]FreezeOverride)
+	if len(overrides) != 2 || overrides[0].Reason != "PCI fix" || overrides[1].Action != "approve" {
+		t.Fatalf("expected the restored override kept alongside the new one, got %+v", approved.Metadata["freezeOverrides"])
+	}
+}
+
+func TestRegions(t *testing.T) {
+	provAny, _ := New(nil)
+	prov := provAny.(*Provider)
+	ctx := context.Background()
+
+	global, err := prov.Regions(ctx, "deploy-011")
+	if err != nil {
+		t.Fatalf("Regions returned error: %v", err)
+	}
+	if global.Strategy != "fan-out" || len(global.Regions) != 4 {
+		t.Fatalf("expected 4-region fan-out rollout, got %+v", global)
+	}
+	byRegion := map[string]RegionStatus{}
+	for _, r := range global.Regions {
+		byRegion[r.Region] = r
+	}
+	if use1 := byRegion["use1"]; use1.Status != RegionSucceeded || use1.FinishedAt == nil {
+		t.Errorf("expected use1 done, got %+v", use1)
+	}
+	if euw1 := byRegion["euw1"]; euw1.Status != RegionInProgress || euw1.StartedAt == nil || euw1.FinishedAt != nil {
+		t.Errorf("expected euw1 in progress, got %+v", euw1)
+	}
+
+	failed, _ := prov.Regions(ctx, "deploy-003")
+	if failed.Regions[0].Status != RegionFailed || failed.Regions[1].Status != RegionSkipped {
+		t.Errorf("expected failed home region and skipped rest, got %+v", failed.Regions)
+	}
+	done, _ := prov.Regions(ctx, "deploy-001")
+	for _, r := range done.Regions {
+		if r.Status != RegionSucceeded || r.FinishedAt.After(*done.Regions[len(done.Regions)-1].FinishedAt) {
+			t.Errorf("expected ordered successful regions, got %+v", done.Regions)
+		}
+	}
+	staging, _ := prov.Regions(ctx, "deploy-008")
+	if len(staging.Regions) != 1 {
+		t.Errorf("expected staging deploy to touch one region, got %+v", staging.Regions)
+	}
+
+	// Returned rollouts are copies.
+	*global.Regions[0].StartedAt = time.Time{}
+	again, _ := prov.Regions(ctx, "deploy-011")
+	if again.Regions[0].StartedAt.IsZero() {
+		t.Error("mutating a returned rollout changed provider state")
+	}
+
+	if _, err := prov.Regions(ctx, "deploy-missing"); err == nil || !strings.Contains(err.Error(), "not_found") {
+		t.Errorf("expected not_found, got %v", err)
+	}
+}
+
+func TestDeploymentHistory(t *testing.T) {
+	provAny, err := New(map[string]any{})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	prov := provAny.(*Provider)
+	ctx := context.Background()
+
+	recent, err := prov.Query(ctx, schema.DeploymentQuery{})
+	if err != nil {
+		t.Fatalf("Query returned error: %v", err)
+	}
+	for _, dep := range recent {
+		if isHistoricalDeployment(dep) {
+			t.Fatalf("historical deployment %s returned by default query", dep.ID)
+		}
+	}
+
+	history, err := prov.History(ctx, HistoryQuery{Service: "svc-checkout", Environment: "prod"})
+	if err != nil {
+		t.Fatalf("History returned error: %v", err)
+	}
+	if len(history) < 25 {
+		t.Fatalf("expected several weeks of checkout deployments, got %d", len(history))
+	}
+	if span := history[len(history)-1].StartedAt.Sub(history[0].StartedAt); span < 28*24*time.Hour {
+		t.Fatalf("expected at least four weeks of history, got %v", span)
+	}
+	for i, dep := range history {
+		if i > 0 && dep.StartedAt.Before(history[i-1].StartedAt) {
+			t.Fatalf("history not sorted oldest first at %s", dep.ID)
+		}
+	}
+	if !isHistoricalDeployment(history[0]) || isHistoricalDeployment(history[len(history)-1]) {
+		t.Fatalf("expected generated history to lead into the seeded deployments")
+	}
+
+	all, err := prov.History(ctx, HistoryQuery{})
+	if err != nil {
+		t.Fatalf("History returned error: %v", err)
+	}
+	failed, retried, rolledBack := 0, 0, 0
+	for _, dep := range all {
+		if !isHistoricalDeployment(dep) {
+			continue
+		}
+		if dep.StartedAt.Weekday() == time.Saturday || dep.StartedAt.Weekday() == time.Sunday {
+			t.Fatalf("historical deployment %s on a weekend", dep.ID)
+		}
+		switch {
+		case dep.Status == "failed":
+			failed++
+		case dep.Metadata["retry_of"] != nil:
+			retried++
+		case dep.Metadata["rolled_back_from"] != nil:
+			rolledBack++
+		}
+	}
+	if failed == 0 || retried != failed || rolledBack == 0 {
+		t.Fatalf("expected mixed outcomes, got %d failed, %d retried, %d rolled back", failed, retried, rolledBack)
+	}
+
+	week, err := prov.History(ctx, HistoryQuery{Service: "svc-checkout", Days: 7})
+	if err != nil {
+		t.Fatalf("History returned error: %v", err)
+	}
+	if len(week) == 0 || len(week) >= len(history) || week[0].StartedAt.Before(time.Now().AddDate(0, 0, -7)) {
+		t.Fatalf("expected days to narrow the history, got %d of %d", len(week), len(history))
+	}
+}
+
+func TestFailureReasonsAndFlakyDeploys(t *testing.T) {
+	ctx := context.Background()
+	provAny, _ := New(map[string]any{})
+	prov := provAny.(*Provider)
+
+	report, err := prov.Failures(ctx, HistoryQuery{})
+	if err != nil {
+		t.Fatalf("Failures returned error: %v", err)
+	}
+	reasons := 0
+	for _, n := range report.ByReason {
+		if n > 0 {
+			reasons++
+		}
+	}
+	if report.Failed == 0 || reasons < 3 || report.Flaky == 0 || report.Flaky == report.Failed {
+		t.Fatalf("expected varied failure reasons and some flaky deploys, got %+v", report)
+	}
+
+	history, _ := prov.History(ctx, HistoryQuery{})
+	failedByID := map[string]schema.Deployment{}
+	for _, dep := range history {
+		if dep.Status == "failed" {
+			failedByID[dep.ID] = dep
+		}
+	}
+	for _, dep := range history {
+		retryOf, _ := dep.Metadata["retry_of"].(string)
+		if retryOf == "" {
+			continue
+		}
+		failed := failedByID[retryOf]
+		flaky, _ := failed.Metadata["flaky"].(bool)
+		if sameBuild := dep.Metadata["commit"] == failed.Metadata["commit"]; sameBuild != flaky || dep.Status != "success" {
+			t.Fatalf("retry %s of %s: flaky %v but same build %v", dep.ID, retryOf, flaky, sameBuild)
+		}
+	}
+
+	seeded, _ := prov.Get(ctx, "deploy-003")
+	if seeded.Metadata["failure_reason"] != FailureHealthcheck {
+		t.Fatalf("expected the seeded failure classified as healthcheck, got %v", seeded.Metadata["failure_reason"])
+	}
+
+	quotaAny, _ := New(map[string]any{"failureMix": map[string]any{"quota": 1}, "flakyRate": 0})
+	quota, _ := quotaAny.(*Provider).Failures(ctx, HistoryQuery{})
+	// The seeded failures keep their health check reason.
+	if quota.ByReason[FailureQuota] == 0 || quota.ByReason[FailureMigration]+quota.ByReason[FailureImagePull] != 0 || quota.Flaky != 0 {
+		t.Fatalf("expected only non-flaky quota failures in the history, got %+v", quota)
+	}
+}
+
+func TestDeploymentActorsComeFromRegistry(t *testing.T) {
+	provAny, err := New(map[string]any{})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	dep, err := provAny.(*Provider).Get(context.Background(), "deploy-001")
+	if err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+	want := mockutil.ActorRef("alex")
+	for _, key := range []string{"type", "name", "displayName", "email", "avatar"} {
+		if dep.Actor[key] != want[key] {
+			t.Fatalf("actor %s = %v, want %v", key, dep.Actor[key], want[key])
+		}
+	}
+}
+
+func TestFlairDisabled(t *testing.T) {
+	prov, _ := New(map[string]any{"flair": false, "warmup": true})
+	out, err := prov.Query(context.Background(), schema.DeploymentQuery{})
+	if err != nil || len(out) == 0 {
+		t.Fatalf("expected deployments, got %d (%v)", len(out), err)
+	}
+	for _, dep := range out {
+		if _, ok := dep.Metadata["deployment_type"]; ok {
+			t.Fatalf("expected no flair on %s, got %v", dep.ID, dep.Metadata)
+		}
+	}
+}
+
+func TestFailedDeployOpensLinkedIncident(t *testing.T) {
+	var opened []mockutil.LinkedIncident
+	t.Cleanup(func() { mockutil.PublishSourceAlerts(AlertSource, nil) })
+
+	prov, _ := New(nil)
+	prov.(*Provider).SetIncidentOpener(func(ctx context.Context, in mockutil.LinkedIncident) (string, error) {
+		opened = append(opened, in)
+		return fmt.Sprintf("inc-%03d", 100+len(opened)), nil
+	})
+	for i := 0; i < 2; i++ {
+		if _, err := prov.Query(context.Background(), schema.DeploymentQuery{}); err != nil {
+			t.Fatalf("Query returned error: %v", err)
+		}
+	}
+	if len(opened) != 1 {
+		t.Fatalf("expected one incident opened across queries, got %d", len(opened))
+	}
+	in := opened[0]
+	if in.Service != "svc-checkout" || in.Metadata["deployment_id"] != "deploy-scenario-003" || in.Metadata["alert_id"] != "al-deploy-deploy-scenario-003" {
+		t.Fatalf("unexpected linked incident: %+v", in)
+	}
+
+	dep, err := prov.Get(context.Background(), "deploy-scenario-003")
+	if err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+	if dep.Metadata["incident_id"] != "inc-101" || dep.Metadata["alert_id"] != "al-deploy-deploy-scenario-003" {
+		t.Fatalf("expected deployment linked to incident and alert, got %v", dep.Metadata)
+	}
+
+	var alert *schema.Alert
+	for _, al := range mockutil.SourceAlerts() {
+		if al.ID == "al-deploy-deploy-scenario-003" {
+			alert = &al
+		}
+	}
+	if alert == nil {
+		t.Fatal("expected failed deployment alert in the shared snapshot")
+	}
+	if alert.Severity != "critical" || alert.Metadata["incident_id"] != "inc-101" || alert.Fields["deployment_id"] != "deploy-scenario-003" {
+		t.Fatalf("unexpected failed deployment alert: %+v", alert)
+	}
+}
+
+func TestFailedDeployCorrelationNeedsScenarioEffects(t *testing.T) {
+	called := false
+	prov, _ := New(map[string]any{"scenarioEffects": false})
+	prov.(*Provider).SetIncidentOpener(func(ctx context.Context, in mockutil.LinkedIncident) (string, error) {
+		called = true
+		return "inc-101", nil
+	})
+	if _, err := prov.Query(context.Background(), schema.DeploymentQuery{}); err != nil {
+		t.Fatalf("Query returned error: %v", err)
+	}
+	if called {
+		t.Fatal("expected no incident opened with scenario effects off")
+	}
+}
+
+// BenchmarkQuery lists 1k, 10k, and 100k deployments cloned from a seed and
+// encodes them the way a plugin returns them.
+func BenchmarkQuery(b *testing.B) {
+	for _, n := range []int{1000, 10000, 100000} {
+		b.Run(fmt.Sprintf("deployments=%d", n), func(b *testing.B) {
+			provAny, _ := New(nil)
+			prov := provAny.(*Provider)
+			base := prov.deployments["deploy-001"]
+			for i := 0; i < n; i++ {
+				dep := cloneDeployment(base)
+				dep.ID = fmt.Sprintf("deploy-bench-%06d", i)
+				prov.deployments[dep.ID] = dep
+			}
+			ctx := context.Background()
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				out, err := prov.Query(ctx, schema.DeploymentQuery{})
+				if err != nil {
+					b.Fatalf("Query returned error: %v", err)
+				}
+				if _, err := json.Marshal(pluginrpc.Response{Result: out}); err != nil {
+					b.Fatalf("marshal: %v", err)
+				}
+			}
+		})
+	}
+}
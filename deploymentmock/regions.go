@@ -0,0 +1,166 @@
+package deploymentmock
+
+import (
+	"context"
+	"time"
+
+	"github.com/opsorch/opsorch-core/orcherr"
+	"github.com/opsorch/opsorch-core/schema"
+)
+
+// Region rollout states.
+const (
+	RegionPending    = "pending"
+	RegionInProgress = "in_progress"
+	RegionSucceeded  = "succeeded"
+	RegionFailed     = "failed"
+	RegionSkipped    = "skipped"
+)
+
+// rolloutRegions is the order production deploys move through regions: the
+// home region first, then the rest of the fleet.
+var rolloutRegions = []string{"use1", "usw2", "euw1", "apse1"}
+
+// regionSlot is how long each region takes in a derived sequential rollout.
+const regionSlot = 10 * time.Minute
+
+// RegionStatus is one region's progress within a deployment.
+type RegionStatus struct {
+	Region     string     `json:"region"`
+	Wave       int        `json:"wave"`
+	Status     string     `json:"status"`
+	Progress   int        `json:"progress"`
+	StartedAt  *time.Time `json:"startedAt,omitempty"`
+	FinishedAt *time.Time `json:"finishedAt,omitempty"`
+	Message    string     `json:"message,omitempty"`
+}
+
+// RegionRollout reports how a deployment is progressing region by region.
+type RegionRollout struct {
+	DeploymentID string         `json:"deploymentId"`
+	Service      string         `json:"service"`
+	Version      string         `json:"version"`
+	Status       string         `json:"status"`
+	Strategy     string         `json:"strategy"`
+	Regions      []RegionStatus `json:"regions"`
+}
+
+// Regions returns the per-region rollout for a deployment. Seeded multi-region
+// rollouts keep their recorded state; other production deployments are modeled
+// as moving through rolloutRegions one at a time, and non-production
+// deployments touch only their own region.
+func (p *Provider) Regions(ctx context.Context, id string) (RegionRollout, error) {
+	_ = ctx
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	dep, ok := p.deploymentLocked(id)
+	if !ok {
+		return RegionRollout{}, orcherr.New("not_found", "deployment not found", nil)
+	}
+	rollout := RegionRollout{
+		DeploymentID: dep.ID,
+		Service:      dep.Service,
+		Version:      dep.Version,
+		Status:       dep.Status,
+		Strategy:     "sequential",
+	}
+	if seeded, ok := p.rollouts[id]; ok {
+		rollout.Strategy = seeded.strategy
+		rollout.Regions = cloneRegions(seeded.regions)
+		return rollout, nil
+	}
+	rollout.Regions = deriveRegions(dep, time.Now().UTC())
+	return rollout, nil
+}
+
+// seededRollout pins the region state of a deployment whose story depends on it.
+type seededRollout struct {
+	strategy string
+	regions  []RegionStatus
+}
+
+// globalConfigRollout mirrors the Global Configuration Update plan: the home
+// region goes first, then the remaining regions fan out in parallel.
+func globalConfigRollout(startedAt time.Time) seededRollout {
+	at := func(d time.Duration) *time.Time {
+		t := startedAt.Add(d)
+		return &t
+	}
+	return seededRollout{
+		strategy: "fan-out",
+		regions: []RegionStatus{
+			{Region: "use1", Wave: 1, Status: RegionSucceeded, Progress: 100, StartedAt: at(0), FinishedAt: at(8 * time.Minute), Message: "Config v2024.11.3 applied; global lock held"},
+			{Region: "usw2", Wave: 2, Status: RegionSucceeded, Progress: 100, StartedAt: at(10 * time.Minute), FinishedAt: at(17 * time.Minute), Message: "Config applied, services restarted"},
+			{Region: "euw1", Wave: 2, Status: RegionInProgress, Progress: 60, StartedAt: at(10 * time.Minute), Message: "Restarting flag evaluators (12/20 pods)"},
+			{Region: "apse1", Wave: 2, Status: RegionPending, Message: "Waiting for APAC change window"},
+		},
+	}
+}
+
+func deriveRegions(dep schema.Deployment, now time.Time) []RegionStatus {
+	regions := rolloutRegions
+	if dep.Environment != "prod" {
+		home, _ := dep.Metadata["region"].(string)
+		if home == "" {
+			home = rolloutRegions[0]
+		}
+		regions = []string{home}
+	}
+
+	out := make([]RegionStatus, len(regions))
+	slot := regionSlot
+	if !dep.FinishedAt.IsZero() {
+		slot = dep.FinishedAt.Sub(dep.StartedAt) / time.Duration(len(regions))
+	}
+	for i, region := range regions {
+		start := dep.StartedAt.Add(time.Duration(i) * slot)
+		end := start.Add(slot)
+		status := RegionStatus{Region: region, Wave: i + 1, Status: RegionPending}
+		switch dep.Status {
+		case "success":
+			status.Status, status.Progress = RegionSucceeded, 100
+			status.StartedAt, status.FinishedAt = &start, &end
+		case "failed":
+			// The first region fails and the rollout halts before the rest.
+			if i == 0 {
+				finished := dep.FinishedAt
+				status.Status = RegionFailed
+				status.StartedAt, status.FinishedAt = &start, &finished
+				status.Message = "Health checks failed; rollout halted"
+			} else {
+				status.Status = RegionSkipped
+				status.Message = "Not started after failure in " + regions[0]
+			}
+		case "running":
+			switch {
+			case !now.Before(end):
+				status.Status, status.Progress = RegionSucceeded, 100
+				status.StartedAt, status.FinishedAt = &start, &end
+			case !now.Before(start):
+				status.Status = RegionInProgress
+				status.Progress = int(100 * now.Sub(start) / slot)
+				status.StartedAt = &start
+			}
+		}
+		out[i] = status
+	}
+	return out
+}
+
+func cloneRegions(in []RegionStatus) []RegionStatus {
+	out := make([]RegionStatus, len(in))
+	copy(out, in)
+	for i := range out {
+		if in[i].StartedAt != nil {
+			t := *in[i].StartedAt
+			out[i].StartedAt = &t
+		}
+		if in[i].FinishedAt != nil {
+			t := *in[i].FinishedAt
+			out[i].FinishedAt = &t
+		}
+	}
+	return out
+}
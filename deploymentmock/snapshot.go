@@ -0,0 +1,54 @@
+package deploymentmock
+
+import (
+	"fmt"
+
+	"github.com/opsorch/opsorch-core/schema"
+	"github.com/opsorch/opsorch-mock-adapters/internal/bundle"
+)
+
+// Snapshot returns the provider's deployments sorted by ID for export.
+func (p *Provider) Snapshot() []schema.Deployment {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.history.Ensure()
+	ids := sortedDeploymentIDs(p.deployments)
+	out := make([]schema.Deployment, 0, len(ids))
+	for _, id := range ids {
+		out = append(out, cloneDeployment(p.deployments[id]))
+	}
+	return out
+}
+
+// Restore replaces the provider's deployments with a bundle's while it is
+// running, as the snapshot config does at startup. Sections the bundle leaves
+// empty keep their current records.
+func (p *Provider) Restore(b *bundle.Bundle) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.restore(b)
+}
+
+// restore replaces the seeded deployments with a bundle's. Seeded region
+// rollouts are dropped for deployments the bundle does not contain.
+func (p *Provider) restore(b *bundle.Bundle) {
+	if b == nil || len(b.Deployments) == 0 {
+		return
+	}
+	p.history.Skip()
+	p.deployments = make(map[string]schema.Deployment, len(b.Deployments))
+	p.nextID = 0
+	for _, dep := range b.Deployments {
+		p.deployments[dep.ID] = cloneDeployment(dep)
+		var n int
+		if _, err := fmt.Sscanf(dep.ID, "deploy-%d", &n); err == nil && n > p.nextID {
+			p.nextID = n
+		}
+	}
+	for id := range p.rollouts {
+		if _, ok := p.deployments[id]; !ok {
+			delete(p.rollouts, id)
+		}
+	}
+}
@@ -0,0 +1,241 @@
+package dnsmock
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/opsorch/opsorch-core/orcherr"
+	"github.com/opsorch/opsorch-mock-adapters/internal/mockutil"
+)
+
+// ProviderName identifies the mock DNS and certificate provider.
+const ProviderName = "mock"
+
+// Certificate statuses.
+const (
+	CertStatusValid    = "valid"
+	CertStatusExpiring = "expiring"
+	CertStatusExpired  = "expired"
+)
+
+// defaultExpiringDays is the renewal window that marks a certificate expiring.
+const defaultExpiringDays = 30
+
+// Config controls mock DNS and certificate data.
+type Config struct {
+	Source       string
+	ExpiringDays int
+}
+
+// Provider tracks DNS zones, records, and TLS certificates for the demo domains.
+type Provider struct {
+	cfg     Config
+	certs   []Certificate
+	records []Record
+}
+
+// Certificate is a TLS certificate and where it is deployed.
+type Certificate struct {
+	ID            string         `json:"id"`
+	Domain        string         `json:"domain"`
+	SANs          []string       `json:"sans,omitempty"`
+	Issuer        string         `json:"issuer"`
+	Serial        string         `json:"serial"`
+	NotBefore     time.Time      `json:"notBefore"`
+	NotAfter      time.Time      `json:"notAfter"`
+	DaysRemaining int            `json:"daysRemaining"`
+	Status        string         `json:"status"`
+	AutoRenew     bool           `json:"autoRenew"`
+	Service       string         `json:"service"`
+	Team          string         `json:"team"`
+	AttachedTo    []string       `json:"attachedTo,omitempty"`
+	Metadata      map[string]any `json:"metadata,omitempty"`
+}
+
+// CertQuery filters certificates. ExpiringWithinDays keeps certificates whose
+// expiry is at most that many days away, including expired ones.
+type CertQuery struct {
+	Service            string `json:"service,omitempty"`
+	Status             string `json:"status,omitempty"`
+	Domain             string `json:"domain,omitempty"`
+	ExpiringWithinDays int    `json:"expiringWithinDays,omitempty"`
+}
+
+// Record is a DNS resource record set.
+type Record struct {
+	Zone     string         `json:"zone"`
+	Name     string         `json:"name"`
+	Type     string         `json:"type"`
+	TTL      int            `json:"ttl"`
+	Values   []string       `json:"values"`
+	Service  string         `json:"service,omitempty"`
+	Region   string         `json:"region,omitempty"`
+	Health   string         `json:"health"`
+	Metadata map[string]any `json:"metadata,omitempty"`
+}
+
+// RecordQuery filters DNS records. Name matches as a case-insensitive substring.
+type RecordQuery struct {
+	Zone    string `json:"zone,omitempty"`
+	Name    string `json:"name,omitempty"`
+	Type    string `json:"type,omitempty"`
+	Service string `json:"service,omitempty"`
+	Region  string `json:"region,omitempty"`
+}
+
+// New constructs the mock DNS and certificate provider.
+func New(cfg map[string]any) (*Provider, error) {
+	p := &Provider{cfg: parseConfig(cfg)}
+	now := time.Now().UTC()
+	p.certs = p.seedCertificates(now)
+	p.records = p.seedRecords()
+	return p, nil
+}
+
+// ListCertificates returns matching certificates, soonest expiry first.
+func (p *Provider) ListCertificates(ctx context.Context, query CertQuery) ([]Certificate, error) {
+	_ = ctx
+
+	now := time.Now().UTC()
+	out := make([]Certificate, 0, len(p.certs))
+	for _, cert := range p.certs {
+		cert = p.withStatus(cert, now)
+		if query.Service != "" && cert.Service != query.Service {
+			continue
+		}
+		if query.Status != "" && cert.Status != query.Status {
+			continue
+		}
+		if query.Domain != "" && !certCovers(cert, query.Domain) {
+			continue
+		}
+		if query.ExpiringWithinDays > 0 && cert.DaysRemaining > query.ExpiringWithinDays {
+			continue
+		}
+		out = append(out, cloneCertificate(cert))
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].NotAfter.Before(out[j].NotAfter) })
+	return out, nil
+}
+
+// GetCertificate returns a certificate by ID or by a domain it covers.
+func (p *Provider) GetCertificate(ctx context.Context, ref string) (Certificate, error) {
+	_ = ctx
+
+	now := time.Now().UTC()
+	for _, cert := range p.certs {
+		if cert.ID == ref {
+			return cloneCertificate(p.withStatus(cert, now)), nil
+		}
+	}
+	for _, cert := range p.certs {
+		if certCovers(cert, ref) {
+			return cloneCertificate(p.withStatus(cert, now)), nil
+		}
+	}
+	return Certificate{}, orcherr.New("not_found", "certificate not found", nil)
+}
+
+// QueryRecords returns matching DNS records ordered by zone, name, and type.
+func (p *Provider) QueryRecords(ctx context.Context, query RecordQuery) ([]Record, error) {
+	_ = ctx
+
+	out := make([]Record, 0)
+	for _, rec := range p.records {
+		if query.Zone != "" && rec.Zone != query.Zone {
+			continue
+		}
+		if query.Name != "" && !strings.Contains(strings.ToLower(rec.Name), strings.ToLower(query.Name)) {
+			continue
+		}
+		if query.Type != "" && !strings.EqualFold(rec.Type, query.Type) {
+			continue
+		}
+		if query.Service != "" && rec.Service != query.Service {
+			continue
+		}
+		if query.Region != "" && rec.Region != query.Region {
+			continue
+		}
+		out = append(out, cloneRecord(rec))
+	}
+	return out, nil
+}
+
+// withStatus recomputes days remaining and status against now.
+func (p *Provider) withStatus(cert Certificate, now time.Time) Certificate {
+	remaining := cert.NotAfter.Sub(now)
+	cert.DaysRemaining = int(math.Floor(remaining.Hours() / 24))
+	switch {
+	case remaining <= 0:
+		cert.Status = CertStatusExpired
+	case cert.DaysRemaining <= p.cfg.ExpiringDays:
+		cert.Status = CertStatusExpiring
+	default:
+		cert.Status = CertStatusValid
+	}
+	return cert
+}
+
+// certCovers reports whether the certificate is valid for domain, honoring
+// single-label wildcards.
+func certCovers(cert Certificate, domain string) bool {
+	domain = strings.ToLower(strings.TrimSuffix(domain, "."))
+	for _, name := range append([]string{cert.Domain}, cert.SANs...) {
+		name = strings.ToLower(name)
+		if name == domain {
+			return true
+		}
+		if strings.HasPrefix(name, "*.") {
+			suffix := name[1:]
+			if strings.HasSuffix(domain, suffix) && !strings.Contains(strings.TrimSuffix(domain, suffix), ".") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func serial(seed string) string {
+	h := fnv.New64a()
+	h.Write([]byte(seed))
+	return fmt.Sprintf("%016x", h.Sum64())
+}
+
+func cloneCertificate(in Certificate) Certificate {
+	out := in
+	out.SANs = mockutil.CloneStringSlice(in.SANs)
+	out.AttachedTo = mockutil.CloneStringSlice(in.AttachedTo)
+	out.Metadata = mockutil.CloneMap(in.Metadata)
+	return out
+}
+
+func cloneRecord(in Record) Record {
+	out := in
+	out.Values = mockutil.CloneStringSlice(in.Values)
+	out.Metadata = mockutil.CloneMap(in.Metadata)
+	return out
+}
+
+func parseConfig(cfg map[string]any) Config {
+	out := Config{Source: "mock", ExpiringDays: defaultExpiringDays}
+	if v, ok := cfg["source"].(string); ok && v != "" {
+		out.Source = v
+	}
+	switch v := cfg["expiringDays"].(type) {
+	case int:
+		if v > 0 {
+			out.ExpiringDays = v
+		}
+	case float64:
+		if v > 0 {
+			out.ExpiringDays = int(v)
+		}
+	}
+	return out
+}
@@ -0,0 +1,85 @@
+package dnsmock
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestCertificatesExpiringSoonPairWithAlert(t *testing.T) {
+	prov, err := New(map[string]any{"source": "test"})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+
+	expiring, err := prov.ListCertificates(context.Background(), CertQuery{Status: CertStatusExpiring})
+	if err != nil {
+		t.Fatalf("ListCertificates returned error: %v", err)
+	}
+	if len(expiring) == 0 || expiring[0].ID != "cert-api-demo-com" {
+		t.Fatalf("expected api.demo.com to expire first, got %+v", expiring)
+	}
+	api := expiring[0]
+	if api.DaysRemaining < 13 || api.DaysRemaining > 14 || api.AutoRenew {
+		t.Fatalf("unexpected api.demo.com certificate: %+v", api)
+	}
+	if api.Metadata["alert_id"] != "al-009" || !strings.Contains(api.Metadata["runbook"].(string), "cert-rotation") {
+		t.Fatalf("expected alert and runbook links, got %v", api.Metadata)
+	}
+
+	soon, _ := prov.ListCertificates(context.Background(), CertQuery{ExpiringWithinDays: 30})
+	for _, cert := range soon {
+		if cert.DaysRemaining > 30 {
+			t.Fatalf("expiry filter ignored: %+v", cert)
+		}
+	}
+	expired, _ := prov.ListCertificates(context.Background(), CertQuery{Status: CertStatusExpired})
+	if len(expired) != 1 || expired[0].ID != "cert-status-legacy" {
+		t.Fatalf("expected one expired certificate, got %+v", expired)
+	}
+}
+
+func TestGetCertificateByIDOrDomain(t *testing.T) {
+	prov, _ := New(nil)
+
+	byDomain, err := prov.GetCertificate(context.Background(), "checkout.api.demo.com")
+	if err != nil || byDomain.ID != "cert-api-demo-com" {
+		t.Fatalf("expected wildcard SAN match, got %+v, %v", byDomain, err)
+	}
+	internal, err := prov.GetCertificate(context.Background(), "search.internal.demo.com")
+	if err != nil || internal.ID != "cert-internal-wildcard" {
+		t.Fatalf("expected internal wildcard, got %+v, %v", internal, err)
+	}
+	if _, err := prov.GetCertificate(context.Background(), "a.b.internal.demo.com"); err == nil || !strings.Contains(err.Error(), "not_found") {
+		t.Fatalf("wildcards must not match nested labels, got %v", err)
+	}
+}
+
+func TestQueryRecords(t *testing.T) {
+	prov, _ := New(nil)
+
+	records, err := prov.QueryRecords(context.Background(), RecordQuery{Zone: "internal.demo.com", Service: "svc-database"})
+	if err != nil {
+		t.Fatalf("QueryRecords returned error: %v", err)
+	}
+	if len(records) != 3 {
+		t.Fatalf("expected database A record plus primary and replica CNAMEs, got %+v", records)
+	}
+
+	failing, _ := prov.QueryRecords(context.Background(), RecordQuery{Region: "eu-west-1"})
+	if len(failing) == 0 {
+		t.Fatalf("expected records in eu-west-1")
+	}
+	for _, rec := range failing {
+		if (rec.Zone == "internal.demo.com" || rec.Zone == "api.demo.com") && (rec.Health != "failing" || rec.Metadata["alert_id"] != "al-010") {
+			t.Fatalf("expected eu-west-1 records to reflect the DNS alert, got %+v", rec)
+		}
+	}
+
+	cnames, _ := prov.QueryRecords(context.Background(), RecordQuery{Name: "API.demo", Type: "cname"})
+	for _, rec := range cnames {
+		if rec.Type != "CNAME" || !strings.Contains(rec.Name, "api.demo") {
+			t.Fatalf("name/type filters ignored: %+v", rec)
+		}
+	}
+}
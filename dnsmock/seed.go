@@ -0,0 +1,183 @@
+package dnsmock
+
+import (
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/opsorch/opsorch-mock-adapters/internal/mockutil"
+)
+
+// dnsFailureRegion mirrors the region in the "DNS resolution failures" alert.
+const dnsFailureRegion = "eu-west-1"
+
+// publicServices are reachable under api.demo.com.
+var publicServices = []string{"svc-checkout", "svc-search", "svc-payments", "svc-order", "svc-catalog", "svc-shipping", "svc-realtime"}
+
+func (p *Provider) seedCertificates(now time.Time) []Certificate {
+	day := 24 * time.Hour
+	certs := []Certificate{
+		{
+			// Pairs with the "Certificate expiration warning" alert and the
+			// Certificate Rotation runbook.
+			ID:         "cert-api-demo-com",
+			Domain:     "api.demo.com",
+			SANs:       []string{"*.api.demo.com"},
+			Issuer:     "Let's Encrypt",
+			NotAfter:   now.Add(14 * day),
+			NotBefore:  now.Add(-76 * day),
+			AutoRenew:  false,
+			Service:    "svc-ingress",
+			AttachedTo: []string{"alb-ingress-alb", "alb-api-gateway-alb"},
+			Metadata: map[string]any{
+				"alert_id": "al-009",
+				"runbook":  "https://runbook.demo/runbooks/cert-rotation",
+				"reason":   "ACME renewal disabled after DNS-01 challenge failures",
+			},
+		},
+		{
+			ID:         "cert-auth-demo-com",
+			Domain:     "auth.demo.com",
+			Issuer:     "Let's Encrypt",
+			NotAfter:   now.Add(45 * day),
+			NotBefore:  now.Add(-45 * day),
+			AutoRenew:  true,
+			Service:    "svc-identity",
+			AttachedTo: []string{"alb-identity-alb"},
+		},
+		{
+			ID:         "cert-www-demo-com",
+			Domain:     "www.demo.com",
+			SANs:       []string{"demo.com"},
+			Issuer:     "DigiCert Global G2 TLS RSA SHA256 2020 CA1",
+			NotAfter:   now.Add(212 * day),
+			NotBefore:  now.Add(-185 * day),
+			AutoRenew:  true,
+			Service:    "svc-web",
+			AttachedTo: []string{"cdn-web", "alb-web-alb"},
+		},
+		{
+			ID:         "cert-payments-mtls",
+			Domain:     "payments-mtls.demo.com",
+			Issuer:     "DigiCert Global G2 TLS RSA SHA256 2020 CA1",
+			NotAfter:   now.Add(118 * day),
+			NotBefore:  now.Add(-279 * day),
+			AutoRenew:  false,
+			Service:    "svc-payments",
+			AttachedTo: []string{"alb-payments-alb"},
+			Metadata:   map[string]any{"usage": "client certificate for PSP mutual TLS"},
+		},
+		{
+			ID:         "cert-internal-wildcard",
+			Domain:     "*.internal.demo.com",
+			Issuer:     "Demo Internal CA",
+			NotAfter:   now.Add(301 * day),
+			NotBefore:  now.Add(-64 * day),
+			AutoRenew:  true,
+			Service:    "svc-ingress",
+			AttachedTo: []string{"service-mesh"},
+		},
+		{
+			// Expired but detached; left behind by a decommissioned status page.
+			ID:        "cert-status-legacy",
+			Domain:    "status-legacy.demo.com",
+			Issuer:    "Let's Encrypt",
+			NotAfter:  now.Add(-3 * day),
+			NotBefore: now.Add(-93 * day),
+			AutoRenew: false,
+			Service:   "svc-web",
+		},
+	}
+	for i := range certs {
+		certs[i].Serial = serial(certs[i].ID)
+		certs[i].Team = mockutil.GetTeamForService(certs[i].Service)
+		if certs[i].Metadata == nil {
+			certs[i].Metadata = map[string]any{}
+		}
+		certs[i].Metadata["source"] = p.cfg.Source
+	}
+	return certs
+}
+
+func (p *Provider) seedRecords() []Record {
+	records := []Record{
+		{Zone: "demo.com", Name: "demo.com", Type: "A", TTL: 60, Values: []string{"ALIAS d1q2w3e4r5t6y7.cloudfront.net"}, Service: "svc-web"},
+		{Zone: "demo.com", Name: "www.demo.com", Type: "CNAME", TTL: 300, Values: []string{"d1q2w3e4r5t6y7.cloudfront.net"}, Service: "svc-web"},
+		{Zone: "demo.com", Name: "auth.demo.com", Type: "CNAME", TTL: 300, Values: []string{albHost("svc-identity")}, Service: "svc-identity", Region: mockutil.ServiceRegion("svc-identity")},
+		{Zone: "demo.com", Name: "status-legacy.demo.com", Type: "CNAME", TTL: 3600, Values: []string{"statuspage-legacy.demo-hosting.net"}, Service: "svc-web"},
+		{Zone: "demo.com", Name: "demo.com", Type: "MX", TTL: 3600, Values: []string{"10 mx1.mail.demo.com", "20 mx2.mail.demo.com"}},
+		{Zone: "demo.com", Name: "_acme-challenge.api.demo.com", Type: "TXT", TTL: 60, Values: []string{}, Service: "svc-ingress",
+			Metadata: map[string]any{"note": "challenge record missing; ACME renewal for api.demo.com cannot complete", "certificate_id": "cert-api-demo-com"}},
+		{Zone: "api.demo.com", Name: "api.demo.com", Type: "A", TTL: 60, Values: []string{"ALIAS " + albHost("svc-ingress")}, Service: "svc-ingress", Region: mockutil.ServiceRegion("svc-ingress")},
+	}
+	for _, service := range publicServices {
+		short := strings.TrimPrefix(service, "svc-")
+		records = append(records, Record{
+			Zone: "api.demo.com", Name: short + ".api.demo.com", Type: "CNAME", TTL: 300,
+			Values: []string{albHost(service)}, Service: service, Region: mockutil.ServiceRegion(service),
+		})
+	}
+	for _, service := range mockutil.Services() {
+		records = append(records, Record{
+			Zone: "internal.demo.com", Name: strings.TrimPrefix(service, "svc-") + ".internal.demo.com", Type: "A", TTL: 30,
+			Values: instanceIPs(service), Service: service, Region: mockutil.ServiceRegion(service),
+		})
+	}
+	dbRegion := mockutil.ServiceRegion("svc-database")
+	records = append(records,
+		Record{Zone: "internal.demo.com", Name: "orders-primary.internal.demo.com", Type: "CNAME", TTL: 5,
+			Values: []string{fmt.Sprintf("orders-primary.c9x1abcd.%s.rds.amazonaws.com", dbRegion)}, Service: "svc-database", Region: dbRegion},
+		Record{Zone: "internal.demo.com", Name: "orders-replica.internal.demo.com", Type: "CNAME", TTL: 5,
+			Values: []string{fmt.Sprintf("orders-replica.c9x1abcd.%s.rds.amazonaws.com", dbRegion)}, Service: "svc-database", Region: dbRegion},
+	)
+
+	for i := range records {
+		rec := &records[i]
+		rec.Health = "healthy"
+		if rec.Metadata == nil {
+			rec.Metadata = map[string]any{}
+		}
+		if rec.Region == dnsFailureRegion && (rec.Zone == "internal.demo.com" || rec.Zone == "api.demo.com") {
+			// Matches the "DNS resolution failures spiking" alert.
+			rec.Health = "failing"
+			rec.Metadata["alert_id"] = "al-010"
+			rec.Metadata["runbook"] = "https://runbook.demo/dns-issues"
+		}
+		rec.Metadata["source"] = p.cfg.Source
+	}
+	sort.SliceStable(records, func(i, j int) bool {
+		if records[i].Zone != records[j].Zone {
+			return records[i].Zone < records[j].Zone
+		}
+		if records[i].Name != records[j].Name {
+			return records[i].Name < records[j].Name
+		}
+		return records[i].Type < records[j].Type
+	})
+	return records
+}
+
+// albHost and instanceIPs follow the naming and addressing in cloudresourcemock
+// so records point at resources that provider lists.
+func albHost(service string) string {
+	seed := serviceSeed(service)
+	return fmt.Sprintf("%s-alb-%d.%s.elb.amazonaws.com", strings.TrimPrefix(service, "svc-"), seed%100000, mockutil.ServiceRegion(service))
+}
+
+func instanceIPs(service string) []string {
+	seed := serviceSeed(service)
+	count := 2 + int(seed%3)
+	out := make([]string, 0, count)
+	for i := 1; i <= count; i++ {
+		out = append(out, fmt.Sprintf("10.%d.%d.%d", seed%200, (seed>>8)%250, 10+i))
+	}
+	return out
+}
+
+func serviceSeed(service string) uint32 {
+	h := fnv.New32a()
+	h.Write([]byte(service))
+	return h.Sum32()
+}
@@ -0,0 +1,347 @@
+package incidentmock
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/opsorch/opsorch-core/orcherr"
+	"github.com/opsorch/opsorch-core/schema"
+	"github.com/opsorch/opsorch-mock-adapters/internal/mockutil"
+	"github.com/opsorch/opsorch-mock-adapters/ticketmock"
+)
+
+// Action item categories.
+const (
+	ActionRemediation = "remediation"
+	ActionDetection   = "detection"
+	ActionFollowUp    = "follow-up"
+	ActionPostmortem  = "postmortem"
+)
+
+// ActionItemDeps are the providers ActionItems links tickets from. A plugin
+// process holds only the incident provider, so a nil Tickets is replaced with
+// a fresh mock on first use; callers holding the ticket provider pass it in
+// so existing and created tickets show up there.
+type ActionItemDeps struct {
+	Tickets *ticketmock.Provider
+
+	// private reports that actionItemTickets built Tickets.
+	private bool
+}
+
+// SetActionItemDeps sets the providers ActionItems uses.
+func (p *Provider) SetActionItemDeps(deps ActionItemDeps) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.actionItems = deps
+}
+
+// ActionItemsInput is the payload of incident.actionItems.
+type ActionItemsInput struct {
+	ID string `json:"id"`
+	// CreateTickets files a ticket for every item that has none yet.
+	CreateTickets bool `json:"createTickets,omitempty"`
+}
+
+// ActionItem is a follow-up derived from an incident. IDs are stable across
+// calls, so a ticket filed for an item stays linked to it.
+type ActionItem struct {
+	ID       string `json:"id"`
+	Title    string `json:"title"`
+	Category string `json:"category"`
+	Priority string `json:"priority"`
+	Owner    string `json:"owner,omitempty"`
+	// Source is "timeline" or "metadata"; SourceID is the timeline entry ID
+	// or metadata key, and Evidence the text the item was derived from.
+	Source   string `json:"source"`
+	SourceID string `json:"sourceId"`
+	Evidence string `json:"evidence"`
+	// Ticket is the linked ticket, if any; Done reports whether it is closed.
+	Ticket *ActionItemTicket `json:"ticket,omitempty"`
+	Done   bool              `json:"done"`
+}
+
+// ActionItemTicket is the ticket tracking an action item.
+type ActionItemTicket struct {
+	ID     string `json:"id"`
+	Ref    string `json:"ref"`
+	Status string `json:"status"`
+	URL    string `json:"url,omitempty"`
+}
+
+// ActionItemsResult lists an incident's action items.
+type ActionItemsResult struct {
+	IncidentID string       `json:"incidentId"`
+	Items      []ActionItem `json:"items"`
+	// Created lists the tickets filed by this call.
+	Created []string `json:"created,omitempty"`
+	// Simulated reports that the tickets came from a private ticket mock, so
+	// the ones filed here exist nowhere else; callers replay them from Items.
+	Simulated bool `json:"simulated,omitempty"`
+}
+
+// actionItemRule turns a temporary mitigation or warning sign mentioned on
+// the timeline into the lasting fix it calls for. Title takes the service.
+// Remediation rules only read entries by people, since a mitigation is
+// something a responder did; a monitor's "Incident detected: Deployment
+// Rollback" is not one.
+type actionItemRule struct {
+	key      string
+	cues     []string
+	category string
+	title    string
+}
+
+// actionItemRules fire at most once per incident, on the first entry whose
+// text contains one of their cues.
+var actionItemRules = []actionItemRule{
+	{key: "rollback", cues: []string{"rolled back", "rollback"}, category: ActionRemediation, title: "Fix the regression behind the %s rollback and re-release behind a canary"},
+	{key: "restart", cues: []string{"restart"}, category: ActionRemediation, title: "Find why %s pods needed a restart and fix the underlying leak or hang"},
+	{key: "scale-out", cues: []string{"manually scaled", "manual scale"}, category: ActionRemediation, title: "Tune %s autoscaling so it keeps up with spikes without a manual scale-out"},
+	{key: "circuit-breaker", cues: []string{"circuit breaker"}, category: ActionRemediation, title: "Review circuit breaker thresholds and fallbacks for %s"},
+	{key: "rate-limit", cues: []string{"rate limit"}, category: ActionRemediation, title: "Add backoff and a request budget to %s calls to the rate-limited provider"},
+	{key: "connection-pool", cues: []string{"connections at", "connection pool"}, category: ActionDetection, title: "Right-size the %s connection pool and alert before it saturates"},
+	{key: "error-budget", cues: []string{"error budget"}, category: ActionDetection, title: "Add a faster burn-rate alert on the %s error budget"},
+}
+
+// actionItemCues mark an explicit follow-up written on the timeline, such as
+// "Follow-up: add a canary stage". The rest of the line is the item.
+var actionItemCues = []string{"follow-up:", "follow up:", "action item:", "todo:"}
+
+// ActionItems derives the follow-ups for an incident from its timeline and
+// scenario metadata, without a language model: explicit "Follow-up:" notes,
+// lasting fixes for the mitigations responders applied, the recorded root
+// cause, and a postmortem for sev1 and sev2. Each item links the ticket
+// already filed for it, and with CreateTickets a ticket is filed for each
+// item that has none.
+func (p *Provider) ActionItems(ctx context.Context, in ActionItemsInput) (ActionItemsResult, error) {
+	p.mu.Lock()
+	inc, ok := p.incidentLocked(in.ID)
+	if !ok {
+		p.mu.Unlock()
+		return ActionItemsResult{}, orcherr.New("not_found", "incident not found", nil)
+	}
+	items := extractActionItems(inc, cloneTimeline(p.timeline[in.ID]))
+	p.mu.Unlock()
+
+	tickets, private, err := p.actionItemTickets()
+	if err != nil {
+		return ActionItemsResult{}, err
+	}
+	linked, err := tickets.Query(ctx, schema.TicketQuery{Metadata: map[string]any{"incident_id": inc.ID}})
+	if err != nil {
+		return ActionItemsResult{}, err
+	}
+	linkActionItemTickets(items, linked)
+
+	result := ActionItemsResult{IncidentID: inc.ID, Items: items, Simulated: private}
+	if in.CreateTickets {
+		for i := range result.Items {
+			if result.Items[i].Ticket != nil {
+				continue
+			}
+			tk, err := tickets.Create(ctx, actionItemTicketInput(inc, result.Items[i]))
+			if err != nil {
+				return ActionItemsResult{}, err
+			}
+			setActionItemTicket(&result.Items[i], tk)
+			result.Created = append(result.Created, tk.ID)
+		}
+	}
+	return result, nil
+}
+
+// actionItemTickets returns the configured ticket provider, building a mock
+// when unset, and whether it was built here.
+func (p *Provider) actionItemTickets() (*ticketmock.Provider, bool, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.actionItems.Tickets == nil {
+		prov, err := ticketmock.New(nil)
+		if err != nil {
+			return nil, false, err
+		}
+		p.actionItems = ActionItemDeps{Tickets: prov.(*ticketmock.Provider), private: true}
+	}
+	return p.actionItems.Tickets, p.actionItems.private, nil
+}
+
+// extractActionItems applies the explicit cues and rules to the timeline in
+// order, then adds the metadata items. Titles already produced are skipped.
+func extractActionItems(inc schema.Incident, timeline []schema.TimelineEntry) []ActionItem {
+	service := incidentService(inc)
+	priority := actionItemPriority(inc.Severity)
+	assignee, _ := inc.Fields["oncall_assignee"].(string)
+
+	items := []ActionItem{}
+	seen := map[string]bool{}
+	add := func(item ActionItem) {
+		if seen[strings.ToLower(item.Title)] {
+			return
+		}
+		seen[strings.ToLower(item.Title)] = true
+		item.Priority = priority
+		if item.Owner == "" {
+			item.Owner = assignee
+		}
+		items = append(items, item)
+	}
+
+	fired := map[string]bool{}
+	for _, entry := range timeline {
+		if entry.Kind == TimelineKindStatusChange {
+			continue
+		}
+		owner := ""
+		if actorType, _ := entry.Actor["type"].(string); actorType == mockutil.ActorHuman {
+			owner, _ = entry.Actor["name"].(string)
+		}
+		if title := explicitActionItem(entry.Body); title != "" {
+			add(ActionItem{ID: "ai-" + entry.ID, Title: title, Category: ActionFollowUp, Owner: owner, Source: "timeline", SourceID: entry.ID, Evidence: entry.Body})
+			continue
+		}
+		text := strings.ToLower(entry.Body)
+		for _, rule := range actionItemRules {
+			if fired[rule.key] || !containsAny(text, rule.cues) || rule.category == ActionRemediation && owner == "" {
+				continue
+			}
+			fired[rule.key] = true
+			add(ActionItem{
+				ID:       fmt.Sprintf("ai-%s-%s", inc.ID, rule.key),
+				Title:    fmt.Sprintf(rule.title, service),
+				Category: rule.category,
+				Owner:    owner,
+				Source:   "timeline",
+				SourceID: entry.ID,
+				Evidence: entry.Body,
+			})
+		}
+	}
+
+	if cause := incidentRootCause(inc); cause != "" {
+		add(ActionItem{ID: fmt.Sprintf("ai-%s-root-cause", inc.ID), Title: "Fix the root cause: " + cause, Category: ActionRemediation, Source: "metadata", SourceID: "root_cause", Evidence: cause})
+	}
+	if inc.Severity == "sev1" || inc.Severity == "sev2" {
+		add(ActionItem{ID: fmt.Sprintf("ai-%s-postmortem", inc.ID), Title: fmt.Sprintf("Write the postmortem for %s: %s", inc.ID, inc.Title), Category: ActionPostmortem, Source: "metadata", SourceID: "severity", Evidence: inc.Severity})
+	}
+	return items
+}
+
+// explicitActionItem returns the text after a follow-up cue, or "".
+func explicitActionItem(body string) string {
+	lower := strings.ToLower(body)
+	for _, cue := range actionItemCues {
+		if i := strings.Index(lower, cue); i >= 0 {
+			line, _, _ := strings.Cut(body[i+len(cue):], "\n")
+			return strings.TrimSpace(line)
+		}
+	}
+	return ""
+}
+
+func containsAny(text string, cues []string) bool {
+	for _, cue := range cues {
+		if strings.Contains(text, cue) {
+			return true
+		}
+	}
+	return false
+}
+
+func incidentRootCause(inc schema.Incident) string {
+	for _, m := range []map[string]any{inc.Metadata, inc.Fields} {
+		if cause, ok := m["root_cause"].(string); ok && cause != "" {
+			return cause
+		}
+	}
+	return ""
+}
+
+// actionItemPriority follows the ticket provider's severity-to-priority
+// mapping, so filed tickets and their items agree.
+func actionItemPriority(severity string) string {
+	switch severity {
+	case "sev1":
+		return "P0"
+	case "sev2":
+		return "P1"
+	case "sev3":
+		return "P2"
+	default:
+		return "P3"
+	}
+}
+
+// linkActionItemTickets attaches the incident's tickets to its items: a ticket
+// filed for an item by ID, a postmortem ticket to the postmortem item, and the
+// incident's remaining tracking ticket to the root-cause item.
+func linkActionItemTickets(items []ActionItem, tickets []schema.Ticket) {
+	sort.Slice(tickets, func(i, j int) bool { return tickets[i].ID < tickets[j].ID })
+	used := map[string]bool{}
+	for i := range items {
+		for _, tk := range tickets {
+			if id, _ := tk.Fields["action_item_id"].(string); id == items[i].ID {
+				setActionItemTicket(&items[i], tk)
+				used[tk.ID] = true
+				break
+			}
+		}
+	}
+	for i := range items {
+		if items[i].Ticket != nil {
+			continue
+		}
+		for _, tk := range tickets {
+			if used[tk.ID] {
+				continue
+			}
+			if _, filed := tk.Fields["action_item_id"].(string); filed {
+				continue
+			}
+			postmortem := tk.Fields["template"] == "postmortem"
+			if items[i].Category == ActionPostmortem && postmortem || items[i].SourceID == "root_cause" && !postmortem {
+				setActionItemTicket(&items[i], tk)
+				used[tk.ID] = true
+				break
+			}
+		}
+	}
+}
+
+func setActionItemTicket(item *ActionItem, tk schema.Ticket) {
+	item.Ticket = &ActionItemTicket{ID: tk.ID, Ref: mockutil.FormatRef(mockutil.RefTicket, tk.ID), Status: tk.Status, URL: tk.URL}
+	item.Done = tk.Status == "done" || tk.Status == "closed" || tk.Status == "resolved"
+}
+
+// actionItemTicketInput describes the ticket filed for an item, labelled as
+// the matching ticket template would label it.
+func actionItemTicketInput(inc schema.Incident, item ActionItem) schema.CreateTicketInput {
+	labels := []string{"action-item", "incident-follow-up"}
+	if item.Category == ActionPostmortem {
+		labels = []string{"postmortem", "incident-follow-up"}
+	}
+	service := incidentService(inc)
+	fields := map[string]any{
+		"service":        service,
+		"team":           mockutil.GetTeamForService(service),
+		"priority":       item.Priority,
+		"labels":         labels,
+		"incident_id":    inc.ID,
+		"action_item_id": item.ID,
+		"category":       item.Category,
+	}
+	if item.Owner != "" {
+		fields["owner"] = item.Owner
+	}
+	return schema.CreateTicketInput{
+		Title:       item.Title,
+		Description: fmt.Sprintf("Follow-up from %s (%s). Derived from: %s", inc.ID, inc.Title, item.Evidence),
+		Fields:      fields,
+		Metadata: map[string]any{
+			"incident_id":      inc.ID,
+			"relatedIncidents": []string{inc.ID},
+		},
+	}
+}
@@ -0,0 +1,45 @@
+package incidentmock
+
+import (
+	"fmt"
+	"hash/fnv"
+
+	"github.com/opsorch/opsorch-core/schema"
+)
+
+// Conference-bridge and shared-doc links are stored under these Metadata keys
+// on every active incident, seeded or declared.
+const (
+	bridgeURLKey    = "bridgeUrl"
+	bridgeDialInKey = "bridgeDialIn"
+	docURLKey       = "docUrl"
+)
+
+// bridgeDialInNumber is the demo dial-in line; the PIN is per incident.
+const bridgeDialInNumber = "+1 555 0100"
+
+// attachBridge sets simulated bridge and working-doc links on an incident.
+// Links are derived from the incident ID, so they are stable across restarts,
+// and links already set, such as by a client, are kept.
+func attachBridge(inc *schema.Incident) {
+	if inc.Metadata == nil {
+		inc.Metadata = map[string]any{}
+	}
+	links := bridgeLinks(inc.ID)
+	for key, val := range links {
+		if existing, _ := inc.Metadata[key].(string); existing == "" {
+			inc.Metadata[key] = val
+		}
+	}
+}
+
+// bridgeLinks builds the links for an incident ID.
+func bridgeLinks(id string) map[string]string {
+	h := fnv.New32a()
+	h.Write([]byte(id))
+	return map[string]string{
+		bridgeURLKey:    fmt.Sprintf("https://meet.demo.com/j/%s", id),
+		bridgeDialInKey: fmt.Sprintf("%s,,%06d#", bridgeDialInNumber, h.Sum32()%1000000),
+		docURLKey:       fmt.Sprintf("https://docs.demo.com/incidents/%s/working-doc", id),
+	}
+}
@@ -0,0 +1,275 @@
+package incidentmock
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/opsorch/opsorch-core/messaging"
+	"github.com/opsorch/opsorch-core/orcherr"
+	"github.com/opsorch/opsorch-core/schema"
+	"github.com/opsorch/opsorch-mock-adapters/internal/mockutil"
+	"github.com/opsorch/opsorch-mock-adapters/messagingmock"
+	"github.com/opsorch/opsorch-mock-adapters/orchestrationmock"
+	"github.com/opsorch/opsorch-mock-adapters/teammock"
+)
+
+// fallbackPlanID is the generic response playbook started when no plan is
+// tagged with the incident's service.
+const fallbackPlanID = "plan-playbook-003"
+
+// DeclareDeps are the providers Declare wires together. A plugin process holds
+// only the incident provider, so any left nil are replaced with fresh mocks on
+// first use; callers holding the other providers pass them in so the channel,
+// page, and run show up there.
+type DeclareDeps struct {
+	Teams         *teammock.Provider
+	Messaging     messaging.Provider
+	Orchestration *orchestrationmock.Provider
+
+	// private names the providers declareDeps built, in declare order.
+	private []string
+}
+
+// SetDeclareDeps sets the providers Declare uses.
+func (p *Provider) SetDeclareDeps(deps DeclareDeps) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.declare = deps
+}
+
+// DeclareInput describes an incident to declare.
+type DeclareInput struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Severity    string `json:"severity,omitempty"`
+	Service     string `json:"service"`
+	// Commander joins as incident commander when set.
+	Commander string `json:"commander,omitempty"`
+	// PlanID overrides the runbook chosen for the service.
+	PlanID string         `json:"planId,omitempty"`
+	Fields map[string]any `json:"fields,omitempty"`
+}
+
+// Page records who was paged for a declared incident and how.
+type Page struct {
+	TeamID    string               `json:"teamId"`
+	Responder string               `json:"responder"`
+	Source    string               `json:"source"`
+	Message   schema.MessageResult `json:"message"`
+}
+
+// DeclareResult is everything Declare set up.
+type DeclareResult struct {
+	Incident     schema.Incident          `json:"incident"`
+	Channel      string                   `json:"channel"`
+	Announcement schema.MessageResult     `json:"announcement"`
+	Page         Page                     `json:"page"`
+	Run          *schema.OrchestrationRun `json:"run,omitempty"`
+	Timeline     []schema.TimelineEntry   `json:"timeline"`
+	// Simulated names the providers, of "teams", "messaging", and
+	// "orchestration", that were private mocks, so what this result reports
+	// from them exists nowhere else; callers replay it from the result.
+	Simulated []string `json:"simulated,omitempty"`
+}
+
+// Declare runs the declare-incident flow the host would otherwise orchestrate:
+// it creates the incident, opens an #inc-<id> channel and announces it there,
+// pages the owning team's on-call responder, starts the runbook for the
+// service, attaches a conference bridge and working doc, and records each step
+// on the timeline. There is no separate paging provider: the page is resolved
+// through teammock's on-call rotation and sent to the responder by messaging,
+// or to the team's channel when the team has no rotation.
+func (p *Provider) Declare(ctx context.Context, in DeclareInput) (DeclareResult, error) {
+	if strings.TrimSpace(in.Title) == "" {
+		return DeclareResult{}, orcherr.New("bad_request", "title is required", nil)
+	}
+	if in.Service == "" {
+		return DeclareResult{}, orcherr.New("bad_request", "service is required", nil)
+	}
+	deps, err := p.declareDeps()
+	if err != nil {
+		return DeclareResult{}, err
+	}
+	teamID := mockutil.GetTeamForService(in.Service)
+	// Teams without a rotation are paged in their channel instead.
+	onCall, onCallErr := deps.Teams.OnCall(ctx, teamID)
+	planID := in.PlanID
+	if planID == "" {
+		planID = planForService(ctx, deps.Orchestration, in.Service)
+	} else if _, err := deps.Orchestration.GetPlan(ctx, planID); err != nil {
+		return DeclareResult{}, err
+	}
+
+	inc, err := p.Create(ctx, schema.CreateIncidentInput{
+		Title:       in.Title,
+		Description: in.Description,
+		Severity:    in.Severity,
+		Service:     in.Service,
+		Fields:      in.Fields,
+	})
+	if err != nil {
+		return DeclareResult{}, err
+	}
+	// Drop the incident when a later step fails, so retries do not leave
+	// half-declared incidents behind.
+	fail := func(err error) (DeclareResult, error) {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		delete(p.incidents, inc.ID)
+		delete(p.timeline, inc.ID)
+		return DeclareResult{}, err
+	}
+	result := DeclareResult{Channel: "#inc-" + strings.TrimPrefix(inc.ID, "inc-"), Simulated: deps.private}
+	links := bridgeLinks(inc.ID)
+
+	result.Announcement, err = deps.Messaging.Send(ctx, schema.Message{
+		Channel:  result.Channel,
+		Body:     fmt.Sprintf(":rotating_light: %s declared (%s, %s): %s\nBridge: %s\nWorking doc: %s", inc.ID, inc.Severity, inc.Service, inc.Title, links[bridgeURLKey], links[docURLKey]),
+		Metadata: map[string]any{"incidentId": inc.ID, bridgeURLKey: links[bridgeURLKey], docURLKey: links[docURLKey]},
+	})
+	if err != nil {
+		return fail(err)
+	}
+
+	page := Page{TeamID: teamID, Responder: onCall.Responder.Name, Source: onCall.Source}
+	pageTo := onCall.Responder.Email
+	if onCallErr != nil || pageTo == "" {
+		page.Responder = "@" + teamID
+		page.Source = "team-channel"
+		pageTo = mockutil.GetChannelForTeam(teamID)
+	}
+	page.Message, err = deps.Messaging.Send(ctx, schema.Message{
+		Channel:  pageTo,
+		Body:     fmt.Sprintf("PAGE %s %s: %s. Join %s", inc.Severity, inc.ID, inc.Title, result.Channel),
+		Metadata: map[string]any{"incidentId": inc.ID, "page": true, "teamId": teamID},
+	})
+	if err != nil {
+		return fail(err)
+	}
+	result.Page = page
+
+	if planID != "" {
+		run, err := deps.Orchestration.StartRunForIncident(ctx, planID, inc.ID)
+		if err != nil {
+			return fail(err)
+		}
+		result.Run = run
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	now := p.now()
+	stored := p.incidents[inc.ID]
+	stored.Metadata["channel"] = result.Channel
+	stored.Metadata["pagedResponder"] = page.Responder
+	attachBridge(&stored)
+	// A run on a private orchestration mock cannot be resolved anywhere else,
+	// so only the result carries it.
+	if result.Run != nil && !containsValue(deps.private, "orchestration") {
+		stored.Metadata["runId"] = result.Run.ID
+		stored.Metadata["planId"] = result.Run.PlanID
+		mockutil.LinkRefs(stored.Metadata, stored.Fields)
+	}
+	p.incidents[inc.ID] = stored
+
+	// Entries for steps taken on a private provider say so.
+	stepMetadata := func(dep string, md map[string]any) map[string]any {
+		if containsValue(deps.private, dep) {
+			md["simulated"] = true
+		}
+		return md
+	}
+	system := mockutil.ActorRef("incident-declare")
+	p.appendTimelineLocked(inc.ID, schema.TimelineAppendInput{At: now, Kind: "declared", Body: fmt.Sprintf("Incident declared for %s", inc.Service), Actor: system})
+	p.appendTimelineLocked(inc.ID, schema.TimelineAppendInput{At: now, Kind: "channel_opened", Body: "Opened " + result.Channel, Actor: system, Metadata: stepMetadata("messaging", map[string]any{"messageId": result.Announcement.ID})})
+	p.appendTimelineLocked(inc.ID, schema.TimelineAppendInput{At: now, Kind: "bridge_opened", Body: fmt.Sprintf("Opened bridge %s and working doc %s", stored.Metadata[bridgeURLKey], stored.Metadata[docURLKey]), Actor: system, Metadata: map[string]any{bridgeURLKey: stored.Metadata[bridgeURLKey], bridgeDialInKey: stored.Metadata[bridgeDialInKey], docURLKey: stored.Metadata[docURLKey]}})
+	p.appendTimelineLocked(inc.ID, schema.TimelineAppendInput{At: now, Kind: "paged", Body: fmt.Sprintf("Paged %s (%s on-call)", page.Responder, teamID), Actor: system, Metadata: stepMetadata("messaging", map[string]any{"messageId": page.Message.ID})})
+	if result.Run != nil {
+		p.appendTimelineLocked(inc.ID, schema.TimelineAppendInput{At: now, Kind: "runbook_started", Body: fmt.Sprintf("Started %s (%s)", result.Run.PlanID, result.Run.ID), Actor: system, Metadata: stepMetadata("orchestration", map[string]any{"runId": result.Run.ID})})
+	}
+	if in.Commander != "" {
+		p.joinLocked(inc.ID, in.Commander, commanderRole, now)
+	}
+	if onCallErr == nil {
+		p.joinLocked(inc.ID, page.Responder, "responder", now)
+	}
+
+	result.Incident = cloneIncident(stored)
+	p.applySLALocked(&result.Incident, now)
+	p.presentSeverity(&result.Incident)
+	result.Timeline = append([]schema.TimelineEntry(nil), p.timeline[inc.ID]...)
+	return result, nil
+}
+
+// declareDeps returns the configured providers, building mocks for any unset.
+func (p *Provider) declareDeps() (DeclareDeps, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.declare.Teams == nil {
+		prov, err := teammock.New(nil)
+		if err != nil {
+			return DeclareDeps{}, err
+		}
+		p.declare.Teams = prov.(*teammock.Provider)
+		p.declare.private = append(p.declare.private, "teams")
+	}
+	if p.declare.Messaging == nil {
+		prov, err := messagingmock.New(nil)
+		if err != nil {
+			return DeclareDeps{}, err
+		}
+		p.declare.Messaging = prov
+		p.declare.private = append(p.declare.private, "messaging")
+	}
+	if p.declare.Orchestration == nil {
+		prov, err := orchestrationmock.New(nil)
+		if err != nil {
+			return DeclareDeps{}, err
+		}
+		p.declare.Orchestration = prov.(*orchestrationmock.Provider)
+		p.declare.private = append(p.declare.private, "orchestration")
+	}
+	deps := p.declare
+	deps.private = append([]string(nil), deps.private...)
+	return deps, nil
+}
+
+// planForService picks the playbook tagged with the service, then any plan
+// tagged with it, then the generic service degradation playbook.
+func planForService(ctx context.Context, orch *orchestrationmock.Provider, service string) string {
+	plans, err := orch.QueryPlans(ctx, schema.OrchestrationPlanQuery{Tags: map[string]string{"service": service}})
+	if err != nil || len(plans) == 0 {
+		return fallbackPlanID
+	}
+	for _, plan := range plans {
+		if plan.Tags["type"] == "playbook" {
+			return plan.ID
+		}
+	}
+	return plans[0].ID
+}
+
+// OpenLinked creates an incident on behalf of another provider, such as a
+// failed deployment, and notes where it came from on the timeline. It is the
+// mockutil.IncidentOpener hosts wire into those providers.
+func (p *Provider) OpenLinked(ctx context.Context, in mockutil.LinkedIncident) (string, error) {
+	inc, err := p.Create(ctx, schema.CreateIncidentInput{
+		Title:       in.Title,
+		Description: in.Description,
+		Status:      "open",
+		Severity:    in.Severity,
+		Service:     in.Service,
+		Fields:      in.Fields,
+		Metadata:    in.Metadata,
+	})
+	if err != nil {
+		return "", err
+	}
+	if in.Note != "" {
+		if err := p.AppendTimeline(ctx, inc.ID, schema.TimelineAppendInput{At: inc.CreatedAt, Kind: "note", Body: in.Note, Actor: in.Actor}); err != nil {
+			return "", err
+		}
+	}
+	return inc.ID, nil
+}
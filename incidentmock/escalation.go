@@ -0,0 +1,136 @@
+package incidentmock
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/opsorch/opsorch-core/schema"
+	"github.com/opsorch/opsorch-mock-adapters/internal/mockutil"
+)
+
+// EscalationRule raises an incident's severity when it stays unacknowledged
+// at From severity for After.
+type EscalationRule struct {
+	Name  string
+	From  string
+	To    string
+	After time.Duration
+}
+
+// defaultEscalationRules walk an ignored sev3 up to sev1.
+var defaultEscalationRules = []EscalationRule{
+	{Name: "sev3-unacked-1h", From: "sev3", To: "sev2", After: time.Hour},
+	{Name: "sev2-unacked-30m", From: "sev2", To: "sev1", After: 30 * time.Minute},
+}
+
+// escalationActor is recorded on timeline entries written by escalation rules.
+var escalationActor = mockutil.ActorRef("escalation-policy")
+
+// escalateLocked applies escalation rules to every unacknowledged incident as of
+// now. Each escalation is stamped at the moment its rule fired, so advancing the
+// clock past several thresholds records each step. Callers must hold p.mu.
+func (p *Provider) escalateLocked(now time.Time) {
+	if len(p.cfg.EscalationRules) == 0 || !p.cfg.Features.Lifecycle {
+		return
+	}
+	for id, inc := range p.incidents {
+		changed := false
+		for {
+			if _, acked := p.acknowledgedAtLocked(inc); acked || resolvedStatuses[inc.Status] {
+				break
+			}
+			rule, ok := p.escalationRuleFor(inc.Severity)
+			if !ok {
+				break
+			}
+			since := inc.CreatedAt
+			if v, ok := inc.Fields["escalatedAt"].(string); ok {
+				if t, err := time.Parse(time.RFC3339, v); err == nil {
+					since = t
+				}
+			}
+			firedAt := since.Add(rule.After)
+			if firedAt.After(now) {
+				break
+			}
+			p.applyEscalationLocked(&inc, rule, firedAt)
+			changed = true
+		}
+		if changed {
+			p.incidents[id] = inc
+		}
+	}
+}
+
+func (p *Provider) applyEscalationLocked(inc *schema.Incident, rule EscalationRule, at time.Time) {
+	if inc.Fields == nil {
+		inc.Fields = map[string]any{}
+	}
+	level := escalationLevel(inc.Fields["escalation_level"]) + 1
+	inc.Severity = rule.To
+	inc.Fields["escalation_level"] = level
+	inc.Fields["escalatedAt"] = at.Format(time.RFC3339)
+	if at.After(inc.UpdatedAt) {
+		inc.UpdatedAt = at
+	}
+	from, to := p.cfg.Severity.label(rule.From), p.cfg.Severity.label(rule.To)
+	p.appendTimelineLocked(inc.ID, schema.TimelineAppendInput{
+		At:    at,
+		Kind:  "escalation",
+		Body:  fmt.Sprintf("Severity escalated from %s to %s after %s unacknowledged", from, to, rule.After),
+		Actor: escalationActor,
+		Metadata: map[string]any{
+			"rule":             rule.Name,
+			"from":             from,
+			"to":               to,
+			"escalation_level": level,
+		},
+	})
+}
+
+func (p *Provider) escalationRuleFor(severity string) (EscalationRule, bool) {
+	for _, rule := range p.cfg.EscalationRules {
+		if rule.From == severity {
+			return rule, true
+		}
+	}
+	return EscalationRule{}, false
+}
+
+// escalationLevel reads escalation_level whether it was seeded as an int or
+// arrived as a JSON number.
+func escalationLevel(v any) int {
+	switch n := v.(type) {
+	case int:
+		return n
+	case float64:
+		return int(n)
+	default:
+		return 0
+	}
+}
+
+// parseEscalationRules reads rules like {"from": "sev2", "to": "sev1", "after": "30m"}.
+// Entries missing a severity or with an unparseable duration are skipped.
+func parseEscalationRules(raw []any) []EscalationRule {
+	out := make([]EscalationRule, 0, len(raw))
+	for _, item := range raw {
+		m, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+		from, _ := m["from"].(string)
+		to, _ := m["to"].(string)
+		after, _ := m["after"].(string)
+		d, err := time.ParseDuration(after)
+		if from == "" || to == "" || err != nil || d <= 0 {
+			continue
+		}
+		name, _ := m["name"].(string)
+		if name == "" {
+			name = fmt.Sprintf("%s-unacked-%s", from, after)
+		}
+		out = append(out, EscalationRule{Name: name, From: from, To: to, After: d})
+	}
+	return out
+}
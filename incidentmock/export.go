@@ -0,0 +1,293 @@
+package incidentmock
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"html/template"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/opsorch/opsorch-core/orcherr"
+	"github.com/opsorch/opsorch-core/schema"
+)
+
+// Supported incident export formats.
+const (
+	ExportFormatMarkdown = "markdown"
+	ExportFormatHTML     = "html"
+)
+
+// IncidentReport is a rendered, shareable incident summary.
+type IncidentReport struct {
+	IncidentID  string    `json:"incidentId"`
+	Format      string    `json:"format"`
+	ContentType string    `json:"contentType"`
+	Content     string    `json:"content"`
+	GeneratedAt time.Time `json:"generatedAt"`
+}
+
+// reportLink is a named link rendered in the metrics snapshot section.
+type reportLink struct {
+	Name string
+	URL  string
+}
+
+// reportData is the format-independent view rendered by both exporters.
+type reportData struct {
+	Incident     schema.Incident
+	Duration     string
+	Team         string
+	Environment  string
+	Participants []string
+	Timeline     []schema.TimelineEntry
+	MetricLinks  []reportLink
+	GeneratedAt  time.Time
+}
+
+// Export renders an incident as a Markdown or HTML report with summary,
+// timeline, metric snapshot links, and participants.
+func (p *Provider) Export(ctx context.Context, id string, format string) (IncidentReport, error) {
+	format = strings.ToLower(strings.TrimSpace(format))
+	if format == "" || format == "md" {
+		format = ExportFormatMarkdown
+	}
+	if format != ExportFormatMarkdown && format != ExportFormatHTML {
+		return IncidentReport{}, orcherr.New("bad_request", fmt.Sprintf("unsupported export format %q", format), nil)
+	}
+
+	p.mu.Lock()
+	now := p.now()
+	p.escalateLocked(now)
+	inc, ok := p.incidentLocked(id)
+	if !ok {
+		p.mu.Unlock()
+		return IncidentReport{}, orcherr.New("not_found", "incident not found", nil)
+	}
+	cloned := cloneIncident(inc)
+	p.applySLALocked(&cloned, now)
+	p.presentSeverity(&cloned)
+	timeline := cloneTimeline(p.timeline[id])
+	presence := make([]Participant, 0, len(p.participants[id]))
+	for _, pt := range p.participants[id] {
+		presence = append(presence, cloneParticipant(pt))
+	}
+	p.mu.Unlock()
+
+	data := buildReportData(cloned, timeline, presence, now)
+	report := IncidentReport{IncidentID: id, Format: format, GeneratedAt: now}
+	switch format {
+	case ExportFormatHTML:
+		content, err := renderHTMLReport(data)
+		if err != nil {
+			return IncidentReport{}, err
+		}
+		report.ContentType = "text/html"
+		report.Content = content
+	default:
+		report.ContentType = "text/markdown"
+		report.Content = renderMarkdownReport(data)
+	}
+	return report, nil
+}
+
+func buildReportData(inc schema.Incident, timeline []schema.TimelineEntry, presence []Participant, now time.Time) reportData {
+	sort.SliceStable(timeline, func(i, j int) bool { return timeline[i].At.Before(timeline[j].At) })
+
+	team, _ := inc.Fields["team"].(string)
+	environment, _ := inc.Fields["environment"].(string)
+
+	seen := map[string]bool{}
+	participants := make([]string, 0)
+	addParticipant := func(name string) {
+		if name != "" && !seen[name] {
+			seen[name] = true
+			participants = append(participants, name)
+		}
+	}
+	if commander, ok := inc.Fields["commander"].(string); ok {
+		addParticipant(commander)
+	}
+	for _, pt := range presence {
+		addParticipant(pt.Name)
+	}
+	for _, entry := range timeline {
+		if actorType, _ := entry.Actor["type"].(string); actorType == "user" {
+			name, _ := entry.Actor["name"].(string)
+			addParticipant(name)
+		}
+	}
+
+	links := make([]reportLink, 0)
+	if inc.Service != "" {
+		for _, metricName := range []string{"http_request_duration_seconds", "http_requests_total", "http_errors_total"} {
+			links = append(links, reportLink{
+				Name: metricName,
+				URL:  fmt.Sprintf("https://grafana.demo.com/explore?query=%s&service=%s", metricName, inc.Service),
+			})
+		}
+	}
+	if dashboard, ok := inc.Metadata["linkedDashboard"].(string); ok {
+		links = append(links, reportLink{Name: dashboard, URL: fmt.Sprintf("https://grafana.demo.com/d/%s", dashboard)})
+	}
+	if runbook, ok := inc.Metadata["runbook"].(string); ok {
+		links = append(links, reportLink{Name: "runbook", URL: runbook})
+	}
+
+	end := now
+	if resolvedStatuses[inc.Status] {
+		end = inc.UpdatedAt
+	}
+
+	return reportData{
+		Incident:     inc,
+		Duration:     end.Sub(inc.CreatedAt).Round(time.Minute).String(),
+		Team:         team,
+		Environment:  environment,
+		Participants: participants,
+		Timeline:     timeline,
+		MetricLinks:  links,
+		GeneratedAt:  now,
+	}
+}
+
+func renderMarkdownReport(data reportData) string {
+	inc := data.Incident
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# %s: %s\n\n", inc.ID, markdownText(inc.Title))
+	b.WriteString("## Summary\n\n")
+	fmt.Fprintf(&b, "| Field | Value |\n|-------|-------|\n")
+	fmt.Fprintf(&b, "| Status | %s |\n", markdownText(inc.Status))
+	fmt.Fprintf(&b, "| Severity | %s |\n", markdownText(inc.Severity))
+	fmt.Fprintf(&b, "| Service | %s |\n", markdownText(inc.Service))
+	if data.Team != "" {
+		fmt.Fprintf(&b, "| Team | %s |\n", markdownText(data.Team))
+	}
+	if data.Environment != "" {
+		fmt.Fprintf(&b, "| Environment | %s |\n", markdownText(data.Environment))
+	}
+	fmt.Fprintf(&b, "| Started | %s |\n", inc.CreatedAt.Format(time.RFC3339))
+	fmt.Fprintf(&b, "| Duration | %s |\n", data.Duration)
+	if inc.URL != "" {
+		fmt.Fprintf(&b, "| Link | %s |\n", markdownText(inc.URL))
+	}
+	if inc.Description != "" {
+		fmt.Fprintf(&b, "\n%s\n", inc.Description)
+	}
+
+	b.WriteString("\n## Timeline\n\n")
+	if len(data.Timeline) == 0 {
+		b.WriteString("_No timeline entries._\n")
+	}
+	for _, entry := range data.Timeline {
+		actor, _ := entry.Actor["name"].(string)
+		if actor == "" {
+			actor = "unknown"
+		}
+		fmt.Fprintf(&b, "- **%s** [%s] %s — %s\n", entry.At.Format(reportTimeLayout), entry.Kind, markdownText(entry.Body), markdownText(actor))
+	}
+
+	b.WriteString("\n## Metrics Snapshot\n\n")
+	if len(data.MetricLinks) == 0 {
+		b.WriteString("_No metric links available._\n")
+	}
+	for _, link := range data.MetricLinks {
+		fmt.Fprintf(&b, "- [%s](%s)\n", link.Name, link.URL)
+	}
+
+	b.WriteString("\n## Participants\n\n")
+	if len(data.Participants) == 0 {
+		b.WriteString("_No participants recorded._\n")
+	}
+	for _, name := range data.Participants {
+		fmt.Fprintf(&b, "- %s\n", name)
+	}
+
+	fmt.Fprintf(&b, "\n_Generated %s_\n", data.GeneratedAt.Format(time.RFC3339))
+	return b.String()
+}
+
+// reportTimeLayout stamps timeline entries with their date, since incidents
+// routinely run past midnight.
+const reportTimeLayout = "2006-01-02 15:04 MST"
+
+// markdownCellEscaper keeps free text on one line and inside its table cell.
+var markdownCellEscaper = strings.NewReplacer("|", `\|`, "\r\n", "<br>", "\n", "<br>", "\r", "<br>")
+
+// markdownText escapes s for a Markdown table cell, heading, or list item.
+func markdownText(s string) string {
+	return markdownCellEscaper.Replace(s)
+}
+
+var htmlReportTemplate = template.Must(template.New("incident").Funcs(template.FuncMap{
+	"rfc3339": func(t time.Time) string { return t.Format(time.RFC3339) },
+	"clock":   func(t time.Time) string { return t.Format(reportTimeLayout) },
+	"actor": func(actor map[string]any) string {
+		if name, ok := actor["name"].(string); ok && name != "" {
+			return name
+		}
+		return "unknown"
+	},
+}).Parse(`<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>{{.Incident.ID}}: {{.Incident.Title}}</title></head>
+<body>
+<h1>{{.Incident.ID}}: {{.Incident.Title}}</h1>
+<h2>Summary</h2>
+<table>
+<tr><th>Status</th><td>{{.Incident.Status}}</td></tr>
+<tr><th>Severity</th><td>{{.Incident.Severity}}</td></tr>
+<tr><th>Service</th><td>{{.Incident.Service}}</td></tr>
+{{- if .Team}}
+<tr><th>Team</th><td>{{.Team}}</td></tr>
+{{- end}}
+{{- if .Environment}}
+<tr><th>Environment</th><td>{{.Environment}}</td></tr>
+{{- end}}
+<tr><th>Started</th><td>{{rfc3339 .Incident.CreatedAt}}</td></tr>
+<tr><th>Duration</th><td>{{.Duration}}</td></tr>
+{{- if .Incident.URL}}
+<tr><th>Link</th><td><a href="{{.Incident.URL}}">{{.Incident.URL}}</a></td></tr>
+{{- end}}
+</table>
+{{- if .Incident.Description}}
+<p>{{.Incident.Description}}</p>
+{{- end}}
+<h2>Timeline</h2>
+<ul>
+{{- range .Timeline}}
+<li><strong>{{clock .At}}</strong> [{{.Kind}}] {{.Body}} &mdash; {{actor .Actor}}</li>
+{{- else}}
+<li><em>No timeline entries.</em></li>
+{{- end}}
+</ul>
+<h2>Metrics Snapshot</h2>
+<ul>
+{{- range .MetricLinks}}
+<li><a href="{{.URL}}">{{.Name}}</a></li>
+{{- else}}
+<li><em>No metric links available.</em></li>
+{{- end}}
+</ul>
+<h2>Participants</h2>
+<ul>
+{{- range .Participants}}
+<li>{{.}}</li>
+{{- else}}
+<li><em>No participants recorded.</em></li>
+{{- end}}
+</ul>
+<p><em>Generated {{rfc3339 .GeneratedAt}}</em></p>
+</body>
+</html>
+`))
+
+func renderHTMLReport(data reportData) (string, error) {
+	var buf bytes.Buffer
+	if err := htmlReportTemplate.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,163 @@
+package incidentmock
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/opsorch/opsorch-core/schema"
+	"github.com/opsorch/opsorch-mock-adapters/internal/mockutil"
+)
+
+// historyWindow is how far back the seeded corpus of resolved incidents reaches.
+const historyWindow = 90 * 24 * time.Hour
+
+// historicalSeed describes one resolved incident from the provider's past.
+// family names the scenario it resembles, matching the scenario_id of the
+// live scenario incidents, so similar-incident search can link them.
+type historicalSeed struct {
+	title       string
+	description string
+	service     string
+	team        string
+	severity    string
+	family      string
+	daysAgo     int
+	hour        int
+	duration    time.Duration
+	rootCause   string
+	resolution  string
+	responder   string
+}
+
+var historicalSeeds = []historicalSeed{
+	{"Checkout error budget burn after traffic surge", "Checkout 5xx burned 40% of the monthly error budget in two hours during a flash sale", "svc-checkout", "team-velocity", "sev1", "slo-exhaustion", 6, 14, 2*time.Hour + 10*time.Minute, "Flash sale traffic exceeded provisioned checkout capacity", "Raised HPA max replicas and added a pre-sale capacity checklist", "alex"},
+	{"Checkout latency SLO breach in EU", "Checkout p95 latency above 1.5s for EU customers; SLO burn rate 14x", "svc-checkout", "team-velocity", "sev2", "slo-exhaustion", 23, 10, 95 * time.Minute, "Cold cache after region failover inflated checkout latency", "Warmed caches before shifting traffic back", "casey"},
+	{"Checkout timeouts during marketing campaign", "Checkout requests timing out under campaign load; error budget nearly exhausted", "svc-checkout", "team-velocity", "sev1", "slo-exhaustion", 61, 16, 3 * time.Hour, "Campaign launched without notice to the checkout team", "Scaled checkout and added campaign calendar to capacity reviews", "alex"},
+	{"Database connection pool exhausted", "Order service connection pool exhausted, cascading failures into checkout and payments", "svc-database", "team-data", "sev1", "cascading-failure", 12, 9, 80 * time.Minute, "Connection leak in a new retry path held connections open", "Fixed the leak and capped the pool per pod", "morgan"},
+	{"Cascading failures from slow database replica", "Read replica lag caused request pile-ups that cascaded through catalog and search", "svc-database", "team-data", "sev2", "cascading-failure", 47, 11, 2 * time.Hour, "Replica lag after a long-running analytics query", "Moved analytics reads to a dedicated replica", "morgan"},
+	{"Payments errors after deployment", "Payment service 5xx jumped to 8% right after deploying v2.7.0; rolled back", "svc-payments", "team-revenue", "sev1", "deployment-rollback", 9, 15, 45 * time.Minute, "Incompatible serializer change in the payments client", "Rolled back and added contract tests for the client", "sam"},
+	{"Search relevance regression after release", "Search release 4.2 returned empty results for multi-word queries; rolled back", "svc-search", "team-aurora", "sev2", "deployment-rollback", 33, 13, 70 * time.Minute, "Analyzer config change dropped the shingle filter", "Rolled back and added relevance smoke tests to the pipeline", "lena"},
+	{"Checkout deploy rolled back after latency spike", "Checkout v3.1.0 doubled p99 latency; automatic rollback triggered", "svc-checkout", "team-velocity", "sev2", "deployment-rollback", 74, 10, 35 * time.Minute, "N+1 query introduced in the cart summary endpoint", "Rolled back and fixed the query before redeploying", "casey"},
+	{"Stripe API rate limiting payments", "Stripe returned 429s for 20 minutes; checkout payments failed intermittently", "svc-payments", "team-revenue", "sev1", "external-dependency-failure", 18, 17, 55 * time.Minute, "Retry storm from payments exceeded the Stripe rate limit", "Added jittered backoff and a circuit breaker for Stripe calls", "fern"},
+	{"Shipping carrier API outage", "Fast-ship carrier API returned 503 for label creation; shipments queued", "svc-shipping", "team-hawkeye", "sev3", "external-dependency-failure", 52, 8, 4 * time.Hour, "Carrier-side outage", "Queued labels and switched to the secondary carrier", "alexis"},
+	{"Search latency during traffic spike", "Search p95 latency tripled while autoscaling lagged behind a traffic spike", "svc-search", "team-aurora", "sev2", "autoscaling-lag", 15, 19, 50 * time.Minute, "HPA scaled on CPU while search was memory bound", "Scaled on request rate and raised the minimum replicas", "lena"},
+	{"Web frontend slow during product launch", "Web frontend pods saturated during a product launch before autoscaling caught up", "svc-web", "team-velocity", "sev3", "autoscaling-lag", 40, 12, 40 * time.Minute, "Node pool hit its max size and new pods stayed pending", "Raised node pool limits and added launch-day pre-scaling", "casey"},
+	{"Recommendation timeouts tripping circuit breakers", "Recommendation inference timeouts opened circuit breakers in web and checkout", "svc-recommendation", "team-orion", "sev2", "circuit-breaker-cascade", 27, 14, 65 * time.Minute, "Model v5 warm-up exceeded the inference timeout", "Added model warm-up before taking traffic", "milo"},
+	{"Circuit breakers open across checkout dependencies", "Breakers opened on checkout calls to recommendations and inventory after a GC pause storm", "svc-checkout", "team-velocity", "sev2", "circuit-breaker-cascade", 68, 9, 30 * time.Minute, "Heap growth after a config change caused long GC pauses", "Reverted the heap setting and tuned breaker thresholds", "milo"},
+	{"Analytics pipeline dropped events", "Analytics ETL dropped APAC events for six hours", "svc-analytics", "team-foundry", "sev3", "", 21, 3, 6 * time.Hour, "Schema change rejected events with a new optional field", "Made the ingest schema tolerant of unknown fields", "jordan"},
+	{"Identity provider token refresh failures", "Token refresh requests failed for 3% of mobile sessions", "svc-identity", "team-guardian", "sev2", "", 84, 7, 90 * time.Minute, "Clock skew on two identity nodes invalidated fresh tokens", "Re-synced NTP and alerted on node clock drift", "kim"},
+}
+
+// recurringIssues are the run-of-the-mill problems each service hits every few
+// weeks. They are repeated across the history window by recurringSeeds so trend
+// reports (MTTR over time, incidents per service per week) have enough points.
+// duration is the typical time to resolve at the start of the window.
+var recurringIssues = []historicalSeed{
+	{title: "Checkout 5xx spike", description: "Checkout 5xx rate above 2% for several minutes", service: "svc-checkout", team: "team-velocity", severity: "sev2", family: "slo-exhaustion", duration: 75 * time.Minute, rootCause: "Pod restarts under memory pressure", resolution: "Raised memory limits and rebalanced pods", responder: "alex"},
+	{title: "Payments provider timeouts", description: "Card authorisations timing out against the payment provider", service: "svc-payments", team: "team-revenue", severity: "sev2", family: "external-dependency-failure", duration: 60 * time.Minute, rootCause: "Provider degradation in one region", resolution: "Failed over to the secondary provider region", responder: "sam"},
+	{title: "Search index lag", description: "Search index updates delayed; new products missing from results", service: "svc-search", team: "team-aurora", severity: "sev3", family: "", duration: 2 * time.Hour, rootCause: "Indexer backlog after a bulk catalog import", resolution: "Scaled indexer workers and throttled bulk imports", responder: "lena"},
+	{title: "Order database slow queries", description: "Order write latency elevated from slow queries on the primary", service: "svc-database", team: "team-data", severity: "sev2", family: "cascading-failure", duration: 90 * time.Minute, rootCause: "Missing index after a schema migration", resolution: "Added the index and a migration review check", responder: "morgan"},
+	{title: "Catalog deploy rolled back", description: "Catalog release raised error rates and was rolled back", service: "svc-catalog", team: "team-atlas", severity: "sev3", family: "deployment-rollback", duration: 40 * time.Minute, rootCause: "Config key renamed without a fallback", resolution: "Rolled back and added config validation at startup", responder: "priya"},
+	{title: "Notification delivery delays", description: "Push and email notifications delayed by more than 15 minutes", service: "svc-notifications", team: "team-signal", severity: "sev3", family: "autoscaling-lag", duration: 100 * time.Minute, rootCause: "Queue consumers did not scale with a send burst", resolution: "Scaled consumers on queue depth", responder: "riley"},
+	{title: "Recommendation latency degraded", description: "Recommendation p95 latency above target, breakers half-open in web", service: "svc-recommendation", team: "team-orion", severity: "sev3", family: "circuit-breaker-cascade", duration: 55 * time.Minute, rootCause: "Feature store cache evictions", resolution: "Resized the feature store cache", responder: "milo"},
+	{title: "Shipping label creation failures", description: "Label creation failing for a share of orders", service: "svc-shipping", team: "team-hawkeye", severity: "sev3", family: "external-dependency-failure", duration: 2*time.Hour + 30*time.Minute, rootCause: "Carrier API returned malformed responses", resolution: "Added response validation and retry to the secondary carrier", responder: "alexis"},
+	{title: "Identity login errors", description: "Login error rate elevated for web sessions", service: "svc-identity", team: "team-guardian", severity: "sev2", family: "deployment-rollback", duration: 50 * time.Minute, rootCause: "Session cookie change broke older clients", resolution: "Rolled back and added a compatibility test", responder: "kim"},
+	{title: "Realtime connections dropping", description: "Websocket connections dropping during peak traffic", service: "svc-realtime", team: "team-nova", severity: "sev3", family: "autoscaling-lag", duration: 70 * time.Minute, rootCause: "Load balancer connection limit reached", resolution: "Raised connection limits and pre-scaled for peaks", responder: "quinn"},
+	{title: "Warehouse sync stalled", description: "Inventory sync from warehouses stalled; stock counts stale", service: "svc-warehouse", team: "team-lumen", severity: "sev3", family: "", duration: 3 * time.Hour, rootCause: "Expired credentials for the warehouse feed", resolution: "Rotated credentials and alerted on expiry", responder: "drew"},
+	{title: "Analytics dashboards stale", description: "Analytics dashboards several hours behind", service: "svc-analytics", team: "team-foundry", severity: "sev4", family: "", duration: 4 * time.Hour, rootCause: "ETL job stuck on a locked table", resolution: "Killed the lock holder and added a job timeout", responder: "jordan"},
+}
+
+// recurringIncidentCount is how many recurring incidents are spread over the
+// history window, on top of the hand-written historicalSeeds.
+const recurringIncidentCount = 34
+
+// recurringSeeds spreads recurringIssues evenly over the history window. Time
+// to resolve shrinks towards the present, so MTTR charts show the team
+// improving, with some deterministic jitter so the trend is not a straight line.
+func recurringSeeds() []historicalSeed {
+	days := int(historyWindow / (24 * time.Hour))
+	seeds := make([]historicalSeed, 0, recurringIncidentCount)
+	for k := 0; k < recurringIncidentCount; k++ {
+		seed := recurringIssues[k%len(recurringIssues)]
+		seed.daysAgo = 2 + k*(days-3)/recurringIncidentCount
+		seed.hour = 7 + (k*5)%12
+		age := 0.6 + 0.8*float64(seed.daysAgo)/float64(days)
+		jitter := 0.8 + float64((k*37)%40)/100
+		seed.duration = time.Duration(float64(seed.duration) * age * jitter).Round(time.Minute)
+		seeds = append(seeds, seed)
+	}
+	return seeds
+}
+
+// historicalIncidents builds the resolved corpus relative to now, with a closed
+// timeline for each. Sev1 and sev2 incidents also carry a published postmortem.
+func historicalIncidents(source string, now time.Time) ([]schema.Incident, map[string][]schema.TimelineEntry) {
+	seeds := append(append([]historicalSeed(nil), historicalSeeds...), recurringSeeds()...)
+	incidents := make([]schema.Incident, 0, len(seeds))
+	timelines := make(map[string][]schema.TimelineEntry, len(seeds))
+	for i, seed := range seeds {
+		id := fmt.Sprintf("inc-hist-%03d", i+1)
+		day := now.Add(-time.Duration(seed.daysAgo) * 24 * time.Hour)
+		createdAt := time.Date(day.Year(), day.Month(), day.Day(), seed.hour, (i*17)%60, 0, 0, time.UTC)
+		ackedAt := createdAt.Add(time.Duration(2+i%4) * time.Minute)
+		resolvedAt := createdAt.Add(seed.duration)
+
+		fields := map[string]any{
+			"service":               seed.service,
+			"team":                  seed.team,
+			"environment":           "prod",
+			"historical":            true,
+			"rootCause":             seed.rootCause,
+			"resolution":            seed.resolution,
+			"acknowledgedAt":        ackedAt.Format(time.RFC3339),
+			"resolvedAt":            resolvedAt.Format(time.RFC3339),
+			"durationMinutes":       int(seed.duration.Minutes()),
+			mockutil.CorrelationKey: mockutil.IncidentCorrelationID(id),
+		}
+		if seed.family != "" {
+			fields["scenario_family"] = seed.family
+		}
+		tags := historicalTags(seed)
+		if len(tags) > 0 {
+			fields[tagsField] = tags
+		}
+		for k, v := range historicalResolution(seed, tags) {
+			fields[k] = v
+		}
+		metadata := map[string]any{"source": source}
+		timeline := []schema.TimelineEntry{
+			{ID: id + "-t1", IncidentID: id, At: createdAt, Kind: "note", Body: "Incident detected: " + seed.title, Actor: mockutil.ActorRef("alertmanager")},
+			{ID: id + "-t2", IncidentID: id, At: ackedAt, Kind: "note", Body: "Acknowledged by " + seed.responder, Actor: mockutil.ActorRef(seed.responder)},
+			{ID: id + "-t3", IncidentID: id, At: createdAt.Add(seed.duration / 3), Kind: "note", Body: "Root cause: " + seed.rootCause, Actor: mockutil.ActorRef(seed.responder)},
+			{ID: id + "-t4", IncidentID: id, At: resolvedAt, Kind: "status_change", Body: seed.resolution, Actor: mockutil.ActorRef(seed.responder), Metadata: map[string]any{"from": "mitigating", "to": "resolved"}},
+		}
+		// Postmortems are published a few days after resolution, so the most
+		// recent incidents may still be waiting for theirs.
+		if seed.severity == "sev1" || seed.severity == "sev2" {
+			url := fmt.Sprintf("https://docs.demo.com/postmortems/%s", id)
+			metadata["postmortem"] = url
+			publishedAt := resolvedAt.Add(3 * 24 * time.Hour)
+			if publishedAt.Before(now) {
+				fields["postmortemStatus"] = "published"
+				fields["postmortemPublishedAt"] = publishedAt.Format(time.RFC3339)
+				timeline = append(timeline, schema.TimelineEntry{ID: id + "-t5", IncidentID: id, At: publishedAt, Kind: "note", Body: "Postmortem published: " + url, Actor: mockutil.ActorRef(seed.responder)})
+			} else {
+				fields["postmortemStatus"] = "draft"
+			}
+		}
+		incidents = append(incidents, schema.Incident{
+			ID:          id,
+			Title:       seed.title,
+			Description: seed.description,
+			Status:      "resolved",
+			Severity:    seed.severity,
+			Service:     seed.service,
+			CreatedAt:   createdAt,
+			UpdatedAt:   resolvedAt,
+			Fields:      fields,
+			Metadata:    metadata,
+		})
+		timelines[id] = timeline
+	}
+	return incidents, timelines
+}
@@ -0,0 +1,205 @@
+package incidentmock
+
+import (
+	"context"
+	"math"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/opsorch/opsorch-core/schema"
+	"github.com/opsorch/opsorch-mock-adapters/metricmock"
+)
+
+// ImpactEstimate is the business cost of an incident over its window, derived
+// from the business metrics metricmock reports for the same period.
+type ImpactEstimate struct {
+	IncidentID  string    `json:"incidentId"`
+	Service     string    `json:"service"`
+	WindowStart time.Time `json:"windowStart"`
+	WindowEnd   time.Time `json:"windowEnd"`
+	// Ongoing is true while the incident is unresolved and the window ends now.
+	Ongoing         bool    `json:"ongoing"`
+	DurationMinutes float64 `json:"durationMinutes"`
+	// ImpactedFraction is the share of traffic assumed degraded, and
+	// FractionSource says where it came from.
+	ImpactedFraction     float64 `json:"impactedFraction"`
+	FractionSource       string  `json:"fractionSource"`
+	ActiveUsers          int     `json:"activeUsers"`
+	AffectedUsers        int     `json:"affectedUsers"`
+	OrdersPerMinute      float64 `json:"ordersPerMinute"`
+	AffectedOrders       int     `json:"affectedOrders"`
+	RevenuePerMinute     float64 `json:"revenuePerMinute"`
+	LostRevenuePerMinute float64 `json:"lostRevenuePerMinute"`
+	LostRevenue          float64 `json:"lostRevenue"`
+	Currency             string  `json:"currency"`
+	// Metrics lists the metricmock series the baselines were read from.
+	Metrics []string `json:"metrics"`
+}
+
+// Business metrics read for impact estimates.
+const (
+	impactUsersMetric   = "active_users_total"
+	impactOrdersMetric  = "orders_created_total"
+	impactRevenueMetric = "revenue_total"
+)
+
+// severityImpact is the share of traffic assumed degraded when an incident
+// does not state one.
+var severityImpact = map[string]float64{
+	"sev1": 0.35,
+	"sev2": 0.15,
+	"sev3": 0.05,
+	"sev4": 0.01,
+}
+
+// revenuePathServices sit directly on the purchase path; incidents elsewhere
+// only reach revenue indirectly and are damped by offPathWeight.
+var revenuePathServices = map[string]bool{
+	"svc-checkout": true,
+	"svc-payments": true,
+	"svc-order":    true,
+	"svc-web":      true,
+	"svc-cart":     true,
+}
+
+const offPathWeight = 0.3
+
+var percentPattern = regexp.MustCompile(`(\d+(?:\.\d+)?)\s*%`)
+
+// Impact estimates the business impact of an incident from the business
+// metrics over its window: resolved incidents span creation to their last
+// update, open ones run until now.
+func (p *Provider) Impact(ctx context.Context, id string) (ImpactEstimate, error) {
+	inc, err := p.Get(ctx, id)
+	if err != nil {
+		return ImpactEstimate{}, err
+	}
+
+	start := inc.CreatedAt
+	end := p.now()
+	ongoing := !resolvedStatuses[inc.Status]
+	if !ongoing && inc.UpdatedAt.After(start) {
+		end = inc.UpdatedAt
+	}
+	if end.Sub(start) < time.Minute {
+		end = start.Add(time.Minute)
+	}
+	minutes := end.Sub(start).Minutes()
+
+	service := inc.Service
+	if service == "" {
+		service, _ = inc.Fields["service"].(string)
+	}
+	fraction, source := impactFraction(inc)
+	if !revenuePathServices[service] {
+		fraction *= offPathWeight
+		source += " (off revenue path)"
+	}
+
+	users, err := businessMetric(ctx, impactUsersMetric, start, end)
+	if err != nil {
+		return ImpactEstimate{}, err
+	}
+	orders, err := businessMetric(ctx, impactOrdersMetric, start, end)
+	if err != nil {
+		return ImpactEstimate{}, err
+	}
+	revenue, err := businessMetric(ctx, impactRevenueMetric, start, end)
+	if err != nil {
+		return ImpactEstimate{}, err
+	}
+
+	est := ImpactEstimate{
+		IncidentID:           inc.ID,
+		Service:              service,
+		WindowStart:          start,
+		WindowEnd:            end,
+		Ongoing:              ongoing,
+		DurationMinutes:      round2(minutes),
+		ImpactedFraction:     round2(fraction),
+		FractionSource:       source,
+		ActiveUsers:          int(math.Round(users)),
+		AffectedUsers:        int(math.Round(users * fraction)),
+		OrdersPerMinute:      round2(orders),
+		AffectedOrders:       int(math.Round(orders * minutes * fraction)),
+		RevenuePerMinute:     round2(revenue),
+		LostRevenuePerMinute: round2(revenue * fraction),
+		LostRevenue:          round2(revenue * fraction * minutes),
+		Currency:             "USD",
+		Metrics:              []string{impactUsersMetric, impactOrdersMetric, impactRevenueMetric},
+	}
+	return est, nil
+}
+
+// impactFraction prefers a numeric Fields["impactPercent"], then a percentage
+// quoted in Fields["customerImpact"], then the severity default.
+func impactFraction(inc schema.Incident) (float64, string) {
+	switch v := inc.Fields["impactPercent"].(type) {
+	case float64:
+		return clampFraction(v / 100), "impactPercent"
+	case int:
+		return clampFraction(float64(v) / 100), "impactPercent"
+	}
+	if text, ok := inc.Fields["customerImpact"].(string); ok {
+		if m := percentPattern.FindStringSubmatch(text); m != nil {
+			if pct, err := strconv.ParseFloat(m[1], 64); err == nil {
+				return clampFraction(pct / 100), "customerImpact"
+			}
+		}
+	}
+	if f, ok := severityImpact[inc.Severity]; ok {
+		return f, "severity"
+	}
+	return severityImpact["sev3"], "severity"
+}
+
+// businessMetric returns the window average of a gauge or the per-minute rate
+// of a counter, read from the metric's baseline series so the estimate
+// reflects normal demand rather than the degraded values.
+func businessMetric(ctx context.Context, name string, start, end time.Time) (float64, error) {
+	prov, err := metricmock.New(nil)
+	if err != nil {
+		return 0, err
+	}
+	series, err := prov.Query(ctx, schema.MetricQuery{
+		Expression: &schema.MetricExpression{MetricName: name},
+		Start:      start,
+		End:        end,
+		// Counters advance once per point, so a one-minute step keeps rates per minute.
+		Step: 60,
+	})
+	if err != nil {
+		return 0, err
+	}
+	var points []schema.MetricPoint
+	for _, s := range series {
+		if s.Metadata["variant"] == "baseline" {
+			points = s.Points
+		}
+	}
+	if len(points) == 0 {
+		return 0, nil
+	}
+	if metricType, _ := series[0].Metadata["metricType"].(string); metricType == "counter" {
+		first, last := points[0], points[len(points)-1]
+		span := last.Timestamp.Sub(first.Timestamp).Minutes()
+		if span <= 0 {
+			return 0, nil
+		}
+		return math.Max(0, (last.Value-first.Value)/span), nil
+	}
+	sum := 0.0
+	for _, pt := range points {
+		sum += pt.Value
+	}
+	return sum / float64(len(points)), nil
+}
+
+func clampFraction(f float64) float64 {
+	return math.Min(1, math.Max(0, f))
+}
+
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
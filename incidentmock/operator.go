@@ -0,0 +1,79 @@
+package incidentmock
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/opsorch/opsorch-core/schema"
+	"github.com/opsorch/opsorch-mock-adapters/internal/mockutil"
+)
+
+// operatorUpdates are the status updates the simulated operator posts, by
+// incident status. Each takes the service.
+var operatorUpdates = map[string]string{
+	"open":          "Looking into %s now; pulling dashboards and recent deploys.",
+	"investigating": "Still investigating %s. Recent deploys and dependency health check out so far; next update in 30 minutes.",
+	"identified":    "Cause identified on %s; preparing the fix.",
+	"mitigating":    "Mitigation rolling out on %s; error rates are trending down.",
+	"monitoring":    "Fix is holding on %s; monitoring before we resolve.",
+}
+
+// RegisterOperatorTasks registers the provider's operator tasks on op.
+func (p *Provider) RegisterOperatorTasks(op *mockutil.Operator) {
+	op.Register("incident.update", p.operatorUpdate)
+}
+
+// operatorUpdate is the simulated operator's incident task: post a status
+// update on the active incident that has gone longest without timeline
+// activity.
+func (p *Provider) operatorUpdate(ctx context.Context, actor string) (mockutil.OperatorAction, bool, error) {
+	p.mu.Lock()
+	type candidate struct {
+		inc  schema.Incident
+		last time.Time
+	}
+	candidates := make([]candidate, 0)
+	for id, inc := range p.incidents {
+		if resolvedStatuses[inc.Status] {
+			continue
+		}
+		last := inc.UpdatedAt
+		if entries := p.timeline[id]; len(entries) > 0 && entries[len(entries)-1].At.After(last) {
+			last = entries[len(entries)-1].At
+		}
+		candidates = append(candidates, candidate{inc: inc, last: last})
+	}
+	p.mu.Unlock()
+	if len(candidates) == 0 {
+		return mockutil.OperatorAction{}, false, nil
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if !candidates[i].last.Equal(candidates[j].last) {
+			return candidates[i].last.Before(candidates[j].last)
+		}
+		return candidates[i].inc.ID < candidates[j].inc.ID
+	})
+
+	inc := candidates[0].inc
+	format, ok := operatorUpdates[inc.Status]
+	if !ok {
+		format = operatorUpdates["investigating"]
+	}
+	body := fmt.Sprintf(format, inc.Service)
+	entry := schema.TimelineAppendInput{
+		At:       p.now(),
+		Kind:     "note",
+		Body:     body,
+		Actor:    mockutil.ActorRef(actor),
+		Metadata: map[string]any{"operator": true},
+	}
+	if err := p.AppendTimeline(ctx, inc.ID, entry); err != nil {
+		return mockutil.OperatorAction{}, false, err
+	}
+	return mockutil.OperatorAction{
+		Ref:     mockutil.FormatRef(mockutil.RefIncident, inc.ID),
+		Summary: fmt.Sprintf("Posted an update on %s: %s", inc.ID, body),
+	}, true, nil
+}
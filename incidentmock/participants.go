@@ -0,0 +1,242 @@
+package incidentmock
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/opsorch/opsorch-core/orcherr"
+	"github.com/opsorch/opsorch-core/schema"
+	"github.com/opsorch/opsorch-mock-adapters/internal/mockutil"
+)
+
+// Participant is one presence session of a responder in an incident. A responder
+// who leaves and rejoins gets a new session.
+type Participant struct {
+	IncidentID string     `json:"incidentId"`
+	Name       string     `json:"name"`
+	Role       string     `json:"role,omitempty"`
+	JoinedAt   time.Time  `json:"joinedAt"`
+	LeftAt     *time.Time `json:"leftAt,omitempty"`
+}
+
+// Active reports whether the participant is still present.
+func (pt Participant) Active() bool {
+	return pt.LeftAt == nil
+}
+
+// HandoffNote captures what an outgoing responder passes to their replacement.
+type HandoffNote struct {
+	ID         string    `json:"id"`
+	IncidentID string    `json:"incidentId"`
+	From       string    `json:"from"`
+	To         string    `json:"to"`
+	Role       string    `json:"role,omitempty"`
+	Summary    string    `json:"summary"`
+	OpenItems  []string  `json:"openItems,omitempty"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+// Participants lists presence sessions for an incident ordered by join time.
+func (p *Provider) Participants(ctx context.Context, incidentID string, activeOnly bool) ([]Participant, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, ok := p.incidentLocked(incidentID); !ok {
+		return nil, orcherr.New("not_found", "incident not found", nil)
+	}
+	out := make([]Participant, 0, len(p.participants[incidentID]))
+	for _, pt := range p.participants[incidentID] {
+		if activeOnly && !pt.Active() {
+			continue
+		}
+		out = append(out, cloneParticipant(pt))
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].JoinedAt.Before(out[j].JoinedAt) })
+	return out, nil
+}
+
+// JoinIncident adds a responder to an incident and records a participant_joined
+// timeline entry. Joining while already present returns the existing session.
+func (p *Provider) JoinIncident(ctx context.Context, incidentID, name, role string) (Participant, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, ok := p.incidentLocked(incidentID); !ok {
+		return Participant{}, orcherr.New("not_found", "incident not found", nil)
+	}
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return Participant{}, orcherr.New("bad_request", "participant name is required", nil)
+	}
+	return p.joinLocked(incidentID, name, role, p.now()), nil
+}
+
+// LeaveIncident ends a responder's active session and records a participant_left timeline entry.
+func (p *Provider) LeaveIncident(ctx context.Context, incidentID, name string) (Participant, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, ok := p.incidentLocked(incidentID); !ok {
+		return Participant{}, orcherr.New("not_found", "incident not found", nil)
+	}
+	pt, ok := p.leaveLocked(incidentID, name, p.now())
+	if !ok {
+		return Participant{}, orcherr.New("not_found", fmt.Sprintf("%s is not an active participant", name), nil)
+	}
+	return pt, nil
+}
+
+// CreateHandoff records a shift handoff: the outgoing responder leaves, the
+// incoming responder joins with the same role, and the note is added to the timeline.
+func (p *Provider) CreateHandoff(ctx context.Context, in HandoffNote) (HandoffNote, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, ok := p.incidentLocked(in.IncidentID); !ok {
+		return HandoffNote{}, orcherr.New("not_found", "incident not found", nil)
+	}
+	in.From = strings.TrimSpace(in.From)
+	in.To = strings.TrimSpace(in.To)
+	if in.From == "" || in.To == "" {
+		return HandoffNote{}, orcherr.New("bad_request", "handoff requires from and to", nil)
+	}
+	if in.From == in.To {
+		return HandoffNote{}, orcherr.New("bad_request", "cannot hand off to yourself", nil)
+	}
+	if strings.TrimSpace(in.Summary) == "" {
+		return HandoffNote{}, orcherr.New("bad_request", "handoff summary is required", nil)
+	}
+	outgoing, ok := p.activeParticipantLocked(in.IncidentID, in.From)
+	if !ok {
+		return HandoffNote{}, orcherr.New("bad_request", fmt.Sprintf("%s is not an active participant", in.From), nil)
+	}
+
+	now := p.now()
+	if in.Role == "" {
+		in.Role = outgoing.Role
+	}
+	p.nextHandoffID++
+	in.ID = fmt.Sprintf("%s-handoff-%d", in.IncidentID, p.nextHandoffID)
+	in.CreatedAt = now
+	in.OpenItems = mockutil.CloneStringSlice(in.OpenItems)
+
+	p.handoffs[in.IncidentID] = append(p.handoffs[in.IncidentID], in)
+	p.appendTimelineLocked(in.IncidentID, schema.TimelineAppendInput{
+		At:       now,
+		Kind:     "handoff",
+		Body:     fmt.Sprintf("Handoff from %s to %s: %s", in.From, in.To, in.Summary),
+		Actor:    mockutil.ActorRef(in.From),
+		Metadata: map[string]any{"handoffId": in.ID, "openItems": mockutil.CloneStringSlice(in.OpenItems)},
+	})
+	p.leaveLocked(in.IncidentID, in.From, now)
+	p.joinLocked(in.IncidentID, in.To, in.Role, now)
+
+	out := in
+	out.OpenItems = mockutil.CloneStringSlice(in.OpenItems)
+	return out, nil
+}
+
+// Handoffs lists handoff notes for an incident, oldest first.
+func (p *Provider) Handoffs(ctx context.Context, incidentID string) ([]HandoffNote, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, ok := p.incidentLocked(incidentID); !ok {
+		return nil, orcherr.New("not_found", "incident not found", nil)
+	}
+	out := make([]HandoffNote, 0, len(p.handoffs[incidentID]))
+	for _, note := range p.handoffs[incidentID] {
+		note.OpenItems = mockutil.CloneStringSlice(note.OpenItems)
+		out = append(out, note)
+	}
+	return out, nil
+}
+
+func (p *Provider) joinLocked(incidentID, name, role string, at time.Time) Participant {
+	if pt, ok := p.activeParticipantLocked(incidentID, name); ok {
+		return pt
+	}
+	pt := Participant{IncidentID: incidentID, Name: name, Role: role, JoinedAt: at}
+	p.participants[incidentID] = append(p.participants[incidentID], pt)
+	body := fmt.Sprintf("%s joined the incident", name)
+	if role != "" {
+		body = fmt.Sprintf("%s joined the incident as %s", name, role)
+	}
+	p.appendTimelineLocked(incidentID, schema.TimelineAppendInput{
+		At:    at,
+		Kind:  "participant_joined",
+		Body:  body,
+		Actor: mockutil.ActorRef(name),
+	})
+	return pt
+}
+
+func (p *Provider) leaveLocked(incidentID, name string, at time.Time) (Participant, bool) {
+	sessions := p.participants[incidentID]
+	for i := range sessions {
+		if sessions[i].Name == name && sessions[i].Active() {
+			left := at
+			sessions[i].LeftAt = &left
+			p.appendTimelineLocked(incidentID, schema.TimelineAppendInput{
+				At:    at,
+				Kind:  "participant_left",
+				Body:  fmt.Sprintf("%s left the incident", name),
+				Actor: mockutil.ActorRef(name),
+			})
+			return cloneParticipant(sessions[i]), true
+		}
+	}
+	return Participant{}, false
+}
+
+func (p *Provider) activeParticipantLocked(incidentID, name string) (Participant, bool) {
+	for _, pt := range p.participants[incidentID] {
+		if pt.Name == name && pt.Active() {
+			return cloneParticipant(pt), true
+		}
+	}
+	return Participant{}, false
+}
+
+func cloneParticipant(in Participant) Participant {
+	out := in
+	if in.LeftAt != nil {
+		left := *in.LeftAt
+		out.LeftAt = &left
+	}
+	return out
+}
+
+// seedParticipants adds presence and a comms handoff to the longest-running
+// scenario incidents so handoff summaries have data to work with.
+func (p *Provider) seedParticipants(now time.Time) {
+	leftAt := func(d time.Duration) *time.Time {
+		t := now.Add(d)
+		return &t
+	}
+
+	p.participants["inc-scenario-003"] = []Participant{
+		{IncidentID: "inc-scenario-003", Name: "sam", Role: "commander", JoinedAt: now.Add(-85 * time.Minute)},
+		{IncidentID: "inc-scenario-003", Name: "priya", Role: "communications", JoinedAt: now.Add(-80 * time.Minute), LeftAt: leftAt(-40 * time.Minute)},
+		{IncidentID: "inc-scenario-003", Name: "devon", Role: "payments-sme", JoinedAt: now.Add(-78 * time.Minute)},
+		{IncidentID: "inc-scenario-003", Name: "rosa", Role: "communications", JoinedAt: now.Add(-40 * time.Minute)},
+	}
+	p.handoffs["inc-scenario-003"] = []HandoffNote{{
+		ID:         "inc-scenario-003-handoff-seed",
+		IncidentID: "inc-scenario-003",
+		From:       "priya",
+		To:         "rosa",
+		Role:       "communications",
+		Summary:    "Status page updated after rollback; merchants notified via support macro. Next update due at the top of the hour.",
+		OpenItems:  []string{"Post all-clear once error rate holds below 0.5% for 30m", "Reply to enterprise merchant escalation ticket"},
+		CreatedAt:  now.Add(-40 * time.Minute),
+	}}
+
+	p.participants["inc-scenario-001"] = []Participant{
+		{IncidentID: "inc-scenario-001", Name: "alex", Role: "commander", JoinedAt: now.Add(-40 * time.Minute)},
+		{IncidentID: "inc-scenario-001", Name: "jordan", Role: "sre", JoinedAt: now.Add(-35 * time.Minute)},
+	}
+}
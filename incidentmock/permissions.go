@@ -0,0 +1,72 @@
+package incidentmock
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/opsorch/opsorch-core/orcherr"
+	"github.com/opsorch/opsorch-core/schema"
+)
+
+// commanderRole is the participant role allowed to resolve an incident.
+const commanderRole = "commander"
+
+// WithActor names the responder making an Update. When set, Update simulates
+// role-based permissions: only the incident commander may resolve or close the
+// incident, and only active responders may change its severity. Updates
+// without an actor are not checked.
+func WithActor(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, actorKey{}, strings.TrimSpace(name))
+}
+
+type actorKey struct{}
+
+func actorFrom(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	name, _ := ctx.Value(actorKey{}).(string)
+	return name
+}
+
+// checkPermissionsLocked returns a forbidden error when actor may not apply in
+// to inc. An incident with no active commander can be resolved by any active
+// responder, so demos without seeded presence still work. Callers must hold p.mu.
+func (p *Provider) checkPermissionsLocked(inc schema.Incident, in schema.UpdateIncidentInput, actor string) error {
+	if actor == "" {
+		return nil
+	}
+	_, isResponder := p.activeParticipantLocked(inc.ID, actor)
+
+	if in.Status != nil && resolvedStatuses[*in.Status] && !resolvedStatuses[inc.Status] {
+		commander, hasCommander := p.activeCommanderLocked(inc.ID)
+		switch {
+		case hasCommander && commander != actor:
+			return orcherr.New("forbidden", fmt.Sprintf("only the incident commander (%s) can %s this incident", commander, resolveVerb(*in.Status)), nil)
+		case !hasCommander && !isResponder:
+			return orcherr.New("forbidden", fmt.Sprintf("%s is not a responder on this incident", actor), nil)
+		}
+	}
+	if in.Severity != nil && *in.Severity != inc.Severity && !isResponder {
+		return orcherr.New("forbidden", fmt.Sprintf("only responders can change severity; %s is not a responder on this incident", actor), nil)
+	}
+	return nil
+}
+
+// activeCommanderLocked returns the name of the incident's active commander.
+func (p *Provider) activeCommanderLocked(incidentID string) (string, bool) {
+	for _, pt := range p.participants[incidentID] {
+		if pt.Active() && pt.Role == commanderRole {
+			return pt.Name, true
+		}
+	}
+	return "", false
+}
+
+func resolveVerb(status string) string {
+	if status == "closed" {
+		return "close"
+	}
+	return "resolve"
+}
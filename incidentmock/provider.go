@@ -10,6 +10,7 @@ import (
 	"github.com/opsorch/opsorch-core/incident"
 	"github.com/opsorch/opsorch-core/orcherr"
 	"github.com/opsorch/opsorch-core/schema"
+	"github.com/opsorch/opsorch-mock-adapters/internal/bundle"
 	"github.com/opsorch/opsorch-mock-adapters/internal/mockutil"
 )
 
@@ -18,8 +19,25 @@ const ProviderName = "mock"
 
 // Config controls mock incident behavior.
 type Config struct {
-	Source          string
+	Source string
+	// DefaultSeverity applies to incidents created without a severity. When
+	// unset they default by service tier (see tierSeverities).
 	DefaultSeverity string
+	// Location aligns seeded incident times to business hours in this timezone when set.
+	Location *time.Location
+	// EscalationRules raise the severity of incidents left unacknowledged.
+	EscalationRules []EscalationRule
+	// Severity labels incident severities on the way in and out.
+	Severity SeverityScheme
+	// Warmup generates the resolved-incident history in New instead of on
+	// first access.
+	Warmup bool
+	// Locale, when set to a translated language such as "de" or "ja",
+	// localizes seeded incident titles and descriptions.
+	Locale string
+	// Features switches realism behaviours off individually; see
+	// mockutil.Features.
+	Features mockutil.Features
 }
 
 // Provider keeps an in-memory incident list for demo purposes.
@@ -29,13 +47,48 @@ type Provider struct {
 	nextID    int
 	incidents map[string]schema.Incident
 	timeline  map[string][]schema.TimelineEntry
+	clock     func() time.Time
+
+	participants  map[string][]Participant
+	handoffs      map[string][]HandoffNote
+	nextHandoffID int
+
+	// history generates the resolved-incident corpus on first access,
+	// relative to seededAt so it lines up with the eagerly seeded incidents.
+	history  *mockutil.LazySeed
+	seededAt time.Time
+
+	// declare holds the providers Declare pages, messages, and starts runs on.
+	declare DeclareDeps
+	// actionItems holds the ticket provider ActionItems links and files tickets in.
+	actionItems ActionItemDeps
 }
 
 // New constructs the provider with seeded demo incidents.
 func New(cfg map[string]any) (incident.Provider, error) {
 	parsed := parseConfig(cfg)
-	p := &Provider{cfg: parsed, incidents: map[string]schema.Incident{}, timeline: map[string][]schema.TimelineEntry{}}
-	p.seed()
+	p := &Provider{
+		cfg:          parsed,
+		incidents:    map[string]schema.Incident{},
+		timeline:     map[string][]schema.TimelineEntry{},
+		participants: map[string][]Participant{},
+		handoffs:     map[string][]HandoffNote{},
+	}
+	p.history = mockutil.NewLazySeed("incident", "history", p.seedHistoryLocked)
+	mockutil.TimeSeed("incident", "incidents", false, func() int {
+		p.seed()
+		return len(p.incidents)
+	})
+	snapshot, err := bundle.FromConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	p.restore(snapshot)
+	if parsed.Warmup {
+		p.history.Warm()
+	}
+	mockutil.RegisterResolver(mockutil.RefIncident, func(ctx context.Context, id string) (any, error) { return p.Get(ctx, id) })
+	mockutil.RegisterSearchSource(mockutil.RefIncident, p.SearchDocs)
 	return p, nil
 }
 
@@ -92,13 +145,32 @@ type scopeKey struct{}
 // Query returns incidents filtered by query parameters. If a QueryScope was attached to the context
 // with WithScope, it is merged with the provided query.Scope (query takes precedence).
 func (p *Provider) Query(ctx context.Context, query schema.IncidentQuery) ([]schema.Incident, error) {
+	filter, err := mockutil.FilterFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	severities := make([]string, 0, len(query.Severities))
+	for _, sev := range query.Severities {
+		canonical, err := p.normalizeSeverity(sev)
+		if err != nil {
+			return nil, err
+		}
+		severities = append(severities, canonical)
+	}
+
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
 	combinedScope := mergeScope(extractScope(ctx), query.Scope)
 	statusFilter := toSet(query.Statuses)
-	severityFilter := toSet(query.Severities)
+	severityFilter := toSet(severities)
 	needle := strings.ToLower(strings.TrimSpace(query.Query))
+	onlyBreached := breachedOnly(ctx)
+	wantedTags := tagsFrom(ctx)
+	now := p.now()
+	p.history.Ensure()
+	p.escalateLocked(now)
 
 	out := make([]schema.Incident, 0, len(p.incidents))
 	for _, inc := range p.incidents {
@@ -114,8 +186,24 @@ func (p *Provider) Query(ctx context.Context, query schema.IncidentQuery) ([]sch
 		if needle != "" && !matchesQuery(needle, inc) {
 			continue
 		}
+		if len(wantedTags) > 0 && !hasTags(inc, wantedTags) {
+			continue
+		}
 
-		out = append(out, cloneIncident(inc))
+		cloned := cloneIncident(inc)
+		p.applySLALocked(&cloned, now)
+		p.presentSeverity(&cloned)
+		if onlyBreached {
+			if breached, _ := cloned.Fields["slaBreached"].(bool); !breached {
+				continue
+			}
+		}
+		// Filters see derived fields such as slaBreached.
+		if !filter.Match(cloned) {
+			continue
+		}
+
+		out = append(out, cloned)
 		if query.Limit > 0 && len(out) >= query.Limit {
 			break
 		}
@@ -134,58 +222,99 @@ func (p *Provider) Get(ctx context.Context, id string) (schema.Incident, error)
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
-	inc, ok := p.incidents[id]
+	now := p.now()
+	p.escalateLocked(now)
+	inc, ok := p.incidentLocked(id)
 	if !ok {
 		return schema.Incident{}, orcherr.New("not_found", "incident not found", nil)
 	}
-	return cloneIncident(inc), nil
+	cloned := cloneIncident(inc)
+	p.applySLALocked(&cloned, now)
+	p.presentSeverity(&cloned)
+	return cloned, nil
 }
 
 // Create inserts a new incident with generated ID and enriched metadata.
 func (p *Provider) Create(ctx context.Context, in schema.CreateIncidentInput) (schema.Incident, error) {
+	severity, err := p.normalizeSeverity(in.Severity)
+	if err != nil {
+		return schema.Incident{}, err
+	}
+	service := inferService(in)
+	fields := mockutil.CloneMap(in.Fields)
+	if fields == nil {
+		fields = map[string]any{}
+	}
+	if err := normalizeTags(fields, service); err != nil {
+		return schema.Incident{}, err
+	}
+	if err := normalizeResolution(fields); err != nil {
+		return schema.Incident{}, err
+	}
+	if len(fields) == 0 {
+		fields = nil
+	}
+
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
 	p.nextID++
 	id := fmt.Sprintf("inc-%03d", p.nextID)
-	now := time.Now().UTC()
+	now := p.now()
 
 	incident := schema.Incident{
 		ID:          id,
 		Title:       in.Title,
 		Description: in.Description,
 		Status:      emptyFallback(in.Status, "open"),
-		Severity:    emptyFallback(in.Severity, p.cfg.DefaultSeverity),
-		Service:     inferService(in),
+		Severity:    emptyFallback(severity, emptyFallback(p.cfg.DefaultSeverity, tierSeverities[mockutil.ServiceTier(service)])),
+		Service:     service,
 		CreatedAt:   now,
 		UpdatedAt:   now,
-		Fields:      mockutil.CloneMap(in.Fields),
+		Fields:      fields,
 		Metadata:    mockutil.CloneMap(in.Metadata),
 	}
 	if incident.Metadata == nil {
 		incident.Metadata = map[string]any{}
 	}
 	incident.Metadata["source"] = p.cfg.Source
+	mockutil.LinkRefs(incident.Metadata, incident.Fields)
 	if incident.Service != "" {
 		if incident.Fields == nil {
 			incident.Fields = map[string]any{}
 		}
 		incident.Fields["service"] = incident.Service
 	}
+	stampCorrelation(&incident)
 
 	p.incidents[id] = incident
-	return cloneIncident(incident), nil
+	cloned := cloneIncident(incident)
+	p.applySLALocked(&cloned, now)
+	p.presentSeverity(&cloned)
+	return cloned, nil
 }
 
 // Update mutates an incident in place.
 func (p *Provider) Update(ctx context.Context, id string, in schema.UpdateIncidentInput) (schema.Incident, error) {
+	if in.Severity != nil {
+		severity, err := p.normalizeSeverity(*in.Severity)
+		if err != nil {
+			return schema.Incident{}, err
+		}
+		in.Severity = &severity
+	}
+
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
-	inc, ok := p.incidents[id]
+	p.escalateLocked(p.now())
+	inc, ok := p.incidentLocked(id)
 	if !ok {
 		return schema.Incident{}, orcherr.New("not_found", "incident not found", nil)
 	}
+	if err := p.checkPermissionsLocked(inc, in, actorFrom(ctx)); err != nil {
+		return schema.Incident{}, err
+	}
 
 	if in.Title != nil {
 		inc.Title = *in.Title
@@ -214,10 +343,30 @@ func (p *Provider) Update(ctx context.Context, id string, in schema.UpdateIncide
 		}
 		inc.Fields["service"] = inc.Service
 	}
-	inc.UpdatedAt = time.Now().UTC()
+	if in.Fields != nil {
+		if err := normalizeTags(inc.Fields, inc.Service); err != nil {
+			return schema.Incident{}, err
+		}
+		if err := normalizeResolution(inc.Fields); err != nil {
+			return schema.Incident{}, err
+		}
+	}
+	inc.UpdatedAt = p.now()
+	if resolvedStatuses[inc.Status] {
+		if _, ok := inc.Fields["resolvedAt"]; !ok {
+			if inc.Fields == nil {
+				inc.Fields = map[string]any{}
+			}
+			inc.Fields["resolvedAt"] = inc.UpdatedAt.Format(time.RFC3339)
+		}
+		fillRootCause(&inc)
+	}
 
 	p.incidents[id] = inc
-	return cloneIncident(inc), nil
+	cloned := cloneIncident(inc)
+	p.applySLALocked(&cloned, inc.UpdatedAt)
+	p.presentSeverity(&cloned)
+	return cloned, nil
 }
 
 // GetTimeline returns timeline entries for an incident.
@@ -225,9 +374,10 @@ func (p *Provider) GetTimeline(ctx context.Context, id string) ([]schema.Timelin
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
-	if _, ok := p.incidents[id]; !ok {
+	if _, ok := p.incidentLocked(id); !ok {
 		return nil, orcherr.New("not_found", "incident not found", nil)
 	}
+	p.escalateLocked(p.now())
 
 	// Get base timeline entries
 	entries := cloneTimeline(p.timeline[id])
@@ -240,10 +390,18 @@ func (p *Provider) AppendTimeline(ctx context.Context, id string, entry schema.T
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
-	if _, ok := p.incidents[id]; !ok {
+	if _, ok := p.incidentLocked(id); !ok {
 		return orcherr.New("not_found", "incident not found", nil)
 	}
+	if err := validateTimelineEntry(entry); err != nil {
+		return err
+	}
+	p.appendTimelineLocked(id, entry)
+	return nil
+}
 
+// appendTimelineLocked records a timeline entry. Callers must hold p.mu.
+func (p *Provider) appendTimelineLocked(id string, entry schema.TimelineAppendInput) {
 	n := len(p.timeline[id]) + 1
 	at := entry.At
 	if at.IsZero() {
@@ -259,11 +417,11 @@ func (p *Provider) AppendTimeline(ctx context.Context, id string, entry schema.T
 		Actor:      mockutil.CloneMap(entry.Actor),
 		Metadata:   mockutil.CloneMap(entry.Metadata),
 	})
-	return nil
 }
 
 func (p *Provider) seed() {
 	now := time.Now().UTC()
+	p.seededAt = now
 
 	seed := []schema.Incident{
 		{
@@ -271,7 +429,7 @@ func (p *Provider) seed() {
 			Title:       "Checkout latency impacting EU customers",
 			Description: "High checkout latency causing timeouts for a slice of EU traffic",
 			Status:      "mitigating",
-			Severity:    p.cfg.DefaultSeverity,
+			Severity:    emptyFallback(p.cfg.DefaultSeverity, "sev2"),
 			Service:     "svc-checkout",
 			CreatedAt:   now.Add(-55 * time.Minute),
 			UpdatedAt:   now.Add(-10 * time.Minute),
@@ -631,6 +789,9 @@ func (p *Provider) seed() {
 	}
 
 	for _, inc := range seed {
+		if !p.cfg.Features.ScenarioEffects && isScenarioIncident(inc.Metadata, inc.Fields) {
+			continue
+		}
 		p.incidents[inc.ID] = inc
 		if n, err := fmt.Sscanf(inc.ID, "inc-%d", &p.nextID); n == 1 && err == nil {
 			// keep the largest parsed ID for incremental IDs
@@ -638,129 +799,133 @@ func (p *Provider) seed() {
 	}
 
 	p.timeline["inc-001"] = []schema.TimelineEntry{
-		{ID: "inc-001-t1", IncidentID: "inc-001", At: now.Add(-50 * time.Minute), Kind: "note", Body: "PagerDuty triggered by checkout p95 > 1.2s", Actor: map[string]any{"type": "system", "name": "pd-bot"}},
-		{ID: "inc-001-t2", IncidentID: "inc-001", At: now.Add(-35 * time.Minute), Kind: "link", Body: "Runbook https://runbook.demo/checkout-latency", Actor: map[string]any{"type": "user", "name": "alex"}},
-		{ID: "inc-001-t3", IncidentID: "inc-001", At: now.Add(-18 * time.Minute), Kind: "note", Body: "Rolled back checkout v2.31.4 in EUW1", Actor: map[string]any{"type": "user", "name": "alex"}},
+		{ID: "inc-001-t1", IncidentID: "inc-001", At: now.Add(-50 * time.Minute), Kind: "note", Body: "PagerDuty triggered by checkout p95 > 1.2s", Actor: mockutil.ActorRef("pd-bot")},
+		{ID: "inc-001-t2", IncidentID: "inc-001", At: now.Add(-35 * time.Minute), Kind: "link", Body: "Runbook https://runbook.demo/checkout-latency", Actor: mockutil.ActorRef("alex")},
+		{ID: "inc-001-t3", IncidentID: "inc-001", At: now.Add(-18 * time.Minute), Kind: "note", Body: "Rolled back checkout v2.31.4 in EUW1", Actor: mockutil.ActorRef("alex")},
 	}
 
 	p.timeline["inc-002"] = []schema.TimelineEntry{
-		{ID: "inc-002-t1", IncidentID: "inc-002", At: now.Add(-110 * time.Minute), Kind: "note", Body: "Search cluster scaled up from 12 -> 16 nodes", Actor: map[string]any{"type": "user", "name": "jamie"}},
-		{ID: "inc-002-t2", IncidentID: "inc-002", At: now.Add(-70 * time.Minute), Kind: "note", Body: "Cache warmup reduces 500s, monitoring", Actor: map[string]any{"type": "user", "name": "taylor"}},
+		{ID: "inc-002-t1", IncidentID: "inc-002", At: now.Add(-110 * time.Minute), Kind: "note", Body: "Search cluster scaled up from 12 -> 16 nodes", Actor: mockutil.ActorRef("jamie")},
+		{ID: "inc-002-t2", IncidentID: "inc-002", At: now.Add(-70 * time.Minute), Kind: "note", Body: "Cache warmup reduces 500s, monitoring", Actor: mockutil.ActorRef("taylor")},
 	}
 
 	p.timeline["inc-003"] = []schema.TimelineEntry{
-		{ID: "inc-003-t1", IncidentID: "inc-003", At: now.Add(-3*time.Hour - 40*time.Minute), Kind: "note", Body: "Stripe webhook errors above 40% (HTTP 504) in us-east-1", Actor: map[string]any{"type": "system", "name": "pd-bot"}},
-		{ID: "inc-003-t2", IncidentID: "inc-003", At: now.Add(-3*time.Hour - 10*time.Minute), Kind: "note", Body: "Acknowledged by oncall, tracing requests through new ALB", Actor: map[string]any{"type": "user", "name": "sam"}},
-		{ID: "inc-003-t3", IncidentID: "inc-003", At: now.Add(-2*time.Hour - 20*time.Minute), Kind: "note", Body: "Shifted 30% traffic to standby workers and increased webhook timeout to 8s", Actor: map[string]any{"type": "user", "name": "sam"}},
-		{ID: "inc-003-t4", IncidentID: "inc-003", At: now.Add(-1 * time.Hour), Kind: "note", Body: "Stripe confirms transient network degradation resolved", Actor: map[string]any{"type": "user", "name": "partner-relations"}},
-		{ID: "inc-003-t5", IncidentID: "inc-003", At: now.Add(-20 * time.Minute), Kind: "note", Body: "Errors back to baseline, watching queues for 30m", Actor: map[string]any{"type": "user", "name": "sam"}},
+		{ID: "inc-003-t1", IncidentID: "inc-003", At: now.Add(-3*time.Hour - 40*time.Minute), Kind: "note", Body: "Stripe webhook errors above 40% (HTTP 504) in us-east-1", Actor: mockutil.ActorRef("pd-bot")},
+		{ID: "inc-003-t2", IncidentID: "inc-003", At: now.Add(-3*time.Hour - 10*time.Minute), Kind: "note", Body: "Acknowledged by oncall, tracing requests through new ALB", Actor: mockutil.ActorRef("sam")},
+		{ID: "inc-003-t3", IncidentID: "inc-003", At: now.Add(-2*time.Hour - 20*time.Minute), Kind: "note", Body: "Shifted 30% traffic to standby workers and increased webhook timeout to 8s", Actor: mockutil.ActorRef("sam")},
+		{ID: "inc-003-t4", IncidentID: "inc-003", At: now.Add(-1 * time.Hour), Kind: "note", Body: "Stripe confirms transient network degradation resolved", Actor: mockutil.ActorRef("partner-relations")},
+		{ID: "inc-003-t5", IncidentID: "inc-003", At: now.Add(-20 * time.Minute), Kind: "note", Body: "Errors back to baseline, watching queues for 30m", Actor: mockutil.ActorRef("sam")},
 	}
 
 	p.timeline["inc-004"] = []schema.TimelineEntry{
-		{ID: "inc-004-t1", IncidentID: "inc-004", At: now.Add(-90 * time.Minute), Kind: "note", Body: "Promo notification latency spiked above 6m", Actor: map[string]any{"type": "system", "name": "alertmanager"}},
-		{ID: "inc-004-t2", IncidentID: "inc-004", At: now.Add(-80 * time.Minute), Kind: "note", Body: "Kafka partitions imbalanced after promo re-shard; consumer lag rising", Actor: map[string]any{"type": "user", "name": "lee"}},
-		{ID: "inc-004-t3", IncidentID: "inc-004", At: now.Add(-55 * time.Minute), Kind: "note", Body: "Rerouted promo fanout to gcp-europe and throttled attachments", Actor: map[string]any{"type": "user", "name": "lee"}},
-		{ID: "inc-004-t4", IncidentID: "inc-004", At: now.Add(-35 * time.Minute), Kind: "note", Body: "Consumer lag trending down, announcement paused", Actor: map[string]any{"type": "user", "name": "taylor"}},
+		{ID: "inc-004-t1", IncidentID: "inc-004", At: now.Add(-90 * time.Minute), Kind: "note", Body: "Promo notification latency spiked above 6m", Actor: mockutil.ActorRef("alertmanager")},
+		{ID: "inc-004-t2", IncidentID: "inc-004", At: now.Add(-80 * time.Minute), Kind: "note", Body: "Kafka partitions imbalanced after promo re-shard; consumer lag rising", Actor: mockutil.ActorRef("lee")},
+		{ID: "inc-004-t3", IncidentID: "inc-004", At: now.Add(-55 * time.Minute), Kind: "note", Body: "Rerouted promo fanout to gcp-europe and throttled attachments", Actor: mockutil.ActorRef("lee")},
+		{ID: "inc-004-t4", IncidentID: "inc-004", At: now.Add(-35 * time.Minute), Kind: "note", Body: "Consumer lag trending down, announcement paused", Actor: mockutil.ActorRef("taylor")},
 	}
 
 	p.timeline["inc-005"] = []schema.TimelineEntry{
-		{ID: "inc-005-t1", IncidentID: "inc-005", At: now.Add(-4 * time.Hour), Kind: "note", Body: "p95 auth latency 1.1s for mobile sign-ins", Actor: map[string]any{"type": "system", "name": "apm"}},
-		{ID: "inc-005-t2", IncidentID: "inc-005", At: now.Add(-3*time.Hour - 45*time.Minute), Kind: "note", Body: "Rolled back mobile-auth service to 1.14.2", Actor: map[string]any{"type": "user", "name": "devon"}},
-		{ID: "inc-005-t3", IncidentID: "inc-005", At: now.Add(-2 * time.Hour), Kind: "note", Body: "Enabled per-region Redis pools to reduce contention", Actor: map[string]any{"type": "user", "name": "devon"}},
-		{ID: "inc-005-t4", IncidentID: "inc-005", At: now.Add(-50 * time.Minute), Kind: "note", Body: "Latency normalizing; keeping increased autoscale minimums", Actor: map[string]any{"type": "user", "name": "devon"}},
+		{ID: "inc-005-t1", IncidentID: "inc-005", At: now.Add(-4 * time.Hour), Kind: "note", Body: "p95 auth latency 1.1s for mobile sign-ins", Actor: mockutil.ActorRef("apm")},
+		{ID: "inc-005-t2", IncidentID: "inc-005", At: now.Add(-3*time.Hour - 45*time.Minute), Kind: "note", Body: "Rolled back mobile-auth service to 1.14.2", Actor: mockutil.ActorRef("devon")},
+		{ID: "inc-005-t3", IncidentID: "inc-005", At: now.Add(-2 * time.Hour), Kind: "note", Body: "Enabled per-region Redis pools to reduce contention", Actor: mockutil.ActorRef("devon")},
+		{ID: "inc-005-t4", IncidentID: "inc-005", At: now.Add(-50 * time.Minute), Kind: "note", Body: "Latency normalizing; keeping increased autoscale minimums", Actor: mockutil.ActorRef("devon")},
 	}
 
 	p.timeline["inc-006"] = []schema.TimelineEntry{
-		{ID: "inc-006-t1", IncidentID: "inc-006", At: now.Add(-7 * time.Hour), Kind: "note", Body: "Batch 2024-09-12-07 stuck at 57% due to lock on partition 7", Actor: map[string]any{"type": "system", "name": "scheduler"}},
-		{ID: "inc-006-t2", IncidentID: "inc-006", At: now.Add(-6*time.Hour - 45*time.Minute), Kind: "note", Body: "Restarted worker batch-wrk-02 without progress", Actor: map[string]any{"type": "user", "name": "morgan"}},
-		{ID: "inc-006-t3", IncidentID: "inc-006", At: now.Add(-6 * time.Hour), Kind: "note", Body: "Moved partition 7 to queue-b; verifying checkpoints", Actor: map[string]any{"type": "user", "name": "morgan"}},
-		{ID: "inc-006-t4", IncidentID: "inc-006", At: now.Add(-5 * time.Hour), Kind: "note", Body: "Scheduled backfill for missing segments post-unlock", Actor: map[string]any{"type": "user", "name": "data-eng"}},
+		{ID: "inc-006-t1", IncidentID: "inc-006", At: now.Add(-7 * time.Hour), Kind: "note", Body: "Batch 2024-09-12-07 stuck at 57% due to lock on partition 7", Actor: mockutil.ActorRef("scheduler")},
+		{ID: "inc-006-t2", IncidentID: "inc-006", At: now.Add(-6*time.Hour - 45*time.Minute), Kind: "note", Body: "Restarted worker batch-wrk-02 without progress", Actor: mockutil.ActorRef("morgan")},
+		{ID: "inc-006-t3", IncidentID: "inc-006", At: now.Add(-6 * time.Hour), Kind: "note", Body: "Moved partition 7 to queue-b; verifying checkpoints", Actor: mockutil.ActorRef("morgan")},
+		{ID: "inc-006-t4", IncidentID: "inc-006", At: now.Add(-5 * time.Hour), Kind: "note", Body: "Scheduled backfill for missing segments post-unlock", Actor: mockutil.ActorRef("data-eng")},
 	}
 
 	p.timeline["inc-007"] = []schema.TimelineEntry{
-		{ID: "inc-007-t1", IncidentID: "inc-007", At: now.Add(-25*time.Hour - 50*time.Minute), Kind: "note", Body: "CTR drop 18% after reco-v5 canary", Actor: map[string]any{"type": "system", "name": "metrics-bot"}},
-		{ID: "inc-007-t2", IncidentID: "inc-007", At: now.Add(-25 * time.Hour), Kind: "note", Body: "Rolled back to reco-v4 for US region", Actor: map[string]any{"type": "user", "name": "riley"}},
-		{ID: "inc-007-t3", IncidentID: "inc-007", At: now.Add(-22 * time.Hour), Kind: "note", Body: "Retrained feature store with refreshed catalog data", Actor: map[string]any{"type": "user", "name": "riley"}},
-		{ID: "inc-007-t4", IncidentID: "inc-007", At: now.Add(-3 * time.Hour), Kind: "note", Body: "Traffic steady; re-enabling 10% canary", Actor: map[string]any{"type": "user", "name": "riley"}},
+		{ID: "inc-007-t1", IncidentID: "inc-007", At: now.Add(-25*time.Hour - 50*time.Minute), Kind: "note", Body: "CTR drop 18% after reco-v5 canary", Actor: mockutil.ActorRef("metrics-bot")},
+		{ID: "inc-007-t2", IncidentID: "inc-007", At: now.Add(-25 * time.Hour), Kind: "note", Body: "Rolled back to reco-v4 for US region", Actor: mockutil.ActorRef("riley")},
+		{ID: "inc-007-t3", IncidentID: "inc-007", At: now.Add(-22 * time.Hour), Kind: "note", Body: "Retrained feature store with refreshed catalog data", Actor: mockutil.ActorRef("riley")},
+		{ID: "inc-007-t4", IncidentID: "inc-007", At: now.Add(-3 * time.Hour), Kind: "note", Body: "Traffic steady; re-enabling 10% canary", Actor: mockutil.ActorRef("riley")},
 	}
 
 	p.timeline["inc-008"] = []schema.TimelineEntry{
-		{ID: "inc-008-t1", IncidentID: "inc-008", At: now.Add(-7*time.Hour - 45*time.Minute), Kind: "note", Body: "APAC ingestion gap detected: 0 events in past 20m", Actor: map[string]any{"type": "system", "name": "spark-monitor"}},
-		{ID: "inc-008-t2", IncidentID: "inc-008", At: now.Add(-7 * time.Hour), Kind: "note", Body: "Spark job failing with expired OAuth token for storage bucket", Actor: map[string]any{"type": "user", "name": "maya"}},
-		{ID: "inc-008-t3", IncidentID: "inc-008", At: now.Add(-5 * time.Hour), Kind: "note", Body: "Rotated service account and replayed backlog from sequence 220", Actor: map[string]any{"type": "user", "name": "maya"}},
-		{ID: "inc-008-t4", IncidentID: "inc-008", At: now.Add(-2 * time.Hour), Kind: "note", Body: "Repartitioned APAC shards to 6 executors; latency stable", Actor: map[string]any{"type": "user", "name": "maya"}},
+		{ID: "inc-008-t1", IncidentID: "inc-008", At: now.Add(-7*time.Hour - 45*time.Minute), Kind: "note", Body: "APAC ingestion gap detected: 0 events in past 20m", Actor: mockutil.ActorRef("spark-monitor")},
+		{ID: "inc-008-t2", IncidentID: "inc-008", At: now.Add(-7 * time.Hour), Kind: "note", Body: "Spark job failing with expired OAuth token for storage bucket", Actor: mockutil.ActorRef("maya")},
+		{ID: "inc-008-t3", IncidentID: "inc-008", At: now.Add(-5 * time.Hour), Kind: "note", Body: "Rotated service account and replayed backlog from sequence 220", Actor: mockutil.ActorRef("maya")},
+		{ID: "inc-008-t4", IncidentID: "inc-008", At: now.Add(-2 * time.Hour), Kind: "note", Body: "Repartitioned APAC shards to 6 executors; latency stable", Actor: mockutil.ActorRef("maya")},
 	}
 
 	p.timeline["inc-009"] = []schema.TimelineEntry{
-		{ID: "inc-009-t1", IncidentID: "inc-009", At: now.Add(-2*time.Hour - 50*time.Minute), Kind: "note", Body: "Order prepaid auth failures exceeded 3% of traffic", Actor: map[string]any{"type": "system", "name": "ops-alerts"}},
-		{ID: "inc-009-t2", IncidentID: "inc-009", At: now.Add(-2 * time.Hour), Kind: "note", Body: "Gateway rejecting prepaid BIN range 5523", Actor: map[string]any{"type": "user", "name": "kim"}},
-		{ID: "inc-009-t3", IncidentID: "inc-009", At: now.Add(-90 * time.Minute), Kind: "note", Body: "Added fallback provider for prepaid and draining queue", Actor: map[string]any{"type": "user", "name": "kim"}},
-		{ID: "inc-009-t4", IncidentID: "inc-009", At: now.Add(-60 * time.Minute), Kind: "note", Body: "QA validating affected orders in sandbox", Actor: map[string]any{"type": "user", "name": "jordan"}},
+		{ID: "inc-009-t1", IncidentID: "inc-009", At: now.Add(-2*time.Hour - 50*time.Minute), Kind: "note", Body: "Order prepaid auth failures exceeded 3% of traffic", Actor: mockutil.ActorRef("ops-alerts")},
+		{ID: "inc-009-t2", IncidentID: "inc-009", At: now.Add(-2 * time.Hour), Kind: "note", Body: "Gateway rejecting prepaid BIN range 5523", Actor: mockutil.ActorRef("kim")},
+		{ID: "inc-009-t3", IncidentID: "inc-009", At: now.Add(-90 * time.Minute), Kind: "note", Body: "Added fallback provider for prepaid and draining queue", Actor: mockutil.ActorRef("kim")},
+		{ID: "inc-009-t4", IncidentID: "inc-009", At: now.Add(-60 * time.Minute), Kind: "note", Body: "QA validating affected orders in sandbox", Actor: mockutil.ActorRef("jordan")},
 	}
 
 	p.timeline["inc-010"] = []schema.TimelineEntry{
-		{ID: "inc-010-t1", IncidentID: "inc-010", At: now.Add(-11*time.Hour - 50*time.Minute), Kind: "note", Body: "Indexer backlog climbed to 450k items after schema deploy", Actor: map[string]any{"type": "system", "name": "indexer"}},
-		{ID: "inc-010-t2", IncidentID: "inc-010", At: now.Add(-10 * time.Hour), Kind: "note", Body: "Schema change introduced null category for legacy SKUs", Actor: map[string]any{"type": "user", "name": "casey"}},
-		{ID: "inc-010-t3", IncidentID: "inc-010", At: now.Add(-6 * time.Hour), Kind: "note", Body: "Added secondary shard and requeued failed jobs", Actor: map[string]any{"type": "user", "name": "casey"}},
-		{ID: "inc-010-t4", IncidentID: "inc-010", At: now.Add(-4 * time.Hour), Kind: "note", Body: "Backlog clearing at 40k/min, ETA 90m", Actor: map[string]any{"type": "user", "name": "casey"}},
+		{ID: "inc-010-t1", IncidentID: "inc-010", At: now.Add(-11*time.Hour - 50*time.Minute), Kind: "note", Body: "Indexer backlog climbed to 450k items after schema deploy", Actor: mockutil.ActorRef("indexer")},
+		{ID: "inc-010-t2", IncidentID: "inc-010", At: now.Add(-10 * time.Hour), Kind: "note", Body: "Schema change introduced null category for legacy SKUs", Actor: mockutil.ActorRef("casey")},
+		{ID: "inc-010-t3", IncidentID: "inc-010", At: now.Add(-6 * time.Hour), Kind: "note", Body: "Added secondary shard and requeued failed jobs", Actor: mockutil.ActorRef("casey")},
+		{ID: "inc-010-t4", IncidentID: "inc-010", At: now.Add(-4 * time.Hour), Kind: "note", Body: "Backlog clearing at 40k/min, ETA 90m", Actor: mockutil.ActorRef("casey")},
 	}
 
 	p.timeline["inc-011"] = []schema.TimelineEntry{
-		{ID: "inc-011-t1", IncidentID: "inc-011", At: now.Add(-17*time.Hour - 50*time.Minute), Kind: "note", Body: "Shipment ETA endpoints serving stale cache (>2h)", Actor: map[string]any{"type": "system", "name": "status-bot"}},
-		{ID: "inc-011-t2", IncidentID: "inc-011", At: now.Add(-16 * time.Hour), Kind: "note", Body: "Paused CDN cache invalidations to stop thrash", Actor: map[string]any{"type": "user", "name": "alexis"}},
-		{ID: "inc-011-t3", IncidentID: "inc-011", At: now.Add(-2 * time.Hour), Kind: "note", Body: "Hotfix to shorten cache TTL for status lookups", Actor: map[string]any{"type": "user", "name": "alexis"}},
-		{ID: "inc-011-t4", IncidentID: "inc-011", At: now.Add(-30 * time.Minute), Kind: "note", Body: "Customer care confirms fresh ETAs; keeping monitors elevated", Actor: map[string]any{"type": "user", "name": "alexis"}},
+		{ID: "inc-011-t1", IncidentID: "inc-011", At: now.Add(-17*time.Hour - 50*time.Minute), Kind: "note", Body: "Shipment ETA endpoints serving stale cache (>2h)", Actor: mockutil.ActorRef("status-bot")},
+		{ID: "inc-011-t2", IncidentID: "inc-011", At: now.Add(-16 * time.Hour), Kind: "note", Body: "Paused CDN cache invalidations to stop thrash", Actor: mockutil.ActorRef("alexis")},
+		{ID: "inc-011-t3", IncidentID: "inc-011", At: now.Add(-2 * time.Hour), Kind: "note", Body: "Hotfix to shorten cache TTL for status lookups", Actor: mockutil.ActorRef("alexis")},
+		{ID: "inc-011-t4", IncidentID: "inc-011", At: now.Add(-30 * time.Minute), Kind: "note", Body: "Customer care confirms fresh ETAs; keeping monitors elevated", Actor: mockutil.ActorRef("alexis")},
 	}
 
 	p.timeline["inc-012"] = []schema.TimelineEntry{
-		{ID: "inc-012-t1", IncidentID: "inc-012", At: now.Add(-2*time.Hour - 10*time.Minute), Kind: "note", Body: "Firefox clients disconnect after 45s with websocket close 1006", Actor: map[string]any{"type": "system", "name": "browser-watch"}},
-		{ID: "inc-012-t2", IncidentID: "inc-012", At: now.Add(-100 * time.Minute), Kind: "note", Body: "Disabled permessage-deflate for Firefox user agent", Actor: map[string]any{"type": "user", "name": "samir"}},
-		{ID: "inc-012-t3", IncidentID: "inc-012", At: now.Add(-40 * time.Minute), Kind: "note", Body: "Added 25s keepalive ping to websocket gateway", Actor: map[string]any{"type": "user", "name": "samir"}},
-		{ID: "inc-012-t4", IncidentID: "inc-012", At: now.Add(-15 * time.Minute), Kind: "note", Body: "User retry reports stable connections; preparing hotfix release", Actor: map[string]any{"type": "user", "name": "samir"}},
+		{ID: "inc-012-t1", IncidentID: "inc-012", At: now.Add(-2*time.Hour - 10*time.Minute), Kind: "note", Body: "Firefox clients disconnect after 45s with websocket close 1006", Actor: mockutil.ActorRef("browser-watch")},
+		{ID: "inc-012-t2", IncidentID: "inc-012", At: now.Add(-100 * time.Minute), Kind: "note", Body: "Disabled permessage-deflate for Firefox user agent", Actor: mockutil.ActorRef("samir")},
+		{ID: "inc-012-t3", IncidentID: "inc-012", At: now.Add(-40 * time.Minute), Kind: "note", Body: "Added 25s keepalive ping to websocket gateway", Actor: mockutil.ActorRef("samir")},
+		{ID: "inc-012-t4", IncidentID: "inc-012", At: now.Add(-15 * time.Minute), Kind: "note", Body: "User retry reports stable connections; preparing hotfix release", Actor: mockutil.ActorRef("samir")},
 	}
 
 	// Scenario incident timelines
 	p.timeline["inc-scenario-001"] = []schema.TimelineEntry{
-		{ID: "inc-scenario-001-t1", IncidentID: "inc-scenario-001", At: now.Add(-45 * time.Minute), Kind: "note", Body: "Incident detected: SLO Budget Exhaustion", Actor: map[string]any{"type": "system", "name": "alertmanager"}},
-		{ID: "inc-scenario-001-t2", IncidentID: "inc-scenario-001", At: now.Add(-40 * time.Minute), Kind: "note", Body: "Investigation started by alex", Actor: map[string]any{"type": "user", "name": "alex"}},
-		{ID: "inc-scenario-001-t3", IncidentID: "inc-scenario-001", At: now.Add(-30 * time.Minute), Kind: "note", Body: "Mitigation actions in progress", Actor: map[string]any{"type": "user", "name": "alex"}},
-		{ID: "inc-scenario-001-t4", IncidentID: "inc-scenario-001", At: now.Add(-10 * time.Minute), Kind: "note", Body: "Scaled up checkout service instances from 12 to 24", Actor: map[string]any{"type": "user", "name": "alex"}},
+		{ID: "inc-scenario-001-t1", IncidentID: "inc-scenario-001", At: now.Add(-45 * time.Minute), Kind: "note", Body: "Incident detected: SLO Budget Exhaustion", Actor: mockutil.ActorRef("alertmanager")},
+		{ID: "inc-scenario-001-t2", IncidentID: "inc-scenario-001", At: now.Add(-40 * time.Minute), Kind: "note", Body: "Investigation started by alex", Actor: mockutil.ActorRef("alex")},
+		{ID: "inc-scenario-001-t3", IncidentID: "inc-scenario-001", At: now.Add(-30 * time.Minute), Kind: "note", Body: "Mitigation actions in progress", Actor: mockutil.ActorRef("alex")},
+		{ID: "inc-scenario-001-t4", IncidentID: "inc-scenario-001", At: now.Add(-10 * time.Minute), Kind: "note", Body: "Scaled up checkout service instances from 12 to 24", Actor: mockutil.ActorRef("alex")},
 	}
 
 	p.timeline["inc-scenario-002"] = []schema.TimelineEntry{
-		{ID: "inc-scenario-002-t1", IncidentID: "inc-scenario-002", At: now.Add(-30 * time.Minute), Kind: "note", Body: "Incident detected: Cascading Failure", Actor: map[string]any{"type": "system", "name": "alertmanager"}},
-		{ID: "inc-scenario-002-t2", IncidentID: "inc-scenario-002", At: now.Add(-25 * time.Minute), Kind: "note", Body: "Investigation started by morgan", Actor: map[string]any{"type": "user", "name": "morgan"}},
-		{ID: "inc-scenario-002-t3", IncidentID: "inc-scenario-002", At: now.Add(-15 * time.Minute), Kind: "note", Body: "Identified connection leak in checkout service", Actor: map[string]any{"type": "user", "name": "morgan"}},
-		{ID: "inc-scenario-002-t4", IncidentID: "inc-scenario-002", At: now.Add(-5 * time.Minute), Kind: "note", Body: "Restarted checkout service pods to release connections", Actor: map[string]any{"type": "user", "name": "morgan"}},
+		{ID: "inc-scenario-002-t1", IncidentID: "inc-scenario-002", At: now.Add(-30 * time.Minute), Kind: "note", Body: "Incident detected: Cascading Failure", Actor: mockutil.ActorRef("alertmanager")},
+		{ID: "inc-scenario-002-t2", IncidentID: "inc-scenario-002", At: now.Add(-25 * time.Minute), Kind: "note", Body: "Investigation started by morgan", Actor: mockutil.ActorRef("morgan")},
+		{ID: "inc-scenario-002-t3", IncidentID: "inc-scenario-002", At: now.Add(-15 * time.Minute), Kind: "note", Body: "Identified connection leak in checkout service", Actor: mockutil.ActorRef("morgan")},
+		{ID: "inc-scenario-002-t4", IncidentID: "inc-scenario-002", At: now.Add(-5 * time.Minute), Kind: "note", Body: "Restarted checkout service pods to release connections", Actor: mockutil.ActorRef("morgan")},
 	}
 
 	p.timeline["inc-scenario-003"] = []schema.TimelineEntry{
-		{ID: "inc-scenario-003-t1", IncidentID: "inc-scenario-003", At: now.Add(-90 * time.Minute), Kind: "note", Body: "Incident detected: Deployment Rollback", Actor: map[string]any{"type": "system", "name": "alertmanager"}},
-		{ID: "inc-scenario-003-t2", IncidentID: "inc-scenario-003", At: now.Add(-85 * time.Minute), Kind: "note", Body: "Investigation started by sam", Actor: map[string]any{"type": "user", "name": "sam"}},
-		{ID: "inc-scenario-003-t3", IncidentID: "inc-scenario-003", At: now.Add(-75 * time.Minute), Kind: "note", Body: "Mitigation actions in progress", Actor: map[string]any{"type": "user", "name": "sam"}},
-		{ID: "inc-scenario-003-t4", IncidentID: "inc-scenario-003", At: now.Add(-60 * time.Minute), Kind: "note", Body: "Rolled back payment service from v2.8.3 to v2.8.2", Actor: map[string]any{"type": "user", "name": "sam"}},
-		{ID: "inc-scenario-003-t5", IncidentID: "inc-scenario-003", At: now.Add(-20 * time.Minute), Kind: "note", Body: "Mitigation applied, monitoring for stability", Actor: map[string]any{"type": "user", "name": "sam"}},
+		{ID: "inc-scenario-003-t1", IncidentID: "inc-scenario-003", At: now.Add(-90 * time.Minute), Kind: "note", Body: "Incident detected: Deployment Rollback", Actor: mockutil.ActorRef("alertmanager")},
+		{ID: "inc-scenario-003-t2", IncidentID: "inc-scenario-003", At: now.Add(-85 * time.Minute), Kind: "note", Body: "Investigation started by sam", Actor: mockutil.ActorRef("sam")},
+		{ID: "inc-scenario-003-t3", IncidentID: "inc-scenario-003", At: now.Add(-75 * time.Minute), Kind: "note", Body: "Mitigation actions in progress", Actor: mockutil.ActorRef("sam")},
+		{ID: "inc-scenario-003-t4", IncidentID: "inc-scenario-003", At: now.Add(-60 * time.Minute), Kind: "note", Body: "Rolled back payment service from v2.8.3 to v2.8.2", Actor: mockutil.ActorRef("sam")},
+		{ID: "inc-scenario-003-t5", IncidentID: "inc-scenario-003", At: now.Add(-20 * time.Minute), Kind: "note", Body: "Mitigation applied, monitoring for stability", Actor: mockutil.ActorRef("sam")},
 	}
 
 	p.timeline["inc-scenario-004"] = []schema.TimelineEntry{
-		{ID: "inc-scenario-004-t1", IncidentID: "inc-scenario-004", At: now.Add(-15 * time.Minute), Kind: "note", Body: "Incident detected: External Dependency Failure", Actor: map[string]any{"type": "system", "name": "alertmanager"}},
-		{ID: "inc-scenario-004-t2", IncidentID: "inc-scenario-004", At: now.Add(-12 * time.Minute), Kind: "note", Body: "Investigation started by fern", Actor: map[string]any{"type": "user", "name": "fern"}},
-		{ID: "inc-scenario-004-t3", IncidentID: "inc-scenario-004", At: now.Add(-8 * time.Minute), Kind: "note", Body: "Confirmed Stripe API rate limiting affecting checkout", Actor: map[string]any{"type": "user", "name": "fern"}},
-		{ID: "inc-scenario-004-t4", IncidentID: "inc-scenario-004", At: now.Add(-5 * time.Minute), Kind: "note", Body: "Enabled circuit breaker for Stripe API calls", Actor: map[string]any{"type": "user", "name": "fern"}},
+		{ID: "inc-scenario-004-t1", IncidentID: "inc-scenario-004", At: now.Add(-15 * time.Minute), Kind: "note", Body: "Incident detected: External Dependency Failure", Actor: mockutil.ActorRef("alertmanager")},
+		{ID: "inc-scenario-004-t2", IncidentID: "inc-scenario-004", At: now.Add(-12 * time.Minute), Kind: "note", Body: "Investigation started by fern", Actor: mockutil.ActorRef("fern")},
+		{ID: "inc-scenario-004-t3", IncidentID: "inc-scenario-004", At: now.Add(-8 * time.Minute), Kind: "note", Body: "Confirmed Stripe API rate limiting affecting checkout", Actor: mockutil.ActorRef("fern")},
+		{ID: "inc-scenario-004-t4", IncidentID: "inc-scenario-004", At: now.Add(-5 * time.Minute), Kind: "note", Body: "Enabled circuit breaker for Stripe API calls", Actor: mockutil.ActorRef("fern")},
 	}
 
 	p.timeline["inc-scenario-005"] = []schema.TimelineEntry{
-		{ID: "inc-scenario-005-t1", IncidentID: "inc-scenario-005", At: now.Add(-12 * time.Minute), Kind: "note", Body: "Incident detected: Autoscaling Lag", Actor: map[string]any{"type": "system", "name": "alertmanager"}},
-		{ID: "inc-scenario-005-t2", IncidentID: "inc-scenario-005", At: now.Add(-10 * time.Minute), Kind: "note", Body: "Investigation started by lena", Actor: map[string]any{"type": "user", "name": "lena"}},
-		{ID: "inc-scenario-005-t3", IncidentID: "inc-scenario-005", At: now.Add(-6 * time.Minute), Kind: "note", Body: "Identified traffic spike from marketing campaign", Actor: map[string]any{"type": "user", "name": "lena"}},
-		{ID: "inc-scenario-005-t4", IncidentID: "inc-scenario-005", At: now.Add(-3 * time.Minute), Kind: "note", Body: "Manually scaled search service from 3 to 8 replicas", Actor: map[string]any{"type": "user", "name": "lena"}},
+		{ID: "inc-scenario-005-t1", IncidentID: "inc-scenario-005", At: now.Add(-12 * time.Minute), Kind: "note", Body: "Incident detected: Autoscaling Lag", Actor: mockutil.ActorRef("alertmanager")},
+		{ID: "inc-scenario-005-t2", IncidentID: "inc-scenario-005", At: now.Add(-10 * time.Minute), Kind: "note", Body: "Investigation started by lena", Actor: mockutil.ActorRef("lena")},
+		{ID: "inc-scenario-005-t3", IncidentID: "inc-scenario-005", At: now.Add(-6 * time.Minute), Kind: "note", Body: "Identified traffic spike from marketing campaign", Actor: mockutil.ActorRef("lena")},
+		{ID: "inc-scenario-005-t4", IncidentID: "inc-scenario-005", At: now.Add(-3 * time.Minute), Kind: "note", Body: "Manually scaled search service from 3 to 8 replicas", Actor: mockutil.ActorRef("lena")},
 	}
 
 	p.timeline["inc-scenario-006"] = []schema.TimelineEntry{
-		{ID: "inc-scenario-006-t1", IncidentID: "inc-scenario-006", At: now.Add(-8 * time.Minute), Kind: "note", Body: "Incident detected: Circuit Breaker Cascade", Actor: map[string]any{"type": "system", "name": "alertmanager"}},
-		{ID: "inc-scenario-006-t2", IncidentID: "inc-scenario-006", At: now.Add(-6 * time.Minute), Kind: "note", Body: "Investigation started by milo", Actor: map[string]any{"type": "user", "name": "milo"}},
-		{ID: "inc-scenario-006-t3", IncidentID: "inc-scenario-006", At: now.Add(-4 * time.Minute), Kind: "note", Body: "Identified recommendation model inference timeout", Actor: map[string]any{"type": "user", "name": "milo"}},
-		{ID: "inc-scenario-006-t4", IncidentID: "inc-scenario-006", At: now.Add(-2 * time.Minute), Kind: "note", Body: "Restarting recommendation service pods", Actor: map[string]any{"type": "user", "name": "milo"}},
+		{ID: "inc-scenario-006-t1", IncidentID: "inc-scenario-006", At: now.Add(-8 * time.Minute), Kind: "note", Body: "Incident detected: Circuit Breaker Cascade", Actor: mockutil.ActorRef("alertmanager")},
+		{ID: "inc-scenario-006-t2", IncidentID: "inc-scenario-006", At: now.Add(-6 * time.Minute), Kind: "note", Body: "Investigation started by milo", Actor: mockutil.ActorRef("milo")},
+		{ID: "inc-scenario-006-t3", IncidentID: "inc-scenario-006", At: now.Add(-4 * time.Minute), Kind: "note", Body: "Identified recommendation model inference timeout", Actor: mockutil.ActorRef("milo")},
+		{ID: "inc-scenario-006-t4", IncidentID: "inc-scenario-006", At: now.Add(-2 * time.Minute), Kind: "note", Body: "Restarting recommendation service pods", Actor: mockutil.ActorRef("milo")},
+	}
+
+	for id, extra := range richScenarioTimelines(now) {
+		p.timeline[id] = mergeTimeline(id, p.timeline[id], extra)
 	}
 
 	// Add analytics incident
@@ -792,7 +957,7 @@ func (p *Provider) seed() {
 			Kind:       "trigger",
 			Body:       "Incident triggered by alert 'Analytics Correlation Lag'",
 			At:         now.Add(-20 * time.Minute),
-			Actor:      map[string]any{"name": "alertmanager", "type": "system"},
+			Actor:      mockutil.ActorRef("alertmanager"),
 		},
 	}
 
@@ -825,19 +990,166 @@ func (p *Provider) seed() {
 			Kind:       "trigger",
 			Body:       "Incident triggered by alert 'Payment Service Latency'",
 			At:         now.Add(-10 * time.Minute),
-			Actor:      map[string]any{"name": "alertmanager", "type": "system"},
+			Actor:      mockutil.ActorRef("alertmanager"),
 		},
 	}
+
+	for id, inc := range p.incidents {
+		mockutil.LinkRefs(inc.Metadata, inc.Fields)
+		if tags, ok := seededTags[id]; ok && inc.Fields != nil {
+			inc.Fields[tagsField] = append([]string(nil), tags...)
+		}
+		stampCorrelation(&inc)
+		if p.cfg.Features.Flair && !resolvedStatuses[inc.Status] {
+			attachBridge(&inc)
+		}
+		if p.cfg.Locale != "" {
+			inc.Title = mockutil.Localize(p.cfg.Locale, inc.Title)
+			inc.Description = mockutil.Localize(p.cfg.Locale, inc.Description)
+		}
+		p.incidents[id] = inc
+	}
+
+	p.seedParticipants(now)
+	if !p.cfg.Features.ScenarioEffects {
+		// Timelines and participants are seeded by ID; drop those of the
+		// scenario incidents left out above.
+		for id := range p.timeline {
+			if _, ok := p.incidents[id]; !ok {
+				delete(p.timeline, id)
+			}
+		}
+		for id := range p.participants {
+			if _, ok := p.incidents[id]; !ok {
+				delete(p.participants, id)
+				delete(p.handoffs, id)
+			}
+		}
+	}
+	seeded := make([]string, 0, len(p.incidents))
+	for id := range p.incidents {
+		seeded = append(seeded, id)
+	}
+	p.alignSeedTimes(seeded)
+}
+
+// stampCorrelation sets Fields["correlation_id"] from the incident's scenario,
+// or from what it was opened for, falling back to one of its own.
+func stampCorrelation(inc *schema.Incident) {
+	id := mockutil.CorrelationFor(inc.Fields, inc.Metadata)
+	if id == "" {
+		id = mockutil.IncidentCorrelationID(inc.ID)
+	}
+	if inc.Fields == nil {
+		inc.Fields = map[string]any{}
+	}
+	inc.Fields[mockutil.CorrelationKey] = id
+}
+
+// seedHistoryLocked adds the resolved-incident history and returns how many
+// incidents it added. Callers must hold p.mu once the provider is shared.
+func (p *Provider) seedHistoryLocked() int {
+	history, timelines := historicalIncidents(p.cfg.Source, p.seededAt)
+	ids := make([]string, 0, len(history))
+	for _, inc := range history {
+		if p.cfg.Locale != "" {
+			inc.Title = mockutil.Localize(p.cfg.Locale, inc.Title)
+			inc.Description = mockutil.Localize(p.cfg.Locale, inc.Description)
+		}
+		p.incidents[inc.ID] = inc
+		p.timeline[inc.ID] = timelines[inc.ID]
+		ids = append(ids, inc.ID)
+	}
+	p.alignSeedTimes(ids)
+	return len(history)
+}
+
+// incidentLocked looks up an incident, generating the history first when id
+// is not among the eagerly seeded incidents. Callers must hold p.mu.
+func (p *Provider) incidentLocked(id string) (schema.Incident, bool) {
+	inc, ok := p.incidents[id]
+	if !ok {
+		p.history.Ensure()
+		inc, ok = p.incidents[id]
+	}
+	return inc, ok
+}
+
+// alignSeedTimes shifts the given non-scenario seeded incidents (and their
+// timelines) so they open during business hours in the configured timezone.
+// Scenario incidents keep their relative "happening now" timing, and
+// incidents created since seeding are never passed in.
+func (p *Provider) alignSeedTimes(ids []string) {
+	if p.cfg.Location == nil {
+		return
+	}
+	for _, id := range ids {
+		inc, ok := p.incidents[id]
+		if !ok || isScenarioIncident(inc.Metadata, inc.Fields) {
+			continue
+		}
+		shift := mockutil.AlignToBusinessHours(inc.CreatedAt, p.cfg.Location).Sub(inc.CreatedAt)
+		if shift == 0 {
+			continue
+		}
+		inc.CreatedAt = inc.CreatedAt.Add(shift)
+		inc.UpdatedAt = inc.UpdatedAt.Add(shift)
+		for _, key := range []string{"acknowledgedAt", "resolvedAt", "postmortemPublishedAt"} {
+			if v, ok := inc.Fields[key].(string); ok {
+				if t, err := time.Parse(time.RFC3339, v); err == nil {
+					inc.Fields[key] = t.Add(shift).Format(time.RFC3339)
+				}
+			}
+		}
+		p.incidents[id] = inc
+		for i := range p.timeline[id] {
+			p.timeline[id][i].At = p.timeline[id][i].At.Add(shift)
+		}
+	}
+}
+
+// now returns the provider's current time, used for SLA clocks.
+func (p *Provider) now() time.Time {
+	if p.clock != nil {
+		return p.clock()
+	}
+	return time.Now().UTC()
 }
 
 func parseConfig(cfg map[string]any) Config {
-	out := Config{Source: "mock", DefaultSeverity: "sev2"}
+	out := Config{Source: "mock", EscalationRules: defaultEscalationRules}
 	if v, ok := cfg["source"].(string); ok && v != "" {
 		out.Source = v
 	}
 	if v, ok := cfg["defaultSeverity"].(string); ok && v != "" {
 		out.DefaultSeverity = v
 	}
+	out.Location = mockutil.ParseLocation(cfg)
+	out.Severity = parseSeverityScheme(cfg)
+	// An empty list turns escalation off.
+	if v, ok := cfg["escalationRules"].([]any); ok {
+		out.EscalationRules = parseEscalationRules(v)
+	}
+	// Configured severities may use the scheme's labels; anything outside the
+	// scheme falls back to the defaults or drops the rule.
+	if sev, ok := out.Severity.toCanonical(out.DefaultSeverity); ok {
+		out.DefaultSeverity = sev
+	} else {
+		out.DefaultSeverity = ""
+	}
+	rules := out.EscalationRules[:0:0]
+	for _, rule := range out.EscalationRules {
+		from, okFrom := out.Severity.toCanonical(rule.From)
+		to, okTo := out.Severity.toCanonical(rule.To)
+		if okFrom && okTo {
+			rule.From, rule.To = from, to
+			rules = append(rules, rule)
+		}
+	}
+	out.EscalationRules = rules
+	out.Warmup = mockutil.ParseWarmup(cfg)
+	out.Locale = mockutil.ParseLocale(cfg)
+	out.Features = mockutil.ParseFeatures(cfg)
 	return out
 }
 
@@ -988,3 +1300,25 @@ func matchesScope(scope schema.QueryScope, inc schema.Incident) bool {
 
 	return true
 }
+
+// SearchDocs indexes incident titles, descriptions, and timeline bodies for
+// global search, including the generated history.
+func (p *Provider) SearchDocs(ctx context.Context) []mockutil.SearchDoc {
+	_ = ctx
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.history.Ensure()
+	docs := make([]mockutil.SearchDoc, 0, len(p.incidents))
+	for _, inc := range p.incidents {
+		body := []string{inc.Description}
+		for _, entry := range p.timeline[inc.ID] {
+			body = append(body, entry.Body)
+		}
+		docs = append(docs, mockutil.SearchDoc{
+			Kind: mockutil.RefIncident, ID: inc.ID, Title: inc.Title, Body: strings.Join(body, "\n"),
+			Service: inc.Service, Status: inc.Status, UpdatedAt: inc.UpdatedAt,
+		})
+	}
+	return docs
+}
@@ -2,11 +2,16 @@ package incidentmock
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/opsorch/opsorch-core/schema"
+	"github.com/opsorch/opsorch-mock-adapters/internal/mockutil"
+	"github.com/opsorch/opsorch-mock-adapters/internal/pluginrpc"
 )
 
 func TestListAndGetSeededIncidents(t *testing.T) {
@@ -116,6 +121,65 @@ func TestCreateUpdateAndTimeline(t *testing.T) {
 	}
 }
 
+func TestRichTimelineEntries(t *testing.T) {
+	provAny, _ := New(nil)
+	prov := provAny.(*Provider)
+	ctx := context.Background()
+
+	kinds := map[string]int{}
+	for i := 1; i <= 6; i++ {
+		id := fmt.Sprintf("inc-scenario-%03d", i)
+		timeline, err := prov.GetTimeline(ctx, id)
+		if err != nil {
+			t.Fatalf("GetTimeline(%s) returned error: %v", id, err)
+		}
+		for j, entry := range timeline {
+			kinds[entry.Kind]++
+			if entry.ID != fmt.Sprintf("%s-t%d", id, j+1) {
+				t.Errorf("entry %d of %s has ID %s", j, id, entry.ID)
+			}
+			if j > 0 && entry.At.Before(timeline[j-1].At) {
+				t.Errorf("%s timeline out of order at %s", id, entry.ID)
+			}
+			if err := validateTimelineEntry(schema.TimelineAppendInput{Kind: entry.Kind, Metadata: entry.Metadata}); err != nil {
+				t.Errorf("seeded entry %s is invalid: %v", entry.ID, err)
+			}
+			if entry.Kind == TimelineKindChart {
+				attachment := entry.Metadata["attachment"].(map[string]any)
+				if data, ok := attachment["data"].(string); ok {
+					raw, _ := base64.StdEncoding.DecodeString(data)
+					if !strings.HasPrefix(string(raw), "<svg") {
+						t.Errorf("chart %s data is not an SVG: %q", entry.ID, raw)
+					}
+				}
+			}
+		}
+	}
+	for _, kind := range []string{TimelineKindStatusChange, TimelineKindMetricSnapshot, TimelineKindChart, TimelineKindCommandOutput} {
+		if kinds[kind] == 0 {
+			t.Errorf("expected seeded %s entries, got kinds %v", kind, kinds)
+		}
+	}
+
+	valid := schema.TimelineAppendInput{Kind: TimelineKindChart, Body: "latency", Metadata: map[string]any{
+		"attachment": map[string]any{"contentType": "image/png", "url": "https://grafana.demo.com/render/x"},
+	}}
+	if err := prov.AppendTimeline(ctx, "inc-001", valid); err != nil {
+		t.Fatalf("AppendTimeline chart returned error: %v", err)
+	}
+	invalid := []schema.TimelineAppendInput{
+		{Kind: TimelineKindStatusChange, Metadata: map[string]any{"from": "triggered"}},
+		{Kind: TimelineKindMetricSnapshot, Metadata: map[string]any{"metric": "cpu", "value": "high"}},
+		{Kind: TimelineKindChart, Metadata: map[string]any{"attachment": map[string]any{"data": "not base64!"}}},
+		{Kind: TimelineKindCommandOutput, Metadata: map[string]any{"command": "uptime"}},
+	}
+	for _, entry := range invalid {
+		if err := prov.AppendTimeline(ctx, "inc-001", entry); err == nil || !strings.Contains(err.Error(), "bad_request") {
+			t.Errorf("expected bad_request for %s entry %v, got %v", entry.Kind, entry.Metadata, err)
+		}
+	}
+}
+
 func TestCloningProtectsState(t *testing.T) {
 	provAny, err := New(map[string]any{})
 	if err != nil {
@@ -421,3 +485,1044 @@ func TestIncidentURLGeneration(t *testing.T) {
 		}
 	}
 }
+
+func TestSLAFieldsAndBreachedFilter(t *testing.T) {
+	provAny, err := New(map[string]any{})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	prov := provAny.(*Provider)
+
+	created, err := prov.Create(context.Background(), schema.CreateIncidentInput{Title: "Fresh sev1", Severity: "sev1", Service: "svc-api"})
+	if err != nil {
+		t.Fatalf("Create returned error: %v", err)
+	}
+	if created.Fields["slaBreached"] != false {
+		t.Fatalf("expected new incident within SLA, got %v", created.Fields["slaBreached"])
+	}
+	if _, ok := created.Fields["timeToAck"]; ok {
+		t.Fatalf("expected no timeToAck on unacknowledged incident")
+	}
+
+	// Advance the clock past the sev1 ack target without acknowledging.
+	prov.clock = func() time.Time { return time.Now().UTC().Add(10 * time.Minute) }
+	got, _ := prov.Get(context.Background(), created.ID)
+	if got.Fields["slaBreached"] != true {
+		t.Fatalf("expected sev1 incident to breach ack SLA, got %v", got.Fields["slaBreached"])
+	}
+	sla, ok := got.Fields["sla"].(map[string]any)
+	if !ok || sla["ackBreached"] != true || sla["ackTarget"] != "5m0s" {
+		t.Fatalf("unexpected sla payload: %v", got.Fields["sla"])
+	}
+
+	status := "investigating"
+	updated, err := prov.Update(context.Background(), created.ID, schema.UpdateIncidentInput{Status: &status})
+	if err != nil {
+		t.Fatalf("Update returned error: %v", err)
+	}
+	if _, ok := updated.Fields["timeToAck"]; !ok {
+		t.Fatalf("expected timeToAck once acknowledged, got %v", updated.Fields)
+	}
+
+	breached, err := prov.Query(WithBreachedOnly(context.Background()), schema.IncidentQuery{})
+	if err != nil {
+		t.Fatalf("Query returned error: %v", err)
+	}
+	all, _ := prov.Query(context.Background(), schema.IncidentQuery{})
+	if len(breached) == 0 || len(breached) >= len(all) {
+		t.Fatalf("expected breachedOnly to return a strict subset, got %d of %d", len(breached), len(all))
+	}
+	for _, inc := range breached {
+		if inc.Fields["slaBreached"] != true {
+			t.Fatalf("incident %s returned by breachedOnly without breach", inc.ID)
+		}
+	}
+}
+
+func TestTimezoneAlignsSeededIncidents(t *testing.T) {
+	provAny, err := New(map[string]any{"timezone": "Europe/Berlin"})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	prov := provAny.(*Provider)
+	if prov.cfg.Location == nil {
+		t.Skip("timezone data unavailable")
+	}
+
+	list, _ := prov.Query(context.Background(), schema.IncidentQuery{})
+	for _, inc := range list {
+		if isScenarioIncident(inc.Metadata, inc.Fields) {
+			continue
+		}
+		if !mockutil.IsBusinessHour(inc.CreatedAt, prov.cfg.Location) {
+			t.Fatalf("incident %s created outside Berlin business hours: %v", inc.ID, inc.CreatedAt.In(prov.cfg.Location))
+		}
+		timeline, _ := prov.GetTimeline(context.Background(), inc.ID)
+		for _, entry := range timeline {
+			if entry.At.Before(inc.CreatedAt.Add(-time.Hour)) {
+				t.Fatalf("timeline entry %s not shifted with incident %s", entry.ID, inc.ID)
+			}
+		}
+	}
+}
+
+func TestExportIncidentReport(t *testing.T) {
+	provAny, err := New(map[string]any{})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	prov := provAny.(*Provider)
+
+	md, err := prov.Export(context.Background(), "inc-scenario-002", "markdown")
+	if err != nil {
+		t.Fatalf("Export returned error: %v", err)
+	}
+	if md.ContentType != "text/markdown" {
+		t.Fatalf("unexpected content type %q", md.ContentType)
+	}
+	for _, want := range []string{"# inc-scenario-002", "## Timeline", "## Metrics Snapshot", "## Participants", "morgan", "service=svc-database"} {
+		if !strings.Contains(md.Content, want) {
+			t.Fatalf("markdown report missing %q:\n%s", want, md.Content)
+		}
+	}
+
+	html, err := prov.Export(context.Background(), "inc-scenario-002", "html")
+	if err != nil {
+		t.Fatalf("Export returned error: %v", err)
+	}
+	if html.ContentType != "text/html" || !strings.Contains(html.Content, "<h2>Timeline</h2>") {
+		t.Fatalf("unexpected html report: %s", html.Content)
+	}
+
+	if _, err := prov.Export(context.Background(), "inc-scenario-002", "pdf"); err == nil {
+		t.Fatalf("expected error for unsupported format")
+	}
+	if _, err := prov.Export(context.Background(), "missing", "markdown"); err == nil {
+		t.Fatalf("expected error for missing incident")
+	}
+}
+
+func TestMarkdownReportEscapesFreeText(t *testing.T) {
+	at := time.Date(2025, 12, 13, 23, 58, 0, 0, time.UTC)
+	report := renderMarkdownReport(reportData{
+		Incident: schema.Incident{ID: "inc-1", Title: "Checkout | ledger\ndown", Status: "open", Severity: "sev1", Service: "svc-a|svc-b", CreatedAt: at},
+		Timeline: []schema.TimelineEntry{{At: at.Add(5 * time.Minute), Kind: "note", Body: "rolled back\nretrying | later", Actor: map[string]any{"name": "morgan"}}},
+	})
+	for _, want := range []string{
+		`# inc-1: Checkout \| ledger<br>down`,
+		`| Service | svc-a\|svc-b |`,
+		`- **2025-12-14 00:03 UTC** [note] rolled back<br>retrying \| later — morgan`,
+	} {
+		if !strings.Contains(report, want) {
+			t.Errorf("report missing %q:\n%s", want, report)
+		}
+	}
+}
+
+func TestParticipantsAndHandoff(t *testing.T) {
+	provAny, err := New(map[string]any{})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	prov := provAny.(*Provider)
+	ctx := context.Background()
+
+	active, err := prov.Participants(ctx, "inc-scenario-003", true)
+	if err != nil {
+		t.Fatalf("Participants returned error: %v", err)
+	}
+	if len(active) != 3 {
+		t.Fatalf("expected 3 active seeded participants, got %+v", active)
+	}
+	seeded, err := prov.Handoffs(ctx, "inc-scenario-003")
+	if err != nil || len(seeded) != 1 || seeded[0].To != "rosa" {
+		t.Fatalf("expected seeded comms handoff, got %+v (%v)", seeded, err)
+	}
+
+	if _, err := prov.JoinIncident(ctx, "inc-001", "kai", "scribe"); err != nil {
+		t.Fatalf("JoinIncident returned error: %v", err)
+	}
+	if _, err := prov.CreateHandoff(ctx, HandoffNote{IncidentID: "inc-001", From: "nobody", To: "kai", Summary: "x"}); err == nil {
+		t.Fatalf("expected error handing off from a non-participant")
+	}
+	note, err := prov.CreateHandoff(ctx, HandoffNote{IncidentID: "inc-001", From: "kai", To: "lee", Summary: "EU traffic drained; watch p95", OpenItems: []string{"Re-enable EU pool"}})
+	if err != nil {
+		t.Fatalf("CreateHandoff returned error: %v", err)
+	}
+	if note.Role != "scribe" || note.ID == "" {
+		t.Fatalf("expected role carried over and ID assigned, got %+v", note)
+	}
+
+	all, _ := prov.Participants(ctx, "inc-001", false)
+	if len(all) != 2 || all[0].Active() || !all[1].Active() || all[1].Name != "lee" {
+		t.Fatalf("expected kai to leave and lee to join, got %+v", all)
+	}
+
+	timeline, _ := prov.GetTimeline(ctx, "inc-001")
+	kinds := map[string]int{}
+	for _, entry := range timeline {
+		kinds[entry.Kind]++
+	}
+	if kinds["participant_joined"] != 2 || kinds["participant_left"] != 1 || kinds["handoff"] != 1 {
+		t.Fatalf("unexpected presence timeline kinds: %v", kinds)
+	}
+
+	if _, err := prov.LeaveIncident(ctx, "inc-001", "kai"); err == nil {
+		t.Fatalf("expected error leaving twice")
+	}
+}
+
+func TestEscalationRulesRaiseUnacknowledgedIncidents(t *testing.T) {
+	provAny, err := New(map[string]any{})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	prov := provAny.(*Provider)
+	start := time.Now().UTC()
+	prov.clock = func() time.Time { return start }
+
+	ignored, _ := prov.Create(context.Background(), schema.CreateIncidentInput{Title: "Ignored", Severity: "sev3", Service: "svc-api"})
+	acked, _ := prov.Create(context.Background(), schema.CreateIncidentInput{Title: "Picked up", Severity: "sev2", Service: "svc-api"})
+	investigating := "investigating"
+	if _, err := prov.Update(context.Background(), acked.ID, schema.UpdateIncidentInput{Status: &investigating}); err != nil {
+		t.Fatalf("Update returned error: %v", err)
+	}
+
+	// Past the sev3 rule (1h) and the following sev2 rule (30m).
+	prov.clock = func() time.Time { return start.Add(95 * time.Minute) }
+	got, _ := prov.Get(context.Background(), ignored.ID)
+	if got.Severity != "sev1" || got.Fields["escalation_level"] != 2 {
+		t.Fatalf("expected two escalations to sev1, got %s level %v", got.Severity, got.Fields["escalation_level"])
+	}
+	timeline, _ := prov.GetTimeline(context.Background(), ignored.ID)
+	var steps []schema.TimelineEntry
+	for _, entry := range timeline {
+		if entry.Kind == "escalation" {
+			steps = append(steps, entry)
+		}
+	}
+	// escalatedAt is stored at second precision like the other timestamp fields.
+	if len(steps) != 2 || !steps[0].At.Equal(start.Add(time.Hour)) || steps[1].At.Sub(start.Add(90*time.Minute)).Abs() >= time.Second {
+		t.Fatalf("expected escalations at +60m and +90m, got %+v", steps)
+	}
+	if steps[1].Metadata["rule"] != "sev2-unacked-30m" || steps[1].Actor["name"] != "escalation-policy" {
+		t.Fatalf("unexpected escalation entry: %+v", steps[1])
+	}
+
+	stillSev2, _ := prov.Get(context.Background(), acked.ID)
+	if stillSev2.Severity != "sev2" {
+		t.Fatalf("acknowledged incident should not escalate, got %s", stillSev2.Severity)
+	}
+}
+
+func TestEscalationRulesConfigurable(t *testing.T) {
+	provAny, _ := New(map[string]any{"escalationRules": []any{
+		map[string]any{"from": "sev4", "to": "sev3", "after": "10m"},
+	}})
+	prov := provAny.(*Provider)
+	start := time.Now().UTC()
+	prov.clock = func() time.Time { return start }
+
+	low, _ := prov.Create(context.Background(), schema.CreateIncidentInput{Title: "Low", Severity: "sev4"})
+	mid, _ := prov.Create(context.Background(), schema.CreateIncidentInput{Title: "Mid", Severity: "sev2"})
+	prov.clock = func() time.Time { return start.Add(time.Hour) }
+
+	if got, _ := prov.Get(context.Background(), low.ID); got.Severity != "sev3" {
+		t.Fatalf("expected custom rule to escalate sev4, got %s", got.Severity)
+	}
+	if got, _ := prov.Get(context.Background(), mid.ID); got.Severity != "sev2" {
+		t.Fatalf("default rules should be replaced, got %s", got.Severity)
+	}
+
+	offAny, _ := New(map[string]any{"escalationRules": []any{}})
+	off := offAny.(*Provider)
+	off.clock = func() time.Time { return start.Add(24 * time.Hour) }
+	if got, _ := off.Get(context.Background(), "inc-009"); got.Severity != "sev2" {
+		t.Fatalf("expected escalation disabled, got %s", got.Severity)
+	}
+}
+
+func TestQueryFilterExpression(t *testing.T) {
+	provAny, _ := New(nil)
+	prov := provAny.(*Provider)
+
+	ctx := mockutil.WithFilter(context.Background(), `severity in ["sev1", "sev2"] && fields.slaBreached == true`)
+	filtered, err := prov.Query(ctx, schema.IncidentQuery{})
+	if err != nil {
+		t.Fatalf("Query returned error: %v", err)
+	}
+	breached, _ := prov.Query(WithBreachedOnly(context.Background()), schema.IncidentQuery{Severities: []string{"sev1", "sev2"}})
+	if len(filtered) == 0 || len(filtered) != len(breached) {
+		t.Fatalf("expected filter on derived SLA fields to match breachedOnly (%d), got %d", len(breached), len(filtered))
+	}
+}
+
+func TestIncidentImpact(t *testing.T) {
+	provAny, _ := New(nil)
+	prov := provAny.(*Provider)
+	ctx := context.Background()
+
+	est, err := prov.Impact(ctx, "inc-scenario-001")
+	if err != nil {
+		t.Fatalf("Impact returned error: %v", err)
+	}
+	if !est.Ongoing || est.FractionSource != "severity" || est.ImpactedFraction != 0.35 {
+		t.Fatalf("expected ongoing sev1 estimate from severity, got %+v", est)
+	}
+	if est.DurationMinutes < 44 || est.AffectedUsers <= 0 || est.AffectedOrders <= 0 || est.LostRevenue <= 0 {
+		t.Fatalf("expected positive impact over the incident window, got %+v", est)
+	}
+	if est.AffectedUsers > est.ActiveUsers || est.LostRevenuePerMinute > est.RevenuePerMinute {
+		t.Fatalf("impact exceeds baseline: %+v", est)
+	}
+
+	inc, err := prov.Create(ctx, schema.CreateIncidentInput{
+		Title:    "Search degraded",
+		Severity: "sev2",
+		Service:  "svc-search",
+		Fields:   map[string]any{"customerImpact": "About 20% of searches time out"},
+	})
+	if err != nil {
+		t.Fatalf("Create returned error: %v", err)
+	}
+	custom, err := prov.Impact(ctx, inc.ID)
+	if err != nil {
+		t.Fatalf("Impact returned error: %v", err)
+	}
+	if custom.ImpactedFraction != 0.06 || !strings.HasPrefix(custom.FractionSource, "customerImpact") {
+		t.Fatalf("expected 20%% damped off the revenue path, got %v from %q", custom.ImpactedFraction, custom.FractionSource)
+	}
+
+	if _, err := prov.Impact(ctx, "inc-missing"); err == nil || !strings.Contains(err.Error(), "not_found") {
+		t.Fatalf("expected not_found, got %v", err)
+	}
+}
+
+func TestSimilarIncidents(t *testing.T) {
+	provAny, _ := New(nil)
+	prov := provAny.(*Provider)
+	ctx := context.Background()
+
+	matches, err := prov.Similar(ctx, "inc-scenario-001", 0)
+	if err != nil {
+		t.Fatalf("Similar returned error: %v", err)
+	}
+	if len(matches) == 0 || len(matches) > defaultSimilarLimit {
+		t.Fatalf("expected 1-%d matches, got %d", defaultSimilarLimit, len(matches))
+	}
+	top := matches[0].Incident
+	if !strings.HasPrefix(top.ID, "inc-hist-") || top.Service != "svc-checkout" || top.Fields["scenario_family"] != "slo-exhaustion" {
+		t.Fatalf("expected a past checkout slo-exhaustion incident first, got %s %v", top.ID, top.Fields)
+	}
+	for i, m := range matches {
+		if m.Incident.ID == "inc-scenario-001" {
+			t.Fatalf("target incident returned as its own match")
+		}
+		if m.Incident.Status != "resolved" || len(m.Reasons) == 0 || m.Score <= 0 || m.Score > 1 {
+			t.Fatalf("unexpected match %+v", m)
+		}
+		if i > 0 && m.Score > matches[i-1].Score {
+			t.Fatalf("matches not sorted by score: %v then %v", matches[i-1].Score, m.Score)
+		}
+	}
+
+	limited, err := prov.Similar(ctx, "inc-scenario-001", 2)
+	if err != nil || len(limited) != 2 {
+		t.Fatalf("expected 2 matches with limit, got %d (%v)", len(limited), err)
+	}
+
+	if _, err := prov.Similar(ctx, "inc-missing", 0); err == nil || !strings.Contains(err.Error(), "not_found") {
+		t.Fatalf("expected not_found for unknown incident, got %v", err)
+	}
+}
+
+func TestDeclareIncident(t *testing.T) {
+	provAny, _ := New(nil)
+	prov := provAny.(*Provider)
+	ctx := context.Background()
+
+	res, err := prov.Declare(ctx, DeclareInput{
+		Title:     "Database connection pool exhausted",
+		Severity:  "sev1",
+		Service:   "svc-catalog",
+		Commander: "Dana",
+	})
+	if err != nil {
+		t.Fatalf("Declare returned error: %v", err)
+	}
+	inc := res.Incident
+	if inc.Status != "open" || inc.Service != "svc-catalog" || inc.Metadata["channel"] != res.Channel {
+		t.Fatalf("unexpected declared incident %+v", inc)
+	}
+	if res.Channel != "#"+inc.ID || res.Announcement.Channel != res.Channel {
+		t.Fatalf("expected announcement in #%s, got %q / %q", inc.ID, res.Channel, res.Announcement.Channel)
+	}
+	if res.Page.TeamID != "team-atlas" || res.Page.Source == "team-channel" || res.Page.Message.ID == "" {
+		t.Fatalf("expected team-atlas on-call to be paged, got %+v", res.Page)
+	}
+	if res.Run == nil || res.Run.PlanID != "plan-runbook-006" || res.Run.Fields["incident_id"] != inc.ID {
+		t.Fatalf("expected the svc-catalog runbook run linked to %s, got %+v", inc.ID, res.Run)
+	}
+
+	kinds := map[string]int{}
+	for _, entry := range res.Timeline {
+		kinds[entry.Kind]++
+	}
+	for _, kind := range []string{"declared", "channel_opened", "bridge_opened", "paged", "runbook_started"} {
+		if kinds[kind] != 1 {
+			t.Fatalf("expected one %s timeline entry, got %v", kind, kinds)
+		}
+	}
+	if kinds["participant_joined"] != 2 {
+		t.Fatalf("expected commander and responder to join, got %v", kinds)
+	}
+	if inc.Metadata["bridgeUrl"] != "https://meet.demo.com/j/"+inc.ID || inc.Metadata["docUrl"] == nil {
+		t.Fatalf("expected bridge and doc links on the declared incident, got %v", inc.Metadata)
+	}
+	if name, ok := prov.activeCommanderLocked(inc.ID); !ok || name != "Dana" {
+		t.Fatalf("expected Dana to be commander, got %q", name)
+	}
+	if strings.Join(res.Simulated, ",") != "teams,messaging,orchestration" {
+		t.Fatalf("expected every unwired provider to be reported simulated, got %v", res.Simulated)
+	}
+	if inc.Metadata["runId"] != nil {
+		t.Fatalf("expected no runId from a private orchestration provider, got %v", inc.Metadata["runId"])
+	}
+	for _, entry := range res.Timeline {
+		if simulated := entry.Metadata["simulated"] == true; simulated != (entry.Kind == "channel_opened" || entry.Kind == "paged" || entry.Kind == "runbook_started") {
+			t.Fatalf("unexpected simulated marker on %s entry: %v", entry.Kind, entry.Metadata)
+		}
+	}
+
+	fallback, err := prov.Declare(ctx, DeclareInput{Title: "Search is slow", Service: "svc-search"})
+	if err != nil || fallback.Run == nil || fallback.Run.PlanID != fallbackPlanID {
+		t.Fatalf("expected fallback playbook for an untagged service, got %+v (%v)", fallback.Run, err)
+	}
+	noRotation, err := prov.Declare(ctx, DeclareInput{Title: "Replica lag", Service: "svc-database"})
+	if err != nil || noRotation.Page.Source != "team-channel" || noRotation.Run.PlanID != "plan-playbook-001" {
+		t.Fatalf("expected team-data to be paged in channel with the db playbook, got %+v (%v)", noRotation.Page, err)
+	}
+	if _, err := prov.Declare(ctx, DeclareInput{Title: "No service"}); err == nil || !strings.Contains(err.Error(), "bad_request") {
+		t.Fatalf("expected bad_request without a service, got %v", err)
+	}
+	before := len(prov.incidents)
+	if _, err := prov.Declare(ctx, DeclareInput{Title: "Bad plan", Service: "svc-search", PlanID: "plan-missing"}); err == nil || !strings.Contains(err.Error(), "not_found") {
+		t.Fatalf("expected not_found for an unknown plan, got %v", err)
+	}
+	if len(prov.incidents) != before {
+		t.Fatalf("expected no incident left behind by a failed declare, had %d now %d", before, len(prov.incidents))
+	}
+}
+
+func TestCreateDefaultsSeverityByServiceTier(t *testing.T) {
+	provAny, _ := New(nil)
+	ctx := context.Background()
+	for service, want := range map[string]string{"svc-checkout": "sev1", "svc-search": "sev2", "svc-analytics": "sev3"} {
+		inc, err := provAny.Create(ctx, schema.CreateIncidentInput{Title: "Tiered", Service: service})
+		if err != nil || inc.Severity != want {
+			t.Fatalf("expected %s incidents to default to %s, got %q (%v)", service, want, inc.Severity, err)
+		}
+	}
+	explicit, _ := provAny.Create(ctx, schema.CreateIncidentInput{Title: "Explicit", Service: "svc-checkout", Severity: "sev3"})
+	if explicit.Severity != "sev3" {
+		t.Fatalf("expected an explicit severity to win, got %q", explicit.Severity)
+	}
+
+	configured, _ := New(map[string]any{"defaultSeverity": "sev4"})
+	inc, _ := configured.Create(ctx, schema.CreateIncidentInput{Title: "Configured", Service: "svc-checkout"})
+	if inc.Severity != "sev4" {
+		t.Fatalf("expected the configured default to win over the tier, got %q", inc.Severity)
+	}
+}
+
+func TestSeededTagsAndTagFilter(t *testing.T) {
+	provAny, _ := New(nil)
+	prov := provAny.(*Provider)
+	ctx := context.Background()
+
+	all, _ := prov.Query(ctx, schema.IncidentQuery{})
+	for _, inc := range all {
+		tags := incidentTags(inc)
+		if len(tags) == 0 {
+			t.Fatalf("expected seeded tags on %s", inc.ID)
+		}
+		for _, tag := range tags {
+			if !validTag(tag) {
+				t.Fatalf("%s carries tag %q outside the taxonomy", inc.ID, tag)
+			}
+		}
+	}
+
+	configChanges, err := prov.Query(WithTags(ctx, "cause:config-change"), schema.IncidentQuery{})
+	if err != nil || len(configChanges) == 0 {
+		t.Fatalf("expected config-change incidents, got %d (%v)", len(configChanges), err)
+	}
+	historical := false
+	for _, inc := range configChanges {
+		if !hasTags(inc, []string{"cause:config-change"}) {
+			t.Fatalf("%s returned without cause:config-change: %v", inc.ID, incidentTags(inc))
+		}
+		historical = historical || inc.Fields["historical"] == true
+	}
+	if !historical {
+		t.Fatal("expected the historical corpus to carry cause tags")
+	}
+
+	both, _ := prov.Query(WithTags(ctx, "surface:checkout", "cause:deployment"), schema.IncidentQuery{})
+	for _, inc := range both {
+		if !hasTags(inc, []string{"surface:checkout", "cause:deployment"}) {
+			t.Fatalf("%s does not carry both tags: %v", inc.ID, incidentTags(inc))
+		}
+	}
+	if len(both) == 0 || len(both) >= len(all) {
+		t.Fatalf("expected tags to narrow results, got %d of %d", len(both), len(all))
+	}
+
+	stats, err := prov.TagStats(WithTags(ctx, "cause"), schema.IncidentQuery{})
+	if err != nil || stats["cause:deployment"] == 0 || stats["surface:checkout"] == 0 {
+		t.Fatalf("expected tag counts, got %v (%v)", stats, err)
+	}
+}
+
+func TestCreateNormalizesTags(t *testing.T) {
+	provAny, _ := New(nil)
+	ctx := context.Background()
+
+	inc, err := provAny.Create(ctx, schema.CreateIncidentInput{
+		Title:   "Config push",
+		Service: "svc-checkout",
+		Fields:  map[string]any{"tags": []any{" Cause:Config-Change ", "cause:config-change"}},
+	})
+	if err != nil {
+		t.Fatalf("Create returned error: %v", err)
+	}
+	if got := strings.Join(incidentTags(inc), ","); got != "cause:config-change,surface:checkout" {
+		t.Fatalf("expected normalized tags with the service surface, got %q", got)
+	}
+
+	if _, err := provAny.Create(ctx, schema.CreateIncidentInput{Title: "Bad tag", Fields: map[string]any{"tags": []string{"cause:gremlins"}}}); err == nil || !strings.Contains(err.Error(), "bad_request") {
+		t.Fatalf("expected bad_request for a tag outside the taxonomy, got %v", err)
+	}
+	if _, err := provAny.Update(ctx, inc.ID, schema.UpdateIncidentInput{Fields: map[string]any{"tags": []string{"team:velocity"}}}); err == nil || !strings.Contains(err.Error(), "bad_request") {
+		t.Fatalf("expected bad_request updating to an unknown namespace, got %v", err)
+	}
+}
+
+func TestResolutionFields(t *testing.T) {
+	provAny, _ := New(nil)
+	prov := provAny.(*Provider)
+	ctx := context.Background()
+
+	all, _ := prov.Query(ctx, schema.IncidentQuery{})
+	historical := 0
+	for _, inc := range all {
+		if inc.Fields["historical"] != true {
+			continue
+		}
+		historical++
+		fields := mockutil.CloneMap(inc.Fields)
+		if err := normalizeResolution(fields); err != nil {
+			t.Fatalf("%s carries resolution fields outside the taxonomy: %v", inc.ID, err)
+		}
+		for _, field := range resolutionTaxonomy {
+			if _, ok := inc.Fields[field.Name]; !ok {
+				t.Fatalf("expected %s on historical %s", field.Name, inc.ID)
+			}
+		}
+	}
+	if historical == 0 {
+		t.Fatal("expected historical incidents")
+	}
+
+	stats, err := prov.ResolutionStats(ctx, schema.IncidentQuery{})
+	if err != nil {
+		t.Fatalf("ResolutionStats returned error: %v", err)
+	}
+	if stats[rootCauseCategoryField]["capacity"] == 0 || stats[resolutionCodeField]["rolled-back"] == 0 || stats[detectionSourceField]["deploy-monitor"] == 0 || stats[contributingFactorsField]["missing-test-coverage"] == 0 {
+		t.Fatalf("expected seeded resolution counts, got %v", stats)
+	}
+
+	inc, err := prov.Create(ctx, schema.CreateIncidentInput{
+		Title:   "Config push",
+		Service: "svc-checkout",
+		Fields:  map[string]any{"tags": []string{"cause:config-change"}, "detectionSource": " Alert "},
+	})
+	if err != nil {
+		t.Fatalf("Create returned error: %v", err)
+	}
+	if inc.Fields[detectionSourceField] != "alert" {
+		t.Fatalf("expected normalized detection source, got %v", inc.Fields[detectionSourceField])
+	}
+	if _, err := prov.Create(ctx, schema.CreateIncidentInput{Title: "Bad code", Fields: map[string]any{"resolutionCode": "prayed"}}); err == nil || !strings.Contains(err.Error(), "bad_request") {
+		t.Fatalf("expected bad_request for a resolution code outside the vocabulary, got %v", err)
+	}
+	if _, err := prov.Update(ctx, inc.ID, schema.UpdateIncidentInput{Fields: map[string]any{"rootCauseCategory": []string{"capacity", "dependency"}}}); err == nil || !strings.Contains(err.Error(), "bad_request") {
+		t.Fatalf("expected bad_request for several root cause categories, got %v", err)
+	}
+
+	fields := mockutil.CloneMap(inc.Fields)
+	fields[contributingFactorsField] = []any{"Missing-Alert", "runbook-gap", "missing-alert"}
+	status := "resolved"
+	closed, err := prov.Update(ctx, inc.ID, schema.UpdateIncidentInput{Status: &status, Fields: fields})
+	if err != nil {
+		t.Fatalf("Update returned error: %v", err)
+	}
+	if closed.Fields[rootCauseCategoryField] != "config-change" {
+		t.Fatalf("expected the root cause category from the cause tag, got %v", closed.Fields[rootCauseCategoryField])
+	}
+	if got := strings.Join(stringValues(closed.Fields[contributingFactorsField]), ","); got != "missing-alert,runbook-gap" {
+		t.Fatalf("expected normalized contributing factors, got %q", got)
+	}
+}
+
+func TestSeededBridgeLinks(t *testing.T) {
+	provAny, _ := New(nil)
+	prov := provAny.(*Provider)
+	ctx := context.Background()
+
+	incs, err := prov.Query(ctx, schema.IncidentQuery{})
+	if err != nil {
+		t.Fatalf("Query returned error: %v", err)
+	}
+	for _, inc := range incs {
+		_, hasBridge := inc.Metadata["bridgeUrl"]
+		if resolvedStatuses[inc.Status] {
+			if hasBridge {
+				t.Fatalf("expected no bridge on resolved %s", inc.ID)
+			}
+			continue
+		}
+		dialIn, _ := inc.Metadata["bridgeDialIn"].(string)
+		if !hasBridge || inc.Metadata["docUrl"] == nil || !strings.HasPrefix(dialIn, bridgeDialInNumber) {
+			t.Fatalf("expected bridge links on active %s, got %v", inc.ID, inc.Metadata)
+		}
+	}
+
+	again, _ := New(nil)
+	first, _ := prov.Get(ctx, "inc-001")
+	second, _ := again.Get(ctx, "inc-001")
+	if first.Metadata["bridgeDialIn"] != second.Metadata["bridgeDialIn"] {
+		t.Fatalf("expected stable dial-in, got %v and %v", first.Metadata["bridgeDialIn"], second.Metadata["bridgeDialIn"])
+	}
+}
+
+func TestHistoricalCorpus(t *testing.T) {
+	provAny, _ := New(map[string]any{"warmup": true})
+	prov := provAny.(*Provider)
+	now := time.Now().UTC()
+
+	var history []schema.Incident
+	services := map[string]bool{}
+	for _, inc := range prov.incidents {
+		if inc.Fields["historical"] == true {
+			history = append(history, inc)
+			services[inc.Service] = true
+		}
+	}
+	if len(history) < 45 || len(services) < 10 {
+		t.Fatalf("expected ~50 historical incidents across many services, got %d across %d", len(history), len(services))
+	}
+
+	var oldMinutes, oldCount, newMinutes, newCount int
+	for _, inc := range history {
+		if inc.Status != "resolved" || now.Sub(inc.CreatedAt) > historyWindow || !inc.UpdatedAt.After(inc.CreatedAt) {
+			t.Fatalf("historical incident %s not resolved within the window: %+v", inc.ID, inc)
+		}
+		tl := prov.timeline[inc.ID]
+		if len(tl) < 4 || tl[3].Kind != "status_change" || tl[3].Metadata["to"] != "resolved" {
+			t.Fatalf("historical incident %s lacks a closed timeline: %+v", inc.ID, tl)
+		}
+		if inc.Severity == "sev1" || inc.Severity == "sev2" {
+			if inc.Metadata["postmortem"] == nil || inc.Fields["postmortemStatus"] == nil {
+				t.Fatalf("expected postmortem on %s %s", inc.Severity, inc.ID)
+			}
+		}
+		minutes := inc.Fields["durationMinutes"].(int)
+		if now.Sub(inc.CreatedAt) > historyWindow/2 {
+			oldMinutes, oldCount = oldMinutes+minutes, oldCount+1
+		} else {
+			newMinutes, newCount = newMinutes+minutes, newCount+1
+		}
+	}
+	if oldCount == 0 || newCount == 0 || oldMinutes/oldCount <= newMinutes/newCount {
+		t.Fatalf("expected MTTR to improve over the window: old %d/%d new %d/%d", oldMinutes, oldCount, newMinutes, newCount)
+	}
+}
+
+func TestUpdatePermissions(t *testing.T) {
+	provAny, err := New(map[string]any{})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	prov := provAny.(*Provider)
+	ctx := context.Background()
+	resolved, sev1 := "resolved", "sev1"
+
+	// inc-scenario-001 is commanded by alex with jordan responding.
+	_, err = prov.Update(WithActor(ctx, "jordan"), "inc-scenario-001", schema.UpdateIncidentInput{Status: &resolved})
+	if err == nil || !strings.Contains(err.Error(), "forbidden") || !strings.Contains(err.Error(), "alex") {
+		t.Fatalf("responder resolve: %v, want forbidden naming the commander", err)
+	}
+	if _, err := prov.Update(WithActor(ctx, "jordan"), "inc-scenario-001", schema.UpdateIncidentInput{Severity: &sev1}); err != nil {
+		t.Fatalf("responder severity change: %v", err)
+	}
+	if _, err := prov.Update(WithActor(ctx, "casey"), "inc-scenario-001", schema.UpdateIncidentInput{Severity: &sev1}); err != nil {
+		t.Fatalf("unchanged severity should not be checked: %v", err)
+	}
+	sev3 := "sev3"
+	_, err = prov.Update(WithActor(ctx, "casey"), "inc-scenario-001", schema.UpdateIncidentInput{Severity: &sev3})
+	if err == nil || !strings.Contains(err.Error(), "forbidden") {
+		t.Fatalf("non-responder severity change: %v, want forbidden", err)
+	}
+	inc, err := prov.Update(WithActor(ctx, "alex"), "inc-scenario-001", schema.UpdateIncidentInput{Status: &resolved})
+	if err != nil || inc.Status != "resolved" || inc.Severity != "sev1" {
+		t.Fatalf("commander resolve: %+v, %v", inc, err)
+	}
+
+	// Without a commander any active responder may resolve; without an actor nothing is checked.
+	if _, err := prov.JoinIncident(ctx, "inc-001", "kim", "sre"); err != nil {
+		t.Fatalf("JoinIncident: %v", err)
+	}
+	if _, err := prov.Update(WithActor(ctx, "lee"), "inc-001", schema.UpdateIncidentInput{Status: &resolved}); err == nil {
+		t.Fatal("non-responder resolved an incident without a commander")
+	}
+	if _, err := prov.Update(WithActor(ctx, "kim"), "inc-001", schema.UpdateIncidentInput{Status: &resolved}); err != nil {
+		t.Fatalf("responder resolve without commander: %v", err)
+	}
+	if _, err := prov.Update(ctx, "inc-scenario-003", schema.UpdateIncidentInput{Status: &resolved}); err != nil {
+		t.Fatalf("update without actor: %v", err)
+	}
+}
+
+func TestHistoryIsSeededLazily(t *testing.T) {
+	provAny, err := New(map[string]any{})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	prov := provAny.(*Provider)
+	ctx := context.Background()
+
+	if _, ok := prov.incidents["inc-hist-001"]; ok {
+		t.Fatal("history generated before first access")
+	}
+	if _, err := prov.Get(ctx, "inc-001"); err != nil {
+		t.Fatalf("Get seeded incident: %v", err)
+	}
+	if _, ok := prov.incidents["inc-hist-001"]; ok {
+		t.Fatal("a seeded lookup should not generate history")
+	}
+	inc, err := prov.Get(ctx, "inc-hist-001")
+	if err != nil || inc.Fields["historical"] != true {
+		t.Fatalf("Get historical incident: %+v, %v", inc, err)
+	}
+
+	var stat *mockutil.SeedStat
+	for _, s := range mockutil.SeedStats() {
+		if s.Provider == "incident" && s.Collection == "history" {
+			s := s
+			stat = &s
+		}
+	}
+	if stat == nil || !stat.Lazy || stat.Records < 45 {
+		t.Fatalf("expected a lazy history seed stat, got %+v", stat)
+	}
+}
+
+func TestHistorySeedLeavesCreatedIncidents(t *testing.T) {
+	provAny, err := New(map[string]any{"timezone": "Asia/Tokyo"})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	prov := provAny.(*Provider)
+	if prov.cfg.Location == nil {
+		t.Skip("timezone data unavailable")
+	}
+	// 16:58 UTC is 01:58 in Tokyo, outside the business hours seeds align to.
+	at := time.Date(2026, time.October, 14, 16, 58, 0, 0, time.UTC)
+	prov.clock = func() time.Time { return at }
+	ctx := context.Background()
+
+	created, err := prov.Create(ctx, schema.CreateIncidentInput{Title: "Overnight page", Service: "svc-checkout"})
+	if err != nil {
+		t.Fatalf("Create returned error: %v", err)
+	}
+	if _, ok := prov.incidents["inc-hist-001"]; ok {
+		t.Fatal("history generated before first query")
+	}
+	if _, err := prov.Query(ctx, schema.IncidentQuery{}); err != nil {
+		t.Fatalf("Query returned error: %v", err)
+	}
+	if _, ok := prov.incidents["inc-hist-001"]; !ok {
+		t.Fatal("expected the query to generate history")
+	}
+	got, err := prov.Get(ctx, created.ID)
+	if err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+	if !got.CreatedAt.Equal(created.CreatedAt) || !got.UpdatedAt.Equal(created.UpdatedAt) {
+		t.Fatalf("expected %s to keep its timestamps, created %v/%v, now %v/%v", created.ID, created.CreatedAt, created.UpdatedAt, got.CreatedAt, got.UpdatedAt)
+	}
+	for _, entry := range prov.timeline[created.ID] {
+		if entry.At.Before(at) {
+			t.Fatalf("expected %s's timeline to stay put, got entry at %v", created.ID, entry.At)
+		}
+	}
+}
+
+func TestSeveritySchemes(t *testing.T) {
+	ctx := context.Background()
+	provAny, _ := New(map[string]any{"severityScheme": "P", "defaultSeverity": "P3", "escalationRules": []any{
+		map[string]any{"from": "P2", "to": "P1", "after": "30m"},
+	}})
+	prov := provAny.(*Provider)
+	start := time.Now().UTC()
+	prov.clock = func() time.Time { return start }
+
+	created, err := prov.Create(ctx, schema.CreateIncidentInput{Title: "Checkout errors", Severity: "p2", Service: "svc-checkout"})
+	if err != nil {
+		t.Fatalf("Create returned error: %v", err)
+	}
+	if created.Severity != "P2" || created.Metadata["canonicalSeverity"] != "sev2" {
+		t.Fatalf("expected P2 (sev2), got %s (%v)", created.Severity, created.Metadata["canonicalSeverity"])
+	}
+	if _, err := prov.Create(ctx, schema.CreateIncidentInput{Title: "Bad", Severity: "SEV0"}); err == nil || !strings.Contains(err.Error(), "bad_request") {
+		t.Fatalf("expected bad_request for a label outside the scheme, got %v", err)
+	}
+	defaulted, _ := prov.Create(ctx, schema.CreateIncidentInput{Title: "Defaulted"})
+	if defaulted.Severity != "P3" {
+		t.Fatalf("expected the P3 default, got %s", defaulted.Severity)
+	}
+
+	got, err := prov.Query(ctx, schema.IncidentQuery{Severities: []string{"P2"}})
+	if err != nil {
+		t.Fatalf("Query returned error: %v", err)
+	}
+	for _, inc := range got {
+		if inc.Severity != "P2" {
+			t.Fatalf("expected only P2 incidents, got %s", inc.Severity)
+		}
+	}
+
+	prov.clock = func() time.Time { return start.Add(31 * time.Minute) }
+	escalated, _ := prov.Get(ctx, created.ID)
+	if escalated.Severity != "P1" {
+		t.Fatalf("expected escalation to P1, got %s", escalated.Severity)
+	}
+	timeline, _ := prov.GetTimeline(ctx, created.ID)
+	if last := timeline[len(timeline)-1]; last.Kind != "escalation" || !strings.Contains(last.Body, "from P2 to P1") {
+		t.Fatalf("expected the escalation in scheme labels, got %+v", last)
+	}
+
+	customAny, _ := New(map[string]any{"severityLabels": []any{"critical", "major", "minor"}})
+	custom := customAny.(*Provider)
+	levels := custom.Severities(ctx)
+	if len(levels) != 3 || levels[0].Label != "critical" || levels[2].Canonical != "sev3" {
+		t.Fatalf("unexpected custom scheme %+v", levels)
+	}
+	if _, err := custom.Create(ctx, schema.CreateIncidentInput{Title: "Too low", Severity: "sev4"}); err == nil {
+		t.Fatal("expected sev4 to fall outside a three-level scheme")
+	}
+}
+
+func TestStatsUsesSeverityLabels(t *testing.T) {
+	provAny, err := New(map[string]any{"severityScheme": "p"})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	prov := provAny.(*Provider)
+	ctx := context.Background()
+
+	incidents, err := prov.List(ctx)
+	if err != nil {
+		t.Fatalf("List returned error: %v", err)
+	}
+	stats, err := prov.Stats(ctx, schema.IncidentQuery{})
+	if err != nil {
+		t.Fatalf("Stats returned error: %v", err)
+	}
+	if stats.Total != len(incidents) {
+		t.Fatalf("expected %d incidents counted, got %d", len(incidents), stats.Total)
+	}
+	if stats.BySeverity["P1"] == 0 || stats.BySeverity["sev1"] != 0 {
+		t.Fatalf("expected severities counted by scheme label, got %v", stats.BySeverity)
+	}
+	if stats.ByTeam["team-velocity"] == 0 {
+		t.Fatalf("expected team counts, got %v", stats.ByTeam)
+	}
+
+	scoped, err := prov.Stats(ctx, schema.IncidentQuery{Scope: schema.QueryScope{Service: "svc-checkout"}})
+	if err != nil {
+		t.Fatalf("Stats returned error: %v", err)
+	}
+	if scoped.Total == 0 || scoped.ByService["svc-checkout"] != scoped.Total {
+		t.Fatalf("expected scoped stats for svc-checkout only, got %v", scoped.ByService)
+	}
+}
+
+func TestLocaleTranslatesSeededIncidents(t *testing.T) {
+	provAny, err := New(map[string]any{"locale": "ja"})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	inc, err := provAny.(*Provider).Get(context.Background(), "inc-001")
+	if err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+	if inc.Title != "チェックアウトの遅延がEUの顧客に影響" {
+		t.Fatalf("expected Japanese title, got %q", inc.Title)
+	}
+}
+
+func TestLocaleTranslatesIncidentHistory(t *testing.T) {
+	provAny, err := New(map[string]any{"locale": "de"})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	inc, err := provAny.(*Provider).Get(context.Background(), "inc-hist-001")
+	if err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+	if inc.Title != "Checkout-Fehlerbudget nach Traffic-Anstieg aufgebraucht" {
+		t.Fatalf("expected German title, got %q", inc.Title)
+	}
+}
+
+func TestEscalationDisabledByLifecycleSwitch(t *testing.T) {
+	provAny, _ := New(map[string]any{"lifecycle": false})
+	prov := provAny.(*Provider)
+	start := time.Now().UTC()
+	prov.clock = func() time.Time { return start }
+
+	ignored, _ := prov.Create(context.Background(), schema.CreateIncidentInput{Title: "Ignored", Severity: "sev3", Service: "svc-api"})
+	prov.clock = func() time.Time { return start.Add(3 * time.Hour) }
+	got, _ := prov.Get(context.Background(), ignored.ID)
+	if got.Severity != "sev3" || got.Fields["escalation_level"] != nil {
+		t.Fatalf("expected no escalation with lifecycle off, got %s level %v", got.Severity, got.Fields["escalation_level"])
+	}
+}
+
+func TestMinimalSeed(t *testing.T) {
+	provAny, _ := New(map[string]any{"minimal": true})
+	prov := provAny.(*Provider)
+
+	incidents, err := prov.List(context.Background())
+	if err != nil {
+		t.Fatalf("List returned error: %v", err)
+	}
+	for _, inc := range incidents {
+		if isScenarioIncident(inc.Metadata, inc.Fields) {
+			t.Fatalf("expected no scenario incidents with scenarioEffects off, got %s", inc.ID)
+		}
+		if _, ok := inc.Metadata[bridgeURLKey]; ok {
+			t.Fatalf("expected no bridge link with flair off, got %v on %s", inc.Metadata[bridgeURLKey], inc.ID)
+		}
+	}
+	if _, ok := prov.participants["inc-scenario-003"]; ok {
+		t.Fatalf("expected no participants for a scenario incident left out")
+	}
+}
+
+// BenchmarkQuery lists 1k, 10k, and 100k incidents cloned from a seed and
+// encodes them the way a plugin returns them.
+func BenchmarkQuery(b *testing.B) {
+	for _, n := range []int{1000, 10000, 100000} {
+		b.Run(fmt.Sprintf("incidents=%d", n), func(b *testing.B) {
+			provAny, _ := New(map[string]any{"warmup": true})
+			prov := provAny.(*Provider)
+			base := prov.incidents["inc-001"]
+			for i := 0; i < n; i++ {
+				inc := cloneIncident(base)
+				inc.ID = fmt.Sprintf("inc-bench-%06d", i)
+				prov.incidents[inc.ID] = inc
+			}
+			ctx := context.Background()
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				out, err := prov.Query(ctx, schema.IncidentQuery{})
+				if err != nil {
+					b.Fatalf("Query returned error: %v", err)
+				}
+				if _, err := json.Marshal(pluginrpc.Response{Result: out}); err != nil {
+					b.Fatalf("marshal: %v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestActionItems(t *testing.T) {
+	provAny, err := New(nil)
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	prov := provAny.(*Provider)
+	ctx := context.Background()
+
+	if _, err := prov.ActionItems(ctx, ActionItemsInput{ID: "inc-missing"}); err == nil || !strings.Contains(err.Error(), "not_found") {
+		t.Fatalf("expected not_found, got %v", err)
+	}
+
+	if err := prov.AppendTimeline(ctx, "inc-scenario-003", schema.TimelineAppendInput{Kind: "note", Body: "Follow-up: add a contract test for the payments API", Actor: mockutil.ActorRef("sam")}); err != nil {
+		t.Fatalf("AppendTimeline returned error: %v", err)
+	}
+	result, err := prov.ActionItems(ctx, ActionItemsInput{ID: "inc-scenario-003"})
+	if err != nil {
+		t.Fatalf("ActionItems returned error: %v", err)
+	}
+	byCategory := map[string][]ActionItem{}
+	for _, item := range result.Items {
+		if item.Priority != "P1" {
+			t.Fatalf("expected sev2 items to be P1, got %+v", item)
+		}
+		byCategory[item.Category] = append(byCategory[item.Category], item)
+	}
+	followUps := byCategory[ActionFollowUp]
+	if len(followUps) != 1 || followUps[0].Title != "add a contract test for the payments API" || followUps[0].Owner != "sam" {
+		t.Fatalf("expected the explicit follow-up, got %+v", followUps)
+	}
+	var rollback, rootCause *ActionItem
+	for i, item := range byCategory[ActionRemediation] {
+		switch item.ID {
+		case "ai-inc-scenario-003-rollback":
+			rollback = &byCategory[ActionRemediation][i]
+		case "ai-inc-scenario-003-root-cause":
+			rootCause = &byCategory[ActionRemediation][i]
+		}
+	}
+	if rollback == nil || rollback.Source != "timeline" || rollback.Owner != "sam" || !strings.Contains(strings.ToLower(rollback.Evidence), "roll") {
+		t.Fatalf("expected a rollback remediation from the timeline, got %+v", byCategory[ActionRemediation])
+	}
+	if rootCause == nil || rootCause.Title != "Fix the root cause: incompatible API change" {
+		t.Fatalf("expected a root cause remediation, got %+v", byCategory[ActionRemediation])
+	}
+	if rootCause.Ticket == nil || rootCause.Ticket.ID != "TCK-SCENARIO-003" {
+		t.Fatalf("expected the scenario ticket linked to the root cause, got %+v", rootCause.Ticket)
+	}
+	if len(byCategory[ActionPostmortem]) != 1 {
+		t.Fatalf("expected a postmortem item for a sev2, got %+v", byCategory[ActionPostmortem])
+	}
+
+	created, err := prov.ActionItems(ctx, ActionItemsInput{ID: "inc-scenario-003", CreateTickets: true})
+	if err != nil {
+		t.Fatalf("ActionItems returned error: %v", err)
+	}
+	if len(created.Created) != len(created.Items)-1 {
+		t.Fatalf("expected a ticket for every unlinked item, created %v for %d items", created.Created, len(created.Items))
+	}
+	if !created.Simulated {
+		t.Fatal("expected tickets from the private ticket provider to be reported simulated")
+	}
+	again, err := prov.ActionItems(ctx, ActionItemsInput{ID: "inc-scenario-003", CreateTickets: true})
+	if err != nil {
+		t.Fatalf("ActionItems returned error: %v", err)
+	}
+	if len(again.Created) != 0 {
+		t.Fatalf("expected filed tickets to stay linked, created %v", again.Created)
+	}
+	for i, item := range again.Items {
+		if item.Ticket == nil || item.Ticket.ID != created.Items[i].Ticket.ID {
+			t.Fatalf("item %s lost its ticket: %+v", item.ID, item.Ticket)
+		}
+	}
+}
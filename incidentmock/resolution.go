@@ -0,0 +1,270 @@
+package incidentmock
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/opsorch/opsorch-core/orcherr"
+	"github.com/opsorch/opsorch-core/schema"
+)
+
+// Resolution fields, stored in Fields with values from resolutionTaxonomy.
+// contributingFactors holds a list; the others hold one value.
+const (
+	rootCauseCategoryField   = "rootCauseCategory"
+	contributingFactorsField = "contributingFactors"
+	detectionSourceField     = "detectionSource"
+	resolutionCodeField      = "resolutionCode"
+)
+
+// unknownRootCause is the root cause category recorded on close when neither
+// the caller nor the incident's cause tag gives one.
+const unknownRootCause = "unknown"
+
+// ResolutionField is one structured resolution field and the values it
+// allows. Multi fields take a list of values.
+type ResolutionField struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Multi       bool     `json:"multi,omitempty"`
+	Values      []string `json:"values"`
+}
+
+// resolutionTaxonomy is the controlled vocabulary for resolution fields;
+// values outside it are rejected. Root cause categories follow the cause tag
+// namespace.
+var resolutionTaxonomy = []ResolutionField{
+	{
+		Name:        rootCauseCategoryField,
+		Description: "Category of the underlying root cause",
+		Values:      []string{"capacity", "code-defect", "config-change", "dependency", "deployment", "expired-credential", "infrastructure", "schema-change", unknownRootCause},
+	},
+	{
+		Name:        contributingFactorsField,
+		Description: "Conditions that made the incident possible or worse",
+		Multi:       true,
+		Values:      []string{"backward-incompatible-change", "insufficient-capacity-planning", "missing-alert", "missing-fallback", "missing-test-coverage", "missing-timeout", "runbook-gap", "single-point-of-failure", "unannounced-change", "unthrottled-load"},
+	},
+	{
+		Name:        detectionSourceField,
+		Description: "How the incident was first noticed",
+		Values:      []string{"alert", "customer-report", "deploy-monitor", "engineer", "slo-burn", "synthetic-check", "vendor-status"},
+	},
+	{
+		Name:        resolutionCodeField,
+		Description: "How the incident was resolved",
+		Values:      []string{"capacity-added", "code-fix", "config-fix", "failed-over", "manual-intervention", "rolled-back", "vendor-resolved"},
+	},
+}
+
+// factorKeywords map wording in the historical root causes and resolutions
+// to contributing factors; every match applies.
+var factorKeywords = []struct{ word, factor string }{
+	{"broke older clients", "backward-incompatible-change"},
+	{"did not scale", "insufficient-capacity-planning"},
+	{"limit", "insufficient-capacity-planning"},
+	{"pre-scaled", "insufficient-capacity-planning"},
+	{"alerted on", "missing-alert"},
+	{"without a fallback", "missing-fallback"},
+	{"secondary", "missing-fallback"},
+	{"test", "missing-test-coverage"},
+	{"validation", "missing-test-coverage"},
+	{"review check", "missing-test-coverage"},
+	{"timeout", "missing-timeout"},
+	{"one region", "single-point-of-failure"},
+	{"bulk", "unthrottled-load"},
+	{"burst", "unthrottled-load"},
+}
+
+// resolutionCodes map wording in the historical resolutions to a resolution
+// code; the first match wins, before falling back to code-fix.
+var resolutionCodes = []struct{ word, code string }{
+	{"rolled back", "rolled-back"},
+	{"failed over", "failed-over"},
+	{"scaled", "capacity-added"},
+	{"raised", "capacity-added"},
+	{"resized", "capacity-added"},
+	{"rotated", "config-fix"},
+	{"killed", "manual-intervention"},
+}
+
+// familyDetection is how incidents of each scenario family were noticed.
+// Incidents outside a family were reported by customers.
+var familyDetection = map[string]string{
+	"autoscaling-lag":             "alert",
+	"cascading-failure":           "alert",
+	"circuit-breaker-cascade":     "alert",
+	"deployment-rollback":         "deploy-monitor",
+	"external-dependency-failure": "vendor-status",
+	"slo-exhaustion":              "slo-burn",
+}
+
+// ResolutionTaxonomy lists the resolution fields and their allowed values.
+func (p *Provider) ResolutionTaxonomy(ctx context.Context) []ResolutionField {
+	out := make([]ResolutionField, len(resolutionTaxonomy))
+	for i, field := range resolutionTaxonomy {
+		field.Values = append([]string(nil), field.Values...)
+		out[i] = field
+	}
+	return out
+}
+
+// ResolutionStats counts the resolution field values on the incidents Query
+// matches, ignoring query.Limit, keyed by field and then value. Incidents
+// without a field are left out of its counts.
+func (p *Provider) ResolutionStats(ctx context.Context, query schema.IncidentQuery) (map[string]map[string]int, error) {
+	query.Limit = 0
+	incidents, err := p.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	counts := make(map[string]map[string]int, len(resolutionTaxonomy))
+	for _, field := range resolutionTaxonomy {
+		counts[field.Name] = map[string]int{}
+	}
+	for _, inc := range incidents {
+		for _, field := range resolutionTaxonomy {
+			for _, value := range stringValues(inc.Fields[field.Name]) {
+				counts[field.Name][value]++
+			}
+		}
+	}
+	return counts, nil
+}
+
+// normalizeResolution validates the resolution fields present in fields
+// against the taxonomy, rewriting them lower-cased in place. Contributing
+// factors are stored as a sorted, de-duplicated []string.
+func normalizeResolution(fields map[string]any) error {
+	for _, field := range resolutionTaxonomy {
+		var raw []string
+		switch v := fields[field.Name].(type) {
+		case nil:
+			continue
+		case string:
+			raw = []string{v}
+		case []string:
+			raw = v
+		case []any:
+			for _, item := range v {
+				s, ok := item.(string)
+				if !ok {
+					return orcherr.New("bad_request", fmt.Sprintf("%s values must be strings, got %v", field.Name, item), nil)
+				}
+				raw = append(raw, s)
+			}
+		default:
+			return orcherr.New("bad_request", fmt.Sprintf("%s must be a string or a list of strings", field.Name), nil)
+		}
+		if !field.Multi && len(raw) != 1 {
+			return orcherr.New("bad_request", fmt.Sprintf("%s takes a single value", field.Name), nil)
+		}
+
+		seen := map[string]bool{}
+		values := make([]string, 0, len(raw))
+		for _, value := range raw {
+			value = strings.ToLower(strings.TrimSpace(value))
+			if !containsValue(field.Values, value) {
+				return orcherr.New("bad_request", fmt.Sprintf("unknown %s %q; see the resolution taxonomy", field.Name, value), nil)
+			}
+			if !seen[value] {
+				seen[value] = true
+				values = append(values, value)
+			}
+		}
+		if field.Multi {
+			sort.Strings(values)
+			fields[field.Name] = values
+		} else {
+			fields[field.Name] = values[0]
+		}
+	}
+	return nil
+}
+
+// fillRootCause records a root cause category on a closing incident that
+// has none, from its cause tag.
+func fillRootCause(inc *schema.Incident) {
+	if inc.Fields == nil {
+		inc.Fields = map[string]any{}
+	}
+	if _, ok := inc.Fields[rootCauseCategoryField]; ok {
+		return
+	}
+	category := unknownRootCause
+	for _, tag := range incidentTags(*inc) {
+		if cause, ok := strings.CutPrefix(tag, "cause:"); ok {
+			category = cause
+			break
+		}
+	}
+	inc.Fields[rootCauseCategoryField] = category
+}
+
+// historicalResolution derives the resolution fields of a corpus incident
+// from its root cause, resolution, and scenario family.
+func historicalResolution(seed historicalSeed, tags []string) map[string]any {
+	category := unknownRootCause
+	for _, tag := range tags {
+		if cause, ok := strings.CutPrefix(tag, "cause:"); ok {
+			category = cause
+		}
+	}
+	text := strings.ToLower(seed.rootCause + " " + seed.resolution)
+	factors := make([]string, 0)
+	for _, fk := range factorKeywords {
+		if strings.Contains(text, fk.word) && !containsValue(factors, fk.factor) {
+			factors = append(factors, fk.factor)
+		}
+	}
+	sort.Strings(factors)
+	code := "code-fix"
+	resolution := strings.ToLower(seed.resolution)
+	for _, rc := range resolutionCodes {
+		if strings.Contains(resolution, rc.word) {
+			code = rc.code
+			break
+		}
+	}
+	detection, ok := familyDetection[seed.family]
+	if !ok {
+		detection = "customer-report"
+	}
+	return map[string]any{
+		rootCauseCategoryField:   category,
+		contributingFactorsField: factors,
+		detectionSourceField:     detection,
+		resolutionCodeField:      code,
+	}
+}
+
+// stringValues reads a string or list of strings, whether stored by the
+// provider or decoded from JSON.
+func stringValues(raw any) []string {
+	switch v := raw.(type) {
+	case string:
+		return []string{v}
+	case []string:
+		return v
+	case []any:
+		out := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				out = append(out, s)
+			}
+		}
+		return out
+	}
+	return nil
+}
+
+func containsValue(values []string, want string) bool {
+	for _, v := range values {
+		if v == want {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,136 @@
+package incidentmock
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/opsorch/opsorch-core/orcherr"
+	"github.com/opsorch/opsorch-core/schema"
+)
+
+// canonicalSeverities are the severities incidents are stored and seeded
+// with, most severe first. A SeverityScheme labels each of them.
+var canonicalSeverities = []string{"sev1", "sev2", "sev3", "sev4", "sev5"}
+
+// severitySchemes are the built-in schemes selectable by name.
+var severitySchemes = map[string][]string{
+	"p":    {"P1", "P2", "P3", "P4", "P5"},
+	"sev0": {"SEV0", "SEV1", "SEV2", "SEV3", "SEV4"},
+}
+
+// SeverityScheme relabels severities for hosts integrating with organizations
+// that use P1–P5, SEV0–SEV4, or their own names. Labels[i] stands for
+// canonicalSeverities[i]; a scheme with no labels is the canonical one and
+// passes any severity through unchanged.
+type SeverityScheme struct {
+	Name   string   `json:"name"`
+	Labels []string `json:"labels,omitempty"`
+}
+
+// SeverityLevel is one entry of the configured scheme.
+type SeverityLevel struct {
+	Rank      int    `json:"rank"`
+	Label     string `json:"label"`
+	Canonical string `json:"canonical"`
+}
+
+// parseSeverityScheme reads severityScheme ("p" or "sev0") or, for custom
+// labels, severityLabels listed most severe first. Unknown names and empty
+// label lists keep the canonical scheme.
+func parseSeverityScheme(cfg map[string]any) SeverityScheme {
+	if raw, ok := cfg["severityLabels"].([]any); ok {
+		labels := make([]string, 0, len(raw))
+		for _, item := range raw {
+			if s, ok := item.(string); ok && strings.TrimSpace(s) != "" && len(labels) < len(canonicalSeverities) {
+				labels = append(labels, strings.TrimSpace(s))
+			}
+		}
+		if len(labels) > 0 {
+			return SeverityScheme{Name: "custom", Labels: labels}
+		}
+	}
+	name, _ := cfg["severityScheme"].(string)
+	name = strings.ToLower(strings.TrimSpace(name))
+	if labels, ok := severitySchemes[name]; ok {
+		return SeverityScheme{Name: name, Labels: labels}
+	}
+	return SeverityScheme{Name: "sev"}
+}
+
+func (s SeverityScheme) canonicalOnly() bool { return len(s.Labels) == 0 }
+
+// toCanonical maps a label of the scheme, case-insensitively, to its
+// canonical severity. Canonical severities are accepted as well.
+func (s SeverityScheme) toCanonical(label string) (string, bool) {
+	if s.canonicalOnly() {
+		return label, true
+	}
+	for i, l := range s.Labels {
+		if strings.EqualFold(l, label) {
+			return canonicalSeverities[i], true
+		}
+	}
+	for _, sev := range canonicalSeverities[:len(s.Labels)] {
+		if strings.EqualFold(sev, label) {
+			return sev, true
+		}
+	}
+	return "", false
+}
+
+// label returns the scheme's label for a canonical severity.
+func (s SeverityScheme) label(canonical string) string {
+	for i, sev := range canonicalSeverities[:len(s.Labels)] {
+		if sev == canonical {
+			return s.Labels[i]
+		}
+	}
+	return canonical
+}
+
+// Severities lists the configured scheme, most severe first.
+func (p *Provider) Severities(ctx context.Context) []SeverityLevel {
+	_ = ctx
+	scheme := p.cfg.Severity
+	levels := canonicalSeverities[:4]
+	if !scheme.canonicalOnly() {
+		levels = canonicalSeverities[:len(scheme.Labels)]
+	}
+	out := make([]SeverityLevel, 0, len(levels))
+	for i, sev := range levels {
+		out = append(out, SeverityLevel{Rank: i + 1, Label: scheme.label(sev), Canonical: sev})
+	}
+	return out
+}
+
+// normalizeSeverity maps an incoming severity to its canonical form. Empty
+// stays empty; labels outside a configured scheme are rejected.
+func (p *Provider) normalizeSeverity(severity string) (string, error) {
+	if severity == "" {
+		return "", nil
+	}
+	canonical, ok := p.cfg.Severity.toCanonical(severity)
+	if !ok {
+		return "", orcherr.New("bad_request", fmt.Sprintf("unknown severity %q for the %s scheme (%s)", severity, p.cfg.Severity.Name, strings.Join(p.cfg.Severity.Labels, ", ")), nil)
+	}
+	return canonical, nil
+}
+
+// tierSeverities are the canonical severities incidents default to by service
+// tier when neither the request nor cfg.DefaultSeverity sets one.
+var tierSeverities = map[int]string{1: "sev1", 2: "sev2", 3: "sev3"}
+
+// presentSeverity relabels an outgoing incident copy with the configured
+// scheme, keeping the stored value in Metadata["canonicalSeverity"] so hosts
+// can check their normalization against it.
+func (p *Provider) presentSeverity(inc *schema.Incident) {
+	if p.cfg.Severity.canonicalOnly() {
+		return
+	}
+	if inc.Metadata == nil {
+		inc.Metadata = map[string]any{}
+	}
+	inc.Metadata["canonicalSeverity"] = inc.Severity
+	inc.Severity = p.cfg.Severity.label(inc.Severity)
+}
@@ -0,0 +1,153 @@
+package incidentmock
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/opsorch/opsorch-core/orcherr"
+	"github.com/opsorch/opsorch-core/schema"
+)
+
+// SimilarIncident is a past incident ranked against the one being handled.
+type SimilarIncident struct {
+	Incident schema.Incident `json:"incident"`
+	// Score is in [0, 1]; Reasons explain which signals contributed.
+	Score          float64  `json:"score"`
+	Reasons        []string `json:"reasons"`
+	SharedKeywords []string `json:"sharedKeywords,omitempty"`
+}
+
+// Similarity weights; they sum to 1 so a perfect match scores 1.
+const (
+	similarServiceWeight = 0.35
+	similarFamilyWeight  = 0.3
+	similarKeywordWeight = 0.35
+	defaultSimilarLimit  = 5
+)
+
+// similarStopwords are dropped before comparing titles and descriptions.
+var similarStopwords = map[string]bool{
+	"the": true, "and": true, "for": true, "with": true, "from": true, "after": true,
+	"into": true, "during": true, "while": true, "was": true, "were": true, "are": true,
+	"that": true, "this": true, "than": true, "before": true, "across": true, "some": true,
+	"service": true, "incident": true,
+}
+
+// Similar ranks resolved incidents from the last 90 days by how closely they
+// resemble the given incident: same service, same scenario family, and
+// overlapping title and description keywords.
+func (p *Provider) Similar(ctx context.Context, id string, limit int) ([]SimilarIncident, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.history.Ensure()
+	target, ok := p.incidents[id]
+	if !ok {
+		return nil, orcherr.New("not_found", "incident not found", nil)
+	}
+	if limit <= 0 {
+		limit = defaultSimilarLimit
+	}
+	now := p.now()
+	targetService := incidentService(target)
+	targetFamily := incidentFamily(target)
+	targetWords := incidentKeywords(target)
+
+	out := []SimilarIncident{}
+	for _, cand := range p.incidents {
+		if cand.ID == target.ID || !resolvedStatuses[cand.Status] || now.Sub(cand.CreatedAt) > historyWindow {
+			continue
+		}
+		match := SimilarIncident{}
+		if svc := incidentService(cand); svc != "" && svc == targetService {
+			match.Score += similarServiceWeight
+			match.Reasons = append(match.Reasons, "same service "+svc)
+		}
+		if family := incidentFamily(cand); family != "" && family == targetFamily {
+			match.Score += similarFamilyWeight
+			match.Reasons = append(match.Reasons, "same scenario family "+family)
+		}
+		candWords := incidentKeywords(cand)
+		if shared := sharedKeywords(targetWords, candWords); len(shared) > 0 {
+			// Overlap relative to the shorter text, so a terse title still matches
+			// a verbose one.
+			smaller := len(targetWords)
+			if len(candWords) < smaller {
+				smaller = len(candWords)
+			}
+			match.Score += similarKeywordWeight * float64(len(shared)) / float64(smaller)
+			match.SharedKeywords = shared
+			match.Reasons = append(match.Reasons, fmt.Sprintf("%d shared keywords", len(shared)))
+		}
+		if match.Score == 0 {
+			continue
+		}
+		match.Score = round2(match.Score)
+		inc := cloneIncident(cand)
+		p.applySLALocked(&inc, now)
+		p.presentSeverity(&inc)
+		match.Incident = inc
+		out = append(out, match)
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Score != out[j].Score {
+			return out[i].Score > out[j].Score
+		}
+		return out[i].Incident.CreatedAt.After(out[j].Incident.CreatedAt)
+	})
+	if len(out) > limit {
+		out = out[:limit]
+	}
+	return out, nil
+}
+
+func incidentService(inc schema.Incident) string {
+	if inc.Service != "" {
+		return inc.Service
+	}
+	svc, _ := inc.Fields["service"].(string)
+	return svc
+}
+
+// incidentFamily is the scenario an incident belongs to or resembles.
+func incidentFamily(inc schema.Incident) string {
+	if family, ok := inc.Fields["scenario_family"].(string); ok && family != "" {
+		return family
+	}
+	family, _ := inc.Fields["scenario_id"].(string)
+	return family
+}
+
+// incidentKeywords returns the distinct significant words of the title and
+// description, with a trailing plural "s" trimmed.
+func incidentKeywords(inc schema.Incident) map[string]bool {
+	words := map[string]bool{}
+	text := strings.ToLower(inc.Title + " " + inc.Description)
+	for _, word := range strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		if len(word) < 3 || similarStopwords[word] {
+			continue
+		}
+		if len(word) > 4 && strings.HasSuffix(word, "s") && !strings.HasSuffix(word, "ss") {
+			word = strings.TrimSuffix(word, "s")
+		}
+		words[word] = true
+	}
+	return words
+}
+
+func sharedKeywords(a, b map[string]bool) []string {
+	var out []string
+	for word := range a {
+		if b[word] {
+			out = append(out, word)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
@@ -0,0 +1,118 @@
+package incidentmock
+
+import (
+	"context"
+	"time"
+
+	"github.com/opsorch/opsorch-core/schema"
+)
+
+// SLATarget is the time allowed to acknowledge and resolve an incident of a given severity.
+type SLATarget struct {
+	Ack     time.Duration
+	Resolve time.Duration
+}
+
+// defaultSLATargets are per-severity response targets used to derive SLA fields.
+var defaultSLATargets = map[string]SLATarget{
+	"sev1": {Ack: 5 * time.Minute, Resolve: 4 * time.Hour},
+	"sev2": {Ack: 15 * time.Minute, Resolve: 8 * time.Hour},
+	"sev3": {Ack: 1 * time.Hour, Resolve: 24 * time.Hour},
+	"sev4": {Ack: 4 * time.Hour, Resolve: 72 * time.Hour},
+}
+
+// unacknowledgedStatuses have not yet been picked up by a responder.
+var unacknowledgedStatuses = map[string]bool{"open": true, "triggered": true}
+
+// resolvedStatuses stop the resolve clock.
+var resolvedStatuses = map[string]bool{"resolved": true, "closed": true}
+
+// WithBreachedOnly restricts Query/List to incidents that have breached an SLA target.
+func WithBreachedOnly(ctx context.Context) context.Context {
+	return context.WithValue(ctx, breachedOnlyKey{}, true)
+}
+
+type breachedOnlyKey struct{}
+
+func breachedOnly(ctx context.Context) bool {
+	if ctx == nil {
+		return false
+	}
+	v, _ := ctx.Value(breachedOnlyKey{}).(bool)
+	return v
+}
+
+// applySLALocked stamps derived SLA clock fields on an outgoing incident copy.
+// Callers must hold p.mu.
+func (p *Provider) applySLALocked(inc *schema.Incident, now time.Time) {
+	target, ok := defaultSLATargets[inc.Severity]
+	if !ok {
+		return
+	}
+
+	ackedAt, acked := p.acknowledgedAtLocked(*inc)
+	resolvedAt, resolved := resolvedAt(*inc)
+
+	ackClock := now.Sub(inc.CreatedAt)
+	if acked {
+		ackClock = ackedAt.Sub(inc.CreatedAt)
+	}
+	resolveClock := now.Sub(inc.CreatedAt)
+	if resolved {
+		resolveClock = resolvedAt.Sub(inc.CreatedAt)
+	}
+	ackBreached := ackClock > target.Ack
+	resolveBreached := resolveClock > target.Resolve
+
+	if inc.Fields == nil {
+		inc.Fields = map[string]any{}
+	}
+	if acked {
+		inc.Fields["timeToAck"] = ackClock.Round(time.Second).String()
+	}
+	if resolved {
+		inc.Fields["timeToResolve"] = resolveClock.Round(time.Second).String()
+	}
+	inc.Fields["slaBreached"] = ackBreached || resolveBreached
+	inc.Fields["sla"] = map[string]any{
+		"ackTarget":       target.Ack.String(),
+		"resolveTarget":   target.Resolve.String(),
+		"ackDueAt":        inc.CreatedAt.Add(target.Ack).Format(time.RFC3339),
+		"resolveDueAt":    inc.CreatedAt.Add(target.Resolve).Format(time.RFC3339),
+		"acknowledged":    acked,
+		"resolved":        resolved,
+		"ackBreached":     ackBreached,
+		"resolveBreached": resolveBreached,
+	}
+}
+
+// acknowledgedAtLocked finds when a responder picked up the incident: an explicit
+// acknowledgedAt field, else the first timeline entry by a user, else the last update.
+func (p *Provider) acknowledgedAtLocked(inc schema.Incident) (time.Time, bool) {
+	if v, ok := inc.Fields["acknowledgedAt"].(string); ok {
+		if t, err := time.Parse(time.RFC3339, v); err == nil {
+			return t, true
+		}
+	}
+	if unacknowledgedStatuses[inc.Status] {
+		return time.Time{}, false
+	}
+	for _, entry := range p.timeline[inc.ID] {
+		if actorType, _ := entry.Actor["type"].(string); actorType == "user" {
+			return entry.At, true
+		}
+	}
+	return inc.UpdatedAt, true
+}
+
+func resolvedAt(inc schema.Incident) (time.Time, bool) {
+	if !resolvedStatuses[inc.Status] {
+		return time.Time{}, false
+	}
+	if v, ok := inc.Fields["resolvedAt"].(string); ok {
+		if t, err := time.Parse(time.RFC3339, v); err == nil {
+			return t, true
+		}
+	}
+	return inc.UpdatedAt, true
+}
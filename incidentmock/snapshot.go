@@ -0,0 +1,76 @@
+package incidentmock
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/opsorch/opsorch-core/schema"
+	"github.com/opsorch/opsorch-mock-adapters/internal/bundle"
+)
+
+// Snapshot returns the provider's incidents sorted by ID and their timelines
+// keyed by incident ID, after applying any escalations that are due.
+func (p *Provider) Snapshot() ([]schema.Incident, map[string][]schema.TimelineEntry) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.history.Ensure()
+	p.escalateLocked(p.now())
+	incidents := make([]schema.Incident, 0, len(p.incidents))
+	for _, inc := range p.incidents {
+		incidents = append(incidents, cloneIncident(inc))
+	}
+	sort.Slice(incidents, func(i, j int) bool { return incidents[i].ID < incidents[j].ID })
+
+	timelines := make(map[string][]schema.TimelineEntry, len(p.timeline))
+	for id, entries := range p.timeline {
+		if len(entries) > 0 {
+			timelines[id] = cloneTimeline(entries)
+		}
+	}
+	return incidents, timelines
+}
+
+// Restore replaces the provider's incidents and timelines with a bundle's while it is
+// running, as the snapshot config does at startup. Sections the bundle leaves
+// empty keep their current records.
+func (p *Provider) Restore(b *bundle.Bundle) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.restore(b)
+}
+
+// restore replaces the seeded incidents and timelines with a bundle's and
+// continues incident numbering after the highest restored ID. Presence and
+// handoff records are kept only for incidents the bundle contains.
+func (p *Provider) restore(b *bundle.Bundle) {
+	if b == nil {
+		return
+	}
+	if len(b.Incidents) > 0 {
+		p.history.Skip()
+		p.incidents = make(map[string]schema.Incident, len(b.Incidents))
+		p.nextID = 0
+		for _, inc := range b.Incidents {
+			p.incidents[inc.ID] = cloneIncident(inc)
+			var n int
+			if _, err := fmt.Sscanf(inc.ID, "inc-%d", &n); err == nil && n > p.nextID {
+				p.nextID = n
+			}
+		}
+		p.timeline = map[string][]schema.TimelineEntry{}
+		for id := range p.participants {
+			if _, ok := p.incidents[id]; !ok {
+				delete(p.participants, id)
+			}
+		}
+		for id := range p.handoffs {
+			if _, ok := p.incidents[id]; !ok {
+				delete(p.handoffs, id)
+			}
+		}
+	}
+	for id, entries := range b.Timelines {
+		p.timeline[id] = cloneTimeline(entries)
+	}
+}
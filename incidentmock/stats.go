@@ -0,0 +1,28 @@
+package incidentmock
+
+import (
+	"context"
+
+	"github.com/opsorch/opsorch-core/schema"
+	"github.com/opsorch/opsorch-mock-adapters/internal/mockutil"
+)
+
+// Stats counts the incidents Query matches, ignoring query.Limit, by status,
+// severity (as labelled by the configured scheme), service, and team.
+func (p *Provider) Stats(ctx context.Context, query schema.IncidentQuery) (mockutil.Counts, error) {
+	query.Limit = 0
+	incidents, err := p.Query(ctx, query)
+	if err != nil {
+		return mockutil.Counts{}, err
+	}
+	counts := mockutil.NewCounts()
+	for _, inc := range incidents {
+		service := inc.Service
+		if service == "" {
+			service, _ = inc.Fields["service"].(string)
+		}
+		team, _ := inc.Fields["team"].(string)
+		counts.Add(inc.Status, inc.Severity, service, team)
+	}
+	return counts, nil
+}
@@ -0,0 +1,271 @@
+package incidentmock
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/opsorch/opsorch-core/orcherr"
+	"github.com/opsorch/opsorch-core/schema"
+)
+
+// Tags are stored in Fields["tags"] as "namespace:value" strings drawn from
+// the managed taxonomy, e.g. "cause:config-change" or "surface:checkout".
+const tagsField = "tags"
+
+// TagNamespace is one managed tag namespace and the values it allows.
+type TagNamespace struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Values      []string `json:"values"`
+}
+
+// tagTaxonomy is the managed taxonomy; tags outside it are rejected.
+var tagTaxonomy = []TagNamespace{
+	{
+		Name:        "cause",
+		Description: "What triggered the incident",
+		Values:      []string{"capacity", "code-defect", "config-change", "dependency", "deployment", "expired-credential", "infrastructure", "schema-change"},
+	},
+	{
+		Name:        "surface",
+		Description: "Customer-facing surface affected",
+		Values:      []string{"analytics", "catalog", "checkout", "login", "notifications", "orders", "payments", "realtime", "recommendations", "search", "shipping", "storefront", "warehouse"},
+	},
+}
+
+// serviceSurfaces is the surface tag added to incidents on a service when no
+// surface is given.
+var serviceSurfaces = map[string]string{
+	"svc-analytics":      "analytics",
+	"svc-catalog":        "catalog",
+	"svc-checkout":       "checkout",
+	"svc-database":       "orders",
+	"svc-identity":       "login",
+	"svc-notifications":  "notifications",
+	"svc-order":          "orders",
+	"svc-payments":       "payments",
+	"svc-realtime":       "realtime",
+	"svc-recommendation": "recommendations",
+	"svc-search":         "search",
+	"svc-shipping":       "shipping",
+	"svc-warehouse":      "warehouse",
+	"svc-web":            "storefront",
+}
+
+// seededTags tag the live seed incidents. The historical corpus is tagged by
+// historicalTags instead.
+var seededTags = map[string][]string{
+	"inc-001":           {"cause:deployment", "surface:checkout"},
+	"inc-002":           {"cause:capacity", "surface:search"},
+	"inc-003":           {"cause:dependency", "surface:payments"},
+	"inc-004":           {"cause:config-change", "surface:notifications"},
+	"inc-005":           {"cause:deployment", "surface:login"},
+	"inc-006":           {"cause:infrastructure", "surface:warehouse"},
+	"inc-007":           {"cause:deployment", "surface:recommendations"},
+	"inc-008":           {"cause:expired-credential", "surface:analytics"},
+	"inc-009":           {"cause:dependency", "surface:orders", "surface:payments"},
+	"inc-010":           {"cause:schema-change", "surface:catalog", "surface:search"},
+	"inc-011":           {"cause:infrastructure", "surface:shipping"},
+	"inc-012":           {"cause:code-defect", "surface:realtime"},
+	"inc-scenario-001":  {"cause:capacity", "surface:checkout"},
+	"inc-scenario-002":  {"cause:code-defect", "surface:checkout", "surface:orders", "surface:payments"},
+	"inc-scenario-003":  {"cause:deployment", "surface:payments"},
+	"inc-scenario-004":  {"cause:dependency", "surface:checkout", "surface:payments"},
+	"inc-scenario-005":  {"cause:capacity", "surface:search"},
+	"inc-scenario-006":  {"cause:dependency", "surface:checkout", "surface:recommendations"},
+	"inc-analytics-001": {"surface:analytics"},
+	"inc-payment-001":   {"surface:payments"},
+}
+
+// rootCauseTags map root cause wording in the historical corpus to a cause
+// tag; the first match wins, before falling back to familyCauses.
+var rootCauseTags = []struct{ word, cause string }{
+	{"config", "config-change"},
+	{"schema", "schema-change"},
+	{"credential", "expired-credential"},
+	{"leak", "code-defect"},
+	{"n+1", "code-defect"},
+	{"clock skew", "infrastructure"},
+	{"locked table", "infrastructure"},
+	{"backlog", "capacity"},
+}
+
+// familyCauses are the cause tags of each scenario family.
+var familyCauses = map[string]string{
+	"autoscaling-lag":             "capacity",
+	"cascading-failure":           "capacity",
+	"circuit-breaker-cascade":     "dependency",
+	"deployment-rollback":         "deployment",
+	"external-dependency-failure": "dependency",
+	"slo-exhaustion":              "capacity",
+}
+
+// WithTags restricts Query/List to incidents carrying every given tag. A bare
+// namespace such as "cause" matches any tag in it.
+func WithTags(ctx context.Context, tags ...string) context.Context {
+	return context.WithValue(ctx, tagsKey{}, tags)
+}
+
+type tagsKey struct{}
+
+func tagsFrom(ctx context.Context) []string {
+	if ctx == nil {
+		return nil
+	}
+	tags, _ := ctx.Value(tagsKey{}).([]string)
+	return tags
+}
+
+// TagTaxonomy lists the managed tag namespaces and their values.
+func (p *Provider) TagTaxonomy(ctx context.Context) []TagNamespace {
+	out := make([]TagNamespace, len(tagTaxonomy))
+	for i, ns := range tagTaxonomy {
+		ns.Values = append([]string(nil), ns.Values...)
+		out[i] = ns
+	}
+	return out
+}
+
+// TagStats counts the tags on the incidents Query matches, ignoring
+// query.Limit.
+func (p *Provider) TagStats(ctx context.Context, query schema.IncidentQuery) (map[string]int, error) {
+	query.Limit = 0
+	incidents, err := p.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	counts := map[string]int{}
+	for _, inc := range incidents {
+		for _, tag := range incidentTags(inc) {
+			counts[tag]++
+		}
+	}
+	return counts, nil
+}
+
+// normalizeTags validates the tags in fields against the taxonomy, rewriting
+// them in place as a sorted, de-duplicated []string. When service maps to a
+// surface and no surface tag is given, it is added.
+func normalizeTags(fields map[string]any, service string) error {
+	var raw []string
+	switch v := fields[tagsField].(type) {
+	case nil:
+	case []string:
+		raw = v
+	case []any:
+		for _, item := range v {
+			s, ok := item.(string)
+			if !ok {
+				return orcherr.New("bad_request", fmt.Sprintf("incident tags must be strings, got %v", item), nil)
+			}
+			raw = append(raw, s)
+		}
+	default:
+		return orcherr.New("bad_request", "incident tags must be a list of strings", nil)
+	}
+
+	seen := map[string]bool{}
+	tags := make([]string, 0, len(raw)+1)
+	hasSurface := false
+	for _, tag := range raw {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if !validTag(tag) {
+			return orcherr.New("bad_request", fmt.Sprintf("unknown incident tag %q; see the tag taxonomy", tag), nil)
+		}
+		if seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		hasSurface = hasSurface || strings.HasPrefix(tag, "surface:")
+		tags = append(tags, tag)
+	}
+	if surface, ok := serviceSurfaces[service]; ok && !hasSurface {
+		tags = append(tags, "surface:"+surface)
+	}
+	if len(tags) == 0 {
+		delete(fields, tagsField)
+		return nil
+	}
+	sort.Strings(tags)
+	fields[tagsField] = tags
+	return nil
+}
+
+func validTag(tag string) bool {
+	name, value, ok := strings.Cut(tag, ":")
+	if !ok {
+		return false
+	}
+	for _, ns := range tagTaxonomy {
+		if ns.Name != name {
+			continue
+		}
+		for _, v := range ns.Values {
+			if v == value {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// historicalTags tags a resolved incident from the corpus by its root cause,
+// scenario family, and service.
+func historicalTags(seed historicalSeed) []string {
+	var tags []string
+	cause := familyCauses[seed.family]
+	rootCause := strings.ToLower(seed.rootCause)
+	for _, rc := range rootCauseTags {
+		if strings.Contains(rootCause, rc.word) {
+			cause = rc.cause
+			break
+		}
+	}
+	if cause != "" {
+		tags = append(tags, "cause:"+cause)
+	}
+	if surface, ok := serviceSurfaces[seed.service]; ok {
+		tags = append(tags, "surface:"+surface)
+	}
+	return tags
+}
+
+// incidentTags reads Fields["tags"], whether stored by the provider or
+// restored from a snapshot as decoded JSON.
+func incidentTags(inc schema.Incident) []string {
+	switch v := inc.Fields[tagsField].(type) {
+	case []string:
+		return v
+	case []any:
+		tags := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				tags = append(tags, s)
+			}
+		}
+		return tags
+	}
+	return nil
+}
+
+// hasTags reports whether inc carries every wanted tag; a wanted bare
+// namespace matches any tag in that namespace.
+func hasTags(inc schema.Incident, wanted []string) bool {
+	tags := incidentTags(inc)
+	for _, want := range wanted {
+		want = strings.ToLower(strings.TrimSpace(want))
+		found := false
+		for _, tag := range tags {
+			if tag == want || strings.HasPrefix(tag, want+":") {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
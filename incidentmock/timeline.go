@@ -0,0 +1,200 @@
+package incidentmock
+
+import (
+	"encoding/base64"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/opsorch/opsorch-core/orcherr"
+	"github.com/opsorch/opsorch-core/schema"
+	"github.com/opsorch/opsorch-mock-adapters/internal/mockutil"
+)
+
+// Timeline entry kinds that carry structured content in Metadata beyond the
+// plain-text Body.
+const (
+	// TimelineKindStatusChange records a status transition: Metadata["from"], Metadata["to"].
+	TimelineKindStatusChange = "status_change"
+	// TimelineKindMetricSnapshot captures a reading: Metadata["metric"], ["value"], ["unit"], ["threshold"].
+	TimelineKindMetricSnapshot = "metric_snapshot"
+	// TimelineKindChart attaches an image in Metadata["attachment"], either
+	// inline ({"contentType", "encoding": "base64", "data"}) or by {"contentType", "url"}.
+	TimelineKindChart = "chart"
+	// TimelineKindCommandOutput records a command run during response:
+	// Metadata["command"], ["output"], ["exitCode"], ["host"].
+	TimelineKindCommandOutput = "command_output"
+)
+
+// validateTimelineEntry checks that rich kinds carry the metadata renderers
+// depend on. Other kinds are free-form.
+func validateTimelineEntry(entry schema.TimelineAppendInput) error {
+	md := entry.Metadata
+	switch entry.Kind {
+	case TimelineKindStatusChange:
+		if metadataString(md, "from") == "" || metadataString(md, "to") == "" {
+			return orcherr.New("bad_request", "status_change entries require metadata.from and metadata.to", nil)
+		}
+	case TimelineKindMetricSnapshot:
+		if metadataString(md, "metric") == "" {
+			return orcherr.New("bad_request", "metric_snapshot entries require metadata.metric", nil)
+		}
+		switch md["value"].(type) {
+		case float64, int, int64:
+		default:
+			return orcherr.New("bad_request", "metric_snapshot entries require a numeric metadata.value", nil)
+		}
+	case TimelineKindChart:
+		attachment, _ := md["attachment"].(map[string]any)
+		url := metadataString(attachment, "url")
+		data := metadataString(attachment, "data")
+		if (url == "") == (data == "") {
+			return orcherr.New("bad_request", "chart entries require metadata.attachment with exactly one of url or data", nil)
+		}
+		if data != "" {
+			if _, err := base64.StdEncoding.DecodeString(data); err != nil {
+				return orcherr.New("bad_request", "chart attachment data must be base64 encoded", nil)
+			}
+		}
+	case TimelineKindCommandOutput:
+		if metadataString(md, "command") == "" {
+			return orcherr.New("bad_request", "command_output entries require metadata.command", nil)
+		}
+		if _, ok := md["output"].(string); !ok {
+			return orcherr.New("bad_request", "command_output entries require metadata.output", nil)
+		}
+	}
+	return nil
+}
+
+func metadataString(md map[string]any, key string) string {
+	v, _ := md[key].(string)
+	return v
+}
+
+// sparklineChart renders values as a small SVG line chart and returns it as an
+// inline base64 attachment.
+func sparklineChart(values []float64) map[string]any {
+	const width, height = 240, 60
+	lo, hi := values[0], values[0]
+	for _, v := range values {
+		lo = min(lo, v)
+		hi = max(hi, v)
+	}
+	span := hi - lo
+	if span == 0 {
+		span = 1
+	}
+	points := make([]string, len(values))
+	for i, v := range values {
+		x := float64(i) * width / float64(max(len(values)-1, 1))
+		y := height - (v-lo)/span*height
+		points[i] = fmt.Sprintf("%.0f,%.0f", x, y)
+	}
+	svg := fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d"><polyline fill="none" stroke="#d9534f" stroke-width="2" points="%s"/></svg>`,
+		width, height, strings.Join(points, " "))
+	return map[string]any{
+		"contentType": "image/svg+xml",
+		"encoding":    "base64",
+		"data":        base64.StdEncoding.EncodeToString([]byte(svg)),
+	}
+}
+
+// chartURL links a chart rendered by the demo Grafana instance.
+func chartURL(panel, service string) map[string]any {
+	return map[string]any{
+		"contentType": "image/png",
+		"url":         fmt.Sprintf("https://grafana.demo.com/render/d-solo/%s?service=%s&width=800&height=300", panel, service),
+	}
+}
+
+// richScenarioTimelines adds structured entries to the scenario incident
+// timelines so hosts can exercise rendering of every entry kind.
+func richScenarioTimelines(now time.Time) map[string][]schema.TimelineEntry {
+	return map[string][]schema.TimelineEntry{
+		"inc-scenario-001": {
+			{At: now.Add(-44 * time.Minute), Kind: TimelineKindMetricSnapshot, Body: "Checkout error budget down to 3.2% remaining", Actor: mockutil.ActorRef("slo-monitor"),
+				Metadata: map[string]any{"metric": "slo:error_budget_remaining", "service": "svc-checkout", "value": 3.2, "unit": "percent", "threshold": 10.0, "window": "30d"}},
+			{At: now.Add(-40 * time.Minute), Kind: TimelineKindStatusChange, Body: "Status changed from triggered to investigating", Actor: mockutil.ActorRef("alex"),
+				Metadata: map[string]any{"from": "triggered", "to": "investigating"}},
+			{At: now.Add(-35 * time.Minute), Kind: TimelineKindChart, Body: "Checkout p95 latency, last 60 minutes", Actor: mockutil.ActorRef("alex"),
+				Metadata: map[string]any{"title": "svc-checkout p95 latency (ms)", "attachment": sparklineChart([]float64{640, 660, 700, 820, 1100, 1420, 1530, 1510, 1490})}},
+			{At: now.Add(-30 * time.Minute), Kind: TimelineKindStatusChange, Body: "Status changed from investigating to mitigating", Actor: mockutil.ActorRef("alex"),
+				Metadata: map[string]any{"from": "investigating", "to": "mitigating"}},
+		},
+		"inc-scenario-002": {
+			{At: now.Add(-28 * time.Minute), Kind: TimelineKindMetricSnapshot, Body: "Database connections at 498 of 500", Actor: mockutil.ActorRef("pg-exporter"),
+				Metadata: map[string]any{"metric": "pg_stat_activity_count", "service": "svc-database", "value": 498.0, "unit": "connections", "threshold": 450.0}},
+			{At: now.Add(-18 * time.Minute), Kind: TimelineKindCommandOutput, Body: "Connections held by checkout pods", Actor: mockutil.ActorRef("morgan"),
+				Metadata: map[string]any{
+					"command":  "psql -c \"select application_name, count(*) from pg_stat_activity group by 1 order by 2 desc limit 3\"",
+					"host":     "db-prod-primary",
+					"exitCode": 0,
+					"output":   " application_name | count\n------------------+-------\n checkout         |   412\n catalog          |    51\n orders           |    35\n(3 rows)\n",
+				}},
+			{At: now.Add(-5 * time.Minute), Kind: TimelineKindCommandOutput, Body: "Rolling restart of checkout", Actor: mockutil.ActorRef("morgan"),
+				Metadata: map[string]any{
+					"command":  "kubectl rollout restart deployment/checkout -n prod",
+					"host":     "bastion-use1",
+					"exitCode": 0,
+					"output":   "deployment.apps/checkout restarted\n",
+				}},
+		},
+		"inc-scenario-003": {
+			{At: now.Add(-88 * time.Minute), Kind: TimelineKindChart, Body: "Payment error rate since v2.8.3 rollout", Actor: mockutil.ActorRef("grafana"),
+				Metadata: map[string]any{"title": "svc-payments 5xx rate", "attachment": chartURL("payments-errors", "svc-payments")}},
+			{At: now.Add(-61 * time.Minute), Kind: TimelineKindCommandOutput, Body: "Rollback to v2.8.2", Actor: mockutil.ActorRef("sam"),
+				Metadata: map[string]any{
+					"command":  "kubectl rollout undo deployment/payment-service -n prod --to-revision=41",
+					"host":     "bastion-use1",
+					"exitCode": 0,
+					"output":   "deployment.apps/payment-service rolled back\n",
+				}},
+			{At: now.Add(-20 * time.Minute), Kind: TimelineKindStatusChange, Body: "Status changed from mitigating to monitoring", Actor: mockutil.ActorRef("sam"),
+				Metadata: map[string]any{"from": "mitigating", "to": "monitoring"}},
+		},
+		"inc-scenario-004": {
+			{At: now.Add(-9 * time.Minute), Kind: TimelineKindCommandOutput, Body: "Probe of Stripe charges API", Actor: mockutil.ActorRef("fern"),
+				Metadata: map[string]any{
+					"command":  "curl -sI https://api.stripe.com/v1/charges",
+					"host":     "checkout-7c9f6-abcde",
+					"exitCode": 0,
+					"output":   "HTTP/2 429\nretry-after: 2\nstripe-should-retry: true\n",
+				}},
+			{At: now.Add(-7 * time.Minute), Kind: TimelineKindMetricSnapshot, Body: "18% of Stripe calls rate limited", Actor: mockutil.ActorRef("apm"),
+				Metadata: map[string]any{"metric": "external_requests_total:429_ratio", "service": "svc-checkout", "value": 18.0, "unit": "percent", "threshold": 1.0}},
+		},
+		"inc-scenario-005": {
+			{At: now.Add(-11 * time.Minute), Kind: TimelineKindChart, Body: "Search replicas vs. request rate", Actor: mockutil.ActorRef("grafana"),
+				Metadata: map[string]any{"title": "svc-search requests per second", "attachment": sparklineChart([]float64{120, 125, 130, 410, 690, 720, 715})}},
+			{At: now.Add(-4 * time.Minute), Kind: TimelineKindCommandOutput, Body: "Manual scale-out of search", Actor: mockutil.ActorRef("lena"),
+				Metadata: map[string]any{
+					"command":  "kubectl scale deployment/search -n prod --replicas=8",
+					"host":     "bastion-use1",
+					"exitCode": 0,
+					"output":   "deployment.apps/search scaled\n",
+				}},
+			{At: now.Add(-3 * time.Minute), Kind: TimelineKindStatusChange, Body: "Status changed from investigating to mitigating", Actor: mockutil.ActorRef("lena"),
+				Metadata: map[string]any{"from": "investigating", "to": "mitigating"}},
+		},
+		"inc-scenario-006": {
+			{At: now.Add(-5 * time.Minute), Kind: TimelineKindMetricSnapshot, Body: "Recommendation inference p99 at 4.8s", Actor: mockutil.ActorRef("apm"),
+				Metadata: map[string]any{"metric": "model_inference_duration_seconds:p99", "service": "svc-recommendation", "value": 4.8, "unit": "seconds", "threshold": 1.0}},
+			{At: now.Add(-3 * time.Minute), Kind: TimelineKindChart, Body: "Open circuit breakers by caller", Actor: mockutil.ActorRef("grafana"),
+				Metadata: map[string]any{"title": "Circuit breaker state", "attachment": chartURL("circuit-breakers", "svc-recommendation")}},
+		},
+	}
+}
+
+// mergeTimeline folds extra entries into an incident's timeline in time order
+// and renumbers IDs so they stay sequential.
+func mergeTimeline(id string, base, extra []schema.TimelineEntry) []schema.TimelineEntry {
+	merged := append(append([]schema.TimelineEntry{}, base...), extra...)
+	sort.SliceStable(merged, func(i, j int) bool { return merged[i].At.Before(merged[j].At) })
+	for i := range merged {
+		merged[i].ID = fmt.Sprintf("%s-t%d", id, i+1)
+		merged[i].IncidentID = id
+	}
+	return merged
+}
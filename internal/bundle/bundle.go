@@ -0,0 +1,180 @@
+// Package bundle defines the snapshot format for sharing mock provider state.
+// A bundle holds the records of the stateful providers and can be written as
+// JSON or YAML; providers given a "snapshot" config path start from it instead
+// of their seeded data.
+package bundle
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/opsorch/opsorch-core/schema"
+)
+
+// Version is the bundle format version written by this package.
+const Version = 1
+
+// ConfigKey is the provider config key naming a bundle file to load at startup.
+const ConfigKey = "snapshot"
+
+// PersistConfigKey is the provider config key naming a bundle file a plugin
+// keeps its state in. Providers start from it, ahead of any snapshot, once it
+// exists, and plugins write their section back to it on shutdown.
+const PersistConfigKey = "persist"
+
+// Supported encodings.
+const (
+	FormatJSON = "json"
+	FormatYAML = "yaml"
+)
+
+// Bundle is a point-in-time copy of the mock universe. Sections left empty are
+// not restored, so the provider keeps its seeded records for them.
+type Bundle struct {
+	Version     int                               `json:"version"`
+	GeneratedAt time.Time                         `json:"generatedAt"`
+	Alerts      []schema.Alert                    `json:"alerts,omitempty"`
+	Incidents   []schema.Incident                 `json:"incidents,omitempty"`
+	Timelines   map[string][]schema.TimelineEntry `json:"timelines,omitempty"`
+	Tickets     []schema.Ticket                   `json:"tickets,omitempty"`
+	Deployments []schema.Deployment               `json:"deployments,omitempty"`
+	Plans       []schema.OrchestrationPlan        `json:"plans,omitempty"`
+	Runs        []schema.OrchestrationRun         `json:"runs,omitempty"`
+}
+
+// FormatForPath picks the encoding from a file extension, defaulting to JSON.
+func FormatForPath(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return FormatYAML
+	default:
+		return FormatJSON
+	}
+}
+
+// Encode writes b in the given format.
+func Encode(w io.Writer, b *Bundle, format string) error {
+	raw, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return err
+	}
+	switch format {
+	case FormatJSON, "":
+		raw = append(raw, '\n')
+		_, err = w.Write(raw)
+		return err
+	case FormatYAML:
+		tree, err := decodeJSONTree(raw)
+		if err != nil {
+			return err
+		}
+		_, err = io.WriteString(w, encodeYAML(tree))
+		return err
+	default:
+		return fmt.Errorf("unsupported bundle format %q", format)
+	}
+}
+
+// Decode reads a bundle in the given format.
+func Decode(r io.Reader, format string) (*Bundle, error) {
+	raw, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	if format == FormatYAML {
+		tree, err := decodeYAML(string(raw))
+		if err != nil {
+			return nil, err
+		}
+		if raw, err = json.Marshal(tree); err != nil {
+			return nil, err
+		}
+	} else if format != FormatJSON && format != "" {
+		return nil, fmt.Errorf("unsupported bundle format %q", format)
+	}
+
+	var b Bundle
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&b); err != nil {
+		return nil, fmt.Errorf("decode bundle: %w", err)
+	}
+	if b.Version > Version {
+		return nil, fmt.Errorf("bundle version %d is newer than supported version %d", b.Version, Version)
+	}
+	return &b, nil
+}
+
+// ReadFile decodes the bundle at path, choosing the format by extension.
+func ReadFile(path string) (*Bundle, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Decode(f, FormatForPath(path))
+}
+
+// WriteFile encodes b to path, choosing the format by extension. It writes a
+// temporary file beside path and renames it, so readers never see half a
+// bundle.
+func WriteFile(path string, b *Bundle) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if err := Encode(tmp, b, FormatForPath(path)); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// PersistPath returns the file named by cfg[PersistConfigKey], or "".
+func PersistPath(cfg map[string]any) string {
+	path, _ := cfg[PersistConfigKey].(string)
+	return path
+}
+
+var (
+	loadedMu sync.Mutex
+	loaded   = map[string]*Bundle{}
+)
+
+// FromConfig returns the bundle named by cfg[PersistConfigKey] when that file
+// exists, else the one named by cfg[ConfigKey], or nil when neither is set.
+// Files are read once per process and shared across providers, which must
+// treat the result as read-only.
+func FromConfig(cfg map[string]any) (*Bundle, error) {
+	path, _ := cfg[ConfigKey].(string)
+	if persist := PersistPath(cfg); persist != "" {
+		if _, err := os.Stat(persist); err == nil {
+			path = persist
+		}
+	}
+	if path == "" {
+		return nil, nil
+	}
+	loadedMu.Lock()
+	defer loadedMu.Unlock()
+	if b, ok := loaded[path]; ok {
+		return b, nil
+	}
+	b, err := ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("load snapshot %s: %w", path, err)
+	}
+	loaded[path] = b
+	return b, nil
+}
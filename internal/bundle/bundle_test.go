@@ -0,0 +1,165 @@
+package bundle_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/opsorch/opsorch-core/schema"
+	"github.com/opsorch/opsorch-mock-adapters/incidentmock"
+	"github.com/opsorch/opsorch-mock-adapters/internal/bundle"
+	"github.com/opsorch/opsorch-mock-adapters/ticketmock"
+)
+
+func sampleBundle() *bundle.Bundle {
+	at := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	return &bundle.Bundle{
+		Version:     bundle.Version,
+		GeneratedAt: at,
+		Incidents: []schema.Incident{{
+			ID:          "inc-042",
+			Title:       "Checkout: \"payments\" timing out",
+			Description: "line one\nline two # not a comment",
+			Status:      "investigating",
+			Severity:    "sev2",
+			Service:     "svc-checkout",
+			CreatedAt:   at,
+			UpdatedAt:   at,
+			Fields:      map[string]any{"team": "team-velocity", "impactPercent": 12.5, "tags": []any{"eu", "true"}, "empty": map[string]any{}},
+		}},
+		Timelines: map[string][]schema.TimelineEntry{
+			"inc-042": {{ID: "inc-042-t1", IncidentID: "inc-042", At: at, Kind: "note", Body: "yes: really"}},
+		},
+		Tickets: []schema.Ticket{{ID: "TCK-077", Title: "Follow up", Status: "open", CreatedAt: at, UpdatedAt: at}},
+	}
+}
+
+func TestEncodeDecodeRoundTrip(t *testing.T) {
+	for _, format := range []string{bundle.FormatJSON, bundle.FormatYAML} {
+		var buf bytes.Buffer
+		if err := bundle.Encode(&buf, sampleBundle(), format); err != nil {
+			t.Fatalf("%s encode: %v", format, err)
+		}
+		got, err := bundle.Decode(&buf, format)
+		if err != nil {
+			t.Fatalf("%s decode: %v", format, err)
+		}
+		inc := got.Incidents[0]
+		if inc.Title != `Checkout: "payments" timing out` || inc.Description != "line one\nline two # not a comment" {
+			t.Errorf("%s: strings not preserved: %+v", format, inc)
+		}
+		if inc.Fields["impactPercent"] != 12.5 || inc.Fields["tags"].([]any)[1] != "true" {
+			t.Errorf("%s: fields not preserved: %+v", format, inc.Fields)
+		}
+		if got.Timelines["inc-042"][0].Body != "yes: really" || !got.GeneratedAt.Equal(sampleBundle().GeneratedAt) {
+			t.Errorf("%s: timeline or timestamp not preserved: %+v", format, got)
+		}
+	}
+}
+
+func TestDecodeYAMLHandEdited(t *testing.T) {
+	src := `# shared demo state
+version: 1
+generatedAt: 2024-03-01T12:00:00Z
+tickets:
+- id: TCK-100
+  title: Hand written ticket
+  status: open
+  createdAt: "2024-03-01T12:00:00Z"
+  updatedAt: "2024-03-01T12:00:00Z"
+`
+	b, err := bundle.Decode(strings.NewReader(src), bundle.FormatYAML)
+	if err != nil {
+		t.Fatalf("Decode returned error: %v", err)
+	}
+	if len(b.Tickets) != 1 || b.Tickets[0].Title != "Hand written ticket" {
+		t.Fatalf("unexpected tickets: %+v", b.Tickets)
+	}
+
+	if _, err := bundle.Decode(strings.NewReader("version: 1\nunknown: true\n"), bundle.FormatYAML); err == nil {
+		t.Error("expected error for unknown section")
+	}
+	if _, err := bundle.Decode(strings.NewReader(`{"version": 99}`), bundle.FormatJSON); err == nil {
+		t.Error("expected error for newer bundle version")
+	}
+}
+
+func TestProvidersRestoreFromSnapshot(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "demo.yaml")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := bundle.Encode(f, sampleBundle(), bundle.FormatForPath(path)); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	ctx := context.Background()
+	incAny, err := incidentmock.New(map[string]any{bundle.ConfigKey: path})
+	if err != nil {
+		t.Fatalf("incidentmock.New returned error: %v", err)
+	}
+	incidents, _ := incAny.Query(ctx, schema.IncidentQuery{})
+	if len(incidents) != 1 || incidents[0].ID != "inc-042" {
+		t.Fatalf("expected only the restored incident, got %d", len(incidents))
+	}
+	timeline, _ := incAny.GetTimeline(ctx, "inc-042")
+	if len(timeline) != 1 {
+		t.Fatalf("expected restored timeline, got %+v", timeline)
+	}
+	created, _ := incAny.Create(ctx, schema.CreateIncidentInput{Title: "new", Severity: "sev3", Service: "svc-search"})
+	if created.ID != "inc-043" {
+		t.Errorf("expected numbering to continue after restored IDs, got %s", created.ID)
+	}
+
+	tkAny, err := ticketmock.New(map[string]any{bundle.ConfigKey: path})
+	if err != nil {
+		t.Fatalf("ticketmock.New returned error: %v", err)
+	}
+	if got := tkAny.(*ticketmock.Provider).Snapshot(); len(got) != 1 || got[0].ID != "TCK-077" {
+		t.Fatalf("expected restored ticket, got %+v", got)
+	}
+
+	if _, err := ticketmock.New(map[string]any{bundle.ConfigKey: filepath.Join(t.TempDir(), "missing.json")}); err == nil {
+		t.Error("expected error for missing snapshot file")
+	}
+}
+
+func TestPersistFileTakesPrecedenceOnceWritten(t *testing.T) {
+	dir := t.TempDir()
+	snapshot := filepath.Join(dir, "demo.json")
+	if err := bundle.WriteFile(snapshot, sampleBundle()); err != nil {
+		t.Fatalf("WriteFile returned error: %v", err)
+	}
+	persist := filepath.Join(dir, "tickets.yaml")
+	cfg := map[string]any{bundle.ConfigKey: snapshot, bundle.PersistConfigKey: persist}
+
+	first, err := ticketmock.New(cfg)
+	if err != nil {
+		t.Fatalf("ticketmock.New returned error: %v", err)
+	}
+	if got := first.(*ticketmock.Provider).Snapshot(); len(got) != 1 || got[0].ID != "TCK-077" {
+		t.Fatalf("expected the snapshot before anything is persisted, got %+v", got)
+	}
+
+	at := time.Date(2024, 3, 2, 9, 0, 0, 0, time.UTC)
+	saved := &bundle.Bundle{Version: bundle.Version, GeneratedAt: at, Tickets: []schema.Ticket{{ID: "TCK-078", Title: "Persisted", Status: "open", CreatedAt: at, UpdatedAt: at}}}
+	if err := bundle.WriteFile(persist, saved); err != nil {
+		t.Fatalf("WriteFile returned error: %v", err)
+	}
+	second, err := ticketmock.New(cfg)
+	if err != nil {
+		t.Fatalf("ticketmock.New returned error: %v", err)
+	}
+	if got := second.(*ticketmock.Provider).Snapshot(); len(got) != 1 || got[0].ID != "TCK-078" {
+		t.Fatalf("expected the persisted state, got %+v", got)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 2 {
+		t.Fatalf("expected no temporary files left behind, got %d entries", len(entries))
+	}
+}
@@ -0,0 +1,394 @@
+package bundle
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// The YAML support covers the block-style subset encodeYAML produces: nested
+// mappings and sequences, double-quoted strings, numbers, booleans, and null.
+// Hand-edited files may also use plain unquoted strings and # comments.
+
+// orderedMap keeps JSON object keys in document order so YAML output follows
+// struct field order instead of sorting.
+type orderedMap struct {
+	keys []string
+	vals map[string]any
+}
+
+func (m *orderedMap) set(key string, val any) {
+	if _, ok := m.vals[key]; !ok {
+		m.keys = append(m.keys, key)
+	}
+	m.vals[key] = val
+}
+
+func (m *orderedMap) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, k := range m.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, _ := json.Marshal(k)
+		val, err := json.Marshal(m.vals[k])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(val)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+func decodeJSONTree(raw []byte) (any, error) {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	return decodeJSONValue(dec)
+}
+
+func decodeJSONValue(dec *json.Decoder) (any, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch t := tok.(type) {
+	case json.Delim:
+		switch t {
+		case '{':
+			m := &orderedMap{vals: map[string]any{}}
+			for dec.More() {
+				keyTok, err := dec.Token()
+				if err != nil {
+					return nil, err
+				}
+				val, err := decodeJSONValue(dec)
+				if err != nil {
+					return nil, err
+				}
+				m.set(keyTok.(string), val)
+			}
+			_, err := dec.Token()
+			return m, err
+		case '[':
+			list := []any{}
+			for dec.More() {
+				val, err := decodeJSONValue(dec)
+				if err != nil {
+					return nil, err
+				}
+				list = append(list, val)
+			}
+			_, err := dec.Token()
+			return list, err
+		}
+	}
+	return tok, nil
+}
+
+var plainKey = regexp.MustCompile(`^[A-Za-z0-9_][A-Za-z0-9_./-]*$`)
+
+func encodeYAML(tree any) string {
+	var b strings.Builder
+	writeYAMLBlock(&b, tree, 0)
+	return b.String()
+}
+
+// writeYAMLBlock writes a mapping or sequence as indented lines, or a scalar
+// followed by a newline.
+func writeYAMLBlock(b *strings.Builder, v any, indent int) {
+	pad := strings.Repeat(" ", indent)
+	switch val := v.(type) {
+	case *orderedMap:
+		if len(val.keys) == 0 {
+			b.WriteString(pad + "{}\n")
+			return
+		}
+		for _, k := range val.keys {
+			b.WriteString(pad + yamlKey(k) + ":")
+			writeYAMLChild(b, val.vals[k], indent)
+		}
+	case []any:
+		if len(val) == 0 {
+			b.WriteString(pad + "[]\n")
+			return
+		}
+		for _, item := range val {
+			// Mappings start on the dash line: "- key: value".
+			if m, ok := item.(*orderedMap); ok && len(m.keys) > 0 {
+				var child strings.Builder
+				writeYAMLBlock(&child, m, indent+2)
+				b.WriteString(pad + "- " + child.String()[indent+2:])
+				continue
+			}
+			b.WriteString(pad + "-")
+			writeYAMLChild(b, item, indent)
+		}
+	default:
+		b.WriteString(pad + yamlScalar(val) + "\n")
+	}
+}
+
+// writeYAMLChild finishes a "key:" or "-" line: scalars and empty collections
+// stay on the line, everything else nests two spaces deeper.
+func writeYAMLChild(b *strings.Builder, v any, indent int) {
+	switch val := v.(type) {
+	case *orderedMap:
+		if len(val.keys) == 0 {
+			b.WriteString(" {}\n")
+			return
+		}
+	case []any:
+		if len(val) == 0 {
+			b.WriteString(" []\n")
+			return
+		}
+	default:
+		b.WriteString(" " + yamlScalar(val) + "\n")
+		return
+	}
+	b.WriteString("\n")
+	writeYAMLBlock(b, v, indent+2)
+}
+
+func yamlKey(k string) string {
+	if plainKey.MatchString(k) && !isReservedScalar(k) {
+		return k
+	}
+	return strconv.Quote(k)
+}
+
+func yamlScalar(v any) string {
+	switch val := v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return strconv.FormatBool(val)
+	case json.Number:
+		return val.String()
+	case string:
+		// JSON string escapes are valid in YAML double-quoted scalars.
+		raw, _ := json.Marshal(val)
+		return string(raw)
+	default:
+		raw, _ := json.Marshal(val)
+		return string(raw)
+	}
+}
+
+func isReservedScalar(s string) bool {
+	switch strings.ToLower(s) {
+	case "null", "~", "true", "false", "yes", "no", "on", "off":
+		return true
+	}
+	_, err := strconv.ParseFloat(s, 64)
+	return err == nil
+}
+
+type yamlLine struct {
+	num    int
+	indent int
+	text   string
+}
+
+type yamlParser struct {
+	lines []yamlLine
+	pos   int
+}
+
+func decodeYAML(src string) (any, error) {
+	p := &yamlParser{}
+	for i, raw := range strings.Split(src, "\n") {
+		trimmed := strings.TrimRight(raw, " \t\r")
+		text := strings.TrimLeft(trimmed, " ")
+		if text == "" || strings.HasPrefix(text, "#") || text == "---" {
+			continue
+		}
+		if strings.HasPrefix(text, "\t") {
+			return nil, fmt.Errorf("yaml line %d: tabs are not allowed for indentation", i+1)
+		}
+		p.lines = append(p.lines, yamlLine{num: i + 1, indent: len(trimmed) - len(text), text: text})
+	}
+	if len(p.lines) == 0 {
+		return nil, fmt.Errorf("yaml document is empty")
+	}
+	v, err := p.parseBlock(p.lines[0].indent)
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.lines) {
+		return nil, fmt.Errorf("yaml line %d: unexpected indentation", p.lines[p.pos].num)
+	}
+	return v, nil
+}
+
+func (p *yamlParser) parseBlock(indent int) (any, error) {
+	line := p.lines[p.pos]
+	if line.text == "-" || strings.HasPrefix(line.text, "- ") {
+		return p.parseSequence(indent)
+	}
+	if _, _, ok, err := splitKey(line.text); err != nil {
+		return nil, fmt.Errorf("yaml line %d: %w", line.num, err)
+	} else if !ok {
+		p.pos++
+		return parseScalar(line.text)
+	}
+	return p.parseMapping(indent)
+}
+
+func (p *yamlParser) parseSequence(indent int) (any, error) {
+	list := []any{}
+	for p.pos < len(p.lines) {
+		line := p.lines[p.pos]
+		if line.indent != indent || !(line.text == "-" || strings.HasPrefix(line.text, "- ")) {
+			break
+		}
+		rest := strings.TrimLeft(strings.TrimPrefix(line.text, "-"), " ")
+		if rest == "" {
+			p.pos++
+			item, err := p.parseNested(indent)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, item)
+			continue
+		}
+		// "- key: value" opens a mapping whose keys align with "key".
+		childIndent := indent + len(line.text) - len(rest)
+		p.lines[p.pos] = yamlLine{num: line.num, indent: childIndent, text: rest}
+		item, err := p.parseBlock(childIndent)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, item)
+	}
+	return list, nil
+}
+
+func (p *yamlParser) parseMapping(indent int) (any, error) {
+	m := &orderedMap{vals: map[string]any{}}
+	for p.pos < len(p.lines) {
+		line := p.lines[p.pos]
+		if line.indent < indent {
+			break
+		}
+		if line.indent > indent {
+			return nil, fmt.Errorf("yaml line %d: unexpected indentation", line.num)
+		}
+		if line.text == "-" || strings.HasPrefix(line.text, "- ") {
+			break
+		}
+		key, rest, ok, err := splitKey(line.text)
+		if err != nil {
+			return nil, fmt.Errorf("yaml line %d: %w", line.num, err)
+		}
+		if !ok {
+			break
+		}
+		p.pos++
+		var val any
+		if rest == "" {
+			if val, err = p.parseNested(indent); err != nil {
+				return nil, err
+			}
+		} else if val, err = parseScalar(rest); err != nil {
+			return nil, fmt.Errorf("yaml line %d: %w", line.num, err)
+		}
+		m.set(key, val)
+	}
+	return m, nil
+}
+
+// parseNested reads the block under a "key:" or "-" line. A sequence may sit
+// at the parent's indentation; anything else must be indented further.
+func (p *yamlParser) parseNested(parent int) (any, error) {
+	if p.pos >= len(p.lines) {
+		return nil, nil
+	}
+	next := p.lines[p.pos]
+	isSeq := next.text == "-" || strings.HasPrefix(next.text, "- ")
+	if next.indent > parent || (next.indent == parent && isSeq) {
+		return p.parseBlock(next.indent)
+	}
+	return nil, nil
+}
+
+// splitKey splits "key: value" into its parts. ok is false when text is a
+// scalar rather than a mapping entry.
+func splitKey(text string) (key, rest string, ok bool, err error) {
+	if strings.HasPrefix(text, `"`) {
+		end := closingQuote(text)
+		if end < 0 {
+			return "", "", false, fmt.Errorf("unterminated string")
+		}
+		after := text[end+1:]
+		if !strings.HasPrefix(after, ":") || (len(after) > 1 && after[1] != ' ') {
+			return "", "", false, nil
+		}
+		if err := json.Unmarshal([]byte(text[:end+1]), &key); err != nil {
+			return "", "", false, err
+		}
+		return key, strings.TrimSpace(after[1:]), true, nil
+	}
+	if strings.HasPrefix(text, "[") || strings.HasPrefix(text, "{") {
+		return "", "", false, nil
+	}
+	if i := strings.Index(text, ": "); i > 0 {
+		return text[:i], strings.TrimSpace(text[i+2:]), true, nil
+	}
+	if strings.HasSuffix(text, ":") {
+		return strings.TrimSuffix(text, ":"), "", true, nil
+	}
+	return "", "", false, nil
+}
+
+func closingQuote(s string) int {
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '"':
+			return i
+		}
+	}
+	return -1
+}
+
+func parseScalar(text string) (any, error) {
+	switch text {
+	case "null", "~":
+		return nil, nil
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	case "{}":
+		return &orderedMap{vals: map[string]any{}}, nil
+	case "[]":
+		return []any{}, nil
+	}
+	if strings.HasPrefix(text, `"`) {
+		var s string
+		if err := json.Unmarshal([]byte(text), &s); err != nil {
+			return nil, fmt.Errorf("invalid quoted string %s", text)
+		}
+		return s, nil
+	}
+	if strings.HasPrefix(text, "'") && strings.HasSuffix(text, "'") && len(text) >= 2 {
+		return strings.ReplaceAll(text[1:len(text)-1], "''", "'"), nil
+	}
+	if _, err := strconv.ParseFloat(text, 64); err == nil {
+		return json.Number(text), nil
+	}
+	// Strip a trailing comment from plain scalars.
+	if i := strings.Index(text, " #"); i >= 0 {
+		text = strings.TrimSpace(text[:i])
+	}
+	return text, nil
+}
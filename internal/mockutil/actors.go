@@ -0,0 +1,157 @@
+package mockutil
+
+import "sort"
+
+// Actor types. Humans are "user" so existing actor maps keep their type.
+const (
+	ActorHuman      = "user"
+	ActorBot        = "bot"
+	ActorAutomation = "automation"
+	ActorSystem     = "system"
+)
+
+// Actor is the canonical identity of someone or something that acts in the
+// mock data: on-call engineers, chat bots, deploy automation, and the
+// monitoring systems that open incidents.
+type Actor struct {
+	// ID is the handle every provider refers to the actor by.
+	ID          string `json:"id"`
+	DisplayName string `json:"displayName"`
+	Type        string `json:"type"`
+	Email       string `json:"email,omitempty"`
+	Team        string `json:"team,omitempty"`
+	Avatar      string `json:"avatar"`
+}
+
+// actorSeeds lists every actor named in the provider seeds. Team leads use
+// their directory IDs; responders go by their first name.
+var actorSeeds = []Actor{
+	// People.
+	{ID: "alice.johnson", DisplayName: "Alice Johnson", Type: ActorHuman, Email: "alice.johnson@opsorch.com", Team: "engineering"},
+	{ID: "charlie.brown", DisplayName: "Charlie Brown", Type: ActorHuman, Email: "charlie.brown@opsorch.com", Team: "team-velocity"},
+	{ID: "diana.prince", DisplayName: "Diana Prince", Type: ActorHuman, Email: "diana.prince@opsorch.com", Team: "team-velocity"},
+	{ID: "alex", DisplayName: "Alex Rivera", Type: ActorHuman, Email: "alex.rivera@opsorch.com", Team: "team-velocity"},
+	{ID: "eve.wilson", DisplayName: "Eve Wilson", Type: ActorHuman, Email: "eve.wilson@opsorch.com", Team: "team-aurora"},
+	{ID: "jamie", DisplayName: "Jamie Torres", Type: ActorHuman, Email: "jamie.torres@opsorch.com", Team: "team-aurora"},
+	{ID: "frank.miller", DisplayName: "Frank Miller", Type: ActorHuman, Email: "frank.miller@opsorch.com", Team: "team-revenue"},
+	{ID: "sam", DisplayName: "Sam Okafor", Type: ActorHuman, Email: "sam.okafor@opsorch.com", Team: "team-revenue"},
+	{ID: "kim", DisplayName: "Kim Andersen", Type: ActorHuman, Email: "kim.andersen@opsorch.com", Team: "team-revenue"},
+	{ID: "grace.hopper", DisplayName: "Grace Hopper", Type: ActorHuman, Email: "grace.hopper@opsorch.com", Team: "team-signal"},
+	{ID: "lee", DisplayName: "Lee Sandoval", Type: ActorHuman, Email: "lee.sandoval@opsorch.com", Team: "team-signal"},
+	{ID: "taylor", DisplayName: "Taylor Nguyen", Type: ActorHuman, Email: "taylor.nguyen@opsorch.com", Team: "team-signal"},
+	{ID: "henry.ford", DisplayName: "Henry Ford", Type: ActorHuman, Email: "henry.ford@opsorch.com", Team: "team-guardian"},
+	{ID: "devon", DisplayName: "Devon Brooks", Type: ActorHuman, Email: "devon.brooks@opsorch.com", Team: "team-guardian"},
+	{ID: "iris.chang", DisplayName: "Iris Chang", Type: ActorHuman, Email: "iris.chang@opsorch.com", Team: "team-foundry"},
+	{ID: "morgan", DisplayName: "Morgan Chen", Type: ActorHuman, Email: "morgan.chen@opsorch.com", Team: "team-foundry"},
+	{ID: "maya", DisplayName: "Maya Singh", Type: ActorHuman, Email: "maya.singh@opsorch.com", Team: "team-foundry"},
+	{ID: "jack.sparrow", DisplayName: "Jack Sparrow", Type: ActorHuman, Email: "jack.sparrow@opsorch.com", Team: "team-orion"},
+	{ID: "riley", DisplayName: "Riley Adams", Type: ActorHuman, Email: "riley.adams@opsorch.com", Team: "team-orion"},
+	{ID: "kate.bishop", DisplayName: "Kate Bishop", Type: ActorHuman, Email: "kate.bishop@opsorch.com", Team: "team-atlas"},
+	{ID: "casey", DisplayName: "Casey Park", Type: ActorHuman, Email: "casey.park@opsorch.com", Team: "team-atlas"},
+	{ID: "luke.cage", DisplayName: "Luke Cage", Type: ActorHuman, Email: "luke.cage@opsorch.com", Team: "team-hawkeye"},
+	{ID: "alexis", DisplayName: "Alexis Moreau", Type: ActorHuman, Email: "alexis.moreau@opsorch.com", Team: "team-hawkeye"},
+	{ID: "maria.hill", DisplayName: "Maria Hill", Type: ActorHuman, Email: "maria.hill@opsorch.com", Team: "team-nova"},
+	{ID: "samir", DisplayName: "Samir Haddad", Type: ActorHuman, Email: "samir.haddad@opsorch.com", Team: "team-nova"},
+	{ID: "jordan", DisplayName: "Jordan Blake", Type: ActorHuman, Email: "jordan.blake@opsorch.com", Team: "team-platform"},
+	{ID: "priya", DisplayName: "Priya Natarajan", Type: ActorHuman, Email: "priya.natarajan@opsorch.com", Team: "team-platform"},
+	{ID: "rosa", DisplayName: "Rosa Delgado", Type: ActorHuman, Email: "rosa.delgado@opsorch.com", Team: "team-platform"},
+	// Responders who appear in history and logs but not the directory.
+	{ID: "lena", DisplayName: "Lena Fischer", Type: ActorHuman, Email: "lena.fischer@opsorch.com", Team: "team-aurora"},
+	{ID: "fern", DisplayName: "Fern Whitaker", Type: ActorHuman, Email: "fern.whitaker@opsorch.com", Team: "team-revenue"},
+	{ID: "milo", DisplayName: "Milo Kovacs", Type: ActorHuman, Email: "milo.kovacs@opsorch.com", Team: "team-orion"},
+
+	// Chat and notification bots.
+	{ID: "sre-bot", DisplayName: "SRE Bot", Type: ActorBot, Email: "sre-bot@opsorch.com", Team: "team-platform"},
+	{ID: "pd-bot", DisplayName: "PagerDuty Bot", Type: ActorBot, Team: "team-platform"},
+	{ID: "status-bot", DisplayName: "Status Page Bot", Type: ActorBot, Team: "team-platform"},
+	{ID: "metrics-bot", DisplayName: "Metrics Bot", Type: ActorBot, Team: "team-platform"},
+
+	// Automations that change systems on someone's behalf.
+	{ID: "deploy-bot", DisplayName: "Deploy Bot", Type: ActorAutomation, Email: "deploy-bot@opsorch.com", Team: "team-platform"},
+	{ID: "config-bot", DisplayName: "Config Bot", Type: ActorAutomation, Team: "team-platform"},
+	{ID: "incident-declare", DisplayName: "Incident Declaration", Type: ActorAutomation, Team: "team-platform"},
+	{ID: "system-automation", DisplayName: "Runbook Automation", Type: ActorAutomation, Team: "team-platform"},
+	{ID: "webhook-runner", DisplayName: "Webhook Runner", Type: ActorAutomation, Team: "team-platform"},
+
+	// Monitoring systems and policies.
+	{ID: "alertmanager", DisplayName: "Alertmanager", Type: ActorSystem},
+	{ID: "apm", DisplayName: "APM", Type: ActorSystem},
+	{ID: "browser-watch", DisplayName: "Browser Watch", Type: ActorSystem},
+	{ID: "indexer", DisplayName: "Search Indexer", Type: ActorSystem},
+	{ID: "ops-alerts", DisplayName: "Ops Alerts", Type: ActorSystem},
+	{ID: "spark-monitor", DisplayName: "Spark Monitor", Type: ActorSystem},
+	{ID: "scheduler", DisplayName: "Scheduler", Type: ActorSystem},
+	{ID: "grafana", DisplayName: "Grafana", Type: ActorSystem},
+	{ID: "pg-exporter", DisplayName: "Postgres Exporter", Type: ActorSystem},
+	{ID: "slo-monitor", DisplayName: "SLO Monitor", Type: ActorSystem},
+	{ID: "escalation-policy", DisplayName: "Escalation Policy", Type: ActorSystem},
+	{ID: "sla-policy", DisplayName: "SLA Policy", Type: ActorSystem},
+}
+
+var actorIndex = func() map[string]int {
+	index := make(map[string]int, len(actorSeeds))
+	for i, a := range actorSeeds {
+		index[a.ID] = i
+	}
+	return index
+}()
+
+// LookupActor returns the registered actor with the given ID.
+func LookupActor(id string) (Actor, bool) {
+	i, ok := actorIndex[id]
+	if !ok {
+		return Actor{}, false
+	}
+	return withAvatar(actorSeeds[i]), true
+}
+
+// Actors lists the registry sorted by ID, optionally only actors of one type.
+func Actors(actorType string) []Actor {
+	out := make([]Actor, 0, len(actorSeeds))
+	for _, a := range actorSeeds {
+		if actorType == "" || a.Type == actorType {
+			out = append(out, withAvatar(a))
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return out
+}
+
+// ActorRef returns the actor map providers put on timeline entries,
+// deployments, and ticket audit entries. "name" stays the handle for existing
+// clients; registered actors add their display name, email, team, and avatar.
+// Unregistered IDs, such as names supplied by clients, are humans.
+func ActorRef(id string) map[string]any {
+	a, ok := LookupActor(id)
+	if !ok {
+		return map[string]any{"type": ActorHuman, "name": id}
+	}
+	ref := map[string]any{
+		"type":        a.Type,
+		"name":        a.ID,
+		"id":          a.ID,
+		"displayName": a.DisplayName,
+		"avatar":      a.Avatar,
+	}
+	if a.Email != "" {
+		ref["email"] = a.Email
+	}
+	if a.Team != "" {
+		ref["team"] = a.Team
+	}
+	return ref
+}
+
+// ActorRefs maps IDs to their actor maps.
+func ActorRefs(ids []string) []map[string]any {
+	out := make([]map[string]any, 0, len(ids))
+	for _, id := range ids {
+		out = append(out, ActorRef(id))
+	}
+	return out
+}
+
+func withAvatar(a Actor) Actor {
+	a.Avatar = "https://avatars.demo.com/" + a.ID + ".png"
+	return a
+}
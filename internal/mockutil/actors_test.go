@@ -0,0 +1,42 @@
+package mockutil
+
+import "testing"
+
+func TestActorRegistry(t *testing.T) {
+	seen := map[string]bool{}
+	for _, a := range Actors("") {
+		if seen[a.ID] {
+			t.Fatalf("duplicate actor %s", a.ID)
+		}
+		seen[a.ID] = true
+		if a.DisplayName == "" || a.Avatar == "" {
+			t.Fatalf("incomplete actor %+v", a)
+		}
+		switch a.Type {
+		case ActorHuman, ActorBot, ActorAutomation, ActorSystem:
+		default:
+			t.Fatalf("actor %s has unknown type %q", a.ID, a.Type)
+		}
+	}
+	for _, a := range Actors(ActorAutomation) {
+		if a.Type != ActorAutomation {
+			t.Fatalf("type filter ignored: %+v", a)
+		}
+	}
+
+	alex := ActorRef("alex")
+	if alex["type"] != ActorHuman || alex["name"] != "alex" || alex["displayName"] != "Alex Rivera" ||
+		alex["email"] != "alex.rivera@opsorch.com" || alex["team"] != "team-velocity" || alex["avatar"] == "" {
+		t.Fatalf("unexpected actor ref for alex: %v", alex)
+	}
+	if bot := ActorRef("deploy-bot"); bot["type"] != ActorAutomation {
+		t.Fatalf("expected deploy-bot to be an automation, got %v", bot)
+	}
+	if guest := ActorRef("guest-responder"); len(guest) != 2 || guest["type"] != ActorHuman || guest["name"] != "guest-responder" {
+		t.Fatalf("expected unregistered actor to fall back to a bare user, got %v", guest)
+	}
+	ActorRef("alex")["displayName"] = "changed"
+	if ActorRef("alex")["displayName"] != "Alex Rivera" {
+		t.Fatal("expected ActorRef to return a fresh map")
+	}
+}
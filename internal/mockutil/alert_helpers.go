@@ -6,6 +6,16 @@ import (
 	"github.com/opsorch/opsorch-core/schema"
 )
 
+// AlertCategoryCost marks financial alerts in Fields["category"]. They share the
+// alert snapshot with operational alerts but do not shape metric waveforms.
+const AlertCategoryCost = "cost"
+
+// IsCostAlert reports whether an alert is a financial rather than operational signal.
+func IsCostAlert(al schema.Alert) bool {
+	category, _ := al.Fields["category"].(string)
+	return category == AlertCategoryCost
+}
+
 // SummarizeAlerts produces lightweight references for embedding in metadata/fields.
 func SummarizeAlerts(alerts []schema.Alert) []map[string]any {
 	if len(alerts) == 0 {
@@ -69,6 +79,9 @@ func StrongestAlertFactor(service string, ts time.Time, alerts []schema.Alert) (
 		if al.Service != "" && service != "" && al.Service != service {
 			continue
 		}
+		if IsCostAlert(al) {
+			continue
+		}
 		if ts.Before(al.CreatedAt) || ts.After(al.UpdatedAt.Add(10*time.Minute)) {
 			continue
 		}
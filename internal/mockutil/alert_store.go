@@ -1,6 +1,7 @@
 package mockutil
 
 import (
+	"sort"
 	"sync"
 	"time"
 
@@ -10,8 +11,32 @@ import (
 var (
 	alertStoreMu sync.RWMutex
 	alertStore   []schema.Alert
+	// sourceAlerts holds alerts contributed by secondary sources such as cost
+	// monitoring, keyed by source, so they survive PublishAlerts.
+	sourceAlerts = map[string][]schema.Alert{}
+	// lazySources compute a secondary source's alerts on every alert query
+	// instead of publishing a copy.
+	lazySources = map[string]AlertSourceFunc{}
 )
 
+// AlertSourceFunc computes a secondary source's current alerts from the
+// config section the alert provider was given for it, which may be nil.
+type AlertSourceFunc func(cfg map[string]any) []schema.Alert
+
+// RegisterAlertSource makes fn the lazy secondary source named source.
+// Alert providers call it through SourceAlertsFor on every query, so its
+// alerts follow the clock and the alert provider's config. A nil fn removes
+// the source.
+func RegisterAlertSource(source string, fn AlertSourceFunc) {
+	alertStoreMu.Lock()
+	defer alertStoreMu.Unlock()
+	if fn == nil {
+		delete(lazySources, source)
+		return
+	}
+	lazySources[source] = fn
+}
+
 func init() {
 	alertStore = buildDefaultAlerts()
 }
@@ -25,10 +50,81 @@ func PublishAlerts(alerts []schema.Alert) {
 	}
 }
 
+// PublishSourceAlerts replaces the alerts one secondary source contributes to
+// the shared snapshot. Publishing an empty slice removes the source.
+func PublishSourceAlerts(source string, alerts []schema.Alert) {
+	alertStoreMu.Lock()
+	defer alertStoreMu.Unlock()
+	if len(alerts) == 0 {
+		delete(sourceAlerts, source)
+		return
+	}
+	sourceAlerts[source] = CloneAlerts(alerts)
+}
+
+// SnapshotAlerts returns the primary alerts followed by every secondary
+// source's alerts.
 func SnapshotAlerts() []schema.Alert {
 	alertStoreMu.RLock()
 	defer alertStoreMu.RUnlock()
-	return CloneAlerts(alertStore)
+	return append(CloneAlerts(alertStore), sourceAlertsLocked()...)
+}
+
+// SourceAlerts returns only the alerts published by secondary sources, ordered by source.
+func SourceAlerts() []schema.Alert {
+	alertStoreMu.RLock()
+	defer alertStoreMu.RUnlock()
+	return sourceAlertsLocked()
+}
+
+// SourceAlertsFor returns the alerts of every secondary source, ordered by
+// source. Lazy sources are computed now with their section of configs, keyed
+// by source name, and replace anything published under the same name.
+func SourceAlertsFor(configs map[string]any) []schema.Alert {
+	alertStoreMu.RLock()
+	published := make(map[string][]schema.Alert, len(sourceAlerts))
+	for source, alerts := range sourceAlerts {
+		published[source] = alerts
+	}
+	lazy := make(map[string]AlertSourceFunc, len(lazySources))
+	for source, fn := range lazySources {
+		lazy[source] = fn
+	}
+	alertStoreMu.RUnlock()
+
+	sources := make([]string, 0, len(published)+len(lazy))
+	for source := range published {
+		if lazy[source] == nil {
+			sources = append(sources, source)
+		}
+	}
+	for source := range lazy {
+		sources = append(sources, source)
+	}
+	sort.Strings(sources)
+	out := []schema.Alert{}
+	for _, source := range sources {
+		if fn := lazy[source]; fn != nil {
+			cfg, _ := configs[source].(map[string]any)
+			out = append(out, CloneAlerts(fn(cfg))...)
+			continue
+		}
+		out = append(out, CloneAlerts(published[source])...)
+	}
+	return out
+}
+
+func sourceAlertsLocked() []schema.Alert {
+	sources := make([]string, 0, len(sourceAlerts))
+	for source := range sourceAlerts {
+		sources = append(sources, source)
+	}
+	sort.Strings(sources)
+	out := []schema.Alert{}
+	for _, source := range sources {
+		out = append(out, CloneAlerts(sourceAlerts[source])...)
+	}
+	return out
 }
 
 func buildDefaultAlerts() []schema.Alert {
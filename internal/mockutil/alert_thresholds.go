@@ -0,0 +1,49 @@
+package mockutil
+
+// AlertThreshold is the warning and critical line of an alert rule, in the
+// unit of the metric series it watches, so charts of the series can draw the
+// lines the alert fires on.
+type AlertThreshold struct {
+	// Rule is the alertmock rule name.
+	Rule string `json:"rule"`
+	// Metric is the metric series the lines apply to.
+	Metric   string  `json:"metric"`
+	Unit     string  `json:"unit"`
+	Warning  float64 `json:"warning"`
+	Critical float64 `json:"critical"`
+	// Direction is "above" when the rule fires as the series rises past a
+	// line and "below" when it falls past one.
+	Direction string `json:"direction"`
+}
+
+// gib is the 1 GiB container memory limit the memory-pressure rule assumes.
+const gib = 1 << 30
+
+// alertThresholds are keyed by alert rule name. Critical matches the
+// threshold the rule reports on fired alerts.
+var alertThresholds = map[string]AlertThreshold{
+	"high-latency":    {Rule: "high-latency", Metric: "http_request_duration_seconds", Unit: "seconds", Warning: 0.8, Critical: 1.2, Direction: "above"},
+	"error-rate":      {Rule: "error-rate", Metric: "error_rate", Unit: "ratio", Warning: 0.02, Critical: 0.05, Direction: "above"},
+	"cpu-saturation":  {Rule: "cpu-saturation", Metric: "cpu_usage_ratio", Unit: "ratio", Warning: 0.75, Critical: 0.9, Direction: "above"},
+	"memory-pressure": {Rule: "memory-pressure", Metric: "memory_working_set_bytes", Unit: "bytes", Warning: 0.8 * gib, Critical: 0.9 * gib, Direction: "above"},
+	"connection-pool": {Rule: "connection-pool", Metric: "db_connections_active", Unit: "connections", Warning: 80, Critical: 95, Direction: "above"},
+	"queue-backlog":   {Rule: "queue-backlog", Metric: "queue_depth", Unit: "messages", Warning: 3000, Critical: 5000, Direction: "above"},
+	"pod-restarts":    {Rule: "pod-restarts", Metric: "container_restarts_total", Unit: "restarts", Warning: 1, Critical: 3, Direction: "above"},
+}
+
+// AlertThresholdForRule returns the lines of an alert rule.
+func AlertThresholdForRule(rule string) (AlertThreshold, bool) {
+	th, ok := alertThresholds[rule]
+	return th, ok
+}
+
+// AlertThresholdForMetric returns the lines of the alert rule watching a
+// metric series.
+func AlertThresholdForMetric(metric string) (AlertThreshold, bool) {
+	for _, th := range alertThresholds {
+		if th.Metric == metric {
+			return th, true
+		}
+	}
+	return AlertThreshold{}, false
+}
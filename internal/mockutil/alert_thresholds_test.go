@@ -0,0 +1,21 @@
+package mockutil
+
+import "testing"
+
+func TestAlertThresholds(t *testing.T) {
+	for name, th := range alertThresholds {
+		if th.Rule != name || th.Metric == "" || th.Unit == "" {
+			t.Fatalf("threshold %s is incomplete: %+v", name, th)
+		}
+		if th.Direction != "above" || th.Warning >= th.Critical {
+			t.Fatalf("threshold %s should warn before it goes critical: %+v", name, th)
+		}
+		byMetric, ok := AlertThresholdForMetric(th.Metric)
+		if !ok || byMetric != th {
+			t.Fatalf("expected %s to be the rule watching %s, got %+v", name, th.Metric, byMetric)
+		}
+	}
+	if _, ok := AlertThresholdForMetric("revenue_total"); ok {
+		t.Fatal("expected no threshold for a metric without an alert rule")
+	}
+}
@@ -0,0 +1,73 @@
+package mockutil
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+	"strings"
+)
+
+// BrandingConfigKey is the config key holding token substitutions applied to
+// every result, e.g. {"runbook.demo": "runbooks.acme.io", "Demo Internal CA":
+// "Acme Internal CA"}, so a demo can be branded for a prospect without
+// forking the seeds.
+const BrandingConfigKey = "branding"
+
+// Branding substitutes configured tokens in results.
+type Branding struct {
+	tokens   map[string]string
+	replacer *strings.Replacer
+}
+
+// ParseBranding reads cfg[BrandingConfigKey], returning nil when no tokens
+// are configured.
+func ParseBranding(cfg map[string]any) *Branding {
+	tokens := ParseRenames(cfg[BrandingConfigKey])
+	if len(tokens) == 0 {
+		return nil
+	}
+	// Longest tokens first, so "grafana.demo.com" is replaced before the
+	// "grafana.demo" inside it.
+	keys := make([]string, 0, len(tokens))
+	for k := range tokens {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if len(keys[i]) != len(keys[j]) {
+			return len(keys[i]) > len(keys[j])
+		}
+		return keys[i] < keys[j]
+	})
+	pairs := make([]string, 0, 2*len(keys))
+	for _, k := range keys {
+		pairs = append(pairs, k, tokens[k])
+	}
+	return &Branding{tokens: tokens, replacer: strings.NewReplacer(pairs...)}
+}
+
+// Tokens returns a copy of the configured substitutions.
+func (b *Branding) Tokens() map[string]string {
+	return CloneStringMap(b.tokens)
+}
+
+// String substitutes the tokens in s.
+func (b *Branding) String(s string) string {
+	return b.replacer.Replace(s)
+}
+
+// Apply returns v as it encodes to JSON with the tokens substituted in every
+// string value. The result has the same JSON encoding as v apart from the
+// substitutions; object keys are left alone.
+func (b *Branding) Apply(v any) (any, error) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	var generic any
+	if err := dec.Decode(&generic); err != nil {
+		return nil, err
+	}
+	return RewriteStrings(generic, b.String), nil
+}
@@ -0,0 +1,96 @@
+package mockutil
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+// CorrelationKey is the field (on alerts and incidents) or metadata key (on
+// metrics and deployments) carrying a correlation ID. Records from the same
+// scenario or event share one, so they can be joined by ID rather than by
+// service and time.
+const CorrelationKey = "correlation_id"
+
+// scenarioAliases map the scenario_id spellings providers use, seed numbers
+// and family slugs alike, to one canonical scenario.
+var scenarioAliases = map[string]string{
+	"scenario-001":                "slo-exhaustion",
+	"slo-exhaustion":              "slo-exhaustion",
+	"scenario-002":                "cascading-failure",
+	"cascading-failure":           "cascading-failure",
+	"scenario-003":                "deployment-rollback",
+	"deployment-rollback":         "deployment-rollback",
+	"scenario-004":                "external-dependency-failure",
+	"external-dependency":         "external-dependency-failure",
+	"external-dependency-failure": "external-dependency-failure",
+	"scenario-005":                "autoscaling-lag",
+	"autoscaling-lag":             "autoscaling-lag",
+	"scenario-006":                "circuit-breaker-cascade",
+	"circuit-breaker-cascade":     "circuit-breaker-cascade",
+}
+
+// scenarioIncidents are the seeded scenario incidents, so alerts linked to
+// one by incident_id alone still share the scenario's correlation ID.
+var scenarioIncidents = map[string]string{
+	"inc-scenario-001": "slo-exhaustion",
+	"inc-scenario-002": "cascading-failure",
+	"inc-scenario-003": "deployment-rollback",
+	"inc-scenario-004": "external-dependency-failure",
+	"inc-scenario-005": "autoscaling-lag",
+	"inc-scenario-006": "circuit-breaker-cascade",
+}
+
+// CorrelationID derives a stable correlation ID, e.g. "corr-1a2b3c4d5e6f",
+// from the kind and ID of the scenario or event that started a chain of
+// records. Every process derives the same ID, so plugins agree without
+// sharing state.
+func CorrelationID(kind, id string) string {
+	sum := sha256.Sum256([]byte(kind + ":" + id))
+	return "corr-" + hex.EncodeToString(sum[:6])
+}
+
+// ScenarioCorrelationID returns the correlation ID of a seeded scenario under
+// any of its scenario_id spellings.
+func ScenarioCorrelationID(scenarioID string) (string, bool) {
+	scenario, ok := scenarioAliases[strings.ToLower(strings.TrimSpace(scenarioID))]
+	if !ok {
+		return "", false
+	}
+	return CorrelationID("scenario", scenario), true
+}
+
+// IncidentCorrelationID is the correlation ID of an incident, shared by the
+// alerts linked to it: its scenario's for a seeded scenario incident.
+func IncidentCorrelationID(incidentID string) string {
+	if scenario, ok := scenarioIncidents[incidentID]; ok {
+		return CorrelationID("scenario", scenario)
+	}
+	return CorrelationID("incident", incidentID)
+}
+
+// CorrelationFor returns the correlation ID a record's fields and metadata
+// imply: one already set, then its scenario's, then the incident it is linked
+// to. It returns "" when none applies.
+func CorrelationFor(fields, metadata map[string]any) string {
+	for _, m := range []map[string]any{fields, metadata} {
+		if id, ok := m[CorrelationKey].(string); ok && id != "" {
+			return id
+		}
+	}
+	for _, m := range []map[string]any{fields, metadata} {
+		if scenario, ok := m["scenario_id"].(string); ok {
+			if id, ok := ScenarioCorrelationID(scenario); ok {
+				return id
+			}
+		}
+	}
+	for _, m := range []map[string]any{fields, metadata} {
+		for _, key := range []string{"incident_id", "incidentId"} {
+			if incidentID, ok := m[key].(string); ok && incidentID != "" {
+				return IncidentCorrelationID(incidentID)
+			}
+		}
+	}
+	return ""
+}
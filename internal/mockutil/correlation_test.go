@@ -0,0 +1,40 @@
+package mockutil
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCorrelationIDs(t *testing.T) {
+	id, ok := ScenarioCorrelationID("scenario-004")
+	if !ok || !strings.HasPrefix(id, "corr-") {
+		t.Fatalf("expected a correlation ID for scenario-004, got %q", id)
+	}
+	for _, alias := range []string{"external-dependency", "External-Dependency-Failure"} {
+		if other, _ := ScenarioCorrelationID(alias); other != id {
+			t.Fatalf("expected %q to share scenario-004's ID, got %q", alias, other)
+		}
+	}
+	if _, ok := ScenarioCorrelationID("scenario-999"); ok {
+		t.Fatal("expected no ID for an unknown scenario")
+	}
+	if IncidentCorrelationID("inc-scenario-004") != id {
+		t.Fatal("expected a scenario incident to share its scenario's ID")
+	}
+
+	cases := []struct {
+		name             string
+		fields, metadata map[string]any
+		want             string
+	}{
+		{"explicit wins", map[string]any{CorrelationKey: "corr-x", "scenario_id": "scenario-004"}, nil, "corr-x"},
+		{"scenario in metadata", nil, map[string]any{"scenario_id": "external-dependency"}, id},
+		{"linked incident", map[string]any{"incident_id": "inc-001"}, nil, IncidentCorrelationID("inc-001")},
+		{"unlinked", map[string]any{"service": "svc-web"}, nil, ""},
+	}
+	for _, tc := range cases {
+		if got := CorrelationFor(tc.fields, tc.metadata); got != tc.want {
+			t.Errorf("%s: got %q, want %q", tc.name, got, tc.want)
+		}
+	}
+}
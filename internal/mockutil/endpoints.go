@@ -0,0 +1,127 @@
+package mockutil
+
+// SLO tiers for endpoints, strictest first.
+const (
+	TierCritical   = "critical"
+	TierStandard   = "standard"
+	TierBestEffort = "best-effort"
+)
+
+// tierLatencyTargetsMs is the p95 latency target of each SLO tier.
+var tierLatencyTargetsMs = map[string]int{
+	TierCritical:   500,
+	TierStandard:   1000,
+	TierBestEffort: 3000,
+}
+
+// Endpoint is one key operation a service exposes.
+type Endpoint struct {
+	Service   string `json:"service"`
+	Method    string `json:"method"`
+	Path      string `json:"path"`
+	Operation string `json:"operation"`
+	SLOTier   string `json:"sloTier"`
+	// LatencyTargetMs is the p95 target for the endpoint's tier.
+	LatencyTargetMs int `json:"latencyTargetMs"`
+	// TrafficShare is the endpoint's share of the service's requests; the
+	// shares of a service sum to 1.
+	TrafficShare float64 `json:"trafficShare"`
+	// LatencyFactor scales the service's latency for this endpoint. Weighted
+	// by TrafficShare, a service's factors average to about 1, so the
+	// endpoints add up to the service-level series.
+	LatencyFactor float64 `json:"latencyFactor"`
+}
+
+// Key identifies the endpoint as "METHOD path".
+func (e Endpoint) Key() string { return e.Method + " " + e.Path }
+
+func endpoint(method, path, operation, tier string, share, latency float64) Endpoint {
+	return Endpoint{Method: method, Path: path, Operation: operation, SLOTier: tier, LatencyTargetMs: tierLatencyTargetsMs[tier], TrafficShare: share, LatencyFactor: latency}
+}
+
+// serviceEndpoints is the endpoint inventory of the catalog services.
+var serviceEndpoints = map[string][]Endpoint{
+	"svc-checkout": {
+		endpoint("POST", "/v1/checkout", "PlaceOrder", TierCritical, 0.3, 1.6),
+		endpoint("GET", "/v1/cart", "GetCart", TierStandard, 0.45, 0.6),
+		endpoint("POST", "/v1/cart/items", "AddToCart", TierStandard, 0.25, 0.9),
+	},
+	"svc-search": {
+		endpoint("GET", "/v1/search", "Search", TierCritical, 0.7, 1.15),
+		endpoint("GET", "/v1/suggest", "Suggest", TierStandard, 0.3, 0.6),
+	},
+	"svc-web": {
+		endpoint("GET", "/", "Home", TierCritical, 0.5, 0.9),
+		endpoint("GET", "/product/:id", "ProductPage", TierStandard, 0.35, 1.3),
+		endpoint("GET", "/static/*", "StaticAssets", TierBestEffort, 0.15, 0.4),
+	},
+	"svc-payments": {
+		endpoint("POST", "/v1/payment_intents", "CreatePaymentIntent", TierCritical, 0.5, 1.4),
+		endpoint("POST", "/v1/webhooks/provider", "ProviderWebhook", TierStandard, 0.3, 0.6),
+		endpoint("GET", "/v1/payment_intents/:id", "GetPaymentIntent", TierStandard, 0.2, 0.5),
+	},
+	"svc-notifications": {
+		endpoint("POST", "/v1/notifications", "SendNotification", TierStandard, 0.8, 1.05),
+		endpoint("GET", "/v1/preferences/:user", "GetPreferences", TierBestEffort, 0.2, 0.8),
+	},
+	"svc-identity": {
+		endpoint("POST", "/v1/sessions", "Login", TierCritical, 0.3, 1.5),
+		endpoint("GET", "/v1/sessions/:id", "ValidateSession", TierCritical, 0.6, 0.7),
+		endpoint("POST", "/v1/tokens/refresh", "RefreshToken", TierStandard, 0.1, 1.0),
+	},
+	"svc-warehouse": {
+		endpoint("GET", "/v1/reservations", "ListReservations", TierStandard, 0.8, 0.75),
+		endpoint("POST", "/v1/exports", "StartExport", TierBestEffort, 0.2, 2.0),
+	},
+	"svc-recommendation": {
+		endpoint("GET", "/v1/recommendations", "Recommend", TierStandard, 0.85, 1.05),
+		endpoint("POST", "/v1/feedback", "RecordFeedback", TierBestEffort, 0.15, 0.7),
+	},
+	"svc-analytics": {
+		endpoint("POST", "/v1/events", "IngestEvents", TierStandard, 0.9, 0.9),
+		endpoint("GET", "/v1/reports/:id", "GetReport", TierBestEffort, 0.1, 1.9),
+	},
+	"svc-order": {
+		endpoint("POST", "/v1/orders", "CreateOrder", TierCritical, 0.35, 1.3),
+		endpoint("GET", "/v1/orders/:id", "GetOrder", TierStandard, 0.5, 0.7),
+		endpoint("POST", "/v1/orders/:id/cancel", "CancelOrder", TierStandard, 0.15, 1.2),
+	},
+	"svc-catalog": {
+		endpoint("GET", "/v1/products/:id", "GetProduct", TierCritical, 0.6, 0.8),
+		endpoint("GET", "/v1/products", "ListProducts", TierStandard, 0.3, 1.3),
+		endpoint("PUT", "/v1/products/:id", "UpdateProduct", TierBestEffort, 0.1, 1.5),
+	},
+	"svc-shipping": {
+		endpoint("POST", "/v1/shipments", "CreateShipment", TierStandard, 0.4, 1.3),
+		endpoint("GET", "/v1/shipments/:id/tracking", "TrackShipment", TierStandard, 0.6, 0.8),
+	},
+	"svc-realtime": {
+		endpoint("GET", "/ws", "Subscribe", TierCritical, 0.7, 0.9),
+		endpoint("POST", "/v1/publish", "Publish", TierStandard, 0.3, 1.2),
+	},
+}
+
+// ServiceEndpoints returns the key endpoints of a service, or nil for
+// services without an inventory.
+func ServiceEndpoints(service string) []Endpoint {
+	endpoints := serviceEndpoints[service]
+	if endpoints == nil {
+		return nil
+	}
+	out := make([]Endpoint, len(endpoints))
+	for i, e := range endpoints {
+		e.Service = service
+		out[i] = e
+	}
+	return out
+}
+
+// FindEndpoint looks up a service endpoint by operation name or "METHOD path".
+func FindEndpoint(service, name string) (Endpoint, bool) {
+	for _, e := range ServiceEndpoints(service) {
+		if e.Operation == name || e.Key() == name {
+			return e, true
+		}
+	}
+	return Endpoint{}, false
+}
@@ -0,0 +1,39 @@
+package mockutil
+
+import (
+	"math"
+	"testing"
+)
+
+func TestServiceEndpointsAddUpToTheService(t *testing.T) {
+	for service := range serviceEndpoints {
+		var share, latency float64
+		for _, e := range ServiceEndpoints(service) {
+			if e.Service != service || e.LatencyTargetMs == 0 {
+				t.Fatalf("%s: endpoint %+v missing service or tier target", service, e)
+			}
+			share += e.TrafficShare
+			latency += e.TrafficShare * e.LatencyFactor
+		}
+		if math.Abs(share-1) > 1e-9 {
+			t.Errorf("%s: traffic shares sum to %v, want 1", service, share)
+		}
+		if math.Abs(latency-1) > 0.05 {
+			t.Errorf("%s: weighted latency factor %v, want about 1", service, latency)
+		}
+	}
+}
+
+func TestFindEndpoint(t *testing.T) {
+	byName, ok := FindEndpoint("svc-checkout", "PlaceOrder")
+	if !ok || byName.SLOTier != TierCritical {
+		t.Fatalf("expected critical PlaceOrder, got %+v", byName)
+	}
+	byKey, ok := FindEndpoint("svc-checkout", "POST /v1/checkout")
+	if !ok || byKey.Operation != "PlaceOrder" {
+		t.Fatalf("expected PlaceOrder by key, got %+v", byKey)
+	}
+	if _, ok := FindEndpoint("svc-checkout", "Search"); ok {
+		t.Fatal("expected no Search endpoint on svc-checkout")
+	}
+}
@@ -0,0 +1,119 @@
+package mockutil
+
+import (
+	"sync"
+	"time"
+)
+
+// Event is one entry on the process-wide mock event bus. Providers publish
+// things that happen outside a request (inbound chat commands, scripted
+// activity) so hosts can react to them as they would to a real integration.
+type Event struct {
+	// Seq increases by one per published event and is the polling cursor.
+	Seq    int64     `json:"seq"`
+	Type   string    `json:"type"`
+	Source string    `json:"source"`
+	At     time.Time `json:"at"`
+	Data   any       `json:"data"`
+}
+
+// eventHistoryLimit bounds how many past events EventsSince can replay.
+const eventHistoryLimit = 1000
+
+// subscriberBuffer is each subscriber's channel capacity. Events published
+// while a subscriber's buffer is full are dropped for that subscriber only;
+// they can still be recovered with EventsSince.
+const subscriberBuffer = 64
+
+var (
+	eventBusMu  sync.Mutex
+	eventSeq    int64
+	eventLog    []Event
+	subscribers = map[int]*eventSubscriber{}
+	nextSubID   int
+)
+
+type eventSubscriber struct {
+	types map[string]bool
+	ch    chan Event
+}
+
+// PublishEvent stamps ev with the next sequence number (and the current time
+// when At is zero), records it, and fans it out to matching subscribers.
+func PublishEvent(ev Event) Event {
+	eventBusMu.Lock()
+	defer eventBusMu.Unlock()
+
+	eventSeq++
+	ev.Seq = eventSeq
+	if ev.At.IsZero() {
+		ev.At = time.Now().UTC()
+	}
+	eventLog = append(eventLog, ev)
+	if len(eventLog) > eventHistoryLimit {
+		eventLog = append([]Event(nil), eventLog[len(eventLog)-eventHistoryLimit:]...)
+	}
+	for _, sub := range subscribers {
+		if len(sub.types) > 0 && !sub.types[ev.Type] {
+			continue
+		}
+		select {
+		case sub.ch <- ev:
+		default:
+		}
+	}
+	return ev
+}
+
+// SubscribeEvents delivers events of the given types (all types when none are
+// given) published after the call. The returned cancel func closes the channel.
+func SubscribeEvents(types ...string) (<-chan Event, func()) {
+	eventBusMu.Lock()
+	defer eventBusMu.Unlock()
+
+	nextSubID++
+	id := nextSubID
+	sub := &eventSubscriber{types: eventTypeSet(types), ch: make(chan Event, subscriberBuffer)}
+	subscribers[id] = sub
+
+	var once sync.Once
+	return sub.ch, func() {
+		once.Do(func() {
+			eventBusMu.Lock()
+			defer eventBusMu.Unlock()
+			delete(subscribers, id)
+			close(sub.ch)
+		})
+	}
+}
+
+// EventsSince returns retained events with Seq greater than after, filtered by
+// type when types are given, oldest first.
+func EventsSince(after int64, types ...string) []Event {
+	eventBusMu.Lock()
+	defer eventBusMu.Unlock()
+
+	filter := eventTypeSet(types)
+	out := []Event{}
+	for _, ev := range eventLog {
+		if ev.Seq <= after {
+			continue
+		}
+		if len(filter) > 0 && !filter[ev.Type] {
+			continue
+		}
+		out = append(out, ev)
+	}
+	return out
+}
+
+func eventTypeSet(types []string) map[string]bool {
+	if len(types) == 0 {
+		return nil
+	}
+	set := make(map[string]bool, len(types))
+	for _, t := range types {
+		set[t] = true
+	}
+	return set
+}
@@ -0,0 +1,278 @@
+package mockutil
+
+import (
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"strings"
+
+	"github.com/opsorch/opsorch-core/orcherr"
+)
+
+// Failure domain kinds accepted by BlastRadius, as "kind:name" components.
+const (
+	DomainRegion  = "region"
+	DomainAZ      = "az"
+	DomainCluster = "cluster"
+	DomainShard   = "shard"
+	DomainService = "service"
+)
+
+// FailureDomains places a service in the shared topology: the region and
+// zones it runs in, its Kubernetes (or database) cluster, and the database
+// shards its data lives on.
+type FailureDomains struct {
+	Region  string   `json:"region"`
+	AZs     []string `json:"azs"`
+	Cluster string   `json:"cluster"`
+	Shards  []string `json:"shards,omitempty"`
+}
+
+// regionShortNames abbreviate regions in cluster names, as alerts do.
+var regionShortNames = map[string]string{
+	"us-east-1": "use1",
+	"us-west-2": "usw2",
+	"eu-west-1": "euw1",
+}
+
+// ResolveRegion accepts a region by name ("eu-west-1") or by its short name
+// ("euw1") and returns the full name.
+func ResolveRegion(name string) (string, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for region, short := range regionShortNames {
+		if name == region || name == short {
+			return region, true
+		}
+	}
+	return "", false
+}
+
+// serviceClusterOverrides keep the cluster names seeded alerts already use.
+var serviceClusterOverrides = map[string]string{
+	"svc-search":   "ares",
+	"svc-database": "prod-primary",
+}
+
+// serviceShards lists the orders database shards each service's data lives
+// on. svc-database hosts both, matching the two shards the Region Evacuation
+// plan backs up.
+var serviceShards = map[string][]string{
+	"svc-database": {"db-shard-1", "db-shard-2"},
+	"svc-checkout": {"db-shard-1"},
+	"svc-catalog":  {"db-shard-2"},
+	"svc-search":   {"db-shard-2"},
+}
+
+// ServiceFailureDomains returns where a service runs. Every service spans two
+// of its region's three zones, chosen from a hash of the service ID like its
+// region, and runs on one of two clusters per region.
+func ServiceFailureDomains(service string) FailureDomains {
+	region := ServiceRegion(service)
+	h := fnv.New32a()
+	h.Write([]byte(service))
+	sum := h.Sum32()
+
+	zones := [][2]string{{"a", "b"}, {"b", "c"}, {"a", "c"}}[sum%3]
+	cluster, ok := serviceClusterOverrides[service]
+	if !ok {
+		cluster = fmt.Sprintf("k8s-%s-%s", regionShortNames[region], []string{"blue", "green"}[(sum>>4)%2])
+	}
+	return FailureDomains{
+		Region:  region,
+		AZs:     []string{region + zones[0], region + zones[1]},
+		Cluster: cluster,
+		Shards:  CloneStringSlice(serviceShards[service]),
+	}
+}
+
+// SLO is a service level objective tracked for a service.
+type SLO struct {
+	ID        string  `json:"id"`
+	Service   string  `json:"service"`
+	Name      string  `json:"name"`
+	Objective float64 `json:"objective"`
+	Window    string  `json:"window"`
+}
+
+// criticalPathServices carry a tighter availability objective and a latency
+// SLO, since they sit on the checkout path.
+var criticalPathServices = map[string]bool{
+	"svc-checkout": true, "svc-payments": true, "svc-identity": true,
+	"svc-order": true, "svc-web": true, "svc-api-gateway": true,
+}
+
+// ServiceSLOs returns a service's SLOs: availability for every service, and
+// p95 latency for services on the checkout path.
+func ServiceSLOs(service string) []SLO {
+	slug := strings.TrimPrefix(service, "svc-")
+	availability := SLO{ID: "slo-" + slug + "-availability", Service: service, Name: "Availability", Objective: 99.9, Window: "30d"}
+	if !criticalPathServices[service] {
+		return []SLO{availability}
+	}
+	availability.Objective = 99.95
+	return []SLO{availability, {ID: "slo-" + slug + "-latency", Service: service, Name: "p95 latency under 1.2s", Objective: 99, Window: "30d"}}
+}
+
+// Impact levels in a blast radius, most severe first.
+const (
+	ImpactDown     = "down"
+	ImpactDegraded = "degraded"
+	ImpactAtRisk   = "at-risk"
+)
+
+var impactRank = map[string]int{ImpactDown: 0, ImpactDegraded: 1, ImpactAtRisk: 2}
+
+// ServiceImpact is one service inside a blast radius. Depth is 0 for
+// services in the failing component and grows along callers.
+type ServiceImpact struct {
+	Service string `json:"service"`
+	Team    string `json:"team"`
+	Impact  string `json:"impact"`
+	Depth   int    `json:"depth"`
+	Reason  string `json:"reason"`
+}
+
+// SLOAtRisk is an SLO of a service in the blast radius.
+type SLOAtRisk struct {
+	SLO
+	Impact string `json:"impact"`
+}
+
+// BlastRadiusReport lists what a failing component takes with it.
+type BlastRadiusReport struct {
+	Component string          `json:"component"`
+	Kind      string          `json:"kind"`
+	Name      string          `json:"name"`
+	Services  []ServiceImpact `json:"services"`
+	Teams     []string        `json:"teams"`
+	SLOs      []SLOAtRisk     `json:"slos"`
+	// Plans are orchestration plans written for this kind of failure.
+	Plans []string `json:"plans,omitempty"`
+}
+
+// blastRadiusPlans map failure domain kinds to the plans that respond to
+// them: a lost region is evacuated (or migrated away from), a lost shard is
+// failed over.
+var blastRadiusPlans = map[string][]string{
+	DomainRegion: {"plan-complex-006", "plan-complex-005"},
+	DomainShard:  {"plan-runbook-001"},
+}
+
+// BlastRadius lists the services, teams, and SLOs put at risk when a
+// component ("region:us-east-1", "az:us-east-1a", "cluster:ares",
+// "shard:db-shard-1", or "service:svc-database") fails. Services inside the
+// component are down, except that losing one of two zones or one shard of
+// several only degrades them. Failures then spread along callers: callers of
+// a down service are degraded, and callers beyond that are at risk.
+func BlastRadius(component string) (BlastRadiusReport, error) {
+	kind, name, ok := strings.Cut(strings.TrimSpace(component), ":")
+	if !ok || name == "" {
+		return BlastRadiusReport{}, orcherr.New("bad_request", fmt.Sprintf("invalid component %q: want kind:name", component), nil)
+	}
+
+	impacts := map[string]ServiceImpact{}
+	for _, service := range Services() {
+		domains := ServiceFailureDomains(service)
+		var impact, reason string
+		switch kind {
+		case DomainRegion:
+			if domains.Region == name {
+				impact, reason = ImpactDown, "runs in "+name
+			}
+		case DomainAZ:
+			if containsString(domains.AZs, name) {
+				impact, reason = ImpactDegraded, fmt.Sprintf("loses %s, one of its %d zones", name, len(domains.AZs))
+				if len(domains.AZs) == 1 {
+					impact = ImpactDown
+				}
+			}
+		case DomainCluster:
+			if domains.Cluster == name {
+				impact, reason = ImpactDown, "runs on cluster "+name
+			}
+		case DomainShard:
+			if containsString(domains.Shards, name) {
+				impact, reason = ImpactDown, "its data lives on "+name
+				if len(domains.Shards) > 1 {
+					impact, reason = ImpactDegraded, fmt.Sprintf("hosts %s, one of its %d shards", name, len(domains.Shards))
+				}
+			}
+		case DomainService:
+			if service == name {
+				impact, reason = ImpactDown, "is the failing service"
+			}
+		default:
+			return BlastRadiusReport{}, orcherr.New("bad_request", fmt.Sprintf("unknown failure domain %q", kind), nil)
+		}
+		if impact != "" {
+			impacts[service] = ServiceImpact{Service: service, Impact: impact, Reason: reason}
+		}
+	}
+	if len(impacts) == 0 {
+		return BlastRadiusReport{}, orcherr.New("not_found", fmt.Sprintf("no services in %s", component), nil)
+	}
+
+	// Breadth-first along callers, keeping each service's most severe impact.
+	queue := make([]string, 0, len(impacts))
+	for service := range impacts {
+		queue = append(queue, service)
+	}
+	sort.Strings(queue)
+	for len(queue) > 0 {
+		current := impacts[queue[0]]
+		queue = queue[1:]
+		next := ImpactAtRisk
+		if current.Impact == ImpactDown {
+			next = ImpactDegraded
+		}
+		for _, caller := range ServiceDependents(current.Service) {
+			if existing, ok := impacts[caller]; ok && impactRank[existing.Impact] <= impactRank[next] {
+				continue
+			}
+			impacts[caller] = ServiceImpact{
+				Service: caller,
+				Impact:  next,
+				Depth:   current.Depth + 1,
+				Reason:  fmt.Sprintf("calls %s (%s)", current.Service, current.Impact),
+			}
+			queue = append(queue, caller)
+		}
+	}
+
+	report := BlastRadiusReport{Component: kind + ":" + name, Kind: kind, Name: name, Plans: CloneStringSlice(blastRadiusPlans[kind])}
+	teams := map[string]bool{}
+	for _, impact := range impacts {
+		impact.Team = GetTeamForService(impact.Service)
+		teams[impact.Team] = true
+		report.Services = append(report.Services, impact)
+	}
+	sort.Slice(report.Services, func(i, j int) bool {
+		a, b := report.Services[i], report.Services[j]
+		if a.Impact != b.Impact {
+			return impactRank[a.Impact] < impactRank[b.Impact]
+		}
+		if a.Depth != b.Depth {
+			return a.Depth < b.Depth
+		}
+		return a.Service < b.Service
+	})
+	for team := range teams {
+		report.Teams = append(report.Teams, team)
+	}
+	sort.Strings(report.Teams)
+	for _, impact := range report.Services {
+		for _, slo := range ServiceSLOs(impact.Service) {
+			report.SLOs = append(report.SLOs, SLOAtRisk{SLO: slo, Impact: impact.Impact})
+		}
+	}
+	return report, nil
+}
+
+func containsString(values []string, want string) bool {
+	for _, v := range values {
+		if v == want {
+			return true
+		}
+	}
+	return false
+}
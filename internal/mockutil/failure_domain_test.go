@@ -0,0 +1,81 @@
+package mockutil
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestServiceFailureDomains(t *testing.T) {
+	for _, service := range Services() {
+		domains := ServiceFailureDomains(service)
+		if domains.Region != ServiceRegion(service) || len(domains.AZs) != 2 || domains.Cluster == "" {
+			t.Fatalf("%s: unexpected domains %+v", service, domains)
+		}
+		for _, az := range domains.AZs {
+			if !strings.HasPrefix(az, domains.Region) {
+				t.Errorf("%s: zone %s outside region %s", service, az, domains.Region)
+			}
+		}
+	}
+	if got := ServiceFailureDomains("svc-search").Cluster; got != "ares" {
+		t.Errorf("svc-search cluster %q, want ares", got)
+	}
+}
+
+func TestBlastRadius(t *testing.T) {
+	report, err := BlastRadius("service:svc-database")
+	if err != nil {
+		t.Fatalf("BlastRadius: %v", err)
+	}
+	byService := map[string]ServiceImpact{}
+	for _, impact := range report.Services {
+		byService[impact.Service] = impact
+	}
+	if byService["svc-database"].Impact != ImpactDown || byService["svc-checkout"].Impact != ImpactDegraded || byService["svc-checkout"].Depth != 1 {
+		t.Fatalf("unexpected impacts %+v", report.Services)
+	}
+	if byService["svc-order"].Impact != ImpactAtRisk || byService["svc-order"].Depth != 2 {
+		t.Errorf("svc-order calls checkout: got %+v, want at-risk at depth 2", byService["svc-order"])
+	}
+	if report.Services[0].Service != "svc-database" || !containsString(report.Teams, "team-data") || !containsString(report.Teams, "team-velocity") {
+		t.Errorf("unexpected order or teams: %+v / %v", report.Services[0], report.Teams)
+	}
+	var checkoutSLOs int
+	for _, slo := range report.SLOs {
+		if slo.Service == "svc-checkout" {
+			checkoutSLOs++
+		}
+	}
+	if checkoutSLOs != 2 {
+		t.Errorf("got %d checkout SLOs at risk, want availability and latency", checkoutSLOs)
+	}
+
+	shard, err := BlastRadius("shard:db-shard-1")
+	if err != nil {
+		t.Fatalf("BlastRadius shard: %v", err)
+	}
+	if shard.Services[0].Service != "svc-checkout" || shard.Services[0].Impact != ImpactDown || len(shard.Plans) != 1 {
+		t.Errorf("shard: got %+v plans %v, want checkout down and the failover runbook", shard.Services[0], shard.Plans)
+	}
+
+	region, err := BlastRadius("region:us-east-1")
+	if err != nil || !containsString(region.Plans, "plan-complex-006") {
+		t.Errorf("region: plans %v (%v), want the evacuation plan", region.Plans, err)
+	}
+	for _, impact := range region.Services {
+		if impact.Depth == 0 && ServiceRegion(impact.Service) != "us-east-1" {
+			t.Errorf("%s is outside us-east-1 but listed as down", impact.Service)
+		}
+	}
+
+	for component, code := range map[string]string{
+		"svc-database":      "bad_request",
+		"rack:r1":           "bad_request",
+		"cluster:no-such":   "not_found",
+		"region:ap-south-1": "not_found",
+	} {
+		if _, err := BlastRadius(component); err == nil || !strings.Contains(err.Error(), code) {
+			t.Errorf("%s: got %v, want %s", component, err, code)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package mockutil
+
+import "strings"
+
+// Features switches a provider's realism behaviours on or off individually.
+// Every feature is on by default; "minimal": true in the provider config
+// turns them all off, and the per-feature keys then opt back in, so a test
+// environment can start from plain deterministic data.
+type Features struct {
+	// ScenarioEffects seeds the scenario alerts and incidents and applies the
+	// scenario anomalies, log lines, and resource states.
+	ScenarioEffects bool
+	// Lifecycle advances records on the clock: alert lifecycles, incident
+	// escalation, and overdue ticket escalation.
+	Lifecycle bool
+	// AlertCoupling shapes generated metrics and logs around active alerts.
+	AlertCoupling bool
+	// Flair enriches seeded records with links, contacts, checklists, and
+	// other derived metadata.
+	Flair bool
+}
+
+// Feature config keys.
+const (
+	MinimalConfigKey         = "minimal"
+	ScenarioEffectsConfigKey = "scenarioEffects"
+	LifecycleConfigKey       = "lifecycle"
+	AlertCouplingConfigKey   = "alertCoupling"
+	FlairConfigKey           = "flair"
+)
+
+// ParseFeatures reads the feature switches from a provider config. Values
+// may be booleans or "true"/"false" strings; anything else keeps the default.
+func ParseFeatures(cfg map[string]any) Features {
+	on := true
+	if minimal, ok := parseSwitch(cfg[MinimalConfigKey]); ok && minimal {
+		on = false
+	}
+	out := Features{ScenarioEffects: on, Lifecycle: on, AlertCoupling: on, Flair: on}
+	for key, field := range map[string]*bool{
+		ScenarioEffectsConfigKey: &out.ScenarioEffects,
+		LifecycleConfigKey:       &out.Lifecycle,
+		AlertCouplingConfigKey:   &out.AlertCoupling,
+		FlairConfigKey:           &out.Flair,
+	} {
+		if v, ok := parseSwitch(cfg[key]); ok {
+			*field = v
+		}
+	}
+	return out
+}
+
+func parseSwitch(v any) (bool, bool) {
+	switch v := v.(type) {
+	case bool:
+		return v, true
+	case string:
+		switch strings.ToLower(strings.TrimSpace(v)) {
+		case "true":
+			return true, true
+		case "false":
+			return false, true
+		}
+	}
+	return false, false
+}
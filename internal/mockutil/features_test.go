@@ -0,0 +1,21 @@
+package mockutil
+
+import "testing"
+
+func TestParseFeatures(t *testing.T) {
+	all := Features{ScenarioEffects: true, Lifecycle: true, AlertCoupling: true, Flair: true}
+	if got := ParseFeatures(nil); got != all {
+		t.Fatalf("expected every feature on by default, got %+v", got)
+	}
+	got := ParseFeatures(map[string]any{"lifecycle": false, "flair": "false"})
+	if got != (Features{ScenarioEffects: true, AlertCoupling: true}) {
+		t.Fatalf("expected lifecycle and flair off, got %+v", got)
+	}
+	got = ParseFeatures(map[string]any{"minimal": true, "alertCoupling": "true"})
+	if got != (Features{AlertCoupling: true}) {
+		t.Fatalf("expected minimal with alert coupling opted in, got %+v", got)
+	}
+	if got := ParseFeatures(map[string]any{"flair": "maybe"}); got != all {
+		t.Fatalf("expected unparseable switches ignored, got %+v", got)
+	}
+}
@@ -0,0 +1,560 @@
+package mockutil
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/opsorch/opsorch-core/orcherr"
+)
+
+// Filter is a parsed filter expression such as
+//
+//	metadata.epic == "PAY-121" && fields.priority in ["P0", "P1"]
+//
+// Paths address a record by its JSON field names, descending into maps with
+// dots. Supported operators are ==, !=, <, <=, >, >=, in, not in, and
+// contains, combined with &&, ||, !, and parentheses. A bare path matches when
+// the value is present and not false, zero, or empty. Comparisons against a
+// missing path are false, except != which is true.
+//
+// A nil *Filter matches everything, so callers can use the result of
+// FilterFromContext without checking it.
+type Filter struct {
+	expr string
+	root filterNode
+}
+
+// FilterOptions carries the optional filter expression accepted by query payloads.
+type FilterOptions struct {
+	Filter string `json:"filter"`
+}
+
+// ParseFilter compiles a filter expression. An empty expression yields a nil
+// filter; a malformed one returns a bad_request error naming the position.
+func ParseFilter(expr string) (*Filter, error) {
+	if strings.TrimSpace(expr) == "" {
+		return nil, nil
+	}
+	tokens, err := tokenizeFilter(expr)
+	if err != nil {
+		return nil, err
+	}
+	parser := &filterParser{tokens: tokens}
+	root, err := parser.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := parser.peek(); tok.kind != tokEOF {
+		return nil, filterError(tok.pos, fmt.Sprintf("unexpected %q", tok.text))
+	}
+	return &Filter{expr: expr, root: root}, nil
+}
+
+// MetadataFilter matches records whose metadata has every key equal to the
+// given value. It backs the Metadata map on ticket and deployment queries.
+func MetadataFilter(metadata map[string]any) *Filter {
+	if len(metadata) == 0 {
+		return nil
+	}
+	normalized, _ := normalizeJSON(metadata).(map[string]any)
+	keys := make([]string, 0, len(normalized))
+	for key := range normalized {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var root filterNode
+	parts := make([]string, 0, len(keys))
+	for _, key := range keys {
+		node := compareNode{path: []string{"metadata", key}, op: "==", value: normalized[key]}
+		literal, _ := json.Marshal(normalized[key])
+		parts = append(parts, fmt.Sprintf("metadata.%s == %s", key, literal))
+		if root == nil {
+			root = node
+		} else {
+			root = andNode{root, node}
+		}
+	}
+	return &Filter{expr: strings.Join(parts, " && "), root: root}
+}
+
+// And combines two filters; either may be nil.
+func (f *Filter) And(other *Filter) *Filter {
+	switch {
+	case f == nil:
+		return other
+	case other == nil:
+		return f
+	}
+	return &Filter{expr: fmt.Sprintf("(%s) && (%s)", f.expr, other.expr), root: andNode{f.root, other.root}}
+}
+
+// Match evaluates the filter against a record (any JSON-serializable value).
+func (f *Filter) Match(record any) bool {
+	if f == nil {
+		return true
+	}
+	doc, ok := normalizeJSON(record).(map[string]any)
+	if !ok {
+		return false
+	}
+	return f.root.eval(doc)
+}
+
+func (f *Filter) String() string {
+	if f == nil {
+		return ""
+	}
+	return f.expr
+}
+
+// WithFilter attaches a filter expression for Query to parse and apply.
+func WithFilter(ctx context.Context, expr string) context.Context {
+	return context.WithValue(ctx, filterKey{}, expr)
+}
+
+type filterKey struct{}
+
+// FilterFromContext parses the expression attached by WithFilter.
+func FilterFromContext(ctx context.Context) (*Filter, error) {
+	if ctx == nil {
+		return nil, nil
+	}
+	expr, _ := ctx.Value(filterKey{}).(string)
+	return ParseFilter(expr)
+}
+
+// normalizeJSON round-trips a value through JSON so records and literals share
+// one representation: maps of string keys, []any, float64 numbers.
+func normalizeJSON(v any) any {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+	var out any
+	if err := json.Unmarshal(raw, &out); err != nil {
+		return nil
+	}
+	return out
+}
+
+func filterError(pos int, msg string) error {
+	return orcherr.New("bad_request", fmt.Sprintf("invalid filter at position %d: %s", pos, msg), nil)
+}
+
+// --- evaluation ---
+
+type filterNode interface {
+	eval(doc map[string]any) bool
+}
+
+type andNode struct{ left, right filterNode }
+type orNode struct{ left, right filterNode }
+type notNode struct{ inner filterNode }
+type existsNode struct{ path []string }
+type compareNode struct {
+	path  []string
+	op    string
+	value any
+}
+
+func (n andNode) eval(doc map[string]any) bool { return n.left.eval(doc) && n.right.eval(doc) }
+func (n orNode) eval(doc map[string]any) bool  { return n.left.eval(doc) || n.right.eval(doc) }
+func (n notNode) eval(doc map[string]any) bool { return !n.inner.eval(doc) }
+
+func (n existsNode) eval(doc map[string]any) bool {
+	v, ok := lookupPath(doc, n.path)
+	if !ok {
+		return false
+	}
+	switch t := v.(type) {
+	case nil:
+		return false
+	case bool:
+		return t
+	case float64:
+		return t != 0
+	case string:
+		return t != ""
+	case []any:
+		return len(t) > 0
+	case map[string]any:
+		return len(t) > 0
+	}
+	return true
+}
+
+func (n compareNode) eval(doc map[string]any) bool {
+	actual, ok := lookupPath(doc, n.path)
+	if !ok {
+		return n.op == "!="
+	}
+	switch n.op {
+	case "==":
+		return filterEqual(actual, n.value)
+	case "!=":
+		return !filterEqual(actual, n.value)
+	case "in", "not in":
+		list, _ := n.value.([]any)
+		found := false
+		if items, isList := actual.([]any); isList {
+			for _, item := range items {
+				if containsValue(list, item) {
+					found = true
+					break
+				}
+			}
+		} else {
+			found = containsValue(list, actual)
+		}
+		return found == (n.op == "in")
+	case "contains":
+		switch a := actual.(type) {
+		case string:
+			s, isString := n.value.(string)
+			return isString && strings.Contains(strings.ToLower(a), strings.ToLower(s))
+		case []any:
+			return containsValue(a, n.value)
+		}
+		return false
+	case "<", "<=", ">", ">=":
+		cmp, comparable := compareOrdered(actual, n.value)
+		if !comparable {
+			return false
+		}
+		switch n.op {
+		case "<":
+			return cmp < 0
+		case "<=":
+			return cmp <= 0
+		case ">":
+			return cmp > 0
+		default:
+			return cmp >= 0
+		}
+	}
+	return false
+}
+
+func lookupPath(doc map[string]any, path []string) (any, bool) {
+	var cur any = doc
+	for _, part := range path {
+		m, ok := cur.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		cur, ok = m[part]
+		if !ok {
+			return nil, false
+		}
+	}
+	return cur, true
+}
+
+func filterEqual(a, b any) bool {
+	switch av := a.(type) {
+	case []any:
+		bv, ok := b.([]any)
+		if !ok || len(av) != len(bv) {
+			return false
+		}
+		for i := range av {
+			if !filterEqual(av[i], bv[i]) {
+				return false
+			}
+		}
+		return true
+	case map[string]any:
+		bv, ok := b.(map[string]any)
+		if !ok || len(av) != len(bv) {
+			return false
+		}
+		for k, v := range av {
+			if other, ok := bv[k]; !ok || !filterEqual(v, other) {
+				return false
+			}
+		}
+		return true
+	}
+	return a == b
+}
+
+func containsValue(list []any, v any) bool {
+	for _, item := range list {
+		if filterEqual(item, v) {
+			return true
+		}
+	}
+	return false
+}
+
+func compareOrdered(a, b any) (int, bool) {
+	switch av := a.(type) {
+	case float64:
+		bv, ok := b.(float64)
+		if !ok {
+			return 0, false
+		}
+		switch {
+		case av < bv:
+			return -1, true
+		case av > bv:
+			return 1, true
+		}
+		return 0, true
+	case string:
+		bv, ok := b.(string)
+		if !ok {
+			return 0, false
+		}
+		return strings.Compare(av, bv), true
+	}
+	return 0, false
+}
+
+// --- parsing ---
+
+type filterTokenKind int
+
+const (
+	tokEOF filterTokenKind = iota
+	tokIdent
+	tokString
+	tokNumber
+	tokOp
+	tokPunct
+)
+
+type filterToken struct {
+	kind filterTokenKind
+	text string
+	pos  int
+}
+
+func tokenizeFilter(expr string) ([]filterToken, error) {
+	tokens := make([]filterToken, 0)
+	runes := []rune(expr)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '"' || r == '\'':
+			start := i
+			i++
+			var b strings.Builder
+			for ; i < len(runes) && runes[i] != r; i++ {
+				if runes[i] == '\\' && i+1 < len(runes) {
+					i++
+				}
+				b.WriteRune(runes[i])
+			}
+			if i >= len(runes) {
+				return nil, filterError(start, "unterminated string")
+			}
+			i++
+			tokens = append(tokens, filterToken{kind: tokString, text: b.String(), pos: start})
+		case unicode.IsDigit(r) || (r == '-' && i+1 < len(runes) && unicode.IsDigit(runes[i+1])):
+			start := i
+			i++
+			for i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '.') {
+				i++
+			}
+			tokens = append(tokens, filterToken{kind: tokNumber, text: string(runes[start:i]), pos: start})
+		case unicode.IsLetter(r) || r == '_':
+			start := i
+			for i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) || strings.ContainsRune("_.-", runes[i])) {
+				i++
+			}
+			tokens = append(tokens, filterToken{kind: tokIdent, text: string(runes[start:i]), pos: start})
+		case strings.ContainsRune("()[],", r):
+			tokens = append(tokens, filterToken{kind: tokPunct, text: string(r), pos: i})
+			i++
+		default:
+			start := i
+			two := ""
+			if i+1 < len(runes) {
+				two = string(runes[i : i+2])
+			}
+			switch {
+			case two == "==" || two == "!=" || two == "<=" || two == ">=" || two == "&&" || two == "||":
+				tokens = append(tokens, filterToken{kind: tokOp, text: two, pos: start})
+				i += 2
+			case r == '<' || r == '>' || r == '!':
+				tokens = append(tokens, filterToken{kind: tokOp, text: string(r), pos: start})
+				i++
+			default:
+				return nil, filterError(start, fmt.Sprintf("unexpected character %q", r))
+			}
+		}
+	}
+	return append(tokens, filterToken{kind: tokEOF, pos: len(runes)}), nil
+}
+
+type filterParser struct {
+	tokens []filterToken
+	pos    int
+}
+
+func (p *filterParser) peek() filterToken { return p.tokens[p.pos] }
+
+func (p *filterParser) next() filterToken {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokEOF {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *filterParser) accept(kind filterTokenKind, text string) bool {
+	if tok := p.peek(); tok.kind == kind && tok.text == text {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *filterParser) parseOr() (filterNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.accept(tokOp, "||") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = orNode{left, right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseAnd() (filterNode, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.accept(tokOp, "&&") {
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = andNode{left, right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseUnary() (filterNode, error) {
+	if p.accept(tokOp, "!") {
+		inner, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return notNode{inner}, nil
+	}
+	if p.accept(tokPunct, "(") {
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if !p.accept(tokPunct, ")") {
+			tok := p.peek()
+			return nil, filterError(tok.pos, "expected )")
+		}
+		return inner, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *filterParser) parseComparison() (filterNode, error) {
+	tok := p.next()
+	if tok.kind != tokIdent {
+		return nil, filterError(tok.pos, "expected a field path")
+	}
+	path := strings.Split(tok.text, ".")
+
+	opTok := p.peek()
+	var op string
+	switch {
+	case opTok.kind == tokOp && opTok.text != "&&" && opTok.text != "||" && opTok.text != "!":
+		op = p.next().text
+	case opTok.kind == tokIdent && (opTok.text == "in" || opTok.text == "contains"):
+		op = p.next().text
+	case opTok.kind == tokIdent && opTok.text == "not":
+		p.next()
+		if !p.accept(tokIdent, "in") {
+			return nil, filterError(p.peek().pos, "expected in after not")
+		}
+		op = "not in"
+	default:
+		return existsNode{path: path}, nil
+	}
+
+	var value any
+	var err error
+	if op == "in" || op == "not in" {
+		value, err = p.parseList()
+	} else {
+		value, err = p.parseLiteral()
+	}
+	if err != nil {
+		return nil, err
+	}
+	return compareNode{path: path, op: op, value: value}, nil
+}
+
+func (p *filterParser) parseList() ([]any, error) {
+	if !p.accept(tokPunct, "[") {
+		return nil, filterError(p.peek().pos, "expected [")
+	}
+	items := make([]any, 0)
+	if p.accept(tokPunct, "]") {
+		return items, nil
+	}
+	for {
+		item, err := p.parseLiteral()
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+		if p.accept(tokPunct, "]") {
+			return items, nil
+		}
+		if !p.accept(tokPunct, ",") {
+			return nil, filterError(p.peek().pos, "expected , or ]")
+		}
+	}
+}
+
+func (p *filterParser) parseLiteral() (any, error) {
+	tok := p.next()
+	switch tok.kind {
+	case tokString:
+		return tok.text, nil
+	case tokNumber:
+		n, err := strconv.ParseFloat(tok.text, 64)
+		if err != nil {
+			return nil, filterError(tok.pos, fmt.Sprintf("invalid number %q", tok.text))
+		}
+		return n, nil
+	case tokIdent:
+		switch tok.text {
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		case "null":
+			return nil, nil
+		}
+	case tokPunct:
+		if tok.text == "[" {
+			p.pos--
+			return p.parseList()
+		}
+	}
+	return nil, filterError(tok.pos, "expected a value")
+}
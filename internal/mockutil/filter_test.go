@@ -0,0 +1,89 @@
+package mockutil
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/opsorch/opsorch-core/schema"
+)
+
+func TestFilter_Match(t *testing.T) {
+	ticket := schema.Ticket{
+		ID:        "TCK-1",
+		Status:    "in_progress",
+		Assignees: []string{"alex", "sam"},
+		Fields:    map[string]any{"priority": "P1", "storyPoints": 5, "labels": map[string]any{"area": "checkout"}},
+		Metadata:  map[string]any{"epic": "PAY-121", "is_scenario": true},
+	}
+
+	tests := []struct {
+		expr string
+		want bool
+	}{
+		{`metadata.epic == "PAY-121" && fields.priority in ["P0","P1"]`, true},
+		{`metadata.epic == "PAY-121" && fields.priority in ["P0"]`, false},
+		{`metadata.epic != "RECO-88"`, true},
+		{`metadata.missing != "x"`, true},
+		{`metadata.missing == "x"`, false},
+		{`fields.storyPoints >= 5 && fields.storyPoints < 8`, true},
+		{`fields.storyPoints > 5`, false},
+		{`fields.labels.area == 'checkout'`, true},
+		{`assignees contains "sam"`, true},
+		{`assignees in ["kim", "alex"]`, true},
+		{`assignees not in ["kim"]`, true},
+		{`metadata.epic contains "pay"`, true},
+		{`metadata.is_scenario`, true},
+		{`!metadata.is_scenario || status == "done"`, false},
+		{`(status == "done" || status == "in_progress") && !(fields.priority == "P2")`, true},
+	}
+	for _, tt := range tests {
+		f, err := ParseFilter(tt.expr)
+		if err != nil {
+			t.Fatalf("ParseFilter(%q) returned error: %v", tt.expr, err)
+		}
+		if got := f.Match(ticket); got != tt.want {
+			t.Errorf("%s: got %v, want %v", tt.expr, got, tt.want)
+		}
+	}
+}
+
+func TestFilter_Errors(t *testing.T) {
+	for _, expr := range []string{
+		`metadata.epic ==`,
+		`metadata.epic == "PAY-121" &&`,
+		`fields.priority in "P0"`,
+		`(status == "open"`,
+		`status = "open"`,
+		`status == "open`,
+		`status not "open"`,
+	} {
+		if _, err := ParseFilter(expr); err == nil || !strings.Contains(err.Error(), "bad_request") {
+			t.Errorf("ParseFilter(%q): expected bad_request, got %v", expr, err)
+		}
+	}
+}
+
+func TestFilter_NilAndMetadata(t *testing.T) {
+	f, err := ParseFilter("  ")
+	if err != nil || f != nil {
+		t.Fatalf("expected nil filter for blank expression, got %v, %v", f, err)
+	}
+	if !f.Match(schema.Ticket{}) {
+		t.Fatal("nil filter should match everything")
+	}
+
+	meta := MetadataFilter(map[string]any{"epic": "PAY-121", "attempts": 2, "tags": []string{"a", "b"}})
+	record := map[string]any{"metadata": map[string]any{"epic": "PAY-121", "attempts": 2.0, "tags": []any{"a", "b"}}}
+	if !meta.Match(record) {
+		t.Fatalf("expected %s to match %v", meta, record)
+	}
+	if meta.And(nil) != meta || (*Filter)(nil).And(meta) != meta {
+		t.Fatal("And with nil should return the other filter")
+	}
+
+	ctx := WithFilter(context.Background(), `status ==`)
+	if _, err := FilterFromContext(ctx); err == nil {
+		t.Fatal("expected parse error from context filter")
+	}
+}
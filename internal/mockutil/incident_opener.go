@@ -0,0 +1,37 @@
+package mockutil
+
+import (
+	"context"
+
+	"github.com/opsorch/opsorch-core/orcherr"
+)
+
+// LinkedIncident is an incident another provider opens through the incident
+// provider it was wired to, such as one raised by a failed deployment.
+type LinkedIncident struct {
+	Title       string
+	Description string
+	Service     string
+	// Severity is left empty to take the incident provider's default.
+	Severity string
+	Fields   map[string]any
+	Metadata map[string]any
+	// Note, when set, is appended to the new incident's timeline by Actor.
+	Note  string
+	Actor map[string]any
+}
+
+// IncidentOpener creates a LinkedIncident and returns its ID. Hosts holding
+// an incident provider pass its OpenLinked to the providers that open
+// incidents; there is no process-wide opener, so two hosts in one process
+// never open incidents in each other's provider.
+type IncidentOpener func(ctx context.Context, in LinkedIncident) (string, error)
+
+// Open opens in. It fails with not_found when o is nil, as when no incident
+// provider was wired in.
+func (o IncidentOpener) Open(ctx context.Context, in LinkedIncident) (string, error) {
+	if o == nil {
+		return "", orcherr.New("not_found", "no incident provider wired in", nil)
+	}
+	return o(ctx, in)
+}
@@ -0,0 +1,45 @@
+package mockutil
+
+import "strings"
+
+// Locales with translated seed text. English, the text the seeds are written
+// in, needs no catalog.
+const (
+	LocaleGerman   = "de"
+	LocaleJapanese = "ja"
+)
+
+// localeCatalogs map seeded English titles and descriptions to their
+// translations.
+var localeCatalogs = map[string]map[string]string{
+	LocaleGerman:   catalogDE,
+	LocaleJapanese: catalogJA,
+}
+
+// ParseLocale reads the "locale" config key, e.g. "de", "de-DE", or "ja_JP",
+// and returns the language with a translated catalog. It returns "" when
+// unset, English, or unsupported, which keeps the seeds in English.
+func ParseLocale(cfg map[string]any) string {
+	raw, ok := cfg["locale"].(string)
+	if !ok {
+		return ""
+	}
+	lang := strings.ToLower(strings.TrimSpace(raw))
+	if i := strings.IndexAny(lang, "-_"); i >= 0 {
+		lang = lang[:i]
+	}
+	if _, ok := localeCatalogs[lang]; !ok {
+		return ""
+	}
+	return lang
+}
+
+// Localize returns the locale's translation of seeded text. Text without a
+// translation, such as generated or client-supplied text, is returned
+// unchanged.
+func Localize(locale, text string) string {
+	if translated, ok := localeCatalogs[locale][text]; ok {
+		return translated
+	}
+	return text
+}
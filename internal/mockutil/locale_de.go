@@ -0,0 +1,496 @@
+package mockutil
+
+// catalogDE holds the German seed text, keyed by the English original.
+var catalogDE = map[string]string{
+	// Incidents.
+	"Checkout latency impacting EU customers":                                                       "Checkout-Latenz beeinträchtigt EU-Kunden",
+	"High checkout latency causing timeouts for a slice of EU traffic":                              "Hohe Checkout-Latenz verursacht Timeouts für einen Teil des EU-Traffics",
+	"Search results intermittently empty":                                                           "Suchergebnisse zeitweise leer",
+	"Search API intermittently returns empty result sets":                                           "Die Such-API liefert zeitweise leere Ergebnismengen",
+	"Payments webhook timeouts from Stripe":                                                         "Zahlungs-Webhook-Timeouts von Stripe",
+	"Stripe webhook requests timing out from payments provider":                                     "Stripe-Webhook-Anfragen vom Zahlungsanbieter laufen in Timeouts",
+	"Notification fanout lagging for promos":                                                        "Benachrichtigungs-Fanout für Aktionen verzögert",
+	"Notification fanout workers are lagging promo campaigns":                                       "Fanout-Worker für Benachrichtigungen hängen bei Aktionskampagnen hinterher",
+	"Auth latency spikes for mobile logins":                                                         "Auth-Latenzspitzen bei mobilen Anmeldungen",
+	"Mobile login auth latency spiking for the identity service":                                    "Auth-Latenz mobiler Anmeldungen im Identity-Service steigt sprunghaft",
+	"Warehouse batch stuck on partition 7":                                                          "Warehouse-Batch hängt bei Partition 7",
+	"Warehouse batch job stuck processing partition 7":                                              "Warehouse-Batchjob bleibt bei der Verarbeitung von Partition 7 hängen",
+	"Recommendation quality drop after rollout":                                                     "Empfehlungsqualität nach Rollout gesunken",
+	"New recommendation rollout degraded quality metrics":                                           "Neuer Empfehlungs-Rollout hat die Qualitätsmetriken verschlechtert",
+	"Analytics pipeline missing events from APAC":                                                   "Analytics-Pipeline fehlen Events aus APAC",
+	"Analytics pipeline missing events originating from APAC region":                                "Der Analytics-Pipeline fehlen Events aus der Region APAC",
+	"Order placement errors for prepaid cards":                                                      "Fehler bei Bestellungen mit Prepaid-Karten",
+	"Orders paid with prepaid cards fail due to processor errors":                                   "Mit Prepaid-Karten bezahlte Bestellungen schlagen wegen Fehlern des Zahlungsabwicklers fehl",
+	"Catalog indexer backlog after schema change":                                                   "Rückstau im Katalog-Indexer nach Schemaänderung",
+	"Catalog indexing backlog building after schema migration":                                      "Nach der Schemamigration baut sich ein Rückstau bei der Katalogindizierung auf",
+	"Shipping tracker returns stale data":                                                           "Sendungsverfolgung liefert veraltete Daten",
+	"Shipping tracker caches are returning stale tracking payloads":                                 "Die Caches der Sendungsverfolgung liefern veraltete Tracking-Daten",
+	"Realtime updates disconnect in Firefox":                                                        "Echtzeit-Updates brechen in Firefox ab",
+	"Realtime websocket connections drop for Firefox clients":                                       "Echtzeit-WebSocket-Verbindungen von Firefox-Clients brechen ab",
+	"Analytics Correlation Failure":                                                                 "Fehler bei der Analytics-Korrelation",
+	"Correlation services are failing to process events, leading to data gaps.":                     "Die Korrelationsdienste können Events nicht verarbeiten, wodurch Datenlücken entstehen.",
+	"Payment Latency Spikes":                                                                        "Latenzspitzen bei Zahlungen",
+	"Payment service is experiencing intermittent latency spikes, impacting checkout success rate.": "Der Zahlungsdienst hat zeitweise Latenzspitzen, die die Checkout-Erfolgsquote beeinträchtigen.",
+	"SLO Budget Exhaustion - Checkout Service":                                                      "SLO-Budget erschöpft – Checkout-Service",
+	"Error budget for checkout service completely exhausted, 99.9% SLO breached":                    "Fehlerbudget des Checkout-Service vollständig aufgebraucht, 99,9-%-SLO verletzt",
+	"Cascading Failure - Database Connection Pool Exhaustion":                                       "Kaskadierender Ausfall – Datenbank-Verbindungspool erschöpft",
+	"Database connection pool exhausted causing cascading failures across dependent services":       "Erschöpfter Datenbank-Verbindungspool verursacht kaskadierende Ausfälle in abhängigen Diensten",
+	"Deployment Rollback - Payment Service v2.8.3":                                                  "Deployment-Rollback – Payment Service v2.8.3",
+	"Automated rollback triggered due to elevated error rates after deployment":                     "Automatischer Rollback wegen erhöhter Fehlerraten nach dem Deployment ausgelöst",
+	"External Dependency Failure - Stripe API Degradation":                                          "Ausfall einer externen Abhängigkeit – Stripe-API beeinträchtigt",
+	"Stripe payment API experiencing elevated latency and rate limiting, impacting checkout flow":   "Die Stripe-Zahlungs-API zeigt erhöhte Latenz und Ratenbegrenzung, was den Checkout beeinträchtigt",
+	"Autoscaling Lag - Traffic Spike Exceeds Capacity":                                              "Verzögertes Autoscaling – Traffic-Spitze übersteigt Kapazität",
+	"Sudden traffic spike detected, autoscaling in progress but lagging behind demand":              "Plötzliche Traffic-Spitze erkannt, Autoscaling läuft, hinkt dem Bedarf aber hinterher",
+	"Circuit Breaker Cascade - Recommendation Service Failure":                                      "Circuit-Breaker-Kaskade – Ausfall des Empfehlungsdienstes",
+	"Circuit breakers tripping across multiple services due to recommendation service degradation":  "Circuit Breaker lösen in mehreren Diensten aus, weil der Empfehlungsdienst beeinträchtigt ist",
+
+	// Incident history.
+	"Checkout error budget burn after traffic surge":                                            "Checkout-Fehlerbudget nach Traffic-Anstieg aufgebraucht",
+	"Checkout 5xx burned 40% of the monthly error budget in two hours during a flash sale":      "Checkout-5xx-Fehler verbrauchten während eines Flash-Sales in zwei Stunden 40 % des monatlichen Fehlerbudgets",
+	"Checkout latency SLO breach in EU":                                                         "Checkout-Latenz-SLO in der EU verletzt",
+	"Checkout p95 latency above 1.5s for EU customers; SLO burn rate 14x":                       "Checkout-p95-Latenz über 1,5 s für EU-Kunden; SLO-Burn-Rate 14x",
+	"Checkout timeouts during marketing campaign":                                               "Checkout-Timeouts während einer Marketingkampagne",
+	"Checkout requests timing out under campaign load; error budget nearly exhausted":           "Checkout-Anfragen laufen unter Kampagnenlast in Timeouts; Fehlerbudget fast aufgebraucht",
+	"Database connection pool exhausted":                                                        "Datenbank-Verbindungspool erschöpft",
+	"Order service connection pool exhausted, cascading failures into checkout and payments":    "Verbindungspool des Bestellservice erschöpft, Folgefehler in Checkout und Zahlungen",
+	"Cascading failures from slow database replica":                                             "Kaskadierende Fehler durch langsames Datenbank-Replikat",
+	"Read replica lag caused request pile-ups that cascaded through catalog and search":         "Verzögerung des Lese-Replikats staute Anfragen, die sich über Katalog und Suche ausbreiteten",
+	"Payments errors after deployment":                                                          "Zahlungsfehler nach Deployment",
+	"Payment service 5xx jumped to 8% right after deploying v2.7.0; rolled back":                "5xx-Rate des Zahlungsservice stieg direkt nach dem Deployment von v2.7.0 auf 8 %; zurückgerollt",
+	"Search relevance regression after release":                                                 "Suchrelevanz nach Release verschlechtert",
+	"Search release 4.2 returned empty results for multi-word queries; rolled back":             "Such-Release 4.2 lieferte leere Ergebnisse für Suchanfragen mit mehreren Wörtern; zurückgerollt",
+	"Checkout deploy rolled back after latency spike":                                           "Checkout-Deployment nach Latenzspitze zurückgerollt",
+	"Checkout v3.1.0 doubled p99 latency; automatic rollback triggered":                         "Checkout v3.1.0 verdoppelte die p99-Latenz; automatischer Rollback ausgelöst",
+	"Stripe API rate limiting payments":                                                         "Stripe-API drosselt Zahlungen",
+	"Stripe returned 429s for 20 minutes; checkout payments failed intermittently":              "Stripe lieferte 20 Minuten lang 429-Fehler; Checkout-Zahlungen schlugen zeitweise fehl",
+	"Shipping carrier API outage":                                                               "Ausfall der Versanddienstleister-API",
+	"Fast-ship carrier API returned 503 for label creation; shipments queued":                   "Die API des Express-Versanddienstleisters lieferte 503 bei der Etikettenerstellung; Sendungen wurden eingereiht",
+	"Search latency during traffic spike":                                                       "Suchlatenz während einer Traffic-Spitze",
+	"Search p95 latency tripled while autoscaling lagged behind a traffic spike":                "Such-p95-Latenz verdreifachte sich, während das Autoscaling einer Traffic-Spitze hinterherhinkte",
+	"Web frontend slow during product launch":                                                   "Web-Frontend während eines Produktlaunchs langsam",
+	"Web frontend pods saturated during a product launch before autoscaling caught up":          "Web-Frontend-Pods waren während eines Produktlaunchs ausgelastet, bevor das Autoscaling nachzog",
+	"Recommendation timeouts tripping circuit breakers":                                         "Empfehlungs-Timeouts lösen Circuit Breaker aus",
+	"Recommendation inference timeouts opened circuit breakers in web and checkout":             "Inferenz-Timeouts der Empfehlungen öffneten Circuit Breaker in Web und Checkout",
+	"Circuit breakers open across checkout dependencies":                                        "Circuit Breaker über Checkout-Abhängigkeiten hinweg offen",
+	"Breakers opened on checkout calls to recommendations and inventory after a GC pause storm": "Nach einer Häufung von GC-Pausen öffneten Breaker bei Checkout-Aufrufen an Empfehlungen und Inventar",
+	"Analytics pipeline dropped events":                                                         "Analytics-Pipeline hat Events verworfen",
+	"Analytics ETL dropped APAC events for six hours":                                           "Analytics-ETL verwarf sechs Stunden lang APAC-Events",
+	"Identity provider token refresh failures":                                                  "Fehler bei der Token-Aktualisierung des Identity-Providers",
+	"Token refresh requests failed for 3% of mobile sessions":                                   "Token-Aktualisierungen schlugen für 3 % der mobilen Sitzungen fehl",
+	"Checkout 5xx spike":                                              "Checkout-5xx-Spitze",
+	"Checkout 5xx rate above 2% for several minutes":                  "Checkout-5xx-Rate mehrere Minuten über 2 %",
+	"Payments provider timeouts":                                      "Timeouts beim Zahlungsanbieter",
+	"Card authorisations timing out against the payment provider":     "Kartenautorisierungen laufen beim Zahlungsanbieter in Timeouts",
+	"Search index lag":                                                "Verzögerung des Suchindex",
+	"Search index updates delayed; new products missing from results": "Suchindex-Aktualisierungen verzögert; neue Produkte fehlen in den Ergebnissen",
+	"Order database slow queries":                                     "Langsame Abfragen in der Bestelldatenbank",
+	"Order write latency elevated from slow queries on the primary":   "Erhöhte Schreiblatenz bei Bestellungen durch langsame Abfragen auf dem Primary",
+	"Catalog deploy rolled back":                                      "Katalog-Deployment zurückgerollt",
+	"Catalog release raised error rates and was rolled back":          "Katalog-Release erhöhte die Fehlerraten und wurde zurückgerollt",
+
+	// Alerts.
+	"Checkout latency SLO breach": "Checkout-Latenz-SLO verletzt",
+	"Checkout p95 latency exceeded 1.2s for the past 15 minutes affecting 45% of transactions":         "Checkout-p95-Latenz lag in den letzten 15 Minuten über 1,2 s und betrifft 45 % der Transaktionen",
+	"Database primary failover initiated":                                                              "Failover der primären Datenbank eingeleitet",
+	"Primary database node unresponsive, automatic failover to replica in progress":                    "Primärer Datenbankknoten reagiert nicht, automatischer Failover auf das Replikat läuft",
+	"Payment processing complete outage":                                                               "Vollständiger Ausfall der Zahlungsabwicklung",
+	"Payment gateway returning 503 errors, zero successful transactions in last 10 minutes":            "Das Payment-Gateway liefert 503-Fehler, in den letzten 10 Minuten keine erfolgreiche Transaktion",
+	"Search 5xx spike on cluster ares":                                                                 "5xx-Spitze bei der Suche auf Cluster ares",
+	"Search error budget is being consumed due to node instability, 4.2% error rate":                   "Das Fehlerbudget der Suche wird durch instabile Knoten aufgebraucht, Fehlerrate 4,2 %",
+	"Catalog inventory sync drift":                                                                     "Abweichung bei der Katalog-Bestandssynchronisierung",
+	"Inventory deltas from ERP lagging beyond 20 minutes in us-east, 2,400 SKUs out of sync":           "Bestandsänderungen aus dem ERP hängen in us-east über 20 Minuten hinterher, 2.400 SKUs nicht synchron",
+	"Realtime websocket disconnects":                                                                   "Echtzeit-WebSocket-Verbindungsabbrüche",
+	"Firefox clients disconnect after ~45s with close code 1006, affecting 27% of connections":         "Firefox-Clients trennen nach ca. 45 s mit Close-Code 1006, betroffen sind 27 % der Verbindungen",
+	"Container restart loop on pod checkout-7d4f":                                                      "Container-Neustartschleife auf Pod checkout-7d4f",
+	"Pod restarted 8 times in 15 minutes due to OOMKilled events":                                      "Pod wurde wegen OOMKilled-Ereignissen in 15 Minuten 8-mal neu gestartet",
+	"API rate limit exhaustion for mobile clients":                                                     "API-Ratenlimit für mobile Clients ausgeschöpft",
+	"Mobile API rate limits hit 95% capacity, throttling beginning":                                    "Mobile API-Ratenlimits zu 95 % ausgelastet, Drosselung beginnt",
+	"Certificate expiration warning":                                                                   "Warnung: Zertifikat läuft ab",
+	"TLS certificate for api.demo.com expires in 14 days":                                              "TLS-Zertifikat für api.demo.com läuft in 14 Tagen ab",
+	"DNS resolution failures spiking":                                                                  "DNS-Auflösungsfehler steigen sprunghaft",
+	"DNS lookup failures increased to 2.1% of requests in eu-west-1":                                   "DNS-Lookup-Fehler in eu-west-1 auf 2,1 % der Anfragen gestiegen",
+	"Notification queue depth high":                                                                    "Hohe Tiefe der Benachrichtigungswarteschlange",
+	"Promo notification fanout queue depth above 40k messages affecting 15,000 users":                  "Warteschlange für Aktionsbenachrichtigungen über 40.000 Nachrichten, 15.000 Nutzer betroffen",
+	"Payments webhook retries exhausted":                                                               "Wiederholungen des Zahlungs-Webhooks ausgeschöpft",
+	"Stripe webhook deliveries repeated 5 times without success for 18 events":                         "Stripe-Webhook-Zustellungen für 18 Events 5-mal ohne Erfolg wiederholt",
+	"Web vitals CLS regression on 8.2":                                                                 "Web-Vitals-CLS-Regression in 8.2",
+	"Core web vitals degrade for EU mobile traffic on release 8.2":                                     "Core Web Vitals verschlechtern sich für mobilen EU-Traffic mit Release 8.2",
+	"Analytics pipeline APAC gap":                                                                      "APAC-Lücke in der Analytics-Pipeline",
+	"APAC tracking stream produced zero events for 12 minutes":                                         "Der APAC-Tracking-Stream hat 12 Minuten lang keine Events geliefert",
+	"Data warehouse compaction backlog":                                                                "Rückstau bei der Data-Warehouse-Kompaktierung",
+	"Compact job queue length exceeding 3x expected baseline":                                          "Länge der Kompaktierungs-Warteschlange übersteigt das Dreifache der erwarteten Baseline",
+	"Cache hit rate degradation":                                                                       "Cache-Trefferquote verschlechtert",
+	"Redis cache hit rate dropped from 94% to 67% over last hour":                                      "Redis-Cache-Trefferquote in der letzten Stunde von 94 % auf 67 % gefallen",
+	"Disk space warning on log aggregator":                                                             "Speicherplatzwarnung auf dem Log-Aggregator",
+	"Log aggregator disk usage at 82% capacity in us-west-2":                                           "Festplattenbelegung des Log-Aggregators in us-west-2 bei 82 %",
+	"Load balancer health check failures":                                                              "Fehlgeschlagene Health Checks am Load Balancer",
+	"3 of 12 backend instances failing health checks in eu-central-1":                                  "3 von 12 Backend-Instanzen in eu-central-1 bestehen die Health Checks nicht",
+	"Circuit breaker tripped for recommendation service":                                               "Circuit Breaker für den Empfehlungsdienst ausgelöst",
+	"Circuit breaker open after 15 consecutive failures to recommendation API":                         "Circuit Breaker nach 15 aufeinanderfolgenden Fehlern der Empfehlungs-API geöffnet",
+	"Unusual authentication pattern detected":                                                          "Ungewöhnliches Authentifizierungsmuster erkannt",
+	"Login attempts from 47 different countries in 10 minutes for user segment":                        "Anmeldeversuche aus 47 verschiedenen Ländern innerhalb von 10 Minuten für ein Nutzersegment",
+	"Background job processing lag":                                                                    "Verzögerung bei der Verarbeitung von Hintergrundjobs",
+	"Email delivery job queue lag increased to 45 minutes":                                             "Verzögerung der E-Mail-Versand-Warteschlange auf 45 Minuten gestiegen",
+	"Database connection pool saturation":                                                              "Datenbank-Verbindungspool ausgelastet",
+	"Connection pool utilization at 92% for catalog database":                                          "Auslastung des Verbindungspools der Katalogdatenbank bei 92 %",
+	"API key usage anomaly":                                                                            "Auffällige Nutzung eines API-Schlüssels",
+	"API key abc123 exceeded normal usage by 340% in last hour":                                        "API-Schlüssel abc123 hat die normale Nutzung in der letzten Stunde um 340 % überschritten",
+	"Warehouse ETL runtime variance":                                                                   "Laufzeitschwankung beim Warehouse-ETL",
+	"ETL job runtime variance exceeded 3x baseline but within acceptable limits":                       "Laufzeitschwankung des ETL-Jobs über dem Dreifachen der Baseline, aber innerhalb akzeptabler Grenzen",
+	"Support chatbot deflection drop":                                                                  "Deflection-Rate des Support-Chatbots gesunken",
+	"Chatbot deflection under 30% causing live agent queue growth":                                     "Chatbot-Deflection unter 30 % lässt die Warteschlange für Live-Agenten wachsen",
+	"Feature flag rollout progressing":                                                                 "Feature-Flag-Rollout schreitet voran",
+	"New checkout flow feature flag at 25% rollout, monitoring for issues":                             "Feature Flag für den neuen Checkout-Ablauf bei 25 % Rollout, Überwachung auf Probleme",
+	"Scheduled maintenance window approaching":                                                         "Geplantes Wartungsfenster steht bevor",
+	"Database maintenance window scheduled in 4 hours for replica upgrades":                            "Datenbank-Wartungsfenster für Replikat-Upgrades in 4 Stunden geplant",
+	"Conversion rate trending below target":                                                            "Conversion-Rate entwickelt sich unter dem Ziel",
+	"Weekly conversion rate at 2.4%, below 2.7% target but within normal variance":                     "Wöchentliche Conversion-Rate bei 2,4 %, unter dem Ziel von 2,7 %, aber innerhalb der normalen Schwankung",
+	"Deployment completed successfully":                                                                "Deployment erfolgreich abgeschlossen",
+	"Catalog service v3.14.2 deployed to production, monitoring for issues":                            "Katalogdienst v3.14.2 in Produktion ausgerollt, Überwachung auf Probleme",
+	"Kafka consumer lag increasing":                                                                    "Kafka-Consumer-Lag steigt",
+	"Analytics consumer group lag exceeded 500k messages on user-events topic":                         "Lag der Analytics-Consumer-Gruppe auf dem Topic user-events über 500.000 Nachrichten",
+	"Message queue depth critical":                                                                     "Tiefe der Nachrichtenwarteschlange kritisch",
+	"Notification delivery queue depth at 85k messages, approaching capacity":                          "Warteschlange für die Benachrichtigungszustellung bei 85.000 Nachrichten, nahe der Kapazitätsgrenze",
+	"Kafka broker disk usage high":                                                                     "Hohe Festplattenbelegung auf Kafka-Broker",
+	"Broker kafka-3 disk usage at 88% in us-west-2":                                                    "Festplattenbelegung von Broker kafka-3 in us-west-2 bei 88 %",
+	"Message producer throttling active":                                                               "Drosselung des Nachrichten-Producers aktiv",
+	"Event producer experiencing throttling due to quota limits":                                       "Event-Producer wird wegen Kontingentgrenzen gedrosselt",
+	"Node NotReady: ip-10-12-4-37.ec2.internal":                                                        "Knoten NotReady: ip-10-12-4-37.ec2.internal",
+	"Kubelet on ip-10-12-4-37 stopped posting status; 14 pods are being evicted":                       "Kubelet auf ip-10-12-4-37 meldet keinen Status mehr; 14 Pods werden verdrängt",
+	"Node disk pressure: ip-10-12-7-118.ec2.internal":                                                  "Knoten mit Festplattendruck: ip-10-12-7-118.ec2.internal",
+	"Root volume on ip-10-12-7-118 is 91% full; kubelet image garbage collection is failing":           "Root-Volume auf ip-10-12-7-118 ist zu 91 % voll; die Image-Garbage-Collection des Kubelets schlägt fehl",
+	"Cluster etcd commit latency high: prod-euw1":                                                      "Hohe etcd-Commit-Latenz im Cluster: prod-euw1",
+	"etcd p99 backend commit latency is 310ms on prod-euw1; API server writes are slowing down":        "etcd-p99-Backend-Commit-Latenz auf prod-euw1 bei 310 ms; Schreibvorgänge des API-Servers werden langsamer",
+	"Load balancer unhealthy targets: public-web-alb":                                                  "Fehlerhafte Ziele am Load Balancer: public-web-alb",
+	"2 of 6 targets behind public-web-alb are failing health checks":                                   "2 von 6 Zielen hinter public-web-alb bestehen die Health Checks nicht",
+	"Interface down on core switch: core-sw-use1-a":                                                    "Interface auf Core-Switch ausgefallen: core-sw-use1-a",
+	"SNMP linkDown trap: TenGigabitEthernet1/0/24 on core-sw-use1-a went down (uplink to rack B7)":     "SNMP-linkDown-Trap: TenGigabitEthernet1/0/24 auf core-sw-use1-a ist ausgefallen (Uplink zu Rack B7)",
+	"BGP peer session flapped: edge-rtr-euw1-b":                                                        "BGP-Peer-Sitzung geflappt: edge-rtr-euw1-b",
+	"SNMP bgpBackwardTransition trap from edge-rtr-euw1-b; peer 169.254.12.1 re-established after 40s": "SNMP-bgpBackwardTransition-Trap von edge-rtr-euw1-b; Peer 169.254.12.1 nach 40 s wiederhergestellt",
+	"SLO budget exhaustion - Checkout service":                                                         "SLO-Budget erschöpft – Checkout-Service",
+	"Error budget for checkout service exhausted, 99.9% SLO breached for 30 minutes":                   "Fehlerbudget des Checkout-Service aufgebraucht, 99,9-%-SLO seit 30 Minuten verletzt",
+	"Cascading failure - Database connection pool exhaustion":                                          "Kaskadierender Ausfall – Datenbank-Verbindungspool erschöpft",
+	"Database connection pool exhausted causing downstream service failures":                           "Erschöpfter Datenbank-Verbindungspool verursacht Ausfälle nachgelagerter Dienste",
+	"Deployment rollback triggered - Payment service":                                                  "Deployment-Rollback ausgelöst – Zahlungsdienst",
+	"Automated rollback initiated due to elevated error rates post-deployment":                         "Automatischer Rollback wegen erhöhter Fehlerraten nach dem Deployment eingeleitet",
+	"External dependency failure - Stripe API degradation":                                             "Ausfall einer externen Abhängigkeit – Stripe-API beeinträchtigt",
+	"Stripe payment API experiencing elevated latency and timeouts":                                    "Die Stripe-Zahlungs-API zeigt erhöhte Latenz und Timeouts",
+	"Autoscaling lag - Traffic spike exceeds capacity":                                                 "Verzögertes Autoscaling – Traffic-Spitze übersteigt Kapazität",
+	"Traffic spike detected, autoscaling in progress but lagging behind demand":                        "Traffic-Spitze erkannt, Autoscaling läuft, hinkt dem Bedarf aber hinterher",
+	"Circuit breaker cascade - Recommendation service":                                                 "Circuit-Breaker-Kaskade – Empfehlungsdienst",
+	"Circuit breakers tripping across multiple services due to recommendation service failure":         "Circuit Breaker lösen in mehreren Diensten wegen des Ausfalls des Empfehlungsdienstes aus",
+	"Analytics Correlation Lag":                                                                        "Verzögerung der Analytics-Korrelation",
+	"Correlation lag exceeds 30 minutes in svc-analytics.":                                             "Die Korrelationsverzögerung in svc-analytics übersteigt 30 Minuten.",
+	"Payment Service Latency":                                                                          "Latenz des Zahlungsdienstes",
+	"P99 latency for svc-payments exceeds 500ms.":                                                      "Die P99-Latenz von svc-payments übersteigt 500 ms.",
+
+	// Orchestration plans and steps.
+	"Multi-Service Feature Rollout": "Service-übergreifender Feature-Rollout",
+	"Orchestrated rollout of a new feature spanning multiple microservices. Demonstrates diamond dependency pattern (1->2,3->4->7 ...).": "Orchestrierter Rollout eines neuen Features über mehrere Microservices. Zeigt ein rautenförmiges Abhängigkeitsmuster (1->2,3->4->7 ...).",
+	"Prepare Shared Infrastructure": "Gemeinsame Infrastruktur vorbereiten",
+	"Provision necessary database schemas and shared message queues. Ensure capacity for new feature load.": "Benötigte Datenbankschemas und gemeinsame Nachrichtenwarteschlangen bereitstellen. Kapazität für die Last des neuen Features sicherstellen.",
+	"Deploy Authorization Service": "Autorisierungsdienst ausrollen",
+	"Deploy updated auth service with new scopes. Verify backward compatibility.": "Aktualisierten Auth-Dienst mit neuen Scopes ausrollen. Abwärtskompatibilität prüfen.",
+	"Deploy Data Processing Service":                                              "Datenverarbeitungsdienst ausrollen",
+	"Deploy new data processor consumers. Start consuming from new topics.":       "Neue Consumer für die Datenverarbeitung ausrollen. Verarbeitung der neuen Topics starten.",
+	"Run Data Migration": "Datenmigration ausführen",
+	"Execute backfill migration script for existing users. Validate data integrity.": "Backfill-Migrationsskript für bestehende Nutzer ausführen. Datenintegrität prüfen.",
+	"Update API Gateway Policies":                                   "API-Gateway-Richtlinien aktualisieren",
+	"Update gateway routing and rate limits. Enable new endpoints.": "Gateway-Routing und Ratenlimits aktualisieren. Neue Endpunkte aktivieren.",
+	"Refresh Client Tokens":                                         "Client-Tokens erneuern",
+	"Force refresh of client tokens to pick up new permissions. Monitor auth error rates.": "Erneuerung der Client-Tokens erzwingen, damit neue Berechtigungen greifen. Auth-Fehlerraten überwachen.",
+	"Enable Global Access": "Globalen Zugriff aktivieren",
+	"Flip feature flag to enable access for all users. Send release notification.": "Feature Flag umschalten, um den Zugriff für alle Nutzer freizugeben. Release-Benachrichtigung versenden.",
+	"Global Configuration Update": "Globale Konfigurationsaktualisierung",
+	"Concurrent update of configuration across all global regions. Demonstrates fan-out/fan-in pattern.": "Gleichzeitige Aktualisierung der Konfiguration in allen globalen Regionen. Zeigt das Fan-out/Fan-in-Muster.",
+	"Initiate Global Update": "Globale Aktualisierung starten",
+	"Prepare configuration payload and version. Acquire global lock.": "Konfigurations-Payload und Version vorbereiten. Globale Sperre setzen.",
+	"Update Region US-East": "Region US-East aktualisieren",
+	"Apply configuration to us-east-1 and us-east-2. Restart services if required.": "Konfiguration auf us-east-1 und us-east-2 anwenden. Dienste bei Bedarf neu starten.",
+	"Update Region US-West": "Region US-West aktualisieren",
+	"Apply configuration to us-west-1 and us-west-2. Restart services if required.": "Konfiguration auf us-west-1 und us-west-2 anwenden. Dienste bei Bedarf neu starten.",
+	"Update Region EU-Central": "Region EU-Central aktualisieren",
+	"Apply configuration to eu-central-1. Restart services if required.":                                "Konfiguration auf eu-central-1 anwenden. Dienste bei Bedarf neu starten.",
+	"Update Region AP-Southeast":                                                                        "Region AP-Southeast aktualisieren",
+	"Apply configuration to ap-southeast-1. Restart services if required.":                              "Konfiguration auf ap-southeast-1 anwenden. Dienste bei Bedarf neu starten.",
+	"Verify Global Consistency":                                                                         "Globale Konsistenz prüfen",
+	"Check all regions report the new configuration version. Release global lock.":                      "Prüfen, dass alle Regionen die neue Konfigurationsversion melden. Globale Sperre freigeben.",
+	"Legacy Monolith Upgrade":                                                                           "Upgrade des Legacy-Monolithen",
+	"Strict sequence of steps required to upgrade legacy monolith. Demonstrates long chain dependency.": "Strikte Schrittfolge für das Upgrade des Legacy-Monolithen. Zeigt eine lange Abhängigkeitskette.",
+	"Notify Maintenance Window":                                                                         "Wartungsfenster ankündigen",
+	"Send email to internal stakeholders.":                                                              "E-Mail an interne Stakeholder senden.",
+	"Stop Background Jobs":                                                                              "Hintergrundjobs stoppen",
+	"Pause all cron jobs and workers.":                                                                  "Alle Cronjobs und Worker pausieren.",
+	"Perform Full Backup":                                                                               "Vollständiges Backup durchführen",
+	"Take snapshot of database and file storage.":                                                       "Snapshot von Datenbank und Dateispeicher erstellen.",
+	"Enable Maintenance Mode":                                                                           "Wartungsmodus aktivieren",
+	"Redirect traffic to maintenance page.":                                                             "Traffic auf die Wartungsseite umleiten.",
+	"Apply Database Patches":                                                                            "Datenbank-Patches einspielen",
+	"Run SQL scripts for schema updates.":                                                               "SQL-Skripte für Schemaänderungen ausführen.",
+	"Upgrade Application Binaries":                                                                      "Anwendungs-Binaries aktualisieren",
+	"Replace executable on all nodes.":                                                                  "Programmdatei auf allen Knoten ersetzen.",
+	"Cold Restart":                                                                                      "Kaltstart",
+	"Start application process.":                                                                        "Anwendungsprozess starten.",
+	"Verify Internal Health":                                                                            "Interne Integrität prüfen",
+	"Check /health endpoint and logs.":                                                                  "Endpunkt /health und Logs prüfen.",
+	"Disable Maintenance Mode":                                                                          "Wartungsmodus deaktivieren",
+	"Restore user traffic.":                                                                             "Nutzer-Traffic wiederherstellen.",
+	"Resume Background Jobs":                                                                            "Hintergrundjobs fortsetzen",
+	"Unpause workers and verify processing.":                                                            "Worker fortsetzen und Verarbeitung prüfen.",
+	"Full Stack Deployment":                                                                             "Full-Stack-Deployment",
+	"Parallel coordinated deployment of Mobile and Web stacks. Demonstrates parallel tracks converging.": "Parallel koordiniertes Deployment der Mobile- und Web-Stacks. Zeigt zusammenlaufende parallele Stränge.",
+	"Mobile: Build iOS App":                        "Mobile: iOS-App bauen",
+	"Compile Swift code and sign archive.":         "Swift-Code kompilieren und Archiv signieren.",
+	"Mobile: Run UI Tests":                         "Mobile: UI-Tests ausführen",
+	"Execute XCTest suite on simulators.":          "XCTest-Suite auf Simulatoren ausführen.",
+	"Mobile: Submit to App Store":                  "Mobile: Im App Store einreichen",
+	"Upload binary to TestFlight for review.":      "Binary zur Prüfung auf TestFlight hochladen.",
+	"Web: Build Frontend":                          "Web: Frontend bauen",
+	"Run webpack build and optimize assets.":       "Webpack-Build ausführen und Assets optimieren.",
+	"Web: Deploy to S3":                            "Web: Auf S3 ausrollen",
+	"Upload static assets to hosting bucket.":      "Statische Assets in den Hosting-Bucket hochladen.",
+	"Web: Invalidate CDN":                          "Web: CDN invalidieren",
+	"Purge CloudFront cache.":                      "CloudFront-Cache leeren.",
+	"Release Announcement":                         "Release-Ankündigung",
+	"Coordinate blog post and social media blast.": "Blogbeitrag und Social-Media-Kampagne koordinieren.",
+	"Data Center Migration (Complex DAG)":          "Rechenzentrumsmigration (komplexer DAG)",
+	"Simulates a large-scale DC migration with deep branching. Start -> DB/Compute Branches -> DB splits to 3 tasks, Compute splits to 2 tasks -> Convergence.": "Simuliert eine groß angelegte RZ-Migration mit tiefer Verzweigung. Start -> DB-/Compute-Zweige -> DB teilt sich in 3 Aufgaben, Compute in 2 Aufgaben -> Zusammenführung.",
+	"Initialize Migration":                         "Migration initialisieren",
+	"Approve migration plan and notify users.":     "Migrationsplan freigeben und Nutzer benachrichtigen.",
+	"Prepare Database":                             "Datenbank vorbereiten",
+	"Allocate new DB resources.":                   "Neue DB-Ressourcen zuweisen.",
+	"Backup Primary DB":                            "Primäre DB sichern",
+	"Take full snapshot.":                          "Vollständigen Snapshot erstellen.",
+	"Provision Storage":                            "Speicher bereitstellen",
+	"Setup high-performance and archival storage.": "Hochleistungs- und Archivspeicher einrichten.",
+	"Configure Replication":                        "Replikation konfigurieren",
+	"Setup async replication to new region.":       "Asynchrone Replikation in die neue Region einrichten.",
+	"Prepare Compute":                              "Compute vorbereiten",
+	"Reserve instance capacity.":                   "Instanzkapazität reservieren.",
+	"Deploy Control Plane":                         "Control Plane ausrollen",
+	"Bootstrap Kubernetes masters.":                "Kubernetes-Master initialisieren.",
+	"Deploy Worker Nodes":                          "Worker-Knoten ausrollen",
+	"Provision autoscaling node groups.":           "Autoscaling-Knotengruppen bereitstellen.",
+	"Verify Infrastructure":                        "Infrastruktur prüfen",
+	"Run integration tests on new environment.":    "Integrationstests in der neuen Umgebung ausführen.",
+	"Switch Traffic":                               "Traffic umschalten",
+	"Update global DNS to point to new DC.":        "Globales DNS auf das neue RZ umstellen.",
+	"Region Evacuation Protocol (Extreme DAG)":     "Protokoll zur Regionsevakuierung (extremer DAG)",
+	"Full-scale region evacuation scenario. Features 3 parallel tracks (Data, Infra, Traffic), cross-track dependencies (Restore needs Backup+Infra), and multiple convergence points.": "Szenario einer vollständigen Regionsevakuierung. Enthält 3 parallele Stränge (Daten, Infra, Traffic), strangübergreifende Abhängigkeiten (Wiederherstellung braucht Backup+Infra) und mehrere Zusammenführungspunkte.",
+	"Initialize Evacuation":                             "Evakuierung einleiten",
+	"Declare incident and trigger evacuation protocol.": "Incident ausrufen und Evakuierungsprotokoll auslösen.",
+	"Block Global Writes":                               "Globale Schreibzugriffe sperren",
+	"Set applications to read-only mode.":               "Anwendungen in den Nur-Lese-Modus versetzen.",
+	"Backup DB Shard 1":                                 "DB-Shard 1 sichern",
+	"Trigger immediate snapshot for Shard 1.":           "Sofortigen Snapshot für Shard 1 auslösen.",
+	"Backup DB Shard 2":                                 "DB-Shard 2 sichern",
+	"Trigger immediate snapshot for Shard 2.":           "Sofortigen Snapshot für Shard 2 auslösen.",
+	"Snapshot Block Volumes":                            "Snapshot der Block-Volumes",
+	"Snapshot EBS volumes for stateful sets.":           "Snapshots der EBS-Volumes für StatefulSets erstellen.",
+	"Drain Regional Traffic":                            "Regionalen Traffic abziehen",
+	"Lower weight in global load balancer.":             "Gewichtung im globalen Load Balancer senken.",
+	"Update CDN Origins":                                "CDN-Origins aktualisieren",
+	"Point CDN to fallback region.":                     "CDN auf die Ausweichregion zeigen lassen.",
+	"Provision Disaster Recovery VPC":                   "Disaster-Recovery-VPC bereitstellen",
+	"Terraform apply new VPC.":                          "Neue VPC mit Terraform anlegen.",
+	"Provision New RDS Instances":                       "Neue RDS-Instanzen bereitstellen",
+	"Create fresh DB instances in DR region.":           "Neue DB-Instanzen in der DR-Region anlegen.",
+	"Provision K8s Cluster":                             "K8s-Cluster bereitstellen",
+	"Boot up EKS cluster.":                              "EKS-Cluster hochfahren.",
+	"Restore Shard 1 to DR":                             "Shard 1 in DR wiederherstellen",
+	"Restore snapshot 1 to new RDS.":                    "Snapshot 1 in neues RDS wiederherstellen.",
+	"Restore Shard 2 to DR":                             "Shard 2 in DR wiederherstellen",
+	"Restore snapshot 2 to new RDS.":                    "Snapshot 2 in neues RDS wiederherstellen.",
+	"Deploy Application Stack":                          "Anwendungs-Stack ausrollen",
+	"Helm install all microservices.":                   "Alle Microservices per Helm installieren.",
+	"Verify System Integrity":                           "Systemintegrität prüfen",
+	"Run end-to-end smoke tests.":                       "End-to-End-Smoke-Tests ausführen.",
+	"Global DNS Switchover":                             "Globale DNS-Umschaltung",
+	"Update Route53 to point to DR region as primary.":  "Route53 so aktualisieren, dass die DR-Region primär ist.",
+	"Database Connection Pool Exhaustion":               "Erschöpfung des Datenbank-Verbindungspools",
+	"Diagnostic and mitigation steps for database connection pool exhaustion incidents. Use this response for 'Cascading Failure - Database Connection Pool Exhaustion' scenarios or saturation alerts.": "Diagnose- und Gegenmaßnahmen bei Incidents durch einen erschöpften Datenbank-Verbindungspool. Für Szenarien 'Cascading Failure - Database Connection Pool Exhaustion' oder Sättigungsalarme verwenden.",
+	"Diagnose connection pool status": "Status des Verbindungspools diagnostizieren",
+	"Check current connection pool metrics and identify saturation levels. Run: `SELECT count(*) FROM pg_stat_activity;`": "Aktuelle Metriken des Verbindungspools prüfen und Sättigungsgrad ermitteln. Ausführen: `SELECT count(*) FROM pg_stat_activity;`",
+	"Identify connection leaks": "Verbindungslecks identifizieren",
+	"Analyze long-running queries and idle connections. Look for queries stuck in transaction state.": "Lang laufende Abfragen und inaktive Verbindungen analysieren. Nach Abfragen suchen, die im Transaktionszustand hängen.",
+	"Notify affected services": "Betroffene Dienste benachrichtigen",
+	"Send notification to service owners about potential connection issues. Prepare for possible service degradation.": "Service-Verantwortliche über mögliche Verbindungsprobleme informieren. Auf mögliche Beeinträchtigungen vorbereiten.",
+	"Restart connection pool": "Verbindungspool neu starten",
+	"Gracefully restart the connection pool to clear leaked connections. Coordinate with on-call to minimize impact.": "Verbindungspool kontrolliert neu starten, um verwaiste Verbindungen zu bereinigen. Mit der Rufbereitschaft abstimmen, um die Auswirkungen gering zu halten.",
+	"Scale connection limits": "Verbindungslimits skalieren",
+	"Increase connection pool size if needed to handle current load. Update configuration and redeploy.": "Größe des Verbindungspools bei Bedarf für die aktuelle Last erhöhen. Konfiguration aktualisieren und neu ausrollen.",
+	"Verify recovery": "Wiederherstellung prüfen",
+	"Confirm connection pool is healthy and services are responding normally. Monitor for 30 minutes.": "Bestätigen, dass der Verbindungspool gesund ist und die Dienste normal antworten. 30 Minuten überwachen.",
+	"High Latency Investigation": "Untersuchung hoher Latenz",
+	"Systematic investigation and mitigation of high latency issues, such as 'Checkout latency impacting EU customers'. Includes observability checks, bottleneck investigation, and escalation procedures.": "Systematische Untersuchung und Behebung hoher Latenz, etwa 'Checkout latency impacting EU customers'. Umfasst Observability-Prüfungen, Engpassanalyse und Eskalationsverfahren.",
+	"Check application metrics": "Anwendungsmetriken prüfen",
+	"Review p50, p95, p99 latency metrics across all services. Identify which services are affected.": "p50-, p95- und p99-Latenzmetriken aller Dienste prüfen. Betroffene Dienste ermitteln.",
+	"Trace request flow": "Anfragefluss nachverfolgen",
+	"Use distributed tracing to identify where latency is introduced. Check database, cache, and external service calls.": "Mit Distributed Tracing ermitteln, wo Latenz entsteht. Datenbank-, Cache- und externe Serviceaufrufe prüfen.",
+	"Check DB Latency": "DB-Latenz prüfen",
+	"Analyze database query performance and wait events.":    "Abfrageleistung und Wait Events der Datenbank analysieren.",
+	"Check Cache Hit Rate":                                   "Cache-Trefferquote prüfen",
+	"Verify Redis/Memcached hit rates and eviction metrics.": "Trefferquoten und Eviction-Metriken von Redis/Memcached prüfen.",
+	"Check Upstream Services":                                "Upstream-Dienste prüfen",
+	"Review latency metrics for dependent microservices.":    "Latenzmetriken der abhängigen Microservices prüfen.",
+	"Identify bottleneck":                                    "Engpass identifizieren",
+	"Determine root cause based on parallel investigation results. Gather evidence for mitigation.": "Ursache anhand der parallelen Untersuchungsergebnisse bestimmen. Belege für die Gegenmaßnahme sammeln.",
+	"Apply mitigation": "Gegenmaßnahme anwenden",
+	"Execute targeted fix based on identified bottleneck. May include query optimization, cache warming, or scaling.": "Gezielte Korrektur für den identifizierten Engpass durchführen. Kann Abfrageoptimierung, Cache-Vorwärmung oder Skalierung umfassen.",
+	"Escalate if needed": "Bei Bedarf eskalieren",
+	"If mitigation unsuccessful, escalate to platform team or external vendor. Prepare incident summary.": "Wenn die Gegenmaßnahme nicht greift, an das Plattform-Team oder den externen Anbieter eskalieren. Incident-Zusammenfassung vorbereiten.",
+	"Service Degradation Response": "Reaktion auf Service-Beeinträchtigung",
+	"Coordinated response to service degradation incidents. Includes triage, impact assessment, communication, and mitigation.": "Koordinierte Reaktion auf Incidents mit beeinträchtigten Diensten. Umfasst Triage, Auswirkungsanalyse, Kommunikation und Gegenmaßnahmen.",
+	"Triage incident": "Incident triagieren",
+	"Assess severity and scope of degradation. Determine if incident or maintenance window.": "Schweregrad und Umfang der Beeinträchtigung bewerten. Feststellen, ob Incident oder Wartungsfenster.",
+	"Assess customer impact": "Auswirkungen auf Kunden bewerten",
+	"Quantify affected users and business impact. Check error rates and transaction volume.": "Betroffene Nutzer und geschäftliche Auswirkungen beziffern. Fehlerraten und Transaktionsvolumen prüfen.",
+	"Communicate status": "Status kommunizieren",
+	"Post status page update and notify stakeholders. Provide ETA for resolution.": "Statusseite aktualisieren und Stakeholder benachrichtigen. Voraussichtliche Lösungszeit angeben.",
+	"Execute mitigation": "Gegenmaßnahme ausführen",
+	"Apply fix or workaround to restore service. May include rollback, scaling, or failover.": "Korrektur oder Workaround anwenden, um den Dienst wiederherzustellen. Kann Rollback, Skalierung oder Failover umfassen.",
+	"Schedule postmortem": "Postmortem planen",
+	"Schedule postmortem meeting to review root cause and prevention measures. Assign action items.": "Postmortem-Meeting zur Besprechung von Ursache und Präventionsmaßnahmen ansetzen. Aufgaben zuweisen.",
+	"Suspicious Activity Protocol": "Protokoll für verdächtige Aktivitäten",
+	"Security response for account anomalies. Triggered by 'Unusual authentication pattern detected' alerts or credential stuffing signals.": "Sicherheitsreaktion auf Kontoanomalien. Ausgelöst durch Alarme 'Unusual authentication pattern detected' oder Anzeichen für Credential Stuffing.",
+	"Analyze GeoIP patterns": "GeoIP-Muster analysieren",
+	"Review login IP distribution and calculate distance velocity. Identify impossible travel.": "Verteilung der Login-IPs prüfen und Reisegeschwindigkeit berechnen. Unmögliche Reisen erkennen.",
+	"Lock compromised accounts": "Kompromittierte Konten sperren",
+	"Temporarily lock accounts with confirmed suspicious activity. Revoke active sessions.": "Konten mit bestätigter verdächtiger Aktivität vorübergehend sperren. Aktive Sitzungen widerrufen.",
+	"Force MFA challenge": "MFA-Abfrage erzwingen",
+	"Require MFA for next login on borderline accounts. Reset 2FA tokens if compromised.": "Für Grenzfall-Konten MFA beim nächsten Login verlangen. 2FA-Tokens bei Kompromittierung zurücksetzen.",
+	"Notify users": "Nutzer benachrichtigen",
+	"Send security alerts to affected users via email/SMS. Prompt for password change.": "Betroffenen Nutzern Sicherheitswarnungen per E-Mail/SMS senden. Zur Passwortänderung auffordern.",
+	"Analytics Correlation Runbook": "Runbook für die Analytics-Korrelation",
+	"Automated response to analytics correlation lag. Includes backfilling data and verifying integrity.": "Automatisierte Reaktion auf Verzögerungen der Analytics-Korrelation. Umfasst das Nachladen von Daten und die Integritätsprüfung.",
+	"Observe Correlation Lag": "Korrelationsverzögerung beobachten",
+	"Check the current lag metrics from the analytics pipeline. Automated check.": "Aktuelle Lag-Metriken der Analytics-Pipeline prüfen. Automatisierte Prüfung.",
+	"Backfill Missing Data": "Fehlende Daten nachladen",
+	"Trigger the backfill job for the affected time range. Automated action.": "Backfill-Job für den betroffenen Zeitraum auslösen. Automatisierte Aktion.",
+	"Verify Data Integrity": "Datenintegrität prüfen",
+	"Run checksum validation on the backfilled data. Automated verification.": "Prüfsummenvalidierung der nachgeladenen Daten ausführen. Automatisierte Prüfung.",
+	"Notify Team": "Team benachrichtigen",
+	"Notify the data platform team about the incident and resolution. Manual step to ensure visibility.": "Das Data-Platform-Team über Incident und Lösung informieren. Manueller Schritt für Transparenz.",
+	"Production Release Checklist": "Checkliste für Produktions-Releases",
+	"Comprehensive checklist for production releases. Includes pre-deploy checks, approval, deployment, testing, and monitoring.": "Umfassende Checkliste für Produktions-Releases. Umfasst Prüfungen vor dem Deployment, Freigabe, Deployment, Tests und Monitoring.",
+	"Pre-deploy checks": "Prüfungen vor dem Deployment",
+	"Verify all tests pass, dependencies are available, and rollback plan is ready.": "Prüfen, dass alle Tests bestehen, Abhängigkeiten verfügbar sind und der Rollback-Plan bereitsteht.",
+	"Get approval": "Freigabe einholen",
+	"Obtain approval from release manager and stakeholders. Confirm maintenance window.": "Freigabe von Release-Manager und Stakeholdern einholen. Wartungsfenster bestätigen.",
+	"Deploy to production": "In Produktion ausrollen",
+	"Execute deployment to production environment. Monitor deployment progress.": "Deployment in die Produktionsumgebung ausführen. Fortschritt des Deployments überwachen.",
+	"Run smoke tests": "Smoke-Tests ausführen",
+	"Execute smoke tests to verify basic functionality. Check critical user flows.": "Smoke-Tests zur Prüfung der Grundfunktionen ausführen. Kritische Nutzerabläufe prüfen.",
+	"Run security scan": "Sicherheitsscan ausführen",
+	"Perform automated vulnerability scan on new deployment.": "Automatisierten Schwachstellenscan des neuen Deployments durchführen.",
+	"Monitor metrics": "Metriken überwachen",
+	"Monitor error rates, latency, and resource usage. Watch for anomalies.": "Fehlerraten, Latenz und Ressourcennutzung überwachen. Auf Anomalien achten.",
+	"Decide on rollback": "Über Rollback entscheiden",
+	"Assess if rollback is needed based on metrics and errors. Proceed or rollback.": "Anhand von Metriken und Fehlern bewerten, ob ein Rollback nötig ist. Fortfahren oder zurückrollen.",
+	"Announce release": "Release ankündigen",
+	"Post announcement about successful release. Update status page.": "Ankündigung des erfolgreichen Releases veröffentlichen. Statusseite aktualisieren.",
+	"Close release ticket": "Release-Ticket schließen",
+	"Close release ticket and document any issues. Schedule postmortem if needed.": "Release-Ticket schließen und etwaige Probleme dokumentieren. Bei Bedarf Postmortem ansetzen.",
+	"Canary Deployment Checklist": "Checkliste für Canary-Deployments",
+	"Checklist for canary deployments with gradual rollout. Includes staged rollout and monitoring at each stage.": "Checkliste für Canary-Deployments mit schrittweisem Rollout. Umfasst stufenweisen Rollout und Monitoring in jeder Stufe.",
+	"Deploy to 5% of traffic": "Für 5 % des Traffics ausrollen",
+	"Deploy new version to 5% of production traffic. Use traffic splitting.": "Neue Version für 5 % des Produktions-Traffics ausrollen. Traffic-Splitting verwenden.",
+	"Monitor 5% canary": "5-%-Canary überwachen",
+	"Monitor error rates and latency for canary traffic. Watch for 10 minutes.": "Fehlerraten und Latenz des Canary-Traffics überwachen. 10 Minuten beobachten.",
+	"Deploy to 25% of traffic":                      "Für 25 % des Traffics ausrollen",
+	"Increase traffic to 25% if canary is healthy.": "Traffic auf 25 % erhöhen, wenn der Canary gesund ist.",
+	"Monitor 25% canary":                            "25-%-Canary überwachen",
+	"Monitor error rates and latency for 25% traffic. Watch for 10 minutes.": "Fehlerraten und Latenz bei 25 % Traffic überwachen. 10 Minuten beobachten.",
+	"Deploy to 100% of traffic":                                      "Für 100 % des Traffics ausrollen",
+	"Roll out to all traffic if 25% canary is healthy.":              "Auf den gesamten Traffic ausrollen, wenn der 25-%-Canary gesund ist.",
+	"Verify full rollout":                                            "Vollständigen Rollout prüfen",
+	"Confirm all traffic is on new version. Monitor for 30 minutes.": "Bestätigen, dass der gesamte Traffic auf der neuen Version läuft. 30 Minuten überwachen.",
+	"Rollback Checklist":                                             "Rollback-Checkliste",
+	"Procedure for rolling back a failed deployment. Includes assessment, revert, verification, and communication.": "Vorgehen zum Zurückrollen eines fehlgeschlagenen Deployments. Umfasst Bewertung, Zurücksetzen, Prüfung und Kommunikation.",
+	"Assess failure": "Fehler bewerten",
+	"Analyze error logs and metrics to understand failure. Determine if rollback is necessary.": "Fehlerlogs und Metriken analysieren, um den Fehler zu verstehen. Feststellen, ob ein Rollback nötig ist.",
+	"Revert to previous version": "Auf vorherige Version zurücksetzen",
+	"Execute rollback to previous stable version. Monitor deployment progress.": "Rollback auf die vorherige stabile Version ausführen. Fortschritt des Deployments überwachen.",
+	"Verify rollback": "Rollback prüfen",
+	"Confirm previous version is running and healthy. Run smoke tests.": "Bestätigen, dass die vorherige Version läuft und gesund ist. Smoke-Tests ausführen.",
+	"Communicate rollback": "Rollback kommunizieren",
+	"Notify stakeholders about rollback. Update status page.":                              "Stakeholder über den Rollback informieren. Statusseite aktualisieren.",
+	"Schedule postmortem to review root cause. Assign action items to prevent recurrence.": "Postmortem zur Ursachenanalyse ansetzen. Aufgaben zur Vermeidung einer Wiederholung zuweisen.",
+	"Database Failover": "Datenbank-Failover",
+	"Procedure for failing over to standby database. Trigger this runbook when 'Database primary failover initiated' alert fires.": "Vorgehen für den Failover auf die Standby-Datenbank. Dieses Runbook auslösen, wenn der Alarm 'Database primary failover initiated' feuert.",
+	"Pre-failover checks": "Prüfungen vor dem Failover",
+	"Verify standby database is healthy and in sync. Check replication lag and disk space.": "Prüfen, dass die Standby-Datenbank gesund und synchron ist. Replikationsverzögerung und Speicherplatz prüfen.",
+	"Notify stakeholders": "Stakeholder benachrichtigen",
+	"Send notification to all service owners about planned failover. Provide maintenance window details.": "Alle Service-Verantwortlichen über den geplanten Failover informieren. Details zum Wartungsfenster angeben.",
+	"Pause all cron jobs and worker queues to prevent data inconsistency.":                                "Alle Cronjobs und Worker-Warteschlangen pausieren, um Dateninkonsistenzen zu vermeiden.",
+	"Prepare Standby": "Standby vorbereiten",
+	"Ensure standby is caught up and ready for promotion.": "Sicherstellen, dass der Standby aufgeholt hat und zur Hochstufung bereit ist.",
+	"Execute failover": "Failover ausführen",
+	"Promote standby to primary and update connection strings. Run: `pg_ctl promote -D /var/lib/postgresql/data`": "Standby zum Primary hochstufen und Verbindungszeichenfolgen aktualisieren. Ausführen: `pg_ctl promote -D /var/lib/postgresql/data`",
+	"Validate new primary": "Neuen Primary validieren",
+	"Confirm new primary is accepting connections and serving queries. Run smoke tests.": "Bestätigen, dass der neue Primary Verbindungen annimmt und Abfragen bedient. Smoke-Tests ausführen.",
+	"Update DNS records": "DNS-Einträge aktualisieren",
+	"Update DNS to point to new primary database. Wait for TTL to expire.": "DNS auf die neue primäre Datenbank umstellen. Ablauf der TTL abwarten.",
+	"Certificate Rotation": "Zertifikatsrotation",
+	"Procedure for rotating SSL/TLS certificates. Recommended response for 'Certificate expiration warning' alerts.": "Vorgehen zur Rotation von SSL/TLS-Zertifikaten. Empfohlene Reaktion auf Alarme 'Certificate expiration warning'.",
+	"Backup current certificates": "Aktuelle Zertifikate sichern",
+	"Create backup of current certificates and keys. Store in secure location.": "Backup der aktuellen Zertifikate und Schlüssel erstellen. An einem sicheren Ort ablegen.",
+	"Generate new certificates": "Neue Zertifikate erzeugen",
+	"Generate new certificates from CA. Run: `certbot renew --force-renewal`": "Neue Zertifikate bei der CA erzeugen. Ausführen: `certbot renew --force-renewal`",
+	"Deploy to LBs": "Auf LBs ausrollen",
+	"Update SSL certificates on HAProxy/ALB endpoints.":         "SSL-Zertifikate auf den HAProxy-/ALB-Endpunkten aktualisieren.",
+	"Deploy to App Servers":                                     "Auf App-Server ausrollen",
+	"Distribute certificates to backend application instances.": "Zertifikate an die Backend-Anwendungsinstanzen verteilen.",
+	"Verify certificate validity":                               "Gültigkeit der Zertifikate prüfen",
+	"Verify new certificates are valid and properly installed. Check expiration dates and certificate chain.": "Prüfen, dass die neuen Zertifikate gültig und korrekt installiert sind. Ablaufdaten und Zertifikatskette prüfen.",
+	"Cache Flush and Warmup": "Cache leeren und vorwärmen",
+	"Procedure for flushing and warming up cache. Use to resolve 'Cache hit rate degradation' alerts or 'Redis cache hit rate dropped' warnings.": "Vorgehen zum Leeren und Vorwärmen des Caches. Zur Behebung von Alarmen 'Cache hit rate degradation' oder Warnungen 'Redis cache hit rate dropped' verwenden.",
+	"Drain cache connections": "Cache-Verbindungen abbauen",
+	"Gracefully drain existing cache connections. Stop accepting new connections.": "Bestehende Cache-Verbindungen kontrolliert abbauen. Keine neuen Verbindungen mehr annehmen.",
+	"Flush cache data": "Cache-Daten leeren",
+	"Clear all data from cache. Run: `redis-cli FLUSHALL`": "Alle Daten aus dem Cache löschen. Ausführen: `redis-cli FLUSHALL`",
+	"Warmup User Data":                          "Nutzerdaten vorwärmen",
+	"Pre-load active user profiles into cache.": "Aktive Nutzerprofile vorab in den Cache laden.",
+	"Warmup Product Catalog":                    "Produktkatalog vorwärmen",
+	"Pre-load high-traffic product listings.":   "Stark frequentierte Produktlisten vorab laden.",
+	"Verify cache health":                       "Cache-Zustand prüfen",
+	"Confirm cache is responding and hit rates are normal. Monitor for 15 minutes.": "Bestätigen, dass der Cache antwortet und die Trefferquoten normal sind. 15 Minuten überwachen.",
+	"Pod Restart Analysis": "Analyse von Pod-Neustarts",
+	"Diagnostic workflow for unstable containers. Response for 'Container restart loop on pod' alerts or OOMKilled events.": "Diagnose-Workflow für instabile Container. Reaktion auf Alarme 'Container restart loop on pod' oder OOMKilled-Ereignisse.",
+	"Fetch pod logs": "Pod-Logs abrufen",
+	"Retrieve previous container logs to identify crash reason. Look for panic stack traces.": "Logs des vorherigen Containers abrufen, um die Absturzursache zu ermitteln. Nach Panic-Stacktraces suchen.",
+	"Check resource limits": "Ressourcenlimits prüfen",
+	"Compare memory usage vs configured limits. Identify memory leaks or under-provisioning.": "Speichernutzung mit den konfigurierten Limits vergleichen. Speicherlecks oder Unterprovisionierung erkennen.",
+	"Redeploy pod": "Pod neu ausrollen",
+	"Delete pod to force reschedule on fresh node. Validate startup health.": "Pod löschen, um eine Neuplanung auf einem frischen Knoten zu erzwingen. Zustand beim Start prüfen.",
+	"API Rate Limit Mitigation": "Behebung von API-Ratenlimits",
+	"Mitigation for 'API rate limit exhaustion' alerts. Managing quotas for high-volume consumers.": "Gegenmaßnahmen bei Alarmen 'API rate limit exhaustion'. Verwaltung der Kontingente für Großverbraucher.",
+	"Identify top consumers": "Größte Verbraucher identifizieren",
+	"Analyze request logs to find IP/API keys exceeding quota. Run: `SELECT client_id, count(*) FROM api_logs GROUP BY client_id`": "Anfragelogs analysieren, um IPs/API-Schlüssel über dem Kontingent zu finden. Ausführen: `SELECT client_id, count(*) FROM api_logs GROUP BY client_id`",
+	"Increase temporary quota":                                                   "Kontingent vorübergehend erhöhen",
+	"Approve temporary limit increase for legitimate traffic spike.":             "Vorübergehende Limiterhöhung für eine legitime Traffic-Spitze freigeben.",
+	"Ban abusive client":                                                         "Missbräuchlichen Client sperren",
+	"Block API key or IP address if traffic is malicious/bot.":                   "API-Schlüssel oder IP-Adresse sperren, wenn der Traffic bösartig ist oder von Bots stammt.",
+	"Verify traffic normalized":                                                  "Normalisierung des Traffics prüfen",
+	"Confirm error rates dropped and latency recovered. Monitor for 10 minutes.": "Bestätigen, dass die Fehlerraten gesunken sind und sich die Latenz erholt hat. 10 Minuten überwachen.",
+	"Catalog Sync Repair":                                                        "Reparatur der Katalogsynchronisierung",
+	"Resolution for 'Catalog inventory sync drift' alerts. Fixes inconsistencies between ERP and Store catalog.": "Lösung für Alarme 'Catalog inventory sync drift'. Behebt Inkonsistenzen zwischen ERP und Shop-Katalog.",
+	"Check drift metrics": "Drift-Metriken prüfen",
+	"Compare item counts and timestamps between ERP and Catalog DB.": "Artikelanzahlen und Zeitstempel zwischen ERP und Katalog-DB vergleichen.",
+	"Trigger full sync": "Vollständige Synchronisierung auslösen",
+	"Force a full reconciliation job to overwrite stale data. Action: `POST /admin/sync/full`": "Vollständigen Abgleichsjob erzwingen, um veraltete Daten zu überschreiben. Aktion: `POST /admin/sync/full`",
+	"Verify record counts":                               "Datensatzanzahlen prüfen",
+	"Confirm drift metric is zero after job completion.": "Bestätigen, dass die Drift-Metrik nach Abschluss des Jobs null ist.",
+	"Payment Latency Mitigation":                         "Behebung von Zahlungslatenz",
+	"Diagnostic and remediation steps for payment service performance issues. Used for high P99 latency alerts.": "Diagnose- und Behebungsschritte bei Leistungsproblemen des Zahlungsdienstes. Für Alarme wegen hoher P99-Latenz.",
+	"Check payment gateway metrics": "Metriken des Payment-Gateways prüfen",
+	"Review latency and error rates for third-party payment gateways. Identify if issue is external.": "Latenz und Fehlerraten der externen Payment-Gateways prüfen. Feststellen, ob das Problem extern liegt.",
+	"Analyze upstream dependencies": "Upstream-Abhängigkeiten analysieren",
+	"Check latency metrics for internal services like Fraud and Inventory. Identify downstream bottlenecks.": "Latenzmetriken interner Dienste wie Fraud und Inventory prüfen. Nachgelagerte Engpässe identifizieren.",
+	"Increase gateway timeout": "Gateway-Timeout erhöhen",
+	"Update payment gateway timeout configuration to handle transient spikes. Deploy config change.": "Timeout-Konfiguration des Payment-Gateways für kurzzeitige Spitzen anpassen. Konfigurationsänderung ausrollen.",
+	"Scale payment service": "Zahlungsdienst skalieren",
+	"Increase horizontal pod autoscaling targets for payment-service. Ensure enough capacity.": "Ziele des Horizontal Pod Autoscalings für payment-service erhöhen. Ausreichende Kapazität sicherstellen.",
+	"Verify latency recovery": "Erholung der Latenz prüfen",
+	"Monitor P99 latency metrics for 15 minutes. Confirm stability.": "P99-Latenzmetriken 15 Minuten überwachen. Stabilität bestätigen.",
+}
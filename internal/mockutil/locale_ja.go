@@ -0,0 +1,496 @@
+package mockutil
+
+// catalogJA holds the Japanese seed text, keyed by the English original.
+var catalogJA = map[string]string{
+	// Incidents.
+	"Checkout latency impacting EU customers":                                                       "チェックアウトの遅延がEUの顧客に影響",
+	"High checkout latency causing timeouts for a slice of EU traffic":                              "チェックアウトの高レイテンシにより、EUトラフィックの一部でタイムアウトが発生",
+	"Search results intermittently empty":                                                           "検索結果が断続的に空になる",
+	"Search API intermittently returns empty result sets":                                           "検索APIが断続的に空の結果セットを返す",
+	"Payments webhook timeouts from Stripe":                                                         "Stripeからの決済Webhookがタイムアウト",
+	"Stripe webhook requests timing out from payments provider":                                     "決済プロバイダーからのStripe Webhookリクエストがタイムアウト",
+	"Notification fanout lagging for promos":                                                        "プロモーション通知のファンアウトが遅延",
+	"Notification fanout workers are lagging promo campaigns":                                       "通知ファンアウトのワーカーがプロモーションキャンペーンに追いついていない",
+	"Auth latency spikes for mobile logins":                                                         "モバイルログインで認証レイテンシが急上昇",
+	"Mobile login auth latency spiking for the identity service":                                    "IDサービスでモバイルログインの認証レイテンシが急上昇",
+	"Warehouse batch stuck on partition 7":                                                          "ウェアハウスのバッチがパーティション7で停止",
+	"Warehouse batch job stuck processing partition 7":                                              "ウェアハウスのバッチジョブがパーティション7の処理で停止",
+	"Recommendation quality drop after rollout":                                                     "ロールアウト後にレコメンドの品質が低下",
+	"New recommendation rollout degraded quality metrics":                                           "新しいレコメンドのロールアウトで品質指標が悪化",
+	"Analytics pipeline missing events from APAC":                                                   "分析パイプラインでAPACのイベントが欠落",
+	"Analytics pipeline missing events originating from APAC region":                                "分析パイプラインでAPACリージョン発のイベントが欠落",
+	"Order placement errors for prepaid cards":                                                      "プリペイドカードでの注文時にエラー",
+	"Orders paid with prepaid cards fail due to processor errors":                                   "プリペイドカード払いの注文が決済処理エラーで失敗",
+	"Catalog indexer backlog after schema change":                                                   "スキーマ変更後にカタログインデクサーのバックログが増加",
+	"Catalog indexing backlog building after schema migration":                                      "スキーマ移行後にカタログのインデックス処理でバックログが蓄積",
+	"Shipping tracker returns stale data":                                                           "配送トラッカーが古いデータを返す",
+	"Shipping tracker caches are returning stale tracking payloads":                                 "配送トラッカーのキャッシュが古い追跡データを返している",
+	"Realtime updates disconnect in Firefox":                                                        "Firefoxでリアルタイム更新が切断される",
+	"Realtime websocket connections drop for Firefox clients":                                       "FirefoxクライアントのリアルタイムWebSocket接続が切断される",
+	"Analytics Correlation Failure":                                                                 "分析の相関処理の障害",
+	"Correlation services are failing to process events, leading to data gaps.":                     "相関サービスがイベントを処理できず、データの欠損が発生しています。",
+	"Payment Latency Spikes":                                                                        "決済レイテンシの急上昇",
+	"Payment service is experiencing intermittent latency spikes, impacting checkout success rate.": "決済サービスで断続的にレイテンシが急上昇し、チェックアウトの成功率に影響しています。",
+	"SLO Budget Exhaustion - Checkout Service":                                                      "SLOバジェットの枯渇 - チェックアウトサービス",
+	"Error budget for checkout service completely exhausted, 99.9% SLO breached":                    "チェックアウトサービスのエラーバジェットを使い切り、99.9%のSLOに違反",
+	"Cascading Failure - Database Connection Pool Exhaustion":                                       "連鎖障害 - データベース接続プールの枯渇",
+	"Database connection pool exhausted causing cascading failures across dependent services":       "データベース接続プールが枯渇し、依存サービス全体で連鎖障害が発生",
+	"Deployment Rollback - Payment Service v2.8.3":                                                  "デプロイのロールバック - 決済サービス v2.8.3",
+	"Automated rollback triggered due to elevated error rates after deployment":                     "デプロイ後のエラー率上昇により自動ロールバックを実行",
+	"External Dependency Failure - Stripe API Degradation":                                          "外部依存の障害 - Stripe APIの性能低下",
+	"Stripe payment API experiencing elevated latency and rate limiting, impacting checkout flow":   "Stripe決済APIでレイテンシの上昇とレート制限が発生し、チェックアウトフローに影響",
+	"Autoscaling Lag - Traffic Spike Exceeds Capacity":                                              "オートスケーリングの遅れ - トラフィック急増が容量を超過",
+	"Sudden traffic spike detected, autoscaling in progress but lagging behind demand":              "急なトラフィック増加を検知。オートスケーリング中だが需要に追いついていない",
+	"Circuit Breaker Cascade - Recommendation Service Failure":                                      "サーキットブレーカーの連鎖 - レコメンドサービスの障害",
+	"Circuit breakers tripping across multiple services due to recommendation service degradation":  "レコメンドサービスの性能低下により、複数サービスでサーキットブレーカーが作動",
+
+	// Incident history.
+	"Checkout error budget burn after traffic surge":                                            "トラフィック急増後にチェックアウトのエラーバジェットを消費",
+	"Checkout 5xx burned 40% of the monthly error budget in two hours during a flash sale":      "フラッシュセール中、チェックアウトの5xxが2時間で月間エラーバジェットの40%を消費",
+	"Checkout latency SLO breach in EU":                                                         "EUでチェックアウトのレイテンシSLO違反",
+	"Checkout p95 latency above 1.5s for EU customers; SLO burn rate 14x":                       "EUの顧客向けチェックアウトのp95レイテンシが1.5秒超、SLOバーンレート14倍",
+	"Checkout timeouts during marketing campaign":                                               "マーケティングキャンペーン中のチェックアウトのタイムアウト",
+	"Checkout requests timing out under campaign load; error budget nearly exhausted":           "キャンペーン負荷でチェックアウトのリクエストがタイムアウトし、エラーバジェットがほぼ枯渇",
+	"Database connection pool exhausted":                                                        "データベースのコネクションプールが枯渇",
+	"Order service connection pool exhausted, cascading failures into checkout and payments":    "注文サービスのコネクションプールが枯渇し、チェックアウトと決済に障害が連鎖",
+	"Cascading failures from slow database replica":                                             "低速なデータベースレプリカによる連鎖障害",
+	"Read replica lag caused request pile-ups that cascaded through catalog and search":         "リードレプリカの遅延でリクエストが滞留し、カタログと検索に連鎖",
+	"Payments errors after deployment":                                                          "デプロイ後の決済エラー",
+	"Payment service 5xx jumped to 8% right after deploying v2.7.0; rolled back":                "v2.7.0のデプロイ直後に決済サービスの5xxが8%に上昇し、ロールバック",
+	"Search relevance regression after release":                                                 "リリース後の検索関連性の低下",
+	"Search release 4.2 returned empty results for multi-word queries; rolled back":             "検索リリース4.2で複数語のクエリが空の結果を返したため、ロールバック",
+	"Checkout deploy rolled back after latency spike":                                           "レイテンシ急増によりチェックアウトのデプロイをロールバック",
+	"Checkout v3.1.0 doubled p99 latency; automatic rollback triggered":                         "チェックアウトv3.1.0でp99レイテンシが2倍になり、自動ロールバックが発動",
+	"Stripe API rate limiting payments":                                                         "Stripe APIのレート制限で決済に影響",
+	"Stripe returned 429s for 20 minutes; checkout payments failed intermittently":              "Stripeが20分間429を返し、チェックアウトの決済が断続的に失敗",
+	"Shipping carrier API outage":                                                               "配送業者APIの障害",
+	"Fast-ship carrier API returned 503 for label creation; shipments queued":                   "速達配送業者のAPIがラベル作成で503を返し、出荷がキューに滞留",
+	"Search latency during traffic spike":                                                       "トラフィック急増時の検索レイテンシ",
+	"Search p95 latency tripled while autoscaling lagged behind a traffic spike":                "オートスケーリングがトラフィック急増に追いつかず、検索のp95レイテンシが3倍に",
+	"Web frontend slow during product launch":                                                   "製品ローンチ中のWebフロントエンドの遅延",
+	"Web frontend pods saturated during a product launch before autoscaling caught up":          "製品ローンチ中、オートスケーリングが追いつく前にWebフロントエンドのPodが飽和",
+	"Recommendation timeouts tripping circuit breakers":                                         "レコメンドのタイムアウトでサーキットブレーカーが作動",
+	"Recommendation inference timeouts opened circuit breakers in web and checkout":             "レコメンドの推論タイムアウトにより、Webとチェックアウトのサーキットブレーカーが開放",
+	"Circuit breakers open across checkout dependencies":                                        "チェックアウトの依存先全体でサーキットブレーカーが開放",
+	"Breakers opened on checkout calls to recommendations and inventory after a GC pause storm": "GCの一時停止が多発した後、チェックアウトからレコメンドと在庫への呼び出しでブレーカーが開放",
+	"Analytics pipeline dropped events":                                                         "分析パイプラインでイベントが欠落",
+	"Analytics ETL dropped APAC events for six hours":                                           "分析ETLがAPACのイベントを6時間にわたり破棄",
+	"Identity provider token refresh failures":                                                  "IDプロバイダーのトークン更新失敗",
+	"Token refresh requests failed for 3% of mobile sessions":                                   "モバイルセッションの3%でトークン更新リクエストが失敗",
+	"Checkout 5xx spike":                                              "チェックアウトの5xx急増",
+	"Checkout 5xx rate above 2% for several minutes":                  "チェックアウトの5xx率が数分間2%を超過",
+	"Payments provider timeouts":                                      "決済プロバイダーのタイムアウト",
+	"Card authorisations timing out against the payment provider":     "決済プロバイダーに対するカード承認がタイムアウト",
+	"Search index lag":                                                "検索インデックスの遅延",
+	"Search index updates delayed; new products missing from results": "検索インデックスの更新が遅延し、新商品が検索結果に表示されない",
+	"Order database slow queries":                                     "注文データベースの低速クエリ",
+	"Order write latency elevated from slow queries on the primary":   "プライマリ上の低速クエリにより注文の書き込みレイテンシが上昇",
+	"Catalog deploy rolled back":                                      "カタログのデプロイをロールバック",
+	"Catalog release raised error rates and was rolled back":          "カタログのリリースでエラー率が上昇し、ロールバック",
+
+	// Alerts.
+	"Checkout latency SLO breach": "チェックアウトのレイテンシSLO違反",
+	"Checkout p95 latency exceeded 1.2s for the past 15 minutes affecting 45% of transactions":         "チェックアウトのp95レイテンシが過去15分間1.2秒を超え、トランザクションの45%に影響",
+	"Database primary failover initiated":                                                              "プライマリデータベースのフェイルオーバーを開始",
+	"Primary database node unresponsive, automatic failover to replica in progress":                    "プライマリデータベースノードが応答せず、レプリカへの自動フェイルオーバーを実行中",
+	"Payment processing complete outage":                                                               "決済処理の全面停止",
+	"Payment gateway returning 503 errors, zero successful transactions in last 10 minutes":            "決済ゲートウェイが503エラーを返し、直近10分間で成功したトランザクションはゼロ",
+	"Search 5xx spike on cluster ares":                                                                 "クラスターaresで検索の5xxが急増",
+	"Search error budget is being consumed due to node instability, 4.2% error rate":                   "ノードの不安定により検索のエラーバジェットを消費中、エラー率4.2%",
+	"Catalog inventory sync drift":                                                                     "カタログ在庫同期のずれ",
+	"Inventory deltas from ERP lagging beyond 20 minutes in us-east, 2,400 SKUs out of sync":           "us-eastでERPからの在庫差分が20分以上遅延し、2,400件のSKUが同期されていない",
+	"Realtime websocket disconnects":                                                                   "リアルタイムWebSocketの切断",
+	"Firefox clients disconnect after ~45s with close code 1006, affecting 27% of connections":         "Firefoxクライアントが約45秒後にクローズコード1006で切断し、接続の27%に影響",
+	"Container restart loop on pod checkout-7d4f":                                                      "Pod checkout-7d4fでコンテナが再起動ループ",
+	"Pod restarted 8 times in 15 minutes due to OOMKilled events":                                      "OOMKilledイベントにより、Podが15分間に8回再起動",
+	"API rate limit exhaustion for mobile clients":                                                     "モバイルクライアントのAPIレート制限の枯渇",
+	"Mobile API rate limits hit 95% capacity, throttling beginning":                                    "モバイルAPIのレート制限が容量の95%に達し、スロットリングが始まっている",
+	"Certificate expiration warning":                                                                   "証明書の有効期限警告",
+	"TLS certificate for api.demo.com expires in 14 days":                                              "api.demo.comのTLS証明書が14日後に期限切れ",
+	"DNS resolution failures spiking":                                                                  "DNS名前解決の失敗が急増",
+	"DNS lookup failures increased to 2.1% of requests in eu-west-1":                                   "eu-west-1でDNSルックアップの失敗がリクエストの2.1%に増加",
+	"Notification queue depth high":                                                                    "通知キューの滞留が多い",
+	"Promo notification fanout queue depth above 40k messages affecting 15,000 users":                  "プロモーション通知のファンアウトキューが4万件を超え、15,000人のユーザーに影響",
+	"Payments webhook retries exhausted":                                                               "決済Webhookのリトライ上限に到達",
+	"Stripe webhook deliveries repeated 5 times without success for 18 events":                         "18件のイベントでStripe Webhookの配信を5回再試行したが成功せず",
+	"Web vitals CLS regression on 8.2":                                                                 "8.2でWeb VitalsのCLSが悪化",
+	"Core web vitals degrade for EU mobile traffic on release 8.2":                                     "リリース8.2でEUのモバイルトラフィックのCore Web Vitalsが悪化",
+	"Analytics pipeline APAC gap":                                                                      "分析パイプラインのAPACデータ欠損",
+	"APAC tracking stream produced zero events for 12 minutes":                                         "APACのトラッキングストリームが12分間イベントを出力していない",
+	"Data warehouse compaction backlog":                                                                "データウェアハウスのコンパクションのバックログ",
+	"Compact job queue length exceeding 3x expected baseline":                                          "コンパクションジョブのキュー長が想定ベースラインの3倍を超過",
+	"Cache hit rate degradation":                                                                       "キャッシュヒット率の低下",
+	"Redis cache hit rate dropped from 94% to 67% over last hour":                                      "直近1時間でRedisキャッシュのヒット率が94%から67%に低下",
+	"Disk space warning on log aggregator":                                                             "ログアグリゲーターのディスク容量警告",
+	"Log aggregator disk usage at 82% capacity in us-west-2":                                           "us-west-2のログアグリゲーターのディスク使用率が82%",
+	"Load balancer health check failures":                                                              "ロードバランサーのヘルスチェック失敗",
+	"3 of 12 backend instances failing health checks in eu-central-1":                                  "eu-central-1でバックエンドインスタンス12台中3台がヘルスチェックに失敗",
+	"Circuit breaker tripped for recommendation service":                                               "レコメンドサービスのサーキットブレーカーが作動",
+	"Circuit breaker open after 15 consecutive failures to recommendation API":                         "レコメンドAPIへの15回連続の失敗でサーキットブレーカーがオープン",
+	"Unusual authentication pattern detected":                                                          "異常な認証パターンを検知",
+	"Login attempts from 47 different countries in 10 minutes for user segment":                        "あるユーザーセグメントで10分間に47か国からログインを試行",
+	"Background job processing lag":                                                                    "バックグラウンドジョブの処理遅延",
+	"Email delivery job queue lag increased to 45 minutes":                                             "メール配信ジョブのキュー遅延が45分に増加",
+	"Database connection pool saturation":                                                              "データベース接続プールの飽和",
+	"Connection pool utilization at 92% for catalog database":                                          "カタログデータベースの接続プール使用率が92%",
+	"API key usage anomaly":                                                                            "APIキー使用量の異常",
+	"API key abc123 exceeded normal usage by 340% in last hour":                                        "APIキーabc123の使用量が直近1時間で通常より340%超過",
+	"Warehouse ETL runtime variance":                                                                   "ウェアハウスETLの実行時間のばらつき",
+	"ETL job runtime variance exceeded 3x baseline but within acceptable limits":                       "ETLジョブの実行時間のばらつきがベースラインの3倍を超えたが、許容範囲内",
+	"Support chatbot deflection drop":                                                                  "サポートチャットボットの自己解決率が低下",
+	"Chatbot deflection under 30% causing live agent queue growth":                                     "チャットボットの自己解決率が30%を下回り、有人対応のキューが増加",
+	"Feature flag rollout progressing":                                                                 "フィーチャーフラグのロールアウト進行中",
+	"New checkout flow feature flag at 25% rollout, monitoring for issues":                             "新しいチェックアウトフローのフィーチャーフラグを25%までロールアウト、問題を監視中",
+	"Scheduled maintenance window approaching":                                                         "計画メンテナンスの時間が近づいている",
+	"Database maintenance window scheduled in 4 hours for replica upgrades":                            "レプリカのアップグレードのため、4時間後にデータベースのメンテナンスを予定",
+	"Conversion rate trending below target":                                                            "コンバージョン率が目標を下回る傾向",
+	"Weekly conversion rate at 2.4%, below 2.7% target but within normal variance":                     "週間コンバージョン率は2.4%で目標の2.7%を下回るが、通常の変動の範囲内",
+	"Deployment completed successfully":                                                                "デプロイが正常に完了",
+	"Catalog service v3.14.2 deployed to production, monitoring for issues":                            "カタログサービスv3.14.2を本番環境にデプロイ、問題を監視中",
+	"Kafka consumer lag increasing":                                                                    "Kafkaコンシューマーラグが増加",
+	"Analytics consumer group lag exceeded 500k messages on user-events topic":                         "user-eventsトピックで分析コンシューマーグループのラグが50万件を超過",
+	"Message queue depth critical":                                                                     "メッセージキューの滞留が危険水準",
+	"Notification delivery queue depth at 85k messages, approaching capacity":                          "通知配信キューの滞留が8万5千件に達し、容量の上限に近づいている",
+	"Kafka broker disk usage high":                                                                     "Kafkaブローカーのディスク使用率が高い",
+	"Broker kafka-3 disk usage at 88% in us-west-2":                                                    "us-west-2のブローカーkafka-3のディスク使用率が88%",
+	"Message producer throttling active":                                                               "メッセージプロデューサーのスロットリングが発生中",
+	"Event producer experiencing throttling due to quota limits":                                       "クォータ制限によりイベントプロデューサーがスロットリングされている",
+	"Node NotReady: ip-10-12-4-37.ec2.internal":                                                        "ノードがNotReady: ip-10-12-4-37.ec2.internal",
+	"Kubelet on ip-10-12-4-37 stopped posting status; 14 pods are being evicted":                       "ip-10-12-4-37のkubeletがステータスの送信を停止し、14個のPodが退避中",
+	"Node disk pressure: ip-10-12-7-118.ec2.internal":                                                  "ノードのディスク逼迫: ip-10-12-7-118.ec2.internal",
+	"Root volume on ip-10-12-7-118 is 91% full; kubelet image garbage collection is failing":           "ip-10-12-7-118のルートボリュームが91%使用済みで、kubeletのイメージガベージコレクションが失敗している",
+	"Cluster etcd commit latency high: prod-euw1":                                                      "クラスターのetcdコミットレイテンシが高い: prod-euw1",
+	"etcd p99 backend commit latency is 310ms on prod-euw1; API server writes are slowing down":        "prod-euw1でetcdのp99バックエンドコミットレイテンシが310msとなり、APIサーバーの書き込みが遅くなっている",
+	"Load balancer unhealthy targets: public-web-alb":                                                  "ロードバランサーに異常なターゲット: public-web-alb",
+	"2 of 6 targets behind public-web-alb are failing health checks":                                   "public-web-alb配下のターゲット6台中2台がヘルスチェックに失敗",
+	"Interface down on core switch: core-sw-use1-a":                                                    "コアスイッチのインターフェースがダウン: core-sw-use1-a",
+	"SNMP linkDown trap: TenGigabitEthernet1/0/24 on core-sw-use1-a went down (uplink to rack B7)":     "SNMP linkDownトラップ: core-sw-use1-aのTenGigabitEthernet1/0/24がダウン（ラックB7へのアップリンク）",
+	"BGP peer session flapped: edge-rtr-euw1-b":                                                        "BGPピアセッションがフラップ: edge-rtr-euw1-b",
+	"SNMP bgpBackwardTransition trap from edge-rtr-euw1-b; peer 169.254.12.1 re-established after 40s": "edge-rtr-euw1-bからSNMP bgpBackwardTransitionトラップ。ピア169.254.12.1は40秒後に再確立",
+	"SLO budget exhaustion - Checkout service":                                                         "SLOバジェットの枯渇 - チェックアウトサービス",
+	"Error budget for checkout service exhausted, 99.9% SLO breached for 30 minutes":                   "チェックアウトサービスのエラーバジェットが枯渇し、99.9%のSLOに30分間違反",
+	"Cascading failure - Database connection pool exhaustion":                                          "連鎖障害 - データベース接続プールの枯渇",
+	"Database connection pool exhausted causing downstream service failures":                           "データベース接続プールが枯渇し、下流のサービスで障害が発生",
+	"Deployment rollback triggered - Payment service":                                                  "デプロイのロールバックを実行 - 決済サービス",
+	"Automated rollback initiated due to elevated error rates post-deployment":                         "デプロイ後のエラー率上昇により自動ロールバックを開始",
+	"External dependency failure - Stripe API degradation":                                             "外部依存の障害 - Stripe APIの性能低下",
+	"Stripe payment API experiencing elevated latency and timeouts":                                    "Stripe決済APIでレイテンシの上昇とタイムアウトが発生",
+	"Autoscaling lag - Traffic spike exceeds capacity":                                                 "オートスケーリングの遅れ - トラフィック急増が容量を超過",
+	"Traffic spike detected, autoscaling in progress but lagging behind demand":                        "トラフィック急増を検知。オートスケーリング中だが需要に追いついていない",
+	"Circuit breaker cascade - Recommendation service":                                                 "サーキットブレーカーの連鎖 - レコメンドサービス",
+	"Circuit breakers tripping across multiple services due to recommendation service failure":         "レコメンドサービスの障害により、複数サービスでサーキットブレーカーが作動",
+	"Analytics Correlation Lag":                                                                        "分析の相関処理の遅延",
+	"Correlation lag exceeds 30 minutes in svc-analytics.":                                             "svc-analyticsで相関処理の遅延が30分を超えています。",
+	"Payment Service Latency":                                                                          "決済サービスのレイテンシ",
+	"P99 latency for svc-payments exceeds 500ms.":                                                      "svc-paymentsのP99レイテンシが500msを超えています。",
+
+	// Orchestration plans and steps.
+	"Multi-Service Feature Rollout": "複数サービスにまたがる機能ロールアウト",
+	"Orchestrated rollout of a new feature spanning multiple microservices. Demonstrates diamond dependency pattern (1->2,3->4->7 ...).": "複数のマイクロサービスにまたがる新機能のオーケストレーションされたロールアウト。ダイヤモンド型の依存関係パターン（1->2,3->4->7 ...）を示します。",
+	"Prepare Shared Infrastructure": "共有インフラを準備",
+	"Provision necessary database schemas and shared message queues. Ensure capacity for new feature load.": "必要なデータベーススキーマと共有メッセージキューをプロビジョニングする。新機能の負荷に対応できる容量を確保する。",
+	"Deploy Authorization Service": "認可サービスをデプロイ",
+	"Deploy updated auth service with new scopes. Verify backward compatibility.": "新しいスコープを含む認証サービスの更新版をデプロイする。後方互換性を確認する。",
+	"Deploy Data Processing Service":                                              "データ処理サービスをデプロイ",
+	"Deploy new data processor consumers. Start consuming from new topics.":       "新しいデータ処理コンシューマーをデプロイする。新しいトピックからの消費を開始する。",
+	"Run Data Migration": "データ移行を実行",
+	"Execute backfill migration script for existing users. Validate data integrity.": "既存ユーザー向けのバックフィル移行スクリプトを実行する。データ整合性を検証する。",
+	"Update API Gateway Policies":                                   "APIゲートウェイのポリシーを更新",
+	"Update gateway routing and rate limits. Enable new endpoints.": "ゲートウェイのルーティングとレート制限を更新する。新しいエンドポイントを有効化する。",
+	"Refresh Client Tokens":                                         "クライアントトークンを更新",
+	"Force refresh of client tokens to pick up new permissions. Monitor auth error rates.": "新しい権限を反映するためクライアントトークンを強制的に更新する。認証エラー率を監視する。",
+	"Enable Global Access": "グローバルアクセスを有効化",
+	"Flip feature flag to enable access for all users. Send release notification.": "フィーチャーフラグを切り替えて全ユーザーにアクセスを開放する。リリース通知を送信する。",
+	"Global Configuration Update": "グローバル設定の更新",
+	"Concurrent update of configuration across all global regions. Demonstrates fan-out/fan-in pattern.": "すべてのグローバルリージョンで設定を同時に更新します。ファンアウト/ファンインパターンを示します。",
+	"Initiate Global Update": "グローバル更新を開始",
+	"Prepare configuration payload and version. Acquire global lock.": "設定ペイロードとバージョンを準備する。グローバルロックを取得する。",
+	"Update Region US-East": "リージョンUS-Eastを更新",
+	"Apply configuration to us-east-1 and us-east-2. Restart services if required.": "us-east-1とus-east-2に設定を適用する。必要に応じてサービスを再起動する。",
+	"Update Region US-West": "リージョンUS-Westを更新",
+	"Apply configuration to us-west-1 and us-west-2. Restart services if required.": "us-west-1とus-west-2に設定を適用する。必要に応じてサービスを再起動する。",
+	"Update Region EU-Central": "リージョンEU-Centralを更新",
+	"Apply configuration to eu-central-1. Restart services if required.":                                "eu-central-1に設定を適用する。必要に応じてサービスを再起動する。",
+	"Update Region AP-Southeast":                                                                        "リージョンAP-Southeastを更新",
+	"Apply configuration to ap-southeast-1. Restart services if required.":                              "ap-southeast-1に設定を適用する。必要に応じてサービスを再起動する。",
+	"Verify Global Consistency":                                                                         "グローバルな整合性を確認",
+	"Check all regions report the new configuration version. Release global lock.":                      "すべてのリージョンが新しい設定バージョンを報告していることを確認する。グローバルロックを解放する。",
+	"Legacy Monolith Upgrade":                                                                           "レガシーモノリスのアップグレード",
+	"Strict sequence of steps required to upgrade legacy monolith. Demonstrates long chain dependency.": "レガシーモノリスのアップグレードに必要な厳密な手順の順序。長い依存関係チェーンを示します。",
+	"Notify Maintenance Window":                                                                         "メンテナンス時間を通知",
+	"Send email to internal stakeholders.":                                                              "社内の関係者にメールを送信する。",
+	"Stop Background Jobs":                                                                              "バックグラウンドジョブを停止",
+	"Pause all cron jobs and workers.":                                                                  "すべてのcronジョブとワーカーを一時停止する。",
+	"Perform Full Backup":                                                                               "フルバックアップを実行",
+	"Take snapshot of database and file storage.":                                                       "データベースとファイルストレージのスナップショットを取得する。",
+	"Enable Maintenance Mode":                                                                           "メンテナンスモードを有効化",
+	"Redirect traffic to maintenance page.":                                                             "トラフィックをメンテナンスページにリダイレクトする。",
+	"Apply Database Patches":                                                                            "データベースパッチを適用",
+	"Run SQL scripts for schema updates.":                                                               "スキーマ更新用のSQLスクリプトを実行する。",
+	"Upgrade Application Binaries":                                                                      "アプリケーションバイナリをアップグレード",
+	"Replace executable on all nodes.":                                                                  "すべてのノードで実行ファイルを置き換える。",
+	"Cold Restart":                                                                                      "コールドリスタート",
+	"Start application process.":                                                                        "アプリケーションプロセスを起動する。",
+	"Verify Internal Health":                                                                            "内部ヘルスを確認",
+	"Check /health endpoint and logs.":                                                                  "/healthエンドポイントとログを確認する。",
+	"Disable Maintenance Mode":                                                                          "メンテナンスモードを無効化",
+	"Restore user traffic.":                                                                             "ユーザートラフィックを復旧する。",
+	"Resume Background Jobs":                                                                            "バックグラウンドジョブを再開",
+	"Unpause workers and verify processing.":                                                            "ワーカーの一時停止を解除し、処理を確認する。",
+	"Full Stack Deployment":                                                                             "フルスタックデプロイ",
+	"Parallel coordinated deployment of Mobile and Web stacks. Demonstrates parallel tracks converging.": "モバイルとWebのスタックを並行して協調デプロイします。並行トラックの合流を示します。",
+	"Mobile: Build iOS App":                        "モバイル: iOSアプリをビルド",
+	"Compile Swift code and sign archive.":         "Swiftコードをコンパイルしてアーカイブに署名する。",
+	"Mobile: Run UI Tests":                         "モバイル: UIテストを実行",
+	"Execute XCTest suite on simulators.":          "シミュレーターでXCTestスイートを実行する。",
+	"Mobile: Submit to App Store":                  "モバイル: App Storeに提出",
+	"Upload binary to TestFlight for review.":      "審査のためにバイナリをTestFlightにアップロードする。",
+	"Web: Build Frontend":                          "Web: フロントエンドをビルド",
+	"Run webpack build and optimize assets.":       "webpackビルドを実行してアセットを最適化する。",
+	"Web: Deploy to S3":                            "Web: S3にデプロイ",
+	"Upload static assets to hosting bucket.":      "静的アセットをホスティング用バケットにアップロードする。",
+	"Web: Invalidate CDN":                          "Web: CDNを無効化",
+	"Purge CloudFront cache.":                      "CloudFrontのキャッシュをパージする。",
+	"Release Announcement":                         "リリースの告知",
+	"Coordinate blog post and social media blast.": "ブログ記事とソーシャルメディアでの一斉告知を調整する。",
+	"Data Center Migration (Complex DAG)":          "データセンター移行（複雑なDAG）",
+	"Simulates a large-scale DC migration with deep branching. Start -> DB/Compute Branches -> DB splits to 3 tasks, Compute splits to 2 tasks -> Convergence.": "深い分岐を持つ大規模なDC移行をシミュレートします。開始 -> DB/コンピュートの分岐 -> DBは3タスク、コンピュートは2タスクに分岐 -> 合流。",
+	"Initialize Migration":                         "移行を初期化",
+	"Approve migration plan and notify users.":     "移行計画を承認し、ユーザーに通知する。",
+	"Prepare Database":                             "データベースを準備",
+	"Allocate new DB resources.":                   "新しいDBリソースを割り当てる。",
+	"Backup Primary DB":                            "プライマリDBをバックアップ",
+	"Take full snapshot.":                          "フルスナップショットを取得する。",
+	"Provision Storage":                            "ストレージをプロビジョニング",
+	"Setup high-performance and archival storage.": "高性能ストレージとアーカイブ用ストレージをセットアップする。",
+	"Configure Replication":                        "レプリケーションを構成",
+	"Setup async replication to new region.":       "新しいリージョンへの非同期レプリケーションをセットアップする。",
+	"Prepare Compute":                              "コンピュートを準備",
+	"Reserve instance capacity.":                   "インスタンス容量を予約する。",
+	"Deploy Control Plane":                         "コントロールプレーンをデプロイ",
+	"Bootstrap Kubernetes masters.":                "Kubernetesマスターをブートストラップする。",
+	"Deploy Worker Nodes":                          "ワーカーノードをデプロイ",
+	"Provision autoscaling node groups.":           "オートスケーリングのノードグループをプロビジョニングする。",
+	"Verify Infrastructure":                        "インフラを確認",
+	"Run integration tests on new environment.":    "新しい環境で統合テストを実行する。",
+	"Switch Traffic":                               "トラフィックを切り替え",
+	"Update global DNS to point to new DC.":        "グローバルDNSを新しいDCに向けるよう更新する。",
+	"Region Evacuation Protocol (Extreme DAG)":     "リージョン退避プロトコル（極端なDAG）",
+	"Full-scale region evacuation scenario. Features 3 parallel tracks (Data, Infra, Traffic), cross-track dependencies (Restore needs Backup+Infra), and multiple convergence points.": "リージョン全体の退避シナリオ。3つの並行トラック（データ、インフラ、トラフィック）、トラック間の依存関係（復元にはバックアップとインフラが必要）、複数の合流点を含みます。",
+	"Initialize Evacuation":                             "退避を開始",
+	"Declare incident and trigger evacuation protocol.": "インシデントを宣言し、退避プロトコルを発動する。",
+	"Block Global Writes":                               "グローバルな書き込みをブロック",
+	"Set applications to read-only mode.":               "アプリケーションを読み取り専用モードにする。",
+	"Backup DB Shard 1":                                 "DBシャード1をバックアップ",
+	"Trigger immediate snapshot for Shard 1.":           "シャード1の即時スナップショットを実行する。",
+	"Backup DB Shard 2":                                 "DBシャード2をバックアップ",
+	"Trigger immediate snapshot for Shard 2.":           "シャード2の即時スナップショットを実行する。",
+	"Snapshot Block Volumes":                            "ブロックボリュームのスナップショット",
+	"Snapshot EBS volumes for stateful sets.":           "StatefulSetのEBSボリュームのスナップショットを取得する。",
+	"Drain Regional Traffic":                            "リージョンのトラフィックを退避",
+	"Lower weight in global load balancer.":             "グローバルロードバランサーでの重みを下げる。",
+	"Update CDN Origins":                                "CDNオリジンを更新",
+	"Point CDN to fallback region.":                     "CDNをフォールバックリージョンに向ける。",
+	"Provision Disaster Recovery VPC":                   "災害復旧用VPCをプロビジョニング",
+	"Terraform apply new VPC.":                          "Terraformで新しいVPCを適用する。",
+	"Provision New RDS Instances":                       "新しいRDSインスタンスをプロビジョニング",
+	"Create fresh DB instances in DR region.":           "DRリージョンに新しいDBインスタンスを作成する。",
+	"Provision K8s Cluster":                             "K8sクラスターをプロビジョニング",
+	"Boot up EKS cluster.":                              "EKSクラスターを起動する。",
+	"Restore Shard 1 to DR":                             "シャード1をDRに復元",
+	"Restore snapshot 1 to new RDS.":                    "スナップショット1を新しいRDSに復元する。",
+	"Restore Shard 2 to DR":                             "シャード2をDRに復元",
+	"Restore snapshot 2 to new RDS.":                    "スナップショット2を新しいRDSに復元する。",
+	"Deploy Application Stack":                          "アプリケーションスタックをデプロイ",
+	"Helm install all microservices.":                   "すべてのマイクロサービスをHelmでインストールする。",
+	"Verify System Integrity":                           "システムの整合性を確認",
+	"Run end-to-end smoke tests.":                       "エンドツーエンドのスモークテストを実行する。",
+	"Global DNS Switchover":                             "グローバルDNSの切り替え",
+	"Update Route53 to point to DR region as primary.":  "DRリージョンをプライマリとして指すようRoute53を更新する。",
+	"Database Connection Pool Exhaustion":               "データベース接続プールの枯渇",
+	"Diagnostic and mitigation steps for database connection pool exhaustion incidents. Use this response for 'Cascading Failure - Database Connection Pool Exhaustion' scenarios or saturation alerts.": "データベース接続プール枯渇インシデントの診断と緩和の手順。「Cascading Failure - Database Connection Pool Exhaustion」のシナリオや飽和アラートに使用します。",
+	"Diagnose connection pool status": "接続プールの状態を診断",
+	"Check current connection pool metrics and identify saturation levels. Run: `SELECT count(*) FROM pg_stat_activity;`": "現在の接続プールのメトリクスを確認し、飽和度を特定する。実行: `SELECT count(*) FROM pg_stat_activity;`",
+	"Identify connection leaks": "接続リークを特定",
+	"Analyze long-running queries and idle connections. Look for queries stuck in transaction state.": "長時間実行中のクエリとアイドル接続を分析する。トランザクション状態で止まっているクエリを探す。",
+	"Notify affected services": "影響を受けるサービスに通知",
+	"Send notification to service owners about potential connection issues. Prepare for possible service degradation.": "接続の問題の可能性についてサービスオーナーに通知する。サービス劣化の可能性に備える。",
+	"Restart connection pool": "接続プールを再起動",
+	"Gracefully restart the connection pool to clear leaked connections. Coordinate with on-call to minimize impact.": "リークした接続を解放するため接続プールをグレースフルに再起動する。影響を最小限にするためオンコール担当と調整する。",
+	"Scale connection limits": "接続上限を拡張",
+	"Increase connection pool size if needed to handle current load. Update configuration and redeploy.": "必要に応じて現在の負荷に対応できるよう接続プールのサイズを増やす。設定を更新して再デプロイする。",
+	"Verify recovery": "復旧を確認",
+	"Confirm connection pool is healthy and services are responding normally. Monitor for 30 minutes.": "接続プールが正常で、サービスが通常どおり応答していることを確認する。30分間監視する。",
+	"High Latency Investigation": "高レイテンシの調査",
+	"Systematic investigation and mitigation of high latency issues, such as 'Checkout latency impacting EU customers'. Includes observability checks, bottleneck investigation, and escalation procedures.": "「Checkout latency impacting EU customers」などの高レイテンシ問題の体系的な調査と緩和。オブザーバビリティの確認、ボトルネック調査、エスカレーション手順を含みます。",
+	"Check application metrics": "アプリケーションメトリクスを確認",
+	"Review p50, p95, p99 latency metrics across all services. Identify which services are affected.": "全サービスのp50、p95、p99のレイテンシメトリクスを確認する。影響を受けているサービスを特定する。",
+	"Trace request flow": "リクエストの流れをトレース",
+	"Use distributed tracing to identify where latency is introduced. Check database, cache, and external service calls.": "分散トレーシングでレイテンシの発生箇所を特定する。データベース、キャッシュ、外部サービスの呼び出しを確認する。",
+	"Check DB Latency": "DBレイテンシを確認",
+	"Analyze database query performance and wait events.":    "データベースのクエリ性能と待機イベントを分析する。",
+	"Check Cache Hit Rate":                                   "キャッシュヒット率を確認",
+	"Verify Redis/Memcached hit rates and eviction metrics.": "Redis/Memcachedのヒット率と退避メトリクスを確認する。",
+	"Check Upstream Services":                                "上流サービスを確認",
+	"Review latency metrics for dependent microservices.":    "依存するマイクロサービスのレイテンシメトリクスを確認する。",
+	"Identify bottleneck":                                    "ボトルネックを特定",
+	"Determine root cause based on parallel investigation results. Gather evidence for mitigation.": "並行調査の結果から根本原因を特定する。緩和策の根拠を集める。",
+	"Apply mitigation": "緩和策を適用",
+	"Execute targeted fix based on identified bottleneck. May include query optimization, cache warming, or scaling.": "特定したボトルネックに対する的を絞った修正を実行する。クエリの最適化、キャッシュのウォームアップ、スケーリングなどを含む場合がある。",
+	"Escalate if needed": "必要に応じてエスカレーション",
+	"If mitigation unsuccessful, escalate to platform team or external vendor. Prepare incident summary.": "緩和策が効果を上げない場合は、プラットフォームチームまたは外部ベンダーにエスカレーションする。インシデントの概要を準備する。",
+	"Service Degradation Response": "サービス劣化への対応",
+	"Coordinated response to service degradation incidents. Includes triage, impact assessment, communication, and mitigation.": "サービス劣化インシデントへの協調的な対応。トリアージ、影響評価、コミュニケーション、緩和を含みます。",
+	"Triage incident": "インシデントをトリアージ",
+	"Assess severity and scope of degradation. Determine if incident or maintenance window.": "劣化の深刻度と範囲を評価する。インシデントかメンテナンス時間かを判断する。",
+	"Assess customer impact": "顧客への影響を評価",
+	"Quantify affected users and business impact. Check error rates and transaction volume.": "影響を受けるユーザー数とビジネスへの影響を定量化する。エラー率とトランザクション量を確認する。",
+	"Communicate status": "状況を共有",
+	"Post status page update and notify stakeholders. Provide ETA for resolution.": "ステータスページを更新し、関係者に通知する。解決の見込み時刻を示す。",
+	"Execute mitigation": "緩和策を実行",
+	"Apply fix or workaround to restore service. May include rollback, scaling, or failover.": "サービスを復旧するため修正または回避策を適用する。ロールバック、スケーリング、フェイルオーバーなどを含む場合がある。",
+	"Schedule postmortem": "ポストモーテムを設定",
+	"Schedule postmortem meeting to review root cause and prevention measures. Assign action items.": "根本原因と再発防止策を振り返るポストモーテム会議を設定する。アクションアイテムを割り当てる。",
+	"Suspicious Activity Protocol": "不審なアクティビティへの対応プロトコル",
+	"Security response for account anomalies. Triggered by 'Unusual authentication pattern detected' alerts or credential stuffing signals.": "アカウントの異常に対するセキュリティ対応。「Unusual authentication pattern detected」アラートやクレデンシャルスタッフィングの兆候によって発動します。",
+	"Analyze GeoIP patterns": "GeoIPのパターンを分析",
+	"Review login IP distribution and calculate distance velocity. Identify impossible travel.": "ログインIPの分布を確認し、移動速度を計算する。物理的に不可能な移動を特定する。",
+	"Lock compromised accounts": "侵害されたアカウントをロック",
+	"Temporarily lock accounts with confirmed suspicious activity. Revoke active sessions.": "不審なアクティビティが確認されたアカウントを一時的にロックする。有効なセッションを無効化する。",
+	"Force MFA challenge": "MFA認証を強制",
+	"Require MFA for next login on borderline accounts. Reset 2FA tokens if compromised.": "判断が微妙なアカウントでは次回ログイン時にMFAを要求する。侵害されている場合は2FAトークンをリセットする。",
+	"Notify users": "ユーザーに通知",
+	"Send security alerts to affected users via email/SMS. Prompt for password change.": "影響を受けるユーザーにメール/SMSでセキュリティ警告を送信する。パスワード変更を促す。",
+	"Analytics Correlation Runbook": "分析の相関処理のランブック",
+	"Automated response to analytics correlation lag. Includes backfilling data and verifying integrity.": "分析の相関処理の遅延に対する自動対応。データのバックフィルと整合性の検証を含みます。",
+	"Observe Correlation Lag": "相関処理の遅延を観測",
+	"Check the current lag metrics from the analytics pipeline. Automated check.": "分析パイプラインの現在の遅延メトリクスを確認する。自動チェック。",
+	"Backfill Missing Data": "欠損データをバックフィル",
+	"Trigger the backfill job for the affected time range. Automated action.": "影響を受けた期間のバックフィルジョブを実行する。自動アクション。",
+	"Verify Data Integrity": "データ整合性を確認",
+	"Run checksum validation on the backfilled data. Automated verification.": "バックフィルしたデータのチェックサム検証を実行する。自動検証。",
+	"Notify Team": "チームに通知",
+	"Notify the data platform team about the incident and resolution. Manual step to ensure visibility.": "データ基盤チームにインシデントと解決内容を通知する。可視性を確保するための手動ステップ。",
+	"Production Release Checklist": "本番リリースのチェックリスト",
+	"Comprehensive checklist for production releases. Includes pre-deploy checks, approval, deployment, testing, and monitoring.": "本番リリースの包括的なチェックリスト。デプロイ前チェック、承認、デプロイ、テスト、監視を含みます。",
+	"Pre-deploy checks": "デプロイ前チェック",
+	"Verify all tests pass, dependencies are available, and rollback plan is ready.": "すべてのテストが通り、依存関係が利用可能で、ロールバック計画が準備できていることを確認する。",
+	"Get approval": "承認を得る",
+	"Obtain approval from release manager and stakeholders. Confirm maintenance window.": "リリースマネージャーと関係者から承認を得る。メンテナンス時間を確定する。",
+	"Deploy to production": "本番環境にデプロイ",
+	"Execute deployment to production environment. Monitor deployment progress.": "本番環境へのデプロイを実行する。デプロイの進捗を監視する。",
+	"Run smoke tests": "スモークテストを実行",
+	"Execute smoke tests to verify basic functionality. Check critical user flows.": "基本機能を確認するためスモークテストを実行する。重要なユーザーフローを確認する。",
+	"Run security scan": "セキュリティスキャンを実行",
+	"Perform automated vulnerability scan on new deployment.": "新しいデプロイに対して自動脆弱性スキャンを実行する。",
+	"Monitor metrics": "メトリクスを監視",
+	"Monitor error rates, latency, and resource usage. Watch for anomalies.": "エラー率、レイテンシ、リソース使用量を監視する。異常に注意する。",
+	"Decide on rollback": "ロールバックを判断",
+	"Assess if rollback is needed based on metrics and errors. Proceed or rollback.": "メトリクスとエラーからロールバックが必要かを評価する。続行するかロールバックする。",
+	"Announce release": "リリースを告知",
+	"Post announcement about successful release. Update status page.": "リリース成功の告知を投稿する。ステータスページを更新する。",
+	"Close release ticket": "リリースチケットをクローズ",
+	"Close release ticket and document any issues. Schedule postmortem if needed.": "リリースチケットをクローズし、問題があれば記録する。必要に応じてポストモーテムを設定する。",
+	"Canary Deployment Checklist": "カナリアデプロイのチェックリスト",
+	"Checklist for canary deployments with gradual rollout. Includes staged rollout and monitoring at each stage.": "段階的なロールアウトを伴うカナリアデプロイのチェックリスト。段階ごとのロールアウトと各段階での監視を含みます。",
+	"Deploy to 5% of traffic": "トラフィックの5%にデプロイ",
+	"Deploy new version to 5% of production traffic. Use traffic splitting.": "新バージョンを本番トラフィックの5%にデプロイする。トラフィック分割を使用する。",
+	"Monitor 5% canary": "5%カナリアを監視",
+	"Monitor error rates and latency for canary traffic. Watch for 10 minutes.": "カナリアトラフィックのエラー率とレイテンシを監視する。10分間観察する。",
+	"Deploy to 25% of traffic":                      "トラフィックの25%にデプロイ",
+	"Increase traffic to 25% if canary is healthy.": "カナリアが正常ならトラフィックを25%に増やす。",
+	"Monitor 25% canary":                            "25%カナリアを監視",
+	"Monitor error rates and latency for 25% traffic. Watch for 10 minutes.": "25%トラフィックのエラー率とレイテンシを監視する。10分間観察する。",
+	"Deploy to 100% of traffic":                                      "トラフィックの100%にデプロイ",
+	"Roll out to all traffic if 25% canary is healthy.":              "25%カナリアが正常なら全トラフィックにロールアウトする。",
+	"Verify full rollout":                                            "完全なロールアウトを確認",
+	"Confirm all traffic is on new version. Monitor for 30 minutes.": "すべてのトラフィックが新バージョンに移行したことを確認する。30分間監視する。",
+	"Rollback Checklist":                                             "ロールバックのチェックリスト",
+	"Procedure for rolling back a failed deployment. Includes assessment, revert, verification, and communication.": "失敗したデプロイをロールバックする手順。評価、切り戻し、検証、コミュニケーションを含みます。",
+	"Assess failure": "障害を評価",
+	"Analyze error logs and metrics to understand failure. Determine if rollback is necessary.": "エラーログとメトリクスを分析して障害を把握する。ロールバックが必要かを判断する。",
+	"Revert to previous version": "以前のバージョンに戻す",
+	"Execute rollback to previous stable version. Monitor deployment progress.": "以前の安定バージョンへのロールバックを実行する。デプロイの進捗を監視する。",
+	"Verify rollback": "ロールバックを確認",
+	"Confirm previous version is running and healthy. Run smoke tests.": "以前のバージョンが稼働し正常であることを確認する。スモークテストを実行する。",
+	"Communicate rollback": "ロールバックを共有",
+	"Notify stakeholders about rollback. Update status page.":                              "関係者にロールバックを通知する。ステータスページを更新する。",
+	"Schedule postmortem to review root cause. Assign action items to prevent recurrence.": "根本原因を振り返るポストモーテムを設定する。再発防止のアクションアイテムを割り当てる。",
+	"Database Failover": "データベースのフェイルオーバー",
+	"Procedure for failing over to standby database. Trigger this runbook when 'Database primary failover initiated' alert fires.": "スタンバイデータベースへのフェイルオーバー手順。「Database primary failover initiated」アラートが発火したときにこのランブックを実行します。",
+	"Pre-failover checks": "フェイルオーバー前チェック",
+	"Verify standby database is healthy and in sync. Check replication lag and disk space.": "スタンバイデータベースが正常で同期していることを確認する。レプリケーション遅延とディスク容量を確認する。",
+	"Notify stakeholders": "関係者に通知",
+	"Send notification to all service owners about planned failover. Provide maintenance window details.": "計画されたフェイルオーバーについて全サービスオーナーに通知する。メンテナンス時間の詳細を伝える。",
+	"Pause all cron jobs and worker queues to prevent data inconsistency.":                                "データの不整合を防ぐため、すべてのcronジョブとワーカーキューを一時停止する。",
+	"Prepare Standby": "スタンバイを準備",
+	"Ensure standby is caught up and ready for promotion.": "スタンバイが追いつき、昇格の準備ができていることを確認する。",
+	"Execute failover": "フェイルオーバーを実行",
+	"Promote standby to primary and update connection strings. Run: `pg_ctl promote -D /var/lib/postgresql/data`": "スタンバイをプライマリに昇格し、接続文字列を更新する。実行: `pg_ctl promote -D /var/lib/postgresql/data`",
+	"Validate new primary": "新しいプライマリを検証",
+	"Confirm new primary is accepting connections and serving queries. Run smoke tests.": "新しいプライマリが接続を受け付け、クエリを処理していることを確認する。スモークテストを実行する。",
+	"Update DNS records": "DNSレコードを更新",
+	"Update DNS to point to new primary database. Wait for TTL to expire.": "新しいプライマリデータベースを指すようDNSを更新する。TTLの期限切れを待つ。",
+	"Certificate Rotation": "証明書のローテーション",
+	"Procedure for rotating SSL/TLS certificates. Recommended response for 'Certificate expiration warning' alerts.": "SSL/TLS証明書のローテーション手順。「Certificate expiration warning」アラートへの推奨対応です。",
+	"Backup current certificates": "現在の証明書をバックアップ",
+	"Create backup of current certificates and keys. Store in secure location.": "現在の証明書と鍵のバックアップを作成する。安全な場所に保管する。",
+	"Generate new certificates": "新しい証明書を生成",
+	"Generate new certificates from CA. Run: `certbot renew --force-renewal`": "CAから新しい証明書を生成する。実行: `certbot renew --force-renewal`",
+	"Deploy to LBs": "LBにデプロイ",
+	"Update SSL certificates on HAProxy/ALB endpoints.":         "HAProxy/ALBエンドポイントのSSL証明書を更新する。",
+	"Deploy to App Servers":                                     "アプリサーバーにデプロイ",
+	"Distribute certificates to backend application instances.": "バックエンドのアプリケーションインスタンスに証明書を配布する。",
+	"Verify certificate validity":                               "証明書の有効性を確認",
+	"Verify new certificates are valid and properly installed. Check expiration dates and certificate chain.": "新しい証明書が有効で正しくインストールされていることを確認する。有効期限と証明書チェーンを確認する。",
+	"Cache Flush and Warmup": "キャッシュのフラッシュとウォームアップ",
+	"Procedure for flushing and warming up cache. Use to resolve 'Cache hit rate degradation' alerts or 'Redis cache hit rate dropped' warnings.": "キャッシュをフラッシュしてウォームアップする手順。「Cache hit rate degradation」アラートや「Redis cache hit rate dropped」警告の解消に使用します。",
+	"Drain cache connections": "キャッシュ接続をドレイン",
+	"Gracefully drain existing cache connections. Stop accepting new connections.": "既存のキャッシュ接続をグレースフルにドレインする。新しい接続の受け付けを停止する。",
+	"Flush cache data": "キャッシュデータをフラッシュ",
+	"Clear all data from cache. Run: `redis-cli FLUSHALL`": "キャッシュからすべてのデータを消去する。実行: `redis-cli FLUSHALL`",
+	"Warmup User Data":                          "ユーザーデータをウォームアップ",
+	"Pre-load active user profiles into cache.": "アクティブなユーザープロファイルをキャッシュに事前ロードする。",
+	"Warmup Product Catalog":                    "商品カタログをウォームアップ",
+	"Pre-load high-traffic product listings.":   "アクセスの多い商品リストを事前ロードする。",
+	"Verify cache health":                       "キャッシュの正常性を確認",
+	"Confirm cache is responding and hit rates are normal. Monitor for 15 minutes.": "キャッシュが応答し、ヒット率が正常であることを確認する。15分間監視する。",
+	"Pod Restart Analysis": "Pod再起動の分析",
+	"Diagnostic workflow for unstable containers. Response for 'Container restart loop on pod' alerts or OOMKilled events.": "不安定なコンテナの診断ワークフロー。「Container restart loop on pod」アラートやOOMKilledイベントへの対応です。",
+	"Fetch pod logs": "Podのログを取得",
+	"Retrieve previous container logs to identify crash reason. Look for panic stack traces.": "クラッシュの原因を特定するため前回のコンテナのログを取得する。panicのスタックトレースを探す。",
+	"Check resource limits": "リソース制限を確認",
+	"Compare memory usage vs configured limits. Identify memory leaks or under-provisioning.": "メモリ使用量を設定された制限と比較する。メモリリークやリソース不足を特定する。",
+	"Redeploy pod": "Podを再デプロイ",
+	"Delete pod to force reschedule on fresh node. Validate startup health.": "Podを削除して新しいノードへの再スケジュールを強制する。起動時のヘルスを確認する。",
+	"API Rate Limit Mitigation": "APIレート制限の緩和",
+	"Mitigation for 'API rate limit exhaustion' alerts. Managing quotas for high-volume consumers.": "「API rate limit exhaustion」アラートの緩和策。大量利用のコンシューマーのクォータを管理します。",
+	"Identify top consumers": "上位のコンシューマーを特定",
+	"Analyze request logs to find IP/API keys exceeding quota. Run: `SELECT client_id, count(*) FROM api_logs GROUP BY client_id`": "リクエストログを分析し、クォータを超過しているIP/APIキーを見つける。実行: `SELECT client_id, count(*) FROM api_logs GROUP BY client_id`",
+	"Increase temporary quota":                                                   "一時的にクォータを引き上げ",
+	"Approve temporary limit increase for legitimate traffic spike.":             "正当なトラフィック急増に対して一時的な上限引き上げを承認する。",
+	"Ban abusive client":                                                         "悪用しているクライアントを遮断",
+	"Block API key or IP address if traffic is malicious/bot.":                   "トラフィックが悪意のあるもの/ボットであればAPIキーまたはIPアドレスをブロックする。",
+	"Verify traffic normalized":                                                  "トラフィックの正常化を確認",
+	"Confirm error rates dropped and latency recovered. Monitor for 10 minutes.": "エラー率が下がり、レイテンシが回復したことを確認する。10分間監視する。",
+	"Catalog Sync Repair":                                                        "カタログ同期の修復",
+	"Resolution for 'Catalog inventory sync drift' alerts. Fixes inconsistencies between ERP and Store catalog.": "「Catalog inventory sync drift」アラートの解決策。ERPとストアカタログの不整合を修正します。",
+	"Check drift metrics": "ずれのメトリクスを確認",
+	"Compare item counts and timestamps between ERP and Catalog DB.": "ERPとカタログDBの件数とタイムスタンプを比較する。",
+	"Trigger full sync": "フル同期を実行",
+	"Force a full reconciliation job to overwrite stale data. Action: `POST /admin/sync/full`": "古いデータを上書きするため完全な突合ジョブを強制実行する。アクション: `POST /admin/sync/full`",
+	"Verify record counts":                               "レコード件数を確認",
+	"Confirm drift metric is zero after job completion.": "ジョブ完了後にずれのメトリクスがゼロであることを確認する。",
+	"Payment Latency Mitigation":                         "決済レイテンシの緩和",
+	"Diagnostic and remediation steps for payment service performance issues. Used for high P99 latency alerts.": "決済サービスの性能問題の診断と修復手順。P99レイテンシが高いときのアラートに使用します。",
+	"Check payment gateway metrics": "決済ゲートウェイのメトリクスを確認",
+	"Review latency and error rates for third-party payment gateways. Identify if issue is external.": "サードパーティの決済ゲートウェイのレイテンシとエラー率を確認する。問題が外部にあるかを特定する。",
+	"Analyze upstream dependencies": "上流の依存関係を分析",
+	"Check latency metrics for internal services like Fraud and Inventory. Identify downstream bottlenecks.": "FraudやInventoryなどの社内サービスのレイテンシメトリクスを確認する。下流のボトルネックを特定する。",
+	"Increase gateway timeout": "ゲートウェイのタイムアウトを延長",
+	"Update payment gateway timeout configuration to handle transient spikes. Deploy config change.": "一時的な急増に対応できるよう決済ゲートウェイのタイムアウト設定を更新する。設定変更をデプロイする。",
+	"Scale payment service": "決済サービスをスケール",
+	"Increase horizontal pod autoscaling targets for payment-service. Ensure enough capacity.": "payment-serviceのHorizontal Pod Autoscalingの目標値を引き上げる。十分な容量を確保する。",
+	"Verify latency recovery": "レイテンシの回復を確認",
+	"Monitor P99 latency metrics for 15 minutes. Confirm stability.": "P99レイテンシのメトリクスを15分間監視する。安定していることを確認する。",
+}
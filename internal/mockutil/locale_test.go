@@ -0,0 +1,46 @@
+package mockutil
+
+import "testing"
+
+func TestParseLocale(t *testing.T) {
+	tests := map[string]string{
+		"de":    LocaleGerman,
+		"de-DE": LocaleGerman,
+		"ja_JP": LocaleJapanese,
+		" JA ":  LocaleJapanese,
+		"en":    "",
+		"fr-FR": "",
+		"":      "",
+	}
+	for in, want := range tests {
+		if got := ParseLocale(map[string]any{"locale": in}); got != want {
+			t.Errorf("ParseLocale(%q) = %q, want %q", in, got, want)
+		}
+	}
+	if got := ParseLocale(nil); got != "" {
+		t.Fatalf("expected no locale for missing config, got %q", got)
+	}
+}
+
+func TestLocalize(t *testing.T) {
+	if got := Localize(LocaleGerman, "Database Failover"); got != "Datenbank-Failover" {
+		t.Fatalf("unexpected German title %q", got)
+	}
+	if got := Localize(LocaleJapanese, "Database Failover"); got != "データベースのフェイルオーバー" {
+		t.Fatalf("unexpected Japanese title %q", got)
+	}
+	if got := Localize(LocaleGerman, "Generated alert 42"); got != "Generated alert 42" {
+		t.Fatalf("expected untranslated text unchanged, got %q", got)
+	}
+	if got := Localize("", "Database Failover"); got != "Database Failover" {
+		t.Fatalf("expected English without a locale, got %q", got)
+	}
+	for text := range catalogDE {
+		if _, ok := catalogJA[text]; !ok {
+			t.Errorf("Japanese catalog missing %q", text)
+		}
+	}
+	if len(catalogDE) != len(catalogJA) {
+		t.Fatalf("catalog sizes differ: de %d, ja %d", len(catalogDE), len(catalogJA))
+	}
+}
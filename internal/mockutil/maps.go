@@ -1,5 +1,7 @@
 package mockutil
 
+import "sort"
+
 // serviceTeamMap provides service-to-team mapping for alert ownership
 // This is used across different mock adapters to ensure consistent team assignments
 var serviceTeamMap = map[string]string{
@@ -62,3 +64,13 @@ func GetChannelForTeam(team string) string {
 	}
 	return "#ops-alerts"
 }
+
+// Services returns every service in the shared topology, sorted by ID.
+func Services() []string {
+	out := make([]string, 0, len(serviceTeamMap))
+	for service := range serviceTeamMap {
+		out = append(out, service)
+	}
+	sort.Strings(out)
+	return out
+}
@@ -0,0 +1,244 @@
+package mockutil
+
+import (
+	"context"
+	"math/rand"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// OperatorConfigKey is the provider config key for the simulated operator,
+// e.g. {"enabled": true, "actor": "alex", "interval": "90s", "jitter": "45s",
+// "seed": 7}. Any provider's config may start it; the first one a host starts
+// its operator with sets the pace.
+const OperatorConfigKey = "operator"
+
+// EventOperatorAction is the event bus type for each action the simulated
+// operator takes.
+const EventOperatorAction = "operator.action"
+
+// Operator defaults: a responder who checks in every minute and a half or so.
+const (
+	defaultOperatorActor    = "alex"
+	defaultOperatorInterval = 90 * time.Second
+	defaultOperatorJitter   = 45 * time.Second
+)
+
+// OperatorConfig paces the simulated operator. Each pause between actions is
+// Interval shifted by a random amount of up to Jitter either way, drawn from
+// Seed so a demo replays the same rhythm.
+type OperatorConfig struct {
+	Enabled  bool
+	Actor    string
+	Interval time.Duration
+	Jitter   time.Duration
+	Seed     int64
+}
+
+// OperatorAction is one thing the simulated operator did.
+type OperatorAction struct {
+	// Seq is the event bus sequence number, usable as a polling cursor.
+	Seq int64 `json:"seq"`
+	// Kind names the task that acted, such as "alert.acknowledge".
+	Kind string `json:"kind"`
+	// Ref is the record acted on, such as "alert:al-001".
+	Ref     string    `json:"ref"`
+	Actor   string    `json:"actor"`
+	Summary string    `json:"summary"`
+	At      time.Time `json:"at"`
+}
+
+// OperatorTask does one piece of the operator's work on a provider, acting as
+// actor, and describes what it did. It reports false when there is nothing to
+// do right now.
+type OperatorTask func(ctx context.Context, actor string) (OperatorAction, bool, error)
+
+// Operator is one simulated operator working the tasks registered on it.
+// Each host, or each plugin process, builds its own, so operators never act
+// on another host's providers.
+type Operator struct {
+	mu    sync.Mutex
+	tasks map[string]OperatorTask
+	// last is the kind of the task that acted last, so the next step starts
+	// with the one after it and the operator moves between providers.
+	last string
+	stop chan struct{}
+}
+
+// NewOperator returns an operator with no tasks.
+func NewOperator() *Operator {
+	return &Operator{tasks: map[string]OperatorTask{}}
+}
+
+// ParseOperatorConfig reads OperatorConfigKey from a provider config. true
+// alone enables the operator with the defaults.
+func ParseOperatorConfig(cfg map[string]any) OperatorConfig {
+	out := OperatorConfig{Actor: defaultOperatorActor, Interval: defaultOperatorInterval, Jitter: defaultOperatorJitter, Seed: 1}
+	raw := cfg[OperatorConfigKey]
+	if on, ok := parseSwitch(raw); ok {
+		out.Enabled = on
+		return out
+	}
+	m, ok := raw.(map[string]any)
+	if !ok {
+		return out
+	}
+	out.Enabled = true
+	if on, ok := parseSwitch(m["enabled"]); ok {
+		out.Enabled = on
+	}
+	if actor, ok := m["actor"].(string); ok && strings.TrimSpace(actor) != "" {
+		out.Actor = strings.TrimSpace(actor)
+	}
+	if s, ok := m["interval"].(string); ok {
+		if d, err := time.ParseDuration(s); err == nil && d > 0 {
+			out.Interval = d
+		}
+	}
+	if s, ok := m["jitter"].(string); ok {
+		if d, err := time.ParseDuration(s); err == nil && d >= 0 {
+			out.Jitter = d
+		}
+	}
+	switch seed := m["seed"].(type) {
+	case float64:
+		out.Seed = int64(seed)
+	case int:
+		out.Seed = int64(seed)
+	case int64:
+		out.Seed = seed
+	}
+	return out
+}
+
+// Register makes fn the operator task of kind, replacing any earlier one.
+// Hosts register their providers' tasks whether or not the operator runs, so
+// it can also be stepped by hand.
+func (o *Operator) Register(kind string, fn OperatorTask) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.tasks[kind] = fn
+}
+
+// Step has the operator take one action as actor: the registered tasks are
+// tried in kind order, starting after the one that acted last, and the first
+// that does something is published on the event bus. It reports false when
+// no task had anything to do. A failing task is skipped; its error is
+// returned only when no other task acted.
+func (o *Operator) Step(ctx context.Context, actor string) (OperatorAction, bool, error) {
+	o.mu.Lock()
+	kinds := make([]string, 0, len(o.tasks))
+	for kind := range o.tasks {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+	start := sort.SearchStrings(kinds, o.last)
+	if start < len(kinds) && kinds[start] == o.last {
+		start++
+	}
+	tasks := make([]OperatorTask, len(kinds))
+	for i, kind := range kinds {
+		tasks[i] = o.tasks[kind]
+	}
+	o.mu.Unlock()
+
+	var firstErr error
+	for i := range kinds {
+		idx := (start + i) % len(kinds)
+		action, ok, err := tasks[idx](ctx, actor)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		if !ok {
+			continue
+		}
+		o.mu.Lock()
+		o.last = kinds[idx]
+		o.mu.Unlock()
+
+		action.Kind = kinds[idx]
+		action.Actor = actor
+		ev := PublishEvent(Event{Type: EventOperatorAction, Source: "operator", At: action.At, Data: action})
+		action.Seq = ev.Seq
+		action.At = ev.At
+		return action, true, nil
+	}
+	return OperatorAction{}, false, firstErr
+}
+
+// OperatorActions returns the operator's actions after the given sequence
+// number, oldest first.
+func OperatorActions(after int64) []OperatorAction {
+	events := EventsSince(after, EventOperatorAction)
+	out := make([]OperatorAction, 0, len(events))
+	for _, ev := range events {
+		if action, ok := ev.Data.(OperatorAction); ok {
+			action.Seq = ev.Seq
+			action.At = ev.At
+			out = append(out, action)
+		}
+	}
+	return out
+}
+
+// Start starts the operator loop when cfg enables it and it is not running
+// yet. Each tick runs one Step.
+func (o *Operator) Start(cfg OperatorConfig) {
+	if !cfg.Enabled || cfg.Interval <= 0 {
+		return
+	}
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.stop != nil {
+		return
+	}
+	stop := make(chan struct{})
+	o.stop = stop
+	go o.run(cfg, stop)
+}
+
+// Stop stops the operator loop, if one is running. It is safe on a nil
+// operator.
+func (o *Operator) Stop() {
+	if o == nil {
+		return
+	}
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.stop != nil {
+		close(o.stop)
+		o.stop = nil
+	}
+}
+
+func (o *Operator) run(cfg OperatorConfig, stop chan struct{}) {
+	rng := rand.New(rand.NewSource(cfg.Seed))
+	for {
+		timer := time.NewTimer(operatorPause(cfg, rng))
+		select {
+		case <-stop:
+			timer.Stop()
+			return
+		case <-timer.C:
+			_, _, _ = o.Step(context.Background(), cfg.Actor)
+		}
+	}
+}
+
+// operatorPause is Interval shifted by up to Jitter either way, and never
+// less than a quarter of Interval so actions do not bunch up.
+func operatorPause(cfg OperatorConfig, rng *rand.Rand) time.Duration {
+	pause := cfg.Interval
+	if cfg.Jitter > 0 {
+		pause += time.Duration(rng.Int63n(int64(2*cfg.Jitter)+1)) - cfg.Jitter
+	}
+	if floor := cfg.Interval / 4; pause < floor {
+		pause = floor
+	}
+	return pause
+}
@@ -0,0 +1,103 @@
+package mockutil
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestParseOperatorConfig(t *testing.T) {
+	if got := ParseOperatorConfig(nil); got.Enabled || got.Actor != "alex" || got.Interval != 90*time.Second {
+		t.Fatalf("expected the operator off with defaults, got %+v", got)
+	}
+	if got := ParseOperatorConfig(map[string]any{"operator": true}); !got.Enabled {
+		t.Fatalf("expected true to enable the operator, got %+v", got)
+	}
+	got := ParseOperatorConfig(map[string]any{"operator": map[string]any{"actor": "sam", "interval": "30s", "jitter": "10s", "seed": float64(7)}})
+	if !got.Enabled || got.Actor != "sam" || got.Interval != 30*time.Second || got.Jitter != 10*time.Second || got.Seed != 7 {
+		t.Fatalf("unexpected operator config %+v", got)
+	}
+	if got := ParseOperatorConfig(map[string]any{"operator": map[string]any{"enabled": false}}); got.Enabled {
+		t.Fatalf("expected enabled: false to win, got %+v", got)
+	}
+
+	cfg := OperatorConfig{Interval: 40 * time.Second, Jitter: time.Minute}
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 50; i++ {
+		if pause := operatorPause(cfg, rng); pause < 10*time.Second || pause > 100*time.Second {
+			t.Fatalf("pause %s outside [10s, 100s]", pause)
+		}
+	}
+}
+
+func TestOperatorStepRotatesTasks(t *testing.T) {
+	counts := map[string]int{}
+	task := func(kind string, work int) OperatorTask {
+		return func(ctx context.Context, actor string) (OperatorAction, bool, error) {
+			if counts[kind] >= work {
+				return OperatorAction{}, false, nil
+			}
+			counts[kind]++
+			return OperatorAction{Ref: "test:" + kind, Summary: actor + " did " + kind}, true, nil
+		}
+	}
+	op := NewOperator()
+	op.Register("test.a", task("test.a", 2))
+	op.Register("test.b", task("test.b", 1))
+	op.Register("test.broken", func(ctx context.Context, actor string) (OperatorAction, bool, error) {
+		return OperatorAction{}, false, errors.New("broken")
+	})
+
+	cursor := EventsSince(0)
+	var after int64
+	if len(cursor) > 0 {
+		after = cursor[len(cursor)-1].Seq
+	}
+	var kinds []string
+	for i := 0; i < 3; i++ {
+		action, acted, err := op.Step(context.Background(), "sam")
+		if err != nil || !acted {
+			t.Fatalf("step %d: acted=%v err=%v", i, acted, err)
+		}
+		if action.Actor != "sam" || action.Seq == 0 {
+			t.Fatalf("unexpected action %+v", action)
+		}
+		kinds = append(kinds, action.Kind)
+	}
+	if kinds[0] != "test.a" || kinds[1] != "test.b" || kinds[2] != "test.a" {
+		t.Fatalf("expected the operator to alternate tasks, got %v", kinds)
+	}
+	if _, acted, err := op.Step(context.Background(), "sam"); acted || err == nil {
+		t.Fatalf("expected the broken task's error once nothing is left, got acted=%v err=%v", acted, err)
+	}
+	if got := OperatorActions(after); len(got) != 3 || got[2].Ref != "test:test.a" {
+		t.Fatalf("expected three recorded actions, got %+v", got)
+	}
+}
+
+func TestOperatorStopEndsLoop(t *testing.T) {
+	var steps atomic.Int64
+	op := NewOperator()
+	op.Register("test.tick", func(ctx context.Context, actor string) (OperatorAction, bool, error) {
+		steps.Add(1)
+		return OperatorAction{}, false, nil
+	})
+	op.Start(OperatorConfig{Enabled: true, Actor: "sam", Interval: time.Millisecond, Seed: 1})
+	deadline := time.Now().Add(time.Second)
+	for steps.Load() == 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if steps.Load() == 0 {
+		t.Fatal("expected the started operator to step")
+	}
+	op.Stop()
+	time.Sleep(10 * time.Millisecond)
+	stopped := steps.Load()
+	time.Sleep(20 * time.Millisecond)
+	if got := steps.Load(); got != stopped {
+		t.Fatalf("expected no steps after Stop, went from %d to %d", stopped, got)
+	}
+}
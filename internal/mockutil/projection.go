@@ -0,0 +1,43 @@
+package mockutil
+
+import "encoding/json"
+
+// ProjectFields reduces a slice of records to the requested JSON fields so list
+// views can skip heavy payloads. The "id" field is always kept. When fields is
+// empty the records are returned unchanged.
+func ProjectFields(records any, fields []string) (any, error) {
+	if len(fields) == 0 {
+		return records, nil
+	}
+
+	raw, err := json.Marshal(records)
+	if err != nil {
+		return nil, err
+	}
+	var rows []map[string]any
+	if err := json.Unmarshal(raw, &rows); err != nil {
+		return nil, err
+	}
+
+	keep := map[string]bool{"id": true}
+	for _, field := range fields {
+		keep[field] = true
+	}
+
+	out := make([]map[string]any, 0, len(rows))
+	for _, row := range rows {
+		projected := make(map[string]any, len(keep))
+		for key, value := range row {
+			if keep[key] {
+				projected[key] = value
+			}
+		}
+		out = append(out, projected)
+	}
+	return out, nil
+}
+
+// ProjectionOptions carries the optional field projection accepted by query payloads.
+type ProjectionOptions struct {
+	Fields []string `json:"fields"`
+}
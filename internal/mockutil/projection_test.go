@@ -0,0 +1,44 @@
+package mockutil
+
+import (
+	"testing"
+
+	"github.com/opsorch/opsorch-core/schema"
+)
+
+func TestProjectFields_Empty(t *testing.T) {
+	tickets := []schema.Ticket{{ID: "TCK-1", Title: "one"}}
+	out, err := ProjectFields(tickets, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := out.([]schema.Ticket); !ok {
+		t.Fatalf("expected records unchanged, got %T", out)
+	}
+}
+
+func TestProjectFields_Subset(t *testing.T) {
+	tickets := []schema.Ticket{
+		{ID: "TCK-1", Title: "one", Description: "long description", Status: "open"},
+		{ID: "TCK-2", Title: "two", Description: "another description", Status: "done"},
+	}
+	out, err := ProjectFields(tickets, []string{"title"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	rows, ok := out.([]map[string]any)
+	if !ok {
+		t.Fatalf("expected []map[string]any, got %T", out)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("got %d rows, want 2", len(rows))
+	}
+	for _, row := range rows {
+		if len(row) != 2 {
+			t.Errorf("got keys %v, want only id and title", row)
+		}
+		if row["id"] == nil || row["title"] == nil {
+			t.Errorf("missing id or title in %v", row)
+		}
+	}
+}
@@ -0,0 +1,188 @@
+package mockutil
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/opsorch/opsorch-core/orcherr"
+)
+
+// Ref kinds understood by ParseRef and the resolver registry.
+const (
+	RefAlert      = "alert"
+	RefIncident   = "incident"
+	RefTicket     = "ticket"
+	RefDeployment = "deployment"
+	RefTeam       = "team"
+	RefPlan       = "plan"
+	RefRun        = "run"
+)
+
+var refKinds = map[string]bool{
+	RefAlert: true, RefIncident: true, RefTicket: true, RefDeployment: true,
+	RefTeam: true, RefPlan: true, RefRun: true,
+}
+
+// RefsKey is the metadata key holding a record's canonical links.
+const RefsKey = "refs"
+
+// legacyRefKeys maps the ad hoc metadata and field keys providers have used
+// for links to the kind of record they name.
+var legacyRefKeys = map[string]string{
+	"incident_id":      RefIncident,
+	"incidentId":       RefIncident,
+	"relatedIncidents": RefIncident,
+	"ticket_id":        RefTicket,
+	"ticketId":         RefTicket,
+	"related_tickets":  RefTicket,
+	"deployment_id":    RefDeployment,
+	"deploymentId":     RefDeployment,
+	"alert_id":         RefAlert,
+	"alertId":          RefAlert,
+	"alertIds":         RefAlert,
+	"plan_id":          RefPlan,
+	"planId":           RefPlan,
+}
+
+// Ref is a cross-provider reference such as "incident:inc-001". The ID is
+// everything after the first colon, so provider-qualified IDs like
+// "alert:pagerduty:PRD123" keep their own prefix.
+type Ref struct {
+	Kind string `json:"kind"`
+	ID   string `json:"id"`
+}
+
+func (r Ref) String() string { return r.Kind + ":" + r.ID }
+
+// FormatRef returns the canonical reference for id of the given kind.
+func FormatRef(kind, id string) string { return Ref{Kind: kind, ID: id}.String() }
+
+// ParseRef splits a canonical reference. Unknown kinds and empty IDs are bad requests.
+func ParseRef(s string) (Ref, error) {
+	kind, id, ok := strings.Cut(strings.TrimSpace(s), ":")
+	if !ok || id == "" {
+		return Ref{}, orcherr.New("bad_request", fmt.Sprintf("invalid ref %q: want kind:id", s), nil)
+	}
+	if !refKinds[kind] {
+		return Ref{}, orcherr.New("bad_request", fmt.Sprintf("unknown ref kind %q", kind), nil)
+	}
+	return Ref{Kind: kind, ID: id}, nil
+}
+
+// LinkRefs records the canonical form of every legacy link key found in meta
+// and fields under meta["refs"], merged with any refs already there and
+// sorted. The legacy keys are left in place for existing clients.
+func LinkRefs(meta map[string]any, fields map[string]any) {
+	if meta == nil {
+		return
+	}
+	seen := map[string]bool{}
+	for _, ref := range refStrings(meta[RefsKey]) {
+		seen[ref] = true
+	}
+	for _, m := range []map[string]any{fields, meta} {
+		for key, kind := range legacyRefKeys {
+			for _, id := range refStrings(m[key]) {
+				if id != "" {
+					seen[FormatRef(kind, id)] = true
+				}
+			}
+		}
+	}
+	if len(seen) == 0 {
+		return
+	}
+	refs := make([]string, 0, len(seen))
+	for ref := range seen {
+		refs = append(refs, ref)
+	}
+	sort.Strings(refs)
+	meta[RefsKey] = refs
+}
+
+func refStrings(v any) []string {
+	switch v := v.(type) {
+	case string:
+		return []string{v}
+	case []string:
+		return v
+	case []any:
+		out := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				out = append(out, s)
+			}
+		}
+		return out
+	}
+	return nil
+}
+
+// Resolver looks up one record of a kind by ID.
+type Resolver func(ctx context.Context, id string) (any, error)
+
+// ResolvedRef is a reference together with the record it names.
+type ResolvedRef struct {
+	Ref    string `json:"ref"`
+	Kind   string `json:"kind"`
+	ID     string `json:"id"`
+	Entity any    `json:"entity"`
+}
+
+var (
+	resolversMu sync.RWMutex
+	resolvers   = map[string]Resolver{}
+)
+
+// RegisterResolver makes fn the process-wide resolver for kind, replacing
+// any earlier one. Providers register themselves from New.
+func RegisterResolver(kind string, fn Resolver) {
+	resolversMu.Lock()
+	defer resolversMu.Unlock()
+	resolvers[kind] = fn
+}
+
+// HasResolver reports whether a provider in this process registered a
+// resolver for kind.
+func HasResolver(kind string) bool {
+	resolversMu.RLock()
+	defer resolversMu.RUnlock()
+	return resolvers[kind] != nil
+}
+
+// ResolveRef parses ref and returns the record it names from whichever
+// provider registered its kind in this process.
+func ResolveRef(ctx context.Context, ref string) (ResolvedRef, error) {
+	resolversMu.RLock()
+	rs := make(Resolvers, len(resolvers))
+	for kind, fn := range resolvers {
+		rs[kind] = fn
+	}
+	resolversMu.RUnlock()
+	return rs.Resolve(ctx, ref)
+}
+
+// Resolvers maps ref kinds to their resolvers, for callers that hold their
+// own providers instead of using the process-wide registry.
+type Resolvers map[string]Resolver
+
+// Resolve parses ref and returns the record it names from the resolver for
+// its kind.
+func (rs Resolvers) Resolve(ctx context.Context, ref string) (ResolvedRef, error) {
+	parsed, err := ParseRef(ref)
+	if err != nil {
+		return ResolvedRef{}, err
+	}
+	fn := rs[parsed.Kind]
+	if fn == nil {
+		return ResolvedRef{}, orcherr.New("not_found", fmt.Sprintf("no %s provider in this process", parsed.Kind), nil)
+	}
+	entity, err := fn(ctx, parsed.ID)
+	if err != nil {
+		return ResolvedRef{}, err
+	}
+	return ResolvedRef{Ref: parsed.String(), Kind: parsed.Kind, ID: parsed.ID, Entity: entity}, nil
+}
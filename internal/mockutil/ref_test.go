@@ -0,0 +1,72 @@
+package mockutil
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/opsorch/opsorch-core/orcherr"
+)
+
+func TestParseRef(t *testing.T) {
+	ref, err := ParseRef("alert:pagerduty:PRD123")
+	if err != nil {
+		t.Fatalf("ParseRef: %v", err)
+	}
+	if ref.Kind != RefAlert || ref.ID != "pagerduty:PRD123" || ref.String() != "alert:pagerduty:PRD123" {
+		t.Errorf("unexpected ref %+v", ref)
+	}
+	for _, bad := range []string{"", "inc-001", "incident:", "widget:w-1"} {
+		if _, err := ParseRef(bad); err == nil || !strings.Contains(err.Error(), "bad_request") {
+			t.Errorf("ParseRef(%q) = %v, want bad_request", bad, err)
+		}
+	}
+}
+
+func TestLinkRefs(t *testing.T) {
+	meta := map[string]any{
+		"refs":            []any{"run:run-7"},
+		"related_tickets": []string{"TCK-002", "TCK-001"},
+		"alertId":         "pagerduty:PRD123",
+	}
+	fields := map[string]any{"incident_id": "inc-001"}
+	LinkRefs(meta, fields)
+
+	want := []string{"alert:pagerduty:PRD123", "incident:inc-001", "run:run-7", "ticket:TCK-001", "ticket:TCK-002"}
+	if got := meta[RefsKey]; !reflect.DeepEqual(got, want) {
+		t.Errorf("refs = %v, want %v", got, want)
+	}
+	if _, ok := meta["related_tickets"]; !ok {
+		t.Error("legacy key should be kept")
+	}
+
+	empty := map[string]any{"source": "mock"}
+	LinkRefs(empty, nil)
+	if _, ok := empty[RefsKey]; ok {
+		t.Error("refs should not be set without links")
+	}
+}
+
+func TestResolveRef(t *testing.T) {
+	RegisterResolver(RefPlan, func(ctx context.Context, id string) (any, error) {
+		if id != "plan-1" {
+			return nil, orcherr.New("not_found", "plan not found", nil)
+		}
+		return map[string]string{"id": id}, nil
+	})
+
+	got, err := ResolveRef(context.Background(), "plan:plan-1")
+	if err != nil {
+		t.Fatalf("ResolveRef: %v", err)
+	}
+	if got.Ref != "plan:plan-1" || got.Kind != RefPlan || got.ID != "plan-1" || got.Entity == nil {
+		t.Errorf("unexpected resolution %+v", got)
+	}
+	if _, err := ResolveRef(context.Background(), "plan:plan-2"); err == nil || !strings.Contains(err.Error(), "not_found") {
+		t.Errorf("missing plan: %v, want not_found", err)
+	}
+	if _, err := ResolveRef(context.Background(), "run:run-1"); err == nil || !strings.Contains(err.Error(), "not_found") {
+		t.Errorf("unregistered kind: %v, want not_found", err)
+	}
+}
@@ -0,0 +1,94 @@
+package mockutil
+
+import (
+	"regexp"
+	"strings"
+)
+
+// nameToken matches identifier-like runs such as service IDs, so renames
+// replace whole names: "svc-order" is renamed in "svc-order" and
+// "service=svc-order" but not in "svc-orders".
+var nameToken = regexp.MustCompile(`[A-Za-z0-9_-]+`)
+
+// RenameTokens replaces every whole identifier in s that names has a
+// replacement for.
+func RenameTokens(s string, names map[string]string) string {
+	if len(names) == 0 || s == "" {
+		return s
+	}
+	return nameToken.ReplaceAllStringFunc(s, func(token string) string {
+		if renamed, ok := names[token]; ok {
+			return renamed
+		}
+		return token
+	})
+}
+
+// RewriteStrings returns a deep copy of v with fn applied to every string in
+// it, including strings nested in maps and slices. Map keys are kept.
+func RewriteStrings(v any, fn func(string) string) any {
+	switch val := v.(type) {
+	case string:
+		return fn(val)
+	case []string:
+		out := make([]string, len(val))
+		for i, s := range val {
+			out[i] = fn(s)
+		}
+		return out
+	case map[string]string:
+		out := make(map[string]string, len(val))
+		for k, s := range val {
+			out[k] = fn(s)
+		}
+		return out
+	case []any:
+		out := make([]any, len(val))
+		for i, item := range val {
+			out[i] = RewriteStrings(item, fn)
+		}
+		return out
+	case map[string]any:
+		return RewriteMap(val, fn)
+	case []map[string]any:
+		out := make([]map[string]any, len(val))
+		for i, item := range val {
+			out[i] = RewriteMap(item, fn)
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+// RewriteMap is RewriteStrings for a map[string]any, keeping nil as nil.
+func RewriteMap(in map[string]any, fn func(string) string) map[string]any {
+	if in == nil {
+		return nil
+	}
+	out := make(map[string]any, len(in))
+	for k, v := range in {
+		out[k] = RewriteStrings(v, fn)
+	}
+	return out
+}
+
+// ParseRenames reads a {"old": "new"} config map, skipping blank entries.
+func ParseRenames(raw any) map[string]string {
+	m, ok := raw.(map[string]any)
+	if !ok {
+		return nil
+	}
+	out := make(map[string]string, len(m))
+	for from, to := range m {
+		s, _ := to.(string)
+		from, s = strings.TrimSpace(from), strings.TrimSpace(s)
+		if from != "" && s != "" && from != s {
+			out[from] = s
+		}
+	}
+	if len(out) == 0 {
+		return nil
+	}
+	return out
+}
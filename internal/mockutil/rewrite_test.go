@@ -0,0 +1,31 @@
+package mockutil
+
+import "testing"
+
+func TestRenameTokensReplacesWholeNames(t *testing.T) {
+	names := map[string]string{"svc-order": "orders-api"}
+	got := RenameTokens("svc-order calls svc-orders; see https://grafana.demo/d/x?service=svc-order", names)
+	want := "orders-api calls svc-orders; see https://grafana.demo/d/x?service=orders-api"
+	if got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
+
+func TestRewriteStringsCopiesNestedValues(t *testing.T) {
+	in := map[string]any{
+		"service": "a",
+		"labels":  map[string]string{"service": "a"},
+		"list":    []any{"a", 3, map[string]any{"deep": []string{"a"}}},
+	}
+	out := RewriteMap(in, func(s string) string { return s + "!" })
+	if out["service"] != "a!" || out["labels"].(map[string]string)["service"] != "a!" {
+		t.Fatalf("unexpected rewrite %+v", out)
+	}
+	deep := out["list"].([]any)[2].(map[string]any)["deep"].([]string)
+	if deep[0] != "a!" || out["list"].([]any)[1] != 3 {
+		t.Fatalf("unexpected nested rewrite %+v", out["list"])
+	}
+	if in["service"] != "a" || in["list"].([]any)[2].(map[string]any)["deep"].([]string)[0] != "a" {
+		t.Fatal("RewriteMap modified its input")
+	}
+}
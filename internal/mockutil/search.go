@@ -0,0 +1,335 @@
+package mockutil
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/opsorch/opsorch-core/orcherr"
+)
+
+// Search defaults.
+const (
+	defaultSearchLimit = 20
+	// searchTitleBoost counts a title match as this many body matches.
+	searchTitleBoost = 3
+	searchSnippetLen = 160
+)
+
+// searchStopwords are ignored in both queries and documents.
+var searchStopwords = map[string]bool{
+	"the": true, "and": true, "for": true, "with": true, "from": true, "into": true,
+	"was": true, "were": true, "are": true, "this": true, "that": true, "its": true,
+	"of": true, "to": true, "in": true, "on": true, "at": true, "by": true, "is": true,
+	"an": true, "or": true, "be": true, "as": true,
+}
+
+// SearchDoc is one record as the global search index sees it.
+type SearchDoc struct {
+	Kind  string `json:"kind"`
+	ID    string `json:"id"`
+	Title string `json:"title"`
+	// Body is the searchable text besides the title: descriptions, timeline
+	// bodies, error messages.
+	Body      string    `json:"body,omitempty"`
+	Service   string    `json:"service,omitempty"`
+	Status    string    `json:"status,omitempty"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// SearchSource lists the current documents of one kind.
+type SearchSource func(ctx context.Context) []SearchDoc
+
+// SearchQuery is the payload of search.global. Kinds limits the hits to
+// those ref kinds; empty searches every kind in the process.
+type SearchQuery struct {
+	Query string   `json:"query"`
+	Kinds []string `json:"kinds,omitempty"`
+	Limit int      `json:"limit,omitempty"`
+}
+
+// SearchHit is one ranked search result.
+type SearchHit struct {
+	Ref       string    `json:"ref"`
+	Kind      string    `json:"kind"`
+	ID        string    `json:"id"`
+	Title     string    `json:"title"`
+	Service   string    `json:"service,omitempty"`
+	Status    string    `json:"status,omitempty"`
+	UpdatedAt time.Time `json:"updatedAt"`
+	// Snippet is the part of the body around the first match, or the start
+	// of the body when only the title matched.
+	Snippet string   `json:"snippet,omitempty"`
+	Score   float64  `json:"score"`
+	Matched []string `json:"matched"`
+}
+
+var (
+	searchSourcesMu sync.RWMutex
+	searchSources   = map[string]SearchSource{}
+)
+
+// RegisterSearchSource makes fn the process-wide search source for kind,
+// replacing any earlier one. Providers register themselves from New.
+func RegisterSearchSource(kind string, fn SearchSource) {
+	searchSourcesMu.Lock()
+	defer searchSourcesMu.Unlock()
+	searchSources[kind] = fn
+}
+
+// Search ranks the documents of every registered source (or of q.Kinds)
+// against q.Query by TF-IDF over the combined corpus, so a term rare across
+// the mock universe outweighs a common one. Terms match with a trailing
+// plural "s" trimmed, and title matches count searchTitleBoost times.
+func Search(ctx context.Context, q SearchQuery) ([]SearchHit, error) {
+	return registeredSearchSources().Search(ctx, q)
+}
+
+// CollectSearchDocs returns the documents of the registered sources for
+// kinds, or of every registered source when kinds is empty.
+func CollectSearchDocs(ctx context.Context, kinds []string) []SearchDoc {
+	return registeredSearchSources().collect(ctx, kinds)
+}
+
+// HasSearchSource reports whether a provider in this process registered a
+// search source for kind.
+func HasSearchSource(kind string) bool {
+	searchSourcesMu.RLock()
+	defer searchSourcesMu.RUnlock()
+	return searchSources[kind] != nil
+}
+
+func registeredSearchSources() SearchSources {
+	searchSourcesMu.RLock()
+	defer searchSourcesMu.RUnlock()
+	sources := make(SearchSources, len(searchSources))
+	for kind, fn := range searchSources {
+		sources[kind] = fn
+	}
+	return sources
+}
+
+// SearchSources maps ref kinds to their search sources, for callers that
+// hold their own providers instead of using the process-wide registry.
+type SearchSources map[string]SearchSource
+
+// Search ranks the documents of every source in ss (or of q.Kinds) the same
+// way as the package-level Search.
+func (ss SearchSources) Search(ctx context.Context, q SearchQuery) ([]SearchHit, error) {
+	if err := q.validate(); err != nil {
+		return nil, err
+	}
+	return RankSearch(ss.collect(ctx, q.Kinds), q)
+}
+
+func (ss SearchSources) collect(ctx context.Context, kinds []string) []SearchDoc {
+	var docs []SearchDoc
+	for _, fn := range ss.selected(kinds) {
+		docs = append(docs, fn(ctx)...)
+	}
+	return docs
+}
+
+// validate rejects empty queries and unknown kinds.
+func (q SearchQuery) validate() error {
+	if len(searchTerms(q.Query)) == 0 {
+		return orcherr.New("bad_request", "query is required", nil)
+	}
+	for _, kind := range q.Kinds {
+		if !refKinds[kind] {
+			return orcherr.New("bad_request", fmt.Sprintf("unknown kind %q", kind), nil)
+		}
+	}
+	return nil
+}
+
+// RankSearch ranks docs against q.Query by TF-IDF over docs as one corpus,
+// as Search does; docs of kinds outside q.Kinds, when set, are left out.
+func RankSearch(docs []SearchDoc, q SearchQuery) ([]SearchHit, error) {
+	if err := q.validate(); err != nil {
+		return nil, err
+	}
+	if len(q.Kinds) > 0 {
+		kept := make([]SearchDoc, 0, len(docs))
+		for _, doc := range docs {
+			if containsString(q.Kinds, doc.Kind) {
+				kept = append(kept, doc)
+			}
+		}
+		docs = kept
+	}
+	terms := searchTerms(q.Query)
+	limit := q.Limit
+	if limit <= 0 {
+		limit = defaultSearchLimit
+	}
+
+	type indexed struct {
+		doc    SearchDoc
+		counts map[string]int
+		length int
+	}
+	index := make([]indexed, 0, len(docs))
+	df := map[string]int{}
+	for _, doc := range docs {
+		counts := map[string]int{}
+		title := tokenizeSearch(doc.Title)
+		for _, term := range title {
+			counts[term] += searchTitleBoost
+		}
+		body := tokenizeSearch(doc.Body)
+		for _, term := range body {
+			counts[term]++
+		}
+		for term := range counts {
+			df[term]++
+		}
+		index = append(index, indexed{doc: doc, counts: counts, length: len(title)*searchTitleBoost + len(body)})
+	}
+
+	hits := []SearchHit{}
+	for _, entry := range index {
+		var score float64
+		var matched []string
+		for _, term := range terms {
+			tf := entry.counts[term]
+			if tf == 0 {
+				continue
+			}
+			idf := math.Log(1 + float64(len(index))/float64(df[term]))
+			score += (1 + math.Log(float64(tf))) * idf
+			matched = append(matched, term)
+		}
+		if len(matched) == 0 {
+			continue
+		}
+		// Damp long documents so a timeline-heavy incident does not win on
+		// length alone.
+		score /= math.Sqrt(math.Max(1, float64(entry.length)/20))
+		doc := entry.doc
+		hits = append(hits, SearchHit{
+			Ref:       FormatRef(doc.Kind, doc.ID),
+			Kind:      doc.Kind,
+			ID:        doc.ID,
+			Title:     doc.Title,
+			Service:   doc.Service,
+			Status:    doc.Status,
+			UpdatedAt: doc.UpdatedAt,
+			Snippet:   searchSnippet(doc.Body, matched),
+			Score:     math.Round(score*1000) / 1000,
+			Matched:   matched,
+		})
+	}
+
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		if !hits[i].UpdatedAt.Equal(hits[j].UpdatedAt) {
+			return hits[i].UpdatedAt.After(hits[j].UpdatedAt)
+		}
+		return hits[i].Ref < hits[j].Ref
+	})
+	if len(hits) > limit {
+		hits = hits[:limit]
+	}
+	return hits, nil
+}
+
+// selected returns the sources for kinds (all when empty), ordered by kind.
+func (ss SearchSources) selected(kinds []string) []SearchSource {
+	want := map[string]bool{}
+	for _, kind := range kinds {
+		want[kind] = true
+	}
+	names := make([]string, 0, len(ss))
+	for kind := range ss {
+		if len(want) == 0 || want[kind] {
+			names = append(names, kind)
+		}
+	}
+	sort.Strings(names)
+	out := make([]SearchSource, 0, len(names))
+	for _, kind := range names {
+		out = append(out, ss[kind])
+	}
+	return out
+}
+
+// searchTerms returns the distinct query terms in query order.
+func searchTerms(query string) []string {
+	seen := map[string]bool{}
+	var out []string
+	for _, term := range tokenizeSearch(query) {
+		if !seen[term] {
+			seen[term] = true
+			out = append(out, term)
+		}
+	}
+	return out
+}
+
+// tokenizeSearch lowercases text, splits it on anything but letters, digits,
+// and hyphens inside IDs, drops stopwords, and trims plural "s".
+func tokenizeSearch(text string) []string {
+	var out []string
+	for _, word := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '-'
+	}) {
+		word = strings.Trim(word, "-")
+		if len(word) < 2 || searchStopwords[word] {
+			continue
+		}
+		out = append(out, searchStem(word))
+	}
+	return out
+}
+
+func searchStem(word string) string {
+	if len(word) > 4 && strings.HasSuffix(word, "s") && !strings.HasSuffix(word, "ss") && !strings.HasSuffix(word, "is") {
+		return strings.TrimSuffix(word, "s")
+	}
+	return word
+}
+
+// searchSnippet cuts about searchSnippetLen characters of body around the
+// first occurrence of a matched term.
+func searchSnippet(body string, matched []string) string {
+	body = strings.Join(strings.Fields(body), " ")
+	if body == "" {
+		return ""
+	}
+	lower := strings.ToLower(body)
+	at := -1
+	for _, term := range matched {
+		if i := strings.Index(lower, term); i >= 0 && (at < 0 || i < at) {
+			at = i
+		}
+	}
+	start := 0
+	if at > searchSnippetLen/3 {
+		start = at - searchSnippetLen/3
+		if space := strings.IndexByte(body[start:], ' '); space >= 0 && space < at-start {
+			start += space + 1
+		}
+	}
+	end := start + searchSnippetLen
+	if end >= len(body) {
+		end = len(body)
+	} else if space := strings.LastIndexByte(body[start:end], ' '); space > 0 {
+		end = start + space
+	}
+	snippet := body[start:end]
+	if start > 0 {
+		snippet = "..." + snippet
+	}
+	if end < len(body) {
+		snippet += "..."
+	}
+	return snippet
+}
@@ -0,0 +1,51 @@
+package mockutil
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSearch(t *testing.T) {
+	now := time.Now().UTC()
+	RegisterSearchSource(RefAlert, func(ctx context.Context) []SearchDoc {
+		return []SearchDoc{
+			{Kind: RefAlert, ID: "al-1", Title: "Connection pool saturation", Body: "Pool at 92% on the orders database", Service: "svc-order", UpdatedAt: now},
+			{Kind: RefAlert, ID: "al-2", Title: "Disk filling up", Body: "Database volume at 85%", UpdatedAt: now},
+		}
+	})
+	RegisterSearchSource(RefIncident, func(ctx context.Context) []SearchDoc {
+		return []SearchDoc{
+			{Kind: RefIncident, ID: "inc-1", Title: "Checkout errors", Body: "Requests failing.\nRestarted the connection pools on svc-order", UpdatedAt: now.Add(-time.Hour)},
+		}
+	})
+
+	hits, err := Search(context.Background(), SearchQuery{Query: "connection pools"})
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if len(hits) != 2 || hits[0].Ref != "alert:al-1" || hits[1].Ref != "incident:inc-1" {
+		t.Fatalf("got %+v, want the title match before the timeline match", hits)
+	}
+	if strings.Join(hits[0].Matched, ",") != "connection,pool" || hits[0].Score <= hits[1].Score {
+		t.Errorf("unexpected top hit %+v", hits[0])
+	}
+	if !strings.Contains(hits[1].Snippet, "connection pools") {
+		t.Errorf("snippet %q does not show the match", hits[1].Snippet)
+	}
+
+	hits, err = Search(context.Background(), SearchQuery{Query: "database", Kinds: []string{RefIncident}})
+	if err != nil || len(hits) != 0 {
+		t.Errorf("got %d incident hits for database (%v), want none", len(hits), err)
+	}
+	if hits, _ := Search(context.Background(), SearchQuery{Query: "database", Limit: 1}); len(hits) != 1 {
+		t.Errorf("got %d hits with limit 1", len(hits))
+	}
+	if _, err := Search(context.Background(), SearchQuery{Query: "the"}); err == nil || !strings.Contains(err.Error(), "bad_request") {
+		t.Errorf("stopword-only query: %v, want bad_request", err)
+	}
+	if _, err := Search(context.Background(), SearchQuery{Query: "pool", Kinds: []string{"widget"}}); err == nil || !strings.Contains(err.Error(), "bad_request") {
+		t.Errorf("unknown kind: %v, want bad_request", err)
+	}
+}
@@ -0,0 +1,111 @@
+package mockutil
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// WarmupConfigKey is the provider config key that generates lazily seeded
+// collections up front instead of on first access.
+const WarmupConfigKey = "warmup"
+
+// ParseWarmup reports whether cfg asks for warmup (true or "true").
+func ParseWarmup(cfg map[string]any) bool {
+	switch v := cfg[WarmupConfigKey].(type) {
+	case bool:
+		return v
+	case string:
+		return strings.EqualFold(strings.TrimSpace(v), "true")
+	}
+	return false
+}
+
+// SeedStat records how long one provider collection took to generate.
+type SeedStat struct {
+	Provider   string `json:"provider"`
+	Collection string `json:"collection"`
+	Records    int    `json:"records"`
+	// Lazy is true when the collection was generated on first access rather
+	// than while the provider was constructed.
+	Lazy        bool      `json:"lazy"`
+	DurationMs  float64   `json:"durationMs"`
+	GeneratedAt time.Time `json:"generatedAt"`
+}
+
+var (
+	seedStatsMu sync.Mutex
+	seedStats   = map[string]SeedStat{}
+)
+
+// TimeSeed runs fn, which generates a collection and returns its record
+// count, and records how long it took. A later run for the same provider and
+// collection replaces the earlier stat.
+func TimeSeed(provider, collection string, lazy bool, fn func() int) {
+	start := time.Now()
+	n := fn()
+	stat := SeedStat{
+		Provider:    provider,
+		Collection:  collection,
+		Records:     n,
+		Lazy:        lazy,
+		DurationMs:  float64(time.Since(start).Microseconds()) / 1000,
+		GeneratedAt: start.UTC(),
+	}
+	seedStatsMu.Lock()
+	defer seedStatsMu.Unlock()
+	seedStats[provider+"/"+collection] = stat
+}
+
+// SeedStats returns the recorded seed timings ordered by provider and collection.
+func SeedStats() []SeedStat {
+	seedStatsMu.Lock()
+	defer seedStatsMu.Unlock()
+	out := make([]SeedStat, 0, len(seedStats))
+	for _, stat := range seedStats {
+		out = append(out, stat)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Provider != out[j].Provider {
+			return out[i].Provider < out[j].Provider
+		}
+		return out[i].Collection < out[j].Collection
+	})
+	return out
+}
+
+// LazySeed generates one provider collection the first time it is needed.
+// It does no locking of its own: callers hold whatever lock the generator
+// needs when they call Ensure.
+type LazySeed struct {
+	provider   string
+	collection string
+	fn         func() int
+	done       bool
+}
+
+// NewLazySeed returns a LazySeed that runs fn (which returns the number of
+// records generated) on the first Ensure.
+func NewLazySeed(provider, collection string, fn func() int) *LazySeed {
+	return &LazySeed{provider: provider, collection: collection, fn: fn}
+}
+
+// Ensure generates the collection on first access unless it already has been
+// or was skipped.
+func (s *LazySeed) Ensure() { s.run(true) }
+
+// Warm generates the collection now, for providers configured with warmup.
+func (s *LazySeed) Warm() { s.run(false) }
+
+func (s *LazySeed) run(lazy bool) {
+	if s.done {
+		return
+	}
+	s.done = true
+	TimeSeed(s.provider, s.collection, lazy, s.fn)
+}
+
+// Skip marks the collection as generated without generating it, e.g. when a
+// snapshot bundle has replaced the provider's records.
+func (s *LazySeed) Skip() { s.done = true }
@@ -0,0 +1,40 @@
+package mockutil
+
+// UnsetGroup is the group counted for results missing a grouping value.
+const UnsetGroup = "none"
+
+// Counts tallies a result set by status, severity, service, and team so
+// dashboard summary tiles do not need to page through the results.
+type Counts struct {
+	Total      int            `json:"total"`
+	ByStatus   map[string]int `json:"byStatus"`
+	BySeverity map[string]int `json:"bySeverity"`
+	ByService  map[string]int `json:"byService"`
+	ByTeam     map[string]int `json:"byTeam"`
+}
+
+// NewCounts returns empty Counts.
+func NewCounts() Counts {
+	return Counts{
+		ByStatus:   map[string]int{},
+		BySeverity: map[string]int{},
+		ByService:  map[string]int{},
+		ByTeam:     map[string]int{},
+	}
+}
+
+// Add counts one result. Empty values are counted under UnsetGroup.
+func (c *Counts) Add(status, severity, service, team string) {
+	c.Total++
+	c.ByStatus[groupKey(status)]++
+	c.BySeverity[groupKey(severity)]++
+	c.ByService[groupKey(service)]++
+	c.ByTeam[groupKey(team)]++
+}
+
+func groupKey(v string) string {
+	if v == "" {
+		return UnsetGroup
+	}
+	return v
+}
@@ -0,0 +1,23 @@
+package mockutil
+
+import "testing"
+
+func TestCountsAdd(t *testing.T) {
+	counts := NewCounts()
+	counts.Add("open", "sev1", "svc-checkout", "team-velocity")
+	counts.Add("open", "sev2", "svc-checkout", "")
+	counts.Add("resolved", "sev2", "", "team-aurora")
+
+	if counts.Total != 3 {
+		t.Fatalf("expected 3 results counted, got %d", counts.Total)
+	}
+	if counts.ByStatus["open"] != 2 || counts.ByStatus["resolved"] != 1 {
+		t.Fatalf("unexpected status counts: %v", counts.ByStatus)
+	}
+	if counts.BySeverity["sev2"] != 2 || counts.ByService["svc-checkout"] != 2 {
+		t.Fatalf("unexpected severity or service counts: %v %v", counts.BySeverity, counts.ByService)
+	}
+	if counts.ByService[UnsetGroup] != 1 || counts.ByTeam[UnsetGroup] != 1 {
+		t.Fatalf("expected missing values counted under %q: %v %v", UnsetGroup, counts.ByService, counts.ByTeam)
+	}
+}
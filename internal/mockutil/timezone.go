@@ -0,0 +1,52 @@
+package mockutil
+
+import "time"
+
+// Business hours used when aligning generated activity to a demo audience's timezone.
+const (
+	BusinessDayStartHour = 9
+	BusinessDayEndHour   = 18
+)
+
+// ParseLocation reads an IANA timezone name (e.g. "Europe/Berlin") from the
+// "timezone" config key. It returns nil when unset or unknown, which keeps
+// generated timestamps in their original UTC layout.
+func ParseLocation(cfg map[string]any) *time.Location {
+	name, ok := cfg["timezone"].(string)
+	if !ok || name == "" {
+		return nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil
+	}
+	return loc
+}
+
+// IsBusinessHour reports whether t falls on a weekday between 09:00 and 18:00 in loc.
+func IsBusinessHour(t time.Time, loc *time.Location) bool {
+	local := t.In(loc)
+	if local.Weekday() == time.Saturday || local.Weekday() == time.Sunday {
+		return false
+	}
+	return local.Hour() >= BusinessDayStartHour && local.Hour() < BusinessDayEndHour
+}
+
+// AlignToBusinessHours moves t backwards to the most recent business-hour
+// instant in loc, keeping the minute and second. Times already inside
+// business hours, or a nil loc, are returned unchanged. The result is in UTC.
+func AlignToBusinessHours(t time.Time, loc *time.Location) time.Time {
+	if loc == nil {
+		return t
+	}
+	local := t.In(loc)
+	for i := 0; i < 8 && !IsBusinessHour(local, loc); i++ {
+		if local.Hour() >= BusinessDayEndHour && local.Weekday() != time.Saturday && local.Weekday() != time.Sunday {
+			local = time.Date(local.Year(), local.Month(), local.Day(), BusinessDayEndHour-1, local.Minute(), local.Second(), 0, loc)
+			continue
+		}
+		prev := local.AddDate(0, 0, -1)
+		local = time.Date(prev.Year(), prev.Month(), prev.Day(), BusinessDayEndHour-1, local.Minute(), local.Second(), 0, loc)
+	}
+	return local.UTC()
+}
@@ -0,0 +1,61 @@
+package mockutil
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseLocation(t *testing.T) {
+	if loc := ParseLocation(map[string]any{"timezone": "Europe/Berlin"}); loc == nil || loc.String() != "Europe/Berlin" {
+		t.Fatalf("expected Europe/Berlin, got %v", loc)
+	}
+	if loc := ParseLocation(map[string]any{"timezone": "Not/AZone"}); loc != nil {
+		t.Fatalf("expected nil for unknown zone, got %v", loc)
+	}
+	if loc := ParseLocation(nil); loc != nil {
+		t.Fatalf("expected nil for missing config, got %v", loc)
+	}
+}
+
+func TestAlignToBusinessHours(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("timezone data unavailable: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		in   time.Time
+		want time.Time
+	}{
+		{
+			name: "inside business hours",
+			in:   time.Date(2024, 3, 5, 11, 20, 0, 0, berlin),
+			want: time.Date(2024, 3, 5, 11, 20, 0, 0, berlin),
+		},
+		{
+			name: "evening moves to end of same day",
+			in:   time.Date(2024, 3, 5, 22, 15, 0, 0, berlin),
+			want: time.Date(2024, 3, 5, 17, 15, 0, 0, berlin),
+		},
+		{
+			name: "early morning moves to previous day",
+			in:   time.Date(2024, 3, 5, 3, 40, 0, 0, berlin),
+			want: time.Date(2024, 3, 4, 17, 40, 0, 0, berlin),
+		},
+		{
+			name: "weekend moves to friday",
+			in:   time.Date(2024, 3, 10, 12, 5, 0, 0, berlin),
+			want: time.Date(2024, 3, 8, 17, 5, 0, 0, berlin),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := AlignToBusinessHours(tt.in.UTC(), berlin)
+			if !got.Equal(tt.want) {
+				t.Errorf("got %v, want %v", got.In(berlin), tt.want)
+			}
+		})
+	}
+}
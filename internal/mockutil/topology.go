@@ -0,0 +1,119 @@
+package mockutil
+
+import (
+	"hash/fnv"
+	"sort"
+)
+
+// serviceDependencyMap is the shared service topology: each service maps to the
+// services it calls. Providers use it to describe dependencies and to spread
+// failures from a degraded service to its callers.
+var serviceDependencyMap = map[string][]string{
+	"svc-checkout":       {"svc-payments", "svc-order", "svc-notifications", "svc-database"},
+	"svc-search":         {"svc-web", "svc-catalog", "svc-database"},
+	"svc-web":            {"svc-realtime"},
+	"svc-payments":       {"svc-identity"},
+	"svc-notifications":  {"svc-analytics"},
+	"svc-identity":       {"svc-web"},
+	"svc-warehouse":      {"svc-analytics"},
+	"svc-recommendation": {"svc-catalog", "svc-analytics"},
+	"svc-analytics":      {"svc-warehouse"},
+	"svc-order":          {"svc-checkout", "svc-payments"},
+	"svc-catalog":        {"svc-warehouse", "svc-database"},
+	"svc-shipping":       {"svc-order"},
+	"svc-realtime":       {"svc-notifications"},
+}
+
+// ServiceDependencies returns the services a service calls, or nil when unknown.
+func ServiceDependencies(service string) []string {
+	return CloneStringSlice(serviceDependencyMap[service])
+}
+
+// ServiceDependents returns the services that call the given service, sorted by ID.
+func ServiceDependents(service string) []string {
+	var out []string
+	for caller, deps := range serviceDependencyMap {
+		for _, dep := range deps {
+			if dep == service {
+				out = append(out, caller)
+				break
+			}
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// ServiceEdge is one caller-to-callee call path in the topology.
+type ServiceEdge struct {
+	Source      string `json:"source"`
+	Destination string `json:"destination"`
+}
+
+// ServiceEdges returns every call path in the topology, sorted by source then
+// destination.
+func ServiceEdges() []ServiceEdge {
+	var out []ServiceEdge
+	for caller, deps := range serviceDependencyMap {
+		for _, dep := range deps {
+			out = append(out, ServiceEdge{Source: caller, Destination: dep})
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Source != out[j].Source {
+			return out[i].Source < out[j].Source
+		}
+		return out[i].Destination < out[j].Destination
+	})
+	return out
+}
+
+// Regions lists the cloud regions services are deployed to.
+var Regions = []string{"us-east-1", "us-west-2", "eu-west-1"}
+
+// serviceRegionOverrides pins services whose scenarios depend on placement.
+var serviceRegionOverrides = map[string]string{
+	// The autoscaling-lag scenario plays out in the us-west-2 cluster.
+	"svc-search": "us-west-2",
+	// The primary database failover alert fires in us-east-1.
+	"svc-database": "us-east-1",
+}
+
+// ServiceRegion returns the region a service runs in, derived from a hash of the
+// service ID so every provider places it the same way.
+func ServiceRegion(service string) string {
+	if region, ok := serviceRegionOverrides[service]; ok {
+		return region
+	}
+	h := fnv.New32a()
+	h.Write([]byte(service))
+	return Regions[int(h.Sum32()>>11)%len(Regions)]
+}
+
+// serviceTiers rank how directly a service carries revenue and sign-in
+// traffic. Services not listed are tier 3.
+var serviceTiers = map[string]int{
+	"svc-checkout":      1,
+	"svc-payments":      1,
+	"svc-order":         1,
+	"svc-identity":      1,
+	"svc-web":           1,
+	"svc-database":      1,
+	"svc-api-gateway":   1,
+	"svc-search":        2,
+	"svc-catalog":       2,
+	"svc-realtime":      2,
+	"svc-notifications": 2,
+	"svc-shipping":      2,
+	"svc-cache":         2,
+	"svc-ingress":       2,
+	"svc-dns":           2,
+}
+
+// ServiceTier returns a service's tier, 1 (most critical) to 3.
+func ServiceTier(service string) int {
+	if tier, ok := serviceTiers[service]; ok {
+		return tier
+	}
+	return 3
+}
@@ -0,0 +1,26 @@
+package mockutil
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+)
+
+// TraceID derives a stable W3C-format trace ID (32 hex chars) for a request to
+// service at ts, so metric exemplars, logs, and a trace provider can agree on IDs.
+func TraceID(service string, ts time.Time) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("trace|%s|%d", service, ts.UnixNano())))
+	return hex.EncodeToString(sum[:16])
+}
+
+// SpanID derives the root span ID (16 hex chars) for a trace ID.
+func SpanID(traceID string) string {
+	sum := sha256.Sum256([]byte("span|" + traceID))
+	return hex.EncodeToString(sum[:8])
+}
+
+// TraceURL links a trace ID to the demo trace explorer.
+func TraceURL(traceID string) string {
+	return fmt.Sprintf("https://grafana.demo.com/explore?traceId=%s", traceID)
+}
@@ -0,0 +1,178 @@
+package pluginrpc
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// SchemaVersionConfigKey is the plugin config key naming the opsorch-core
+// schema version the host speaks. Requests are upgraded to the current schema
+// before reaching the handler and results are downgraded on the way out, so
+// one plugin build can be tested against several core releases.
+const SchemaVersionConfigKey = "schemaVersion"
+
+// Schema versions understood at the plugin boundary.
+const (
+	// SchemaV1 is the schema of core releases before scoped queries: query
+	// payloads carry service, team, and environment at the top level, records
+	// keep everything in metadata, and timeline entries use "timestamp".
+	SchemaV1 = "v1"
+	// SchemaV2 is the current schema the providers implement.
+	SchemaV2 = "v2"
+)
+
+// CurrentSchemaVersion is the version used when the config does not set one.
+const CurrentSchemaVersion = SchemaV2
+
+// ErrCodeUnsupportedSchema is returned when the config names an unknown version.
+const ErrCodeUnsupportedSchema = "unsupported_schema_version"
+
+// schemaShim translates one older schema version to and from the current one.
+type schemaShim struct {
+	upgradeRequest  func(method string, payload map[string]any)
+	downgradeResult func(method string, result any) any
+}
+
+var schemaShims = map[string]schemaShim{
+	SchemaV1: {upgradeRequest: upgradeV1Request, downgradeResult: downgradeV1Result},
+}
+
+// schemaShimFor returns the shim for the configured version, or nil when the
+// host already speaks the current schema.
+func schemaShimFor(config map[string]any) (*schemaShim, *errorValue) {
+	raw, ok := config[SchemaVersionConfigKey]
+	if !ok || raw == nil {
+		return nil, nil
+	}
+	version := strings.ToLower(strings.TrimSpace(fmt.Sprint(raw)))
+	if version != "" && !strings.HasPrefix(version, "v") {
+		version = "v" + version
+	}
+	if version == "" || version == CurrentSchemaVersion {
+		return nil, nil
+	}
+	shim, ok := schemaShims[version]
+	if !ok {
+		return nil, &errorValue{Code: ErrCodeUnsupportedSchema, Message: fmt.Sprintf("schema version %v is not supported; use %s or %s", raw, SchemaV1, SchemaV2)}
+	}
+	return &shim, nil
+}
+
+// upgrade rewrites req.Payload into the current schema. Payloads that are not
+// JSON objects pass through untouched for the handler to reject.
+func (s *schemaShim) upgrade(req Request) Request {
+	var payload map[string]any
+	if len(req.Payload) == 0 || json.Unmarshal(req.Payload, &payload) != nil || payload == nil {
+		return req
+	}
+	s.upgradeRequest(req.Method, payload)
+	if raw, err := json.Marshal(payload); err == nil {
+		req.Payload = raw
+	}
+	return req
+}
+
+// downgrade rewrites a handler result into the older schema by round-tripping
+// it through generic JSON values.
+func (s *schemaShim) downgrade(method string, res any) any {
+	if res == nil {
+		return nil
+	}
+	raw, err := json.Marshal(res)
+	if err != nil {
+		return res
+	}
+	var generic any
+	if err := json.Unmarshal(raw, &generic); err != nil {
+		return res
+	}
+	return s.downgradeResult(method, generic)
+}
+
+// scopeKeys are the v1 top-level query filters that moved under "scope".
+var scopeKeys = []string{"service", "team", "environment"}
+
+func upgradeV1Request(method string, payload map[string]any) {
+	if strings.HasSuffix(method, ".query") {
+		scope, _ := payload["scope"].(map[string]any)
+		for _, key := range scopeKeys {
+			v, ok := payload[key]
+			if !ok {
+				continue
+			}
+			if scope == nil {
+				scope = map[string]any{}
+			}
+			if _, set := scope[key]; !set {
+				scope[key] = v
+			}
+			delete(payload, key)
+		}
+		if scope != nil {
+			payload["scope"] = scope
+		}
+	}
+	if method == "incident.timeline.append" {
+		if entry, ok := payload["entry"].(map[string]any); ok {
+			renameKey(entry, "timestamp", "at")
+		}
+	}
+}
+
+func downgradeV1Result(method string, result any) any {
+	switch method {
+	case "incident.timeline.get":
+		if entries, ok := result.([]any); ok {
+			for _, e := range entries {
+				if entry, ok := e.(map[string]any); ok {
+					renameKey(entry, "at", "timestamp")
+				}
+			}
+		}
+		return result
+	}
+	switch v := result.(type) {
+	case []any:
+		for _, item := range v {
+			if record, ok := item.(map[string]any); ok {
+				foldFieldsIntoMetadata(record)
+			}
+		}
+	case map[string]any:
+		foldFieldsIntoMetadata(v)
+	}
+	return result
+}
+
+// foldFieldsIntoMetadata merges a record's "fields" into "metadata", which v1
+// used for both. Metadata wins on key collisions.
+func foldFieldsIntoMetadata(record map[string]any) {
+	if _, isRecord := record["id"]; !isRecord {
+		return
+	}
+	fields, ok := record["fields"].(map[string]any)
+	if !ok {
+		return
+	}
+	delete(record, "fields")
+	metadata, _ := record["metadata"].(map[string]any)
+	if metadata == nil {
+		metadata = make(map[string]any, len(fields))
+	}
+	for k, v := range fields {
+		if _, exists := metadata[k]; !exists {
+			metadata[k] = v
+		}
+	}
+	record["metadata"] = metadata
+}
+
+func renameKey(m map[string]any, from, to string) {
+	if v, ok := m[from]; ok {
+		if _, exists := m[to]; !exists {
+			m[to] = v
+		}
+		delete(m, from)
+	}
+}
@@ -0,0 +1,180 @@
+package pluginrpc
+
+import (
+	"encoding/json"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/opsorch/opsorch-core/orcherr"
+)
+
+// ControlDirEnvVar names a directory in which a plugin also serves requests on
+// a unix socket, <dir>/<executable name>.sock, alongside stdin. Tools such as
+// mockexport use it to reach plugins a host has already started. Control
+// requests pass the same token check, rate limits, and payload validation, and
+// run concurrently with the host's, so handlers must be safe for that. They
+// are refused with ErrCodeUnavailable until the host's first request on stdin
+// has been handled, so the plugin is always configured by its host, and go
+// unanswered once a simulated hang engages.
+const ControlDirEnvVar = "OPSORCH_PLUGIN_CONTROL_DIR"
+
+// ControlSocket returns the control socket path of the plugin binary named
+// name, such as "alertplugin", under dir.
+func ControlSocket(dir, name string) string {
+	return filepath.Join(dir, name+".sock")
+}
+
+func controlPathFromEnv() string {
+	dir := os.Getenv(ControlDirEnvVar)
+	if dir == "" {
+		return ""
+	}
+	return ControlSocket(dir, filepath.Base(os.Args[0]))
+}
+
+// controlServer answers requests on a control socket, in order per connection.
+type controlServer struct {
+	ln      net.Listener
+	mu      sync.Mutex
+	conns   map[net.Conn]bool
+	handled sync.WaitGroup
+}
+
+// listenControl starts serving dispatch on a unix socket at path, replacing a
+// stale socket left by an earlier process. Requests dispatch reports false
+// for are left unanswered.
+func listenControl(path string, dispatch func(Request) (Response, bool)) (*controlServer, error) {
+	_ = os.Remove(path)
+	ln, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+	s := &controlServer{ln: ln, conns: map[net.Conn]bool{}}
+	// The accept loop holds a count until the listener closes, so every
+	// connection is added to handled before wait can return.
+	s.handled.Add(1)
+	go func() {
+		defer s.handled.Done()
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			s.mu.Lock()
+			s.conns[conn] = true
+			s.mu.Unlock()
+			s.handled.Add(1)
+			go s.serveConn(conn, dispatch)
+		}
+	}()
+	return s, nil
+}
+
+func (s *controlServer) serveConn(conn net.Conn, dispatch func(Request) (Response, bool)) {
+	defer s.handled.Done()
+	defer func() {
+		s.mu.Lock()
+		delete(s.conns, conn)
+		s.mu.Unlock()
+		conn.Close()
+	}()
+	dec := json.NewDecoder(conn)
+	enc := json.NewEncoder(conn)
+	for {
+		var req Request
+		if err := dec.Decode(&req); err != nil {
+			return
+		}
+		resp, answer := dispatch(req)
+		if !answer {
+			continue
+		}
+		if err := enc.Encode(resp); err != nil {
+			return
+		}
+	}
+}
+
+// close stops accepting connections and requests. Requests already being
+// handled still run; wait blocks until they finish.
+func (s *controlServer) close() {
+	if s == nil {
+		return
+	}
+	s.ln.Close()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for conn := range s.conns {
+		conn.Close()
+	}
+}
+
+func (s *controlServer) wait() {
+	if s != nil {
+		s.handled.Wait()
+	}
+}
+
+// Client sends requests to a running plugin over its control socket, one at
+// a time.
+type Client struct {
+	mu    sync.Mutex
+	conn  net.Conn
+	enc   *json.Encoder
+	dec   *json.Decoder
+	token string
+}
+
+// Dial connects to the control socket at path. Requests carry the token from
+// TokenEnvVar, as a host's would.
+func Dial(path string) (*Client, error) {
+	conn, err := net.Dial("unix", path)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{conn: conn, enc: json.NewEncoder(conn), dec: json.NewDecoder(conn), token: os.Getenv(TokenEnvVar)}, nil
+}
+
+// Call sends method with config and payload, and decodes the result into out,
+// which may be nil. A plugin error with a code comes back as an
+// orcherr.OpsOrchError.
+func (c *Client) Call(method string, config map[string]any, payload, out any) error {
+	raw := json.RawMessage("{}")
+	if payload != nil {
+		var err error
+		if raw, err = json.Marshal(payload); err != nil {
+			return err
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.enc.Encode(Request{Method: method, Config: config, Payload: raw, Token: c.token}); err != nil {
+		return err
+	}
+	var resp struct {
+		Result json.RawMessage `json:"result"`
+		Error  *errorValue     `json:"error"`
+	}
+	if err := c.dec.Decode(&resp); err != nil {
+		return err
+	}
+	if resp.Error != nil {
+		if resp.Error.Code == "" {
+			return errors.New(resp.Error.Message)
+		}
+		return orcherr.New(resp.Error.Code, resp.Error.Message, nil)
+	}
+	if out == nil || len(resp.Result) == 0 {
+		return nil
+	}
+	return json.Unmarshal(resp.Result, out)
+}
+
+// Close closes the connection.
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
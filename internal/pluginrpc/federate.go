@@ -0,0 +1,165 @@
+package pluginrpc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/opsorch/opsorch-core/orcherr"
+	"github.com/opsorch/opsorch-mock-adapters/internal/mockutil"
+)
+
+// ErrCodeUnavailable is returned for requests that need a sibling plugin
+// which cannot be reached over its control socket.
+const ErrCodeUnavailable = "unavailable"
+
+// refPlugins names the plugin binary that owns each ref kind.
+var refPlugins = map[string]string{
+	mockutil.RefAlert:      "alertplugin",
+	mockutil.RefIncident:   "incidentplugin",
+	mockutil.RefTicket:     "ticketplugin",
+	mockutil.RefDeployment: "deploymentplugin",
+	mockutil.RefTeam:       "teamplugin",
+	mockutil.RefPlan:       "orchestrationplugin",
+	mockutil.RefRun:        "orchestrationplugin",
+}
+
+// searchKinds are the ref kinds with searchable documents.
+var searchKinds = []string{mockutil.RefAlert, mockutil.RefDeployment, mockutil.RefIncident, mockutil.RefPlan, mockutil.RefTeam, mockutil.RefTicket}
+
+// refRequest is the payload of ref.resolve. Forwarded marks a request one
+// plugin passed to another, which answers from its own providers only so
+// requests never bounce between plugins.
+type refRequest struct {
+	Ref       string `json:"ref"`
+	Forwarded bool   `json:"forwarded,omitempty"`
+}
+
+// ResolveRef answers ref.resolve. Refs of kinds a provider in this process
+// registered resolve here; the rest are forwarded to the plugin owning the
+// kind over its control socket (see ControlDirEnvVar), so a ref resolves
+// whichever plugin it is sent to. When the owner cannot be reached the
+// request fails with ErrCodeUnavailable rather than not_found.
+func ResolveRef(ctx context.Context, payload json.RawMessage) (any, error) {
+	var in refRequest
+	if err := json.Unmarshal(payload, &in); err != nil {
+		return nil, err
+	}
+	ref, err := mockutil.ParseRef(in.Ref)
+	if err != nil {
+		return nil, err
+	}
+	if in.Forwarded || mockutil.HasResolver(ref.Kind) {
+		return mockutil.ResolveRef(ctx, in.Ref)
+	}
+	var out mockutil.ResolvedRef
+	if err := callSibling(refPlugins[ref.Kind], "ref.resolve", refRequest{Ref: in.Ref, Forwarded: true}, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// docsRequest is the payload of search.docs.
+type docsRequest struct {
+	Kinds []string `json:"kinds,omitempty"`
+}
+
+// Search answers search.global over the documents of every plugin as one
+// corpus: the kinds registered here are ranked together with the documents
+// their owning plugins return for search.docs over their control sockets, so
+// TF-IDF scores, and so rankings, are the same whichever plugin is asked.
+// Kinds named in the query whose owner cannot be reached fail with
+// ErrCodeUnavailable; a query without kinds searches the plugins reachable.
+func Search(ctx context.Context, payload json.RawMessage) (any, error) {
+	var q mockutil.SearchQuery
+	if err := json.Unmarshal(payload, &q); err != nil {
+		return nil, err
+	}
+	if _, err := mockutil.RankSearch(nil, q); err != nil {
+		return nil, err
+	}
+	kinds := q.Kinds
+	if len(kinds) == 0 {
+		kinds = searchKinds
+	}
+	docs := localSearchDocs(ctx, kinds)
+	remote := map[string][]string{}
+	for _, kind := range kinds {
+		if name := refPlugins[kind]; containsKind(searchKinds, kind) && !mockutil.HasSearchSource(kind) {
+			remote[name] = append(remote[name], kind)
+		}
+	}
+	names := make([]string, 0, len(remote))
+	for name := range remote {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		var got []mockutil.SearchDoc
+		if err := callSibling(name, "search.docs", docsRequest{Kinds: remote[name]}, &got); err != nil {
+			if len(q.Kinds) > 0 {
+				return nil, err
+			}
+			continue
+		}
+		docs = append(docs, got...)
+	}
+	return mockutil.RankSearch(docs, q)
+}
+
+// SearchDocs answers search.docs with the documents of this process's own
+// providers for the requested kinds, for another plugin's Search to rank.
+func SearchDocs(ctx context.Context, payload json.RawMessage) (any, error) {
+	var in docsRequest
+	if len(payload) > 0 {
+		if err := json.Unmarshal(payload, &in); err != nil {
+			return nil, err
+		}
+	}
+	kinds := in.Kinds
+	if len(kinds) == 0 {
+		kinds = searchKinds
+	}
+	return localSearchDocs(ctx, kinds), nil
+}
+
+// localSearchDocs returns the documents of the kinds among kinds with a
+// search source in this process.
+func localSearchDocs(ctx context.Context, kinds []string) []mockutil.SearchDoc {
+	local := make([]string, 0, len(kinds))
+	for _, kind := range kinds {
+		if mockutil.HasSearchSource(kind) {
+			local = append(local, kind)
+		}
+	}
+	if len(local) == 0 {
+		return []mockutil.SearchDoc{}
+	}
+	return mockutil.CollectSearchDocs(ctx, local)
+}
+
+func containsKind(kinds []string, want string) bool {
+	for _, kind := range kinds {
+		if kind == want {
+			return true
+		}
+	}
+	return false
+}
+
+// callSibling sends method to the plugin binary named name over its control
+// socket, failing with ErrCodeUnavailable when it cannot be reached.
+func callSibling(name, method string, payload, out any) error {
+	dir := os.Getenv(ControlDirEnvVar)
+	if dir == "" {
+		return orcherr.New(ErrCodeUnavailable, fmt.Sprintf("%s is served by %s; set %s to reach it", method, name, ControlDirEnvVar), nil)
+	}
+	client, err := Dial(ControlSocket(dir, name))
+	if err != nil {
+		return orcherr.New(ErrCodeUnavailable, fmt.Sprintf("%s is not reachable: %v", name, err), nil)
+	}
+	defer client.Close()
+	return client.Call(method, nil, payload, out)
+}
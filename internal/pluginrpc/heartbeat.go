@@ -0,0 +1,131 @@
+package pluginrpc
+
+import (
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// HeartbeatEnvVar sets how often a plugin writes heartbeat frames, as a Go
+// duration such as "5s". Heartbeats are off when it is unset or invalid.
+const HeartbeatEnvVar = "OPSORCH_PLUGIN_HEARTBEAT"
+
+// MethodPing is answered by every plugin, without reaching its handler, with a
+// PingResult. It is exempt from rate limits so a busy plugin still answers.
+const MethodPing = "plugin.ping"
+
+// HangConfigKey is the plugin config key that simulates a hung plugin for
+// resilience testing. Set it to true to hang on the first request, or to
+// {"afterRequests": 3} to hang after answering three. A hung plugin keeps
+// reading stdin but writes no responses or heartbeats until it is signalled.
+const HangConfigKey = "simulateHang"
+
+// Heartbeat is written to stdout, as the heartbeat of a response without an
+// ID, every heartbeat interval while the plugin is serving.
+type Heartbeat struct {
+	Type string `json:"type"`
+	// Seq counts heartbeats from 1, so a host can spot dropped frames.
+	Seq      int64     `json:"seq"`
+	At       time.Time `json:"at"`
+	UptimeMs int64     `json:"uptimeMs"`
+	// InFlight is how many requests are running; a count that never drops
+	// points at a stuck handler rather than a dead process.
+	InFlight int64 `json:"inFlight"`
+}
+
+// PingResult is the result of MethodPing.
+type PingResult struct {
+	Pong     bool      `json:"pong"`
+	At       time.Time `json:"at"`
+	UptimeMs int64     `json:"uptimeMs"`
+	// InFlight is how many other requests are running.
+	InFlight int64 `json:"inFlight"`
+}
+
+func heartbeatFromEnv() time.Duration {
+	if d, err := time.ParseDuration(os.Getenv(HeartbeatEnvVar)); err == nil && d > 0 {
+		return d
+	}
+	return 0
+}
+
+// startHeartbeat writes a heartbeat every interval until the returned stop
+// function is called. Stop waits for the writer, so no heartbeat follows it.
+func startHeartbeat(interval time.Duration, started time.Time, inFlight *atomic.Int64, write func(Response)) (stop func()) {
+	if interval <= 0 {
+		return func() {}
+	}
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		var seq int64
+		for {
+			select {
+			case <-done:
+				return
+			case now := <-ticker.C:
+				seq++
+				write(Response{Heartbeat: &Heartbeat{
+					Type:     "heartbeat",
+					Seq:      seq,
+					At:       now.UTC(),
+					UptimeMs: now.Sub(started).Milliseconds(),
+					InFlight: inFlight.Load(),
+				}})
+			}
+		}
+	}()
+	return func() {
+		close(done)
+		wg.Wait()
+	}
+}
+
+// hangSim tracks the simulated hang, configured lazily from the first
+// request's config the same way rateLimits is.
+type hangSim struct {
+	once sync.Once
+	// after is how many requests are answered before hanging, or -1 when the
+	// simulation is off.
+	after  int64
+	served atomic.Int64
+	hung   atomic.Bool
+}
+
+// engaged configures the simulation on first use and reports whether the
+// plugin has stopped responding.
+func (h *hangSim) engaged(config map[string]any) bool {
+	h.once.Do(func() {
+		h.after = parseHang(config[HangConfigKey])
+		if h.after == 0 {
+			h.hung.Store(true)
+		}
+	})
+	return h.hung.Load()
+}
+
+// answered counts a written response and hangs once the limit is reached.
+func (h *hangSim) answered() {
+	if h.after > 0 && h.served.Add(1) >= h.after {
+		h.hung.Store(true)
+	}
+}
+
+func parseHang(setting any) int64 {
+	switch v := setting.(type) {
+	case bool:
+		if v {
+			return 0
+		}
+	case map[string]any:
+		if n, ok := v["afterRequests"].(float64); ok && n >= 0 {
+			return int64(n)
+		}
+	}
+	return -1
+}
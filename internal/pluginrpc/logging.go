@@ -0,0 +1,169 @@
+package pluginrpc
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// LogConfigKey is the plugin config key that enables request logging. Set it to
+// true or "stderr" to log to stderr, or to a file path to append to that file.
+const LogConfigKey = "requestLog"
+
+// Redacted replaces sensitive values in request logs.
+const Redacted = "[REDACTED]"
+
+// Redactor scrubs a decoded request payload before it is logged. It receives the
+// payload after the built-in sensitive-key redaction and returns what to log.
+type Redactor func(method string, payload any) any
+
+// Option customizes Run.
+type Option func(*serverConfig)
+
+// WithRedactor adds plugin-specific redaction to request logs.
+func WithRedactor(r Redactor) Option {
+	return func(cfg *serverConfig) {
+		cfg.redactor = r
+	}
+}
+
+// sensitiveKeyParts mark payload keys whose values are always redacted.
+var sensitiveKeyParts = []string{"password", "secret", "token", "apikey", "api_key", "authorization", "credential"}
+
+type logEntry struct {
+	Time       time.Time       `json:"time"`
+	Method     string          `json:"method"`
+	ID         json.RawMessage `json:"id,omitempty"`
+	DurationMs float64         `json:"durationMs"`
+	Outcome    string          `json:"outcome"`
+	ErrorCode  string          `json:"errorCode,omitempty"`
+	Error      string          `json:"error,omitempty"`
+	Encoding   string          `json:"encoding,omitempty"`
+	Payload    any             `json:"payload,omitempty"`
+}
+
+type requestLogger struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+	// file is the log file when logging to a path, closed on shutdown.
+	file *os.File
+}
+
+// requestLogs lazily builds the logger from the first request's config, which is
+// when plugins first see their configuration.
+type requestLogs struct {
+	once   sync.Once
+	logger *requestLogger
+}
+
+func (l *requestLogs) get(config map[string]any) *requestLogger {
+	l.once.Do(func() {
+		if w := logOutput(config[LogConfigKey]); w != nil {
+			l.logger = &requestLogger{enc: json.NewEncoder(w)}
+			if f, ok := w.(*os.File); ok && f != os.Stderr {
+				l.logger.file = f
+			}
+		}
+	})
+	return l.logger
+}
+
+// close syncs and closes a request log file so no entries are lost on exit.
+func (l *requestLogs) close() {
+	l.once.Do(func() {})
+	if l.logger == nil || l.logger.file == nil {
+		return
+	}
+	l.logger.mu.Lock()
+	defer l.logger.mu.Unlock()
+	_ = l.logger.file.Sync()
+	_ = l.logger.file.Close()
+}
+
+func logOutput(setting any) io.Writer {
+	switch v := setting.(type) {
+	case bool:
+		if v {
+			return os.Stderr
+		}
+	case string:
+		switch strings.TrimSpace(v) {
+		case "", "false", "off":
+			return nil
+		case "true", "stderr":
+			return os.Stderr
+		default:
+			f, err := os.OpenFile(v, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+			if err != nil {
+				return os.Stderr
+			}
+			return f
+		}
+	}
+	return nil
+}
+
+func (l *requestLogger) log(req Request, resp Response, elapsed time.Duration, redactor Redactor) {
+	entry := logEntry{
+		Time:       time.Now().UTC(),
+		Method:     req.Method,
+		ID:         req.ID,
+		DurationMs: float64(elapsed.Microseconds()) / 1000,
+		Outcome:    "ok",
+		Encoding:   resp.Encoding,
+	}
+	if resp.Error != nil {
+		entry.Outcome = "error"
+		entry.ErrorCode = resp.Error.Code
+		entry.Error = resp.Error.Message
+	}
+	if len(req.Payload) > 0 {
+		var payload any
+		if err := json.Unmarshal(req.Payload, &payload); err == nil {
+			payload = redactSensitive(payload)
+			if redactor != nil {
+				payload = redactor(req.Method, payload)
+			}
+			entry.Payload = payload
+		}
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	_ = l.enc.Encode(entry)
+}
+
+// redactSensitive walks a decoded JSON value and masks values under sensitive keys.
+func redactSensitive(v any) any {
+	switch val := v.(type) {
+	case map[string]any:
+		for k, inner := range val {
+			if isSensitiveKey(k) {
+				val[k] = Redacted
+				continue
+			}
+			val[k] = redactSensitive(inner)
+		}
+		return val
+	case []any:
+		for i := range val {
+			val[i] = redactSensitive(val[i])
+		}
+		return val
+	default:
+		return v
+	}
+}
+
+func isSensitiveKey(key string) bool {
+	lower := strings.ToLower(key)
+	for _, part := range sensitiveKeyParts {
+		if strings.Contains(lower, part) {
+			return true
+		}
+	}
+	return false
+}
@@ -1,54 +1,373 @@
 package pluginrpc
 
 import (
+	"bytes"
+	"compress/gzip"
+	"crypto/subtle"
 	"encoding/json"
 	"errors"
 	"io"
 	"os"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/opsorch/opsorch-core/orcherr"
+	"github.com/opsorch/opsorch-mock-adapters/internal/mockutil"
 )
 
+// EncodingGzip is the only compression scheme currently negotiated.
+const EncodingGzip = "gzip"
+
+// DefaultCompressionThreshold is the minimum encoded result size, in bytes,
+// compressed when a request opts in without its own threshold.
+const DefaultCompressionThreshold = 16 * 1024
+
+// TokenEnvVar names the environment variable holding the optional shared secret.
+// When it is set, every request must carry a matching Token or it is rejected
+// with an auth_failed error.
+const TokenEnvVar = "OPSORCH_PLUGIN_TOKEN"
+
+// ErrCodeAuthFailed is the error code returned for missing or mismatched tokens.
+const ErrCodeAuthFailed = "auth_failed"
+
+// WorkersEnvVar sets how many requests a plugin handles concurrently. The
+// default of 1 processes requests sequentially in arrival order; with more
+// workers responses may arrive out of order and hosts should correlate them by ID.
+const WorkersEnvVar = "OPSORCH_PLUGIN_WORKERS"
+
+// MethodSeedStats is answered by every plugin, without reaching its handler,
+// with the mockutil.SeedStats recorded in the process so far.
+const MethodSeedStats = "plugin.seedStats"
+
 // Request mirrors the JSON payload OpsOrch sends to plugins.
 type Request struct {
+	// ID is echoed on the matching Response so concurrent results can be correlated.
+	ID      json.RawMessage `json:"id,omitempty"`
 	Method  string          `json:"method"`
 	Config  map[string]any  `json:"config"`
 	Payload json.RawMessage `json:"payload"`
+	// Token is the shared secret checked against TokenEnvVar when configured.
+	Token string `json:"token,omitempty"`
+	// Compression opts into compressed results ("gzip"). Results smaller than
+	// CompressionThreshold bytes are still sent as plain JSON.
+	Compression          string `json:"compression,omitempty"`
+	CompressionThreshold int    `json:"compressionThreshold,omitempty"`
 }
 
-// Response is emitted for every request.
+// Response is emitted for every request. Compressed responses leave Result empty
+// and carry the gzipped JSON result in Data (base64 on the wire) with Encoding set.
 type Response struct {
-	Result any         `json:"result,omitempty"`
-	Error  *errorValue `json:"error,omitempty"`
+	ID       json.RawMessage `json:"id,omitempty"`
+	Result   any             `json:"result,omitempty"`
+	Encoding string          `json:"encoding,omitempty"`
+	Data     []byte          `json:"data,omitempty"`
+	Error    *errorValue     `json:"error,omitempty"`
+	// Event is set only on the final line a plugin writes before exiting on a signal.
+	Event *ShutdownEvent `json:"event,omitempty"`
+	// Heartbeat is set on the periodic liveness frames enabled by HeartbeatEnvVar.
+	Heartbeat *Heartbeat `json:"heartbeat,omitempty"`
 }
 
 type errorValue struct {
 	Code    string `json:"code,omitempty"`
 	Message string `json:"message"`
+	// Status and RetryAfterMs are set on rate_limited errors so hosts can
+	// treat them like an HTTP 429 with a Retry-After header.
+	Status       int   `json:"status,omitempty"`
+	RetryAfterMs int64 `json:"retryAfterMs,omitempty"`
+	// Fields lists the offending fields of an invalid_payload error.
+	Fields []FieldError `json:"fields,omitempty"`
+	// Details is the JSON form of the error an OpsOrchError wraps, such as a
+	// policy violation, when it encodes to more than an empty object.
+	Details json.RawMessage `json:"details,omitempty"`
 }
 
 // Run decodes requests from stdin, dispatches to handler, and writes responses to stdout.
-func Run(handler func(Request) (any, error)) {
-	dec := json.NewDecoder(os.Stdin)
-	enc := json.NewEncoder(os.Stdout)
+// Handlers must be safe for concurrent use when more than one worker is configured.
+//
+// On SIGTERM or SIGINT the plugin stops reading, waits up to the drain timeout
+// for in-flight requests, runs the shutdown hooks if they finished, writes a
+// ShutdownEvent, and exits with one of the ExitCode constants.
+func Run(handler func(Request) (any, error), opts ...Option) {
+	cfg := configFromEnv()
+	cfg.stop = shutdownSignals()
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if code, signalled := serve(os.Stdin, os.Stdout, cfg, handler); signalled {
+		os.Exit(code)
+	}
+}
 
-	for {
-		var req Request
-		if err := dec.Decode(&req); err != nil {
-			if errors.Is(err, io.EOF) {
-				return
-			}
-			_ = enc.Encode(Response{Error: toErrorValue(err)})
+type serverConfig struct {
+	token        string
+	workers      int
+	redactor     Redactor
+	hooks        []func() error
+	stop         <-chan os.Signal
+	drainTimeout time.Duration
+	heartbeat    time.Duration
+	// controlPath is the control socket to listen on, if any.
+	controlPath string
+	// payloadSchemas adds payload types to corePayloadSchemas by method.
+	payloadSchemas map[string][]any
+}
+
+func configFromEnv() serverConfig {
+	cfg := serverConfig{token: os.Getenv(TokenEnvVar), workers: 1, drainTimeout: drainTimeoutFromEnv(), heartbeat: heartbeatFromEnv(), controlPath: controlPathFromEnv()}
+	if n, err := strconv.Atoi(os.Getenv(WorkersEnvVar)); err == nil && n > 1 {
+		cfg.workers = n
+	}
+	return cfg
+}
+
+// serve handles requests until r is exhausted or cfg.stop fires. It reports
+// the exit code and whether the shutdown was signalled.
+func serve(r io.Reader, w io.Writer, cfg serverConfig, handler func(Request) (any, error)) (int, bool) {
+	dec := json.NewDecoder(r)
+	enc := json.NewEncoder(w)
+	var encMu sync.Mutex
+	var hang hangSim
+	write := func(resp Response) {
+		if hang.hung.Load() && resp.Event == nil {
 			return
 		}
+		encMu.Lock()
+		defer encMu.Unlock()
+		_ = enc.Encode(resp)
+	}
 
-		res, err := handler(req)
+	workers := cfg.workers
+	if workers < 1 {
+		workers = 1
+	}
+	var logs requestLogs
+	var limits rateLimits
+	var inFlight atomic.Int64
+	started := time.Now()
+	ping := func() PingResult {
+		now := time.Now()
+		// The ping itself is in flight while it is answered.
+		return PingResult{Pong: true, At: now.UTC(), UptimeMs: now.Sub(started).Milliseconds(), InFlight: inFlight.Load() - 1}
+	}
+	stopHeartbeat := startHeartbeat(cfg.heartbeat, started, &inFlight, write)
+	// initialized is set once the handler has served a host request from
+	// stdin, so it has built its provider from the host's config. Pings, seed
+	// stats and rejected tokens never reach the handler and don't count.
+	var initialized atomic.Bool
+	hostHandler := func(req Request) (any, error) {
+		defer initialized.Store(true)
+		return handler(req)
+	}
+	var control *controlServer
+	if cfg.controlPath != "" {
+		var err error
+		control, err = listenControl(cfg.controlPath, func(req Request) (Response, bool) {
+			if hang.hung.Load() {
+				return Response{}, false
+			}
+			if !initialized.Load() {
+				return Response{Error: &errorValue{Code: ErrCodeUnavailable, Message: "plugin not initialized: waiting for the host's first request"}}, true
+			}
+			inFlight.Add(1)
+			defer inFlight.Add(-1)
+			return handle(req, cfg.token, limits.get(req.Config), ping, cfg.payloadSchemas, handler), true
+		})
 		if err != nil {
-			_ = enc.Encode(Response{Error: toErrorValue(err)})
-			continue
+			write(Response{Error: toErrorValue(err)})
+		}
+	}
+	jobs := make(chan Request)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for req := range jobs {
+				if hang.engaged(req.Config) {
+					continue
+				}
+				inFlight.Add(1)
+				started := time.Now()
+				resp := handle(req, cfg.token, limits.get(req.Config), ping, cfg.payloadSchemas, hostHandler)
+				write(resp)
+				hang.answered()
+				if logger := logs.get(req.Config); logger != nil {
+					logger.log(req, resp, time.Since(started), cfg.redactor)
+				}
+				inFlight.Add(-1)
+			}
+		}()
+	}
+
+	// Decode on a separate goroutine so a signal is noticed while the read
+	// blocks. After a signal the goroutine is abandoned; the process exits.
+	reqs := make(chan Request)
+	decodeDone := make(chan error, 1)
+	go func() {
+		for {
+			var req Request
+			if err := dec.Decode(&req); err != nil {
+				decodeDone <- err
+				return
+			}
+			reqs <- req
+		}
+	}()
+
+	var decodeErr error
+	var sig os.Signal
+read:
+	for {
+		select {
+		case req := <-reqs:
+			select {
+			case jobs <- req:
+			case sig = <-cfg.stop:
+				write(Response{ID: req.ID, Error: &errorValue{Code: ErrCodeShuttingDown, Message: "plugin is shutting down"}})
+				break read
+			}
+		case err := <-decodeDone:
+			if !errors.Is(err, io.EOF) {
+				decodeErr = err
+			}
+			break read
+		case sig = <-cfg.stop:
+			break read
+		}
+	}
+	close(jobs)
+	control.close()
+
+	if sig == nil {
+		wg.Wait()
+		control.wait()
+		stopHeartbeat()
+		if decodeErr != nil {
+			write(Response{Error: toErrorValue(decodeErr)})
 		}
-		_ = enc.Encode(Response{Result: res})
+		_ = runHooks(cfg.hooks)
+		logs.close()
+		return ExitCodeShutdown, false
 	}
+
+	event := ShutdownEvent{Type: "shutdown", Signal: sig.String(), InFlight: inFlight.Load(), Drained: true, ExitCode: ExitCodeShutdown}
+	drained := make(chan struct{})
+	go func() {
+		wg.Wait()
+		control.wait()
+		close(drained)
+	}()
+	timeout := cfg.drainTimeout
+	if timeout <= 0 {
+		timeout = DefaultDrainTimeout
+	}
+	select {
+	case <-drained:
+		if err := runHooks(cfg.hooks); err != nil {
+			event.FlushError = err.Error()
+			event.ExitCode = ExitCodeFlushFailed
+		}
+	case <-time.After(timeout):
+		// The requests still running are abandoned, and could change the
+		// state a hook flushes while it runs, so the hooks are skipped.
+		event.Drained = false
+		event.HooksSkipped = len(cfg.hooks) > 0
+		event.ExitCode = ExitCodeDrainTimeout
+	}
+	logs.close()
+	stopHeartbeat()
+	write(Response{Event: &event})
+	return event.ExitCode, true
+}
+
+// handle authorizes, rate limits, and dispatches a single request, translating
+// it through the configured schema shim, validating its payload, and
+// substituting the configured branding tokens in the result. Rejected
+// requests, invalid payloads, MethodPing, and MethodSeedStats never reach the
+// handler.
+func handle(req Request, token string, limiter *rateLimiter, ping func() PingResult, schemas map[string][]any, handler func(Request) (any, error)) Response {
+	if !authorized(req, token) {
+		return Response{ID: req.ID, Error: &errorValue{Code: ErrCodeAuthFailed, Message: "missing or invalid plugin token"}}
+	}
+	if req.Method == MethodPing {
+		return Response{ID: req.ID, Result: ping()}
+	}
+	if limiter != nil {
+		if errVal := limiter.allow(req.Method); errVal != nil {
+			return Response{ID: req.ID, Error: errVal}
+		}
+	}
+	if req.Method == MethodSeedStats {
+		resp := buildResponse(req, mockutil.SeedStats())
+		resp.ID = req.ID
+		return resp
+	}
+	shim, errVal := schemaShimFor(req.Config)
+	if errVal != nil {
+		return Response{ID: req.ID, Error: errVal}
+	}
+	if shim != nil {
+		req = shim.upgrade(req)
+	}
+	if errVal := validatePayload(req, schemas); errVal != nil {
+		return Response{ID: req.ID, Error: errVal}
+	}
+	res, err := handler(req)
+	if err != nil {
+		return Response{ID: req.ID, Error: toErrorValue(err)}
+	}
+	if shim != nil {
+		res = shim.downgrade(req.Method, res)
+	}
+	if branding := mockutil.ParseBranding(req.Config); branding != nil {
+		if res, err = branding.Apply(res); err != nil {
+			return Response{ID: req.ID, Error: toErrorValue(err)}
+		}
+	}
+	resp := buildResponse(req, res)
+	resp.ID = req.ID
+	return resp
+}
+
+// authorized reports whether req carries the configured token. An empty token
+// disables the check.
+func authorized(req Request, token string) bool {
+	if token == "" {
+		return true
+	}
+	return subtle.ConstantTimeCompare([]byte(req.Token), []byte(token)) == 1
+}
+
+// buildResponse wraps a result, compressing it when the request negotiated
+// gzip and the encoded result meets the size threshold.
+func buildResponse(req Request, res any) Response {
+	if req.Compression != EncodingGzip || res == nil {
+		return Response{Result: res}
+	}
+	raw, err := json.Marshal(res)
+	if err != nil {
+		return Response{Result: res}
+	}
+	threshold := req.CompressionThreshold
+	if threshold <= 0 {
+		threshold = DefaultCompressionThreshold
+	}
+	if len(raw) < threshold {
+		return Response{Result: json.RawMessage(raw)}
+	}
+
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(raw); err != nil {
+		return Response{Result: json.RawMessage(raw)}
+	}
+	if err := zw.Close(); err != nil {
+		return Response{Result: json.RawMessage(raw)}
+	}
+	return Response{Encoding: EncodingGzip, Data: buf.Bytes()}
 }
 
 func toErrorValue(err error) *errorValue {
@@ -57,7 +376,20 @@ func toErrorValue(err error) *errorValue {
 	}
 	var oe orcherr.OpsOrchError
 	if errors.As(err, &oe) {
-		return &errorValue{Code: oe.Code, Message: oe.Message}
+		return &errorValue{Code: oe.Code, Message: oe.Message, Details: errorDetails(oe.Err)}
 	}
 	return &errorValue{Message: err.Error()}
 }
+
+// errorDetails encodes err for errorValue.Details, or returns nil when it has
+// nothing to add: plain errors encode to an empty object.
+func errorDetails(err error) json.RawMessage {
+	if err == nil {
+		return nil
+	}
+	raw, marshalErr := json.Marshal(err)
+	if marshalErr != nil || string(raw) == "{}" || string(raw) == "null" {
+		return nil
+	}
+	return raw
+}
@@ -0,0 +1,897 @@
+package pluginrpc
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/opsorch/opsorch-core/orcherr"
+	"github.com/opsorch/opsorch-mock-adapters/deploymentmock"
+	"github.com/opsorch/opsorch-mock-adapters/internal/mockutil"
+)
+
+func largeResult() []string {
+	out := make([]string, 2000)
+	for i := range out {
+		out[i] = "metric-point-value"
+	}
+	return out
+}
+
+func TestServe_PlainResult(t *testing.T) {
+	in := strings.NewReader(`{"method":"demo"}`)
+	var out bytes.Buffer
+	serve(in, &out, serverConfig{}, func(Request) (any, error) { return largeResult(), nil })
+
+	var resp struct {
+		Result   []string `json:"result"`
+		Encoding string   `json:"encoding"`
+	}
+	if err := json.Unmarshal(out.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if resp.Encoding != "" || len(resp.Result) != 2000 {
+		t.Errorf("got encoding %q with %d results, want plain 2000", resp.Encoding, len(resp.Result))
+	}
+}
+
+func TestServe_GzipResult(t *testing.T) {
+	in := strings.NewReader(`{"method":"demo","compression":"gzip"}`)
+	var out bytes.Buffer
+	serve(in, &out, serverConfig{}, func(Request) (any, error) { return largeResult(), nil })
+
+	var resp Response
+	if err := json.Unmarshal(out.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if resp.Encoding != EncodingGzip {
+		t.Fatalf("got encoding %q, want gzip", resp.Encoding)
+	}
+	if len(resp.Data) == 0 {
+		t.Fatal("expected compressed data")
+	}
+
+	zr, err := gzip.NewReader(bytes.NewReader(resp.Data))
+	if err != nil {
+		t.Fatalf("gzip reader: %v", err)
+	}
+	raw, err := io.ReadAll(zr)
+	if err != nil {
+		t.Fatalf("read gzip: %v", err)
+	}
+	var result []string
+	if err := json.Unmarshal(raw, &result); err != nil {
+		t.Fatalf("decode result: %v", err)
+	}
+	if len(result) != 2000 {
+		t.Errorf("got %d results, want 2000", len(result))
+	}
+}
+
+func TestServe_GzipBelowThreshold(t *testing.T) {
+	in := strings.NewReader(`{"method":"demo","compression":"gzip"}`)
+	var out bytes.Buffer
+	serve(in, &out, serverConfig{}, func(Request) (any, error) { return map[string]string{"id": "small"}, nil })
+
+	var resp struct {
+		Result   map[string]string `json:"result"`
+		Encoding string            `json:"encoding"`
+	}
+	if err := json.Unmarshal(out.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if resp.Encoding != "" || resp.Result["id"] != "small" {
+		t.Errorf("got encoding %q result %v, want plain small result", resp.Encoding, resp.Result)
+	}
+}
+
+func TestServe_TokenRequired(t *testing.T) {
+	in := strings.NewReader(`{"method":"demo"}
+{"method":"demo","token":"wrong"}
+{"method":"demo","token":"s3cret"}
+`)
+	var out bytes.Buffer
+	calls := 0
+	serve(in, &out, serverConfig{token: "s3cret"}, func(Request) (any, error) {
+		calls++
+		return map[string]string{"id": "ok"}, nil
+	})
+
+	dec := json.NewDecoder(&out)
+	for i := 0; i < 2; i++ {
+		var resp Response
+		if err := dec.Decode(&resp); err != nil {
+			t.Fatalf("decode response %d: %v", i, err)
+		}
+		if resp.Error == nil || resp.Error.Code != ErrCodeAuthFailed {
+			t.Fatalf("response %d: got error %+v, want %s", i, resp.Error, ErrCodeAuthFailed)
+		}
+	}
+	var resp Response
+	if err := dec.Decode(&resp); err != nil {
+		t.Fatalf("decode authorized response: %v", err)
+	}
+	if resp.Error != nil || resp.Result == nil {
+		t.Fatalf("expected authorized result, got %+v", resp)
+	}
+	if calls != 1 {
+		t.Errorf("handler called %d times, want 1", calls)
+	}
+}
+
+func TestServe_ConcurrentWorkersEchoIDs(t *testing.T) {
+	in := strings.NewReader(`{"id":1,"method":"slow"}
+{"id":"two","method":"fast"}
+`)
+	var out bytes.Buffer
+	release := make(chan struct{})
+	serve(in, &out, serverConfig{workers: 2}, func(req Request) (any, error) {
+		if req.Method == "slow" {
+			<-release
+			return "slow", nil
+		}
+		close(release)
+		return "fast", nil
+	})
+
+	dec := json.NewDecoder(&out)
+	var order []string
+	for i := 0; i < 2; i++ {
+		var resp struct {
+			ID     json.RawMessage `json:"id"`
+			Result string          `json:"result"`
+		}
+		if err := dec.Decode(&resp); err != nil {
+			t.Fatalf("decode response %d: %v", i, err)
+		}
+		order = append(order, string(resp.ID)+"="+resp.Result)
+	}
+	if order[0] != `"two"=fast` || order[1] != "1=slow" {
+		t.Errorf("got responses %v, want fast request answered first with echoed IDs", order)
+	}
+}
+
+func TestServe_RequestLogRedactsSecrets(t *testing.T) {
+	logPath := filepath.Join(t.TempDir(), "requests.log")
+	in := strings.NewReader(`{"id":7,"method":"secret.put","config":{"requestLog":"` + logPath + `"},"payload":{"key":"db","value":"hunter2","apiKey":"abc"}}
+{"method":"secret.get","config":{"requestLog":"` + logPath + `"},"payload":{"key":"missing"}}
+`)
+	var out bytes.Buffer
+	redactValue := func(method string, payload any) any {
+		if fields, ok := payload.(map[string]any); ok && method == "secret.put" {
+			fields["value"] = Redacted
+		}
+		return payload
+	}
+	serve(in, &out, serverConfig{redactor: redactValue}, func(req Request) (any, error) {
+		if req.Method == "secret.get" {
+			return nil, orcherr.New("not_found", "missing not found", nil)
+		}
+		return nil, nil
+	})
+
+	raw, err := os.ReadFile(logPath)
+	if err != nil {
+		t.Fatalf("read log: %v", err)
+	}
+	if strings.Contains(string(raw), "hunter2") || strings.Contains(string(raw), "abc") {
+		t.Fatalf("log leaked secret values: %s", raw)
+	}
+	lines := strings.Split(strings.TrimSpace(string(raw)), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d log lines, want 2: %s", len(lines), raw)
+	}
+	var first, second logEntry
+	if err := json.Unmarshal([]byte(lines[0]), &first); err != nil {
+		t.Fatalf("decode log line: %v", err)
+	}
+	if err := json.Unmarshal([]byte(lines[1]), &second); err != nil {
+		t.Fatalf("decode log line: %v", err)
+	}
+	if first.Method != "secret.put" || first.Outcome != "ok" || string(first.ID) != "7" {
+		t.Errorf("unexpected first entry: %+v", first)
+	}
+	if second.Outcome != "error" || second.ErrorCode != "not_found" {
+		t.Errorf("unexpected second entry: %+v", second)
+	}
+}
+
+func TestServe_RateLimitPerMethod(t *testing.T) {
+	config := `"config":{"rateLimits":{"alert.query":{"limit":2,"window":"1m"},"*":{"limit":1,"window":"1m"}}}`
+	in := strings.NewReader(`{"id":1,"method":"alert.query",` + config + `}
+{"id":2,"method":"alert.query",` + config + `}
+{"id":3,"method":"alert.query",` + config + `}
+{"id":4,"method":"alert.get",` + config + `}
+{"id":5,"method":"alert.get",` + config + `}
+`)
+	var out bytes.Buffer
+	calls := 0
+	serve(in, &out, serverConfig{}, func(Request) (any, error) {
+		calls++
+		return "ok", nil
+	})
+
+	dec := json.NewDecoder(&out)
+	limited := map[string]*errorValue{}
+	for i := 0; i < 5; i++ {
+		var resp Response
+		if err := dec.Decode(&resp); err != nil {
+			t.Fatalf("decode response %d: %v", i, err)
+		}
+		if resp.Error != nil {
+			limited[string(resp.ID)] = resp.Error
+		}
+	}
+	if len(limited) != 2 || limited["3"] == nil || limited["5"] == nil {
+		t.Fatalf("got limited responses %v, want ids 3 and 5", limited)
+	}
+	for id, errVal := range limited {
+		if errVal.Code != ErrCodeRateLimited || errVal.Status != 429 {
+			t.Errorf("response %s: got %+v, want rate_limited 429", id, errVal)
+		}
+		if errVal.RetryAfterMs <= 0 || errVal.RetryAfterMs > 60000 {
+			t.Errorf("response %s: retryAfterMs %d outside (0, 60000]", id, errVal.RetryAfterMs)
+		}
+	}
+	if calls != 3 {
+		t.Errorf("handler called %d times, want 3", calls)
+	}
+}
+
+func TestRateLimiter_WindowResets(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	limiter := &rateLimiter{
+		now:     func() time.Time { return now },
+		limits:  parseRateLimits(map[string]any{"metric.query": map[string]any{"limit": float64(1), "window": "10s"}}),
+		windows: map[string]*rateWindow{},
+	}
+	if errVal := limiter.allow("metric.query"); errVal != nil {
+		t.Fatalf("first call limited: %+v", errVal)
+	}
+	now = now.Add(4 * time.Second)
+	errVal := limiter.allow("metric.query")
+	if errVal == nil || errVal.RetryAfterMs != 6000 {
+		t.Fatalf("got %+v, want rate_limited with retryAfterMs 6000", errVal)
+	}
+	if limiter.allow("metric.list") != nil {
+		t.Error("unconfigured method should not be limited")
+	}
+	now = now.Add(6 * time.Second)
+	if errVal := limiter.allow("metric.query"); errVal != nil {
+		t.Errorf("call after window reset limited: %+v", errVal)
+	}
+}
+
+func TestServe_SchemaV1Shim(t *testing.T) {
+	in := strings.NewReader(`{"id":1,"method":"incident.query","config":{"schemaVersion":"v1"},"payload":{"query":"db","service":"svc-db"}}
+{"id":2,"method":"incident.timeline.append","config":{"schemaVersion":1},"payload":{"id":"inc-1","entry":{"timestamp":"2024-01-01T00:00:00Z","body":"x"}}}
+{"id":3,"method":"incident.query","config":{"schemaVersion":"v9"}}
+`)
+	var out bytes.Buffer
+	var payloads []string
+	serve(in, &out, serverConfig{}, func(req Request) (any, error) {
+		payloads = append(payloads, string(req.Payload))
+		return []map[string]any{{
+			"id":       "inc-1",
+			"fields":   map[string]any{"team": "team-data", "source": "fields"},
+			"metadata": map[string]any{"source": "mock"},
+		}}, nil
+	})
+
+	want := []string{
+		`{"query":"db","scope":{"service":"svc-db"}}`,
+		`{"entry":{"at":"2024-01-01T00:00:00Z","body":"x"},"id":"inc-1"}`,
+	}
+	if len(payloads) != len(want) {
+		t.Fatalf("handler saw %d requests, want %d", len(payloads), len(want))
+	}
+	for i := range want {
+		var got, exp any
+		_ = json.Unmarshal([]byte(payloads[i]), &got)
+		_ = json.Unmarshal([]byte(want[i]), &exp)
+		gotRaw, _ := json.Marshal(got)
+		expRaw, _ := json.Marshal(exp)
+		if string(gotRaw) != string(expRaw) {
+			t.Errorf("payload %d upgraded to %s, want %s", i, gotRaw, expRaw)
+		}
+	}
+
+	dec := json.NewDecoder(&out)
+	var first struct {
+		Result []map[string]any `json:"result"`
+	}
+	if err := dec.Decode(&first); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	record := first.Result[0]
+	metadata := record["metadata"].(map[string]any)
+	if _, ok := record["fields"]; ok || metadata["team"] != "team-data" || metadata["source"] != "mock" {
+		t.Errorf("fields not folded into metadata: %+v", record)
+	}
+	var resp Response
+	_ = dec.Decode(&resp)
+	if err := dec.Decode(&resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if resp.Error == nil || resp.Error.Code != ErrCodeUnsupportedSchema {
+		t.Errorf("got %+v, want unsupported_schema_version", resp.Error)
+	}
+}
+
+func TestSchemaV1_TimelineDowngrade(t *testing.T) {
+	shim, errVal := schemaShimFor(map[string]any{SchemaVersionConfigKey: SchemaV1})
+	if errVal != nil || shim == nil {
+		t.Fatalf("expected v1 shim, got %+v", errVal)
+	}
+	res := shim.downgrade("incident.timeline.get", []map[string]any{{"id": "t1", "at": "2024-01-01T00:00:00Z"}})
+	entry := res.([]any)[0].(map[string]any)
+	if entry["timestamp"] != "2024-01-01T00:00:00Z" || entry["at"] != nil {
+		t.Errorf("timeline entry not downgraded: %+v", entry)
+	}
+	if shim, errVal := schemaShimFor(map[string]any{SchemaVersionConfigKey: "v2"}); shim != nil || errVal != nil {
+		t.Error("current schema should not need a shim")
+	}
+}
+
+func TestServe_SignalDrainsInFlightRequests(t *testing.T) {
+	pr, pw := io.Pipe()
+	defer pw.Close()
+	stop := make(chan os.Signal, 1)
+	started := make(chan struct{})
+	release := make(chan struct{})
+	flushed := false
+	cfg := serverConfig{stop: stop, drainTimeout: time.Second, hooks: []func() error{func() error { flushed = true; return nil }}}
+
+	var out bytes.Buffer
+	done := make(chan struct{})
+	var code int
+	var signalled bool
+	go func() {
+		code, signalled = serve(pr, &out, cfg, func(Request) (any, error) {
+			close(started)
+			<-release
+			return "done", nil
+		})
+		close(done)
+	}()
+
+	if _, err := io.WriteString(pw, `{"id":1,"method":"slow"}`+"\n"); err != nil {
+		t.Fatalf("write request: %v", err)
+	}
+	<-started
+	stop <- syscall.SIGTERM
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	<-done
+
+	if !signalled || code != ExitCodeShutdown || !flushed {
+		t.Fatalf("got code %d signalled %v flushed %v, want clean signalled shutdown", code, signalled, flushed)
+	}
+	dec := json.NewDecoder(&out)
+	var first, last Response
+	if err := dec.Decode(&first); err != nil || string(first.ID) != "1" || first.Result != "done" {
+		t.Fatalf("in-flight response = %+v (%v), want id 1 answered", first, err)
+	}
+	if err := dec.Decode(&last); err != nil || last.Event == nil {
+		t.Fatalf("expected shutdown event, got %+v (%v)", last, err)
+	}
+	if ev := last.Event; ev.Type != "shutdown" || ev.Signal != syscall.SIGTERM.String() || ev.InFlight != 1 || !ev.Drained || ev.ExitCode != ExitCodeShutdown {
+		t.Errorf("unexpected shutdown event %+v", ev)
+	}
+}
+
+func TestServe_SignalDrainTimeoutAndFlushFailure(t *testing.T) {
+	run := func(block bool, hookErr error) ShutdownEvent {
+		t.Helper()
+		pr, pw := io.Pipe()
+		defer pw.Close()
+		stop := make(chan os.Signal, 1)
+		started := make(chan struct{})
+		release := make(chan struct{})
+		defer close(release)
+		cfg := serverConfig{stop: stop, drainTimeout: 20 * time.Millisecond, hooks: []func() error{func() error { return hookErr }}}
+
+		var out bytes.Buffer
+		done := make(chan int)
+		go func() {
+			code, _ := serve(pr, &out, cfg, func(Request) (any, error) {
+				close(started)
+				if block {
+					<-release
+				}
+				return "done", nil
+			})
+			done <- code
+		}()
+		if _, err := io.WriteString(pw, `{"id":1,"method":"demo"}`+"\n"); err != nil {
+			t.Fatalf("write request: %v", err)
+		}
+		<-started
+		time.Sleep(5 * time.Millisecond)
+		stop <- os.Interrupt
+		code := <-done
+
+		lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+		var last Response
+		if err := json.Unmarshal([]byte(lines[len(lines)-1]), &last); err != nil || last.Event == nil {
+			t.Fatalf("expected shutdown event last, got %q (%v)", lines[len(lines)-1], err)
+		}
+		if last.Event.ExitCode != code {
+			t.Errorf("event exit code %d, serve returned %d", last.Event.ExitCode, code)
+		}
+		return *last.Event
+	}
+
+	if ev := run(true, errors.New("flushed mid-request")); ev.Drained || ev.ExitCode != ExitCodeDrainTimeout || !ev.HooksSkipped || ev.FlushError != "" {
+		t.Errorf("blocked request: got %+v, want drain timeout without running hooks", ev)
+	}
+	if ev := run(false, errors.New("disk full")); !ev.Drained || ev.ExitCode != ExitCodeFlushFailed || ev.FlushError != "disk full" {
+		t.Errorf("failing hook: got %+v, want flush failure", ev)
+	}
+}
+
+func TestServe_SeedStatsBypassesHandler(t *testing.T) {
+	mockutil.TimeSeed("demo", "records", true, func() int { return 3 })
+	in := strings.NewReader(`{"id":1,"method":"plugin.seedStats"}`)
+	var out bytes.Buffer
+	serve(in, &out, serverConfig{}, func(Request) (any, error) {
+		t.Fatal("seed stats reached the handler")
+		return nil, nil
+	})
+
+	var resp struct {
+		Result []mockutil.SeedStat `json:"result"`
+	}
+	if err := json.Unmarshal(out.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	for _, stat := range resp.Result {
+		if stat.Provider == "demo" && stat.Collection == "records" {
+			if stat.Records != 3 || !stat.Lazy {
+				t.Errorf("got %+v, want 3 lazily seeded records", stat)
+			}
+			return
+		}
+	}
+	t.Fatalf("demo/records missing from %+v", resp.Result)
+}
+
+func TestServe_ErrorDetails(t *testing.T) {
+	in := strings.NewReader(`{"id":1,"method":"policy"}
+{"id":2,"method":"plain"}
+`)
+	var out bytes.Buffer
+	serve(in, &out, serverConfig{}, func(req Request) (any, error) {
+		if req.Method == "policy" {
+			violation := &deploymentmock.FreezeViolation{Policy: deploymentmock.PolicyChangeFreeze, FreezeID: "freeze-payments", Allowed: []string{"hotfix"}}
+			return nil, orcherr.New("forbidden", violation.Error(), violation)
+		}
+		return nil, orcherr.New("forbidden", "no access", errors.New("denied"))
+	})
+
+	dec := json.NewDecoder(&out)
+	var policy, plain Response
+	if err := dec.Decode(&policy); err != nil || policy.Error == nil {
+		t.Fatalf("decode policy response: %+v (%v)", policy, err)
+	}
+	var details deploymentmock.FreezeViolation
+	if err := json.Unmarshal(policy.Error.Details, &details); err != nil || details.Policy != deploymentmock.PolicyChangeFreeze || details.FreezeID != "freeze-payments" || len(details.Allowed) != 1 {
+		t.Errorf("details = %s (%v), want the wrapped violation", policy.Error.Details, err)
+	}
+	if err := dec.Decode(&plain); err != nil || plain.Error == nil {
+		t.Fatalf("decode plain response: %+v (%v)", plain, err)
+	}
+	if plain.Error.Details != nil {
+		t.Errorf("plain wrapped error sent details %s", plain.Error.Details)
+	}
+}
+
+func TestServe_PayloadValidation(t *testing.T) {
+	in := strings.NewReader(`{"id":1,"method":"incident.query","payload":{"query":"db","limt":5,"limit":"5","scope":{"service":7},"statuses":["open",3],"fields":["id"]}}
+{"id":2,"method":"incident.timeline.append","payload":{"id":"inc-1","entry":{"at":"yesterday","body":"x"}}}
+{"id":3,"method":"incident.query","payload":{"query":"db","breachedOnly":true}}
+{"id":4,"method":"incident.query","config":{"payloadValidation":"types"},"payload":{"query":"db","limt":5}}
+{"id":5,"method":"incident.query","config":{"payloadValidation":"off"},"payload":{"limit":"5"}}
+{"id":6,"method":"incident.update","payload":{"id":"inc-1","input":{"status":"resolved"}}}
+{"id":7,"method":"incident.create","payload":[]}
+{"id":8,"method":"demo.custom","payload":{"anything":1}}
+`)
+	var out bytes.Buffer
+	var handled []string
+	var mu sync.Mutex
+	cfg := serverConfig{}
+	WithPayloadSchema("incident.query", struct {
+		BreachedOnly bool `json:"breachedOnly"`
+	}{})(&cfg)
+	serve(in, &out, cfg, func(req Request) (any, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		handled = append(handled, string(req.ID))
+		return "ok", nil
+	})
+
+	if got := strings.Join(handled, ","); got != "3,4,5,6,8" {
+		t.Errorf("handler saw requests %s, want 3,4,5,6,8", got)
+	}
+	dec := json.NewDecoder(&out)
+	errs := map[string]*errorValue{}
+	for dec.More() {
+		var resp Response
+		if err := dec.Decode(&resp); err != nil {
+			t.Fatalf("decode response: %v", err)
+		}
+		errs[string(resp.ID)] = resp.Error
+	}
+
+	first := errs["1"]
+	if first == nil || first.Code != ErrCodeInvalidPayload {
+		t.Fatalf("got %+v, want invalid_payload", first)
+	}
+	want := []FieldError{
+		{Field: "limit", Problem: ProblemWrongType, Expected: "integer", Got: "string"},
+		{Field: "limt", Problem: ProblemUnknownField},
+		{Field: "scope.service", Problem: ProblemWrongType, Expected: "string", Got: "number"},
+		{Field: "statuses[1]", Problem: ProblemWrongType, Expected: "string", Got: "number"},
+	}
+	if len(first.Fields) != len(want) {
+		t.Fatalf("got field errors %+v, want %+v", first.Fields, want)
+	}
+	for i := range want {
+		if first.Fields[i] != want[i] {
+			t.Errorf("field error %d: got %+v, want %+v", i, first.Fields[i], want[i])
+		}
+	}
+	if !strings.Contains(first.Message, "limt: unknown field") || !strings.Contains(first.Message, "scope.service: expected string, got number") {
+		t.Errorf("message %q does not list the fields", first.Message)
+	}
+
+	if second := errs["2"]; second == nil || len(second.Fields) != 1 || second.Fields[0].Field != "entry.at" || second.Fields[0].Expected != "RFC3339 timestamp" {
+		t.Errorf("got %+v, want entry.at rejected as a timestamp", second)
+	}
+	if seventh := errs["7"]; seventh == nil || len(seventh.Fields) != 1 || seventh.Fields[0].Field != "payload" || seventh.Fields[0].Got != "array" {
+		t.Errorf("got %+v, want the payload rejected as an array", seventh)
+	}
+	for _, id := range []string{"3", "4", "5", "6", "8"} {
+		if errs[id] != nil {
+			t.Errorf("request %s: unexpected error %+v", id, errs[id])
+		}
+	}
+}
+
+func TestServe_BrandingSubstitutesResultTokens(t *testing.T) {
+	in := strings.NewReader(`{"id":1,"method":"alert.get","config":{"branding":{"runbook.demo":"runbooks.acme.io","grafana.demo.com":"grafana.acme.io","grafana.demo":"dash.acme.io"}}}
+{"id":2,"method":"alert.get"}
+`)
+	var out bytes.Buffer
+	serve(in, &out, serverConfig{}, func(Request) (any, error) {
+		return map[string]any{
+			"url":   "https://grafana.demo.com/d/x",
+			"count": 12,
+			"links": []string{"https://runbook.demo/db", "https://grafana.demo/d/db"},
+		}, nil
+	})
+
+	dec := json.NewDecoder(&out)
+	var branded, plain struct {
+		Result struct {
+			URL   string   `json:"url"`
+			Count int      `json:"count"`
+			Links []string `json:"links"`
+		} `json:"result"`
+	}
+	if err := dec.Decode(&branded); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if branded.Result.URL != "https://grafana.acme.io/d/x" || branded.Result.Count != 12 ||
+		branded.Result.Links[0] != "https://runbooks.acme.io/db" || branded.Result.Links[1] != "https://dash.acme.io/d/db" {
+		t.Errorf("unexpected branded result %+v", branded.Result)
+	}
+	if err := dec.Decode(&plain); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if plain.Result.URL != "https://grafana.demo.com/d/x" {
+		t.Errorf("branding leaked into an unbranded request: %+v", plain.Result)
+	}
+}
+
+func TestServe_PingBypassesHandler(t *testing.T) {
+	in := strings.NewReader(`{"id":1,"method":"plugin.ping","config":{"rateLimits":{"*":{"limit":0}}}}`)
+	var out bytes.Buffer
+	serve(in, &out, serverConfig{}, func(Request) (any, error) {
+		t.Fatal("ping reached the handler")
+		return nil, nil
+	})
+
+	var resp struct {
+		ID     json.RawMessage `json:"id"`
+		Result PingResult      `json:"result"`
+		Error  *errorValue     `json:"error"`
+	}
+	if err := json.Unmarshal(out.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if resp.Error != nil || string(resp.ID) != "1" || !resp.Result.Pong || resp.Result.At.IsZero() || resp.Result.InFlight != 0 {
+		t.Errorf("got %+v, want pong for id 1 with nothing else in flight", resp)
+	}
+}
+
+// syncBuffer lets a test read output while heartbeats are still being written.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestServe_HeartbeatFrames(t *testing.T) {
+	pr, pw := io.Pipe()
+	var out syncBuffer
+	done := make(chan struct{})
+	go func() {
+		serve(pr, &out, serverConfig{heartbeat: 2 * time.Millisecond}, func(Request) (any, error) { return "ok", nil })
+		close(done)
+	}()
+	time.Sleep(20 * time.Millisecond)
+	pw.Close()
+	<-done
+
+	var beats []Heartbeat
+	for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+		var resp Response
+		if err := json.Unmarshal([]byte(line), &resp); err != nil || resp.Heartbeat == nil {
+			t.Fatalf("expected only heartbeats, got %q (%v)", line, err)
+		}
+		beats = append(beats, *resp.Heartbeat)
+	}
+	if len(beats) < 2 {
+		t.Fatalf("got %d heartbeats in 20ms at a 2ms interval", len(beats))
+	}
+	for i, beat := range beats {
+		if beat.Type != "heartbeat" || beat.Seq != int64(i+1) || beat.At.IsZero() {
+			t.Errorf("heartbeat %d = %+v, want sequence %d", i, beat, i+1)
+		}
+	}
+}
+
+func TestServe_SimulatedHangAfterRequests(t *testing.T) {
+	config := `"config":{"simulateHang":{"afterRequests":2}}`
+	in := strings.NewReader(`{"id":1,"method":"demo",` + config + `}
+{"id":2,"method":"demo",` + config + `}
+{"id":3,"method":"plugin.ping",` + config + `}`)
+	var out bytes.Buffer
+	calls := 0
+	serve(in, &out, serverConfig{}, func(Request) (any, error) {
+		calls++
+		return "ok", nil
+	})
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 2 || calls != 2 {
+		t.Fatalf("got %d responses and %d handler calls, want 2 of each before hanging: %q", len(lines), calls, lines)
+	}
+}
+
+func TestServe_SimulatedHangStopsHeartbeats(t *testing.T) {
+	pr, pw := io.Pipe()
+	var out syncBuffer
+	done := make(chan struct{})
+	go func() {
+		serve(pr, &out, serverConfig{heartbeat: 2 * time.Millisecond}, func(Request) (any, error) {
+			t.Error("hung plugin reached the handler")
+			return nil, nil
+		})
+		close(done)
+	}()
+	if _, err := io.WriteString(pw, `{"id":1,"method":"demo","config":{"simulateHang":true}}`+"\n"); err != nil {
+		t.Fatalf("write request: %v", err)
+	}
+	time.Sleep(10 * time.Millisecond)
+	hungAt := out.String()
+	if _, err := io.WriteString(pw, `{"id":2,"method":"plugin.ping"}`+"\n"); err != nil {
+		t.Fatalf("write ping: %v", err)
+	}
+	time.Sleep(20 * time.Millisecond)
+	pw.Close()
+	<-done
+
+	if got := out.String(); got != hungAt {
+		t.Errorf("hung plugin kept writing: %q", strings.TrimPrefix(got, hungAt))
+	}
+	if strings.Contains(out.String(), `"id"`) {
+		t.Errorf("hung plugin answered a request: %q", out.String())
+	}
+}
+
+func TestServe_ControlSocket(t *testing.T) {
+	t.Setenv(TokenEnvVar, "s3cret")
+	path := ControlSocket(t.TempDir(), "demoplugin")
+	pr, pw := io.Pipe()
+	var out syncBuffer
+	done := make(chan struct{})
+	go func() {
+		serve(pr, &out, serverConfig{token: "s3cret", controlPath: path}, func(req Request) (any, error) {
+			if req.Method == "demo.missing" {
+				return nil, orcherr.New("not_found", "no such record", nil)
+			}
+			return map[string]any{"name": req.Config["name"]}, nil
+		})
+		close(done)
+	}()
+
+	c := dialControl(t, path)
+	defer c.Close()
+
+	if err := c.Call("demo.get", map[string]any{"name": "mockexport"}, nil, nil); !isCode(err, ErrCodeUnavailable) {
+		t.Errorf("control call before the host's first request = %v, want %s", err, ErrCodeUnavailable)
+	}
+	if _, err := io.WriteString(pw, `{"id":1,"method":"plugin.ping","token":"s3cret"}`+"\n"); err != nil {
+		t.Fatalf("write ping: %v", err)
+	}
+	for i := 0; i < 100 && out.String() == ""; i++ {
+		time.Sleep(time.Millisecond)
+	}
+	if err := c.Call("demo.get", nil, nil, nil); !isCode(err, ErrCodeUnavailable) {
+		t.Errorf("control call after a ping = %v, want %s", err, ErrCodeUnavailable)
+	}
+	if _, err := io.WriteString(pw, `{"id":2,"method":"demo.get","token":"s3cret","config":{"name":"host"}}`+"\n"); err != nil {
+		t.Fatalf("write request: %v", err)
+	}
+	for i := 0; i < 100 && !strings.Contains(out.String(), `"host"`); i++ {
+		time.Sleep(time.Millisecond)
+	}
+	hostOut := out.String()
+
+	var got map[string]string
+	if err := c.Call("demo.get", map[string]any{"name": "checkout"}, nil, &got); err != nil || got["name"] != "checkout" {
+		t.Errorf("control call = %v (%v), want the handler's result", got, err)
+	}
+	err := c.Call("demo.missing", nil, nil, nil)
+	var oe orcherr.OpsOrchError
+	if !errors.As(err, &oe) || oe.Code != "not_found" {
+		t.Errorf("control error = %v, want not_found", err)
+	}
+
+	pw.Close()
+	<-done
+	if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("control socket left behind: %v", err)
+	}
+	if !strings.Contains(hostOut, `"host"`) || out.String() != hostOut {
+		t.Errorf("stdout = %q, want only the host's response", out.String())
+	}
+}
+
+func TestServe_ControlSocketHangs(t *testing.T) {
+	path := ControlSocket(t.TempDir(), "demoplugin")
+	pr, pw := io.Pipe()
+	var out syncBuffer
+	done := make(chan struct{})
+	go func() {
+		serve(pr, &out, serverConfig{controlPath: path}, func(Request) (any, error) {
+			t.Error("hung plugin reached the handler")
+			return nil, nil
+		})
+		close(done)
+	}()
+	c := dialControl(t, path)
+	defer c.Close()
+
+	if _, err := io.WriteString(pw, `{"id":1,"method":"demo","config":{"simulateHang":true}}`+"\n"); err != nil {
+		t.Fatalf("write request: %v", err)
+	}
+	time.Sleep(10 * time.Millisecond)
+	answered := make(chan error, 1)
+	go func() { answered <- c.Call("demo", nil, nil, nil) }()
+	select {
+	case err := <-answered:
+		t.Fatalf("hung plugin answered on the control socket: %v", err)
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	pw.Close()
+	<-done
+	if err := <-answered; err == nil {
+		t.Error("control call succeeded after shutdown, want a closed connection")
+	}
+}
+
+func dialControl(t *testing.T, path string) *Client {
+	t.Helper()
+	var c *Client
+	var err error
+	for i := 0; i < 100; i++ {
+		if c, err = Dial(path); err == nil {
+			return c
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("dial control socket: %v", err)
+	return nil
+}
+
+func TestResolveRef_ForwardsToOwningPlugin(t *testing.T) {
+	dir := t.TempDir()
+	var forwarded refRequest
+	sibling, err := listenControl(ControlSocket(dir, "incidentplugin"), func(req Request) (Response, bool) {
+		_ = json.Unmarshal(req.Payload, &forwarded)
+		return Response{Result: mockutil.ResolvedRef{Ref: forwarded.Ref, Kind: "incident", ID: "inc-001", Entity: map[string]any{"title": "Checkout latency"}}}, true
+	})
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	defer func() { sibling.close(); sibling.wait() }()
+
+	payload := json.RawMessage(`{"ref": "incident:inc-001"}`)
+	if _, err := ResolveRef(context.Background(), payload); !isCode(err, ErrCodeUnavailable) {
+		t.Errorf("without a control dir: %v, want %s", err, ErrCodeUnavailable)
+	}
+
+	t.Setenv(ControlDirEnvVar, dir)
+	got, err := ResolveRef(context.Background(), payload)
+	if err != nil {
+		t.Fatalf("ResolveRef: %v", err)
+	}
+	if resolved := got.(mockutil.ResolvedRef); resolved.ID != "inc-001" || !forwarded.Forwarded {
+		t.Errorf("resolved %+v with forwarded %+v, want inc-001 from a marked forward", resolved, forwarded)
+	}
+	if _, err := ResolveRef(context.Background(), json.RawMessage(`{"ref": "ticket:TCK-001"}`)); !isCode(err, ErrCodeUnavailable) {
+		t.Errorf("unreachable owner: %v, want %s", err, ErrCodeUnavailable)
+	}
+}
+
+func isCode(err error, code string) bool {
+	var oe orcherr.OpsOrchError
+	return errors.As(err, &oe) && oe.Code == code
+}
+
+func TestSearch_RanksSiblingDocumentsInOneCorpus(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv(ControlDirEnvVar, dir)
+	var asked docsRequest
+	sibling, err := listenControl(ControlSocket(dir, "ticketplugin"), func(req Request) (Response, bool) {
+		if req.Method != "search.docs" {
+			return Response{Error: &errorValue{Code: "not_found", Message: req.Method}}, true
+		}
+		_ = json.Unmarshal(req.Payload, &asked)
+		return Response{Result: []mockutil.SearchDoc{
+			{Kind: "ticket", ID: "TCK-001", Title: "Ledger export stalled"},
+			{Kind: "ticket", ID: "TCK-002", Title: "Rotate API keys"},
+		}}, true
+	})
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	defer func() { sibling.close(); sibling.wait() }()
+
+	got, err := Search(context.Background(), json.RawMessage(`{"query": "ledger", "kinds": ["ticket"]}`))
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	hits := got.([]mockutil.SearchHit)
+	if len(hits) != 1 || hits[0].Ref != "ticket:TCK-001" || len(asked.Kinds) != 1 || asked.Kinds[0] != "ticket" {
+		t.Errorf("hits %+v after asking for %v, want TCK-001 from the ticket plugin", hits, asked.Kinds)
+	}
+	if _, err := Search(context.Background(), json.RawMessage(`{"query": "ledger", "kinds": ["team"]}`)); !isCode(err, ErrCodeUnavailable) {
+		t.Errorf("unreachable owner: %v, want %s", err, ErrCodeUnavailable)
+	}
+}
@@ -0,0 +1,112 @@
+package pluginrpc
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// RateLimitsConfigKey is the plugin config key holding per-method rate limits,
+// for example {"incident.query": {"limit": 5, "window": "10s"}}. The "*" entry
+// applies to every method without its own entry, each method counted separately.
+const RateLimitsConfigKey = "rateLimits"
+
+// ErrCodeRateLimited is the error code returned once a method exceeds its limit.
+const ErrCodeRateLimited = "rate_limited"
+
+// defaultRateWindow is used when a limit omits or mangles its window.
+const defaultRateWindow = time.Second
+
+type rateLimit struct {
+	limit  int
+	window time.Duration
+}
+
+// rateWindow is a fixed window counter for one method.
+type rateWindow struct {
+	start time.Time
+	count int
+}
+
+type rateLimiter struct {
+	mu      sync.Mutex
+	now     func() time.Time
+	limits  map[string]rateLimit
+	windows map[string]*rateWindow
+}
+
+// rateLimits lazily builds the limiter from the first request's config, the
+// same way requestLogs does.
+type rateLimits struct {
+	once    sync.Once
+	limiter *rateLimiter
+}
+
+func (l *rateLimits) get(config map[string]any) *rateLimiter {
+	l.once.Do(func() {
+		if limits := parseRateLimits(config[RateLimitsConfigKey]); len(limits) > 0 {
+			l.limiter = &rateLimiter{now: time.Now, limits: limits, windows: map[string]*rateWindow{}}
+		}
+	})
+	return l.limiter
+}
+
+func parseRateLimits(setting any) map[string]rateLimit {
+	entries, ok := setting.(map[string]any)
+	if !ok {
+		return nil
+	}
+	limits := make(map[string]rateLimit, len(entries))
+	for method, raw := range entries {
+		entry, ok := raw.(map[string]any)
+		if !ok {
+			continue
+		}
+		limit, ok := entry["limit"].(float64)
+		if !ok || limit < 0 {
+			continue
+		}
+		window := defaultRateWindow
+		if s, ok := entry["window"].(string); ok {
+			if d, err := time.ParseDuration(s); err == nil && d > 0 {
+				window = d
+			}
+		}
+		limits[method] = rateLimit{limit: int(limit), window: window}
+	}
+	return limits
+}
+
+// allow counts a call to method and, once its limit is spent, returns an
+// error telling the caller how long to wait for the window to reset.
+func (l *rateLimiter) allow(method string) *errorValue {
+	limit, ok := l.limits[method]
+	if !ok {
+		if limit, ok = l.limits["*"]; !ok {
+			return nil
+		}
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	w := l.windows[method]
+	if w == nil || now.Sub(w.start) >= limit.window {
+		w = &rateWindow{start: now}
+		l.windows[method] = w
+	}
+	if w.count < limit.limit {
+		w.count++
+		return nil
+	}
+	retryAfter := w.start.Add(limit.window).Sub(now)
+	if retryAfter < time.Millisecond {
+		retryAfter = time.Millisecond
+	}
+	return &errorValue{
+		Code:         ErrCodeRateLimited,
+		Message:      fmt.Sprintf("rate limit exceeded for %s: %d requests per %s", method, limit.limit, limit.window),
+		Status:       429,
+		RetryAfterMs: retryAfter.Milliseconds(),
+	}
+}
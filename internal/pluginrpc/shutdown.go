@@ -0,0 +1,84 @@
+package pluginrpc
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+// DrainTimeoutEnvVar bounds how long a plugin waits for in-flight requests
+// after SIGTERM or SIGINT, as a Go duration such as "5s".
+const DrainTimeoutEnvVar = "OPSORCH_PLUGIN_DRAIN_TIMEOUT"
+
+// DefaultDrainTimeout is used when DrainTimeoutEnvVar is unset or invalid.
+const DefaultDrainTimeout = 10 * time.Second
+
+// Exit codes after a signal-initiated shutdown. A plugin whose stdin closes
+// returns from Run normally instead.
+const (
+	// ExitCodeShutdown means every in-flight request finished and every
+	// shutdown hook succeeded.
+	ExitCodeShutdown = 0
+	// ExitCodeFlushFailed means a shutdown hook returned an error.
+	ExitCodeFlushFailed = 1
+	// ExitCodeDrainTimeout means requests were still running when the drain
+	// timeout expired; their responses are never written and the shutdown
+	// hooks are skipped.
+	ExitCodeDrainTimeout = 2
+)
+
+// ErrCodeShuttingDown is returned for a request read but not yet started when
+// the shutdown signal arrived.
+const ErrCodeShuttingDown = "shutting_down"
+
+// ShutdownEvent is written to stdout, as the event of a response without an
+// ID, as the last line before a signalled plugin exits.
+type ShutdownEvent struct {
+	Type   string `json:"type"`
+	Signal string `json:"signal"`
+	// InFlight is how many requests were running when the signal arrived.
+	InFlight int64 `json:"inFlight"`
+	Drained  bool  `json:"drained"`
+	// FlushError is the first shutdown hook error, if any.
+	FlushError string `json:"flushError,omitempty"`
+	// HooksSkipped reports that the shutdown hooks did not run because the
+	// drain timed out.
+	HooksSkipped bool `json:"hooksSkipped,omitempty"`
+	ExitCode     int  `json:"exitCode"`
+}
+
+// WithShutdownHook registers fn to run once requests have drained, whether the
+// plugin is stopping on a signal or because stdin closed. Plugins use it to
+// flush persisted state; hooks run in registration order. They never run
+// alongside a handler: a signalled plugin whose drain times out skips them.
+func WithShutdownHook(fn func() error) Option {
+	return func(cfg *serverConfig) {
+		cfg.hooks = append(cfg.hooks, fn)
+	}
+}
+
+// shutdownSignals delivers SIGTERM and SIGINT to Run.
+func shutdownSignals() <-chan os.Signal {
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, syscall.SIGTERM, os.Interrupt)
+	return ch
+}
+
+func drainTimeoutFromEnv() time.Duration {
+	if d, err := time.ParseDuration(os.Getenv(DrainTimeoutEnvVar)); err == nil && d > 0 {
+		return d
+	}
+	return DefaultDrainTimeout
+}
+
+// runHooks calls every hook and returns the first error.
+func runHooks(hooks []func() error) error {
+	var first error
+	for _, hook := range hooks {
+		if err := hook(); err != nil && first == nil {
+			first = err
+		}
+	}
+	return first
+}
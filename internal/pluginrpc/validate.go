@@ -0,0 +1,377 @@
+package pluginrpc
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/opsorch/opsorch-core/schema"
+	"github.com/opsorch/opsorch-mock-adapters/internal/mockutil"
+)
+
+// PayloadValidationConfigKey is the plugin config key choosing how strictly
+// payloads of methods with a known schema are checked before reaching the
+// handler: PayloadValidationStrict (the default) reports unknown fields and
+// wrong types, PayloadValidationTypes only wrong types, and
+// PayloadValidationOff skips the check.
+const PayloadValidationConfigKey = "payloadValidation"
+
+// Payload validation modes.
+const (
+	PayloadValidationStrict = "strict"
+	PayloadValidationTypes  = "types"
+	PayloadValidationOff    = "off"
+)
+
+// ErrCodeInvalidPayload is returned for payloads that do not match the
+// method's schema; the error lists every offending field.
+const ErrCodeInvalidPayload = "invalid_payload"
+
+// Field problems reported in a FieldError.
+const (
+	ProblemUnknownField = "unknown_field"
+	ProblemWrongType    = "wrong_type"
+)
+
+// FieldError is one payload field that does not match the schema. Field is
+// a dotted path, with [i] for array elements.
+type FieldError struct {
+	Field    string `json:"field"`
+	Problem  string `json:"problem"`
+	Expected string `json:"expected,omitempty"`
+	Got      string `json:"got,omitempty"`
+}
+
+func (e FieldError) String() string {
+	if e.Problem == ProblemUnknownField {
+		return e.Field + ": unknown field"
+	}
+	return fmt.Sprintf("%s: expected %s, got %s", e.Field, e.Expected, e.Got)
+}
+
+type idPayload struct {
+	ID string `json:"id"`
+}
+
+// corePayloadSchemas are the payload types of the opsorch-core provider
+// methods. A method may list several types, as query payloads carry the core
+// query and the plugin-level projection and filter options side by side; a
+// field is known when any of them declares it.
+var corePayloadSchemas = map[string][]any{
+	"incident.query":  {schema.IncidentQuery{}, mockutil.ProjectionOptions{}, mockutil.FilterOptions{}},
+	"incident.get":    {idPayload{}},
+	"incident.create": {schema.CreateIncidentInput{}},
+	"incident.update": {struct {
+		ID    string                     `json:"id"`
+		Input schema.UpdateIncidentInput `json:"input"`
+	}{}},
+	"incident.timeline.get": {idPayload{}},
+	"incident.timeline.append": {struct {
+		ID    string                     `json:"id"`
+		Entry schema.TimelineAppendInput `json:"entry"`
+	}{}},
+	"alert.query":   {schema.AlertQuery{}, mockutil.FilterOptions{}},
+	"alert.get":     {idPayload{}},
+	"ticket.query":  {schema.TicketQuery{}, mockutil.ProjectionOptions{}, mockutil.FilterOptions{}},
+	"ticket.get":    {idPayload{}},
+	"ticket.create": {schema.CreateTicketInput{}},
+	"ticket.update": {struct {
+		ID    string                   `json:"id"`
+		Input schema.UpdateTicketInput `json:"input"`
+	}{}},
+	"deployment.query": {schema.DeploymentQuery{}, mockutil.ProjectionOptions{}, mockutil.FilterOptions{}},
+	"deployment.get":   {idPayload{}},
+	"log.query":        {schema.LogQuery{}},
+	"metric.query":     {schema.MetricQuery{}},
+	"service.query":    {schema.ServiceQuery{}},
+	"team.query":       {schema.TeamQuery{}},
+	"team.get":         {idPayload{}},
+
+	"orchestration.plans.query": {schema.OrchestrationPlanQuery{}},
+	"orchestration.plans.get": {struct {
+		PlanID string `json:"planId"`
+	}{}},
+	"orchestration.runs.query": {schema.OrchestrationRunQuery{}},
+	"orchestration.runs.get": {struct {
+		RunID string `json:"runId"`
+	}{}},
+	"orchestration.runs.start": {struct {
+		PlanID     string `json:"planId"`
+		IncidentID string `json:"incidentId"`
+	}{}},
+	"orchestration.runs.steps.complete": {struct {
+		RunID  string `json:"runId"`
+		StepID string `json:"stepId"`
+		Actor  string `json:"actor"`
+		Note   string `json:"note"`
+	}{}},
+}
+
+// WithPayloadSchema declares payload types for method on top of the core
+// schema, for plugins that accept extra options on a core method or want
+// their own methods checked.
+func WithPayloadSchema(method string, types ...any) Option {
+	return func(c *serverConfig) {
+		if c.payloadSchemas == nil {
+			c.payloadSchemas = map[string][]any{}
+		}
+		c.payloadSchemas[method] = append(c.payloadSchemas[method], types...)
+	}
+}
+
+// payloadTypes returns the types a method's payload is checked against.
+func payloadTypes(extra map[string][]any, method string) []reflect.Type {
+	values := append(append([]any(nil), corePayloadSchemas[method]...), extra[method]...)
+	types := make([]reflect.Type, 0, len(values))
+	for _, v := range values {
+		types = append(types, reflect.TypeOf(v))
+	}
+	return types
+}
+
+// validatePayload checks req.Payload against the method's schema. Methods
+// without one, and empty or null payloads, pass.
+func validatePayload(req Request, extra map[string][]any) *errorValue {
+	mode := strings.ToLower(strings.TrimSpace(fmt.Sprint(req.Config[PayloadValidationConfigKey])))
+	if mode == PayloadValidationOff {
+		return nil
+	}
+	types := payloadTypes(extra, req.Method)
+	if len(types) == 0 {
+		return nil
+	}
+	raw := bytes.TrimSpace(req.Payload)
+	if len(raw) == 0 || bytes.Equal(raw, []byte("null")) {
+		return nil
+	}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	var payload any
+	if err := dec.Decode(&payload); err != nil {
+		return &errorValue{Code: ErrCodeInvalidPayload, Message: fmt.Sprintf("invalid payload for %s: %v", req.Method, err)}
+	}
+
+	v := payloadValidator{strict: mode != PayloadValidationTypes}
+	v.object("", payload, types)
+	if len(v.errs) == 0 {
+		return nil
+	}
+	details := make([]string, len(v.errs))
+	for i, e := range v.errs {
+		details[i] = e.String()
+	}
+	return &errorValue{
+		Code:    ErrCodeInvalidPayload,
+		Message: fmt.Sprintf("invalid payload for %s: %s", req.Method, strings.Join(details, "; ")),
+		Fields:  v.errs,
+	}
+}
+
+type payloadValidator struct {
+	strict bool
+	errs   []FieldError
+}
+
+var (
+	jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// object checks a JSON object against the union of the fields of types.
+func (v *payloadValidator) object(path string, value any, types []reflect.Type) {
+	obj, ok := value.(map[string]any)
+	if !ok {
+		v.wrongType(path, "object", value)
+		return
+	}
+	fields := map[string]reflect.Type{}
+	for _, t := range types {
+		for name, ft := range jsonFields(t) {
+			if _, seen := fields[name]; !seen {
+				fields[name] = ft
+			}
+		}
+	}
+	for _, k := range sortedKeys(obj) {
+		ft, ok := fields[k]
+		if !ok {
+			// encoding/json matches field names case-insensitively.
+			for name, t := range fields {
+				if strings.EqualFold(name, k) {
+					ft, ok = t, true
+					break
+				}
+			}
+		}
+		if !ok {
+			if v.strict {
+				v.errs = append(v.errs, FieldError{Field: joinPath(path, k), Problem: ProblemUnknownField})
+			}
+			continue
+		}
+		v.value(joinPath(path, k), obj[k], ft)
+	}
+}
+
+// value checks one JSON value against t. Null is accepted everywhere, as
+// encoding/json leaves the field unset.
+func (v *payloadValidator) value(path string, value any, t reflect.Type) {
+	if value == nil {
+		return
+	}
+	if t.Implements(jsonUnmarshalerType) || reflect.PointerTo(t).Implements(jsonUnmarshalerType) ||
+		t.Implements(textUnmarshalerType) || reflect.PointerTo(t).Implements(textUnmarshalerType) {
+		raw, _ := json.Marshal(value)
+		if err := json.Unmarshal(raw, reflect.New(t).Interface()); err != nil {
+			v.errs = append(v.errs, FieldError{Field: path, Problem: ProblemWrongType, Expected: typeName(t), Got: fmt.Sprintf("%s (%v)", jsonKind(value), err)})
+		}
+		return
+	}
+	switch t.Kind() {
+	case reflect.Pointer:
+		v.value(path, value, t.Elem())
+	case reflect.Interface:
+	case reflect.Struct:
+		v.object(path, value, []reflect.Type{t})
+	case reflect.Map:
+		obj, ok := value.(map[string]any)
+		if !ok {
+			v.wrongType(path, "object", value)
+			return
+		}
+		for _, k := range sortedKeys(obj) {
+			v.value(joinPath(path, k), obj[k], t.Elem())
+		}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			if _, ok := value.(string); ok {
+				return
+			}
+		}
+		list, ok := value.([]any)
+		if !ok {
+			v.wrongType(path, "array", value)
+			return
+		}
+		for i, item := range list {
+			v.value(fmt.Sprintf("%s[%d]", path, i), item, t.Elem())
+		}
+	case reflect.String:
+		if _, ok := value.(string); !ok {
+			v.wrongType(path, "string", value)
+		}
+	case reflect.Bool:
+		if _, ok := value.(bool); !ok {
+			v.wrongType(path, "boolean", value)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, ok := value.(json.Number)
+		if _, err := n.Int64(); !ok || err != nil {
+			v.wrongType(path, "integer", value)
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, ok := value.(json.Number)
+		if i, err := n.Int64(); !ok || err != nil || i < 0 {
+			v.wrongType(path, "non-negative integer", value)
+		}
+	case reflect.Float32, reflect.Float64:
+		n, ok := value.(json.Number)
+		if _, err := n.Float64(); !ok || err != nil {
+			v.wrongType(path, "number", value)
+		}
+	}
+}
+
+func (v *payloadValidator) wrongType(path, expected string, value any) {
+	v.errs = append(v.errs, FieldError{Field: fallbackPath(path), Problem: ProblemWrongType, Expected: expected, Got: jsonKind(value)})
+}
+
+// jsonFields maps the JSON names of a struct's fields, including promoted
+// fields of embedded structs, to their types.
+func jsonFields(t reflect.Type) map[string]reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	out := map[string]reflect.Type{}
+	if t.Kind() != reflect.Struct {
+		return out
+	}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		if f.Anonymous && name == "" {
+			for k, ft := range jsonFields(f.Type) {
+				if _, ok := out[k]; !ok {
+					out[k] = ft
+				}
+			}
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		out[name] = f.Type
+	}
+	return out
+}
+
+// typeName describes t the way FieldError reports expected types.
+func typeName(t reflect.Type) string {
+	if t.Kind() == reflect.Struct && t.PkgPath() == "time" && t.Name() == "Time" {
+		return "RFC3339 timestamp"
+	}
+	return t.String()
+}
+
+// jsonKind names the JSON type of a decoded value.
+func jsonKind(value any) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	case json.Number:
+		return "number"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func fallbackPath(path string) string {
+	if path == "" {
+		return "payload"
+	}
+	return path
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
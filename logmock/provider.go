@@ -21,6 +21,9 @@ const ProviderName = "mock"
 type Config struct {
 	DefaultLimit int
 	Source       string
+	// Features switches realism behaviours off individually; see
+	// mockutil.Features.
+	Features mockutil.Features
 }
 
 // Provider returns generated log entries for demo queries.
@@ -139,7 +142,10 @@ func (p *Provider) Query(ctx context.Context, query schema.LogQuery) (schema.Log
 	}
 
 	service := inferService(query)
-	alertSnapshot := mockutil.SnapshotAlerts()
+	var alertSnapshot []schema.Alert
+	if p.cfg.Features.AlertCoupling {
+		alertSnapshot = mockutil.SnapshotAlerts()
+	}
 	// Filter alerts for this service - check if alert is active during the time window
 	serviceAlerts := make([]schema.Alert, 0)
 	for _, alert := range alertSnapshot {
@@ -279,7 +285,10 @@ func (p *Provider) Query(ctx context.Context, query schema.LogQuery) (schema.Log
 	}
 
 	// Add static scenario-themed logs
-	scenarioLogs := getScenarioLogs(end)
+	var scenarioLogs []schema.LogEntry
+	if p.cfg.Features.ScenarioEffects {
+		scenarioLogs = getScenarioLogs(end)
+	}
 	for _, sl := range scenarioLogs {
 		// Only include logs within the query time range
 		if (sl.Timestamp.Equal(start) || sl.Timestamp.After(start)) &&
@@ -410,6 +419,7 @@ func parseConfig(cfg map[string]any) Config {
 	if v, ok := cfg["source"].(string); ok && v != "" {
 		out.Source = v
 	}
+	out.Features = mockutil.ParseFeatures(cfg)
 	return out
 }
 
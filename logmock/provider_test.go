@@ -595,3 +595,19 @@ func TestLogURLGeneration(t *testing.T) {
 		}
 	}
 }
+
+func TestScenarioLogsDisabled(t *testing.T) {
+	provAny, _ := New(map[string]any{"scenarioEffects": false})
+	prov := provAny.(*Provider)
+
+	end := time.Now().UTC()
+	entries, err := prov.Query(context.Background(), schema.LogQuery{Start: end.Add(-30 * time.Minute), End: end, Limit: 100})
+	if err != nil {
+		t.Fatalf("Query returned error: %v", err)
+	}
+	for _, entry := range entries.Entries {
+		if entry.Fields["is_scenario"] == true {
+			t.Fatalf("expected no scenario logs with scenario effects off, got %s", entry.Message)
+		}
+	}
+}
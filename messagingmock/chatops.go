@@ -0,0 +1,239 @@
+package messagingmock
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/opsorch/opsorch-core/orcherr"
+	"github.com/opsorch/opsorch-mock-adapters/internal/mockutil"
+)
+
+// EventChatCommand is the event bus type for inbound chat commands.
+const EventChatCommand = "messaging.command.received"
+
+// ChatCommand is a slash command a user typed in chat, as a Slack-style
+// workspace would deliver it to the host's ChatOps handler.
+type ChatCommand struct {
+	ID string `json:"id"`
+	// Seq is the event bus sequence number, usable as a polling cursor.
+	Seq int64 `json:"seq"`
+	// Command is the slash command ("/incident"); Args are the remaining
+	// words, with quoted phrases kept together.
+	Command    string    `json:"command"`
+	Args       []string  `json:"args"`
+	Text       string    `json:"text"`
+	Channel    string    `json:"channel"`
+	User       string    `json:"user"`
+	ReceivedAt time.Time `json:"receivedAt"`
+	// Known reports whether Command is one of knownCommands; unknown commands
+	// are delivered anyway so hosts can exercise their fallback reply.
+	Known bool `json:"known"`
+	// Scripted is true for commands delivered by the configured script.
+	Scripted    bool   `json:"scripted"`
+	ResponseURL string `json:"responseUrl"`
+}
+
+// ScriptedCommand is one step of the chatops script: Text is delivered on
+// Channel as User once After has elapsed since the provider was created.
+type ScriptedCommand struct {
+	After   time.Duration
+	Text    string
+	Channel string
+	User    string
+}
+
+// knownCommands are the slash commands the demo workspace has installed.
+var knownCommands = map[string]bool{
+	"/incident": true,
+	"/ack":      true,
+	"/resolve":  true,
+	"/page":     true,
+	"/oncall":   true,
+	"/runbook":  true,
+	"/status":   true,
+}
+
+// defaultChatScript walks a responder through a typical checkout incident.
+var defaultChatScript = []ScriptedCommand{
+	{After: 0, Text: "/ack al-001", Channel: "#ops-alerts", User: "alex"},
+	{After: 20 * time.Second, Text: `/incident declare sev1 "Checkout latency SLO breach" --service svc-checkout`, Channel: "#ops-alerts", User: "alex"},
+	{After: 45 * time.Second, Text: "/oncall svc-payments", Channel: "#checkout-war-room", User: "alex"},
+	{After: 90 * time.Second, Text: "/page team-revenue Payments latency contributing to checkout breach", Channel: "#checkout-war-room", User: "morgan"},
+	{After: 3 * time.Minute, Text: "/runbook run checkout-latency", Channel: "#checkout-war-room", User: "alex"},
+	{After: 6 * time.Minute, Text: "/incident status mitigating", Channel: "#checkout-war-room", User: "alex"},
+	{After: 10 * time.Minute, Text: "/resolve al-001", Channel: "#ops-alerts", User: "morgan"},
+}
+
+// InjectCommand delivers a chat command immediately, as if a user had typed
+// it, and publishes it on the event bus.
+func (p *Provider) InjectCommand(ctx context.Context, text, channel, user string) (ChatCommand, error) {
+	cmd, err := parseChatCommand(text)
+	if err != nil {
+		return ChatCommand{}, err
+	}
+	cmd.Channel = channel
+	cmd.User = user
+	return p.emitCommand(cmd), nil
+}
+
+// Commands returns commands delivered after the given sequence number, oldest
+// first, for hosts that poll rather than subscribe.
+func (p *Provider) Commands(after int64) []ChatCommand {
+	events := mockutil.EventsSince(after, EventChatCommand)
+	out := make([]ChatCommand, 0, len(events))
+	for _, ev := range events {
+		if cmd, ok := ev.Data.(ChatCommand); ok {
+			cmd.Seq = ev.Seq
+			cmd.Args = append([]string(nil), cmd.Args...)
+			out = append(out, cmd)
+		}
+	}
+	return out
+}
+
+// SubscribeCommands streams commands delivered after the call. The cancel
+// func stops the stream and closes the channel.
+func (p *Provider) SubscribeCommands() (<-chan ChatCommand, func()) {
+	events, cancel := mockutil.SubscribeEvents(EventChatCommand)
+	out := make(chan ChatCommand, cap(events))
+	go func() {
+		defer close(out)
+		for ev := range events {
+			if cmd, ok := ev.Data.(ChatCommand); ok {
+				cmd.Seq = ev.Seq
+				cmd.Args = append([]string(nil), cmd.Args...)
+				out <- cmd
+			}
+		}
+	}()
+	return out, cancel
+}
+
+// startChatScript schedules each scripted command relative to now.
+func (p *Provider) startChatScript(script []ScriptedCommand) {
+	for _, step := range script {
+		cmd, err := parseChatCommand(step.Text)
+		if err != nil {
+			continue
+		}
+		cmd.Channel = step.Channel
+		cmd.User = step.User
+		cmd.Scripted = true
+		time.AfterFunc(step.After, func() {
+			p.emitCommand(cmd)
+		})
+	}
+}
+
+func (p *Provider) emitCommand(cmd ChatCommand) ChatCommand {
+	p.mu.Lock()
+	p.nextCommandID++
+	cmd.ID = fmt.Sprintf("cmd-%04d", p.nextCommandID)
+	p.mu.Unlock()
+
+	if cmd.Channel == "" {
+		cmd.Channel = "#ops-alerts"
+	}
+	if cmd.User == "" {
+		cmd.User = "oncall"
+	}
+	cmd.ReceivedAt = time.Now().UTC()
+	cmd.ResponseURL = fmt.Sprintf("https://slack.demo.com/commands/%s/respond", cmd.ID)
+
+	ev := mockutil.PublishEvent(mockutil.Event{Type: EventChatCommand, Source: p.cfg.Provider, At: cmd.ReceivedAt, Data: cmd})
+	cmd.Seq = ev.Seq
+	return cmd
+}
+
+// parseChatCommand splits "/incident declare sev1 \"Checkout down\"" into the
+// command and its arguments.
+func parseChatCommand(text string) (ChatCommand, error) {
+	text = strings.TrimSpace(text)
+	if !strings.HasPrefix(text, "/") {
+		return ChatCommand{}, orcherr.New("bad_request", "chat commands must start with /", nil)
+	}
+	words := splitCommandWords(text)
+	command := strings.ToLower(words[0])
+	return ChatCommand{
+		Command: command,
+		Args:    words[1:],
+		Text:    text,
+		Known:   knownCommands[command],
+	}, nil
+}
+
+// splitCommandWords splits on whitespace, keeping double-quoted phrases as one
+// word without their quotes.
+func splitCommandWords(text string) []string {
+	var (
+		words   []string
+		current strings.Builder
+		quoted  bool
+	)
+	flush := func() {
+		if current.Len() > 0 {
+			words = append(words, current.String())
+			current.Reset()
+		}
+	}
+	for _, r := range text {
+		switch {
+		case r == '"':
+			quoted = !quoted
+			if !quoted {
+				flush()
+			}
+		case unicode.IsSpace(r) && !quoted:
+			flush()
+		default:
+			current.WriteRune(r)
+		}
+	}
+	flush()
+	return words
+}
+
+// parseChatScript reads {"enabled": true, "script": [{"after": "30s",
+// "text": "/ack al-001", "channel": "#ops-alerts", "user": "alex"}]}. A script
+// implies enabled; enabled without a script uses defaultChatScript.
+func parseChatScript(raw any) []ScriptedCommand {
+	cfg, ok := raw.(map[string]any)
+	if !ok {
+		return nil
+	}
+	steps, hasScript := cfg["script"].([]any)
+	enabled, set := cfg["enabled"].(bool)
+	if !set {
+		enabled = hasScript
+	}
+	if !enabled {
+		return nil
+	}
+	if !hasScript {
+		return append([]ScriptedCommand(nil), defaultChatScript...)
+	}
+	out := make([]ScriptedCommand, 0, len(steps))
+	for _, s := range steps {
+		step, ok := s.(map[string]any)
+		if !ok {
+			continue
+		}
+		text, _ := step["text"].(string)
+		if !strings.HasPrefix(strings.TrimSpace(text), "/") {
+			continue
+		}
+		cmd := ScriptedCommand{Text: text}
+		if after, ok := step["after"].(string); ok {
+			if d, err := time.ParseDuration(after); err == nil && d >= 0 {
+				cmd.After = d
+			}
+		}
+		cmd.Channel, _ = step["channel"].(string)
+		cmd.User, _ = step["user"].(string)
+		out = append(out, cmd)
+	}
+	return out
+}
@@ -18,6 +18,8 @@ const ProviderName = "mock"
 // Config controls message metadata.
 type Config struct {
 	Provider string
+	// ChatScript is delivered as inbound chat commands after New returns.
+	ChatScript []ScriptedCommand
 }
 
 // Provider stores sent messages in-memory for demo feedback.
@@ -26,12 +28,16 @@ type Provider struct {
 	mu      sync.Mutex
 	nextID  int
 	history []schema.MessageResult
+
+	nextCommandID int
 }
 
 // New constructs the mock messaging provider.
 func New(cfg map[string]any) (messaging.Provider, error) {
 	parsed := parseConfig(cfg)
-	return &Provider{cfg: parsed}, nil
+	p := &Provider{cfg: parsed}
+	p.startChatScript(parsed.ChatScript)
+	return p, nil
 }
 
 func init() {
@@ -48,6 +54,14 @@ func generateMessagingURL(messageID, channel string) string {
 
 // Send records the message send and returns a synthetic provider response.
 func (p *Provider) Send(ctx context.Context, msg schema.Message) (schema.MessageResult, error) {
+	rendered, isTemplate, err := renderMessageTemplate(msg)
+	if err != nil {
+		return schema.MessageResult{}, err
+	}
+	if isTemplate {
+		msg.Body = rendered.Text
+	}
+
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
@@ -68,6 +82,13 @@ func (p *Provider) Send(ctx context.Context, msg schema.Message) (schema.Message
 	metadata["provider"] = provider
 	metadata["channelType"] = channelType
 	metadata["preview"] = previewBody(msg.Body)
+	if isTemplate {
+		metadata["renderedBody"] = rendered.Text
+		metadata["renderedFrom"] = "inline"
+		if rendered.TemplateID != "" {
+			metadata["renderedFrom"] = rendered.TemplateID
+		}
+	}
 	metadata["providerMessageId"] = fmt.Sprintf("%s-%04d", provider, p.nextID)
 
 	// Simulate realistic delivery patterns
@@ -261,6 +282,7 @@ func parseConfig(cfg map[string]any) Config {
 	if v, ok := cfg["provider"].(string); ok && v != "" {
 		out.Provider = v
 	}
+	out.ChatScript = parseChatScript(cfg["chatops"])
 	return out
 }
 
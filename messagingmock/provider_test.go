@@ -4,6 +4,7 @@ import (
 	"context"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/opsorch/opsorch-core/schema"
 )
@@ -67,3 +68,139 @@ func TestMessagingURLGeneration(t *testing.T) {
 		t.Errorf("message URL should contain /p for message permalink: %s", result.URL)
 	}
 }
+
+func TestChatCommandsPublishedOnEventBus(t *testing.T) {
+	// The bus is process-wide; only look at commands delivered from here on.
+	var cursor int64
+	if earlier := (&Provider{}).Commands(0); len(earlier) > 0 {
+		cursor = earlier[len(earlier)-1].Seq
+	}
+	provAny, err := New(map[string]any{"chatops": map[string]any{"script": []any{
+		map[string]any{"after": "0s", "text": `/incident declare sev1 "Checkout down" --service svc-checkout`, "channel": "#ops-alerts", "user": "alex"},
+		map[string]any{"after": "10ms", "text": "/ack al-001", "user": "morgan"},
+		map[string]any{"text": "not a command"},
+	}}})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	prov := provAny.(*Provider)
+	if len(prov.cfg.ChatScript) != 2 {
+		t.Fatalf("expected invalid script step to be skipped, got %d steps", len(prov.cfg.ChatScript))
+	}
+
+	deadline := time.After(2 * time.Second)
+	var scripted []ChatCommand
+	for len(scripted) < 2 {
+		select {
+		case <-deadline:
+			t.Fatalf("expected two scripted commands, got %+v", scripted)
+		case <-time.After(5 * time.Millisecond):
+		}
+		scripted = prov.Commands(cursor)
+	}
+	declare := scripted[0]
+	if declare.Command != "/incident" || len(declare.Args) != 5 || declare.Args[1] != "sev1" || declare.Args[2] != "Checkout down" || !declare.Known || !declare.Scripted {
+		t.Fatalf("unexpected parsed command: %+v", declare)
+	}
+	if ack := scripted[1]; ack.Command != "/ack" || ack.Channel != "#ops-alerts" || ack.User != "morgan" || ack.Seq <= declare.Seq {
+		t.Fatalf("unexpected second command: %+v", ack)
+	}
+
+	stream, cancel := prov.SubscribeCommands()
+	defer cancel()
+	injected, err := prov.InjectCommand(context.Background(), "/deploy rollback svc-search", "#search", "sam")
+	if err != nil {
+		t.Fatalf("InjectCommand returned error: %v", err)
+	}
+	select {
+	case got := <-stream:
+		if got.ID != injected.ID || got.Seq != injected.Seq || got.Known || got.Scripted {
+			t.Fatalf("subscriber got %+v, want %+v", got, injected)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected injected command on subscription")
+	}
+	if after := prov.Commands(injected.Seq); len(after) != 0 {
+		t.Fatalf("expected no commands after the latest cursor, got %d", len(after))
+	}
+
+	if _, err := prov.InjectCommand(context.Background(), "ack al-001", "", ""); err == nil || !strings.Contains(err.Error(), "bad_request") {
+		t.Fatalf("expected bad_request for text without slash, got %v", err)
+	}
+}
+
+func TestRenderTemplate(t *testing.T) {
+	provAny, _ := New(nil)
+	prov := provAny.(*Provider)
+	ctx := context.Background()
+
+	out, err := prov.RenderTemplate(ctx, RenderRequest{
+		Body:      "Hi {{ user.name }}, {{service}} is {{status|degraded}}",
+		Variables: map[string]any{"user": map[string]any{"name": "Alex"}, "service": "svc-checkout", "extra": 1},
+	})
+	if err != nil {
+		t.Fatalf("RenderTemplate returned error: %v", err)
+	}
+	if !out.Valid || out.Text != "Hi Alex, svc-checkout is degraded" {
+		t.Fatalf("unexpected render: %+v", out)
+	}
+	if strings.Join(out.Used, ",") != "service,user" || strings.Join(out.Unused, ",") != "extra" {
+		t.Fatalf("unexpected used/unused: %v / %v", out.Used, out.Unused)
+	}
+
+	out, err = prov.RenderTemplate(ctx, RenderRequest{Body: "{{title}}\nowner: {{owner}} {{ }} {{bad name}} {{open"})
+	if err != nil {
+		t.Fatalf("RenderTemplate returned error: %v", err)
+	}
+	if out.Valid || len(out.Errors) != 5 {
+		t.Fatalf("expected five template errors, got %+v", out.Errors)
+	}
+	missing := out.Errors[1]
+	if missing.Code != TemplateErrMissingVariable || missing.Variable != "owner" || missing.Line != 2 || missing.Column != 8 {
+		t.Fatalf("unexpected missing variable error: %+v", missing)
+	}
+	if out.Errors[2].Code != TemplateErrEmpty || out.Errors[3].Code != TemplateErrInvalidName || out.Errors[4].Code != TemplateErrUnclosed {
+		t.Fatalf("unexpected error codes: %+v", out.Errors)
+	}
+	if !strings.Contains(out.Text, "{{owner}}") {
+		t.Fatalf("expected unresolved placeholders left in the preview, got %q", out.Text)
+	}
+
+	if _, err := prov.RenderTemplate(ctx, RenderRequest{TemplateID: "tmpl-missing"}); err == nil {
+		t.Fatal("expected an error for an unknown template")
+	}
+	for _, tmpl := range prov.Templates(ctx) {
+		if tmpl.ID == "tmpl-page" && strings.Join(tmpl.Variables, ",") != "severity,service,title,ackUrl" {
+			t.Fatalf("unexpected template variables: %v", tmpl.Variables)
+		}
+	}
+}
+
+func TestSendRendersTemplates(t *testing.T) {
+	provAny, _ := New(nil)
+	prov := provAny.(*Provider)
+	ctx := context.Background()
+
+	result, err := prov.Send(ctx, schema.Message{Channel: "sms:+15550100", Metadata: map[string]any{
+		TemplateIDKey: "tmpl-page",
+		TemplateVariablesKey: map[string]any{
+			"severity": "sev1", "service": "svc-checkout", "title": "Checkout down", "ackUrl": "https://ack.demo/1",
+		},
+	}})
+	if err != nil {
+		t.Fatalf("Send returned error: %v", err)
+	}
+	if result.Metadata["renderedBody"] != "PAGE sev1 svc-checkout: Checkout down. Ack: https://ack.demo/1" || result.Metadata["renderedFrom"] != "tmpl-page" {
+		t.Fatalf("unexpected rendered metadata: %+v", result.Metadata)
+	}
+
+	_, err = prov.Send(ctx, schema.Message{Channel: "#ops", Body: "{{title}} needs {{owner}}", Metadata: map[string]any{
+		TemplateVariablesKey: map[string]any{"title": "Checkout down"},
+	}})
+	if err == nil || !strings.Contains(err.Error(), `missing variable "owner"`) {
+		t.Fatalf("expected a missing variable error, got %v", err)
+	}
+	if len(prov.History()) != 1 {
+		t.Fatalf("expected the failed render not to be sent, got %d messages", len(prov.History()))
+	}
+}
@@ -0,0 +1,312 @@
+package messagingmock
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/opsorch/opsorch-core/orcherr"
+	"github.com/opsorch/opsorch-core/schema"
+)
+
+// Message metadata keys that make Send render the body as a template.
+const (
+	// TemplateVariablesKey holds the variables, as a map, to render the body
+	// with. Its presence alone marks the body as a template.
+	TemplateVariablesKey = "templateVariables"
+	// TemplateIDKey names a stored template to render instead of the body.
+	TemplateIDKey = "templateId"
+)
+
+// Template is a stored notification template. Placeholders are written
+// {{name}}; a dotted name such as {{incident.title}} reads a nested map, and
+// {{name|fallback}} renders fallback when name is missing.
+type Template struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Body        string `json:"body"`
+	// Variables are the placeholder names Body uses, without fallbacks.
+	Variables []string `json:"variables"`
+}
+
+// RenderRequest is the payload of messaging.template.render. Body is used
+// when TemplateID is empty.
+type RenderRequest struct {
+	TemplateID string         `json:"templateId,omitempty"`
+	Body       string         `json:"body,omitempty"`
+	Variables  map[string]any `json:"variables,omitempty"`
+}
+
+// RenderResult is a rendered template. Text is rendered even when Valid is
+// false, with each unresolved placeholder left as written, so a template
+// builder can preview it next to the errors.
+type RenderResult struct {
+	TemplateID string          `json:"templateId,omitempty"`
+	Text       string          `json:"text"`
+	Valid      bool            `json:"valid"`
+	Errors     []TemplateError `json:"errors,omitempty"`
+	// Used lists the variables the template read; Unused the ones it did not.
+	Used   []string `json:"used"`
+	Unused []string `json:"unused,omitempty"`
+}
+
+// TemplateError is a problem at one placeholder, located by 1-based line and
+// column of its opening braces.
+type TemplateError struct {
+	Code     string `json:"code"`
+	Variable string `json:"variable,omitempty"`
+	Message  string `json:"message"`
+	Line     int    `json:"line"`
+	Column   int    `json:"column"`
+}
+
+// Template error codes.
+const (
+	TemplateErrMissingVariable = "missing_variable"
+	TemplateErrUnclosed        = "unclosed_placeholder"
+	TemplateErrEmpty           = "empty_placeholder"
+	TemplateErrInvalidName     = "invalid_variable_name"
+)
+
+// stockTemplates are the templates every provider starts with.
+var stockTemplates = []Template{
+	{
+		ID:          "tmpl-incident-declared",
+		Name:        "Incident declared",
+		Description: "Announces a new incident in its war room channel",
+		Body:        ":rotating_light: {{incident.severity}} declared: {{incident.title}}\nService: {{incident.service}}\nCommander: {{commander|unassigned}}\nBridge: {{bridgeUrl}}",
+	},
+	{
+		ID:          "tmpl-status-update",
+		Name:        "Status update",
+		Description: "Periodic stakeholder update for an open incident",
+		Body:        "[{{incident.id}}] {{incident.title}} is {{status}}.\n{{summary}}\nNext update in {{nextUpdate|30 minutes}}.",
+	},
+	{
+		ID:          "tmpl-page",
+		Name:        "Page responder",
+		Description: "SMS page sent to the on-call responder",
+		Body:        "PAGE {{severity}} {{service}}: {{title}}. Ack: {{ackUrl}}",
+	},
+}
+
+// Templates lists the stored templates.
+func (p *Provider) Templates(ctx context.Context) []Template {
+	_ = ctx
+	out := make([]Template, 0, len(stockTemplates))
+	for _, tmpl := range stockTemplates {
+		tmpl.Variables = templateVariables(tmpl.Body)
+		out = append(out, tmpl)
+	}
+	return out
+}
+
+// RenderTemplate validates and renders a template without sending it. Only an
+// unknown template ID or an empty template fails; problems inside the
+// template are reported in the result.
+func (p *Provider) RenderTemplate(ctx context.Context, req RenderRequest) (RenderResult, error) {
+	_ = ctx
+	return render(req)
+}
+
+func render(req RenderRequest) (RenderResult, error) {
+	body := req.Body
+	if req.TemplateID != "" {
+		tmpl, ok := lookupTemplate(req.TemplateID)
+		if !ok {
+			return RenderResult{}, orcherr.New("not_found", fmt.Sprintf("template %q not found", req.TemplateID), nil)
+		}
+		body = tmpl.Body
+	}
+	if strings.TrimSpace(body) == "" {
+		return RenderResult{}, orcherr.New("bad_request", "template body is required", nil)
+	}
+	result := renderTemplate(body, req.Variables)
+	result.TemplateID = req.TemplateID
+	return result, nil
+}
+
+// renderMessageTemplate renders msg's body, or its stored template, when its
+// metadata asks for it, failing with bad_request when the template does not
+// render cleanly. It reports whether msg was a template.
+func renderMessageTemplate(msg schema.Message) (RenderResult, bool, error) {
+	vars, hasVars := msg.Metadata[TemplateVariablesKey]
+	templateID, _ := msg.Metadata[TemplateIDKey].(string)
+	if !hasVars && templateID == "" {
+		return RenderResult{}, false, nil
+	}
+	variables, ok := vars.(map[string]any)
+	if vars != nil && !ok {
+		return RenderResult{}, true, orcherr.New("bad_request", TemplateVariablesKey+" must be an object", nil)
+	}
+	result, err := render(RenderRequest{TemplateID: templateID, Body: msg.Body, Variables: variables})
+	if err != nil {
+		return RenderResult{}, true, err
+	}
+	if !result.Valid {
+		return result, true, orcherr.New("bad_request", "template did not render: "+result.Errors[0].Message, nil)
+	}
+	return result, true, nil
+}
+
+func lookupTemplate(id string) (Template, bool) {
+	for _, tmpl := range stockTemplates {
+		if tmpl.ID == id {
+			return tmpl, true
+		}
+	}
+	return Template{}, false
+}
+
+// placeholder is one {{...}} in a template.
+type placeholder struct {
+	start, end int // byte offsets of the braces, end exclusive
+	name       string
+	fallback   string
+	hasDefault bool
+	err        *TemplateError
+}
+
+// parsePlaceholders finds the placeholders in body. An unclosed "{{" is
+// reported and ends the scan.
+func parsePlaceholders(body string) []placeholder {
+	var out []placeholder
+	for i := 0; i < len(body); {
+		open := strings.Index(body[i:], "{{")
+		if open < 0 {
+			break
+		}
+		open += i
+		line, col := position(body, open)
+		closeAt := strings.Index(body[open+2:], "}}")
+		if closeAt < 0 {
+			out = append(out, placeholder{start: open, end: len(body), err: &TemplateError{
+				Code: TemplateErrUnclosed, Message: fmt.Sprintf("unclosed placeholder at line %d, column %d", line, col), Line: line, Column: col,
+			}})
+			break
+		}
+		end := open + 2 + closeAt + 2
+		ph := placeholder{start: open, end: end}
+		inner := body[open+2 : end-2]
+		name, fallback, hasDefault := strings.Cut(inner, "|")
+		ph.name = strings.TrimSpace(name)
+		ph.fallback = strings.TrimSpace(fallback)
+		ph.hasDefault = hasDefault
+		switch {
+		case ph.name == "":
+			ph.err = &TemplateError{Code: TemplateErrEmpty, Message: fmt.Sprintf("empty placeholder at line %d, column %d", line, col), Line: line, Column: col}
+		case !validVariableName(ph.name):
+			ph.err = &TemplateError{Code: TemplateErrInvalidName, Variable: ph.name, Message: fmt.Sprintf("invalid variable name %q at line %d, column %d", ph.name, line, col), Line: line, Column: col}
+		}
+		out = append(out, ph)
+		i = end
+	}
+	return out
+}
+
+// renderTemplate substitutes variables into body, collecting an error for
+// each malformed placeholder and each missing variable without a fallback.
+func renderTemplate(body string, variables map[string]any) RenderResult {
+	result := RenderResult{Used: []string{}}
+	used := map[string]bool{}
+	var text strings.Builder
+	last := 0
+	for _, ph := range parsePlaceholders(body) {
+		text.WriteString(body[last:ph.start])
+		last = ph.end
+		if ph.err != nil {
+			result.Errors = append(result.Errors, *ph.err)
+			text.WriteString(body[ph.start:ph.end])
+			continue
+		}
+		value, ok := lookupVariable(variables, ph.name)
+		switch {
+		case ok:
+			used[rootName(ph.name)] = true
+			text.WriteString(fmt.Sprint(value))
+		case ph.hasDefault:
+			text.WriteString(ph.fallback)
+		default:
+			line, col := position(body, ph.start)
+			result.Errors = append(result.Errors, TemplateError{
+				Code: TemplateErrMissingVariable, Variable: ph.name, Line: line, Column: col,
+				Message: fmt.Sprintf("missing variable %q at line %d, column %d", ph.name, line, col),
+			})
+			text.WriteString(body[ph.start:ph.end])
+		}
+	}
+	text.WriteString(body[last:])
+
+	result.Text = text.String()
+	result.Valid = len(result.Errors) == 0
+	for name := range used {
+		result.Used = append(result.Used, name)
+	}
+	for name := range variables {
+		if !used[name] {
+			result.Unused = append(result.Unused, name)
+		}
+	}
+	sort.Strings(result.Used)
+	sort.Strings(result.Unused)
+	return result
+}
+
+// templateVariables lists the distinct variable names a template uses, in
+// order of first use, skipping those with a fallback.
+func templateVariables(body string) []string {
+	seen := map[string]bool{}
+	out := []string{}
+	for _, ph := range parsePlaceholders(body) {
+		if ph.err != nil || ph.hasDefault || seen[ph.name] {
+			continue
+		}
+		seen[ph.name] = true
+		out = append(out, ph.name)
+	}
+	return out
+}
+
+// lookupVariable resolves a dotted name through nested maps. Nil values count
+// as missing.
+func lookupVariable(variables map[string]any, name string) (any, bool) {
+	var current any = variables
+	for _, part := range strings.Split(name, ".") {
+		m, ok := current.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if current, ok = m[part]; !ok || current == nil {
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+func rootName(name string) string {
+	root, _, _ := strings.Cut(name, ".")
+	return root
+}
+
+func validVariableName(name string) bool {
+	for _, part := range strings.Split(name, ".") {
+		if part == "" {
+			return false
+		}
+		for _, r := range part {
+			if !(r == '_' || r == '-' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9') {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// position returns the 1-based line and column of byte offset i.
+func position(body string, i int) (int, int) {
+	line := 1 + strings.Count(body[:i], "\n")
+	col := i - strings.LastIndex(body[:i], "\n")
+	return line, col
+}
@@ -0,0 +1,290 @@
+package metricmock
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/opsorch/opsorch-core/orcherr"
+	"github.com/opsorch/opsorch-core/schema"
+	"github.com/opsorch/opsorch-mock-adapters/internal/mockutil"
+)
+
+// Supported aggregation functions for AggregateQuery.
+const (
+	AggregationAvg  = "avg"
+	AggregationMax  = "max"
+	AggregationMin  = "min"
+	AggregationSum  = "sum"
+	AggregationLast = "last"
+	AggregationP95  = "p95"
+)
+
+const defaultAggregateLimit = 10
+
+// AggregateQuery asks for one value per service over a window, e.g.
+// "top 5 services by error_rate over the last hour".
+type AggregateQuery struct {
+	MetricName  string            `json:"metricName"`
+	Aggregation string            `json:"aggregation,omitempty"`
+	GroupBy     string            `json:"groupBy,omitempty"`
+	Limit       int               `json:"limit,omitempty"`
+	Order       string            `json:"order,omitempty"`
+	Start       time.Time         `json:"start,omitempty"`
+	End         time.Time         `json:"end,omitempty"`
+	Step        int               `json:"step,omitempty"`
+	Scope       schema.QueryScope `json:"scope,omitempty"`
+}
+
+// AggregateRow is one ranked entry of an aggregation result.
+type AggregateRow struct {
+	Rank    int     `json:"rank"`
+	Service string  `json:"service"`
+	Team    string  `json:"team"`
+	Value   float64 `json:"value"`
+	URL     string  `json:"url"`
+}
+
+// AggregateResult is a leaderboard of services ranked by an aggregated metric value.
+type AggregateResult struct {
+	MetricName  string `json:"metricName"`
+	Aggregation string `json:"aggregation"`
+	GroupBy     string `json:"groupBy"`
+	Order       string `json:"order"`
+	Unit        string `json:"unit"`
+	// OriginalUnit is set when values were normalized from another unit.
+	OriginalUnit string    `json:"originalUnit,omitempty"`
+	Start        time.Time `json:"start"`
+	End          time.Time `json:"end"`
+	// Step is the sampling step used, widened for long windows.
+	Step        string         `json:"step"`
+	TotalGroups int            `json:"totalGroups"`
+	Rows        []AggregateRow `json:"rows"`
+	Stats       QueryStats     `json:"stats"`
+}
+
+// Aggregate reduces a metric to one value per service across the topology and
+// returns the top Limit services. Values come from the same generator as Query,
+// so a leaderboard row matches the series returned when drilling into that service.
+// Counters are aggregated as per-second rates.
+func (p *Provider) Aggregate(ctx context.Context, query AggregateQuery) (AggregateResult, error) {
+	name := strings.TrimSpace(query.MetricName)
+	if name == "" {
+		return AggregateResult{}, orcherr.New("bad_request", "metricName is required", nil)
+	}
+	aggregation := strings.ToLower(fallback(query.Aggregation, AggregationAvg))
+	switch aggregation {
+	case AggregationAvg, AggregationMax, AggregationMin, AggregationSum, AggregationLast, AggregationP95:
+	default:
+		return AggregateResult{}, orcherr.New("bad_request", fmt.Sprintf("unsupported aggregation %q", query.Aggregation), nil)
+	}
+	groupBy := strings.ToLower(fallback(query.GroupBy, "service"))
+	if groupBy != "service" {
+		return AggregateResult{}, orcherr.New("bad_request", fmt.Sprintf("unsupported groupBy %q", query.GroupBy), nil)
+	}
+	order := strings.ToLower(fallback(query.Order, "desc"))
+	if order != "desc" && order != "asc" {
+		return AggregateResult{}, orcherr.New("bad_request", fmt.Sprintf("unsupported order %q", query.Order), nil)
+	}
+	limit := query.Limit
+	if limit <= 0 {
+		limit = defaultAggregateLimit
+	}
+
+	end := query.End
+	if end.IsZero() {
+		end = time.Now().UTC()
+	}
+	start := query.Start
+	if start.IsZero() {
+		start = end.Add(-time.Hour)
+	}
+	if start.After(end) {
+		start, end = end, start
+	}
+	step, _ := resolveStep(query.Step, end.Sub(start))
+
+	def, ok := metricCatalogIndex[sanitizeMetricName(name)]
+	if !ok {
+		def = adHocDefinition(sanitizeMetricName(name))
+	}
+	typ := def.Type
+	if typ == "" {
+		typ = inferType(def.Name)
+	}
+	unit, originalUnit, factor := def.Unit, "", 1.0
+	if conv, ok := canonicalUnits[unit]; ok && normalizeUnits(ctx) {
+		unit, originalUnit, factor = conv.To, def.Unit, conv.Factor
+	}
+
+	services := aggregateServices(query.Scope)
+	stats := estimateQueryStats(1, len(services), end.Sub(start))
+	if err := p.cfg.Budget.check(stats, end.Sub(start)); err != nil {
+		return AggregateResult{}, err
+	}
+
+	alertSnapshot := p.alertSnapshot()
+	now := time.Now().UTC()
+	scenarioAnomalies := p.scenarioAnomalies(now)
+
+	rows := make([]AggregateRow, 0)
+	for _, service := range services {
+		serviceAlerts := make([]schema.Alert, 0)
+		for _, alert := range alertSnapshot {
+			if alert.Service == service && alert.CreatedAt.Before(end) && alert.UpdatedAt.After(start) {
+				serviceAlerts = append(serviceAlerts, alert)
+			}
+		}
+		points := generateSeriesPoints(start, end, step, def, service, serviceAlerts, now, p.cfg.Location, p.cfg.Noise)
+		if len(scenarioAnomalies) > 0 {
+			applyScenarioMetricAnomalies(points, scenarioAnomalies, def.Name, service, start, end, step)
+		}
+
+		values := pointValues(points)
+		if typ == "counter" {
+			values = counterRates(points)
+		}
+		if len(values) == 0 {
+			continue
+		}
+		rows = append(rows, AggregateRow{
+			Service: service,
+			Team:    mockutil.GetTeamForService(service),
+			Value:   math.Round(aggregateValues(values, aggregation)*factor*10000) / 10000,
+			URL:     generateMetricURL(def.Name, service),
+		})
+	}
+
+	sort.SliceStable(rows, func(i, j int) bool {
+		if rows[i].Value == rows[j].Value {
+			return rows[i].Service < rows[j].Service
+		}
+		if order == "asc" {
+			return rows[i].Value < rows[j].Value
+		}
+		return rows[i].Value > rows[j].Value
+	})
+	total := len(rows)
+	if len(rows) > limit {
+		rows = rows[:limit]
+	}
+	for i := range rows {
+		rows[i].Rank = i + 1
+	}
+
+	if typ == "counter" {
+		unit = fallback(unit, "events") + "_per_second"
+		if originalUnit != "" {
+			originalUnit += "_per_second"
+		}
+	}
+	return AggregateResult{
+		MetricName:   def.Name,
+		Aggregation:  aggregation,
+		GroupBy:      groupBy,
+		Order:        order,
+		Unit:         unit,
+		OriginalUnit: originalUnit,
+		Start:        start,
+		End:          end,
+		Step:         step.String(),
+		TotalGroups:  total,
+		Rows:         rows,
+		Stats:        stats,
+	}, nil
+}
+
+// aggregateServices lists the topology services matching a scope.
+func aggregateServices(scope schema.QueryScope) []string {
+	if scope.Service != "" {
+		return []string{scope.Service}
+	}
+	services := mockutil.Services()
+	if scope.Team == "" {
+		return services
+	}
+	out := make([]string, 0, len(services))
+	for _, service := range services {
+		if mockutil.GetTeamForService(service) == scope.Team {
+			out = append(out, service)
+		}
+	}
+	return out
+}
+
+// serviceWeight scales a metric profile per service so each service in the
+// topology has a distinct but stable level. The metric's default service keeps
+// its catalog profile unchanged.
+func serviceWeight(def metricDefinition, service string) float64 {
+	if service == "" || service == def.DefaultService {
+		return 1
+	}
+	h := fnv.New32a()
+	h.Write([]byte(def.Name + "|" + service))
+	return 0.55 + float64(h.Sum32()%1000)/1000*0.9
+}
+
+func pointValues(points []schema.MetricPoint) []float64 {
+	values := make([]float64, 0, len(points))
+	for _, pt := range points {
+		values = append(values, pt.Value)
+	}
+	return values
+}
+
+// counterRates converts a cumulative counter series into per-second rates.
+func counterRates(points []schema.MetricPoint) []float64 {
+	rates := make([]float64, 0, len(points))
+	for i := 1; i < len(points); i++ {
+		elapsed := points[i].Timestamp.Sub(points[i-1].Timestamp).Seconds()
+		delta := points[i].Value - points[i-1].Value
+		if elapsed <= 0 || delta < 0 {
+			continue
+		}
+		rates = append(rates, delta/elapsed)
+	}
+	return rates
+}
+
+func aggregateValues(values []float64, aggregation string) float64 {
+	switch aggregation {
+	case AggregationMax:
+		out := values[0]
+		for _, v := range values[1:] {
+			out = math.Max(out, v)
+		}
+		return out
+	case AggregationMin:
+		out := values[0]
+		for _, v := range values[1:] {
+			out = math.Min(out, v)
+		}
+		return out
+	case AggregationSum:
+		sum := 0.0
+		for _, v := range values {
+			sum += v
+		}
+		return sum
+	case AggregationLast:
+		return values[len(values)-1]
+	case AggregationP95:
+		sorted := append([]float64(nil), values...)
+		sort.Float64s(sorted)
+		idx := int(math.Ceil(0.95*float64(len(sorted)))) - 1
+		if idx < 0 {
+			idx = 0
+		}
+		return sorted[idx]
+	default:
+		sum := 0.0
+		for _, v := range values {
+			sum += v
+		}
+		return sum / float64(len(values))
+	}
+}
@@ -0,0 +1,114 @@
+package metricmock
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/opsorch/opsorch-mock-adapters/internal/mockutil"
+)
+
+const (
+	// cascadeMaxDepth bounds how many caller hops a scenario anomaly spreads.
+	cascadeMaxDepth = 2
+	// cascadeDamping shrinks the excess anomaly factor at each hop.
+	cascadeDamping = 0.5
+	// cascadeHopDelay delays the onset of the propagated anomaly per hop.
+	cascadeHopDelay = 2 * time.Minute
+	// cascadeSaturationFactor stands in for anomalies that pin a value rather than scale it.
+	cascadeSaturationFactor = 2.0
+	// cascadeMinFactor drops propagated effects too small to show on a chart.
+	cascadeMinFactor = 1.05
+)
+
+// withCascadingAnomalies returns the scenario anomalies plus milder derived
+// anomalies on each degraded service's callers, walked through the shared
+// topology. A degraded database therefore raises latency on the services that
+// query it, and their callers a little less. Anomalies that only lower a value
+// (e.g. conversion drops) do not propagate, and an explicit scenario anomaly
+// for the same scenario, metric, and service always wins.
+func withCascadingAnomalies(anomalies []ScenarioMetricAnomaly) []ScenarioMetricAnomaly {
+	explicit := make(map[string]bool, len(anomalies))
+	for _, anomaly := range anomalies {
+		explicit[cascadeKey(anomaly.ScenarioID, anomaly.MetricName, anomaly.Service)] = true
+	}
+
+	out := append([]ScenarioMetricAnomaly(nil), anomalies...)
+	for _, origin := range anomalies {
+		factor := origin.Factor
+		if origin.Value != nil {
+			factor = cascadeSaturationFactor
+		}
+		if origin.Service == "" || factor <= 1 {
+			continue
+		}
+
+		visited := map[string]bool{origin.Service: true}
+		frontier := []string{origin.Service}
+		for depth := 1; depth <= cascadeMaxDepth && len(frontier) > 0; depth++ {
+			hopFactor := 1 + (factor-1)*math.Pow(cascadeDamping, float64(depth))
+			if hopFactor < cascadeMinFactor {
+				break
+			}
+			var next []string
+			for _, service := range frontier {
+				for _, caller := range mockutil.ServiceDependents(service) {
+					if visited[caller] {
+						continue
+					}
+					visited[caller] = true
+					next = append(next, caller)
+					for _, metricName := range cascadeMetricsFor(origin.MetricName) {
+						key := cascadeKey(origin.ScenarioID, metricName, caller)
+						if explicit[key] {
+							continue
+						}
+						explicit[key] = true
+						out = append(out, cascadedAnomaly(origin, caller, metricName, hopFactor, depth))
+					}
+				}
+			}
+			frontier = next
+		}
+	}
+	return out
+}
+
+func cascadedAnomaly(origin ScenarioMetricAnomaly, service, metricName string, factor float64, depth int) ScenarioMetricAnomaly {
+	start := origin.Start
+	if !start.IsZero() {
+		start = start.Add(time.Duration(depth) * cascadeHopDelay)
+	}
+	return ScenarioMetricAnomaly{
+		ScenarioID:   origin.ScenarioID,
+		ScenarioName: origin.ScenarioName,
+		StageName:    "cascade",
+		MetricName:   metricName,
+		Service:      service,
+		Factor:       math.Round(factor*100) / 100,
+		Start:        start,
+		End:          origin.End,
+		Description:  fmt.Sprintf("Cascading impact from %s %s", origin.Service, origin.MetricName),
+		Metadata: map[string]any{
+			"anomaly_type":   "cascade",
+			"origin_service": origin.Service,
+			"origin_metric":  origin.MetricName,
+			"depth":          depth,
+		},
+	}
+}
+
+// cascadeMetricsFor picks the caller-side symptoms of a degraded dependency:
+// error anomalies surface as caller error rates, everything else as latency.
+func cascadeMetricsFor(metricName string) []string {
+	lower := strings.ToLower(metricName)
+	if strings.Contains(lower, "error") || strings.Contains(lower, "circuit_breaker") {
+		return []string{"error_rate"}
+	}
+	return []string{"http_request_duration_seconds", "latency_p99"}
+}
+
+func cascadeKey(scenarioID, metricName, service string) string {
+	return scenarioID + "|" + metricName + "|" + service
+}
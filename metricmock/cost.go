@@ -0,0 +1,103 @@
+package metricmock
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/opsorch/opsorch-core/orcherr"
+)
+
+// ErrCodeQueryTooExpensive is the error code for queries rejected by QueryBudget.
+const ErrCodeQueryTooExpensive = "query_too_expensive"
+
+// scrapeInterval is the raw sample resolution the simulated TSDB scans,
+// regardless of the step a query asks for.
+const scrapeInterval = 15 * time.Second
+
+// QueryBudget caps what a single query may scan. Zero fields are unlimited.
+type QueryBudget struct {
+	MaxWindow  time.Duration
+	MaxSeries  int
+	MaxSamples int64
+}
+
+func (b QueryBudget) enabled() bool {
+	return b.MaxWindow > 0 || b.MaxSeries > 0 || b.MaxSamples > 0
+}
+
+// QueryStats is the simulated cost of a query, attached to every returned
+// series under Metadata["queryStats"] and to aggregate results.
+type QueryStats struct {
+	SeriesScanned   int     `json:"seriesScanned"`
+	SamplesScanned  int64   `json:"samplesScanned"`
+	ExecutionTimeMs float64 `json:"executionTimeMs"`
+	Window          string  `json:"window"`
+}
+
+// estimateQueryStats models a TSDB that reads every raw sample of every
+// matching series: unscoped queries fan out across all services, and cost
+// grows linearly with both cardinality and window.
+func estimateQueryStats(metrics, fanout int, window time.Duration) QueryStats {
+	if fanout < 1 {
+		fanout = 1
+	}
+	series := metrics * fanout
+	perSeries := int64(math.Ceil(float64(window) / float64(scrapeInterval)))
+	if perSeries < 1 {
+		perSeries = 1
+	}
+	samples := int64(series) * perSeries
+	execMs := 2 + float64(series)*0.05 + float64(samples)/40000
+	return QueryStats{
+		SeriesScanned:   series,
+		SamplesScanned:  samples,
+		ExecutionTimeMs: math.Round(execMs*10) / 10,
+		Window:          window.String(),
+	}
+}
+
+// check rejects stats that exceed the budget, naming every limit crossed so
+// callers can narrow the right dimension.
+func (b QueryBudget) check(stats QueryStats, window time.Duration) error {
+	var over []string
+	if b.MaxWindow > 0 && window > b.MaxWindow {
+		over = append(over, fmt.Sprintf("window %s exceeds %s", window, b.MaxWindow))
+	}
+	if b.MaxSeries > 0 && stats.SeriesScanned > b.MaxSeries {
+		over = append(over, fmt.Sprintf("%d series exceeds %d", stats.SeriesScanned, b.MaxSeries))
+	}
+	if b.MaxSamples > 0 && stats.SamplesScanned > b.MaxSamples {
+		over = append(over, fmt.Sprintf("%d samples exceeds %d", stats.SamplesScanned, b.MaxSamples))
+	}
+	if len(over) == 0 {
+		return nil
+	}
+	return orcherr.New(ErrCodeQueryTooExpensive, "query too expensive: "+strings.Join(over, "; ")+"; narrow the window or scope the query to a service", nil)
+}
+
+// parseQueryBudget reads {"maxWindow": "24h", "maxSeries": 50, "maxSamples": 1000000}.
+func parseQueryBudget(raw any) QueryBudget {
+	cfg, ok := raw.(map[string]any)
+	if !ok {
+		return QueryBudget{}
+	}
+	var out QueryBudget
+	if s, ok := cfg["maxWindow"].(string); ok {
+		if d, err := time.ParseDuration(s); err == nil && d > 0 {
+			out.MaxWindow = d
+		}
+	}
+	if n, ok := cfg["maxSeries"].(float64); ok && n > 0 {
+		out.MaxSeries = int(n)
+	} else if n, ok := cfg["maxSeries"].(int); ok && n > 0 {
+		out.MaxSeries = n
+	}
+	if n, ok := cfg["maxSamples"].(float64); ok && n > 0 {
+		out.MaxSamples = int64(n)
+	} else if n, ok := cfg["maxSamples"].(int); ok && n > 0 {
+		out.MaxSamples = int64(n)
+	}
+	return out
+}
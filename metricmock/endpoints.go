@@ -0,0 +1,115 @@
+package metricmock
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/opsorch/opsorch-core/orcherr"
+	"github.com/opsorch/opsorch-core/schema"
+	"github.com/opsorch/opsorch-mock-adapters/internal/mockutil"
+)
+
+// endpointMetrics are the HTTP metrics broken down per endpoint.
+var endpointMetrics = map[string]bool{
+	"http_request_duration_seconds": true,
+	"http_requests_total":           true,
+	"http_errors_total":             true,
+}
+
+// EndpointQuery asks for one series per endpoint of a service.
+type EndpointQuery struct {
+	Service string `json:"service"`
+	// Endpoint limits the result to one endpoint, by operation name or
+	// "METHOD path".
+	Endpoint string `json:"endpoint,omitempty"`
+	// MetricName defaults to http_request_duration_seconds.
+	MetricName string    `json:"metricName,omitempty"`
+	Start      time.Time `json:"start,omitempty"`
+	End        time.Time `json:"end,omitempty"`
+	Step       int       `json:"step,omitempty"`
+}
+
+// EndpointSeries breaks a service's HTTP metric down by the endpoints in its
+// inventory. Each endpoint series is derived from the service series Query
+// returns, latency scaled by the endpoint's latency factor and counters by its
+// traffic share, so scenario anomalies on the service show up on every
+// endpoint and the endpoints add back up to the service.
+func (p *Provider) EndpointSeries(ctx context.Context, query EndpointQuery) ([]schema.MetricSeries, error) {
+	if query.Service == "" {
+		return nil, orcherr.New("bad_request", "service is required", nil)
+	}
+	metricName := fallback(query.MetricName, "http_request_duration_seconds")
+	if !endpointMetrics[metricName] {
+		return nil, orcherr.New("bad_request", fmt.Sprintf("metric %q has no endpoint breakdown", metricName), nil)
+	}
+	endpoints := mockutil.ServiceEndpoints(query.Service)
+	if query.Endpoint != "" {
+		endpoint, ok := mockutil.FindEndpoint(query.Service, query.Endpoint)
+		if !ok {
+			return nil, orcherr.New("not_found", fmt.Sprintf("endpoint %q not found on %s", query.Endpoint, query.Service), nil)
+		}
+		endpoints = []mockutil.Endpoint{endpoint}
+	}
+	if len(endpoints) == 0 {
+		return nil, orcherr.New("not_found", fmt.Sprintf("no endpoint inventory for %s", query.Service), nil)
+	}
+
+	series, err := p.Query(ctx, schema.MetricQuery{
+		Expression: &schema.MetricExpression{MetricName: metricName},
+		Start:      query.Start,
+		End:        query.End,
+		Step:       query.Step,
+		Scope:      schema.QueryScope{Service: query.Service},
+	})
+	if err != nil {
+		return nil, err
+	}
+	var service *schema.MetricSeries
+	for i := range series {
+		if series[i].Name == metricName {
+			service = &series[i]
+			break
+		}
+	}
+	if service == nil {
+		return nil, orcherr.New("not_found", fmt.Sprintf("metric %q not found", metricName), nil)
+	}
+
+	latency := strings.HasSuffix(metricName, "_seconds")
+	out := make([]schema.MetricSeries, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		factor := endpoint.TrafficShare
+		if latency {
+			factor = endpoint.LatencyFactor
+		}
+		points := make([]schema.MetricPoint, len(service.Points))
+		for i, point := range service.Points {
+			point.Value = math.Round(point.Value*factor*1000) / 1000
+			points[i] = point
+		}
+		labels := mockutil.CloneMap(service.Labels)
+		labels["endpoint"] = endpoint.Operation
+		labels["method"] = endpoint.Method
+		labels["path"] = endpoint.Path
+		labels["slo_tier"] = endpoint.SLOTier
+		metadata := mockutil.CloneMap(service.Metadata)
+		delete(metadata, "exemplars")
+		metadata["endpoint"] = endpoint
+		if latency {
+			// Compare the series against the tier target in seconds.
+			metadata["latencyTarget"] = float64(endpoint.LatencyTargetMs) / 1000
+		}
+		out = append(out, schema.MetricSeries{
+			Name:     metricName,
+			Service:  query.Service,
+			Labels:   labels,
+			Points:   points,
+			URL:      service.URL + "&endpoint=" + endpoint.Operation,
+			Metadata: metadata,
+		})
+	}
+	return out, nil
+}
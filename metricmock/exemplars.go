@@ -0,0 +1,54 @@
+package metricmock
+
+import (
+	"sort"
+
+	"github.com/opsorch/opsorch-core/schema"
+	"github.com/opsorch/opsorch-mock-adapters/internal/mockutil"
+)
+
+// maxExemplarsPerSeries caps how many points carry a trace exemplar.
+const maxExemplarsPerSeries = 5
+
+// exemplarsForSeries links the slowest points of a histogram series to traces.
+// Points above the series p90 are candidates; the highest values win. Exemplars
+// are returned in timestamp order and live in series metadata because
+// schema.MetricPoint has no exemplar field.
+func exemplarsForSeries(def metricDefinition, service string, points []schema.MetricPoint) []map[string]any {
+	if def.Type != "histogram" || len(points) == 0 {
+		return nil
+	}
+
+	values := make([]float64, len(points))
+	for i, pt := range points {
+		values[i] = pt.Value
+	}
+	sort.Float64s(values)
+	threshold := values[(len(values)*9)/10]
+
+	candidates := make([]schema.MetricPoint, 0)
+	for _, pt := range points {
+		if pt.Value >= threshold && pt.Value > 0 {
+			candidates = append(candidates, pt)
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].Value > candidates[j].Value })
+	if len(candidates) > maxExemplarsPerSeries {
+		candidates = candidates[:maxExemplarsPerSeries]
+	}
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].Timestamp.Before(candidates[j].Timestamp) })
+
+	exemplars := make([]map[string]any, 0, len(candidates))
+	for _, pt := range candidates {
+		traceID := mockutil.TraceID(service, pt.Timestamp)
+		exemplars = append(exemplars, map[string]any{
+			"timestamp": pt.Timestamp,
+			"value":     pt.Value,
+			"traceId":   traceID,
+			"spanId":    mockutil.SpanID(traceID),
+			"service":   service,
+			"url":       mockutil.TraceURL(traceID),
+		})
+	}
+	return exemplars
+}
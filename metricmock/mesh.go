@@ -0,0 +1,176 @@
+package metricmock
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/opsorch/opsorch-core/orcherr"
+	"github.com/opsorch/opsorch-core/schema"
+	"github.com/opsorch/opsorch-mock-adapters/internal/mockutil"
+)
+
+// meshMetrics map each edge metric to the destination's HTTP metric it is
+// derived from.
+var meshMetrics = map[string]string{
+	"mesh_requests_total":           "http_requests_total",
+	"mesh_errors_total":             "http_errors_total",
+	"mesh_request_duration_seconds": "http_request_duration_seconds",
+}
+
+// EdgeQuery asks for one series per call path between two services. Source
+// and Destination filter the topology's edges; leave both empty for every
+// edge.
+type EdgeQuery struct {
+	Source      string `json:"source,omitempty"`
+	Destination string `json:"destination,omitempty"`
+	// MetricName defaults to mesh_requests_total.
+	MetricName string    `json:"metricName,omitempty"`
+	Start      time.Time `json:"start,omitempty"`
+	End        time.Time `json:"end,omitempty"`
+	Step       int       `json:"step,omitempty"`
+}
+
+// EdgeSeries returns service mesh metrics for the call paths in the topology,
+// one series per source → destination edge labeled `source` and
+// `destination`. Each edge is derived from the destination's HTTP series:
+// counters are split across the destination's callers by a stable traffic
+// share, so a destination's incoming edges add back up to it, and latency is
+// the destination's latency plus a small per-edge network overhead, so
+// scenario anomalies on a service show up on every edge into it.
+func (p *Provider) EdgeSeries(ctx context.Context, query EdgeQuery) ([]schema.MetricSeries, error) {
+	metricName := fallback(query.MetricName, "mesh_requests_total")
+	baseMetric, ok := meshMetrics[metricName]
+	if !ok {
+		return nil, orcherr.New("bad_request", fmt.Sprintf("metric %q is not a mesh metric", metricName), nil)
+	}
+
+	edges := make([]mockutil.ServiceEdge, 0)
+	for _, edge := range mockutil.ServiceEdges() {
+		if query.Source != "" && edge.Source != query.Source {
+			continue
+		}
+		if query.Destination != "" && edge.Destination != query.Destination {
+			continue
+		}
+		edges = append(edges, edge)
+	}
+	if len(edges) == 0 {
+		switch {
+		case query.Source != "" && query.Destination != "":
+			return nil, orcherr.New("not_found", fmt.Sprintf("%s does not call %s", query.Source, query.Destination), nil)
+		case query.Source != "":
+			return nil, orcherr.New("not_found", fmt.Sprintf("%s has no outgoing edges", query.Source), nil)
+		default:
+			return nil, orcherr.New("not_found", fmt.Sprintf("%s has no incoming edges", query.Destination), nil)
+		}
+	}
+
+	// Query each destination once, however many edges lead into it.
+	destinations := map[string]*schema.MetricSeries{}
+	for _, edge := range edges {
+		if _, ok := destinations[edge.Destination]; ok {
+			continue
+		}
+		series, err := p.Query(ctx, schema.MetricQuery{
+			Expression: &schema.MetricExpression{MetricName: baseMetric},
+			Start:      query.Start,
+			End:        query.End,
+			Step:       query.Step,
+			Scope:      schema.QueryScope{Service: edge.Destination},
+		})
+		if err != nil {
+			return nil, err
+		}
+		for i := range series {
+			if series[i].Name == baseMetric {
+				destinations[edge.Destination] = &series[i]
+				break
+			}
+		}
+		if destinations[edge.Destination] == nil {
+			return nil, orcherr.New("not_found", fmt.Sprintf("metric %q not found", baseMetric), nil)
+		}
+	}
+
+	latency := strings.HasSuffix(metricName, "_seconds")
+	out := make([]schema.MetricSeries, 0, len(edges))
+	for _, edge := range edges {
+		dest := destinations[edge.Destination]
+		share := edgeTrafficShare(edge)
+		overhead := edgeOverhead(edge)
+		points := make([]schema.MetricPoint, len(dest.Points))
+		for i, point := range dest.Points {
+			if latency {
+				point.Value += overhead
+			} else {
+				point.Value *= share
+			}
+			point.Value = math.Round(point.Value*1000) / 1000
+			points[i] = point
+		}
+		labels := mockutil.CloneMap(dest.Labels)
+		delete(labels, "service")
+		labels["source"] = edge.Source
+		labels["destination"] = edge.Destination
+		labels["source_region"] = mockutil.ServiceRegion(edge.Source)
+		labels["destination_region"] = mockutil.ServiceRegion(edge.Destination)
+		metadata := mockutil.CloneMap(dest.Metadata)
+		delete(metadata, "exemplars")
+		metadata["edge"] = edge
+		metadata["derivedFrom"] = baseMetric
+		if latency {
+			metadata["networkOverhead"] = overhead
+		} else {
+			metadata["trafficShare"] = math.Round(share*1000) / 1000
+		}
+		out = append(out, schema.MetricSeries{
+			Name:     metricName,
+			Service:  edge.Destination,
+			Labels:   labels,
+			Points:   points,
+			URL:      dest.URL + "&source=" + edge.Source,
+			Metadata: metadata,
+		})
+	}
+	return out, nil
+}
+
+// edgeTrafficShare is the fraction of the destination's traffic that comes
+// from the edge's source. Callers get stable, uneven weights that sum to one
+// across every edge into the destination.
+func edgeTrafficShare(edge mockutil.ServiceEdge) float64 {
+	var total, own float64
+	for _, caller := range mockutil.ServiceDependents(edge.Destination) {
+		w := edgeWeight(caller, edge.Destination)
+		total += w
+		if caller == edge.Source {
+			own = w
+		}
+	}
+	if total == 0 {
+		return 0
+	}
+	return own / total
+}
+
+// edgeWeight is a stable 0.5–1.5 weight for the source → destination edge.
+func edgeWeight(source, destination string) float64 {
+	h := fnv.New32a()
+	h.Write([]byte(source + "->" + destination))
+	return 0.5 + float64(h.Sum32()%1000)/1000
+}
+
+// edgeOverhead is the network latency, in seconds, a call adds on top of the
+// destination's own latency: a millisecond or two within a region and tens of
+// milliseconds across regions.
+func edgeOverhead(edge mockutil.ServiceEdge) float64 {
+	overhead := 0.001 + edgeWeight(edge.Source, edge.Destination)/1000
+	if mockutil.ServiceRegion(edge.Source) != mockutil.ServiceRegion(edge.Destination) {
+		overhead += 0.04
+	}
+	return math.Round(overhead*1000) / 1000
+}
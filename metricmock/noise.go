@@ -0,0 +1,109 @@
+package metricmock
+
+import (
+	"hash/fnv"
+	"math"
+	"time"
+)
+
+// NoiseConfig shapes the jitter added to gauge series. It is read from the
+// "noise" config map, e.g. {"seed": 7, "roughness": 0.8}.
+type NoiseConfig struct {
+	// Seed selects the noise pattern; the same seed yields the same series.
+	Seed int64
+	// Roughness (0-1) weighs the fine detail layered on the slow wander: 0 is
+	// a single smooth octave and 1 weighs every octave equally.
+	Roughness float64
+}
+
+const (
+	defaultNoiseSeed      = 1
+	defaultNoiseRoughness = 0.5
+
+	// noiseOctaves layers value noise at halving periods, starting from
+	// noisePeriod minutes.
+	noiseOctaves = 4
+	noisePeriod  = 10.0
+)
+
+// parseNoiseConfig reads {"seed": 7, "roughness": 0.8}.
+func parseNoiseConfig(raw any) NoiseConfig {
+	out := NoiseConfig{Seed: defaultNoiseSeed, Roughness: defaultNoiseRoughness}
+	cfg, ok := raw.(map[string]any)
+	if !ok {
+		return out
+	}
+	switch v := cfg["seed"].(type) {
+	case int:
+		out.Seed = int64(v)
+	case int64:
+		out.Seed = v
+	case float64:
+		out.Seed = int64(v)
+	}
+	switch v := cfg["roughness"].(type) {
+	case float64:
+		if v >= 0 && v <= 1 {
+			out.Roughness = v
+		}
+	case int:
+		if v == 0 || v == 1 {
+			out.Roughness = float64(v)
+		}
+	}
+	return out
+}
+
+// seriesNoise is smooth pseudo-random noise for one series. It is a function
+// of absolute time, so overlapping windows agree like the rest of the series.
+type seriesNoise struct {
+	seed      uint64
+	roughness float64
+}
+
+// forSeries derives the noise of one metric and service from the seed, so
+// series do not jitter in lockstep.
+func (c NoiseConfig) forSeries(metric, service string) seriesNoise {
+	h := fnv.New64a()
+	h.Write([]byte(metric))
+	h.Write([]byte{0})
+	h.Write([]byte(service))
+	return seriesNoise{seed: h.Sum64() ^ uint64(c.Seed)*0x9e3779b97f4a7c15, roughness: c.Roughness}
+}
+
+// at returns the noise at ts, between -1 and 1.
+func (n seriesNoise) at(ts time.Time) float64 {
+	minutes := ts.Sub(counterEpoch).Minutes()
+	sum, norm, weight, period := 0.0, 0.0, 1.0, noisePeriod
+	for octave := uint64(0); octave < noiseOctaves; octave++ {
+		sum += weight * n.valueNoise(octave, minutes/period)
+		norm += weight
+		weight *= n.roughness
+		period /= 2
+	}
+	return sum / norm
+}
+
+// valueNoise interpolates random lattice values with a smoothstep, so the
+// noise has no corners at lattice points.
+func (n seriesNoise) valueNoise(octave uint64, x float64) float64 {
+	cell := math.Floor(x)
+	t := x - cell
+	t = t * t * (3 - 2*t)
+	a := n.lattice(octave, int64(cell))
+	b := n.lattice(octave, int64(cell)+1)
+	return a + (b-a)*t
+}
+
+// lattice returns the value, between -1 and 1, at one lattice point.
+func (n seriesNoise) lattice(octave uint64, cell int64) float64 {
+	v := splitmix64(n.seed ^ splitmix64(uint64(cell)^octave<<56))
+	return float64(v>>11)/float64(1<<53)*2 - 1
+}
+
+func splitmix64(x uint64) uint64 {
+	x += 0x9e3779b97f4a7c15
+	x = (x ^ (x >> 30)) * 0xbf58476d1ce4e5b9
+	x = (x ^ (x >> 27)) * 0x94d049bb133111eb
+	return x ^ (x >> 31)
+}
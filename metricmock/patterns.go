@@ -164,3 +164,70 @@ func addNoise(points []schema.MetricPoint, noiseFactor float64) []schema.MetricP
 	}
 	return result
 }
+
+// applyLocalBusinessPattern applies diurnal and weekly patterns using the wall
+// clock in loc, so peaks follow the demo audience's business hours. Timestamps
+// are returned unchanged.
+func applyLocalBusinessPattern(points []schema.MetricPoint, loc *time.Location) []schema.MetricPoint {
+	if len(points) == 0 || loc == nil {
+		return points
+	}
+
+	local := make([]schema.MetricPoint, len(points))
+	for i, pt := range points {
+		local[i] = schema.MetricPoint{Timestamp: pt.Timestamp.In(loc), Value: pt.Value}
+	}
+	shaped := applyWeeklyPattern(applyDiurnalPattern(local, 9, 2))
+	for i := range shaped {
+		shaped[i].Timestamp = points[i].Timestamp
+	}
+	return shaped
+}
+
+// weekendFactor is how much quieter weekends are than weekdays.
+const weekendFactor = 0.65
+
+// seasonalGaugeUnits are the gauge units that follow traffic, and so dip at
+// weekends; ratios, latencies, sizes, and flat limits do not.
+var seasonalGaugeUnits = map[string]bool{
+	"connections": true,
+	"jobs":        true,
+	"load":        true,
+	"messages":    true,
+	"sessions":    true,
+	"users":       true,
+}
+
+// seasonality scales generated values by weekday in loc. The zero value
+// leaves values unchanged.
+type seasonality struct {
+	loc     *time.Location
+	weekend float64
+}
+
+func weeklySeasonality(loc *time.Location) seasonality {
+	if loc == nil {
+		loc = time.UTC
+	}
+	return seasonality{loc: loc, weekend: weekendFactor}
+}
+
+func (s seasonality) factor(t time.Time) float64 {
+	if s.loc == nil {
+		return 1
+	}
+	if day := t.In(s.loc).Weekday(); day == time.Saturday || day == time.Sunday {
+		return s.weekend
+	}
+	return 1
+}
+
+// nextChange returns the next local midnight after t, where the factor may
+// change, or the zero time when it never does.
+func (s seasonality) nextChange(t time.Time) time.Time {
+	if s.loc == nil {
+		return time.Time{}
+	}
+	local := t.In(s.loc)
+	return time.Date(local.Year(), local.Month(), local.Day()+1, 0, 0, 0, 0, s.loc)
+}
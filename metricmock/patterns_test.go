@@ -146,3 +146,27 @@ func TestAddNoise(t *testing.T) {
 		t.Error("expected noise to modify values")
 	}
 }
+
+func TestApplyLocalBusinessPattern(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Skipf("timezone data unavailable: %v", err)
+	}
+
+	// 02:00 UTC on a Tuesday is 11:00 in Tokyo (business hours); 14:00 UTC is 23:00 (off hours).
+	tokyoMorning := time.Date(2025, 12, 9, 2, 0, 0, 0, time.UTC)
+	tokyoNight := time.Date(2025, 12, 9, 14, 0, 0, 0, time.UTC)
+	points := []schema.MetricPoint{
+		{Timestamp: tokyoMorning, Value: 100},
+		{Timestamp: tokyoNight, Value: 100},
+	}
+
+	result := applyLocalBusinessPattern(points, tokyo)
+
+	if result[0].Value <= result[1].Value {
+		t.Errorf("expected Tokyo business-hour value (%.2f) above night value (%.2f)", result[0].Value, result[1].Value)
+	}
+	if !result[0].Timestamp.Equal(tokyoMorning) || result[0].Timestamp.Location() != time.UTC {
+		t.Errorf("expected timestamps preserved in UTC, got %v", result[0].Timestamp)
+	}
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/opsorch/opsorch-core/metric"
@@ -18,11 +20,26 @@ const ProviderName = "mock"
 // Config tunes metric generation.
 type Config struct {
 	Source string
+	// Location shapes gauge metrics with business-hour patterns in this timezone when set.
+	Location *time.Location
+	// Budget rejects queries that would scan more than it allows.
+	Budget QueryBudget
+	// Noise shapes the jitter on gauge series.
+	Noise NoiseConfig
+	// Features switches realism behaviours off individually; see
+	// mockutil.Features.
+	Features mockutil.Features
 }
 
 // Provider generates deterministic demo time-series data.
 type Provider struct {
 	cfg Config
+
+	mu sync.Mutex
+	// applied holds anomalies added at runtime by ApplyTemplate and
+	// InjectAnomaly; injected counts the latter for their scenario IDs.
+	applied  []ScenarioMetricAnomaly
+	injected int
 }
 
 type metricDefinition struct {
@@ -133,8 +150,6 @@ func generateMetricDescriptorURL(metricName string) string {
 
 // Query returns a single synthetic series derived from the expression and window.
 func (p *Provider) Query(ctx context.Context, query schema.MetricQuery) ([]schema.MetricSeries, error) {
-	_ = ctx
-
 	start := query.Start
 	end := query.End
 	if end.IsZero() {
@@ -146,10 +161,7 @@ func (p *Provider) Query(ctx context.Context, query schema.MetricQuery) ([]schem
 	if start.After(end) {
 		start, end = end, start
 	}
-	step := time.Duration(query.Step) * time.Second
-	if step <= 0 {
-		step = 60 * time.Second
-	}
+	step, stepAdapted := resolveStep(query.Step, end.Sub(start))
 
 	metricName := ""
 	if query.Expression != nil {
@@ -158,9 +170,16 @@ func (p *Provider) Query(ctx context.Context, query schema.MetricQuery) ([]schem
 
 	requested := requestedMetricNames(metricName)
 	defs := definitionsForRequest(metricName, requested)
+	stats := estimateQueryStats(len(defs), len(aggregateServices(query.Scope)), end.Sub(start))
+	if err := p.cfg.Budget.check(stats, end.Sub(start)); err != nil {
+		return nil, err
+	}
 	series := make([]schema.MetricSeries, 0, len(defs)*2)
-	alertSnapshot := mockutil.SnapshotAlerts()
-	scenarioAnomalies := getScenarioMetricAnomalies(end)
+	alertSnapshot := p.alertSnapshot()
+	// Scenario anomalies happen relative to the present, so they only show up
+	// in windows that reach back over the last half hour.
+	now := time.Now().UTC()
+	scenarioAnomalies := p.scenarioAnomalies(now)
 	// Filter alerts for time window
 	for _, def := range defs {
 		labels := scopedLabelsForDefinition(def, query)
@@ -173,10 +192,10 @@ func (p *Provider) Query(ctx context.Context, query schema.MetricQuery) ([]schem
 				serviceAlerts = append(serviceAlerts, alert)
 			}
 		}
-		points := generateSeriesPoints(start, end, step, def, service, serviceAlerts)
+		points := generateSeriesPoints(start, end, step, def, service, serviceAlerts, now, p.cfg.Location, p.cfg.Noise)
 		var scenarioEffects []map[string]any
 		if len(scenarioAnomalies) > 0 {
-			scenarioEffects = applyScenarioMetricAnomalies(points, scenarioAnomalies, def.Name, service, start, end)
+			scenarioEffects = applyScenarioMetricAnomalies(points, scenarioAnomalies, def.Name, service, start, end, step)
 		}
 		metadata := buildSeriesMetadata(def, query, labels, start, end, step, p.cfg.Source, service, points)
 		if len(serviceAlerts) > 0 {
@@ -184,7 +203,22 @@ func (p *Provider) Query(ctx context.Context, query schema.MetricQuery) ([]schem
 		}
 		if len(scenarioEffects) > 0 {
 			metadata["scenario_effects"] = scenarioEffects
+			metadata["correlation_ids"] = effectCorrelationIDs(scenarioEffects)
+		}
+		if p.cfg.Location != nil {
+			metadata["timezone"] = p.cfg.Location.String()
+		}
+		if exemplars := exemplarsForSeries(def, service, points); len(exemplars) > 0 {
+			metadata["exemplars"] = exemplars
+		}
+		if th, ok := mockutil.AlertThresholdForMetric(def.Name); ok {
+			metadata["thresholds"] = th
+		}
+		if stepAdapted {
+			metadata["stepAdapted"] = true
+			metadata["requestedStep"] = (time.Duration(query.Step) * time.Second).String()
 		}
+		metadata["queryStats"] = stats
 		metadata["variant"] = "active"
 		active := schema.MetricSeries{
 			Name:     def.Name,
@@ -203,10 +237,16 @@ func (p *Provider) Query(ctx context.Context, query schema.MetricQuery) ([]schem
 		baseline.URL = generateMetricURL(def.Name+".baseline", service)
 		baseline.Metadata = mockutil.CloneMap(active.Metadata)
 		baseline.Metadata["variant"] = "baseline"
+		delete(baseline.Metadata, "exemplars")
 		baseline.Points = buildBaselinePoints(active.Points)
 		series = append(series, baseline)
 	}
 
+	if normalizeUnits(ctx) {
+		for i := range series {
+			normalizeSeries(&series[i])
+		}
+	}
 	return series, nil
 }
 
@@ -226,11 +266,24 @@ func (p *Provider) Describe(ctx context.Context, scope schema.QueryScope) ([]sch
 	return descriptors, nil
 }
 
+// alertSnapshot returns the active alerts that shape generated series, or
+// none when alert coupling is off.
+func (p *Provider) alertSnapshot() []schema.Alert {
+	if !p.cfg.Features.AlertCoupling {
+		return nil
+	}
+	return mockutil.SnapshotAlerts()
+}
+
 func parseConfig(cfg map[string]any) Config {
 	out := Config{Source: "mock-metric"}
 	if v, ok := cfg["source"].(string); ok && v != "" {
 		out.Source = v
 	}
+	out.Location = mockutil.ParseLocation(cfg)
+	out.Budget = parseQueryBudget(cfg["queryBudget"])
+	out.Noise = parseNoiseConfig(cfg["noise"])
+	out.Features = mockutil.ParseFeatures(cfg)
 	return out
 }
 
@@ -244,7 +297,20 @@ func inferService(expr string) string {
 	return ""
 }
 
-func generatePoints(start, end time.Time, step time.Duration, profile seriesProfile, metricType string) []schema.MetricPoint {
+// driftHorizon is how many minutes of a profile's per-minute trend show up in
+// a gauge. The trend plays out over the half hour before now and levels off
+// further back, so month-long windows do not drift without bound.
+const driftHorizon = 30.0
+
+// counterEpoch is when generated counters last reset. Counters are integrated
+// from it, so any two windows agree on the value at a given instant.
+var counterEpoch = time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// generatePoints samples a profile between start and end. Values depend only
+// on the timestamp (and on now, for the gauge trend), so overlapping windows
+// of any length line up. season scales gauges and counter rates, e.g. for
+// quieter weekends, and noise jitters gauges.
+func generatePoints(start, end time.Time, step time.Duration, profile seriesProfile, metricType string, now time.Time, season seasonality, noise seriesNoise) []schema.MetricPoint {
 	points := []schema.MetricPoint{}
 
 	count := int(end.Sub(start) / step)
@@ -252,8 +318,11 @@ func generatePoints(start, end time.Time, step time.Duration, profile seriesProf
 		count = 3
 	}
 
-	// For counters, we want a running total.
-	runningTotal := profile.baseline
+	// Counters increase by trend plus an oscillating share of the amplitude
+	// every minute; the integral is carried from one point to the next.
+	rate := math.Max(profile.trend, 0) + profile.amplitude*0.1
+	var total float64
+	var prev time.Time
 
 	for i := 0; i <= count; i++ {
 		ts := start.Add(time.Duration(i) * step)
@@ -263,20 +332,19 @@ func generatePoints(start, end time.Time, step time.Duration, profile seriesProf
 
 		var val float64
 		if metricType == "counter" {
-			// Monotonically increasing
-			// Add a random increment based on "trend" (rate) + some noise
-			increment := profile.trend + (math.Sin(float64(i)/3.5)+1.0)*profile.amplitude*0.1
-			if increment < 0 {
-				increment = 0
+			if i == 0 {
+				total = profile.baseline + counterIncrease(counterEpoch, ts, rate, profile.amplitude, season)
+			} else {
+				total += counterIncrease(prev, ts, rate, profile.amplitude, season)
 			}
-			runningTotal += increment
-			val = runningTotal
+			prev = ts
+			val = total
 		} else {
-			// Gauge / Histogram (latency view) - fluctuating
-			wave := math.Sin(float64(i)/3.5) * profile.amplitude
-			trend := profile.trend * float64(i) // slight trend up/down
-			noise := float64((i%4)-1) * profile.amplitude * 0.5
-			val = profile.baseline + wave + trend + noise
+			minute := float64(ts.Unix()) / 60
+			wave := math.Sin(minute/3.5) * profile.amplitude
+			drift := profile.trend * driftHorizon * math.Tanh(ts.Sub(now).Minutes()/driftHorizon)
+			jitter := noise.at(ts) * profile.amplitude * 0.5
+			val = profile.baseline*season.factor(ts) + wave + drift + jitter
 			if val < 0 {
 				val = 0
 			}
@@ -287,6 +355,59 @@ func generatePoints(start, end time.Time, step time.Duration, profile seriesProf
 	return points
 }
 
+// counterIncrease integrates the counter rate (per minute) from a to b,
+// splitting at the points where the seasonal factor changes.
+func counterIncrease(a, b time.Time, rate, amplitude float64, season seasonality) float64 {
+	// Antiderivative of rate + 0.1*amplitude*sin(t/3.5), t in minutes.
+	integral := func(t time.Time) float64 {
+		minutes := t.Sub(counterEpoch).Minutes()
+		return rate*minutes - 0.35*amplitude*math.Cos(minutes/3.5)
+	}
+	sum := 0.0
+	for a.Before(b) {
+		next := season.nextChange(a)
+		if next.IsZero() || next.After(b) {
+			next = b
+		}
+		sum += season.factor(a) * (integral(next) - integral(a))
+		a = next
+	}
+	return sum
+}
+
+// defaultPoints is the most points a series gets. Windows up to a day keep
+// the default 60s resolution; longer ones, and finer requested steps, widen
+// the step to stay under it.
+const defaultPoints = 1440
+
+// stepLadder lists the steps a too-fine step is widened to, as a dashboard
+// would pick them.
+var stepLadder = []time.Duration{
+	time.Minute, 2 * time.Minute, 5 * time.Minute, 10 * time.Minute, 15 * time.Minute, 30 * time.Minute,
+	time.Hour, 2 * time.Hour, 3 * time.Hour, 6 * time.Hour, 12 * time.Hour, 24 * time.Hour,
+}
+
+// resolveStep returns the step to sample a window at: the requested step (in
+// seconds, 60 when unset) unless it would yield more than defaultPoints
+// points, in which case the smallest ladder step that fits. adapted reports
+// whether a requested step was widened.
+func resolveStep(requested int, window time.Duration) (step time.Duration, adapted bool) {
+	step = time.Duration(requested) * time.Second
+	if step <= 0 {
+		step = time.Minute
+	}
+	if window <= defaultPoints*step {
+		return step, false
+	}
+	for _, candidate := range stepLadder {
+		if candidate > step && window <= defaultPoints*candidate {
+			return candidate, requested > 0
+		}
+	}
+	days := (window + defaultPoints*24*time.Hour - 1) / (defaultPoints * 24 * time.Hour)
+	return days * 24 * time.Hour, requested > 0
+}
+
 func inferType(expr string) string {
 	lower := strings.ToLower(expr)
 	if strings.HasSuffix(lower, "_total") || strings.HasSuffix(lower, "_count") || strings.HasSuffix(lower, "_sum") {
@@ -352,7 +473,11 @@ func fallback(val, def string) string {
 	return def
 }
 
-func generateSeriesPoints(start, end time.Time, step time.Duration, def metricDefinition, service string, alerts []schema.Alert) []schema.MetricPoint {
+// generateSeriesPoints builds one service's series for def. Counters follow a
+// weekly cycle in loc (UTC when nil). Gauges that track load follow it in UTC
+// when loc is nil, and are shaped to business hours in loc otherwise; limits,
+// ratios, and other flat gauges are left alone.
+func generateSeriesPoints(start, end time.Time, step time.Duration, def metricDefinition, service string, alerts []schema.Alert, now time.Time, loc *time.Location, noise NoiseConfig) []schema.MetricPoint {
 	profile := def.Profile
 	if profile == (seriesProfile{}) {
 		profile = profileForExpression(def.Name)
@@ -361,7 +486,22 @@ func generateSeriesPoints(start, end time.Time, step time.Duration, def metricDe
 	if typ == "" {
 		typ = inferType(def.Name)
 	}
-	points := generatePoints(start, end, step, profile, typ)
+	if weight := serviceWeight(def, service); weight != 1 {
+		profile = seriesProfile{baseline: profile.baseline * weight, amplitude: profile.amplitude * weight, trend: profile.trend * weight}
+	}
+	// Flat gauges such as db_connections_max are limits, not load.
+	loadGauge := typ != "counter" && seasonalGaugeUnits[def.Unit] && profile.amplitude > 0
+	season := seasonality{}
+	switch {
+	case typ == "counter":
+		season = weeklySeasonality(loc)
+	case loc == nil && loadGauge:
+		season = weeklySeasonality(time.UTC)
+	}
+	points := generatePoints(start, end, step, profile, typ, now, season, noise.forSeries(def.Name, service))
+	if loc != nil && loadGauge {
+		points = applyLocalBusinessPattern(points, loc)
+	}
 	applyAlertAnomalies(points, typ, service, alerts)
 
 	// Apply bounds for ratio metrics
@@ -423,7 +563,10 @@ func buildBaselinePoints(points []schema.MetricPoint) []schema.MetricPoint {
 	return out
 }
 
-func applyScenarioMetricAnomalies(points []schema.MetricPoint, anomalies []ScenarioMetricAnomaly, metricName, service string, queryStart, queryEnd time.Time) []map[string]any {
+// applyScenarioMetricAnomalies applies anomalies to the points whose bucket,
+// from the point's timestamp to the next one step later, overlaps the
+// anomaly, so widened steps still show anomalies shorter than a bucket.
+func applyScenarioMetricAnomalies(points []schema.MetricPoint, anomalies []ScenarioMetricAnomaly, metricName, service string, queryStart, queryEnd time.Time, step time.Duration) []map[string]any {
 	if len(points) == 0 || len(anomalies) == 0 {
 		return nil
 	}
@@ -445,7 +588,7 @@ func applyScenarioMetricAnomalies(points []schema.MetricPoint, anomalies []Scena
 		applied := false
 		for i := range points {
 			ts := points[i].Timestamp
-			if ts.Before(windowStart) || ts.After(windowEnd) {
+			if ts.After(windowEnd) || !ts.Add(step).After(windowStart) {
 				continue
 			}
 			if anomaly.Value != nil {
@@ -471,6 +614,9 @@ func applyScenarioMetricAnomalies(points []schema.MetricPoint, anomalies []Scena
 		} else if anomaly.Service != "" {
 			effect["service"] = anomaly.Service
 		}
+		if id, ok := mockutil.ScenarioCorrelationID(anomaly.ScenarioID); ok {
+			effect[mockutil.CorrelationKey] = id
+		}
 		if anomaly.Description != "" {
 			effect["description"] = anomaly.Description
 		}
@@ -494,6 +640,21 @@ func applyScenarioMetricAnomalies(points []schema.MetricPoint, anomalies []Scena
 	return effects
 }
 
+// effectCorrelationIDs lists the distinct correlation IDs of a series'
+// scenario effects, sorted, so the series joins every scenario shaping it.
+func effectCorrelationIDs(effects []map[string]any) []string {
+	seen := map[string]bool{}
+	ids := []string{}
+	for _, effect := range effects {
+		if id, ok := effect[mockutil.CorrelationKey].(string); ok && !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
 func clampAnomalyWindow(anomaly ScenarioMetricAnomaly, queryStart, queryEnd time.Time) (time.Time, time.Time, bool) {
 	start := queryStart
 	if !anomaly.Start.IsZero() && anomaly.Start.After(start) {
@@ -798,23 +959,23 @@ func metricServiceKey(service string) string {
 
 // ScenarioMetricAnomaly describes how a scenario should influence metric output.
 type ScenarioMetricAnomaly struct {
-	ScenarioID   string
-	ScenarioName string
-	StageName    string
-	MetricName   string
-	Service      string
-	Labels       map[string]string
-	Value        *float64
-	Factor       float64
-	Start        time.Time
-	End          time.Time
-	Description  string
-	Metadata     map[string]any
+	ScenarioID   string            `json:"scenarioId"`
+	ScenarioName string            `json:"scenarioName"`
+	StageName    string            `json:"stage"`
+	MetricName   string            `json:"metric"`
+	Service      string            `json:"service"`
+	Labels       map[string]string `json:"labels,omitempty"`
+	Value        *float64          `json:"value,omitempty"`
+	Factor       float64           `json:"factor,omitempty"`
+	Start        time.Time         `json:"start"`
+	End          time.Time         `json:"end"`
+	Description  string            `json:"description,omitempty"`
+	Metadata     map[string]any    `json:"metadata,omitempty"`
 }
 
 // getScenarioMetricAnomalies returns static scenario-themed metric anomalies
 func getScenarioMetricAnomalies(now time.Time) []ScenarioMetricAnomaly {
-	return []ScenarioMetricAnomaly{
+	anomalies := []ScenarioMetricAnomaly{
 		{
 			ScenarioID:   "scenario-001",
 			ScenarioName: "SLO Budget Exhaustion",
@@ -876,38 +1037,6 @@ func getScenarioMetricAnomalies(now time.Time) []ScenarioMetricAnomaly {
 				"estimated_lost_orders": 420,
 			},
 		},
-		{
-			ScenarioID:   "scenario-002",
-			ScenarioName: "Cascading Database Failure",
-			StageName:    "escalating",
-			MetricName:   "db_connections_active",
-			Service:      "svc-search",
-			Factor:       0,
-			Value:        floatPtr(100.0),
-			Start:        now.Add(-20 * time.Minute),
-			End:          now.Add(-5 * time.Minute),
-			Description:  "Database connection pool exhausted",
-			Metadata: map[string]any{
-				"anomaly_type": "resource_exhaustion",
-				"severity":     "critical",
-				"pool_size":    100,
-			},
-		},
-		{
-			ScenarioID:   "scenario-002",
-			ScenarioName: "Cascading Database Failure",
-			StageName:    "escalating",
-			MetricName:   "db_query_duration_seconds",
-			Service:      "svc-search",
-			Factor:       4.5,
-			Start:        now.Add(-20 * time.Minute),
-			End:          now.Add(-5 * time.Minute),
-			Description:  "Slow queries due to connection pool exhaustion",
-			Metadata: map[string]any{
-				"anomaly_type": "latency_spike",
-				"severity":     "critical",
-			},
-		},
 		{
 			ScenarioID:   "scenario-002",
 			ScenarioName: "Cascading Database Failure",
@@ -1181,6 +1310,17 @@ func getScenarioMetricAnomalies(now time.Time) []ScenarioMetricAnomaly {
 			},
 		},
 	}
+
+	// The database cascade escalates with svc-search exhausting its pool.
+	pool, _ := templateAnomalies("connection-pool-exhaustion", TemplateTarget{
+		Service:      "svc-search",
+		Start:        now.Add(-20 * time.Minute),
+		End:          now.Add(-5 * time.Minute),
+		ScenarioID:   "scenario-002",
+		ScenarioName: "Cascading Database Failure",
+		StageName:    "escalating",
+	})
+	return append(anomalies, pool...)
 }
 
 func floatPtr(f float64) *float64 {
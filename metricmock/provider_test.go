@@ -2,11 +2,14 @@ package metricmock
 
 import (
 	"context"
+	"math"
+	"reflect"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/opsorch/opsorch-core/schema"
+	"github.com/opsorch/opsorch-mock-adapters/internal/mockutil"
 )
 
 func TestQueryBuildsSeries(t *testing.T) {
@@ -278,24 +281,34 @@ func TestProperty_CapacityGrowthTrend(t *testing.T) {
 			t.Fatalf("expected series for %s", metricName)
 		}
 
-		primarySeries := series[0]
-		points := primarySeries.Points
-
-		if len(points) < 2 {
-			t.Fatalf("not enough points to determine trend for %s", metricName)
-		}
-
-		// Calculate simple linear trend: is last value > first value?
-		firstValue := points[0].Value
-		lastValue := points[len(points)-1].Value
-
-		if lastValue <= firstValue {
-			t.Errorf("capacity metric %s: last value (%.2f) is not greater than first value (%.2f), expected growth trend",
-				metricName, lastValue, firstValue)
+		for _, s := range series {
+			if len(s.Points) < 2 {
+				t.Fatalf("not enough points to determine trend for %s", metricName)
+			}
+			// Fit a line through each series, so the wave and noise around the
+			// trend do not decide the outcome.
+			if slope := fittedSlope(s.Points); slope <= 0 {
+				t.Errorf("capacity metric %s (%s): fitted slope %.2f per minute, expected growth trend",
+					metricName, s.Labels["service"], slope)
+			}
 		}
 	}
 }
 
+// fittedSlope is the least-squares slope of points, per minute.
+func fittedSlope(points []schema.MetricPoint) float64 {
+	var sx, sy, sxx, sxy float64
+	n := float64(len(points))
+	for _, pt := range points {
+		x := pt.Timestamp.Sub(points[0].Timestamp).Minutes()
+		sx += x
+		sy += pt.Value
+		sxx += x * x
+		sxy += x * pt.Value
+	}
+	return (n*sxy - sx*sy) / (n*sxx - sx*sx)
+}
+
 // Feature: enhanced-mock-data, Property 13: Metrics include infrastructure labels
 func TestProperty_InfrastructureLabels(t *testing.T) {
 	provAny, err := New(map[string]any{})
@@ -841,3 +854,767 @@ func TestMetricURLGeneration(t *testing.T) {
 		}
 	}
 }
+
+func TestAggregateRanksTopServices(t *testing.T) {
+	provAny, err := New(map[string]any{})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	prov := provAny.(*Provider)
+
+	end := time.Date(2024, time.March, 4, 12, 0, 0, 0, time.UTC)
+	query := AggregateQuery{MetricName: "error_rate", Aggregation: "max", Limit: 5, Start: end.Add(-time.Hour), End: end}
+	res, err := prov.Aggregate(context.Background(), query)
+	if err != nil {
+		t.Fatalf("Aggregate returned error: %v", err)
+	}
+	if len(res.Rows) != 5 {
+		t.Fatalf("expected 5 rows, got %d", len(res.Rows))
+	}
+	if res.TotalGroups <= 5 {
+		t.Fatalf("expected more groups than the limit, got %d", res.TotalGroups)
+	}
+	for i, row := range res.Rows {
+		if row.Rank != i+1 {
+			t.Fatalf("expected rank %d, got %d", i+1, row.Rank)
+		}
+		if i > 0 && row.Value > res.Rows[i-1].Value {
+			t.Fatalf("rows not sorted descending: %+v", res.Rows)
+		}
+		if row.Team == "" {
+			t.Fatalf("expected team for %s", row.Service)
+		}
+	}
+
+	again, err := prov.Aggregate(context.Background(), query)
+	if err != nil {
+		t.Fatalf("Aggregate returned error: %v", err)
+	}
+	if again.Rows[0] != res.Rows[0] {
+		t.Fatalf("expected deterministic results, got %+v and %+v", res.Rows[0], again.Rows[0])
+	}
+
+	series, err := prov.Query(context.Background(), schema.MetricQuery{
+		Expression: &schema.MetricExpression{MetricName: "error_rate"},
+		Scope:      schema.QueryScope{Service: res.Rows[0].Service},
+		Start:      query.Start,
+		End:        query.End,
+		Step:       60,
+	})
+	if err != nil {
+		t.Fatalf("Query returned error: %v", err)
+	}
+	maxVal := 0.0
+	for _, pt := range series[0].Points {
+		if pt.Value > maxVal {
+			maxVal = pt.Value
+		}
+	}
+	if maxVal != res.Rows[0].Value {
+		t.Fatalf("expected leaderboard value %v to match series max %v", res.Rows[0].Value, maxVal)
+	}
+}
+
+func TestAggregateFiltersByTeamAndValidates(t *testing.T) {
+	provAny, _ := New(map[string]any{})
+	prov := provAny.(*Provider)
+
+	res, err := prov.Aggregate(context.Background(), AggregateQuery{MetricName: "http_requests_total", Order: "asc", Scope: schema.QueryScope{Team: "team-velocity"}})
+	if err != nil {
+		t.Fatalf("Aggregate returned error: %v", err)
+	}
+	if res.Unit != "requests_per_second" {
+		t.Fatalf("expected counter rate unit, got %s", res.Unit)
+	}
+	for _, row := range res.Rows {
+		if row.Team != "team-velocity" {
+			t.Fatalf("expected only team-velocity services, got %+v", row)
+		}
+	}
+
+	if _, err := prov.Aggregate(context.Background(), AggregateQuery{MetricName: "error_rate", Aggregation: "median"}); err == nil {
+		t.Fatalf("expected error for unsupported aggregation")
+	}
+	if _, err := prov.Aggregate(context.Background(), AggregateQuery{}); err == nil {
+		t.Fatalf("expected error for missing metric name")
+	}
+}
+
+func TestCascadingAnomaliesReachCallers(t *testing.T) {
+	now := time.Date(2024, time.March, 4, 12, 0, 0, 0, time.UTC)
+	anomalies := withCascadingAnomalies([]ScenarioMetricAnomaly{{
+		ScenarioID:  "scenario-test",
+		MetricName:  "db_replication_lag_seconds",
+		Service:     "svc-database",
+		Factor:      3,
+		Start:       now.Add(-20 * time.Minute),
+		End:         now,
+		Description: "database degraded",
+	}, {
+		ScenarioID: "scenario-test",
+		MetricName: "latency_p99",
+		Service:    "svc-search",
+		Factor:     1.2,
+	}})
+
+	byKey := map[string]ScenarioMetricAnomaly{}
+	for _, a := range anomalies {
+		byKey[a.MetricName+"|"+a.Service] = a
+	}
+	checkout, ok := byKey["http_request_duration_seconds|svc-checkout"]
+	if !ok {
+		t.Fatalf("expected latency cascade onto svc-checkout, got %+v", anomalies)
+	}
+	if checkout.Factor != 2 || checkout.StageName != "cascade" {
+		t.Fatalf("expected damped first-hop factor 2, got %+v", checkout)
+	}
+	if !checkout.Start.Equal(now.Add(-18 * time.Minute)) {
+		t.Fatalf("expected propagation delay, got %v", checkout.Start)
+	}
+	if byKey["latency_p99|svc-search"].Factor != 1.2 {
+		t.Fatalf("expected explicit anomaly to win over cascade")
+	}
+	order, ok := byKey["http_request_duration_seconds|svc-order"]
+	if !ok || order.Factor != 1.5 {
+		t.Fatalf("expected second-hop cascade onto svc-order, got %+v", order)
+	}
+	if _, ok := byKey["http_request_duration_seconds|svc-database"]; ok {
+		t.Fatalf("origin service should not receive a cascade")
+	}
+
+	drops := withCascadingAnomalies([]ScenarioMetricAnomaly{{MetricName: "conversion_rate", Service: "svc-database", Factor: 0.7}})
+	if len(drops) != 1 {
+		t.Fatalf("expected drops not to propagate, got %d anomalies", len(drops))
+	}
+}
+
+func TestHistogramSeriesCarryExemplars(t *testing.T) {
+	provAny, _ := New(map[string]any{})
+	prov := provAny.(*Provider)
+
+	end := time.Date(2024, time.March, 4, 12, 0, 0, 0, time.UTC)
+	query := schema.MetricQuery{
+		Expression: &schema.MetricExpression{MetricName: "http_request_duration_seconds"},
+		Start:      end.Add(-time.Hour),
+		End:        end,
+		Step:       60,
+	}
+	series, err := prov.Query(context.Background(), query)
+	if err != nil {
+		t.Fatalf("Query returned error: %v", err)
+	}
+	exemplars, ok := series[0].Metadata["exemplars"].([]map[string]any)
+	if !ok || len(exemplars) == 0 || len(exemplars) > maxExemplarsPerSeries {
+		t.Fatalf("expected 1-%d exemplars, got %v", maxExemplarsPerSeries, series[0].Metadata["exemplars"])
+	}
+	for _, ex := range exemplars {
+		if id, _ := ex["traceId"].(string); len(id) != 32 {
+			t.Fatalf("expected 32-char trace id, got %v", ex["traceId"])
+		}
+	}
+	if _, ok := series[1].Metadata["exemplars"]; ok {
+		t.Fatalf("baseline series should not carry exemplars")
+	}
+
+	again, _ := prov.Query(context.Background(), query)
+	if again[0].Metadata["exemplars"].([]map[string]any)[0]["traceId"] != exemplars[0]["traceId"] {
+		t.Fatalf("expected stable trace ids across queries")
+	}
+
+	gauge, _ := prov.Query(context.Background(), schema.MetricQuery{Expression: &schema.MetricExpression{MetricName: "cpu_usage_ratio"}})
+	if _, ok := gauge[0].Metadata["exemplars"]; ok {
+		t.Fatalf("gauge series should not carry exemplars")
+	}
+}
+
+func TestNormalizedUnits(t *testing.T) {
+	provAny, _ := New(map[string]any{})
+	prov := provAny.(*Provider)
+
+	end := time.Date(2024, time.March, 4, 12, 0, 0, 0, time.UTC)
+	query := func(ctx context.Context, name string) schema.MetricSeries {
+		t.Helper()
+		series, err := prov.Query(ctx, schema.MetricQuery{
+			Expression: &schema.MetricExpression{MetricName: name},
+			Start:      end.Add(-30 * time.Minute),
+			End:        end,
+			Step:       60,
+		})
+		if err != nil || len(series) == 0 {
+			t.Fatalf("Query(%s) returned %d series, err %v", name, len(series), err)
+		}
+		return series[0]
+	}
+	normalized := WithNormalizedUnits(context.Background())
+
+	cases := []struct {
+		metric, from, to string
+		factor           float64
+	}{
+		{"memory_working_set_bytes", "bytes", "gibibytes", 1.0 / (1 << 30)},
+		{"http_request_duration_seconds", "seconds", "milliseconds", 1000},
+	}
+	for _, tc := range cases {
+		raw := query(context.Background(), tc.metric)
+		conv := query(normalized, tc.metric)
+		if raw.Metadata["unit"] != tc.from || conv.Metadata["unit"] != tc.to || conv.Metadata["originalUnit"] != tc.from {
+			t.Fatalf("%s: expected unit %s -> %s, got %v -> %v", tc.metric, tc.from, tc.to, raw.Metadata["unit"], conv.Metadata["unit"])
+		}
+		for i := range raw.Points {
+			want := raw.Points[i].Value * tc.factor
+			if math.Abs(conv.Points[i].Value-want) > 1e-9*math.Max(1, math.Abs(want)) {
+				t.Fatalf("%s point %d: expected %v, got %v", tc.metric, i, want, conv.Points[i].Value)
+			}
+		}
+	}
+
+	latency := query(normalized, "latency_p99")
+	if latency.Metadata["unit"] != "milliseconds" || latency.Metadata["originalUnit"] != nil {
+		t.Fatalf("expected canonical units to pass through, got %v", latency.Metadata)
+	}
+
+	aggQuery := AggregateQuery{MetricName: "memory_working_set_bytes", Start: end.Add(-time.Hour), End: end}
+	rawAgg, _ := prov.Aggregate(context.Background(), aggQuery)
+	convAgg, err := prov.Aggregate(normalized, aggQuery)
+	if err != nil {
+		t.Fatalf("Aggregate returned error: %v", err)
+	}
+	if convAgg.Unit != "gibibytes" || convAgg.OriginalUnit != "bytes" {
+		t.Fatalf("expected normalized aggregate unit, got %s from %s", convAgg.Unit, convAgg.OriginalUnit)
+	}
+	if got, want := convAgg.Rows[0].Value, rawAgg.Rows[0].Value/(1<<30); math.Abs(got-want) > 0.001 {
+		t.Fatalf("expected aggregate value %v GiB, got %v", want, got)
+	}
+}
+
+func TestQueryCostStatsAndBudget(t *testing.T) {
+	provAny, _ := New(map[string]any{"queryBudget": map[string]any{"maxWindow": "24h", "maxSamples": float64(100000)}})
+	prov := provAny.(*Provider)
+	ctx := context.Background()
+	end := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	scoped := schema.MetricQuery{
+		Expression: &schema.MetricExpression{MetricName: "http_requests_total"},
+		Start:      end.Add(-time.Hour),
+		End:        end,
+		Scope:      schema.QueryScope{Service: "svc-checkout"},
+	}
+	series, err := prov.Query(ctx, scoped)
+	if err != nil {
+		t.Fatalf("Query returned error: %v", err)
+	}
+	stats, ok := series[0].Metadata["queryStats"].(QueryStats)
+	if !ok || stats.SeriesScanned != 1 || stats.SamplesScanned != 240 || stats.ExecutionTimeMs <= 0 {
+		t.Fatalf("unexpected query stats %+v", series[0].Metadata["queryStats"])
+	}
+
+	wide := scoped
+	wide.Start = end.Add(-72 * time.Hour)
+	_, err = prov.Query(ctx, wide)
+	if err == nil || !strings.Contains(err.Error(), ErrCodeQueryTooExpensive) || !strings.Contains(err.Error(), "window 72h0m0s exceeds 24h0m0s") {
+		t.Fatalf("expected window budget rejection, got %v", err)
+	}
+
+	// All catalog metrics across every service blows the sample budget.
+	_, err = prov.Query(ctx, schema.MetricQuery{Start: end.Add(-6 * time.Hour), End: end})
+	if err == nil || !strings.Contains(err.Error(), "samples exceeds 100000") {
+		t.Fatalf("expected sample budget rejection, got %v", err)
+	}
+
+	res, err := prov.Aggregate(ctx, AggregateQuery{MetricName: "error_rate", Start: end.Add(-time.Hour), End: end})
+	if err != nil {
+		t.Fatalf("Aggregate returned error: %v", err)
+	}
+	if res.Stats.SeriesScanned != res.TotalGroups || res.Stats.SamplesScanned != int64(240*res.TotalGroups) {
+		t.Fatalf("unexpected aggregate stats %+v for %d groups", res.Stats, res.TotalGroups)
+	}
+
+	unlimitedAny, _ := New(nil)
+	if _, err := unlimitedAny.Query(ctx, wide); err != nil {
+		t.Fatalf("expected no budget by default, got %v", err)
+	}
+}
+
+func TestMonthLongWindowsStayCoherent(t *testing.T) {
+	provAny, _ := New(map[string]any{})
+	prov := provAny.(*Provider)
+	ctx := context.Background()
+	now := time.Now().UTC()
+	end := now.Truncate(time.Hour)
+	query := func(name string, start, end time.Time, step int) schema.MetricSeries {
+		t.Helper()
+		series, err := prov.Query(ctx, schema.MetricQuery{
+			Expression: &schema.MetricExpression{MetricName: name},
+			Scope:      schema.QueryScope{Service: "svc-checkout"},
+			Start:      start,
+			End:        end,
+			Step:       step,
+		})
+		if err != nil || len(series) == 0 {
+			t.Fatalf("Query(%s) returned %d series, err %v", name, len(series), err)
+		}
+		return series[0]
+	}
+
+	month := query("http_requests_total", end.Add(-30*24*time.Hour), end, 0)
+	if n := len(month.Points); n < 1000 || n > defaultPoints+1 {
+		t.Fatalf("expected the default step to widen for a month, got %d points", n)
+	}
+	var weekday, weekend []float64
+	for i := 1; i < len(month.Points); i++ {
+		prev, cur := month.Points[i-1], month.Points[i]
+		if cur.Value < prev.Value {
+			t.Fatalf("counter decreased at %v", cur.Timestamp)
+		}
+		rate := (cur.Value - prev.Value) / cur.Timestamp.Sub(prev.Timestamp).Minutes()
+		if day := prev.Timestamp.Weekday(); day == time.Saturday || day == time.Sunday {
+			weekend = append(weekend, rate)
+		} else {
+			weekday = append(weekday, rate)
+		}
+	}
+	if mean(weekend) >= mean(weekday)*0.8 {
+		t.Fatalf("expected quieter weekends, got weekday %.2f/min weekend %.2f/min", mean(weekday), mean(weekend))
+	}
+
+	// Overlapping windows agree on the values they share.
+	for _, name := range []string{"http_requests_total", "websocket_connections_active", "memory_working_set_bytes"} {
+		long := query(name, end.Add(-30*24*time.Hour), end.Add(-2*24*time.Hour), 3600)
+		short := query(name, end.Add(-5*24*time.Hour), end.Add(-4*24*time.Hour), 3600)
+		byTime := map[time.Time]float64{}
+		for _, pt := range long.Points {
+			byTime[pt.Timestamp] = pt.Value
+		}
+		for _, pt := range short.Points {
+			want, ok := byTime[pt.Timestamp]
+			if !ok || math.Abs(pt.Value-want) > 0.05 {
+				t.Fatalf("%s at %v: short window %v, long window %v (present %v)", name, pt.Timestamp, pt.Value, want, ok)
+			}
+		}
+	}
+
+	// Gauges do not drift without bound over a month. Points under an alert
+	// carry its spike on top and are left out.
+	def := metricCatalogIndex["memory_working_set_bytes"]
+	weight := serviceWeight(def, "svc-checkout")
+	limit := (def.Profile.amplitude*2 + def.Profile.trend*driftHorizon) * weight
+	alerts := mockutil.SnapshotAlerts()
+	for _, pt := range query("memory_working_set_bytes", end.Add(-30*24*time.Hour), end, 0).Points {
+		if factor, _ := mockutil.StrongestAlertFactor("svc-checkout", pt.Timestamp, alerts); factor > 1.01 {
+			continue
+		}
+		if math.Abs(pt.Value-def.Profile.baseline*weight) > limit {
+			t.Fatalf("memory drifted to %v at %v", pt.Value, pt.Timestamp)
+		}
+	}
+
+	// Scenario anomalies stay in the recent window.
+	old := query("http_request_duration_seconds", now.Add(-11*24*time.Hour), now.Add(-10*24*time.Hour), 0)
+	if _, ok := old.Metadata["scenario_effects"]; ok {
+		t.Fatalf("scenario anomalies leaked into a window ten days ago")
+	}
+	recent := query("http_request_duration_seconds", now.Add(-30*24*time.Hour), now, 60)
+	if _, ok := recent.Metadata["scenario_effects"]; !ok {
+		t.Fatalf("expected scenario anomalies in a month window ending now")
+	}
+	// Widened buckets overlapping an anomaly carry it, whatever step was asked
+	// for.
+	for _, step := range []int{0, 300} {
+		if _, ok := query("http_request_duration_seconds", now.Add(-30*24*time.Hour), now, step).Metadata["scenario_effects"]; !ok {
+			t.Fatalf("expected scenario anomalies in a month window at step %d", step)
+		}
+	}
+
+	// A 60s step over a month is widened rather than returning 43k points.
+	widened := query("http_request_duration_seconds", now.Add(-30*24*time.Hour), now, 60)
+	if widened.Metadata["step"] != "30m0s" || widened.Metadata["stepAdapted"] != true || widened.Metadata["requestedStep"] != "1m0s" {
+		t.Fatalf("expected the month window widened to 30m, got step %v adapted %v", widened.Metadata["step"], widened.Metadata["stepAdapted"])
+	}
+	if n := len(widened.Points); n > defaultPoints+1 {
+		t.Fatalf("expected at most %d points, got %d", defaultPoints+1, n)
+	}
+}
+
+func TestResolveStep(t *testing.T) {
+	tests := []struct {
+		requested int
+		window    time.Duration
+		want      time.Duration
+		adapted   bool
+	}{
+		{0, 30 * time.Minute, time.Minute, false},
+		{0, 24 * time.Hour, time.Minute, false},
+		{0, 7 * 24 * time.Hour, 10 * time.Minute, false},
+		{60, 7 * 24 * time.Hour, 10 * time.Minute, true},
+		{300, 2 * 24 * time.Hour, 5 * time.Minute, false},
+		{3600, 30 * 24 * time.Hour, time.Hour, false},
+		{60, 90 * 24 * time.Hour, 2 * time.Hour, true},
+		{60, 5 * 365 * 24 * time.Hour, 48 * time.Hour, true},
+	}
+	for _, tc := range tests {
+		got, adapted := resolveStep(tc.requested, tc.window)
+		if got != tc.want || adapted != tc.adapted {
+			t.Errorf("resolveStep(%d, %s) = %s, %v; want %s, %v", tc.requested, tc.window, got, adapted, tc.want, tc.adapted)
+		}
+	}
+}
+
+func mean(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sum := 0.0
+	for _, v := range values {
+		sum += v
+	}
+	return sum / float64(len(values))
+}
+
+func TestApplyAnomalyTemplate(t *testing.T) {
+	provAny, _ := New(map[string]any{})
+	prov := provAny.(*Provider)
+	ctx := context.Background()
+	now := time.Now().UTC()
+
+	if _, err := prov.ApplyTemplate(ctx, "no-such-template", TemplateTarget{Service: "svc-order"}); err == nil || !strings.Contains(err.Error(), "not_found") {
+		t.Fatalf("expected not_found for an unknown template, got %v", err)
+	}
+	applied, err := prov.ApplyTemplate(ctx, "connection-pool-exhaustion", TemplateTarget{Service: "svc-order", Start: now.Add(-10 * time.Minute), End: now})
+	if err != nil {
+		t.Fatalf("ApplyTemplate returned error: %v", err)
+	}
+	if len(applied) != len(anomalyTemplates["connection-pool-exhaustion"].Effects) {
+		t.Fatalf("got %d anomalies, want one per template effect", len(applied))
+	}
+
+	query := func(name string) schema.MetricSeries {
+		t.Helper()
+		series, err := prov.Query(ctx, schema.MetricQuery{
+			Expression: &schema.MetricExpression{MetricName: name},
+			Scope:      schema.QueryScope{Service: "svc-order"},
+			Start:      now.Add(-30 * time.Minute),
+			End:        now,
+			Step:       60,
+		})
+		if err != nil || len(series) == 0 {
+			t.Fatalf("Query(%s) returned %d series, err %v", name, len(series), err)
+		}
+		return series[0]
+	}
+	max := query("db_connections_max").Points[0].Value
+	active := query("db_connections_active")
+	if last := active.Points[len(active.Points)-1].Value; last != max {
+		t.Fatalf("expected active connections pegged at max %v, got %v", max, last)
+	}
+	for _, name := range []string{"db_connections_active", "http_request_duration_seconds", "http_errors_total"} {
+		effects, _ := query(name).Metadata["scenario_effects"].([]map[string]any)
+		found := false
+		for _, effect := range effects {
+			found = found || effect["scenario_id"] == "template-connection-pool-exhaustion"
+		}
+		if !found {
+			t.Fatalf("%s missing the template's scenario effect: %+v", name, effects)
+		}
+	}
+}
+
+func TestInjectAnomaly(t *testing.T) {
+	provAny, _ := New(map[string]any{})
+	prov := provAny.(*Provider)
+	ctx := context.Background()
+	now := time.Now().UTC()
+
+	if _, err := prov.InjectAnomaly(ctx, AnomalyInjection{MetricName: "no_such_metric", Factor: 2}); err == nil || !strings.Contains(err.Error(), "bad_request") {
+		t.Fatalf("expected bad_request for an unknown metric, got %v", err)
+	}
+	if _, err := prov.InjectAnomaly(ctx, AnomalyInjection{MetricName: "queue_depth", Factor: 2, Value: floatPtr(5)}); err == nil {
+		t.Fatal("expected an error when both factor and value are set")
+	}
+
+	injected, err := prov.InjectAnomaly(ctx, AnomalyInjection{MetricName: "queue_depth", Service: "svc-order", Value: floatPtr(9999), Start: now.Add(-10 * time.Minute), End: now})
+	if err != nil {
+		t.Fatalf("InjectAnomaly returned error: %v", err)
+	}
+	series, err := prov.Query(ctx, schema.MetricQuery{
+		Expression: &schema.MetricExpression{MetricName: "queue_depth"},
+		Scope:      schema.QueryScope{Service: "svc-order"},
+		Start:      now.Add(-30 * time.Minute),
+		End:        now,
+		Step:       60,
+	})
+	if err != nil || len(series) == 0 {
+		t.Fatalf("Query returned %d series, err %v", len(series), err)
+	}
+	points := series[0].Points
+	if last := points[len(points)-1].Value; last != 9999 {
+		t.Fatalf("expected the injected plateau at the end of the window, got %v", last)
+	}
+	if first := points[0].Value; first == 9999 {
+		t.Fatal("injected plateau leaked before its window")
+	}
+	effects, _ := series[0].Metadata["scenario_effects"].([]map[string]any)
+	if len(effects) != 1 || effects[0]["scenario_id"] != injected.ScenarioID || effects[0]["stage"] != "injected" {
+		t.Fatalf("expected the injected anomaly in scenario_effects, got %+v", effects)
+	}
+}
+
+func TestEndpointSeries(t *testing.T) {
+	provAny, _ := New(map[string]any{})
+	prov := provAny.(*Provider)
+	ctx := context.Background()
+	now := time.Now().UTC()
+	window := EndpointQuery{Service: "svc-checkout", Start: now.Add(-30 * time.Minute), End: now, Step: 60}
+
+	if _, err := prov.InjectAnomaly(ctx, AnomalyInjection{MetricName: "http_request_duration_seconds", Service: "svc-checkout", Value: floatPtr(2), Start: now.Add(-10 * time.Minute), End: now}); err != nil {
+		t.Fatalf("InjectAnomaly returned error: %v", err)
+	}
+	latency, err := prov.EndpointSeries(ctx, window)
+	if err != nil {
+		t.Fatalf("EndpointSeries returned error: %v", err)
+	}
+	if len(latency) != 3 || latency[0].Labels["endpoint"] != "PlaceOrder" || latency[0].Labels["slo_tier"] != "critical" {
+		t.Fatalf("unexpected endpoint series %+v", latency)
+	}
+	// The service-level spike shows up on every endpoint, scaled by its factor.
+	if last := latency[0].Points[len(latency[0].Points)-1].Value; last != 3.2 {
+		t.Fatalf("expected PlaceOrder latency 3.2s during the anomaly, got %v", last)
+	}
+
+	requests := window
+	requests.MetricName = "http_requests_total"
+	byEndpoint, err := prov.EndpointSeries(ctx, requests)
+	if err != nil {
+		t.Fatalf("EndpointSeries returned error: %v", err)
+	}
+	service, _ := prov.Query(ctx, schema.MetricQuery{
+		Expression: &schema.MetricExpression{MetricName: "http_requests_total"},
+		Scope:      schema.QueryScope{Service: "svc-checkout"},
+		Start:      window.Start,
+		End:        window.End,
+		Step:       window.Step,
+	})
+	var sum float64
+	for _, s := range byEndpoint {
+		sum += s.Points[0].Value
+	}
+	if want := service[0].Points[0].Value; math.Abs(sum-want) > 0.01 {
+		t.Fatalf("endpoint requests sum to %v, want the service's %v", sum, want)
+	}
+
+	window.Endpoint = "GET /v1/cart"
+	if one, err := prov.EndpointSeries(ctx, window); err != nil || len(one) != 1 || one[0].Labels["endpoint"] != "GetCart" {
+		t.Fatalf("expected only GetCart, got %+v, err %v", one, err)
+	}
+	window.Endpoint = "Search"
+	if _, err := prov.EndpointSeries(ctx, window); err == nil || !strings.Contains(err.Error(), "not_found") {
+		t.Fatalf("expected not_found for an unknown endpoint, got %v", err)
+	}
+	if _, err := prov.EndpointSeries(ctx, EndpointQuery{Service: "svc-checkout", MetricName: "queue_depth"}); err == nil || !strings.Contains(err.Error(), "bad_request") {
+		t.Fatalf("expected bad_request for a metric without endpoints, got %v", err)
+	}
+}
+
+func TestEdgeSeries(t *testing.T) {
+	provAny, _ := New(map[string]any{})
+	prov := provAny.(*Provider)
+	ctx := context.Background()
+	now := time.Now().UTC()
+	window := EdgeQuery{Start: now.Add(-30 * time.Minute), End: now, Step: 60}
+
+	outgoing := window
+	outgoing.Source = "svc-checkout"
+	edges, err := prov.EdgeSeries(ctx, outgoing)
+	if err != nil {
+		t.Fatalf("EdgeSeries returned error: %v", err)
+	}
+	if len(edges) != 4 || edges[0].Labels["source"] != "svc-checkout" || edges[0].Labels["destination"] != "svc-database" {
+		t.Fatalf("unexpected outgoing edges %+v", edges)
+	}
+
+	// The edges into a service split its traffic between its callers.
+	incoming := window
+	incoming.Destination = "svc-payments"
+	edges, err = prov.EdgeSeries(ctx, incoming)
+	if err != nil {
+		t.Fatalf("EdgeSeries returned error: %v", err)
+	}
+	service, _ := prov.Query(ctx, schema.MetricQuery{
+		Expression: &schema.MetricExpression{MetricName: "http_requests_total"},
+		Scope:      schema.QueryScope{Service: "svc-payments"},
+		Start:      window.Start,
+		End:        window.End,
+		Step:       window.Step,
+	})
+	var sum float64
+	for _, s := range edges {
+		if s.Labels["destination"] != "svc-payments" {
+			t.Fatalf("unexpected edge %+v", s.Labels)
+		}
+		sum += s.Points[0].Value
+	}
+	if len(edges) != 2 || math.Abs(sum-service[0].Points[0].Value) > 0.01 {
+		t.Fatalf("expected 2 edges summing to %v, got %d summing to %v", service[0].Points[0].Value, len(edges), sum)
+	}
+
+	latency := window
+	latency.Source, latency.Destination, latency.MetricName = "svc-checkout", "svc-payments", "mesh_request_duration_seconds"
+	edges, err = prov.EdgeSeries(ctx, latency)
+	if err != nil || len(edges) != 1 {
+		t.Fatalf("expected one latency edge, got %+v, err %v", edges, err)
+	}
+	overhead, _ := edges[0].Metadata["networkOverhead"].(float64)
+	if overhead <= 0 {
+		t.Fatalf("expected a network overhead on the edge, got %v", edges[0].Metadata)
+	}
+
+	missing := window
+	missing.Source, missing.Destination = "svc-payments", "svc-checkout"
+	if _, err := prov.EdgeSeries(ctx, missing); err == nil || !strings.Contains(err.Error(), "not_found") {
+		t.Fatalf("expected not_found for an edge outside the topology, got %v", err)
+	}
+	if _, err := prov.EdgeSeries(ctx, EdgeQuery{MetricName: "queue_depth"}); err == nil || !strings.Contains(err.Error(), "bad_request") {
+		t.Fatalf("expected bad_request for a non-mesh metric, got %v", err)
+	}
+}
+
+func TestSeriesCarryAlertThresholds(t *testing.T) {
+	provAny, _ := New(map[string]any{})
+	prov := provAny.(*Provider)
+
+	end := time.Date(2024, time.March, 4, 12, 0, 0, 0, time.UTC)
+	query := func(ctx context.Context, name string) schema.MetricSeries {
+		t.Helper()
+		series, err := prov.Query(ctx, schema.MetricQuery{
+			Expression: &schema.MetricExpression{MetricName: name},
+			Start:      end.Add(-30 * time.Minute),
+			End:        end,
+			Step:       60,
+		})
+		if err != nil || len(series) == 0 {
+			t.Fatalf("Query(%s) returned %d series, err %v", name, len(series), err)
+		}
+		return series[0]
+	}
+
+	latency := query(context.Background(), "http_request_duration_seconds")
+	th, ok := latency.Metadata["thresholds"].(mockutil.AlertThreshold)
+	if !ok || th.Rule != "high-latency" || th.Warning != 0.8 || th.Critical != 1.2 || th.Unit != "seconds" {
+		t.Fatalf("expected high-latency thresholds on the latency series, got %v", latency.Metadata["thresholds"])
+	}
+	normalized := query(WithNormalizedUnits(context.Background()), "http_request_duration_seconds")
+	th, _ = normalized.Metadata["thresholds"].(mockutil.AlertThreshold)
+	if th.Unit != "milliseconds" || math.Abs(th.Critical-1200) > 1e-9 {
+		t.Fatalf("expected thresholds converted with the series, got %+v", th)
+	}
+
+	if revenue := query(context.Background(), "revenue_total"); revenue.Metadata["thresholds"] != nil {
+		t.Fatalf("expected no thresholds without an alert rule, got %v", revenue.Metadata["thresholds"])
+	}
+}
+
+func TestMinimalFeatures(t *testing.T) {
+	provAny, _ := New(map[string]any{"minimal": true})
+	prov := provAny.(*Provider)
+
+	end := time.Now().UTC()
+	series, err := prov.Query(context.Background(), schema.MetricQuery{
+		Scope:      schema.QueryScope{Service: "svc-checkout"},
+		Start:      end.Add(-30 * time.Minute),
+		End:        end,
+		Step:       60,
+		Expression: &schema.MetricExpression{MetricName: "http_request_duration_seconds"},
+	})
+	if err != nil || len(series) == 0 {
+		t.Fatalf("expected series, got %d (%v)", len(series), err)
+	}
+	for _, key := range []string{"scenario_effects", "alerts"} {
+		if _, ok := series[0].Metadata[key]; ok {
+			t.Fatalf("expected no %s in minimal mode, got %v", key, series[0].Metadata[key])
+		}
+	}
+}
+
+func TestSeededSmoothNoise(t *testing.T) {
+	start := time.Date(2025, 3, 4, 10, 0, 0, 0, time.UTC)
+	sample := func(cfg NoiseConfig, service string) []float64 {
+		noise := cfg.forSeries("http_request_duration_seconds", service)
+		out := make([]float64, 240)
+		for i := range out {
+			out[i] = noise.at(start.Add(time.Duration(i) * time.Minute))
+		}
+		return out
+	}
+	roughness := func(vals []float64) float64 {
+		sum := 0.0
+		for i := 1; i < len(vals); i++ {
+			sum += math.Abs(vals[i] - vals[i-1])
+		}
+		return sum / float64(len(vals)-1)
+	}
+
+	base := sample(NoiseConfig{Seed: 7, Roughness: 0.5}, "svc-checkout")
+	if again := sample(NoiseConfig{Seed: 7, Roughness: 0.5}, "svc-checkout"); !reflect.DeepEqual(base, again) {
+		t.Fatalf("expected the same seed to reproduce the noise")
+	}
+	if reflect.DeepEqual(base, sample(NoiseConfig{Seed: 8, Roughness: 0.5}, "svc-checkout")) {
+		t.Fatalf("expected a different seed to change the noise")
+	}
+	if reflect.DeepEqual(base, sample(NoiseConfig{Seed: 7, Roughness: 0.5}, "svc-search")) {
+		t.Fatalf("expected services to get different noise")
+	}
+	periodic := true
+	for i := 4; i < len(base); i++ {
+		if base[i] != base[i-4] {
+			periodic = false
+		}
+		if base[i] < -1 || base[i] > 1 {
+			t.Fatalf("noise out of range at %d: %v", i, base[i])
+		}
+	}
+	if periodic {
+		t.Fatalf("expected noise not to repeat every four minutes")
+	}
+	smooth := roughness(sample(NoiseConfig{Seed: 7}, "svc-checkout"))
+	rough := roughness(sample(NoiseConfig{Seed: 7, Roughness: 1}, "svc-checkout"))
+	if smooth >= rough {
+		t.Fatalf("expected roughness 1 to jitter more than 0, got %.3f vs %.3f", rough, smooth)
+	}
+
+	if cfg := parseConfig(map[string]any{"noise": map[string]any{"seed": float64(42), "roughness": 0.9}}); cfg.Noise != (NoiseConfig{Seed: 42, Roughness: 0.9}) {
+		t.Fatalf("unexpected noise config %+v", cfg.Noise)
+	}
+	if cfg := parseConfig(nil); cfg.Noise != (NoiseConfig{Seed: defaultNoiseSeed, Roughness: defaultNoiseRoughness}) {
+		t.Fatalf("unexpected default noise config %+v", cfg.Noise)
+	}
+}
+
+func TestTimezoneLeavesFlatGaugesUnshaped(t *testing.T) {
+	if _, err := time.LoadLocation("Europe/Berlin"); err != nil {
+		t.Skipf("timezone data unavailable: %v", err)
+	}
+	utcAny, _ := New(nil)
+	berlinAny, _ := New(map[string]any{"timezone": "Europe/Berlin"})
+	// Saturday 03:00 UTC, deep in the local weekend trough.
+	start := time.Date(2025, 12, 13, 3, 0, 0, 0, time.UTC)
+	query := func(prov any, name string) []schema.MetricPoint {
+		t.Helper()
+		series, err := prov.(*Provider).Query(context.Background(), schema.MetricQuery{
+			Expression: &schema.MetricExpression{MetricName: name},
+			Start:      start,
+			End:        start.Add(time.Hour),
+			Step:       300,
+		})
+		if err != nil || len(series) == 0 {
+			t.Fatalf("Query(%s) returned %d series, err %v", name, len(series), err)
+		}
+		return series[0].Points
+	}
+	for _, name := range []string{"db_connections_max", "node_ready_status", "cache_hit_ratio"} {
+		utc, berlin := query(utcAny, name), query(berlinAny, name)
+		for i := range utc {
+			if berlin[i].Value != utc[i].Value {
+				t.Fatalf("expected %s unchanged under a timezone, got %v instead of %v", name, berlin[i].Value, utc[i].Value)
+			}
+		}
+	}
+}
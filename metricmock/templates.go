@@ -0,0 +1,271 @@
+package metricmock
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/opsorch/opsorch-core/orcherr"
+)
+
+// defaultTemplateWindow is how long an applied template lasts when the caller
+// gives no end.
+const defaultTemplateWindow = 15 * time.Minute
+
+// TemplateEffect is one metric symptom of an anomaly template. Exactly one of
+// Factor, Value, or PegTo is set.
+type TemplateEffect struct {
+	MetricName string  `json:"metric"`
+	Factor     float64 `json:"factor,omitempty"`
+	// Value pins the metric to a fixed value.
+	Value *float64 `json:"value,omitempty"`
+	// PegTo pins the metric to another metric's baseline for the same
+	// service, e.g. active connections held at db_connections_max.
+	PegTo string `json:"pegTo,omitempty"`
+	// Delay shifts the effect's onset after the template starts, so
+	// downstream symptoms trail their cause.
+	Delay       time.Duration `json:"delay,omitempty"`
+	Description string        `json:"description"`
+	AnomalyType string        `json:"anomalyType"`
+}
+
+// AnomalyTemplate bundles the correlated metric effects of one failure mode
+// so it can be applied to any service in one step.
+type AnomalyTemplate struct {
+	Name        string           `json:"name"`
+	Title       string           `json:"title"`
+	Description string           `json:"description"`
+	Effects     []TemplateEffect `json:"effects"`
+}
+
+// anomalyTemplates are keyed by name.
+var anomalyTemplates = map[string]AnomalyTemplate{
+	"connection-pool-exhaustion": {
+		Name:        "connection-pool-exhaustion",
+		Title:       "Connection pool exhaustion",
+		Description: "Every pooled database connection is checked out, so queries queue, requests slow down, and timeouts turn into errors",
+		Effects: []TemplateEffect{
+			{MetricName: "db_connections_active", PegTo: "db_connections_max", Description: "Database connection pool exhausted", AnomalyType: "resource_exhaustion"},
+			{MetricName: "db_query_duration_seconds", Factor: 4.5, Description: "Slow queries due to connection pool exhaustion", AnomalyType: "latency_spike"},
+			{MetricName: "http_request_duration_seconds", Factor: 4, Delay: time.Minute, Description: "Requests wait on a free connection", AnomalyType: "latency_spike"},
+			{MetricName: "http_errors_total", Factor: 5, Delay: 2 * time.Minute, Description: "Connection timeouts surface as request errors", AnomalyType: "error_spike"},
+			{MetricName: "error_rate", Factor: 5, Delay: 2 * time.Minute, Description: "Error ratio rises with connection timeouts", AnomalyType: "error_spike"},
+		},
+	},
+	"memory-leak": {
+		Name:        "memory-leak",
+		Title:       "Memory leak",
+		Description: "Working set grows until containers are OOM-killed and restart",
+		Effects: []TemplateEffect{
+			{MetricName: "memory_working_set_bytes", Factor: 1.9, Description: "Working set climbs without being released", AnomalyType: "resource_pressure"},
+			{MetricName: "memory_available_bytes", Factor: 0.25, Description: "Available memory runs out", AnomalyType: "resource_pressure"},
+			{MetricName: "container_restarts_total", Factor: 3, Delay: 5 * time.Minute, Description: "Containers restart after OOM kills", AnomalyType: "deployment_instability"},
+			{MetricName: "http_request_duration_seconds", Factor: 1.8, Delay: 3 * time.Minute, Description: "Garbage collection pauses slow requests", AnomalyType: "latency_spike"},
+		},
+	},
+	"cpu-saturation": {
+		Name:        "cpu-saturation",
+		Title:       "CPU saturation",
+		Description: "Pods run at their CPU limit and are throttled, so latency climbs with load",
+		Effects: []TemplateEffect{
+			{MetricName: "cpu_usage_ratio", Factor: 2.2, Description: "CPU pinned near the container limit", AnomalyType: "resource_pressure"},
+			{MetricName: "cpu_throttling_seconds_total", Factor: 4, Description: "CFS throttling surges", AnomalyType: "resource_pressure"},
+			{MetricName: "load_average_1m", Factor: 3, Description: "Run queue backs up", AnomalyType: "resource_pressure"},
+			{MetricName: "http_request_duration_seconds", Factor: 2.5, Delay: time.Minute, Description: "Throttled pods answer slowly", AnomalyType: "latency_spike"},
+		},
+	},
+	"cache-stampede": {
+		Name:        "cache-stampede",
+		Title:       "Cache stampede",
+		Description: "Hot keys expire together and every miss falls through to the database",
+		Effects: []TemplateEffect{
+			{MetricName: "cache_hit_ratio", Factor: 0.45, Description: "Hit ratio collapses as hot keys expire", AnomalyType: "cache_miss"},
+			{MetricName: "cache_misses_total", Factor: 6, Description: "Misses surge on the expired keys", AnomalyType: "cache_miss"},
+			{MetricName: "db_query_duration_seconds", Factor: 3, Delay: time.Minute, Description: "The database absorbs the cache misses", AnomalyType: "latency_spike"},
+			{MetricName: "http_request_duration_seconds", Factor: 2, Delay: time.Minute, Description: "Requests wait on database reads", AnomalyType: "latency_spike"},
+		},
+	},
+	"queue-backlog": {
+		Name:        "queue-backlog",
+		Title:       "Queue backlog",
+		Description: "Consumers fall behind producers and work piles up",
+		Effects: []TemplateEffect{
+			{MetricName: "queue_depth", Factor: 4, Description: "Queue depth grows faster than it drains", AnomalyType: "queue_backlog"},
+			{MetricName: "kafka_consumer_lag", Factor: 5, Description: "Consumer lag keeps climbing", AnomalyType: "queue_backlog"},
+			{MetricName: "background_jobs_queued", Factor: 3, Delay: 2 * time.Minute, Description: "Background jobs wait for workers", AnomalyType: "queue_backlog"},
+		},
+	},
+}
+
+// AnomalyTemplates lists the built-in anomaly templates ordered by name.
+func AnomalyTemplates() []AnomalyTemplate {
+	out := make([]AnomalyTemplate, 0, len(anomalyTemplates))
+	for _, tmpl := range anomalyTemplates {
+		out = append(out, tmpl)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+// TemplateTarget says where and when to apply an anomaly template. Scenario
+// fields label the resulting scenario_effects; they default to the template.
+type TemplateTarget struct {
+	Service      string    `json:"service"`
+	Start        time.Time `json:"start,omitempty"`
+	End          time.Time `json:"end,omitempty"`
+	ScenarioID   string    `json:"scenarioId,omitempty"`
+	ScenarioName string    `json:"scenarioName,omitempty"`
+	StageName    string    `json:"stage,omitempty"`
+}
+
+// templateAnomalies expands the named template into one anomaly per effect
+// for target.Service. Pegged effects resolve to the limit metric's value for
+// that service, so a pegged pool reads exactly at its maximum.
+func templateAnomalies(name string, target TemplateTarget) ([]ScenarioMetricAnomaly, error) {
+	tmpl, ok := anomalyTemplates[name]
+	if !ok {
+		return nil, orcherr.New("not_found", fmt.Sprintf("anomaly template %q not found", name), nil)
+	}
+	if target.Service == "" {
+		return nil, orcherr.New("bad_request", "service is required", nil)
+	}
+	if target.ScenarioID == "" {
+		target.ScenarioID = "template-" + tmpl.Name
+	}
+	if target.ScenarioName == "" {
+		target.ScenarioName = tmpl.Title
+	}
+	if target.StageName == "" {
+		target.StageName = "active"
+	}
+
+	out := make([]ScenarioMetricAnomaly, 0, len(tmpl.Effects))
+	for _, effect := range tmpl.Effects {
+		anomaly := ScenarioMetricAnomaly{
+			ScenarioID:   target.ScenarioID,
+			ScenarioName: target.ScenarioName,
+			StageName:    target.StageName,
+			MetricName:   effect.MetricName,
+			Service:      target.Service,
+			Factor:       effect.Factor,
+			Value:        effect.Value,
+			Start:        target.Start,
+			End:          target.End,
+			Description:  effect.Description,
+			Metadata: map[string]any{
+				"anomaly_type": effect.AnomalyType,
+				"template":     tmpl.Name,
+			},
+		}
+		if !anomaly.Start.IsZero() {
+			anomaly.Start = anomaly.Start.Add(effect.Delay)
+		}
+		if effect.PegTo != "" {
+			limit := metricCatalogIndex[effect.PegTo]
+			// Rounded like generated points.
+			value := math.Round(limit.Profile.baseline*serviceWeight(limit, target.Service)*100) / 100
+			anomaly.Value = &value
+			anomaly.Metadata["pegged_to"] = effect.PegTo
+		}
+		out = append(out, anomaly)
+	}
+	return out, nil
+}
+
+// ApplyTemplate applies a template to a service from now on, so later
+// queries show its correlated effects (and their cascade onto callers) in
+// scenario_effects. Start defaults to now and End to defaultTemplateWindow
+// after Start. It returns the anomalies added.
+func (p *Provider) ApplyTemplate(ctx context.Context, name string, target TemplateTarget) ([]ScenarioMetricAnomaly, error) {
+	_ = ctx
+	if target.Start.IsZero() {
+		target.Start = time.Now().UTC()
+	}
+	if target.End.IsZero() {
+		target.End = target.Start.Add(defaultTemplateWindow)
+	}
+	if !target.End.After(target.Start) {
+		return nil, orcherr.New("bad_request", "end must be after start", nil)
+	}
+	anomalies, err := templateAnomalies(name, target)
+	if err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.applied = append(p.applied, anomalies...)
+	return anomalies, nil
+}
+
+// scenarioAnomalies returns the built-in scenario anomalies, the anomalies
+// applied at runtime, and their cascade onto callers.
+func (p *Provider) scenarioAnomalies(now time.Time) []ScenarioMetricAnomaly {
+	var anomalies []ScenarioMetricAnomaly
+	if p.cfg.Features.ScenarioEffects {
+		anomalies = getScenarioMetricAnomalies(now)
+	}
+	p.mu.Lock()
+	anomalies = append(anomalies, p.applied...)
+	p.mu.Unlock()
+	return withCascadingAnomalies(anomalies)
+}
+
+// AnomalyInjection is a single metric anomaly added live, e.g. by a demo
+// operator. Exactly one of Factor and Value is set.
+type AnomalyInjection struct {
+	MetricName string   `json:"metric"`
+	Service    string   `json:"service,omitempty"`
+	Factor     float64  `json:"factor,omitempty"`
+	Value      *float64 `json:"value,omitempty"`
+	// Start defaults to now and End to defaultTemplateWindow after Start.
+	Start       time.Time `json:"start,omitempty"`
+	End         time.Time `json:"end,omitempty"`
+	Description string    `json:"description,omitempty"`
+}
+
+// InjectAnomaly scales or pins one metric over a window. An empty Service
+// affects the metric on every service. The anomaly shows up in later queries
+// like a scenario's, under scenario "injected-N" with stage "injected".
+func (p *Provider) InjectAnomaly(ctx context.Context, in AnomalyInjection) (ScenarioMetricAnomaly, error) {
+	_ = ctx
+	if _, ok := metricCatalogIndex[in.MetricName]; !ok {
+		return ScenarioMetricAnomaly{}, orcherr.New("bad_request", fmt.Sprintf("unknown metric %q", in.MetricName), nil)
+	}
+	if (in.Value == nil) == (in.Factor <= 0) {
+		return ScenarioMetricAnomaly{}, orcherr.New("bad_request", "set exactly one of factor (> 0) and value", nil)
+	}
+	if in.Start.IsZero() {
+		in.Start = time.Now().UTC()
+	}
+	if in.End.IsZero() {
+		in.End = in.Start.Add(defaultTemplateWindow)
+	}
+	if !in.End.After(in.Start) {
+		return ScenarioMetricAnomaly{}, orcherr.New("bad_request", "end must be after start", nil)
+	}
+	if in.Description == "" {
+		in.Description = "Injected " + in.MetricName + " anomaly"
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.injected++
+	anomaly := ScenarioMetricAnomaly{
+		ScenarioID:   fmt.Sprintf("injected-%d", p.injected),
+		ScenarioName: "Injected anomaly",
+		StageName:    "injected",
+		MetricName:   in.MetricName,
+		Service:      in.Service,
+		Factor:       in.Factor,
+		Value:        in.Value,
+		Start:        in.Start,
+		End:          in.End,
+		Description:  in.Description,
+		Metadata:     map[string]any{"anomaly_type": "injected"},
+	}
+	p.applied = append(p.applied, anomaly)
+	return anomaly, nil
+}
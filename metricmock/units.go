@@ -0,0 +1,66 @@
+package metricmock
+
+import (
+	"context"
+
+	"github.com/opsorch/opsorch-core/schema"
+	"github.com/opsorch/opsorch-mock-adapters/internal/mockutil"
+)
+
+// unitConversion rescales a raw unit into the canonical unit hosts display.
+type unitConversion struct {
+	To     string
+	Factor float64
+}
+
+// canonicalUnits lists the raw units normalization rewrites. Units not listed
+// are already canonical and pass through untouched.
+var canonicalUnits = map[string]unitConversion{
+	"bytes":   {To: "gibibytes", Factor: 1.0 / (1 << 30)},
+	"seconds": {To: "milliseconds", Factor: 1000},
+}
+
+// WithNormalizedUnits makes Query and Aggregate return values in canonical
+// units (bytes as GiB, seconds as milliseconds).
+func WithNormalizedUnits(ctx context.Context) context.Context {
+	return context.WithValue(ctx, normalizeUnitsKey{}, true)
+}
+
+type normalizeUnitsKey struct{}
+
+func normalizeUnits(ctx context.Context) bool {
+	if ctx == nil {
+		return false
+	}
+	v, _ := ctx.Value(normalizeUnitsKey{}).(bool)
+	return v
+}
+
+// normalizeSeries converts a series' points, exemplars, and alert thresholds in place and records
+// the original unit and factor next to the converted unit in metadata.
+func normalizeSeries(series *schema.MetricSeries) {
+	unit, _ := series.Metadata["unit"].(string)
+	conv, ok := canonicalUnits[unit]
+	if !ok {
+		return
+	}
+	for i := range series.Points {
+		series.Points[i].Value *= conv.Factor
+	}
+	if exemplars, ok := series.Metadata["exemplars"].([]map[string]any); ok {
+		for _, ex := range exemplars {
+			if v, ok := ex["value"].(float64); ok {
+				ex["value"] = v * conv.Factor
+			}
+		}
+	}
+	if th, ok := series.Metadata["thresholds"].(mockutil.AlertThreshold); ok && th.Unit == unit {
+		th.Warning *= conv.Factor
+		th.Critical *= conv.Factor
+		th.Unit = conv.To
+		series.Metadata["thresholds"] = th
+	}
+	series.Metadata["unit"] = conv.To
+	series.Metadata["originalUnit"] = unit
+	series.Metadata["unitConversion"] = map[string]any{"from": unit, "to": conv.To, "factor": conv.Factor}
+}
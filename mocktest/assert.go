@@ -0,0 +1,108 @@
+package mocktest
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/opsorch/opsorch-core/schema"
+)
+
+// RequireIncidentLinkedToAlert fails the test unless inc and al are linked.
+// They count as linked when the incident lists the alert under alertId or
+// alertIds, when the alert names the incident under incidentId, or when both
+// carry the same scenario_id, checking Fields and Metadata for each.
+func RequireIncidentLinkedToAlert(t testing.TB, inc schema.Incident, al schema.Alert) {
+	t.Helper()
+
+	for _, id := range stringValues(inc.Fields, inc.Metadata, "alertId", "alertIds") {
+		if id == al.ID {
+			return
+		}
+	}
+	for _, id := range stringValues(al.Fields, al.Metadata, "incidentId") {
+		if id == inc.ID {
+			return
+		}
+	}
+	incScenarios := stringValues(inc.Fields, inc.Metadata, "scenario_id")
+	for _, id := range stringValues(al.Fields, al.Metadata, "scenario_id") {
+		for _, other := range incScenarios {
+			if id != "" && id == other {
+				return
+			}
+		}
+	}
+	t.Fatalf("incident %s is not linked to alert %s (incident alerts %v, alert incident %v, scenarios %v vs %v)",
+		inc.ID, al.ID,
+		stringValues(inc.Fields, inc.Metadata, "alertId", "alertIds"),
+		stringValues(al.Fields, al.Metadata, "incidentId"),
+		incScenarios, stringValues(al.Fields, al.Metadata, "scenario_id"))
+}
+
+// RequireAlertStatus fails the test unless al has the given status.
+func RequireAlertStatus(t testing.TB, al schema.Alert, status string) {
+	t.Helper()
+	if al.Status != status {
+		t.Fatalf("alert %s status = %q, want %q", al.ID, al.Status, status)
+	}
+}
+
+// RequireIncidentStatus fails the test unless inc has the given status.
+func RequireIncidentStatus(t testing.TB, inc schema.Incident, status string) {
+	t.Helper()
+	if inc.Status != status {
+		t.Fatalf("incident %s status = %q, want %q", inc.ID, inc.Status, status)
+	}
+}
+
+// RequireTimelineEntry returns the first entry of the given kind whose body
+// contains substr, failing the test when there is none. An empty substr
+// matches any body.
+func RequireTimelineEntry(t testing.TB, entries []schema.TimelineEntry, kind, substr string) schema.TimelineEntry {
+	t.Helper()
+	for _, entry := range entries {
+		if entry.Kind == kind && strings.Contains(entry.Body, substr) {
+			return entry
+		}
+	}
+	kinds := make([]string, len(entries))
+	for i, entry := range entries {
+		kinds[i] = entry.Kind
+	}
+	t.Fatalf("no %q timeline entry containing %q among %d entries (kinds %v)", kind, substr, len(entries), kinds)
+	return schema.TimelineEntry{}
+}
+
+// RequireErrorCode fails the test unless err is an orcherr carrying code,
+// e.g. "not_found" or "bad_request".
+func RequireErrorCode(t testing.TB, err error, code string) {
+	t.Helper()
+	if err == nil {
+		t.Fatalf("expected %s error, got nil", code)
+	}
+	if !strings.Contains(err.Error(), code) {
+		t.Fatalf("expected %s error, got %v", code, err)
+	}
+}
+
+// stringValues collects the string and string-list values stored under keys
+// in either map.
+func stringValues(fields, metadata map[string]any, keys ...string) []string {
+	var out []string
+	for _, m := range []map[string]any{fields, metadata} {
+		for _, key := range keys {
+			switch v := m[key].(type) {
+			case string:
+				out = append(out, v)
+			case []string:
+				out = append(out, v...)
+			case []any:
+				for _, item := range v {
+					out = append(out, fmt.Sprint(item))
+				}
+			}
+		}
+	}
+	return out
+}
@@ -0,0 +1,247 @@
+package mocktest
+
+import (
+	"time"
+
+	"github.com/opsorch/opsorch-core/schema"
+)
+
+// FixtureTime is the default creation time for fixtures, fixed so tests that
+// compare timestamps or sort by them are deterministic.
+var FixtureTime = time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+
+// AlertBuilder assembles a schema.Alert with sensible defaults.
+type AlertBuilder struct {
+	al schema.Alert
+}
+
+// Alert starts a firing critical alert on svc-checkout.
+func Alert(id string) *AlertBuilder {
+	return &AlertBuilder{al: schema.Alert{
+		ID:        id,
+		Title:     "Test alert " + id,
+		Status:    "firing",
+		Severity:  "critical",
+		Service:   "svc-checkout",
+		CreatedAt: FixtureTime,
+		UpdatedAt: FixtureTime,
+		Fields:    map[string]any{},
+		Metadata:  map[string]any{"source": "mocktest"},
+	}}
+}
+
+// Title sets the title.
+func (b *AlertBuilder) Title(title string) *AlertBuilder {
+	b.al.Title = title
+	return b
+}
+
+// Status sets the status.
+func (b *AlertBuilder) Status(status string) *AlertBuilder {
+	b.al.Status = status
+	return b
+}
+
+// Severity sets the severity.
+func (b *AlertBuilder) Severity(severity string) *AlertBuilder {
+	b.al.Severity = severity
+	return b
+}
+
+// Service sets the owning service.
+func (b *AlertBuilder) Service(service string) *AlertBuilder {
+	b.al.Service = service
+	return b
+}
+
+// At sets both timestamps.
+func (b *AlertBuilder) At(t time.Time) *AlertBuilder {
+	b.al.CreatedAt, b.al.UpdatedAt = t, t
+	return b
+}
+
+// Field sets a key in Fields.
+func (b *AlertBuilder) Field(key string, value any) *AlertBuilder {
+	b.al.Fields[key] = value
+	return b
+}
+
+// Meta sets a key in Metadata.
+func (b *AlertBuilder) Meta(key string, value any) *AlertBuilder {
+	b.al.Metadata[key] = value
+	return b
+}
+
+// Scenario tags the alert with a scenario ID the way seeded scenario alerts are.
+func (b *AlertBuilder) Scenario(id string) *AlertBuilder {
+	b.al.Fields["scenario_id"] = id
+	b.al.Metadata["scenario_id"] = id
+	return b
+}
+
+// Incident records the incident the alert was escalated to.
+func (b *AlertBuilder) Incident(incidentID string) *AlertBuilder {
+	b.al.Metadata["incidentId"] = incidentID
+	return b
+}
+
+// Build returns the alert. The builder may keep being used afterwards.
+func (b *AlertBuilder) Build() schema.Alert {
+	out := b.al
+	out.Fields = copyMap(b.al.Fields)
+	out.Metadata = copyMap(b.al.Metadata)
+	return out
+}
+
+// IncidentBuilder assembles a schema.Incident with sensible defaults.
+type IncidentBuilder struct {
+	inc schema.Incident
+}
+
+// Incident starts an open sev2 incident on svc-checkout.
+func Incident(id string) *IncidentBuilder {
+	return &IncidentBuilder{inc: schema.Incident{
+		ID:        id,
+		Title:     "Test incident " + id,
+		Status:    "open",
+		Severity:  "sev2",
+		Service:   "svc-checkout",
+		CreatedAt: FixtureTime,
+		UpdatedAt: FixtureTime,
+		Fields:    map[string]any{},
+		Metadata:  map[string]any{"source": "mocktest"},
+	}}
+}
+
+// Title sets the title.
+func (b *IncidentBuilder) Title(title string) *IncidentBuilder {
+	b.inc.Title = title
+	return b
+}
+
+// Status sets the status.
+func (b *IncidentBuilder) Status(status string) *IncidentBuilder {
+	b.inc.Status = status
+	return b
+}
+
+// Severity sets the severity.
+func (b *IncidentBuilder) Severity(severity string) *IncidentBuilder {
+	b.inc.Severity = severity
+	return b
+}
+
+// Service sets the owning service.
+func (b *IncidentBuilder) Service(service string) *IncidentBuilder {
+	b.inc.Service = service
+	return b
+}
+
+// At sets both timestamps.
+func (b *IncidentBuilder) At(t time.Time) *IncidentBuilder {
+	b.inc.CreatedAt, b.inc.UpdatedAt = t, t
+	return b
+}
+
+// Field sets a key in Fields.
+func (b *IncidentBuilder) Field(key string, value any) *IncidentBuilder {
+	b.inc.Fields[key] = value
+	return b
+}
+
+// Meta sets a key in Metadata.
+func (b *IncidentBuilder) Meta(key string, value any) *IncidentBuilder {
+	b.inc.Metadata[key] = value
+	return b
+}
+
+// Scenario tags the incident with a scenario ID the way seeded scenario
+// incidents are.
+func (b *IncidentBuilder) Scenario(id string) *IncidentBuilder {
+	b.inc.Fields["scenario_id"] = id
+	b.inc.Metadata["scenario_id"] = id
+	return b
+}
+
+// Alerts links the incident to the given alert IDs.
+func (b *IncidentBuilder) Alerts(alertIDs ...string) *IncidentBuilder {
+	ids := make([]any, len(alertIDs))
+	for i, id := range alertIDs {
+		ids[i] = id
+	}
+	b.inc.Metadata["alertIds"] = ids
+	return b
+}
+
+// Build returns the incident. The builder may keep being used afterwards.
+func (b *IncidentBuilder) Build() schema.Incident {
+	out := b.inc
+	out.Fields = copyMap(b.inc.Fields)
+	out.Metadata = copyMap(b.inc.Metadata)
+	return out
+}
+
+// TicketBuilder assembles a schema.Ticket with sensible defaults.
+type TicketBuilder struct {
+	tk schema.Ticket
+}
+
+// Ticket starts an open ticket.
+func Ticket(id string) *TicketBuilder {
+	return &TicketBuilder{tk: schema.Ticket{
+		ID:        id,
+		Title:     "Test ticket " + id,
+		Status:    "open",
+		CreatedAt: FixtureTime,
+		UpdatedAt: FixtureTime,
+		Fields:    map[string]any{},
+		Metadata:  map[string]any{"source": "mocktest"},
+	}}
+}
+
+// Title sets the title.
+func (b *TicketBuilder) Title(title string) *TicketBuilder {
+	b.tk.Title = title
+	return b
+}
+
+// Status sets the status.
+func (b *TicketBuilder) Status(status string) *TicketBuilder {
+	b.tk.Status = status
+	return b
+}
+
+// Assignees sets the assignees.
+func (b *TicketBuilder) Assignees(names ...string) *TicketBuilder {
+	b.tk.Assignees = names
+	return b
+}
+
+// Field sets a key in Fields.
+func (b *TicketBuilder) Field(key string, value any) *TicketBuilder {
+	b.tk.Fields[key] = value
+	return b
+}
+
+// Incident records the incident the ticket follows up on.
+func (b *TicketBuilder) Incident(incidentID string) *TicketBuilder {
+	b.tk.Metadata["incidentId"] = incidentID
+	return b
+}
+
+// Build returns the ticket. The builder may keep being used afterwards.
+func (b *TicketBuilder) Build() schema.Ticket {
+	out := b.tk
+	out.Assignees = append([]string(nil), b.tk.Assignees...)
+	out.Fields = copyMap(b.tk.Fields)
+	out.Metadata = copyMap(b.tk.Metadata)
+	return out
+}
+
+func copyMap(in map[string]any) map[string]any {
+	out := make(map[string]any, len(in))
+	for k, v := range in {
+		out[k] = v
+	}
+	return out
+}
@@ -0,0 +1,524 @@
+// Package mocktest helps integration tests drive the mock providers without
+// spawning plugin processes. A Host builds every core provider in memory and
+// routes plugin method strings to them with the same JSON encoding the plugin
+// wire uses; fixture builders and Require helpers cover the common setup and
+// assertions.
+package mocktest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/opsorch/opsorch-core/alert"
+	"github.com/opsorch/opsorch-core/deployment"
+	"github.com/opsorch/opsorch-core/incident"
+	"github.com/opsorch/opsorch-core/log"
+	"github.com/opsorch/opsorch-core/messaging"
+	"github.com/opsorch/opsorch-core/metric"
+	"github.com/opsorch/opsorch-core/orchestration"
+	"github.com/opsorch/opsorch-core/schema"
+	"github.com/opsorch/opsorch-core/secret"
+	coreservice "github.com/opsorch/opsorch-core/service"
+	coreteam "github.com/opsorch/opsorch-core/team"
+	coreticket "github.com/opsorch/opsorch-core/ticket"
+	"github.com/opsorch/opsorch-mock-adapters/alertmock"
+	"github.com/opsorch/opsorch-mock-adapters/deploymentmock"
+	"github.com/opsorch/opsorch-mock-adapters/incidentmock"
+	"github.com/opsorch/opsorch-mock-adapters/internal/bundle"
+	"github.com/opsorch/opsorch-mock-adapters/internal/mockutil"
+	"github.com/opsorch/opsorch-mock-adapters/logmock"
+	"github.com/opsorch/opsorch-mock-adapters/messagingmock"
+	"github.com/opsorch/opsorch-mock-adapters/metricmock"
+	"github.com/opsorch/opsorch-mock-adapters/orchestrationmock"
+	"github.com/opsorch/opsorch-mock-adapters/secretmock"
+	"github.com/opsorch/opsorch-mock-adapters/servicemock"
+	"github.com/opsorch/opsorch-mock-adapters/teammock"
+	"github.com/opsorch/opsorch-mock-adapters/ticketmock"
+)
+
+// Handler serves one method on a Host. payload is the request body as JSON.
+type Handler func(ctx context.Context, payload json.RawMessage) (any, error)
+
+// Host holds one of each core mock provider and dispatches method strings to
+// them. The providers are exported so tests can also call them directly.
+type Host struct {
+	Alerts        alert.Provider
+	Incidents     incident.Provider
+	Tickets       coreticket.Provider
+	Deployments   deployment.Provider
+	Services      coreservice.Provider
+	Teams         coreteam.Provider
+	Metrics       metric.Provider
+	Logs          log.Provider
+	Messaging     messaging.Provider
+	Secrets       secret.Provider
+	Orchestration orchestration.Provider
+
+	handlers  map[string]Handler
+	branding  *mockutil.Branding
+	resolvers mockutil.Resolvers
+	sources   mockutil.SearchSources
+	operator  *mockutil.Operator
+}
+
+// Option customizes NewHost.
+type Option func(*hostOptions)
+
+type hostOptions struct {
+	configs  map[string]map[string]any
+	fixture  bundle.Bundle
+	branding map[string]any
+}
+
+// WithConfig sets the config passed to one capability's provider, e.g.
+// WithConfig("alert", map[string]any{"source": "test"}).
+func WithConfig(capability string, cfg map[string]any) Option {
+	return func(o *hostOptions) {
+		o.configs[capability] = cfg
+	}
+}
+
+// WithBranding substitutes tokens in every result, as the plugins do for the
+// branding config key, e.g. WithBranding(map[string]string{"runbook.demo":
+// "runbooks.acme.io"}).
+func WithBranding(tokens map[string]string) Option {
+	return func(o *hostOptions) {
+		o.branding = map[string]any{}
+		for k, v := range tokens {
+			o.branding[k] = v
+		}
+	}
+}
+
+// WithAlerts replaces the seeded alerts with the given ones.
+func WithAlerts(alerts ...schema.Alert) Option {
+	return func(o *hostOptions) {
+		o.fixture.Alerts = append(o.fixture.Alerts, alerts...)
+	}
+}
+
+// WithIncidents replaces the seeded incidents with the given ones.
+func WithIncidents(incidents ...schema.Incident) Option {
+	return func(o *hostOptions) {
+		o.fixture.Incidents = append(o.fixture.Incidents, incidents...)
+	}
+}
+
+// WithTickets replaces the seeded tickets with the given ones.
+func WithTickets(tickets ...schema.Ticket) Option {
+	return func(o *hostOptions) {
+		o.fixture.Tickets = append(o.fixture.Tickets, tickets...)
+	}
+}
+
+// WithDeployments replaces the seeded deployments with the given ones.
+func WithDeployments(deployments ...schema.Deployment) Option {
+	return func(o *hostOptions) {
+		o.fixture.Deployments = append(o.fixture.Deployments, deployments...)
+	}
+}
+
+// NewHost builds every core provider and registers the core methods. Fixtures
+// given through With* options replace the seeded records for their kind only;
+// other kinds keep the demo data. Construction errors fail the test.
+func NewHost(t testing.TB, opts ...Option) *Host {
+	t.Helper()
+
+	o := hostOptions{configs: map[string]map[string]any{}}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if snapshot := writeFixture(t, o.fixture); snapshot != "" {
+		for _, name := range []string{"alert", "incident", "ticket", "deployment"} {
+			cfg := map[string]any{}
+			for k, v := range o.configs[name] {
+				cfg[k] = v
+			}
+			cfg[bundle.ConfigKey] = snapshot
+			o.configs[name] = cfg
+		}
+	}
+
+	h := &Host{handlers: map[string]Handler{}, branding: mockutil.ParseBranding(map[string]any{mockutil.BrandingConfigKey: o.branding})}
+	var err error
+	build := func(name string, fn func(cfg map[string]any) error) {
+		if err != nil {
+			return
+		}
+		if buildErr := fn(o.configs[name]); buildErr != nil {
+			err = fmt.Errorf("%s provider: %w", name, buildErr)
+		}
+	}
+	build("alert", func(cfg map[string]any) (err error) { h.Alerts, err = alertmock.New(cfg); return })
+	build("incident", func(cfg map[string]any) (err error) { h.Incidents, err = incidentmock.New(cfg); return })
+	build("ticket", func(cfg map[string]any) (err error) { h.Tickets, err = ticketmock.New(cfg); return })
+	build("deployment", func(cfg map[string]any) (err error) { h.Deployments, err = deploymentmock.New(cfg); return })
+	build("service", func(cfg map[string]any) (err error) { h.Services, err = servicemock.New(cfg); return })
+	build("team", func(cfg map[string]any) (err error) { h.Teams, err = teammock.New(cfg); return })
+	build("metric", func(cfg map[string]any) (err error) { h.Metrics, err = metricmock.New(cfg); return })
+	build("log", func(cfg map[string]any) (err error) { h.Logs, err = logmock.New(cfg); return })
+	build("messaging", func(cfg map[string]any) (err error) { h.Messaging, err = messagingmock.New(cfg); return })
+	build("secret", func(cfg map[string]any) (err error) { h.Secrets, err = secretmock.New(cfg); return })
+	build("orchestration", func(cfg map[string]any) (err error) { h.Orchestration, err = orchestrationmock.New(cfg); return })
+	if err != nil {
+		t.Fatalf("mocktest: %v", err)
+	}
+	// Declared incidents page, message, and start runs on the host's providers.
+	h.Incidents.(*incidentmock.Provider).SetDeclareDeps(incidentmock.DeclareDeps{
+		Teams:         h.Teams.(*teammock.Provider),
+		Messaging:     h.Messaging,
+		Orchestration: h.Orchestration.(*orchestrationmock.Provider),
+	})
+	// Failed deployments and simulated outages open incidents in the host's
+	// incident provider.
+	h.Deployments.(*deploymentmock.Provider).SetIncidentOpener(h.Incidents.(*incidentmock.Provider).OpenLinked)
+	h.Alerts.(*alertmock.Provider).SetIncidentOpener(h.Incidents.(*incidentmock.Provider).OpenLinked)
+	// Action items link and file tickets on the host's ticket provider.
+	h.Incidents.(*incidentmock.Provider).SetActionItemDeps(incidentmock.ActionItemDeps{
+		Tickets: h.Tickets.(*ticketmock.Provider),
+	})
+	// Workload counts the host's incidents, pages, and tickets.
+	h.Teams.(*teammock.Provider).SetWorkloadDeps(teammock.WorkloadDeps{
+		Incidents: h.Incidents,
+		Alerts:    h.Alerts,
+		Tickets:   h.Tickets,
+	})
+	// Scorecards read on-call and plans from the host's providers.
+	h.Services.(*servicemock.Provider).SetScorecardDeps(servicemock.ScorecardDeps{
+		Teams:         h.Teams.(*teammock.Provider),
+		Orchestration: h.Orchestration.(*orchestrationmock.Provider),
+	})
+
+	// The simulated operator works this host's providers only, paced by the
+	// first config that enables it, and stops with the test.
+	h.operator = mockutil.NewOperator()
+	h.Alerts.(*alertmock.Provider).RegisterOperatorTasks(h.operator)
+	h.Incidents.(*incidentmock.Provider).RegisterOperatorTasks(h.operator)
+	h.Tickets.(*ticketmock.Provider).RegisterOperatorTasks(h.operator)
+	h.Orchestration.(*orchestrationmock.Provider).RegisterOperatorTasks(h.operator)
+	for _, name := range []string{"alert", "incident", "ticket", "orchestration"} {
+		h.operator.Start(mockutil.ParseOperatorConfig(o.configs[name]))
+	}
+	t.Cleanup(h.operator.Stop)
+
+	// Refs resolve and searches run against this host's providers, not
+	// whichever host last registered in the process.
+	h.resolvers = mockutil.Resolvers{
+		mockutil.RefAlert:      func(ctx context.Context, id string) (any, error) { return h.Alerts.Get(ctx, id) },
+		mockutil.RefIncident:   func(ctx context.Context, id string) (any, error) { return h.Incidents.Get(ctx, id) },
+		mockutil.RefTicket:     func(ctx context.Context, id string) (any, error) { return h.Tickets.Get(ctx, id) },
+		mockutil.RefDeployment: func(ctx context.Context, id string) (any, error) { return h.Deployments.Get(ctx, id) },
+		mockutil.RefTeam:       func(ctx context.Context, id string) (any, error) { return h.Teams.Get(ctx, id) },
+		mockutil.RefPlan:       func(ctx context.Context, id string) (any, error) { return h.Orchestration.GetPlan(ctx, id) },
+		mockutil.RefRun:        func(ctx context.Context, id string) (any, error) { return h.Orchestration.GetRun(ctx, id) },
+	}
+	h.sources = mockutil.SearchSources{
+		mockutil.RefAlert:      h.Alerts.(*alertmock.Provider).SearchDocs,
+		mockutil.RefIncident:   h.Incidents.(*incidentmock.Provider).SearchDocs,
+		mockutil.RefTicket:     h.Tickets.(*ticketmock.Provider).SearchDocs,
+		mockutil.RefDeployment: h.Deployments.(*deploymentmock.Provider).SearchDocs,
+		mockutil.RefTeam:       h.Teams.(*teammock.Provider).SearchDocs,
+		mockutil.RefPlan:       h.Orchestration.(*orchestrationmock.Provider).SearchDocs,
+	}
+
+	h.registerCore()
+	return h
+}
+
+// writeFixture stores the fixture records as a snapshot bundle in the test's
+// temp dir and returns its path, or "" when no fixtures were given.
+func writeFixture(t testing.TB, fixture bundle.Bundle) string {
+	t.Helper()
+	if len(fixture.Alerts) == 0 && len(fixture.Incidents) == 0 && len(fixture.Tickets) == 0 && len(fixture.Deployments) == 0 {
+		return ""
+	}
+	fixture.Version = bundle.Version
+	fixture.GeneratedAt = time.Now().UTC()
+
+	path := filepath.Join(t.TempDir(), "fixture.json")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("mocktest: write fixture: %v", err)
+	}
+	defer f.Close()
+	if err := bundle.Encode(f, &fixture, bundle.FormatJSON); err != nil {
+		t.Fatalf("mocktest: write fixture: %v", err)
+	}
+	return path
+}
+
+// Handle registers h for method, replacing any existing handler. Use it to
+// stub extension methods or inject failures.
+func (h *Host) Handle(method string, handler Handler) {
+	h.handlers[method] = handler
+}
+
+// Methods lists the registered method names in sorted order.
+func (h *Host) Methods() []string {
+	out := make([]string, 0, len(h.handlers))
+	for method := range h.handlers {
+		out = append(out, method)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// Call dispatches method with payload encoded as JSON. A json.RawMessage or
+// []byte payload is sent as is; nil sends an empty object. With WithBranding
+// the result comes back as decoded JSON with the tokens substituted.
+func (h *Host) Call(ctx context.Context, method string, payload any) (any, error) {
+	handler, ok := h.handlers[method]
+	if !ok {
+		return nil, fmt.Errorf("unknown method %s", method)
+	}
+	raw, err := encodePayload(payload)
+	if err != nil {
+		return nil, err
+	}
+	result, err := handler(ctx, raw)
+	if err != nil || h.branding == nil {
+		return result, err
+	}
+	return h.branding.Apply(result)
+}
+
+// CallInto dispatches method and decodes the result into out through JSON,
+// so out sees exactly what a plugin client would. out may be nil.
+func (h *Host) CallInto(ctx context.Context, method string, payload, out any) error {
+	result, err := h.Call(ctx, method, payload)
+	if err != nil || out == nil {
+		return err
+	}
+	raw, err := json.Marshal(result)
+	if err != nil {
+		return fmt.Errorf("encode %s result: %w", method, err)
+	}
+	return json.Unmarshal(raw, out)
+}
+
+// MustCall is CallInto with a background context that fails the test on error.
+func (h *Host) MustCall(t testing.TB, method string, payload, out any) {
+	t.Helper()
+	if err := h.CallInto(context.Background(), method, payload, out); err != nil {
+		t.Fatalf("%s: %v", method, err)
+	}
+}
+
+func encodePayload(payload any) (json.RawMessage, error) {
+	switch p := payload.(type) {
+	case nil:
+		return json.RawMessage("{}"), nil
+	case json.RawMessage:
+		return p, nil
+	case []byte:
+		return p, nil
+	default:
+		return json.Marshal(p)
+	}
+}
+
+// route adapts a typed method to a Handler by decoding the payload into In.
+func route[In any](fn func(ctx context.Context, in In) (any, error)) Handler {
+	return func(ctx context.Context, payload json.RawMessage) (any, error) {
+		var in In
+		if err := json.Unmarshal(payload, &in); err != nil {
+			return nil, err
+		}
+		return fn(ctx, in)
+	}
+}
+
+type idPayload struct {
+	ID string `json:"id"`
+}
+
+// registerCore wires the methods of the core provider interfaces using the
+// same payload shapes as the plugins under cmd/.
+func (h *Host) registerCore() {
+	h.Handle("alert.query", route(func(ctx context.Context, q schema.AlertQuery) (any, error) {
+		return h.Alerts.Query(ctx, q)
+	}))
+	h.Handle("alert.get", route(func(ctx context.Context, in idPayload) (any, error) {
+		return h.Alerts.Get(ctx, in.ID)
+	}))
+	h.Handle("alert.acknowledge", route(func(ctx context.Context, in struct {
+		ID    string `json:"id"`
+		Actor string `json:"actor"`
+	}) (any, error) {
+		return h.Alerts.(*alertmock.Provider).Acknowledge(ctx, in.ID, in.Actor)
+	}))
+	h.Handle("alert.simulateOutage", route(func(ctx context.Context, in alertmock.OutageRequest) (any, error) {
+		return h.Alerts.(*alertmock.Provider).SimulateOutage(ctx, in)
+	}))
+
+	h.Handle("incident.query", route(func(ctx context.Context, q schema.IncidentQuery) (any, error) {
+		return h.Incidents.Query(ctx, q)
+	}))
+	h.Handle("incident.get", route(func(ctx context.Context, in idPayload) (any, error) {
+		return h.Incidents.Get(ctx, in.ID)
+	}))
+	h.Handle("incident.create", route(func(ctx context.Context, in schema.CreateIncidentInput) (any, error) {
+		return h.Incidents.Create(ctx, in)
+	}))
+	h.Handle("incident.update", route(func(ctx context.Context, in struct {
+		ID    string                     `json:"id"`
+		Input schema.UpdateIncidentInput `json:"input"`
+		Actor string                     `json:"actor"`
+	}) (any, error) {
+		if in.Actor != "" {
+			ctx = incidentmock.WithActor(ctx, in.Actor)
+		}
+		return h.Incidents.Update(ctx, in.ID, in.Input)
+	}))
+	h.Handle("incident.timeline.get", route(func(ctx context.Context, in idPayload) (any, error) {
+		return h.Incidents.GetTimeline(ctx, in.ID)
+	}))
+	h.Handle("incident.timeline.append", route(func(ctx context.Context, in struct {
+		ID    string                     `json:"id"`
+		Entry schema.TimelineAppendInput `json:"entry"`
+	}) (any, error) {
+		return nil, h.Incidents.AppendTimeline(ctx, in.ID, in.Entry)
+	}))
+	h.Handle("incident.declare", route(func(ctx context.Context, in incidentmock.DeclareInput) (any, error) {
+		return h.Incidents.(*incidentmock.Provider).Declare(ctx, in)
+	}))
+	h.Handle("incident.actionItems", route(func(ctx context.Context, in incidentmock.ActionItemsInput) (any, error) {
+		return h.Incidents.(*incidentmock.Provider).ActionItems(ctx, in)
+	}))
+
+	h.Handle("ticket.query", route(func(ctx context.Context, q schema.TicketQuery) (any, error) {
+		return h.Tickets.Query(ctx, q)
+	}))
+	h.Handle("ticket.get", route(func(ctx context.Context, in idPayload) (any, error) {
+		return h.Tickets.Get(ctx, in.ID)
+	}))
+	h.Handle("ticket.create", route(func(ctx context.Context, in schema.CreateTicketInput) (any, error) {
+		return h.Tickets.Create(ctx, in)
+	}))
+	h.Handle("ticket.update", route(func(ctx context.Context, in struct {
+		ID    string                   `json:"id"`
+		Input schema.UpdateTicketInput `json:"input"`
+	}) (any, error) {
+		return h.Tickets.Update(ctx, in.ID, in.Input)
+	}))
+	h.Handle("ticket.sync", route(func(ctx context.Context, in ticketmock.SyncRequest) (any, error) {
+		return h.Tickets.(*ticketmock.Provider).Sync(ctx, in)
+	}))
+
+	h.Handle("deployment.query", route(func(ctx context.Context, q schema.DeploymentQuery) (any, error) {
+		return h.Deployments.Query(ctx, q)
+	}))
+	h.Handle("deployment.get", route(func(ctx context.Context, in idPayload) (any, error) {
+		return h.Deployments.Get(ctx, in.ID)
+	}))
+
+	h.Handle("service.query", route(func(ctx context.Context, q schema.ServiceQuery) (any, error) {
+		return h.Services.Query(ctx, q)
+	}))
+
+	h.Handle("team.query", route(func(ctx context.Context, q schema.TeamQuery) (any, error) {
+		return h.Teams.Query(ctx, q)
+	}))
+	h.Handle("team.get", route(func(ctx context.Context, in idPayload) (any, error) {
+		return h.Teams.Get(ctx, in.ID)
+	}))
+	h.Handle("team.members", route(func(ctx context.Context, in struct {
+		TeamID string `json:"teamID"`
+	}) (any, error) {
+		return h.Teams.Members(ctx, in.TeamID)
+	}))
+	h.Handle("team.workload", route(func(ctx context.Context, in struct {
+		TeamID string `json:"teamID"`
+	}) (any, error) {
+		return h.Teams.(*teammock.Provider).Workload(ctx, in.TeamID)
+	}))
+
+	h.Handle("metric.query", route(func(ctx context.Context, q schema.MetricQuery) (any, error) {
+		return h.Metrics.Query(ctx, q)
+	}))
+	h.Handle("metric.describe", route(func(ctx context.Context, scope schema.QueryScope) (any, error) {
+		return h.Metrics.Describe(ctx, scope)
+	}))
+
+	h.Handle("log.query", route(func(ctx context.Context, q schema.LogQuery) (any, error) {
+		return h.Logs.Query(ctx, q)
+	}))
+
+	h.Handle("messaging.send", route(func(ctx context.Context, msg schema.Message) (any, error) {
+		return h.Messaging.Send(ctx, msg)
+	}))
+
+	h.Handle("secret.get", route(func(ctx context.Context, in struct {
+		Key string `json:"key"`
+	}) (any, error) {
+		return h.Secrets.Get(ctx, in.Key)
+	}))
+	h.Handle("secret.put", route(func(ctx context.Context, in struct {
+		Key   string `json:"key"`
+		Value string `json:"value"`
+	}) (any, error) {
+		return nil, h.Secrets.Put(ctx, in.Key, in.Value)
+	}))
+
+	h.Handle("ref.resolve", route(func(ctx context.Context, in struct {
+		Ref string `json:"ref"`
+	}) (any, error) {
+		return h.resolvers.Resolve(ctx, in.Ref)
+	}))
+	h.Handle("search.global", route(func(ctx context.Context, q mockutil.SearchQuery) (any, error) {
+		return h.sources.Search(ctx, q)
+	}))
+	h.Handle("operator.step", route(func(ctx context.Context, in struct {
+		Actor string `json:"actor"`
+	}) (any, error) {
+		if in.Actor == "" {
+			in.Actor = mockutil.ParseOperatorConfig(nil).Actor
+		}
+		action, acted, err := h.operator.Step(ctx, in.Actor)
+		if err != nil || !acted {
+			return nil, err
+		}
+		return action, nil
+	}))
+	h.Handle("operator.actions", route(func(ctx context.Context, in struct {
+		After int64 `json:"after"`
+	}) (any, error) {
+		return mockutil.OperatorActions(in.After), nil
+	}))
+
+	h.Handle("orchestration.plans.query", route(func(ctx context.Context, q schema.OrchestrationPlanQuery) (any, error) {
+		return h.Orchestration.QueryPlans(ctx, q)
+	}))
+	h.Handle("orchestration.plans.get", route(func(ctx context.Context, in struct {
+		PlanID string `json:"planId"`
+	}) (any, error) {
+		return h.Orchestration.GetPlan(ctx, in.PlanID)
+	}))
+	h.Handle("orchestration.runs.query", route(func(ctx context.Context, q schema.OrchestrationRunQuery) (any, error) {
+		return h.Orchestration.QueryRuns(ctx, q)
+	}))
+	h.Handle("orchestration.runs.get", route(func(ctx context.Context, in struct {
+		RunID string `json:"runId"`
+	}) (any, error) {
+		return h.Orchestration.GetRun(ctx, in.RunID)
+	}))
+	h.Handle("orchestration.runs.start", route(func(ctx context.Context, in struct {
+		PlanID string `json:"planId"`
+	}) (any, error) {
+		return h.Orchestration.StartRun(ctx, in.PlanID)
+	}))
+	h.Handle("orchestration.runs.steps.complete", route(func(ctx context.Context, in struct {
+		RunID  string `json:"runId"`
+		StepID string `json:"stepId"`
+		Actor  string `json:"actor"`
+		Note   string `json:"note"`
+	}) (any, error) {
+		return nil, h.Orchestration.CompleteStep(ctx, in.RunID, in.StepID, in.Actor, in.Note)
+	}))
+}
@@ -0,0 +1,425 @@
+package mocktest_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/opsorch/opsorch-core/schema"
+	"github.com/opsorch/opsorch-mock-adapters/alertmock"
+	"github.com/opsorch/opsorch-mock-adapters/mocktest"
+	"github.com/opsorch/opsorch-mock-adapters/teammock"
+)
+
+// fatalRecorder captures Fatalf so failing assertions can be tested.
+type fatalRecorder struct {
+	testing.TB
+	failed string
+}
+
+func (r *fatalRecorder) Helper() {}
+
+func (r *fatalRecorder) Fatalf(format string, args ...any) {
+	r.failed = fmt.Sprintf(format, args...)
+}
+
+func TestHostRoutesCoreMethods(t *testing.T) {
+	h := mocktest.NewHost(t)
+	ctx := context.Background()
+
+	var al schema.Alert
+	h.MustCall(t, "alert.get", map[string]string{"id": "al-scenario-001"}, &al)
+	var inc schema.Incident
+	h.MustCall(t, "incident.get", map[string]string{"id": "inc-scenario-001"}, &inc)
+	mocktest.RequireIncidentLinkedToAlert(t, inc, al)
+
+	if err := h.CallInto(ctx, "incident.timeline.append", map[string]any{
+		"id":    inc.ID,
+		"entry": schema.TimelineAppendInput{Kind: "note", Body: "Paged payments on-call"},
+	}, nil); err != nil {
+		t.Fatalf("timeline append: %v", err)
+	}
+	var timeline []schema.TimelineEntry
+	h.MustCall(t, "incident.timeline.get", map[string]string{"id": inc.ID}, &timeline)
+	mocktest.RequireTimelineEntry(t, timeline, "note", "Paged payments")
+
+	_, err := h.Call(ctx, "incident.get", map[string]string{"id": "inc-missing"})
+	mocktest.RequireErrorCode(t, err, "not_found")
+	if _, err := h.Call(ctx, "incident.nope", nil); err == nil {
+		t.Error("expected error for unknown method")
+	}
+
+	h.Handle("incident.export", func(ctx context.Context, payload json.RawMessage) (any, error) {
+		return map[string]string{"format": "markdown"}, nil
+	})
+	var exported map[string]string
+	h.MustCall(t, "incident.export", nil, &exported)
+	if exported["format"] != "markdown" {
+		t.Errorf("custom handler not used: %+v", exported)
+	}
+}
+
+func TestHostResolvesRefs(t *testing.T) {
+	h := mocktest.NewHost(t)
+
+	var tk schema.Ticket
+	h.MustCall(t, "ticket.get", map[string]string{"id": "TCK-001"}, &tk)
+	refs, _ := tk.Metadata["refs"].([]any)
+	if len(refs) == 0 {
+		t.Fatalf("ticket %s has no refs: %+v", tk.ID, tk.Metadata)
+	}
+
+	var resolved struct {
+		Ref    string          `json:"ref"`
+		Kind   string          `json:"kind"`
+		Entity schema.Incident `json:"entity"`
+	}
+	h.MustCall(t, "ref.resolve", map[string]any{"ref": refs[0]}, &resolved)
+	if resolved.Kind != "incident" || resolved.Ref != refs[0] || resolved.Entity.ID == "" {
+		t.Errorf("unexpected resolution %+v", resolved)
+	}
+
+	var team struct {
+		Entity schema.Team `json:"entity"`
+	}
+	h.MustCall(t, "ref.resolve", map[string]any{"ref": "team:team-velocity"}, &team)
+	if team.Entity.ID != "team-velocity" {
+		t.Errorf("team ref resolved to %+v", team.Entity)
+	}
+
+	_, err := h.Call(context.Background(), "ref.resolve", map[string]any{"ref": "incident:inc-missing"})
+	mocktest.RequireErrorCode(t, err, "not_found")
+	_, err = h.Call(context.Background(), "ref.resolve", map[string]any{"ref": "inc-001"})
+	mocktest.RequireErrorCode(t, err, "bad_request")
+}
+
+func TestHostResolvesRefsOnItsOwnProviders(t *testing.T) {
+	first := mocktest.NewHost(t, mocktest.WithIncidents(mocktest.Incident("inc-first").Build()))
+	second := mocktest.NewHost(t, mocktest.WithIncidents(mocktest.Incident("inc-second").Build()))
+
+	var resolved struct {
+		Entity schema.Incident `json:"entity"`
+	}
+	first.MustCall(t, "ref.resolve", map[string]any{"ref": "incident:inc-first"}, &resolved)
+	if resolved.Entity.ID != "inc-first" {
+		t.Errorf("first host resolved %+v", resolved.Entity)
+	}
+	_, err := first.Call(context.Background(), "ref.resolve", map[string]any{"ref": "incident:inc-second"})
+	mocktest.RequireErrorCode(t, err, "not_found")
+	second.MustCall(t, "ref.resolve", map[string]any{"ref": "incident:inc-second"}, &resolved)
+	if resolved.Entity.ID != "inc-second" {
+		t.Errorf("second host resolved %+v", resolved.Entity)
+	}
+}
+
+func TestHostOpensLinkedIncidentsInItsOwnProvider(t *testing.T) {
+	first := mocktest.NewHost(t)
+	second := mocktest.NewHost(t)
+	ctx := context.Background()
+
+	before, err := second.Incidents.Query(ctx, schema.IncidentQuery{})
+	if err != nil {
+		t.Fatalf("incident query: %v", err)
+	}
+	var result alertmock.OutageResult
+	first.MustCall(t, "alert.simulateOutage", map[string]string{"region": "euw1"}, &result)
+	if _, err := first.Incidents.Get(ctx, result.IncidentID); err != nil {
+		t.Fatalf("expected %s on the first host: %v", result.IncidentID, err)
+	}
+	after, err := second.Incidents.Query(ctx, schema.IncidentQuery{})
+	if err != nil {
+		t.Fatalf("incident query: %v", err)
+	}
+	if len(after) != len(before) {
+		t.Fatalf("expected the second host's incidents untouched, had %d now %d", len(before), len(after))
+	}
+}
+
+func TestHostOperatorWorksItsOwnProviders(t *testing.T) {
+	first := mocktest.NewHost(t)
+	second := mocktest.NewHost(t)
+
+	var action struct {
+		Kind string `json:"kind"`
+		Ref  string `json:"ref"`
+	}
+	first.MustCall(t, "operator.step", map[string]string{"actor": "sam"}, &action)
+	if action.Kind != "alert.acknowledge" {
+		t.Fatalf("expected the first step to acknowledge an alert, got %+v", action)
+	}
+	id := map[string]string{"id": strings.TrimPrefix(action.Ref, "alert:")}
+	var al schema.Alert
+	first.MustCall(t, "alert.get", id, &al)
+	if al.Status != "acknowledged" {
+		t.Fatalf("expected %s acknowledged on the first host, got %s", al.ID, al.Status)
+	}
+	second.MustCall(t, "alert.get", id, &al)
+	if al.Status == "acknowledged" {
+		t.Fatalf("expected %s untouched on the second host", al.ID)
+	}
+}
+
+func TestHostGlobalSearch(t *testing.T) {
+	h := mocktest.NewHost(t)
+
+	var hits []struct {
+		Ref  string `json:"ref"`
+		Kind string `json:"kind"`
+	}
+	h.MustCall(t, "search.global", map[string]any{"query": "checkout latency EU"}, &hits)
+	kinds := map[string]bool{}
+	for _, hit := range hits {
+		kinds[hit.Kind] = true
+	}
+	if len(hits) == 0 || hits[0].Ref != "incident:inc-001" {
+		t.Fatalf("got %+v, want inc-001 first", hits)
+	}
+	for _, kind := range []string{"incident", "alert", "plan"} {
+		if !kinds[kind] {
+			t.Errorf("no %s hits in %+v", kind, hits)
+		}
+	}
+}
+
+func TestHostSearchesItsOwnProviders(t *testing.T) {
+	first := mocktest.NewHost(t, mocktest.WithIncidents(mocktest.Incident("inc-first").Title("Ledger reconciliation stalled").Build()))
+	mocktest.NewHost(t, mocktest.WithIncidents(mocktest.Incident("inc-second").Title("Ledger export stalled").Build()))
+
+	var hits []struct {
+		Ref string `json:"ref"`
+	}
+	first.MustCall(t, "search.global", map[string]any{"query": "ledger", "kinds": []string{"incident"}}, &hits)
+	if len(hits) != 1 || hits[0].Ref != "incident:inc-first" {
+		t.Errorf("first host found %+v, want only inc-first", hits)
+	}
+}
+
+func TestHostDeclareUsesHostProviders(t *testing.T) {
+	h := mocktest.NewHost(t)
+
+	var declared struct {
+		Incident  schema.Incident          `json:"incident"`
+		Run       *schema.OrchestrationRun `json:"run"`
+		Simulated []string                 `json:"simulated"`
+	}
+	h.MustCall(t, "incident.declare", map[string]any{"title": "Catalog sync stalled", "service": "svc-catalog"}, &declared)
+	if declared.Run == nil {
+		t.Fatalf("expected a run for %s", declared.Incident.ID)
+	}
+	if len(declared.Simulated) != 0 || declared.Incident.Metadata["runId"] != declared.Run.ID {
+		t.Fatalf("expected no simulated providers and a linked run on a host, got %v / %v", declared.Simulated, declared.Incident.Metadata["runId"])
+	}
+	var run schema.OrchestrationRun
+	h.MustCall(t, "orchestration.runs.get", map[string]string{"runId": declared.Run.ID}, &run)
+	if run.Fields["incident_id"] != declared.Incident.ID {
+		t.Fatalf("expected run %s on the host orchestration provider linked to %s, got %+v", run.ID, declared.Incident.ID, run.Fields)
+	}
+}
+
+func TestHostBranding(t *testing.T) {
+	h := mocktest.NewHost(t, mocktest.WithBranding(map[string]string{"runbook.demo": "runbooks.acme.io"}))
+
+	var al schema.Alert
+	h.MustCall(t, "alert.get", map[string]string{"id": "al-002"}, &al)
+	if al.Metadata["runbook"] != "https://runbooks.acme.io/db-failover" {
+		t.Fatalf("expected the branded runbook link, got %v", al.Metadata["runbook"])
+	}
+}
+
+func TestHostFixturesReplaceSeededRecords(t *testing.T) {
+	alert := mocktest.Alert("al-test-1").Severity("warning").Scenario("db-failover").Build()
+	incident := mocktest.Incident("inc-test-1").Alerts(alert.ID).Build()
+	h := mocktest.NewHost(t,
+		mocktest.WithAlerts(alert),
+		mocktest.WithIncidents(incident),
+		mocktest.WithTickets(mocktest.Ticket("TCK-900").Incident(incident.ID).Build()),
+	)
+
+	var incidents []schema.Incident
+	h.MustCall(t, "incident.query", schema.IncidentQuery{}, &incidents)
+	if len(incidents) != 1 || incidents[0].ID != "inc-test-1" {
+		t.Fatalf("expected only the fixture incident, got %d", len(incidents))
+	}
+	mocktest.RequireIncidentStatus(t, incidents[0], "open")
+
+	var got schema.Alert
+	h.MustCall(t, "alert.get", map[string]string{"id": "al-test-1"}, &got)
+	mocktest.RequireAlertStatus(t, got, "firing")
+	mocktest.RequireIncidentLinkedToAlert(t, incidents[0], got)
+
+	var ticket schema.Ticket
+	h.MustCall(t, "ticket.get", map[string]string{"id": "TCK-900"}, &ticket)
+
+	// Kinds without fixtures keep their seeded data.
+	var services []schema.Service
+	h.MustCall(t, "service.query", schema.ServiceQuery{}, &services)
+	if len(services) == 0 {
+		t.Error("expected seeded services")
+	}
+}
+
+func TestRequireIncidentLinkedToAlertFails(t *testing.T) {
+	rec := &fatalRecorder{TB: t}
+	inc := mocktest.Incident("inc-a").Scenario("slo-exhaustion").Build()
+	al := mocktest.Alert("al-b").Scenario("autoscaling-lag").Build()
+	mocktest.RequireIncidentLinkedToAlert(rec, inc, al)
+	if rec.failed == "" {
+		t.Fatal("expected unrelated incident and alert to fail")
+	}
+
+	rec.failed = ""
+	mocktest.RequireIncidentLinkedToAlert(rec, inc, mocktest.Alert("al-c").Incident("inc-a").Build())
+	if rec.failed != "" {
+		t.Fatalf("expected incidentId link to pass: %s", rec.failed)
+	}
+}
+
+func TestHostCorrelatesScenarioByID(t *testing.T) {
+	h := mocktest.NewHost(t)
+	ctx := context.Background()
+
+	var al schema.Alert
+	h.MustCall(t, "alert.get", map[string]string{"id": "al-scenario-003"}, &al)
+	var inc schema.Incident
+	h.MustCall(t, "incident.get", map[string]string{"id": "inc-scenario-003"}, &inc)
+	want, _ := al.Fields["correlation_id"].(string)
+	if want == "" || inc.Fields["correlation_id"] != want {
+		t.Fatalf("expected alert and incident to share a correlation ID, got %v and %v", al.Fields["correlation_id"], inc.Fields["correlation_id"])
+	}
+
+	deployments, err := h.Deployments.Query(ctx, schema.DeploymentQuery{})
+	if err != nil {
+		t.Fatalf("deployment query: %v", err)
+	}
+	found := false
+	for _, dep := range deployments {
+		if dep.ID == "deploy-scenario-003" {
+			found = dep.Metadata["correlation_id"] == want
+		}
+	}
+	if !found {
+		t.Fatalf("expected deploy-scenario-003 to carry correlation ID %s", want)
+	}
+
+	end := time.Now().UTC()
+	series, err := h.Metrics.Query(ctx, schema.MetricQuery{
+		Expression: &schema.MetricExpression{MetricName: "error_rate"},
+		Scope:      schema.QueryScope{Service: "svc-checkout"},
+		Start:      end.Add(-30 * time.Minute),
+		End:        end,
+		Step:       60,
+	})
+	if err != nil || len(series) == 0 {
+		t.Fatalf("metric query: %d series (%v)", len(series), err)
+	}
+	ids, _ := series[0].Metadata["correlation_ids"].([]string)
+	if !slices.Contains(ids, want) {
+		t.Fatalf("expected checkout error_rate to carry correlation ID %s, got %v", want, series[0].Metadata["correlation_ids"])
+	}
+}
+
+func TestHostSimulatesRegionOutage(t *testing.T) {
+	h := mocktest.NewHost(t)
+	ctx := context.Background()
+
+	var result alertmock.OutageResult
+	h.MustCall(t, "alert.simulateOutage", map[string]string{"region": "euw1"}, &result)
+	if result.IncidentID == "" || len(result.Alerts) == 0 {
+		t.Fatalf("expected alerts and an incident, got %+v", result)
+	}
+	var inc schema.Incident
+	h.MustCall(t, "incident.get", map[string]string{"id": result.IncidentID}, &inc)
+	if inc.Severity != "sev1" || inc.Fields["correlation_id"] != result.CorrelationID || inc.Metadata["planId"] != "plan-complex-006" {
+		t.Fatalf("expected a sev1 outage incident on the evacuation plan, got %+v", inc)
+	}
+	al := result.Alerts[0]
+	if al.Metadata["incident_id"] != result.IncidentID {
+		t.Fatalf("expected %s linked to the incident, got %v", al.ID, al.Metadata["incident_id"])
+	}
+
+	end := time.Now().UTC()
+	series, err := h.Metrics.Query(ctx, schema.MetricQuery{
+		Expression: &schema.MetricExpression{MetricName: "http_request_duration_seconds"},
+		Scope:      schema.QueryScope{Service: al.Service},
+		Start:      end.Add(-30 * time.Minute),
+		End:        end.Add(time.Minute),
+		Step:       60,
+	})
+	if err != nil || len(series) == 0 {
+		t.Fatalf("metric query: %d series (%v)", len(series), err)
+	}
+	if series[0].Metadata["alerts"] == nil {
+		t.Fatalf("expected %s latency to be shaped by the outage alerts, got %v", al.Service, series[0].Metadata)
+	}
+}
+
+func TestHostWorkloadCountsIncidents(t *testing.T) {
+	h := mocktest.NewHost(t)
+
+	var report teammock.WorkloadReport
+	h.MustCall(t, "team.workload", map[string]string{"teamID": "team-velocity"}, &report)
+	for _, m := range report.Members {
+		if m.ID == "alex" {
+			if !slices.Contains(m.Incidents, "inc-001") || m.OpenTickets == 0 {
+				t.Fatalf("expected alex to have worked inc-001 and hold tickets, got %+v", m)
+			}
+			return
+		}
+	}
+	t.Fatalf("expected alex in team-velocity's workload, got %+v", report.Members)
+}
+
+func TestHostOperatorActsAcrossProviders(t *testing.T) {
+	h := mocktest.NewHost(t)
+
+	var before []struct {
+		Seq int64 `json:"seq"`
+	}
+	h.MustCall(t, "operator.actions", nil, &before)
+	var after int64
+	if len(before) > 0 {
+		after = before[len(before)-1].Seq
+	}
+
+	kinds := map[string]string{}
+	for i := 0; i < 4; i++ {
+		var action struct {
+			Kind  string `json:"kind"`
+			Ref   string `json:"ref"`
+			Actor string `json:"actor"`
+		}
+		h.MustCall(t, "operator.step", map[string]string{"actor": "sam"}, &action)
+		if action.Actor != "sam" {
+			t.Fatalf("expected the operator to act as sam, got %+v", action)
+		}
+		kinds[action.Kind] = action.Ref
+	}
+	for _, kind := range []string{"alert.acknowledge", "incident.update", "run.completeStep", "ticket.close"} {
+		if kinds[kind] == "" {
+			t.Fatalf("expected one %s action in four steps, got %v", kind, kinds)
+		}
+	}
+
+	var al schema.Alert
+	h.MustCall(t, "alert.get", map[string]string{"id": strings.TrimPrefix(kinds["alert.acknowledge"], "alert:")}, &al)
+	if al.Status != "acknowledged" || al.Fields["acknowledgedBy"] != "sam" {
+		t.Fatalf("expected the operator's alert acknowledged by sam, got %s %v", al.Status, al.Fields["acknowledgedBy"])
+	}
+	var tk schema.Ticket
+	h.MustCall(t, "ticket.get", map[string]string{"id": strings.TrimPrefix(kinds["ticket.close"], "ticket:")}, &tk)
+	if tk.Status != "done" {
+		t.Fatalf("expected the operator's ticket closed, got %s", tk.Status)
+	}
+
+	var actions []struct {
+		Kind string `json:"kind"`
+	}
+	h.MustCall(t, "operator.actions", map[string]int64{"after": after}, &actions)
+	if len(actions) != 4 {
+		t.Fatalf("expected four recorded actions, got %d", len(actions))
+	}
+}
@@ -0,0 +1,82 @@
+package networkmock
+
+// degradation is a scenario-driven impairment applied to matching paths.
+type degradation struct {
+	scenarioID   string
+	scenarioName string
+	incidentID   string
+	planID       string
+	description  string
+	applies      func(src, dst endpoint) bool
+	latency      float64
+	loss         float64
+}
+
+// evacuatingRegion is the region being drained in the region-evacuation scenario.
+const evacuatingRegion = "us-east-1"
+
+var activeDegradations = []degradation{
+	{
+		// Cross-region links out of the evacuating region are saturated by
+		// replication and drained traffic.
+		scenarioID:   "region-evacuation",
+		scenarioName: "Region Evacuation",
+		planID:       "plan-complex-006",
+		description:  "Backbone links from " + evacuatingRegion + " saturated during evacuation",
+		applies: func(src, dst endpoint) bool {
+			return src.region != dst.region && (src.region == evacuatingRegion || dst.region == evacuatingRegion) && dst.kind != "external"
+		},
+		latency: 2.4,
+		loss:    0.06,
+	},
+	{
+		// Inside the evacuating region, draining load balancers drop a little traffic.
+		scenarioID:   "region-evacuation",
+		scenarioName: "Region Evacuation",
+		planID:       "plan-complex-006",
+		description:  "Load balancers in " + evacuatingRegion + " draining connections",
+		applies: func(src, dst endpoint) bool {
+			return src.region == evacuatingRegion && dst.region == evacuatingRegion && src.name != dst.name && dst.kind != "external"
+		},
+		latency: 1.3,
+		loss:    0.015,
+	},
+	{
+		scenarioID:   "external-dependency-failure",
+		scenarioName: "External Dependency Failure - Stripe",
+		incidentID:   "inc-scenario-004",
+		description:  "Elevated latency and timeouts reaching api.stripe.com",
+		applies: func(src, dst endpoint) bool {
+			return dst.name == "api.stripe.com"
+		},
+		latency: 6,
+		loss:    0.12,
+	},
+}
+
+func (d degradation) apply(res *PathResult) {
+	if d.latency > 1 {
+		for i := range res.Hops {
+			if res.Hops[i].Type == "backbone" || res.Hops[i].Type == "external" || res.Hops[i].Type == "vpc" {
+				res.Hops[i].LatencyMs = round2(res.Hops[i].LatencyMs * d.latency)
+			}
+		}
+	}
+	// Independent loss on each degraded segment compounds.
+	res.PacketLoss = round4(1 - (1-res.PacketLoss)*(1-d.loss))
+
+	scenarios, _ := res.Metadata["degradations"].([]map[string]any)
+	entry := map[string]any{
+		"scenario_id":   d.scenarioID,
+		"scenario_name": d.scenarioName,
+		"description":   d.description,
+	}
+	if d.incidentID != "" {
+		entry["incident_id"] = d.incidentID
+	}
+	if d.planID != "" {
+		entry["plan_id"] = d.planID
+	}
+	res.Metadata["degradations"] = append(scenarios, entry)
+	res.Metadata["is_scenario"] = true
+}
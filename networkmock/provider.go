@@ -0,0 +1,313 @@
+package networkmock
+
+import (
+	"context"
+	"hash/fnv"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/opsorch/opsorch-core/orcherr"
+	"github.com/opsorch/opsorch-mock-adapters/internal/mockutil"
+)
+
+// ProviderName identifies the mock network provider.
+const ProviderName = "mock"
+
+// Path statuses.
+const (
+	StatusHealthy     = "healthy"
+	StatusDegraded    = "degraded"
+	StatusUnreachable = "unreachable"
+)
+
+// Matrix levels.
+const (
+	LevelRegion  = "region"
+	LevelService = "service"
+)
+
+// degradedLossThreshold marks a path degraded once packet loss reaches it.
+const degradedLossThreshold = 0.01
+
+// Config controls mock network data.
+type Config struct {
+	Source string
+	// Degradations enables the scenario-driven impairments.
+	Degradations bool
+}
+
+// Provider simulates reachability, latency, and packet loss between services,
+// regions, and external endpoints.
+type Provider struct {
+	cfg Config
+}
+
+// PathQuery names the two ends of a path. Each end is a service ID, a region,
+// or an external endpoint host.
+type PathQuery struct {
+	Source      string `json:"source"`
+	Destination string `json:"destination"`
+	Port        int    `json:"port,omitempty"`
+}
+
+// Hop is one segment of a path.
+type Hop struct {
+	Name      string  `json:"name"`
+	Type      string  `json:"type"`
+	LatencyMs float64 `json:"latencyMs"`
+}
+
+// PathResult describes connectivity between two endpoints.
+type PathResult struct {
+	Source            string         `json:"source"`
+	Destination       string         `json:"destination"`
+	SourceRegion      string         `json:"sourceRegion"`
+	DestinationRegion string         `json:"destinationRegion"`
+	Port              int            `json:"port,omitempty"`
+	Reachable         bool           `json:"reachable"`
+	Status            string         `json:"status"`
+	LatencyMs         float64        `json:"latencyMs"`
+	LatencyP99Ms      float64        `json:"latencyP99Ms"`
+	PacketLoss        float64        `json:"packetLoss"`
+	Hops              []Hop          `json:"hops"`
+	CheckedAt         time.Time      `json:"checkedAt"`
+	Metadata          map[string]any `json:"metadata,omitempty"`
+}
+
+// MatrixQuery selects the nodes of a connectivity matrix. Level defaults to
+// region; a service matrix covers Nodes, or every service when Nodes is empty.
+type MatrixQuery struct {
+	Level string   `json:"level,omitempty"`
+	Nodes []string `json:"nodes,omitempty"`
+}
+
+// Matrix is the pairwise connectivity between nodes, in row-major order.
+type Matrix struct {
+	Level string       `json:"level"`
+	Nodes []string     `json:"nodes"`
+	Cells []PathResult `json:"cells"`
+}
+
+// crossRegionRTT is the baseline round-trip latency between region pairs.
+var crossRegionRTT = map[string]float64{
+	"us-east-1|us-west-2": 64,
+	"eu-west-1|us-east-1": 76,
+	"eu-west-1|us-west-2": 138,
+}
+
+// externalEndpoints are third-party APIs services call, with the region whose
+// egress reaches them fastest and the extra latency beyond it.
+var externalEndpoints = map[string]struct {
+	region    string
+	latencyMs float64
+}{
+	"api.stripe.com":   {region: "us-east-1", latencyMs: 18},
+	"api.sendgrid.com": {region: "us-west-2", latencyMs: 22},
+	"api.twilio.com":   {region: "us-east-1", latencyMs: 20},
+}
+
+// New constructs the mock network provider.
+func New(cfg map[string]any) (*Provider, error) {
+	return &Provider{cfg: parseConfig(cfg)}, nil
+}
+
+// PathCheck measures connectivity from source to destination.
+func (p *Provider) PathCheck(ctx context.Context, query PathQuery) (PathResult, error) {
+	_ = ctx
+
+	if strings.TrimSpace(query.Source) == "" || strings.TrimSpace(query.Destination) == "" {
+		return PathResult{}, orcherr.New("bad_request", "source and destination are required", nil)
+	}
+	src, ok := resolveEndpoint(query.Source)
+	if !ok {
+		return PathResult{}, orcherr.New("not_found", "unknown source "+query.Source, nil)
+	}
+	dst, ok := resolveEndpoint(query.Destination)
+	if !ok {
+		return PathResult{}, orcherr.New("not_found", "unknown destination "+query.Destination, nil)
+	}
+	return p.path(src, dst, query.Port, time.Now().UTC()), nil
+}
+
+// Matrix checks every ordered pair of nodes, including each node to itself.
+func (p *Provider) Matrix(ctx context.Context, query MatrixQuery) (Matrix, error) {
+	_ = ctx
+
+	level := query.Level
+	if level == "" {
+		level = LevelRegion
+	}
+	nodes := append([]string(nil), query.Nodes...)
+	switch level {
+	case LevelRegion:
+		if len(nodes) == 0 {
+			nodes = append(nodes, mockutil.Regions...)
+		}
+	case LevelService:
+		if len(nodes) == 0 {
+			nodes = mockutil.Services()
+		}
+	default:
+		return Matrix{}, orcherr.New("bad_request", "level must be region or service", nil)
+	}
+
+	endpoints := make([]endpoint, 0, len(nodes))
+	for _, node := range nodes {
+		ep, ok := resolveEndpoint(node)
+		if !ok || (level == LevelRegion && ep.kind != "region") || (level == LevelService && ep.kind != "service") {
+			return Matrix{}, orcherr.New("bad_request", "unknown "+level+" "+node, nil)
+		}
+		endpoints = append(endpoints, ep)
+	}
+
+	now := time.Now().UTC()
+	out := Matrix{Level: level, Nodes: nodes, Cells: make([]PathResult, 0, len(nodes)*len(nodes))}
+	for _, src := range endpoints {
+		for _, dst := range endpoints {
+			cell := p.path(src, dst, 0, now)
+			// Keep large matrices compact; hop detail is available from PathCheck.
+			cell.Hops = nil
+			out.Cells = append(out.Cells, cell)
+		}
+	}
+	return out, nil
+}
+
+type endpoint struct {
+	name   string
+	kind   string
+	region string
+}
+
+func resolveEndpoint(name string) (endpoint, bool) {
+	for _, region := range mockutil.Regions {
+		if name == region {
+			return endpoint{name: name, kind: "region", region: region}, true
+		}
+	}
+	if ext, ok := externalEndpoints[name]; ok {
+		return endpoint{name: name, kind: "external", region: ext.region}, true
+	}
+	for _, service := range mockutil.Services() {
+		if name == service {
+			return endpoint{name: name, kind: "service", region: mockutil.ServiceRegion(service)}, true
+		}
+	}
+	return endpoint{}, false
+}
+
+func (p *Provider) path(src, dst endpoint, port int, now time.Time) PathResult {
+	res := PathResult{
+		Source:            src.name,
+		Destination:       dst.name,
+		SourceRegion:      src.region,
+		DestinationRegion: dst.region,
+		Port:              port,
+		Reachable:         true,
+		CheckedAt:         now,
+		Metadata:          map[string]any{"source": p.cfg.Source},
+	}
+
+	jitter := float64(pairHash(src.name, dst.name)%100) / 100
+	res.Hops = append(res.Hops, Hop{Name: src.name, Type: src.kind, LatencyMs: round2(0.2 + jitter*0.3)})
+	if src.region == dst.region {
+		res.Hops = append(res.Hops, Hop{Name: src.region + "-fabric", Type: "vpc", LatencyMs: round2(0.6 + jitter*1.2)})
+	} else {
+		res.Hops = append(res.Hops,
+			Hop{Name: src.region + "-tgw", Type: "transit_gateway", LatencyMs: round2(0.8 + jitter*0.4)},
+			Hop{Name: src.region + "<->" + dst.region, Type: "backbone", LatencyMs: round2(regionRTT(src.region, dst.region) * (1 + jitter*0.05))},
+			Hop{Name: dst.region + "-tgw", Type: "transit_gateway", LatencyMs: round2(0.8 + jitter*0.4)},
+		)
+	}
+	if dst.kind == "external" {
+		res.Hops = append(res.Hops, Hop{Name: dst.region + "-nat", Type: "nat_gateway", LatencyMs: round2(0.5 + jitter*0.3)})
+		res.Hops = append(res.Hops, Hop{Name: dst.name, Type: "external", LatencyMs: round2(externalEndpoints[dst.name].latencyMs * (1 + jitter*0.2))})
+	} else {
+		res.Hops = append(res.Hops, Hop{Name: dst.name, Type: dst.kind, LatencyMs: round2(0.2 + jitter*0.3)})
+	}
+	res.PacketLoss = round4(jitter * 0.001)
+	if group, blocked := blockedBy(src, dst); blocked {
+		res.Reachable = false
+		res.Metadata["reason"] = "blocked by security group " + group
+	}
+
+	if p.cfg.Degradations {
+		for _, d := range activeDegradations {
+			if d.applies(src, dst) {
+				d.apply(&res)
+			}
+		}
+	}
+
+	for _, hop := range res.Hops {
+		res.LatencyMs += hop.LatencyMs
+	}
+	res.LatencyMs = round2(res.LatencyMs)
+	res.LatencyP99Ms = round2(res.LatencyMs * (1.6 + res.PacketLoss*40))
+	switch {
+	case !res.Reachable:
+		res.Status = StatusUnreachable
+		res.LatencyMs, res.LatencyP99Ms = 0, 0
+	case res.PacketLoss >= degradedLossThreshold:
+		res.Status = StatusDegraded
+	default:
+		res.Status = StatusHealthy
+	}
+	return res
+}
+
+// restrictedServices only accept connections from services that depend on
+// them, mirroring a database security group.
+var restrictedServices = map[string]string{
+	"svc-database": "sg-database",
+}
+
+// blockedBy returns the security group that drops traffic from src to dst.
+func blockedBy(src, dst endpoint) (string, bool) {
+	group, ok := restrictedServices[dst.name]
+	if !ok || src.kind != "service" || src.name == dst.name {
+		return "", false
+	}
+	for _, dep := range mockutil.ServiceDependencies(src.name) {
+		if dep == dst.name {
+			return "", false
+		}
+	}
+	return group, true
+}
+
+func regionRTT(a, b string) float64 {
+	if a > b {
+		a, b = b, a
+	}
+	if rtt, ok := crossRegionRTT[a+"|"+b]; ok {
+		return rtt
+	}
+	return 100
+}
+
+func pairHash(a, b string) uint32 {
+	h := fnv.New32a()
+	h.Write([]byte(a + "->" + b))
+	return h.Sum32()
+}
+
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
+
+func round4(v float64) float64 {
+	return math.Round(v*10000) / 10000
+}
+
+func parseConfig(cfg map[string]any) Config {
+	out := Config{Source: "mock", Degradations: true}
+	if v, ok := cfg["source"].(string); ok && v != "" {
+		out.Source = v
+	}
+	if v, ok := cfg["degradations"].(bool); ok {
+		out.Degradations = v
+	}
+	return out
+}
@@ -0,0 +1,85 @@
+package networkmock
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestPathCheckRegionEvacuationDegradesCrossRegionLinks(t *testing.T) {
+	prov, err := New(map[string]any{"source": "test"})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+
+	degraded, err := prov.PathCheck(context.Background(), PathQuery{Source: "us-east-1", Destination: "eu-west-1"})
+	if err != nil {
+		t.Fatalf("PathCheck returned error: %v", err)
+	}
+	if degraded.Status != StatusDegraded || degraded.PacketLoss < 0.05 {
+		t.Fatalf("expected degraded evacuation path, got %+v", degraded)
+	}
+	if degraded.Metadata["is_scenario"] != true {
+		t.Fatalf("expected scenario metadata, got %v", degraded.Metadata)
+	}
+
+	healthy, _ := prov.PathCheck(context.Background(), PathQuery{Source: "us-west-2", Destination: "eu-west-1"})
+	if healthy.Status != StatusHealthy || !healthy.Reachable {
+		t.Fatalf("expected healthy path away from the evacuating region, got %+v", healthy)
+	}
+	if len(healthy.Hops) < 4 {
+		t.Fatalf("expected cross-region hops, got %+v", healthy.Hops)
+	}
+
+	off, _ := New(map[string]any{"degradations": false})
+	baseline, _ := off.PathCheck(context.Background(), PathQuery{Source: "us-east-1", Destination: "eu-west-1"})
+	if baseline.Status != StatusHealthy || baseline.LatencyMs >= degraded.LatencyMs {
+		t.Fatalf("expected baseline faster than degraded path: %v vs %v", baseline.LatencyMs, degraded.LatencyMs)
+	}
+}
+
+func TestPathCheckServicesAndExternal(t *testing.T) {
+	prov, _ := New(nil)
+
+	allowed, err := prov.PathCheck(context.Background(), PathQuery{Source: "svc-catalog", Destination: "svc-database", Port: 5432})
+	if err != nil || !allowed.Reachable {
+		t.Fatalf("expected catalog to reach the database, got %+v, %v", allowed, err)
+	}
+	blocked, _ := prov.PathCheck(context.Background(), PathQuery{Source: "svc-shipping", Destination: "svc-database", Port: 5432})
+	if blocked.Reachable || blocked.Status != StatusUnreachable {
+		t.Fatalf("expected security group to block shipping, got %+v", blocked)
+	}
+
+	stripe, _ := prov.PathCheck(context.Background(), PathQuery{Source: "svc-payments", Destination: "api.stripe.com"})
+	if stripe.Status != StatusDegraded {
+		t.Fatalf("expected degraded path to Stripe, got %+v", stripe)
+	}
+
+	if _, err := prov.PathCheck(context.Background(), PathQuery{Source: "svc-nope", Destination: "us-east-1"}); err == nil || !strings.Contains(err.Error(), "not_found") {
+		t.Fatalf("expected not_found, got %v", err)
+	}
+}
+
+func TestMatrix(t *testing.T) {
+	prov, _ := New(nil)
+
+	regions, err := prov.Matrix(context.Background(), MatrixQuery{})
+	if err != nil {
+		t.Fatalf("Matrix returned error: %v", err)
+	}
+	if regions.Level != LevelRegion || len(regions.Cells) != len(regions.Nodes)*len(regions.Nodes) {
+		t.Fatalf("unexpected region matrix: %+v", regions)
+	}
+
+	services, err := prov.Matrix(context.Background(), MatrixQuery{Level: LevelService, Nodes: []string{"svc-checkout", "svc-database"}})
+	if err != nil {
+		t.Fatalf("Matrix returned error: %v", err)
+	}
+	if len(services.Cells) != 4 || services.Cells[1].Destination != "svc-database" || !services.Cells[1].Reachable {
+		t.Fatalf("unexpected service matrix: %+v", services.Cells)
+	}
+
+	if _, err := prov.Matrix(context.Background(), MatrixQuery{Level: LevelService, Nodes: []string{"us-east-1"}}); err == nil {
+		t.Fatalf("expected error for a region in a service matrix")
+	}
+}
@@ -0,0 +1,110 @@
+package orchestrationmock
+
+import (
+	"context"
+
+	"github.com/opsorch/opsorch-core/orcherr"
+	"github.com/opsorch/opsorch-core/schema"
+)
+
+// PlanAnalysis summarizes the shape of a plan's step DAG for layout and scheduling UIs.
+// Each step is treated as one unit of work.
+type PlanAnalysis struct {
+	PlanID         string     `json:"planId"`
+	StepCount      int        `json:"stepCount"`
+	Levels         [][]string `json:"levels"`
+	CriticalPath   []string   `json:"criticalPath"`
+	MaxParallelism int        `json:"maxParallelism"`
+}
+
+// AnalyzePlan returns topological levels, the critical path, and max parallelism for a plan.
+func (p *Provider) AnalyzePlan(ctx context.Context, planID string) (*PlanAnalysis, error) {
+	p.mu.Lock()
+	plan, ok := p.plans[planID]
+	p.mu.Unlock()
+	if !ok {
+		return nil, orcherr.New("not_found", "plan not found", nil)
+	}
+	return AnalyzePlan(plan)
+}
+
+// AnalyzePlan computes the DAG analysis for a plan. Steps are placed on the
+// earliest level their dependencies allow, and steps within a level keep plan order.
+func AnalyzePlan(plan schema.OrchestrationPlan) (*PlanAnalysis, error) {
+	if err := ValidatePlan(plan); err != nil {
+		return nil, err
+	}
+
+	levelOf := stepLevels(plan.Steps)
+
+	analysis := &PlanAnalysis{
+		PlanID:       plan.ID,
+		StepCount:    len(plan.Steps),
+		Levels:       [][]string{},
+		CriticalPath: []string{},
+	}
+	deepest := ""
+	for _, step := range plan.Steps {
+		level := levelOf[step.ID]
+		for len(analysis.Levels) <= level {
+			analysis.Levels = append(analysis.Levels, []string{})
+		}
+		analysis.Levels[level] = append(analysis.Levels[level], step.ID)
+		if deepest == "" || level > levelOf[deepest] {
+			deepest = step.ID
+		}
+	}
+	for _, ids := range analysis.Levels {
+		if len(ids) > analysis.MaxParallelism {
+			analysis.MaxParallelism = len(ids)
+		}
+	}
+
+	// Walk back from the deepest step through the dependency that set its level.
+	deps := make(map[string][]string, len(plan.Steps))
+	for _, step := range plan.Steps {
+		deps[step.ID] = step.DependsOn
+	}
+	for id := deepest; id != ""; {
+		analysis.CriticalPath = append([]string{id}, analysis.CriticalPath...)
+		next := ""
+		for _, depID := range deps[id] {
+			if levelOf[depID] == levelOf[id]-1 {
+				next = depID
+				break
+			}
+		}
+		id = next
+	}
+
+	return analysis, nil
+}
+
+// stepLevels assigns each step the length of the longest dependency chain
+// leading to it. The plan must already be validated as acyclic.
+func stepLevels(steps []schema.OrchestrationStep) map[string]int {
+	deps := make(map[string][]string, len(steps))
+	for _, step := range steps {
+		deps[step.ID] = step.DependsOn
+	}
+
+	levels := make(map[string]int, len(steps))
+	var level func(id string) int
+	level = func(id string) int {
+		if l, ok := levels[id]; ok {
+			return l
+		}
+		l := 0
+		for _, depID := range deps[id] {
+			if dl := level(depID) + 1; dl > l {
+				l = dl
+			}
+		}
+		levels[id] = l
+		return l
+	}
+	for _, step := range steps {
+		level(step.ID)
+	}
+	return levels
+}
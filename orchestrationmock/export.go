@@ -0,0 +1,186 @@
+package orchestrationmock
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/opsorch/opsorch-core/orcherr"
+	"github.com/opsorch/opsorch-core/schema"
+)
+
+// RunReport is a rendered narrative of a run for attaching to incidents and
+// postmortems.
+type RunReport struct {
+	RunID       string    `json:"runId"`
+	Format      string    `json:"format"`
+	ContentType string    `json:"contentType"`
+	Content     string    `json:"content"`
+	GeneratedAt time.Time `json:"generatedAt"`
+}
+
+// runEvent is one line of the run narrative.
+type runEvent struct {
+	At   time.Time
+	Text string
+	Note string
+}
+
+// terminalStepStatuses have a finish time, or at least a last update, to
+// narrate.
+var terminalStepStatuses = map[string]bool{"succeeded": true, "failed": true, "skipped": true, "cancelled": true}
+
+// ExportRun renders a run as Markdown: a summary, the chronological timeline
+// of step starts and finishes with their actors and notes, and the final state
+// of every step.
+func (p *Provider) ExportRun(ctx context.Context, runID string) (RunReport, error) {
+	p.mu.Lock()
+	run, ok := p.runs[runID]
+	if !ok {
+		p.mu.Unlock()
+		return RunReport{}, orcherr.New("not_found", "run not found", nil)
+	}
+	run = cloneRun(run)
+	plan := p.plans[run.PlanID]
+	if run.Plan != nil {
+		plan = *run.Plan
+	}
+	p.mu.Unlock()
+
+	steps := map[string]schema.OrchestrationStep{}
+	for _, step := range plan.Steps {
+		steps[step.ID] = step
+	}
+	now := time.Now().UTC()
+	return RunReport{
+		RunID:       run.ID,
+		Format:      "markdown",
+		ContentType: "text/markdown",
+		Content:     renderRunMarkdown(run, plan, steps, runTimeline(run, plan, steps), now),
+		GeneratedAt: now,
+	}, nil
+}
+
+// runTimeline orders the run's recorded transitions. Steps without start or
+// finish times, such as seeded ones, are placed at their last update.
+func runTimeline(run schema.OrchestrationRun, plan schema.OrchestrationPlan, steps map[string]schema.OrchestrationStep) []runEvent {
+	title := firstNonEmpty(plan.Title, run.PlanID)
+	events := []runEvent{{At: run.CreatedAt, Text: fmt.Sprintf("Run started from plan **%s**", title)}}
+	last := run.CreatedAt
+	for _, state := range run.Steps {
+		name := stepLabel(state.StepID, steps)
+		if state.StartedAt != nil {
+			events = append(events, runEvent{At: *state.StartedAt, Text: fmt.Sprintf("%s started", name)})
+			last = later(last, *state.StartedAt)
+		}
+		if !terminalStepStatuses[state.Status] {
+			continue
+		}
+		at := state.FinishedAt
+		if at == nil {
+			at = state.UpdatedAt
+		}
+		if at == nil {
+			continue
+		}
+		text := fmt.Sprintf("%s %s", name, state.Status)
+		if state.Actor != "" {
+			text += " by " + state.Actor
+		}
+		events = append(events, runEvent{At: *at, Text: text, Note: state.Note})
+		last = later(last, *at)
+	}
+	resumes, _ := run.Fields["resumes"].([]map[string]any)
+	for _, resume := range resumes {
+		raw, _ := resume["at"].(string)
+		at, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			continue
+		}
+		text := "Run resumed"
+		if actor, _ := resume["actor"].(string); actor != "" {
+			text += " by " + actor
+		}
+		if ids, _ := resume["steps"].([]string); len(ids) > 0 {
+			text += ": retrying " + strings.Join(ids, ", ")
+		}
+		events = append(events, runEvent{At: at, Text: text})
+		last = later(last, at)
+	}
+	switch run.Status {
+	case "completed", "failed", "cancelled":
+		events = append(events, runEvent{At: later(last, run.UpdatedAt), Text: fmt.Sprintf("Run %s", run.Status)})
+	}
+	sort.SliceStable(events, func(i, j int) bool { return events[i].At.Before(events[j].At) })
+	return events
+}
+
+func renderRunMarkdown(run schema.OrchestrationRun, plan schema.OrchestrationPlan, steps map[string]schema.OrchestrationStep, events []runEvent, now time.Time) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# %s: %s\n\n", run.ID, firstNonEmpty(plan.Title, run.PlanID))
+	b.WriteString("## Summary\n\n")
+	b.WriteString("| Field | Value |\n|-------|-------|\n")
+	fmt.Fprintf(&b, "| Status | %s |\n", run.Status)
+	fmt.Fprintf(&b, "| Plan | %s |\n", run.PlanID)
+	if incidentID := runIncidentID(run); incidentID != "" {
+		fmt.Fprintf(&b, "| Incident | %s |\n", incidentID)
+	}
+	if run.Scope.Service != "" {
+		fmt.Fprintf(&b, "| Service | %s |\n", run.Scope.Service)
+	}
+	if run.Scope.Environment != "" {
+		fmt.Fprintf(&b, "| Environment | %s |\n", run.Scope.Environment)
+	}
+	fmt.Fprintf(&b, "| Started | %s |\n", run.CreatedAt.Format(time.RFC3339))
+	end := now
+	if run.Status == "completed" || run.Status == "failed" || run.Status == "cancelled" {
+		end = run.UpdatedAt
+	}
+	fmt.Fprintf(&b, "| Duration | %s |\n", end.Sub(run.CreatedAt).Round(time.Second))
+	if plan.Description != "" {
+		fmt.Fprintf(&b, "\n%s\n", plan.Description)
+	}
+
+	b.WriteString("\n## Timeline\n\n")
+	for _, event := range events {
+		fmt.Fprintf(&b, "- **%s** %s\n", event.At.Format("15:04:05 MST"), event.Text)
+		if event.Note != "" {
+			fmt.Fprintf(&b, "  > %s\n", event.Note)
+		}
+	}
+
+	b.WriteString("\n## Steps\n\n")
+	b.WriteString("| Step | Type | Status | Actor | Note |\n|------|------|--------|-------|------|\n")
+	for _, state := range run.Steps {
+		step := steps[state.StepID]
+		fmt.Fprintf(&b, "| %s | %s | %s | %s | %s |\n",
+			stepLabel(state.StepID, steps), firstNonEmpty(step.Type, "manual"), state.Status,
+			firstNonEmpty(state.Actor, "-"), firstNonEmpty(markdownCell(state.Note), "-"))
+	}
+
+	fmt.Fprintf(&b, "\n_Generated %s_\n", now.Format(time.RFC3339))
+	return b.String()
+}
+
+// stepLabel names a step by its plan title and ID.
+func stepLabel(stepID string, steps map[string]schema.OrchestrationStep) string {
+	if step, ok := steps[stepID]; ok && step.Title != "" {
+		return fmt.Sprintf("%s (`%s`)", step.Title, stepID)
+	}
+	return fmt.Sprintf("`%s`", stepID)
+}
+
+// markdownCell keeps free text from breaking a table row.
+func markdownCell(s string) string {
+	return strings.NewReplacer("|", "\\|", "\n", " ").Replace(s)
+}
+
+func later(a, b time.Time) time.Time {
+	if b.After(a) {
+		return b
+	}
+	return a
+}
@@ -0,0 +1,65 @@
+package orchestrationmock
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/opsorch/opsorch-mock-adapters/internal/mockutil"
+)
+
+// RegisterOperatorTasks registers the provider's operator tasks on op.
+func (p *Provider) RegisterOperatorTasks(op *mockutil.Operator) {
+	op.Register("run.completeStep", p.operatorCompleteStep)
+}
+
+// operatorCompleteStep is the simulated operator's runbook task: complete the
+// first ready manual step of the run that has waited longest on a person.
+func (p *Provider) operatorCompleteStep(ctx context.Context, actor string) (mockutil.OperatorAction, bool, error) {
+	p.mu.Lock()
+	runIDs := make([]string, 0, len(p.runs))
+	for id := range p.runs {
+		runIDs = append(runIDs, id)
+	}
+	sort.Slice(runIDs, func(i, j int) bool {
+		a, b := p.runs[runIDs[i]], p.runs[runIDs[j]]
+		if !a.UpdatedAt.Equal(b.UpdatedAt) {
+			return a.UpdatedAt.Before(b.UpdatedAt)
+		}
+		return a.ID < b.ID
+	})
+	var runID, stepID, title string
+	for _, id := range runIDs {
+		run := p.runs[id]
+		if run.Status == "completed" || run.Status == "failed" {
+			continue
+		}
+		for _, step := range run.Steps {
+			if step.Status == "ready" {
+				runID, stepID, title = id, step.StepID, step.StepID
+				for _, def := range p.plans[run.PlanID].Steps {
+					if def.ID == step.StepID && def.Title != "" {
+						title = def.Title
+					}
+				}
+				break
+			}
+		}
+		if runID != "" {
+			break
+		}
+	}
+	p.mu.Unlock()
+	if runID == "" {
+		return mockutil.OperatorAction{}, false, nil
+	}
+
+	note := fmt.Sprintf("Done: %s", title)
+	if err := p.CompleteStep(ctx, runID, stepID, actor, note); err != nil {
+		return mockutil.OperatorAction{}, false, err
+	}
+	return mockutil.OperatorAction{
+		Ref:     mockutil.FormatRef(mockutil.RefRun, runID),
+		Summary: fmt.Sprintf("Completed step %q of %s", title, runID),
+	}, true, nil
+}
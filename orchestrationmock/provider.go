@@ -3,6 +3,8 @@ package orchestrationmock
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -10,6 +12,8 @@ import (
 	"github.com/opsorch/opsorch-core/orcherr"
 	"github.com/opsorch/opsorch-core/orchestration"
 	"github.com/opsorch/opsorch-core/schema"
+	"github.com/opsorch/opsorch-mock-adapters/internal/bundle"
+	"github.com/opsorch/opsorch-mock-adapters/internal/mockutil"
 )
 
 // ProviderName can be referenced via OPSORCH_ORCHESTRATION_PROVIDER.
@@ -19,26 +23,50 @@ const ProviderName = "mock"
 type Config struct {
 	Source       string
 	StepDuration time.Duration
+	// StepWebhookURL, when set, hands automated steps to an external runner
+	// instead of completing them after StepDuration.
+	StepWebhookURL string
+	// StepCallbackTimeout fails a handed-off step if the runner has not called back.
+	StepCallbackTimeout time.Duration
+	// Locale, when set to a translated language such as "de" or "ja",
+	// localizes seeded plan and step titles and descriptions.
+	Locale string
 }
 
 // Provider keeps an in-memory plan and run store for demo purposes.
 type Provider struct {
-	cfg    Config
-	mu     sync.Mutex
-	nextID int
-	plans  map[string]schema.OrchestrationPlan
-	runs   map[string]schema.OrchestrationRun
+	cfg        Config
+	mu         sync.Mutex
+	nextID     int
+	plans      map[string]schema.OrchestrationPlan
+	runs       map[string]schema.OrchestrationRun
+	httpClient *http.Client
+	// dispatches numbers step webhook handoffs so a timer or delivery left
+	// from an earlier handoff of a resumed step is ignored.
+	dispatches int
 }
 
 // New constructs the provider with seeded demo plans and runs.
 func New(cfg map[string]any) (orchestration.Provider, error) {
 	parsed := parseConfig(cfg)
 	p := &Provider{
-		cfg:   parsed,
-		plans: map[string]schema.OrchestrationPlan{},
-		runs:  map[string]schema.OrchestrationRun{},
+		cfg:        parsed,
+		plans:      map[string]schema.OrchestrationPlan{},
+		runs:       map[string]schema.OrchestrationRun{},
+		httpClient: &http.Client{Timeout: 10 * time.Second},
 	}
 	p.seed()
+	snapshot, err := bundle.FromConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	p.restore(snapshot)
+	if err := p.validatePlans(); err != nil {
+		return nil, err
+	}
+	mockutil.RegisterResolver(mockutil.RefPlan, func(ctx context.Context, id string) (any, error) { return p.GetPlan(ctx, id) })
+	mockutil.RegisterResolver(mockutil.RefRun, func(ctx context.Context, id string) (any, error) { return p.GetRun(ctx, id) })
+	mockutil.RegisterSearchSource(mockutil.RefPlan, p.SearchDocs)
 	return p, nil
 }
 
@@ -49,8 +77,9 @@ func init() {
 // parseConfig extracts configuration from a map.
 func parseConfig(cfg map[string]any) Config {
 	parsed := Config{
-		Source:       "mock",
-		StepDuration: 10 * time.Second,
+		Source:              "mock",
+		StepDuration:        10 * time.Second,
+		StepCallbackTimeout: 5 * time.Minute,
 	}
 	if cfg == nil {
 		return parsed
@@ -63,6 +92,15 @@ func parseConfig(cfg map[string]any) Config {
 			parsed.StepDuration = d
 		}
 	}
+	if url, ok := cfg["step_webhook_url"].(string); ok {
+		parsed.StepWebhookURL = url
+	}
+	if durationStr, ok := cfg["step_callback_timeout"].(string); ok && durationStr != "" {
+		if d, err := time.ParseDuration(durationStr); err == nil && d > 0 {
+			parsed.StepCallbackTimeout = d
+		}
+	}
+	parsed.Locale = mockutil.ParseLocale(cfg)
 	return parsed
 }
 
@@ -127,6 +165,7 @@ func (p *Provider) QueryRuns(ctx context.Context, query schema.OrchestrationRunQ
 	statusFilter := toSet(query.Statuses)
 	planIDFilter := toSet(query.PlanIDs)
 	scopeFilter := query.Scope
+	now := time.Now().UTC()
 
 	out := make([]schema.OrchestrationRun, 0, len(p.runs))
 	for _, run := range p.runs {
@@ -153,7 +192,7 @@ func (p *Provider) QueryRuns(ctx context.Context, query schema.OrchestrationRunQ
 			continue
 		}
 
-		out = append(out, cloneRun(run))
+		out = append(out, p.presentRun(run, now))
 		if query.Limit > 0 && len(out) >= query.Limit {
 			break
 		}
@@ -170,12 +209,17 @@ func (p *Provider) GetRun(ctx context.Context, runID string) (*schema.Orchestrat
 	if !ok {
 		return nil, orcherr.New("not_found", "run not found", nil)
 	}
-	cloned := cloneRun(run)
+	cloned := p.presentRun(run, time.Now().UTC())
 	return &cloned, nil
 }
 
 // StartRun creates a new run from a plan.
 func (p *Provider) StartRun(ctx context.Context, planID string) (*schema.OrchestrationRun, error) {
+	return p.StartRunForIncident(ctx, planID, "")
+}
+
+// StartRunForIncident creates a new run from a plan and links it to an incident when incidentID is set.
+func (p *Provider) StartRunForIncident(ctx context.Context, planID string, incidentID string) (*schema.OrchestrationRun, error) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
@@ -225,21 +269,49 @@ func (p *Provider) StartRun(ctx context.Context, planID string) (*schema.Orchest
 			"source": p.cfg.Source,
 		},
 	}
+	if incidentID != "" {
+		run.Fields = map[string]any{"incident_id": incidentID}
+	}
 
 	p.runs[runID] = run
-	cloned := cloneRun(run)
 
 	// Check for automated steps to trigger
-	p.checkAutomatedSteps(context.Background(), &cloned)
+	p.checkAutomatedSteps(context.Background(), &run)
 
+	cloned := p.presentRun(p.runs[runID], now)
 	return &cloned, nil
 }
 
+// RunsForIncident returns runs linked to the given incident, oldest first.
+func (p *Provider) RunsForIncident(ctx context.Context, incidentID string) ([]schema.OrchestrationRun, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now().UTC()
+	out := make([]schema.OrchestrationRun, 0)
+	for _, run := range p.runs {
+		if runIncidentID(run) == incidentID {
+			out = append(out, p.presentRun(run, now))
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].CreatedAt.Equal(out[j].CreatedAt) {
+			return out[i].ID < out[j].ID
+		}
+		return out[i].CreatedAt.Before(out[j].CreatedAt)
+	})
+	return out, nil
+}
+
 // CompleteStep marks a step as complete and updates dependent steps.
 func (p *Provider) CompleteStep(ctx context.Context, runID string, stepID string, actor string, note string) error {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
+	return p.completeStepLocked(ctx, runID, stepID, actor, note)
+}
+
+func (p *Provider) completeStepLocked(ctx context.Context, runID string, stepID string, actor string, note string) error {
 	run, ok := p.runs[runID]
 	if !ok {
 		return orcherr.New("not_found", "run not found", nil)
@@ -334,6 +406,7 @@ func (p *Provider) updateDependentSteps(run *schema.OrchestrationRun, completedS
 }
 
 // checkAutomatedSteps identifies and triggers running steps marked as automated.
+// Callers must hold p.mu.
 func (p *Provider) checkAutomatedSteps(ctx context.Context, run *schema.OrchestrationRun) {
 	for _, step := range run.Steps {
 		if step.Status != "running" {
@@ -365,6 +438,10 @@ func (p *Provider) checkAutomatedSteps(ctx context.Context, run *schema.Orchestr
 			}
 		}
 
+		if isAutomated && p.cfg.StepWebhookURL != "" {
+			p.dispatchStepWebhookLocked(run, *stepDef)
+			continue
+		}
 		if isAutomated {
 			// Spawn a goroutine to execute the step
 			go func(runID, stepID string) {
@@ -373,7 +450,7 @@ func (p *Provider) checkAutomatedSteps(ctx context.Context, run *schema.Orchestr
 
 				// Complete the step
 				// We create a background context since original ctx might cancel
-				_ = p.CompleteStep(context.Background(), runID, stepID, "system-automation", "Automated execution completed")
+				_ = p.CompleteStep(context.Background(), runID, stepID, automationActor, "Automated execution completed")
 			}(run.ID, step.StepID)
 		}
 	}
@@ -424,6 +501,11 @@ func matchesScopeForRun(scope schema.QueryScope, run schema.OrchestrationRun) bo
 	return true
 }
 
+func runIncidentID(run schema.OrchestrationRun) string {
+	id, _ := run.Fields["incident_id"].(string)
+	return id
+}
+
 func findStepState(states []schema.OrchestrationStepState, stepID string) *schema.OrchestrationStepState {
 	for i, s := range states {
 		if s.StepID == stepID {
@@ -456,6 +538,24 @@ func cloneRun(run schema.OrchestrationRun) schema.OrchestrationRun {
 	return cloned
 }
 
+// stampStepActors sets Fields["stepActors"] to the actor map of each step's
+// actor from the shared registry, keyed by step ID.
+func stampStepActors(run *schema.OrchestrationRun) {
+	actors := map[string]map[string]any{}
+	for _, state := range run.Steps {
+		if state.Actor != "" {
+			actors[state.StepID] = mockutil.ActorRef(state.Actor)
+		}
+	}
+	if len(actors) == 0 {
+		return
+	}
+	if run.Fields == nil {
+		run.Fields = map[string]any{}
+	}
+	run.Fields["stepActors"] = actors
+}
+
 func cloneMap(m map[string]any) map[string]any {
 	if m == nil {
 		return nil
@@ -477,3 +577,24 @@ func cloneStringMap(m map[string]string) map[string]string {
 	}
 	return cloned
 }
+
+// SearchDocs indexes plan titles, descriptions, and step titles for global
+// search. Runs are instances of plans and are not indexed.
+func (p *Provider) SearchDocs(ctx context.Context) []mockutil.SearchDoc {
+	_ = ctx
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	docs := make([]mockutil.SearchDoc, 0, len(p.plans))
+	for _, plan := range p.plans {
+		body := []string{plan.Description}
+		for _, step := range plan.Steps {
+			body = append(body, step.Title)
+		}
+		docs = append(docs, mockutil.SearchDoc{
+			Kind: mockutil.RefPlan, ID: plan.ID, Title: plan.Title, Body: strings.Join(body, "\n"),
+			Service: plan.Tags["service"],
+		})
+	}
+	return docs
+}
@@ -2,7 +2,11 @@ package orchestrationmock
 
 import (
 	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -231,9 +235,9 @@ func TestQueryRuns_Empty(t *testing.T) {
 		t.Fatalf("unexpected error: %v", err)
 	}
 
-	// Should return all seeded runs (4)
-	if len(runs) != 4 {
-		t.Errorf("got %d runs, want 4", len(runs))
+	// Should return all seeded runs (6)
+	if len(runs) != 6 {
+		t.Errorf("got %d runs, want 6", len(runs))
 	}
 }
 
@@ -325,7 +329,7 @@ func TestQueryRuns_Limit(t *testing.T) {
 	}{
 		{"limit 1", 1, 1},
 		{"limit 2", 2, 2},
-		{"limit 0 (no limit)", 0, 4},
+		{"limit 0 (no limit)", 0, 6},
 	}
 
 	for _, tt := range tests {
@@ -605,8 +609,8 @@ func TestSeed_ActiveRuns(t *testing.T) {
 
 	runs, _ := p.QueryRuns(context.Background(), schema.OrchestrationRunQuery{})
 
-	if len(runs) != 4 {
-		t.Errorf("got %d runs, want 4", len(runs))
+	if len(runs) != 6 {
+		t.Errorf("got %d runs, want 6", len(runs))
 	}
 
 	// Verify run statuses
@@ -624,6 +628,9 @@ func TestSeed_ActiveRuns(t *testing.T) {
 	if statusCounts["completed"] != 1 {
 		t.Errorf("got %d completed runs, want 1", statusCounts["completed"])
 	}
+	if statusCounts["failed"] != 2 {
+		t.Errorf("got %d failed runs, want 2", statusCounts["failed"])
+	}
 }
 
 func TestAutomatedExecution(t *testing.T) {
@@ -674,3 +681,648 @@ func TestAutomatedExecution(t *testing.T) {
 		t.Errorf("step 4 status %q, want running", updatedRun.Steps[3].Status)
 	}
 }
+
+func TestValidatePlan_SeededPlans(t *testing.T) {
+	p, err := New(nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for id, plan := range p.(*Provider).plans {
+		if err := ValidatePlan(plan); err != nil {
+			t.Errorf("seeded plan %s invalid: %v", id, err)
+		}
+	}
+}
+
+func TestValidatePlan_Issues(t *testing.T) {
+	tests := []struct {
+		name     string
+		steps    []schema.OrchestrationStep
+		wantKind string
+	}{
+		{
+			name: "duplicate step",
+			steps: []schema.OrchestrationStep{
+				{ID: "a"},
+				{ID: "a"},
+			},
+			wantKind: IssueDuplicateStep,
+		},
+		{
+			name: "dangling dependency",
+			steps: []schema.OrchestrationStep{
+				{ID: "a"},
+				{ID: "b", DependsOn: []string{"missing"}},
+			},
+			wantKind: IssueDanglingDependency,
+		},
+		{
+			name: "cycle",
+			steps: []schema.OrchestrationStep{
+				{ID: "a", DependsOn: []string{"c"}},
+				{ID: "b", DependsOn: []string{"a"}},
+				{ID: "c", DependsOn: []string{"b"}},
+			},
+			wantKind: IssueCycle,
+		},
+		{
+			name: "self dependency",
+			steps: []schema.OrchestrationStep{
+				{ID: "a", DependsOn: []string{"a"}},
+			},
+			wantKind: IssueCycle,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidatePlan(schema.OrchestrationPlan{ID: "plan-test", Steps: tt.steps})
+			if err == nil {
+				t.Fatal("expected validation error")
+			}
+			verr, ok := err.(*PlanValidationError)
+			if !ok {
+				t.Fatalf("got %T, want *PlanValidationError", err)
+			}
+			if verr.PlanID != "plan-test" {
+				t.Errorf("got plan ID %q, want plan-test", verr.PlanID)
+			}
+			if len(verr.Issues) == 0 || verr.Issues[0].Kind != tt.wantKind {
+				t.Errorf("got issues %+v, want kind %s", verr.Issues, tt.wantKind)
+			}
+		})
+	}
+}
+
+func TestValidatePlan_CyclePath(t *testing.T) {
+	err := ValidatePlan(schema.OrchestrationPlan{
+		ID: "plan-test",
+		Steps: []schema.OrchestrationStep{
+			{ID: "a"},
+			{ID: "b", DependsOn: []string{"a", "c"}},
+			{ID: "c", DependsOn: []string{"b"}},
+		},
+	})
+	verr, ok := err.(*PlanValidationError)
+	if !ok {
+		t.Fatalf("got %T, want *PlanValidationError", err)
+	}
+	path := strings.Join(verr.Issues[0].Path, " -> ")
+	if path != "b -> c -> b" {
+		t.Errorf("got cycle path %q, want %q", path, "b -> c -> b")
+	}
+}
+
+func TestAnalyzePlan_RegionEvacuation(t *testing.T) {
+	p, _ := New(nil)
+	provider := p.(*Provider)
+
+	analysis, err := provider.AnalyzePlan(context.Background(), "plan-complex-006")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if analysis.StepCount != 15 {
+		t.Errorf("got %d steps, want 15", analysis.StepCount)
+	}
+	if len(analysis.Levels) == 0 || len(analysis.Levels[0]) != 1 || analysis.Levels[0][0] != "s1-init" {
+		t.Errorf("got first level %v, want [s1-init]", analysis.Levels)
+	}
+	if analysis.MaxParallelism < 3 {
+		t.Errorf("got max parallelism %d, want at least 3", analysis.MaxParallelism)
+	}
+	if len(analysis.CriticalPath) != len(analysis.Levels) {
+		t.Errorf("critical path length %d does not match level count %d", len(analysis.CriticalPath), len(analysis.Levels))
+	}
+	if analysis.CriticalPath[0] != "s1-init" || analysis.CriticalPath[len(analysis.CriticalPath)-1] != "s15-switch-dns" {
+		t.Errorf("got critical path %v, want s1-init ... s15-switch-dns", analysis.CriticalPath)
+	}
+}
+
+func TestSimulateRun(t *testing.T) {
+	p, _ := New(nil)
+	provider := p.(*Provider)
+	ctx := context.Background()
+	before, _ := provider.QueryRuns(ctx, schema.OrchestrationRunQuery{})
+
+	sim, err := provider.SimulateRun(ctx, "plan-playbook-001")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// Six sequential steps: four at the 15m default, then 10m and 35m.
+	if sim.EstimatedDuration != "1h45m0s" || sim.TotalEffort != sim.EstimatedDuration {
+		t.Errorf("got duration %s and effort %s, want 1h45m0s for both", sim.EstimatedDuration, sim.TotalEffort)
+	}
+	if got := strings.Join(sim.Order, ","); got != "step-1,step-2,step-3,step-4,step-5,step-6" {
+		t.Errorf("got order %s", got)
+	}
+	if len(sim.CriticalPath) != 6 || sim.Requirements.PeakResponders != 1 {
+		t.Errorf("got critical path %v and %d responders, want all 6 steps and 1", sim.CriticalPath, sim.Requirements.PeakResponders)
+	}
+	if sim.Requirements.Team != "platform" || len(sim.Requirements.Services) != 1 || sim.Requirements.Services[0] != "svc-database" {
+		t.Errorf("got requirements %+v", sim.Requirements)
+	}
+	if last := sim.Steps[5]; last.Start != "1h10m0s" || last.End != "1h45m0s" || !last.Critical {
+		t.Errorf("got last step %+v", last)
+	}
+
+	sim, err = provider.SimulateRun(ctx, "plan-complex-006")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if sim.Order[0] != "s1-init" || sim.Order[len(sim.Order)-1] != "s15-switch-dns" {
+		t.Errorf("got order %v", sim.Order)
+	}
+	if sim.EstimatedDuration != "1h45m0s" || sim.TotalEffort != "3h45m0s" {
+		t.Errorf("got duration %s and effort %s, want 1h45m0s and 3h45m0s", sim.EstimatedDuration, sim.TotalEffort)
+	}
+	if sim.Requirements.PeakResponders < 3 {
+		t.Errorf("got %d peak responders, want at least 3", sim.Requirements.PeakResponders)
+	}
+
+	sim, err = provider.SimulateRun(ctx, "plan-release-001")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(sim.Requirements.Approvals) == 0 || sim.Requirements.Approvals[0] != "step-2" {
+		t.Errorf("got approvals %v, want step-2", sim.Requirements.Approvals)
+	}
+
+	after, _ := provider.QueryRuns(ctx, schema.OrchestrationRunQuery{})
+	if len(after) != len(before) {
+		t.Errorf("simulation created runs: %d before, %d after", len(before), len(after))
+	}
+	if _, err := provider.SimulateRun(ctx, "nonexistent"); err == nil || !strings.Contains(err.Error(), "not_found") {
+		t.Errorf("got %v, want not_found", err)
+	}
+}
+
+func TestRecommendPlans(t *testing.T) {
+	p, _ := New(nil)
+	provider := p.(*Provider)
+	ctx := context.Background()
+
+	recs, err := provider.RecommendPlans(ctx, RecommendInput{Title: "Checkout latency impacting EU customers", Service: "svc-checkout"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(recs) == 0 || recs[0].Plan.ID != "plan-playbook-002" {
+		t.Fatalf("got %+v, want plan-playbook-002 first", recs)
+	}
+	if !strings.Contains(strings.Join(recs[0].Reasons, "; "), "written for 'Checkout latency impacting EU customers'") {
+		t.Errorf("got reasons %v, want the scenario hint", recs[0].Reasons)
+	}
+
+	alert := &schema.Alert{
+		Title:    "Connection pool near capacity on svc-database",
+		Service:  "svc-database",
+		Fields:   map[string]any{"environment": "prod"},
+		Metadata: map[string]any{"planId": "plan-playbook-001"},
+	}
+	recs, err = provider.RecommendPlans(ctx, RecommendInput{Alert: alert, Limit: 2})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(recs) != 2 || recs[0].Plan.ID != "plan-playbook-001" || recs[0].Score <= recs[1].Score {
+		t.Fatalf("got %+v, want plan-playbook-001 ranked first of 2", recs)
+	}
+	for _, rec := range recs {
+		if rec.Score <= 0 || rec.Score > 1 || len(rec.Reasons) == 0 {
+			t.Errorf("unexpected recommendation %+v", rec)
+		}
+	}
+
+	recs, err = provider.RecommendPlans(ctx, RecommendInput{Tags: map[string]string{"environment": "prod"}, Title: "zzz"})
+	if err != nil || len(recs) != 0 {
+		t.Errorf("got %d recommendations from tags alone (%v), want none", len(recs), err)
+	}
+	if _, err := provider.RecommendPlans(ctx, RecommendInput{}); err == nil || !strings.Contains(err.Error(), "bad_request") {
+		t.Errorf("got %v, want bad_request for an empty context", err)
+	}
+}
+
+func TestAnalyzePlan_Diamond(t *testing.T) {
+	analysis, err := AnalyzePlan(schema.OrchestrationPlan{
+		ID: "plan-test",
+		Steps: []schema.OrchestrationStep{
+			{ID: "a"},
+			{ID: "b", DependsOn: []string{"a"}},
+			{ID: "c", DependsOn: []string{"a"}},
+			{ID: "d", DependsOn: []string{"c"}},
+			{ID: "e", DependsOn: []string{"b", "d"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	wantLevels := "a|b,c|d|e"
+	levels := make([]string, len(analysis.Levels))
+	for i, ids := range analysis.Levels {
+		levels[i] = strings.Join(ids, ",")
+	}
+	if got := strings.Join(levels, "|"); got != wantLevels {
+		t.Errorf("got levels %q, want %q", got, wantLevels)
+	}
+	if got := strings.Join(analysis.CriticalPath, ","); got != "a,c,d,e" {
+		t.Errorf("got critical path %q, want a,c,d,e", got)
+	}
+	if analysis.MaxParallelism != 2 {
+		t.Errorf("got max parallelism %d, want 2", analysis.MaxParallelism)
+	}
+}
+
+func TestAnalyzePlan_NotFound(t *testing.T) {
+	p, _ := New(nil)
+	_, err := p.(*Provider).AnalyzePlan(context.Background(), "nonexistent")
+	if err == nil || !strings.Contains(err.Error(), "not_found") {
+		t.Errorf("got error %v, want not_found", err)
+	}
+}
+
+func TestRunsForIncident_Seeded(t *testing.T) {
+	p, _ := New(nil)
+	runs, err := p.(*Provider).RunsForIncident(context.Background(), "inc-scenario-002")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(runs) != 1 || runs[0].ID != "run-scenario-001" {
+		t.Fatalf("got %d runs, want run-scenario-001", len(runs))
+	}
+}
+
+func TestStartRunForIncident(t *testing.T) {
+	p, _ := New(nil)
+	provider := p.(*Provider)
+
+	run, err := provider.StartRunForIncident(context.Background(), "plan-runbook-001", "inc-001")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if run.Fields["incident_id"] != "inc-001" {
+		t.Errorf("got incident_id %v, want inc-001", run.Fields["incident_id"])
+	}
+
+	runs, _ := provider.RunsForIncident(context.Background(), "inc-001")
+	if len(runs) != 1 || runs[0].ID != run.ID {
+		t.Errorf("got %d runs for inc-001, want %s", len(runs), run.ID)
+	}
+
+	unlinked, _ := provider.StartRun(context.Background(), "plan-runbook-001")
+	if _, ok := unlinked.Fields["incident_id"]; ok {
+		t.Error("StartRun without incident should not set incident_id")
+	}
+}
+
+func TestStepWebhookCallback(t *testing.T) {
+	received := make(chan stepWebhookPayload, 4)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload stepWebhookPayload
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+		received <- payload
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer srv.Close()
+
+	pAny, _ := New(map[string]any{"step_webhook_url": srv.URL, "step_callback_timeout": "5s"})
+	p := pAny.(*Provider)
+	run, err := p.StartRun(context.Background(), "plan-playbook-005")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var payload stepWebhookPayload
+	select {
+	case payload = <-received:
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected webhook for the first automated step")
+	}
+	if payload.RunID != run.ID || payload.StepID != run.Steps[0].StepID || payload.Event != "orchestration.step.started" {
+		t.Fatalf("unexpected webhook payload: %+v", payload)
+	}
+
+	// The step waits for the runner instead of completing on its own.
+	got, _ := p.GetRun(context.Background(), run.ID)
+	if got.Steps[0].Status != "running" {
+		t.Fatalf("expected step to wait for callback, got %s", got.Steps[0].Status)
+	}
+
+	if err := p.StepCallback(context.Background(), run.ID, payload.StepID, "succeeded", "runner finished"); err != nil {
+		t.Fatalf("callback failed: %v", err)
+	}
+	got, _ = p.GetRun(context.Background(), run.ID)
+	if got.Steps[0].Status != "succeeded" || got.Steps[0].Actor != webhookActor {
+		t.Fatalf("expected step completed by runner, got %+v", got.Steps[0])
+	}
+	select {
+	case next := <-received:
+		if next.StepID != got.Steps[1].StepID {
+			t.Fatalf("expected webhook for the next step, got %s", next.StepID)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected webhook for the dependent automated step")
+	}
+
+	if err := p.StepCallback(context.Background(), run.ID, payload.StepID, "succeeded", ""); err == nil || !strings.Contains(err.Error(), "bad_request") {
+		t.Fatalf("expected bad_request for a step no longer awaiting a callback, got %v", err)
+	}
+}
+
+func TestStepWebhookFailures(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/broken" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	waitForStatus := func(p *Provider, runID, want string) schema.OrchestrationRun {
+		t.Helper()
+		deadline := time.Now().Add(2 * time.Second)
+		for {
+			run, _ := p.GetRun(context.Background(), runID)
+			if run.Status == want || time.Now().After(deadline) {
+				return *run
+			}
+			time.Sleep(20 * time.Millisecond)
+		}
+	}
+
+	silent, _ := New(map[string]any{"step_webhook_url": srv.URL, "step_callback_timeout": "100ms"})
+	run, _ := silent.StartRun(context.Background(), "plan-playbook-005")
+	timedOut := waitForStatus(silent.(*Provider), run.ID, "failed")
+	if timedOut.Status != "failed" || timedOut.Steps[0].Status != "failed" {
+		t.Fatalf("expected callback timeout to fail the run, got %s / %s", timedOut.Status, timedOut.Steps[0].Status)
+	}
+	hooks, _ := timedOut.Fields["stepWebhooks"].(map[string]any)
+	if record, _ := hooks[timedOut.Steps[0].StepID].(map[string]any); record["status"] != webhookTimedOut {
+		t.Fatalf("expected timed_out webhook record, got %v", hooks)
+	}
+	if timedOut.Steps[1].Status != "pending" {
+		t.Fatalf("expected dependent step to stay pending, got %s", timedOut.Steps[1].Status)
+	}
+
+	broken, _ := New(map[string]any{"step_webhook_url": srv.URL + "/broken", "step_callback_timeout": "1m"})
+	run, _ = broken.StartRun(context.Background(), "plan-playbook-005")
+	rejected := waitForStatus(broken.(*Provider), run.ID, "failed")
+	if rejected.Steps[0].Status != "failed" || !strings.Contains(rejected.Steps[0].Note, "500") {
+		t.Fatalf("expected rejected delivery to fail the step, got %+v", rejected.Steps[0])
+	}
+}
+
+func TestResumedStepIgnoresStaleWebhookTimer(t *testing.T) {
+	var mu sync.Mutex
+	deliveries := 0
+	received := make(chan struct{}, 4)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		deliveries++
+		first := deliveries == 1
+		mu.Unlock()
+		if first {
+			w.WriteHeader(http.StatusInternalServerError)
+		} else {
+			w.WriteHeader(http.StatusAccepted)
+		}
+		received <- struct{}{}
+	}))
+	defer srv.Close()
+
+	pAny, _ := New(map[string]any{"step_webhook_url": srv.URL, "step_callback_timeout": "1m"})
+	p := pAny.(*Provider)
+	run, _ := p.StartRun(context.Background(), "plan-playbook-005")
+	stepID := run.Steps[0].StepID
+	stepRecord := func() map[string]any {
+		got, _ := p.GetRun(context.Background(), run.ID)
+		hooks, _ := got.Fields["stepWebhooks"].(map[string]any)
+		record, _ := hooks[stepID].(map[string]any)
+		return record
+	}
+	waitFor := func(cond func() bool, what string) {
+		t.Helper()
+		deadline := time.Now().Add(2 * time.Second)
+		for !cond() {
+			if time.Now().After(deadline) {
+				t.Fatalf("timed out waiting for %s", what)
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+
+	<-received
+	waitFor(func() bool { return stepRecord()["status"] == webhookFailed }, "the failed delivery")
+	stale, _ := stepRecord()["dispatch"].(int)
+
+	if _, err := p.ResumeRun(context.Background(), run.ID, "jordan"); err != nil {
+		t.Fatalf("ResumeRun returned error: %v", err)
+	}
+	<-received
+	waitFor(func() bool { return stepRecord()["status"] == webhookDelivered }, "the second delivery")
+
+	// The first handoff's callback timer fires after the resume.
+	p.timeoutStep(run.ID, stepID, stale)
+	got, _ := p.GetRun(context.Background(), run.ID)
+	if got.Steps[0].Status != "running" || got.Status != "running" {
+		t.Fatalf("stale timer failed the resumed step: step %s, run %s", got.Steps[0].Status, got.Status)
+	}
+	if err := p.StepCallback(context.Background(), run.ID, stepID, "succeeded", "runner finished"); err != nil {
+		t.Fatalf("callback for the resumed step failed: %v", err)
+	}
+}
+
+func TestResumeRun(t *testing.T) {
+	pAny, _ := New(map[string]any{"step_duration": "50ms"})
+	p := pAny.(*Provider)
+
+	run, err := p.ResumeRun(context.Background(), "run-005", "jordan")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if run.Status != "running" {
+		t.Fatalf("got status %q, want running", run.Status)
+	}
+	want := map[string]string{"step-1": "succeeded", "step-2": "succeeded", "step-3a": "succeeded", "step-3b": "ready", "step-4": "pending"}
+	for _, state := range run.Steps {
+		if state.Status != want[state.StepID] {
+			t.Errorf("step %s status %q, want %q", state.StepID, state.Status, want[state.StepID])
+		}
+	}
+	if run.Steps[0].Actor != "jordan" {
+		t.Errorf("succeeded step lost its actor: %+v", run.Steps[0])
+	}
+	resumes, _ := run.Fields["resumes"].([]map[string]any)
+	if len(resumes) != 1 || resumes[0]["actor"] != "jordan" {
+		t.Fatalf("expected resume recorded, got %v", run.Fields["resumes"])
+	}
+
+	if err := p.CompleteStep(context.Background(), "run-005", "step-3b", "jordan", "retried with refreshed bundle"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	run, _ = p.GetRun(context.Background(), "run-005")
+	if state := findStepState(run.Steps, "step-4"); state.Status != "ready" {
+		t.Errorf("step-4 status %q, want ready once its dependencies succeed", state.Status)
+	}
+
+	if _, err := p.ResumeRun(context.Background(), "run-005", "jordan"); err == nil || !strings.Contains(err.Error(), "bad_request") {
+		t.Errorf("expected bad_request resuming a running run, got %v", err)
+	}
+	if _, err := p.ResumeRun(context.Background(), "run-missing", "jordan"); err == nil || !strings.Contains(err.Error(), "not_found") {
+		t.Errorf("expected not_found, got %v", err)
+	}
+
+	// Automated failed steps restart and run to completion again.
+	automated, err := p.ResumeRun(context.Background(), "run-004", "sre-bot")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if state := findStepState(automated.Steps, "step-2"); state.Status != "running" || state.StartedAt == nil {
+		t.Fatalf("expected automated step restarted, got %+v", state)
+	}
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		run, _ = p.GetRun(context.Background(), "run-004")
+		if findStepState(run.Steps, "step-3").Status == "succeeded" || time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	if findStepState(run.Steps, "step-2").Status != "succeeded" || findStepState(run.Steps, "step-3").Status != "succeeded" {
+		t.Fatalf("expected resumed automation to finish, got %+v", run.Steps)
+	}
+}
+
+func TestExportRun(t *testing.T) {
+	pAny, _ := New(map[string]any{"step_duration": "1h"})
+	p := pAny.(*Provider)
+	ctx := context.Background()
+
+	run, err := p.StartRunForIncident(ctx, "plan-playbook-001", "inc-001")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	first := run.Steps[0].StepID
+	if err := p.CompleteStep(ctx, run.ID, first, "alex", "Paged DBA | confirmed replica lag"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	report, err := p.ExportRun(ctx, run.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if report.RunID != run.ID || report.ContentType != "text/markdown" {
+		t.Fatalf("unexpected report envelope: %+v", report)
+	}
+	plan, _ := p.GetPlan(ctx, "plan-playbook-001")
+	for _, want := range []string{
+		"# " + run.ID + ": " + plan.Title,
+		"| Incident | inc-001 |",
+		"## Timeline",
+		"Run started from plan",
+		plan.Steps[0].Title + " (`" + first + "`) succeeded by alex",
+		"  > Paged DBA | confirmed replica lag",
+		"Paged DBA \\| confirmed replica lag",
+	} {
+		if !strings.Contains(report.Content, want) {
+			t.Fatalf("export missing %q:\n%s", want, report.Content)
+		}
+	}
+	if strings.Index(report.Content, "Run started") > strings.Index(report.Content, "succeeded by alex") {
+		t.Fatalf("expected the timeline in chronological order:\n%s", report.Content)
+	}
+
+	completed, err := p.ExportRun(ctx, "run-003")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	timeline := completed.Content[strings.Index(completed.Content, "## Timeline"):strings.Index(completed.Content, "## Steps")]
+	if !strings.HasSuffix(strings.TrimSpace(timeline), "Run completed") {
+		t.Fatalf("expected the run to finish after its last step:\n%s", timeline)
+	}
+
+	if _, err := p.ExportRun(ctx, "run-missing"); err == nil || !strings.Contains(err.Error(), "not_found") {
+		t.Fatalf("expected not_found, got %v", err)
+	}
+}
+
+func TestLocaleTranslatesSeededPlans(t *testing.T) {
+	provAny, err := New(map[string]any{"locale": "de"})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	plan, err := provAny.(*Provider).GetPlan(context.Background(), "plan-runbook-001")
+	if err != nil {
+		t.Fatalf("GetPlan returned error: %v", err)
+	}
+	if plan.Title != "Datenbank-Failover" {
+		t.Fatalf("expected German plan title, got %q", plan.Title)
+	}
+	if len(plan.Steps) == 0 || plan.Steps[0].Title != "Prüfungen vor dem Failover" {
+		t.Fatalf("expected German step titles, got %+v", plan.Steps)
+	}
+}
+
+func TestRunStepTimersFlagOverdueSteps(t *testing.T) {
+	provAny, _ := New(nil)
+	p := provAny.(*Provider)
+	ctx := context.Background()
+
+	plan, _ := p.GetPlan(ctx, "plan-playbook-001")
+	if got := plan.Steps[0].Metadata["expectedDuration"]; got != "15m0s" {
+		t.Errorf("expected manual steps to default to 15m, got %v", got)
+	}
+	if got := plan.Steps[2].Metadata["expectedDuration"]; got != "10m" {
+		t.Errorf("expected the seeded expectation to be kept, got %v", got)
+	}
+
+	stuck, _ := p.GetRun(ctx, "run-001")
+	timers, _ := stuck.Fields["stepTimers"].([]StepTimer)
+	if len(timers) != 1 || timers[0].StepID != "step-3" || !timers[0].Overdue || timers[0].Expected != "10m0s" || timers[0].OverdueBy == "" {
+		t.Fatalf("expected step-3 overdue against 10m, got %+v", timers)
+	}
+	if overdue, _ := stuck.Fields["overdueSteps"].([]string); len(overdue) != 1 || overdue[0] != "step-3" {
+		t.Fatalf("expected overdueSteps [step-3], got %v", stuck.Fields["overdueSteps"])
+	}
+
+	onTrack, _ := p.GetRun(ctx, "run-002")
+	if overdue, _ := onTrack.Fields["overdueSteps"].([]string); len(overdue) != 0 {
+		t.Fatalf("expected run-002 on track, got overdue %v", overdue)
+	}
+
+	// Parallel branches each run their own clock.
+	now := time.Now().UTC()
+	early, late := now.Add(-40*time.Minute), now.Add(-2*time.Minute)
+	p.mu.Lock()
+	run := p.runs["run-scenario-001"]
+	run.Steps[1] = schema.OrchestrationStepState{StepID: "step-2", Status: "ready", UpdatedAt: &early}
+	run.Steps[2] = schema.OrchestrationStepState{StepID: "step-3", Status: "ready", UpdatedAt: &late}
+	p.runs[run.ID] = run
+	p.mu.Unlock()
+	runs, _ := p.RunsForIncident(ctx, "inc-scenario-002")
+	timers, _ = runs[0].Fields["stepTimers"].([]StepTimer)
+	if len(timers) != 2 || !timers[0].Overdue || timers[1].Overdue {
+		t.Fatalf("expected only the older branch overdue, got %+v", timers)
+	}
+
+	done, _ := p.GetRun(ctx, "run-003")
+	if _, ok := done.Fields["stepTimers"]; ok {
+		t.Fatalf("expected no timers on a completed run, got %v", done.Fields["stepTimers"])
+	}
+}
+
+func TestRunStepActorsResolveThroughRegistry(t *testing.T) {
+	provAny, _ := New(nil)
+	p := provAny.(*Provider)
+
+	run, err := p.GetRun(context.Background(), "run-004")
+	if err != nil {
+		t.Fatalf("GetRun returned error: %v", err)
+	}
+	actors, ok := run.Fields["stepActors"].(map[string]map[string]any)
+	if !ok {
+		t.Fatalf("expected step actors, got %v", run.Fields["stepActors"])
+	}
+	if got := actors["step-2"]; got["type"] != "automation" || got["displayName"] != "Runbook Automation" {
+		t.Fatalf("expected system-automation from the registry, got %v", got)
+	}
+}
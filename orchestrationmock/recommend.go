@@ -0,0 +1,226 @@
+package orchestrationmock
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/opsorch/opsorch-core/orcherr"
+	"github.com/opsorch/opsorch-core/schema"
+)
+
+// Recommendation weights; they sum to 1 so a plan matching on every signal
+// scores 1.
+const (
+	recommendLinkWeight    = 0.3
+	recommendServiceWeight = 0.2
+	recommendHintWeight    = 0.2
+	recommendKeywordWeight = 0.2
+	recommendTagWeight     = 0.1
+	defaultRecommendLimit  = 3
+)
+
+// recommendStopwords are dropped before comparing the context with plan text.
+var recommendStopwords = map[string]bool{
+	"the": true, "and": true, "for": true, "with": true, "from": true, "into": true,
+	"this": true, "that": true, "such": true, "use": true, "are": true, "was": true,
+	"service": true, "incident": true, "incidents": true, "response": true, "scenario": true,
+}
+
+// scenarioHint matches the quoted scenario titles in plan descriptions, as in
+// "Use this response for 'Checkout latency impacting EU customers' ...".
+var scenarioHint = regexp.MustCompile(`'([^']+)'`)
+
+// RecommendInput is the incident or alert context plans are scored against.
+// Incident or Alert fill any of the plain fields left empty.
+type RecommendInput struct {
+	Title       string            `json:"title,omitempty"`
+	Description string            `json:"description,omitempty"`
+	Service     string            `json:"service,omitempty"`
+	Tags        map[string]string `json:"tags,omitempty"`
+	// PlanID is a plan the context already links to, such as an alert's
+	// Metadata["planId"] from its runbook.
+	PlanID   string           `json:"planId,omitempty"`
+	Incident *schema.Incident `json:"incident,omitempty"`
+	Alert    *schema.Alert    `json:"alert,omitempty"`
+	Limit    int              `json:"limit,omitempty"`
+}
+
+// PlanRecommendation is a plan ranked against an incident or alert.
+type PlanRecommendation struct {
+	Plan schema.OrchestrationPlan `json:"plan"`
+	// Score is in [0, 1]; Reasons explain which signals contributed.
+	Score          float64  `json:"score"`
+	Reasons        []string `json:"reasons"`
+	SharedKeywords []string `json:"sharedKeywords,omitempty"`
+}
+
+// RecommendPlans ranks the plans by how well they fit an incident or alert:
+// a plan the context already links to, the same service tag, a quoted
+// scenario title from the plan description found in the context, keywords
+// shared between the context and the plan's title and description, and
+// matching tags. Plans matching on tags alone are left out.
+func (p *Provider) RecommendPlans(ctx context.Context, in RecommendInput) ([]PlanRecommendation, error) {
+	_ = ctx
+	in = in.withRecord()
+	if strings.TrimSpace(in.Title+in.Description) == "" && in.Service == "" && in.PlanID == "" {
+		return nil, orcherr.New("bad_request", "title, description, service, or an incident or alert is required", nil)
+	}
+	limit := in.Limit
+	if limit <= 0 {
+		limit = defaultRecommendLimit
+	}
+	text := strings.ToLower(in.Title + " " + in.Description)
+	words := recommendKeywords(text)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	out := []PlanRecommendation{}
+	for _, plan := range p.plans {
+		rec := PlanRecommendation{}
+		if in.PlanID != "" && plan.ID == in.PlanID {
+			rec.Score += recommendLinkWeight
+			rec.Reasons = append(rec.Reasons, "linked from the runbook")
+		}
+		if in.Service != "" && plan.Tags["service"] == in.Service {
+			rec.Score += recommendServiceWeight
+			rec.Reasons = append(rec.Reasons, "tagged for "+in.Service)
+		}
+		if hint := matchingHint(plan.Description, text); hint != "" {
+			rec.Score += recommendHintWeight
+			rec.Reasons = append(rec.Reasons, fmt.Sprintf("written for '%s'", hint))
+		}
+		planWords := recommendKeywords(strings.ToLower(plan.Title + " " + plan.Description))
+		if shared := sharedWords(words, planWords); len(shared) > 0 {
+			// Relative to the context, so a terse alert title can still match
+			// fully; plan descriptions are always the longer text.
+			rec.Score += recommendKeywordWeight * math.Min(1, float64(len(shared))/float64(len(words)))
+			rec.SharedKeywords = shared
+			rec.Reasons = append(rec.Reasons, fmt.Sprintf("%d shared keywords", len(shared)))
+		}
+		if rec.Score == 0 {
+			continue
+		}
+		// Most plans share the prod environment tag, so tags only break ties
+		// between plans that matched on something else.
+		if matched := matchingTags(plan.Tags, in.Tags); len(matched) > 0 {
+			rec.Score += recommendTagWeight * float64(len(matched)) / float64(len(in.Tags))
+			rec.Reasons = append(rec.Reasons, "matching tags "+strings.Join(matched, ", "))
+		}
+		rec.Score = math.Round(rec.Score*100) / 100
+		rec.Plan = clonePlan(plan)
+		out = append(out, rec)
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Score != out[j].Score {
+			return out[i].Score > out[j].Score
+		}
+		return out[i].Plan.ID < out[j].Plan.ID
+	})
+	if len(out) > limit {
+		out = out[:limit]
+	}
+	return out, nil
+}
+
+// withRecord fills empty fields from the incident or alert.
+func (in RecommendInput) withRecord() RecommendInput {
+	var title, description, service string
+	var fields, metadata map[string]any
+	switch {
+	case in.Incident != nil:
+		title, description, service = in.Incident.Title, in.Incident.Description, in.Incident.Service
+		fields, metadata = in.Incident.Fields, in.Incident.Metadata
+	case in.Alert != nil:
+		title, description, service = in.Alert.Title, in.Alert.Description, in.Alert.Service
+		fields, metadata = in.Alert.Fields, in.Alert.Metadata
+	default:
+		return in
+	}
+	if service == "" {
+		service, _ = fields["service"].(string)
+	}
+	planID, _ := metadata["planId"].(string)
+	in.Title = firstNonEmpty(in.Title, title)
+	in.Description = firstNonEmpty(in.Description, description)
+	in.Service = firstNonEmpty(in.Service, service)
+	in.PlanID = firstNonEmpty(in.PlanID, planID)
+	if env, ok := fields["environment"].(string); ok && env != "" {
+		tags := cloneStringMap(in.Tags)
+		if tags == nil {
+			tags = map[string]string{}
+		}
+		if _, set := tags["environment"]; !set {
+			tags["environment"] = env
+		}
+		in.Tags = tags
+	}
+	return in
+}
+
+// matchingHint returns the first quoted scenario title in description that
+// appears in text.
+func matchingHint(description, text string) string {
+	for _, m := range scenarioHint.FindAllStringSubmatch(description, -1) {
+		if strings.Contains(text, strings.ToLower(m[1])) {
+			return m[1]
+		}
+	}
+	return ""
+}
+
+// matchingTags returns the sorted keys whose values agree in both tag sets.
+func matchingTags(planTags, want map[string]string) []string {
+	var out []string
+	for k, v := range want {
+		if v != "" && planTags[k] == v {
+			out = append(out, k)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// recommendKeywords returns the distinct significant words of lowercased
+// text, with a trailing plural "s" trimmed (but not from words like "redis").
+func recommendKeywords(text string) map[string]bool {
+	words := map[string]bool{}
+	for _, word := range strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		if len(word) < 3 || recommendStopwords[word] {
+			continue
+		}
+		if len(word) > 4 && strings.HasSuffix(word, "s") && !strings.HasSuffix(word, "ss") && !strings.HasSuffix(word, "is") {
+			word = strings.TrimSuffix(word, "s")
+		}
+		words[word] = true
+	}
+	return words
+}
+
+func sharedWords(a, b map[string]bool) []string {
+	var out []string
+	for word := range a {
+		if b[word] {
+			out = append(out, word)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
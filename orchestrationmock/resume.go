@@ -0,0 +1,97 @@
+package orchestrationmock
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/opsorch/opsorch-core/orcherr"
+	"github.com/opsorch/opsorch-core/schema"
+)
+
+// ResumeRun restarts a failed run from its failed steps. Succeeded steps keep
+// their state; failed steps go back to running (automated) or ready (manual)
+// and automation picks them up again. Each resume is recorded in
+// run.Fields["resumes"].
+func (p *Provider) ResumeRun(ctx context.Context, runID string, actor string) (*schema.OrchestrationRun, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	run, ok := p.runs[runID]
+	if !ok {
+		return nil, orcherr.New("not_found", "run not found", nil)
+	}
+	if run.Status != "failed" {
+		return nil, orcherr.New("bad_request", fmt.Sprintf("run %s is %s; only failed runs can be resumed", runID, run.Status), nil)
+	}
+
+	now := time.Now().UTC()
+	steps := make([]schema.OrchestrationStepState, len(run.Steps))
+	copy(steps, run.Steps)
+	resumed := make([]string, 0)
+	for i, state := range steps {
+		if state.Status != "failed" {
+			continue
+		}
+		state.Status = "ready"
+		state.StartedAt = nil
+		if p.isAutomatedStep(run, state.StepID) {
+			state.Status = "running"
+			state.StartedAt = &now
+		}
+		state.Actor = ""
+		state.Note = fmt.Sprintf("Resumed after failure: %s", steps[i].Note)
+		state.FinishedAt = nil
+		state.UpdatedAt = &now
+		steps[i] = state
+		resumed = append(resumed, state.StepID)
+	}
+
+	fields := cloneMap(run.Fields)
+	if fields == nil {
+		fields = map[string]any{}
+	}
+	// Resumed steps get a fresh webhook handoff.
+	if hooks, ok := fields["stepWebhooks"].(map[string]any); ok {
+		hooks = cloneMap(hooks)
+		for _, id := range resumed {
+			delete(hooks, id)
+		}
+		fields["stepWebhooks"] = hooks
+	}
+	history, _ := fields["resumes"].([]map[string]any)
+	fields["resumes"] = append(append([]map[string]any{}, history...), map[string]any{
+		"at":    now.Format(time.RFC3339),
+		"actor": actor,
+		"steps": resumed,
+	})
+
+	run.Steps = steps
+	run.Fields = fields
+	run.Status = "running"
+	run.UpdatedAt = now
+	p.runs[runID] = run
+
+	p.checkAutomatedSteps(ctx, &run)
+
+	cloned := p.presentRun(p.runs[runID], time.Now().UTC())
+	return &cloned, nil
+}
+
+func (p *Provider) isAutomatedStep(run schema.OrchestrationRun, stepID string) bool {
+	plan := run.Plan
+	if plan == nil {
+		stored, ok := p.plans[run.PlanID]
+		if !ok {
+			return false
+		}
+		plan = &stored
+	}
+	for _, step := range plan.Steps {
+		if step.ID == stepID {
+			automated, _ := step.Metadata["automated"].(bool)
+			return step.Type == "automated" || automated
+		}
+	}
+	return false
+}
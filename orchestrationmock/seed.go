@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/opsorch/opsorch-core/schema"
+	"github.com/opsorch/opsorch-mock-adapters/internal/mockutil"
 )
 
 func (p *Provider) seed() {
@@ -21,10 +22,34 @@ func (p *Provider) seed() {
 	// Seed complex flow plans
 	p.seedComplexFlows(now)
 
+	// Translate plans and stamp step expectations before runs copy them
+	p.localizePlans()
+	p.stampExpectedDurations()
+
 	// Seed active runs
 	p.seedRuns(now)
 }
 
+// localizePlans translates seeded plan and step titles and descriptions into
+// cfg.Locale.
+func (p *Provider) localizePlans() {
+	if p.cfg.Locale == "" {
+		return
+	}
+	for id, plan := range p.plans {
+		plan.Title = mockutil.Localize(p.cfg.Locale, plan.Title)
+		plan.Description = mockutil.Localize(p.cfg.Locale, plan.Description)
+		steps := make([]schema.OrchestrationStep, len(plan.Steps))
+		for i, step := range plan.Steps {
+			step.Title = mockutil.Localize(p.cfg.Locale, step.Title)
+			step.Description = mockutil.Localize(p.cfg.Locale, step.Description)
+			steps[i] = step
+		}
+		plan.Steps = steps
+		p.plans[id] = plan
+	}
+}
+
 func (p *Provider) seedPlaybooks(now time.Time) {
 	playbooks := []schema.OrchestrationPlan{
 		{
@@ -55,6 +80,7 @@ func (p *Provider) seedPlaybooks(now time.Time) {
 					Description: "Send notification to service owners about potential connection issues. " +
 						"Prepare for possible service degradation.",
 					DependsOn: []string{"step-2"},
+					Metadata:  map[string]any{"expectedDuration": "10m"},
 				},
 				{
 					ID:    "step-4",
@@ -79,6 +105,7 @@ func (p *Provider) seedPlaybooks(now time.Time) {
 					Description: "Confirm connection pool is healthy and services are responding normally. " +
 						"Monitor for 30 minutes.",
 					DependsOn: []string{"step-5"},
+					Metadata:  map[string]any{"expectedDuration": "35m"},
 				},
 			},
 			URL:     "https://runbook.demo/playbooks/db-connection-pool",
@@ -1243,6 +1270,11 @@ func (p *Provider) seedComplexFlows(now time.Time) {
 }
 
 func (p *Provider) seedRuns(now time.Time) {
+	// run-001 is blocked on a notification step that has waited well past its
+	// 10m expectation, so run monitors have a stuck manual step to flag;
+	// run-002's current step is still within its expectation.
+	stuckSince := now.Add(-28 * time.Minute)
+	releaseStepStarted := now.Add(-3 * time.Minute)
 	runs := []schema.OrchestrationRun{
 		{
 			ID:     "run-001",
@@ -1255,7 +1287,7 @@ func (p *Provider) seedRuns(now time.Time) {
 			Steps: []schema.OrchestrationStepState{
 				{StepID: "step-1", Status: "succeeded", UpdatedAt: &now},
 				{StepID: "step-2", Status: "succeeded", UpdatedAt: &now},
-				{StepID: "step-3", Status: "ready", UpdatedAt: &now},
+				{StepID: "step-3", Status: "ready", UpdatedAt: &stuckSince},
 				{StepID: "step-4", Status: "pending", UpdatedAt: &now},
 				{StepID: "step-5", Status: "pending", UpdatedAt: &now},
 				{StepID: "step-6", Status: "pending", UpdatedAt: &now},
@@ -1276,7 +1308,7 @@ func (p *Provider) seedRuns(now time.Time) {
 			Steps: []schema.OrchestrationStepState{
 				{StepID: "step-1", Status: "succeeded", UpdatedAt: &now},
 				{StepID: "step-2", Status: "succeeded", UpdatedAt: &now},
-				{StepID: "step-3", Status: "running", UpdatedAt: &now},
+				{StepID: "step-3", Status: "running", StartedAt: &releaseStepStarted, UpdatedAt: &now},
 				{StepID: "step-4", Status: "pending", UpdatedAt: &now},
 				{StepID: "step-5", Status: "pending", UpdatedAt: &now},
 				{StepID: "step-6", Status: "pending", UpdatedAt: &now},
@@ -1308,6 +1340,48 @@ func (p *Provider) seedRuns(now time.Time) {
 				"source": p.cfg.Source,
 			},
 		},
+		{
+			// Backfill hit its timeout; resume retries it without redoing step-1.
+			ID:     "run-004",
+			PlanID: "plan-playbook-005",
+			Status: "failed",
+			Scope: schema.QueryScope{
+				Service:     "svc-analytics",
+				Environment: "prod",
+			},
+			Steps: []schema.OrchestrationStepState{
+				{StepID: "step-1", Status: "succeeded", Actor: automationActor, UpdatedAt: &now},
+				{StepID: "step-2", Status: "failed", Actor: automationActor, Note: "Backfill job exceeded its 30m timeout after 6 of 9 partitions", UpdatedAt: &now},
+				{StepID: "step-3", Status: "pending", UpdatedAt: &now},
+				{StepID: "step-4", Status: "pending", UpdatedAt: &now},
+			},
+			CreatedAt: now.Add(-50 * time.Minute),
+			UpdatedAt: now.Add(-12 * time.Minute),
+			Metadata: map[string]any{
+				"source": p.cfg.Source,
+			},
+		},
+		{
+			ID:     "run-005",
+			PlanID: "plan-runbook-002",
+			Status: "failed",
+			Scope: schema.QueryScope{
+				Service:     "svc-ingress",
+				Environment: "prod",
+			},
+			Steps: []schema.OrchestrationStepState{
+				{StepID: "step-1", Status: "succeeded", Actor: "jordan", UpdatedAt: &now},
+				{StepID: "step-2", Status: "succeeded", Actor: "jordan", UpdatedAt: &now},
+				{StepID: "step-3a", Status: "succeeded", Actor: "jordan", UpdatedAt: &now},
+				{StepID: "step-3b", Status: "failed", Actor: "jordan", Note: "Config push rejected by 2 of 6 app servers: stale trust bundle", UpdatedAt: &now},
+				{StepID: "step-4", Status: "pending", UpdatedAt: &now},
+			},
+			CreatedAt: now.Add(-3 * time.Hour),
+			UpdatedAt: now.Add(-2 * time.Hour),
+			Metadata: map[string]any{
+				"source": p.cfg.Source,
+			},
+		},
 		{
 			ID:     "run-scenario-001",
 			PlanID: "plan-playbook-001",
@@ -1326,6 +1400,9 @@ func (p *Provider) seedRuns(now time.Time) {
 			},
 			CreatedAt: now.Add(-10 * time.Minute),
 			UpdatedAt: now.Add(-1 * time.Minute),
+			Fields: map[string]any{
+				"incident_id": "inc-scenario-002",
+			},
 			Metadata: map[string]any{
 				"source":      p.cfg.Source,
 				"scenario_id": "active-incident-response",
@@ -0,0 +1,242 @@
+package orchestrationmock
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/opsorch/opsorch-core/orcherr"
+	"github.com/opsorch/opsorch-core/schema"
+)
+
+// approvalKeywords mark a step as a sign-off in its title or description,
+// unless its Metadata["approval"] says otherwise.
+var approvalKeywords = []string{"approval", "approve", "sign-off", "sign off"}
+
+// Step executors in a simulation.
+const (
+	ExecutorResponder = "responder"
+	ExecutorRunner    = "runner"
+	ExecutorWebhook   = "webhook"
+)
+
+// SimulatedStep is one step's predicted slot in a run, as offsets from the
+// run's start.
+type SimulatedStep struct {
+	StepID    string   `json:"stepId"`
+	Title     string   `json:"title"`
+	Type      string   `json:"type"`
+	Level     int      `json:"level"`
+	DependsOn []string `json:"dependsOn,omitempty"`
+	Start     string   `json:"start"`
+	End       string   `json:"end"`
+	Expected  string   `json:"expected"`
+	Executor  string   `json:"executor"`
+	Approval  bool     `json:"approval,omitempty"`
+	Critical  bool     `json:"critical,omitempty"`
+}
+
+// RunRequirements are what a run of the plan needs lined up before it starts.
+type RunRequirements struct {
+	// Approvals are the steps that need a sign-off.
+	Approvals      []string `json:"approvals"`
+	ManualSteps    int      `json:"manualSteps"`
+	AutomatedSteps int      `json:"automatedSteps"`
+	// PeakResponders is the most manual steps predicted to be open at once.
+	PeakResponders int               `json:"peakResponders"`
+	Team           string            `json:"team,omitempty"`
+	Services       []string          `json:"services"`
+	Webhook        string            `json:"webhook,omitempty"`
+	Tags           map[string]string `json:"tags,omitempty"`
+}
+
+// RunSimulation predicts how a run of a plan would go, without starting one.
+type RunSimulation struct {
+	PlanID    string          `json:"planId"`
+	PlanTitle string          `json:"planTitle"`
+	Order     []string        `json:"order"`
+	Steps     []SimulatedStep `json:"steps"`
+	// EstimatedDuration is wall-clock time with parallel branches overlapped;
+	// TotalEffort adds every step up end to end.
+	EstimatedDuration string          `json:"estimatedDuration"`
+	TotalEffort       string          `json:"totalEffort"`
+	CriticalPath      []string        `json:"criticalPath"`
+	MaxParallelism    int             `json:"maxParallelism"`
+	Requirements      RunRequirements `json:"requirements"`
+}
+
+// SimulateRun dry-runs a plan: each step starts as soon as its dependencies
+// finish and takes its expected duration, giving the predicted execution
+// order, the run's duration, and the critical path by time rather than by
+// step count. Requirements list the approval steps, how many responders the
+// busiest stretch needs, and who runs automated steps. No run is created.
+func (p *Provider) SimulateRun(ctx context.Context, planID string) (*RunSimulation, error) {
+	_ = ctx
+	p.mu.Lock()
+	plan, ok := p.plans[planID]
+	webhook := p.cfg.StepWebhookURL
+	p.mu.Unlock()
+	if !ok {
+		return nil, orcherr.New("not_found", "plan not found", nil)
+	}
+	analysis, err := AnalyzePlan(plan)
+	if err != nil {
+		return nil, err
+	}
+	levelOf := stepLevels(plan.Steps)
+
+	// Schedule in level order so every dependency finishes before its
+	// dependents are placed.
+	steps := make(map[string]schema.OrchestrationStep, len(plan.Steps))
+	for _, step := range plan.Steps {
+		steps[step.ID] = step
+	}
+	start := map[string]time.Duration{}
+	end := map[string]time.Duration{}
+	var total, effort time.Duration
+	for _, ids := range analysis.Levels {
+		for _, id := range ids {
+			step := steps[id]
+			for _, depID := range step.DependsOn {
+				if end[depID] > start[id] {
+					start[id] = end[depID]
+				}
+			}
+			d, _ := expectedDuration(step)
+			end[id] = start[id] + d
+			effort += d
+			if end[id] > total {
+				total = end[id]
+			}
+		}
+	}
+
+	sim := &RunSimulation{
+		PlanID:            plan.ID,
+		PlanTitle:         plan.Title,
+		Order:             make([]string, 0, len(plan.Steps)),
+		Steps:             make([]SimulatedStep, 0, len(plan.Steps)),
+		EstimatedDuration: total.String(),
+		TotalEffort:       effort.String(),
+		CriticalPath:      timedCriticalPath(plan.Steps, start, end, total),
+		MaxParallelism:    analysis.MaxParallelism,
+		Requirements: RunRequirements{
+			Approvals: []string{},
+			Team:      stringMetadata(plan.Metadata, "team"),
+			Services:  []string{},
+			Tags:      cloneStringMap(plan.Tags),
+		},
+	}
+	if service := plan.Tags["service"]; service != "" {
+		sim.Requirements.Services = append(sim.Requirements.Services, service)
+	}
+	critical := toSet(sim.CriticalPath)
+
+	for _, step := range plan.Steps {
+		d, _ := expectedDuration(step)
+		simulated := SimulatedStep{
+			StepID:    step.ID,
+			Title:     step.Title,
+			Type:      stepType(step),
+			Level:     levelOf[step.ID],
+			DependsOn: step.DependsOn,
+			Start:     start[step.ID].String(),
+			End:       end[step.ID].String(),
+			Expected:  d.String(),
+			Executor:  ExecutorResponder,
+			Approval:  needsApproval(step),
+			Critical:  critical[step.ID],
+		}
+		if simulated.Type == "automated" {
+			simulated.Executor = ExecutorRunner
+			if webhook != "" {
+				simulated.Executor = ExecutorWebhook
+				sim.Requirements.Webhook = webhook
+			}
+			sim.Requirements.AutomatedSteps++
+		} else {
+			sim.Requirements.ManualSteps++
+		}
+		if simulated.Approval {
+			sim.Requirements.Approvals = append(sim.Requirements.Approvals, step.ID)
+		}
+		sim.Steps = append(sim.Steps, simulated)
+	}
+	sim.Requirements.PeakResponders = peakResponders(sim.Steps, start, end)
+
+	ordered := append([]SimulatedStep(nil), sim.Steps...)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return start[ordered[i].StepID] < start[ordered[j].StepID]
+	})
+	for _, step := range ordered {
+		sim.Order = append(sim.Order, step.StepID)
+	}
+	return sim, nil
+}
+
+// timedCriticalPath walks back from the last step to finish through the
+// dependency that finishes exactly when each step starts.
+func timedCriticalPath(planSteps []schema.OrchestrationStep, start, end map[string]time.Duration, total time.Duration) []string {
+	deps := make(map[string][]string, len(planSteps))
+	last := ""
+	for _, step := range planSteps {
+		deps[step.ID] = step.DependsOn
+		if last == "" && end[step.ID] == total {
+			last = step.ID
+		}
+	}
+	path := []string{}
+	for id := last; id != ""; {
+		path = append([]string{id}, path...)
+		next := ""
+		for _, depID := range deps[id] {
+			if end[depID] == start[id] {
+				next = depID
+				break
+			}
+		}
+		id = next
+	}
+	return path
+}
+
+// peakResponders is the most manual steps whose predicted slots overlap.
+func peakResponders(steps []SimulatedStep, start, end map[string]time.Duration) int {
+	peak := 0
+	for _, at := range steps {
+		if at.Executor != ExecutorResponder {
+			continue
+		}
+		open := 0
+		for _, step := range steps {
+			if step.Executor == ExecutorResponder && start[step.StepID] <= start[at.StepID] && end[step.StepID] > start[at.StepID] {
+				open++
+			}
+		}
+		if open > peak {
+			peak = open
+		}
+	}
+	return peak
+}
+
+// needsApproval reports whether a step is a sign-off: Metadata["approval"]
+// when set, otherwise an approval keyword in its title or description.
+func needsApproval(step schema.OrchestrationStep) bool {
+	if approval, ok := step.Metadata["approval"].(bool); ok {
+		return approval
+	}
+	text := strings.ToLower(step.Title + " " + step.Description)
+	for _, keyword := range approvalKeywords {
+		if strings.Contains(text, keyword) {
+			return true
+		}
+	}
+	return false
+}
+
+func stringMetadata(m map[string]any, key string) string {
+	s, _ := m[key].(string)
+	return s
+}
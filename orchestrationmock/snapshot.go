@@ -0,0 +1,62 @@
+package orchestrationmock
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/opsorch/opsorch-core/schema"
+	"github.com/opsorch/opsorch-mock-adapters/internal/bundle"
+)
+
+// Snapshot returns the provider's plans and runs sorted by ID for export.
+func (p *Provider) Snapshot() ([]schema.OrchestrationPlan, []schema.OrchestrationRun) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	plans := make([]schema.OrchestrationPlan, 0, len(p.plans))
+	for _, plan := range p.plans {
+		plans = append(plans, clonePlan(plan))
+	}
+	sort.Slice(plans, func(i, j int) bool { return plans[i].ID < plans[j].ID })
+
+	runs := make([]schema.OrchestrationRun, 0, len(p.runs))
+	for _, run := range p.runs {
+		runs = append(runs, cloneRun(run))
+	}
+	sort.Slice(runs, func(i, j int) bool { return runs[i].ID < runs[j].ID })
+	return plans, runs
+}
+
+// Restore replaces the provider's plans and runs with a bundle's while it is
+// running, as the snapshot config does at startup. Sections the bundle leaves
+// empty keep their current records.
+func (p *Provider) Restore(b *bundle.Bundle) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.restore(b)
+}
+
+// restore replaces the seeded plans and runs with a bundle's and continues
+// run numbering after the highest restored ID.
+func (p *Provider) restore(b *bundle.Bundle) {
+	if b == nil {
+		return
+	}
+	if len(b.Plans) > 0 {
+		p.plans = make(map[string]schema.OrchestrationPlan, len(b.Plans))
+		for _, plan := range b.Plans {
+			p.plans[plan.ID] = clonePlan(plan)
+		}
+	}
+	if len(b.Runs) > 0 {
+		p.runs = make(map[string]schema.OrchestrationRun, len(b.Runs))
+		p.nextID = 0
+		for _, run := range b.Runs {
+			p.runs[run.ID] = cloneRun(run)
+			var n int
+			if _, err := fmt.Sscanf(run.ID, "run-%d", &n); err == nil && n > p.nextID {
+				p.nextID = n
+			}
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package orchestrationmock
+
+import (
+	"time"
+
+	"github.com/opsorch/opsorch-core/schema"
+)
+
+// expectedDurationKey is the step Metadata key holding how long a step should
+// take, as a Go duration string such as "15m".
+const expectedDurationKey = "expectedDuration"
+
+// defaultExpectedDurations apply, by step type, to steps that do not set
+// their own expected duration.
+var defaultExpectedDurations = map[string]time.Duration{
+	"automated": 5 * time.Minute,
+	"manual":    15 * time.Minute,
+}
+
+// timedStepStatuses have a running clock: ready steps wait on a responder and
+// running steps on automation.
+var timedStepStatuses = map[string]bool{"ready": true, "running": true}
+
+// StepTimer is the elapsed time of a ready or running step against its
+// expected duration. Runs carry one per timed step in Fields["stepTimers"].
+type StepTimer struct {
+	StepID string `json:"stepId"`
+	Status string `json:"status"`
+	// Since is when the step started running or became ready.
+	Since     time.Time `json:"since"`
+	Elapsed   string    `json:"elapsed"`
+	Expected  string    `json:"expected"`
+	Overdue   bool      `json:"overdue"`
+	OverdueBy string    `json:"overdueBy,omitempty"`
+}
+
+// stampExpectedDurations records the default expected duration on every
+// seeded step that does not set one, so plan payloads show it.
+func (p *Provider) stampExpectedDurations() {
+	for id, plan := range p.plans {
+		steps := make([]schema.OrchestrationStep, len(plan.Steps))
+		for i, step := range plan.Steps {
+			if _, ok := step.Metadata[expectedDurationKey]; !ok {
+				if d, ok := defaultExpectedDurations[stepType(step)]; ok {
+					step.Metadata = cloneMap(step.Metadata)
+					if step.Metadata == nil {
+						step.Metadata = map[string]any{}
+					}
+					step.Metadata[expectedDurationKey] = d.String()
+				}
+			}
+			steps[i] = step
+		}
+		plan.Steps = steps
+		p.plans[id] = plan
+	}
+}
+
+// expectedDuration reads a step's expected duration, falling back to the
+// default for its type.
+func expectedDuration(step schema.OrchestrationStep) (time.Duration, bool) {
+	if raw, ok := step.Metadata[expectedDurationKey].(string); ok {
+		if d, err := time.ParseDuration(raw); err == nil && d > 0 {
+			return d, true
+		}
+	}
+	d, ok := defaultExpectedDurations[stepType(step)]
+	return d, ok
+}
+
+func stepType(step schema.OrchestrationStep) string {
+	return firstNonEmpty(step.Type, "manual")
+}
+
+// presentRun clones a run for callers, stamping its step actors and timers
+// for its ready and running steps. Every timed step is tracked at once, so
+// parallel branches each show their own clock. Callers must hold p.mu.
+func (p *Provider) presentRun(run schema.OrchestrationRun, now time.Time) schema.OrchestrationRun {
+	cloned := cloneRun(run)
+	stampStepActors(&cloned)
+	plan := p.plans[run.PlanID]
+	if run.Plan != nil {
+		plan = *run.Plan
+	}
+	steps := map[string]schema.OrchestrationStep{}
+	for _, step := range plan.Steps {
+		steps[step.ID] = step
+	}
+
+	timers := []StepTimer{}
+	overdue := []string{}
+	for _, state := range run.Steps {
+		if !timedStepStatuses[state.Status] {
+			continue
+		}
+		expected, ok := expectedDuration(steps[state.StepID])
+		if !ok {
+			continue
+		}
+		since := run.CreatedAt
+		switch {
+		case state.StartedAt != nil:
+			since = *state.StartedAt
+		case state.UpdatedAt != nil:
+			since = *state.UpdatedAt
+		}
+		elapsed := now.Sub(since)
+		if elapsed < 0 {
+			elapsed = 0
+		}
+		timer := StepTimer{
+			StepID:   state.StepID,
+			Status:   state.Status,
+			Since:    since,
+			Elapsed:  elapsed.Round(time.Second).String(),
+			Expected: expected.String(),
+			Overdue:  elapsed > expected,
+		}
+		if timer.Overdue {
+			timer.OverdueBy = (elapsed - expected).Round(time.Second).String()
+			overdue = append(overdue, state.StepID)
+		}
+		timers = append(timers, timer)
+	}
+	if len(timers) == 0 {
+		return cloned
+	}
+	if cloned.Fields == nil {
+		cloned.Fields = map[string]any{}
+	}
+	cloned.Fields["stepTimers"] = timers
+	cloned.Fields["overdueSteps"] = overdue
+	return cloned
+}
@@ -0,0 +1,147 @@
+package orchestrationmock
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/opsorch/opsorch-core/schema"
+)
+
+// Plan issue kinds reported by ValidatePlan.
+const (
+	IssueDuplicateStep      = "duplicate_step"
+	IssueDanglingDependency = "dangling_dependency"
+	IssueCycle              = "cycle"
+)
+
+// PlanIssue describes a single structural problem in a plan's step graph.
+type PlanIssue struct {
+	Kind   string   `json:"kind"`
+	StepID string   `json:"stepId"`
+	Detail string   `json:"detail"`
+	Path   []string `json:"path,omitempty"`
+}
+
+// PlanValidationError is returned when a plan's steps do not form a valid DAG.
+type PlanValidationError struct {
+	PlanID string      `json:"planId"`
+	Issues []PlanIssue `json:"issues"`
+}
+
+func (e *PlanValidationError) Error() string {
+	details := make([]string, 0, len(e.Issues))
+	for _, issue := range e.Issues {
+		details = append(details, issue.Detail)
+	}
+	return fmt.Sprintf("invalid plan %s: %s", e.PlanID, strings.Join(details, "; "))
+}
+
+// ValidatePlan checks that step IDs are unique, every DependsOn entry refers to
+// a step in the same plan, and the dependency graph contains no cycles.
+func ValidatePlan(plan schema.OrchestrationPlan) error {
+	var issues []PlanIssue
+
+	steps := make(map[string]schema.OrchestrationStep, len(plan.Steps))
+	for _, step := range plan.Steps {
+		if _, exists := steps[step.ID]; exists {
+			issues = append(issues, PlanIssue{
+				Kind:   IssueDuplicateStep,
+				StepID: step.ID,
+				Detail: fmt.Sprintf("duplicate step id %q", step.ID),
+			})
+			continue
+		}
+		steps[step.ID] = step
+	}
+
+	for _, step := range plan.Steps {
+		for _, depID := range step.DependsOn {
+			if _, ok := steps[depID]; !ok {
+				issues = append(issues, PlanIssue{
+					Kind:   IssueDanglingDependency,
+					StepID: step.ID,
+					Detail: fmt.Sprintf("step %q depends on unknown step %q", step.ID, depID),
+				})
+			}
+		}
+	}
+
+	if cycle := findCycle(plan.Steps, steps); len(cycle) > 0 {
+		issues = append(issues, PlanIssue{
+			Kind:   IssueCycle,
+			StepID: cycle[0],
+			Detail: fmt.Sprintf("dependency cycle %s", strings.Join(cycle, " -> ")),
+			Path:   cycle,
+		})
+	}
+
+	if len(issues) > 0 {
+		return &PlanValidationError{PlanID: plan.ID, Issues: issues}
+	}
+	return nil
+}
+
+// findCycle walks the dependency graph depth-first in step order and returns
+// the first cycle found as a closed path (first and last element equal).
+func findCycle(order []schema.OrchestrationStep, steps map[string]schema.OrchestrationStep) []string {
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	state := make(map[string]int, len(steps))
+	var stack []string
+
+	var visit func(id string) []string
+	visit = func(id string) []string {
+		state[id] = visiting
+		stack = append(stack, id)
+		for _, depID := range steps[id].DependsOn {
+			if _, ok := steps[depID]; !ok {
+				continue
+			}
+			switch state[depID] {
+			case visiting:
+				for i, sid := range stack {
+					if sid == depID {
+						cycle := append([]string{}, stack[i:]...)
+						return append(cycle, depID)
+					}
+				}
+			case unvisited:
+				if cycle := visit(depID); cycle != nil {
+					return cycle
+				}
+			}
+		}
+		stack = stack[:len(stack)-1]
+		state[id] = done
+		return nil
+	}
+
+	for _, step := range order {
+		if state[step.ID] != unvisited {
+			continue
+		}
+		if cycle := visit(step.ID); cycle != nil {
+			return cycle
+		}
+	}
+	return nil
+}
+
+// validatePlans checks every stored plan, reporting the first invalid one by ID order.
+func (p *Provider) validatePlans() error {
+	ids := make([]string, 0, len(p.plans))
+	for id := range p.plans {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		if err := ValidatePlan(p.plans[id]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,226 @@
+package orchestrationmock
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/opsorch/opsorch-core/orcherr"
+	"github.com/opsorch/opsorch-core/schema"
+)
+
+// Webhook delivery states recorded in run.Fields["stepWebhooks"][stepID]["status"].
+const (
+	webhookDelivered = "delivered"
+	webhookFailed    = "delivery_failed"
+	webhookCompleted = "completed"
+	webhookTimedOut  = "timed_out"
+)
+
+// Step actors, both registered in the shared actor registry. webhookActor
+// completes or fails steps on behalf of the external runner; automationActor
+// runs automated steps in process and fails those whose runner never answers.
+const (
+	webhookActor    = "webhook-runner"
+	automationActor = "system-automation"
+)
+
+// stepWebhookPayload is POSTed when an automated step starts. The runner
+// reports back through the orchestration.runs.steps.callback method.
+type stepWebhookPayload struct {
+	Event            string         `json:"event"`
+	RunID            string         `json:"runId"`
+	PlanID           string         `json:"planId"`
+	StepID           string         `json:"stepId"`
+	StepTitle        string         `json:"stepTitle"`
+	IncidentID       string         `json:"incidentId,omitempty"`
+	StartedAt        time.Time      `json:"startedAt"`
+	CallbackDeadline time.Time      `json:"callbackDeadline"`
+	Callback         map[string]any `json:"callback"`
+	StepMetadata     map[string]any `json:"stepMetadata,omitempty"`
+}
+
+// StepCallback records the outcome an external runner reports for a step that
+// was handed off by webhook. Status is "succeeded" or "failed".
+func (p *Provider) StepCallback(ctx context.Context, runID, stepID, status, note string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	run, ok := p.runs[runID]
+	if !ok {
+		return orcherr.New("not_found", "run not found", nil)
+	}
+	state := findStepState(run.Steps, stepID)
+	if state == nil {
+		return orcherr.New("not_found", "step not found", nil)
+	}
+	if state.Status != "running" {
+		return orcherr.New("bad_request", fmt.Sprintf("step %s is %s, not awaiting a callback", stepID, state.Status), nil)
+	}
+
+	switch status {
+	case "succeeded":
+		p.setStepWebhookLocked(runID, stepID, map[string]any{"status": webhookCompleted, "result": status})
+		return p.completeStepLocked(ctx, runID, stepID, webhookActor, note)
+	case "failed":
+		p.setStepWebhookLocked(runID, stepID, map[string]any{"status": webhookCompleted, "result": status})
+		p.failStepLocked(runID, stepID, webhookActor, note)
+		return nil
+	default:
+		return orcherr.New("bad_request", fmt.Sprintf("unsupported callback status %q", status), nil)
+	}
+}
+
+// dispatchStepWebhookLocked hands an automated step to the configured runner
+// once. Delivery happens in the background; a failed delivery or a missing
+// callback after StepCallbackTimeout fails the step. Callers must hold p.mu.
+func (p *Provider) dispatchStepWebhookLocked(run *schema.OrchestrationRun, step schema.OrchestrationStep) {
+	if hooks, ok := p.runs[run.ID].Fields["stepWebhooks"].(map[string]any); ok {
+		if _, sent := hooks[step.ID]; sent {
+			return
+		}
+	}
+
+	p.dispatches++
+	dispatch := p.dispatches
+	now := time.Now().UTC()
+	deadline := now.Add(p.cfg.StepCallbackTimeout)
+	payload := stepWebhookPayload{
+		Event:            "orchestration.step.started",
+		RunID:            run.ID,
+		PlanID:           run.PlanID,
+		StepID:           step.ID,
+		StepTitle:        step.Title,
+		IncidentID:       runIncidentID(*run),
+		StartedAt:        now,
+		CallbackDeadline: deadline,
+		Callback: map[string]any{
+			"method": "orchestration.runs.steps.callback",
+			"params": map[string]any{"runId": run.ID, "stepId": step.ID},
+		},
+		StepMetadata: step.Metadata,
+	}
+	p.setStepWebhookLocked(run.ID, step.ID, map[string]any{
+		"url":              p.cfg.StepWebhookURL,
+		"status":           "pending",
+		"callbackDeadline": deadline.Format(time.RFC3339),
+		"dispatch":         dispatch,
+	})
+
+	go p.deliverStepWebhook(payload, dispatch)
+	time.AfterFunc(p.cfg.StepCallbackTimeout, func() {
+		p.timeoutStep(run.ID, step.ID, dispatch)
+	})
+}
+
+// currentDispatchLocked reports whether dispatch is the step's latest
+// webhook handoff. Callers must hold p.mu.
+func (p *Provider) currentDispatchLocked(runID, stepID string, dispatch int) bool {
+	hooks, _ := p.runs[runID].Fields["stepWebhooks"].(map[string]any)
+	record, _ := hooks[stepID].(map[string]any)
+	current, _ := record["dispatch"].(int)
+	return current == dispatch
+}
+
+func (p *Provider) deliverStepWebhook(payload stepWebhookPayload, dispatch int) {
+	body, err := json.Marshal(payload)
+	if err == nil {
+		var resp *http.Response
+		resp, err = p.httpClient.Post(p.cfg.StepWebhookURL, "application/json", bytes.NewReader(body))
+		if err == nil {
+			resp.Body.Close()
+			if resp.StatusCode >= 300 {
+				err = fmt.Errorf("webhook returned %s", resp.Status)
+			}
+		}
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if !p.currentDispatchLocked(payload.RunID, payload.StepID, dispatch) {
+		return
+	}
+	if err != nil {
+		p.setStepWebhookLocked(payload.RunID, payload.StepID, map[string]any{"status": webhookFailed, "error": err.Error()})
+		p.failStepLocked(payload.RunID, payload.StepID, automationActor, fmt.Sprintf("Webhook delivery failed: %v", err))
+		return
+	}
+	p.setStepWebhookLocked(payload.RunID, payload.StepID, map[string]any{
+		"status":      webhookDelivered,
+		"deliveredAt": time.Now().UTC().Format(time.RFC3339),
+	})
+}
+
+// timeoutStep fails a step whose runner never called back after the given
+// handoff. Timers from a handoff the step has since been resumed past do
+// nothing.
+func (p *Provider) timeoutStep(runID, stepID string, dispatch int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	run, ok := p.runs[runID]
+	if !ok || !p.currentDispatchLocked(runID, stepID, dispatch) {
+		return
+	}
+	if state := findStepState(run.Steps, stepID); state == nil || state.Status != "running" {
+		return
+	}
+	p.setStepWebhookLocked(runID, stepID, map[string]any{"status": webhookTimedOut})
+	p.failStepLocked(runID, stepID, automationActor, fmt.Sprintf("No callback from automation runner within %s", p.cfg.StepCallbackTimeout))
+}
+
+// failStepLocked marks a running step and its run as failed. Callers must hold p.mu.
+func (p *Provider) failStepLocked(runID, stepID, actor, note string) {
+	run, ok := p.runs[runID]
+	if !ok {
+		return
+	}
+	state := findStepState(run.Steps, stepID)
+	if state == nil || state.Status != "running" {
+		return
+	}
+	now := time.Now().UTC()
+	state.Status = "failed"
+	state.Actor = actor
+	state.Note = note
+	state.FinishedAt = &now
+	state.UpdatedAt = &now
+	run.Status = "failed"
+	run.UpdatedAt = now
+	p.runs[runID] = run
+}
+
+// setStepWebhookLocked merges update into the step's webhook record. Records are
+// replaced rather than mutated so clones handed to callers never change.
+// Callers must hold p.mu.
+func (p *Provider) setStepWebhookLocked(runID, stepID string, update map[string]any) {
+	run, ok := p.runs[runID]
+	if !ok {
+		return
+	}
+	hooks, _ := run.Fields["stepWebhooks"].(map[string]any)
+	nextHooks := cloneMap(hooks)
+	if nextHooks == nil {
+		nextHooks = map[string]any{}
+	}
+	prev, _ := nextHooks[stepID].(map[string]any)
+	record := cloneMap(prev)
+	if record == nil {
+		record = map[string]any{}
+	}
+	for k, v := range update {
+		record[k] = v
+	}
+	nextHooks[stepID] = record
+
+	fields := cloneMap(run.Fields)
+	if fields == nil {
+		fields = map[string]any{}
+	}
+	fields["stepWebhooks"] = nextHooks
+	run.Fields = fields
+	p.runs[runID] = run
+}
@@ -0,0 +1,409 @@
+package runbookdocmock
+
+// Document kinds.
+const (
+	KindPlaybook  = "playbook"
+	KindRunbook   = "runbook"
+	KindChecklist = "checklist"
+	KindProcedure = "procedure"
+	KindService   = "service"
+	KindAlert     = "alert"
+)
+
+// topic is the compact seed form of a document. Response runbooks fill in
+// symptoms, diagnosis, and mitigation; checklists and procedures use steps.
+type topic struct {
+	slug      string
+	path      string
+	aliases   []string
+	title     string
+	kind      string
+	service   string
+	tags      []string
+	summary   string
+	symptoms  []string
+	diagnosis []string
+	mitigate  []string
+	steps     []string
+	related   []string
+}
+
+// topics covers every runbook.demo link used by the other mock providers.
+var topics = []topic{
+	// Playbooks backing the orchestration plans.
+	{
+		slug: "db-connection-pool", path: "playbooks/db-connection-pool", aliases: []string{"db-connections"},
+		title: "Database Connection Pool Exhaustion", kind: KindPlaybook, service: "svc-database",
+		tags:      []string{"database", "connections", "pool", "postgres"},
+		summary:   "Applications cannot acquire database connections; requests queue and time out while the pool sits at its maximum.",
+		symptoms:  []string{"Connection pool utilization pinned at 100%", "Spikes in 'timeout acquiring connection' errors", "Checkout and catalog latency rising together"},
+		diagnosis: []string{"Check pool metrics (active, idle, waiting) per service", "List long-running transactions with pg_stat_activity", "Look for connection leaks after the most recent deployment"},
+		mitigate:  []string{"Restart the connection pool on the worst offender", "Raise max connections temporarily if the primary has headroom", "Roll back the deployment that introduced the leak"},
+		related:   []string{"db-failover", "high-latency"},
+	},
+	{
+		slug: "high-latency", path: "playbooks/high-latency",
+		title: "High Latency Investigation", kind: KindPlaybook,
+		tags:      []string{"latency", "performance", "p99"},
+		summary:   "Generic investigation for elevated p95/p99 latency on a request-serving service.",
+		symptoms:  []string{"p99 latency above SLO for more than 5 minutes", "Upstream callers reporting timeouts"},
+		diagnosis: []string{"Check application metrics for saturation (CPU, GC, thread pools)", "Trace slow requests to find the dominant span", "Check database latency and cache hit rate", "Check upstream dependency health"},
+		mitigate:  []string{"Scale out the saturated tier", "Shed or rate-limit expensive endpoints", "Roll back a suspect deployment", "Escalate to the dependency owner if the bottleneck is upstream"},
+		related:   []string{"checkout-latency", "payment-latency", "db-connection-pool"},
+	},
+	{
+		slug: "service-degradation", path: "playbooks/service-degradation",
+		title: "Service Degradation Response", kind: KindPlaybook,
+		tags:      []string{"degradation", "incident-response", "communications"},
+		summary:   "Coordinated response for partial outages: triage, assess customer impact, communicate, and mitigate.",
+		symptoms:  []string{"Error rate or latency SLO burning faster than 2x", "Customer reports or support ticket spike"},
+		diagnosis: []string{"Triage the incident and assign a commander", "Assess customer impact by region and tier", "Correlate with recent deployments and config changes"},
+		mitigate:  []string{"Communicate status on the status page every 30 minutes", "Execute the service-specific mitigation runbook", "Schedule a postmortem within 5 business days"},
+		related:   []string{"high-latency", "rollback"},
+	},
+	{
+		slug: "security-incident", path: "playbooks/security-incident",
+		title: "Suspicious Activity Protocol", kind: KindPlaybook, service: "svc-identity",
+		tags:      []string{"security", "auth", "mfa", "geoip"},
+		summary:   "Response for credential stuffing, account takeover, or anomalous login patterns.",
+		symptoms:  []string{"Failed login ratio above baseline", "Logins from unusual GeoIP regions", "MFA challenge failures spiking"},
+		diagnosis: []string{"Analyze GeoIP and ASN patterns for the affected accounts", "Confirm whether successful logins followed failures"},
+		mitigate:  []string{"Lock compromised accounts", "Force an MFA challenge for the affected cohort", "Notify users and security leadership"},
+		related:   []string{"api-abuse", "rate-limits"},
+	},
+	{
+		slug: "analytics-correlation", path: "playbooks/analytics-correlation",
+		title: "Analytics Correlation Runbook", kind: KindPlaybook, service: "svc-analytics",
+		tags:      []string{"analytics", "etl", "backfill", "data-quality"},
+		summary:   "Handles correlation lag between event ingestion and warehouse exports.",
+		symptoms:  []string{"Dashboards missing the last hour of data", "Correlation lag metric above 15 minutes"},
+		diagnosis: []string{"Observe correlation lag per pipeline stage", "Check for stalled consumers on the ingestion topic"},
+		mitigate:  []string{"Backfill missing partitions", "Verify row counts against the source", "Notify analytics consumers once data is complete"},
+		related:   []string{"job-lag", "kafka-lag"},
+	},
+
+	// Operational runbooks.
+	{
+		slug: "db-failover", path: "runbooks/db-failover",
+		title: "Database Failover", kind: KindRunbook, service: "svc-database",
+		tags:    []string{"database", "failover", "postgres", "dns"},
+		summary: "Promote the standby when the primary is unhealthy or must be taken down.",
+		steps: []string{
+			"Run pre-failover checks: replication lag under 5s, standby healthy",
+			"Notify stakeholders in #database and the incident channel",
+			"Stop background jobs that write to the primary",
+			"Prepare the standby for promotion",
+			"Execute the failover",
+			"Validate the new primary accepts writes",
+			"Update DNS records to the new primary",
+		},
+		related: []string{"db-connection-pool", "dns-issues"},
+	},
+	{
+		slug: "cert-rotation", path: "runbooks/cert-rotation", aliases: []string{"cert-renewal"},
+		title: "Certificate Rotation", kind: KindRunbook, service: "svc-ingress",
+		tags:    []string{"tls", "certificates", "expiry"},
+		summary: "Renew and roll out TLS certificates before they expire.",
+		steps: []string{
+			"List certificates expiring within 14 days",
+			"Request renewal from the issuing CA",
+			"Upload the new certificate to the secret store",
+			"Roll the ingress and load balancer listeners",
+			"Verify the served certificate chain and expiry",
+		},
+		related: []string{"lb-health-check"},
+	},
+	{
+		slug: "cache-flush", path: "runbooks/cache-flush", aliases: []string{"cache-degradation"},
+		title: "Cache Flush and Warmup", kind: KindRunbook, service: "svc-cache",
+		tags:    []string{"cache", "redis", "hit-rate"},
+		summary: "Recover from a poisoned or degraded cache without stampeding the database.",
+		steps: []string{
+			"Confirm hit rate drop and eviction spike on the cache dashboard",
+			"Enable request coalescing on callers",
+			"Flush the affected keyspace",
+			"Warm hot keys from the precomputed list",
+			"Watch database load for 15 minutes",
+		},
+		related: []string{"high-latency", "db-connection-pool"},
+	},
+	{
+		slug: "pod-restart", path: "runbooks/pod-restart",
+		title: "Pod Restart Loop", kind: KindRunbook,
+		tags:    []string{"kubernetes", "crashloop", "oom"},
+		summary: "Diagnose and stop CrashLoopBackOff or repeated OOM kills.",
+		steps: []string{
+			"Check restart counts and last termination reason",
+			"Read the previous container logs",
+			"Compare memory limits with actual usage",
+			"Roll back the image or raise limits",
+			"Confirm restarts have stopped for 10 minutes",
+		},
+		related: []string{"disk-cleanup"},
+	},
+	{
+		slug: "rate-limits", path: "runbooks/rate-limits",
+		title: "Rate Limit Tuning", kind: KindRunbook, service: "svc-api-gateway",
+		tags:    []string{"rate-limit", "gateway", "429"},
+		summary: "Adjust gateway rate limits when legitimate traffic is throttled or abusive traffic gets through.",
+		steps: []string{
+			"Identify the clients receiving 429 responses",
+			"Separate legitimate bursts from abuse",
+			"Adjust per-client limits in the gateway config",
+			"Deploy the config and watch the 429 rate",
+		},
+		related: []string{"api-abuse"},
+	},
+	{
+		slug: "catalog-sync", path: "runbooks/catalog-sync",
+		title: "Catalog Inventory Sync", kind: KindRunbook, service: "svc-catalog",
+		tags:    []string{"catalog", "inventory", "erp", "sync"},
+		summary: "Repair drift between ERP inventory and the catalog index.",
+		steps: []string{
+			"Compare ERP and catalog SKU counts",
+			"Pause the incremental sync",
+			"Run a full resync for the drifted partitions",
+			"Resume the incremental sync",
+			"Spot-check availability on product pages",
+		},
+		related: []string{"catalog-index"},
+	},
+	{
+		slug: "payment-latency", path: "runbooks/payment-latency",
+		title: "Payment Latency", kind: KindRunbook, service: "svc-payments",
+		tags:    []string{"payments", "latency", "psp"},
+		summary: "Reduce authorization latency when the payment service or its PSP slows down.",
+		steps: []string{
+			"Split latency between internal processing and PSP calls",
+			"Check the PSP status page",
+			"Shift traffic to the secondary PSP if the primary is degraded",
+			"Verify authorization success rate after the shift",
+		},
+		related: []string{"payment-outage", "checkout-latency"},
+	},
+
+	// Release checklists.
+	{
+		slug: "prod-release", path: "checklists/prod-release",
+		title: "Production Release Checklist", kind: KindChecklist,
+		tags:    []string{"release", "deployment", "checklist"},
+		steps:   []string{"Confirm change ticket is approved", "Check for active incidents and freeze windows", "Deploy to staging and run smoke tests", "Deploy to production", "Watch error rate and latency for 30 minutes"},
+		related: []string{"canary-deploy", "rollback"},
+	},
+	{
+		slug: "canary-deploy", path: "checklists/canary-deploy",
+		title: "Canary Deployment Checklist", kind: KindChecklist,
+		tags:    []string{"canary", "deployment", "checklist"},
+		steps:   []string{"Deploy to 5% of pods", "Compare canary and baseline error rate and latency", "Promote to 25%, then 100%", "Abort and roll back on any regression"},
+		related: []string{"prod-release", "rollback"},
+	},
+	{
+		slug: "rollback", path: "checklists/rollback",
+		title: "Rollback Checklist", kind: KindChecklist,
+		tags:    []string{"rollback", "deployment", "checklist"},
+		steps:   []string{"Identify the last known good version", "Announce the rollback in the incident channel", "Roll back the deployment", "Verify recovery metrics", "Open a follow-up ticket for the failed change"},
+		related: []string{"prod-release"},
+	},
+
+	// Larger coordinated procedures.
+	{
+		slug: "multi-service-feature", path: "rollouts/multi-service-feature",
+		title: "Multi-Service Feature Rollout", kind: KindProcedure,
+		tags:    []string{"rollout", "feature-flags"},
+		steps:   []string{"Deploy backend services with the flag off", "Deploy the frontend with the flag off", "Enable the flag for internal users", "Ramp the flag to all users", "Remove the flag after a week"},
+		related: []string{"prod-release"},
+	},
+	{
+		slug: "global-config-update", path: "ops/global-config-update",
+		title: "Global Configuration Update", kind: KindProcedure, service: "svc-feature-flags",
+		tags:    []string{"config", "rollout"},
+		steps:   []string{"Validate the config change in staging", "Apply to one region", "Monitor for 15 minutes", "Apply to remaining regions"},
+		related: []string{"rollback"},
+	},
+	{
+		slug: "monolith-upgrade", path: "maintenance/monolith-upgrade",
+		title: "Monolith Upgrade Maintenance", kind: KindProcedure,
+		tags:    []string{"maintenance", "upgrade", "downtime"},
+		steps:   []string{"Announce the maintenance window", "Pause background jobs", "Enable maintenance mode", "Run database migrations", "Deploy the new version", "Disable maintenance mode", "Resume background jobs"},
+		related: []string{"rollback"},
+	},
+	{
+		slug: "full-stack", path: "releases/full-stack",
+		title: "Full-Stack Release", kind: KindProcedure,
+		tags:    []string{"release", "deployment"},
+		steps:   []string{"Release data layer changes", "Release backend services in dependency order", "Release the web frontend", "Run end-to-end checks"},
+		related: []string{"prod-release", "canary-deploy"},
+	},
+	{
+		slug: "dc-move", path: "migrations/dc-move",
+		title: "Data Center Migration", kind: KindProcedure,
+		tags:    []string{"migration", "dns", "infrastructure"},
+		steps:   []string{"Provision infrastructure in the new data center", "Replicate data stores", "Deploy services to the new environment", "Verify infrastructure with integration tests", "Switch traffic by updating global DNS"},
+		related: []string{"region-evacuation", "dns-issues"},
+	},
+	{
+		slug: "region-evacuation", path: "dr/region-evacuation",
+		title: "Region Evacuation (Disaster Recovery)", kind: KindProcedure,
+		tags:    []string{"dr", "region", "failover", "dns"},
+		steps:   []string{"Declare a disaster and page leadership", "Snapshot volumes in the failing region", "Promote DR databases", "Update the CDN origin", "Deploy applications in the DR region", "Verify system integrity with smoke tests", "Switch global DNS to the DR region"},
+		related: []string{"db-failover", "dc-move"},
+	},
+
+	// Alert runbooks.
+	{
+		slug: "checkout-latency", title: "Checkout Latency", kind: KindRunbook, service: "svc-checkout",
+		tags:      []string{"checkout", "latency", "p95"},
+		summary:   "Checkout p95 latency above 800ms.",
+		symptoms:  []string{"Checkout p95 above 800ms", "Cart abandonment rising"},
+		diagnosis: []string{"Check payments and order service latency", "Check database connection pool wait time", "Look for a recent checkout deployment"},
+		mitigate:  []string{"Roll back the latest checkout release", "Scale checkout pods", "Disable non-critical checkout widgets via feature flags"},
+		related:   []string{"high-latency", "payment-latency", "db-connection-pool"},
+	},
+	{
+		slug: "payment-outage", title: "Payment Processing Outage", kind: KindRunbook, service: "svc-payments",
+		tags:      []string{"payments", "outage", "psp"},
+		summary:   "Payment authorizations failing across all methods.",
+		symptoms:  []string{"Authorization success rate near zero", "Checkout errors on the payment step"},
+		diagnosis: []string{"Check PSP connectivity and credentials", "Check the payments error log for a common code"},
+		mitigate:  []string{"Fail over to the secondary PSP", "Enable the degraded checkout banner", "Page the payments lead"},
+		related:   []string{"payment-latency", "payments-webhooks"},
+	},
+	{
+		slug: "payments-webhooks", title: "Payment Webhook Backlog", kind: KindRunbook, service: "svc-payments",
+		tags:      []string{"payments", "webhooks", "queue"},
+		summary:   "PSP webhooks are failing or delayed, leaving orders in pending payment.",
+		symptoms:  []string{"Webhook delivery failures", "Orders stuck in pending_payment"},
+		diagnosis: []string{"Check webhook endpoint error rate", "Verify signing secret has not rotated"},
+		mitigate:  []string{"Replay failed webhooks from the PSP dashboard", "Reconcile pending orders"},
+		related:   []string{"payment-outage", "queue-depth"},
+	},
+	{
+		slug: "search-5xx", title: "Search 5xx Spike", kind: KindRunbook, service: "svc-search",
+		tags:      []string{"search", "5xx", "errors"},
+		summary:   "Search cluster returning elevated 5xx responses.",
+		symptoms:  []string{"5xx rate above 2%", "Search result pages empty"},
+		diagnosis: []string{"Check cluster health and shard allocation", "Check pending pods and autoscaler status", "Check query rejections in thread pools"},
+		mitigate:  []string{"Scale the search deployment", "Raise namespace quota if the HPA is blocked", "Serve cached results for popular queries"},
+		related:   []string{"high-latency", "pod-restart"},
+	},
+	{
+		slug: "dns-issues", title: "DNS Resolution Failures", kind: KindRunbook, service: "svc-dns",
+		tags:      []string{"dns", "resolution", "network"},
+		summary:   "Services failing to resolve internal or external names.",
+		symptoms:  []string{"NXDOMAIN or SERVFAIL errors in logs", "Intermittent connection failures across services"},
+		diagnosis: []string{"Query the resolver directly from an affected pod", "Check recent DNS record changes", "Check resolver pod health"},
+		mitigate:  []string{"Revert the offending record change", "Restart resolver pods", "Increase resolver replicas"},
+		related:   []string{"db-failover", "dc-move"},
+	},
+	{
+		slug: "disk-cleanup", title: "Disk Space Cleanup", kind: KindRunbook,
+		tags:      []string{"disk", "storage", "logs"},
+		summary:   "Node or volume disk usage above 85%.",
+		symptoms:  []string{"Disk usage above 85%", "Pods evicted for ephemeral storage"},
+		diagnosis: []string{"Find the largest directories on the node", "Check log rotation settings"},
+		mitigate:  []string{"Delete rotated logs and unused images", "Expand the volume", "Fix the log rotation policy"},
+		related:   []string{"pod-restart", "kafka-disk"},
+	},
+	{
+		slug: "lb-health-check", title: "Load Balancer Health Check Failures", kind: KindRunbook, service: "svc-loadbalancer",
+		tags:      []string{"load-balancer", "health-check", "network"},
+		summary:   "Targets failing load balancer health checks.",
+		symptoms:  []string{"Unhealthy target count rising", "502s at the edge"},
+		diagnosis: []string{"Hit the health endpoint directly on a target", "Check security group and port changes"},
+		mitigate:  []string{"Revert network changes", "Replace unhealthy targets", "Relax the health check threshold temporarily"},
+		related:   []string{"cert-rotation"},
+	},
+	{
+		slug: "circuit-breaker", title: "Circuit Breaker Open", kind: KindRunbook,
+		tags:      []string{"circuit-breaker", "resilience", "dependencies"},
+		summary:   "A caller has opened its circuit breaker to a failing dependency.",
+		symptoms:  []string{"Circuit breaker state open", "Fallback responses served"},
+		diagnosis: []string{"Identify the downstream dependency", "Check the dependency's error rate and latency"},
+		mitigate:  []string{"Fix or scale the dependency", "Let the breaker half-open and confirm recovery"},
+		related:   []string{"high-latency", "service-degradation"},
+	},
+	{
+		slug: "job-lag", title: "Batch Job Lag", kind: KindRunbook, service: "svc-warehouse",
+		tags:      []string{"batch", "jobs", "lag"},
+		summary:   "Scheduled jobs running behind their SLA.",
+		symptoms:  []string{"Job lag above 30 minutes", "Downstream reports stale"},
+		diagnosis: []string{"Check the scheduler queue", "Look for a long-running job holding a lock"},
+		mitigate:  []string{"Kill the stuck job", "Add workers", "Rerun missed schedules"},
+		related:   []string{"analytics-correlation"},
+	},
+	{
+		slug: "api-abuse", title: "API Abuse", kind: KindRunbook, service: "svc-api-gateway",
+		tags:      []string{"security", "abuse", "rate-limit"},
+		summary:   "A client is scraping or hammering the public API.",
+		symptoms:  []string{"Single client over 10x its normal request rate", "Elevated 429s"},
+		diagnosis: []string{"Identify the client by API key and IP range", "Check whether the traffic is authenticated"},
+		mitigate:  []string{"Block the client at the gateway", "Tighten rate limits", "Rotate the client's API key"},
+		related:   []string{"rate-limits", "security-incident"},
+	},
+	{
+		slug: "kafka-lag", title: "Kafka Consumer Lag", kind: KindRunbook,
+		tags:      []string{"kafka", "consumer", "lag"},
+		summary:   "Consumer groups falling behind producers.",
+		symptoms:  []string{"Consumer lag growing for 10 minutes", "Delayed downstream processing"},
+		diagnosis: []string{"Check consumer group membership and rebalances", "Check per-partition lag for hot partitions"},
+		mitigate:  []string{"Scale consumers up to the partition count", "Restart stuck consumers"},
+		related:   []string{"queue-depth", "producer-throttle"},
+	},
+	{
+		slug: "queue-depth", title: "Queue Depth", kind: KindRunbook, service: "svc-workers",
+		tags:      []string{"queue", "workers", "backlog"},
+		summary:   "Work queue depth above threshold.",
+		symptoms:  []string{"Queue depth rising steadily", "Worker throughput flat"},
+		diagnosis: []string{"Check worker error rates", "Look for poison messages"},
+		mitigate:  []string{"Scale workers", "Move poison messages to the dead-letter queue"},
+		related:   []string{"kafka-lag"},
+	},
+	{
+		slug: "kafka-disk", title: "Kafka Broker Disk", kind: KindRunbook,
+		tags:      []string{"kafka", "disk", "retention"},
+		summary:   "Kafka broker disks filling up.",
+		symptoms:  []string{"Broker disk usage above 80%"},
+		diagnosis: []string{"Find the largest topics", "Check retention settings"},
+		mitigate:  []string{"Lower retention on large topics", "Add broker storage"},
+		related:   []string{"disk-cleanup", "kafka-lag"},
+	},
+	{
+		slug: "producer-throttle", title: "Kafka Producer Throttling", kind: KindRunbook,
+		tags:      []string{"kafka", "producer", "quota"},
+		summary:   "Producers throttled by broker quotas.",
+		symptoms:  []string{"Producer throttle time above 0", "Publish latency rising"},
+		diagnosis: []string{"Check quota usage by client ID"},
+		mitigate:  []string{"Raise the client quota", "Batch and compress messages"},
+		related:   []string{"kafka-lag"},
+	},
+	{
+		slug: "auth-latency", title: "Authentication Latency", kind: KindRunbook, service: "svc-identity",
+		tags:      []string{"auth", "latency", "identity"},
+		summary:   "Login and token validation slower than normal.",
+		symptoms:  []string{"Login p95 above 1s", "Token validation timeouts in callers"},
+		diagnosis: []string{"Check session store latency", "Check MFA provider latency"},
+		mitigate:  []string{"Scale identity pods", "Extend token cache TTL"},
+		related:   []string{"high-latency", "security-incident"},
+	},
+	{
+		slug: "catalog-index", title: "Catalog Index Rebuild", kind: KindRunbook, service: "svc-catalog",
+		tags:      []string{"catalog", "index", "search"},
+		summary:   "Catalog index stale or failing to build.",
+		symptoms:  []string{"Index age above 2 hours", "Products missing from search"},
+		diagnosis: []string{"Check indexer job logs", "Compare document counts with the catalog database"},
+		mitigate:  []string{"Rebuild the index from a snapshot", "Swap the alias once the rebuild completes"},
+		related:   []string{"catalog-sync", "search-5xx"},
+	},
+	{
+		slug: "platform", title: "Platform Operations", kind: KindService, service: "svc-api",
+		tags:    []string{"platform", "infrastructure"},
+		summary: "Entry point for shared platform components: gateway, ingress, DNS, cache, and load balancers.",
+		related: []string{"dns-issues", "lb-health-check", "rate-limits", "cache-flush"},
+	},
+}
+
+// serviceSlugs maps services whose runbook page slug is not the service ID minus "svc-".
+var serviceSlugs = map[string]string{
+	"svc-recommendation": "recommendations",
+	"svc-order":          "orders",
+}
@@ -0,0 +1,468 @@
+package runbookdocmock
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/opsorch/opsorch-core/orcherr"
+	"github.com/opsorch/opsorch-mock-adapters/internal/mockutil"
+)
+
+// ProviderName identifies the mock knowledge-base provider.
+const ProviderName = "mock"
+
+// baseURL is the host every other mock provider uses for runbook links.
+const baseURL = "https://runbook.demo"
+
+// defaultSearchLimit caps search results when the query does not set a limit.
+const defaultSearchLimit = 10
+
+// Config controls mock knowledge-base data.
+type Config struct {
+	Source string
+}
+
+// Provider serves the runbook.demo links referenced by alerts, incidents,
+// services, tickets, and orchestration plans as structured documents.
+type Provider struct {
+	cfg     Config
+	docs    []Document
+	byID    map[string]int
+	byPath  map[string]int
+	slugFor map[string]string
+}
+
+// Section is one headed part of a document. Steps are ordered actions.
+type Section struct {
+	ID      string   `json:"id"`
+	Heading string   `json:"heading"`
+	Body    string   `json:"body,omitempty"`
+	Steps   []string `json:"steps,omitempty"`
+}
+
+// Document is a knowledge-base article such as a runbook, playbook, or checklist.
+type Document struct {
+	ID        string         `json:"id"`
+	Title     string         `json:"title"`
+	URL       string         `json:"url"`
+	Aliases   []string       `json:"aliases,omitempty"`
+	Kind      string         `json:"kind"`
+	Service   string         `json:"service,omitempty"`
+	Team      string         `json:"team,omitempty"`
+	Tags      []string       `json:"tags,omitempty"`
+	Summary   string         `json:"summary,omitempty"`
+	Sections  []Section      `json:"sections"`
+	Related   []string       `json:"related,omitempty"`
+	UpdatedAt time.Time      `json:"updatedAt"`
+	Metadata  map[string]any `json:"metadata,omitempty"`
+}
+
+// SearchQuery filters and ranks documents. Query terms are matched against
+// titles, tags, summaries, and section text; empty fields match everything.
+type SearchQuery struct {
+	Query   string   `json:"query,omitempty"`
+	Service string   `json:"service,omitempty"`
+	Team    string   `json:"team,omitempty"`
+	Kind    string   `json:"kind,omitempty"`
+	Tags    []string `json:"tags,omitempty"`
+	Limit   int      `json:"limit,omitempty"`
+}
+
+// SearchResult is a ranked document summary with the best-matching section.
+type SearchResult struct {
+	ID      string   `json:"id"`
+	Title   string   `json:"title"`
+	URL     string   `json:"url"`
+	Kind    string   `json:"kind"`
+	Service string   `json:"service,omitempty"`
+	Team    string   `json:"team,omitempty"`
+	Tags    []string `json:"tags,omitempty"`
+	Score   float64  `json:"score"`
+	Section string   `json:"section,omitempty"`
+	Snippet string   `json:"snippet,omitempty"`
+}
+
+// New constructs the mock knowledge-base provider.
+func New(cfg map[string]any) (*Provider, error) {
+	p := &Provider{
+		cfg:     parseConfig(cfg),
+		byID:    map[string]int{},
+		byPath:  map[string]int{},
+		slugFor: map[string]string{},
+	}
+	p.seed(time.Now().UTC())
+	return p, nil
+}
+
+// Get resolves a document by ID or by any runbook URL that points at it,
+// including alias paths such as /db-failover for /runbooks/db-failover.
+// Alert runbook links of the form /<service>-<alert-type> that have no
+// curated document resolve to a generated per-service triage page.
+func (p *Provider) Get(ctx context.Context, ref string) (Document, error) {
+	_ = ctx
+
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return Document{}, orcherr.New("bad_request", "document id or url is required", nil)
+	}
+	if idx, ok := p.byID[ref]; ok {
+		return cloneDocument(p.docs[idx]), nil
+	}
+	path, ok := p.refPath(ref)
+	if !ok {
+		return Document{}, orcherr.New("not_found", "document not found", nil)
+	}
+	if idx, ok := p.byPath[path]; ok {
+		return cloneDocument(p.docs[idx]), nil
+	}
+	if doc, ok := p.alertDocument(path); ok {
+		return doc, nil
+	}
+	return Document{}, orcherr.New("not_found", "document not found", nil)
+}
+
+// Search ranks documents against the query terms. Title and tag matches weigh
+// more than body matches; ties are broken by ID so results are stable.
+func (p *Provider) Search(ctx context.Context, query SearchQuery) ([]SearchResult, error) {
+	_ = ctx
+
+	terms := strings.Fields(strings.ToLower(query.Query))
+	limit := query.Limit
+	if limit <= 0 {
+		limit = defaultSearchLimit
+	}
+
+	out := make([]SearchResult, 0)
+	for _, doc := range p.docs {
+		if query.Service != "" && doc.Service != query.Service {
+			continue
+		}
+		if query.Team != "" && doc.Team != query.Team {
+			continue
+		}
+		if query.Kind != "" && doc.Kind != query.Kind {
+			continue
+		}
+		if !hasTags(doc.Tags, query.Tags) {
+			continue
+		}
+		score, section, snippet := scoreDocument(doc, terms)
+		if len(terms) > 0 && score == 0 {
+			continue
+		}
+		out = append(out, SearchResult{
+			ID:      doc.ID,
+			Title:   doc.Title,
+			URL:     doc.URL,
+			Kind:    doc.Kind,
+			Service: doc.Service,
+			Team:    doc.Team,
+			Tags:    mockutil.CloneStringSlice(doc.Tags),
+			Score:   score,
+			Section: section,
+			Snippet: snippet,
+		})
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].Score != out[j].Score {
+			return out[i].Score > out[j].Score
+		}
+		return out[i].ID < out[j].ID
+	})
+	if len(out) > limit {
+		out = out[:limit]
+	}
+	return out, nil
+}
+
+func (p *Provider) seed(now time.Time) {
+	for _, t := range topics {
+		p.add(topicDocument(t, now))
+	}
+	for _, service := range mockutil.Services() {
+		slug := serviceSlug(service)
+		if _, exists := p.byPath[slug]; exists {
+			continue
+		}
+		p.slugFor[service] = slug
+		p.add(p.serviceDocument(service, slug, now))
+	}
+	for i := range p.docs {
+		p.docs[i].Metadata["source"] = p.cfg.Source
+	}
+}
+
+func (p *Provider) add(doc Document) {
+	idx := len(p.docs)
+	p.docs = append(p.docs, doc)
+	p.byID[doc.ID] = idx
+	p.byPath[strings.TrimPrefix(doc.URL, baseURL+"/")] = idx
+	for _, alias := range doc.Aliases {
+		p.byPath[strings.TrimPrefix(alias, baseURL+"/")] = idx
+	}
+}
+
+// refPath reduces a URL on the runbook host, or a bare path, to its lookup key.
+func (p *Provider) refPath(ref string) (string, bool) {
+	if strings.Contains(ref, "://") {
+		u, err := url.Parse(ref)
+		if err != nil {
+			return "", false
+		}
+		base, err := url.Parse(baseURL)
+		if err != nil || !strings.EqualFold(u.Host, base.Host) {
+			return "", false
+		}
+		ref = u.Path
+	}
+	path := strings.Trim(ref, "/")
+	return path, path != ""
+}
+
+// alertDocument builds a triage page for links produced by alert enrichment,
+// which appends the normalized alert type to the owning service ID.
+func (p *Provider) alertDocument(path string) (Document, bool) {
+	if strings.Contains(path, "/") {
+		return Document{}, false
+	}
+	var service string
+	for _, candidate := range mockutil.Services() {
+		if strings.HasPrefix(path, candidate+"-") && len(candidate) > len(service) {
+			service = candidate
+		}
+	}
+	if service == "" {
+		return Document{}, false
+	}
+	alertType := strings.TrimPrefix(path, service+"-")
+	label := strings.ReplaceAll(alertType, "-", " ")
+	team := mockutil.GetTeamForService(service)
+
+	related := []string{}
+	if slug, ok := p.slugFor[service]; ok {
+		related = append(related, slug)
+	}
+	if idx, ok := p.byPath[alertType]; ok {
+		related = append(related, p.docs[idx].ID)
+	}
+
+	return Document{
+		ID:      path,
+		Title:   fmt.Sprintf("%s: %s", service, label),
+		URL:     baseURL + "/" + path,
+		Kind:    KindAlert,
+		Service: service,
+		Team:    team,
+		Tags:    []string{alertType, strings.TrimPrefix(service, "svc-")},
+		Summary: fmt.Sprintf("Triage steps for %s alerts on %s.", label, service),
+		Sections: []Section{
+			{ID: "triage", Heading: "Triage", Steps: []string{
+				"Acknowledge the alert and check for an open incident on " + service,
+				"Compare the alerting metric with the last 24 hours",
+				"Check deployments to " + service + " in the last 2 hours",
+			}},
+			{ID: "mitigation", Heading: "Mitigation", Steps: []string{
+				"Roll back a suspect deployment",
+				"Scale " + service + " if it is saturated",
+				"Follow the related runbooks for dependency issues",
+			}},
+			{ID: "escalation", Heading: "Escalation", Body: escalationBody(service)},
+		},
+		Related:   related,
+		UpdatedAt: updatedAt(path, time.Now().UTC()),
+		Metadata:  map[string]any{"source": p.cfg.Source, "generated": true, "alert_type": alertType},
+	}, true
+}
+
+func (p *Provider) serviceDocument(service, slug string, now time.Time) Document {
+	team := mockutil.GetTeamForService(service)
+	doc := Document{
+		ID:        slug,
+		Title:     service + " Service Runbook",
+		URL:       baseURL + "/" + slug,
+		Kind:      KindService,
+		Service:   service,
+		Team:      team,
+		Tags:      []string{strings.TrimPrefix(service, "svc-"), "service"},
+		Summary:   fmt.Sprintf("Ownership, dependencies, and runbooks for %s.", service),
+		UpdatedAt: updatedAt(slug, now),
+		Metadata:  map[string]any{},
+	}
+	doc.Sections = append(doc.Sections, Section{
+		ID:      "ownership",
+		Heading: "Ownership",
+		Body:    fmt.Sprintf("%s is owned by %s. Page the team through %s.", service, team, mockutil.GetChannelForTeam(team)),
+	})
+	if deps := mockutil.ServiceDependencies(service); len(deps) > 0 {
+		doc.Sections = append(doc.Sections, Section{ID: "dependencies", Heading: "Dependencies", Steps: deps})
+	}
+	if callers := mockutil.ServiceDependents(service); len(callers) > 0 {
+		doc.Sections = append(doc.Sections, Section{ID: "dependents", Heading: "Dependents", Steps: callers})
+	}
+	for _, existing := range p.docs {
+		if existing.Service == service {
+			doc.Related = append(doc.Related, existing.ID)
+		}
+	}
+	doc.Sections = append(doc.Sections, Section{ID: "escalation", Heading: "Escalation", Body: escalationBody(service)})
+	return doc
+}
+
+func topicDocument(t topic, now time.Time) Document {
+	path := t.path
+	if path == "" {
+		path = t.slug
+	}
+	doc := Document{
+		ID:        t.slug,
+		Title:     t.title,
+		URL:       baseURL + "/" + path,
+		Kind:      t.kind,
+		Service:   t.service,
+		Tags:      mockutil.CloneStringSlice(t.tags),
+		Summary:   t.summary,
+		Related:   mockutil.CloneStringSlice(t.related),
+		UpdatedAt: updatedAt(t.slug, now),
+		Metadata:  map[string]any{},
+	}
+	if t.service != "" {
+		doc.Team = mockutil.GetTeamForService(t.service)
+	}
+	if path != t.slug {
+		doc.Aliases = append(doc.Aliases, baseURL+"/"+t.slug)
+	}
+	for _, alias := range t.aliases {
+		doc.Aliases = append(doc.Aliases, baseURL+"/"+alias)
+	}
+
+	if t.summary != "" {
+		doc.Sections = append(doc.Sections, Section{ID: "overview", Heading: "Overview", Body: t.summary})
+	}
+	if len(t.symptoms) > 0 {
+		doc.Sections = append(doc.Sections, Section{ID: "symptoms", Heading: "Symptoms", Steps: mockutil.CloneStringSlice(t.symptoms)})
+	}
+	if len(t.diagnosis) > 0 {
+		doc.Sections = append(doc.Sections, Section{ID: "diagnosis", Heading: "Diagnosis", Steps: mockutil.CloneStringSlice(t.diagnosis)})
+	}
+	if len(t.mitigate) > 0 {
+		doc.Sections = append(doc.Sections, Section{ID: "mitigation", Heading: "Mitigation", Steps: mockutil.CloneStringSlice(t.mitigate)})
+	}
+	if len(t.steps) > 0 {
+		heading := "Procedure"
+		if t.kind == KindChecklist {
+			heading = "Checklist"
+		}
+		doc.Sections = append(doc.Sections, Section{ID: strings.ToLower(heading), Heading: heading, Steps: mockutil.CloneStringSlice(t.steps)})
+	}
+	if t.service != "" && t.kind != KindService {
+		doc.Sections = append(doc.Sections, Section{ID: "escalation", Heading: "Escalation", Body: escalationBody(t.service)})
+	}
+	return doc
+}
+
+func escalationBody(service string) string {
+	team := mockutil.GetTeamForService(service)
+	return fmt.Sprintf("If not mitigated within 30 minutes, page %s on-call and post in %s.", team, mockutil.GetChannelForTeam(team))
+}
+
+func serviceSlug(service string) string {
+	if slug, ok := serviceSlugs[service]; ok {
+		return slug
+	}
+	return strings.TrimPrefix(service, "svc-")
+}
+
+// updatedAt gives each document a stable last-edited date within the past quarter.
+func updatedAt(id string, now time.Time) time.Time {
+	h := fnv.New32a()
+	h.Write([]byte(id))
+	day := now.Truncate(24 * time.Hour)
+	return day.Add(-time.Duration(1+h.Sum32()%90) * 24 * time.Hour)
+}
+
+func scoreDocument(doc Document, terms []string) (float64, string, string) {
+	if len(terms) == 0 {
+		return 0, "", doc.Summary
+	}
+	title := strings.ToLower(doc.Title)
+	tags := strings.ToLower(strings.Join(doc.Tags, " "))
+	summary := strings.ToLower(doc.Summary)
+
+	score := 0.0
+	bestSection, snippet, bestHits := "", doc.Summary, 0
+	for _, term := range terms {
+		if strings.Contains(title, term) {
+			score += 3
+		}
+		if strings.Contains(tags, term) {
+			score += 2
+		}
+		if strings.Contains(summary, term) {
+			score++
+		}
+	}
+	for _, section := range doc.Sections {
+		lines := append([]string{section.Heading, section.Body}, section.Steps...)
+		hits := 0
+		firstHit := ""
+		for _, line := range lines {
+			lower := strings.ToLower(line)
+			for _, term := range terms {
+				if strings.Contains(lower, term) {
+					hits++
+					if firstHit == "" {
+						firstHit = line
+					}
+				}
+			}
+		}
+		score += 0.5 * float64(hits)
+		if hits > bestHits {
+			bestHits, bestSection, snippet = hits, section.ID, firstHit
+		}
+	}
+	return score, bestSection, snippet
+}
+
+func hasTags(docTags, want []string) bool {
+	for _, tag := range want {
+		found := false
+		for _, have := range docTags {
+			if strings.EqualFold(have, tag) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func cloneDocument(in Document) Document {
+	out := in
+	out.Aliases = mockutil.CloneStringSlice(in.Aliases)
+	out.Tags = mockutil.CloneStringSlice(in.Tags)
+	out.Related = mockutil.CloneStringSlice(in.Related)
+	out.Sections = make([]Section, len(in.Sections))
+	for i, section := range in.Sections {
+		section.Steps = mockutil.CloneStringSlice(section.Steps)
+		out.Sections[i] = section
+	}
+	out.Metadata = mockutil.CloneMap(in.Metadata)
+	return out
+}
+
+func parseConfig(cfg map[string]any) Config {
+	out := Config{Source: "mock"}
+	if v, ok := cfg["source"].(string); ok && v != "" {
+		out.Source = v
+	}
+	return out
+}
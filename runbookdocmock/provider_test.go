@@ -0,0 +1,102 @@
+package runbookdocmock
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestGetResolvesCanonicalAndAliasURLs(t *testing.T) {
+	prov, err := New(map[string]any{"source": "test"})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+
+	canonical, err := prov.Get(context.Background(), "https://runbook.demo/runbooks/db-failover")
+	if err != nil {
+		t.Fatalf("Get canonical returned error: %v", err)
+	}
+	alias, err := prov.Get(context.Background(), "https://runbook.demo/db-failover")
+	if err != nil {
+		t.Fatalf("Get alias returned error: %v", err)
+	}
+	if canonical.ID != "db-failover" || alias.ID != canonical.ID {
+		t.Fatalf("expected both URLs to resolve to db-failover, got %q and %q", canonical.ID, alias.ID)
+	}
+	if len(canonical.Sections) == 0 || canonical.Metadata["source"] != "test" {
+		t.Fatalf("expected sections and source metadata, got %+v", canonical)
+	}
+
+	byID, err := prov.Get(context.Background(), "cert-rotation")
+	if err != nil {
+		t.Fatalf("Get by id returned error: %v", err)
+	}
+	renewal, _ := prov.Get(context.Background(), "https://runbook.demo/cert-renewal")
+	if renewal.ID != byID.ID {
+		t.Fatalf("expected cert-renewal alias to resolve to cert-rotation, got %q", renewal.ID)
+	}
+}
+
+func TestGetResolvesServiceAndAlertLinks(t *testing.T) {
+	prov, _ := New(nil)
+
+	orders, err := prov.Get(context.Background(), "https://runbook.demo/orders")
+	if err != nil {
+		t.Fatalf("Get service page returned error: %v", err)
+	}
+	if orders.Kind != KindService || orders.Service != "svc-order" {
+		t.Fatalf("expected svc-order service page, got %+v", orders)
+	}
+
+	alert, err := prov.Get(context.Background(), "https://runbook.demo/svc-checkout-high-latency")
+	if err != nil {
+		t.Fatalf("Get alert page returned error: %v", err)
+	}
+	if alert.Kind != KindAlert || alert.Service != "svc-checkout" || alert.Metadata["generated"] != true {
+		t.Fatalf("expected generated svc-checkout alert page, got %+v", alert)
+	}
+	foundTopic := false
+	for _, id := range alert.Related {
+		if id == "high-latency" {
+			foundTopic = true
+		}
+	}
+	if !foundTopic {
+		t.Fatalf("expected alert page to link high-latency playbook, got %v", alert.Related)
+	}
+
+	_, err = prov.Get(context.Background(), "https://example.com/runbooks/db-failover")
+	if err == nil || !strings.Contains(err.Error(), "not_found") {
+		t.Fatalf("expected not_found for foreign host, got %v", err)
+	}
+}
+
+func TestSearchRanksAndFilters(t *testing.T) {
+	prov, _ := New(nil)
+
+	results, err := prov.Search(context.Background(), SearchQuery{Query: "connection pool"})
+	if err != nil {
+		t.Fatalf("Search returned error: %v", err)
+	}
+	if len(results) == 0 || results[0].ID != "db-connection-pool" {
+		t.Fatalf("expected db-connection-pool first, got %+v", results)
+	}
+	if results[0].Snippet == "" {
+		t.Fatalf("expected a snippet on the top result")
+	}
+
+	payments, _ := prov.Search(context.Background(), SearchQuery{Service: "svc-payments", Limit: 2})
+	if len(payments) != 2 {
+		t.Fatalf("expected limit to apply, got %d", len(payments))
+	}
+	for _, res := range payments {
+		if res.Service != "svc-payments" {
+			t.Fatalf("service filter ignored: %+v", res)
+		}
+	}
+
+	checklists, _ := prov.Search(context.Background(), SearchQuery{Kind: KindChecklist, Tags: []string{"canary"}})
+	if len(checklists) != 1 || checklists[0].ID != "canary-deploy" {
+		t.Fatalf("expected canary checklist, got %+v", checklists)
+	}
+}
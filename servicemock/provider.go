@@ -4,7 +4,9 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"sync"
 
+	"github.com/opsorch/opsorch-core/orcherr"
 	"github.com/opsorch/opsorch-core/schema"
 	coreservice "github.com/opsorch/opsorch-core/service"
 	"github.com/opsorch/opsorch-mock-adapters/internal/mockutil"
@@ -17,12 +19,18 @@ const ProviderName = "mock"
 type Config struct {
 	// Environment tag that will be stamped on all demo services.
 	Environment string
+	// Features switches realism behaviours off individually; see
+	// mockutil.Features.
+	Features mockutil.Features
 }
 
 // Provider serves a static set of demo services and applies client-side filtering.
 type Provider struct {
 	cfg      Config
 	services []schema.Service
+
+	mu        sync.Mutex
+	scorecard ScorecardDeps
 }
 
 // New constructs the mock service provider.
@@ -79,6 +87,7 @@ func parseConfig(cfg map[string]any) Config {
 	if v, ok := cfg["environment"].(string); ok && v != "" {
 		out.Environment = v
 	}
+	out.Features = mockutil.ParseFeatures(cfg)
 	return out
 }
 
@@ -228,8 +237,10 @@ func seedServices(cfg Config) []schema.Service {
 		},
 	}
 
-	for i := range base {
-		applyServiceFlair(&base[i])
+	if cfg.Features.Flair {
+		for i := range base {
+			applyServiceFlair(&base[i])
+		}
 	}
 	out := make([]schema.Service, len(base))
 	for i, svc := range base {
@@ -250,43 +261,37 @@ func applyServiceFlair(svc *schema.Service) {
 		"pager": fmt.Sprintf("pagerduty://%s", strings.TrimPrefix(owner, "team-")),
 	}
 	svc.Metadata["contacts"] = contacts
-	svc.Metadata["dependencies"] = serviceDependencies(svc.ID)
+	svc.Metadata["dependencies"] = mockutil.ServiceDependencies(svc.ID)
 	svc.Metadata["repositories"] = []string{fmt.Sprintf("https://github.com/opsorch/%s", slug)}
 	svc.Metadata["dashboards"] = []string{fmt.Sprintf("https://grafana.demo/d/%s-overview", slug)}
 	svc.Metadata["goldenMetrics"] = []string{"latency", "errors", "saturation"}
+
+	domains := mockutil.ServiceFailureDomains(svc.ID)
+	svc.Tags["region"] = domains.Region
+	svc.Tags["cluster"] = domains.Cluster
+	svc.Metadata["failureDomains"] = domains
+	svc.Metadata["slos"] = mockutil.ServiceSLOs(svc.ID)
+	if endpoints := mockutil.ServiceEndpoints(svc.ID); len(endpoints) > 0 {
+		svc.Metadata["endpoints"] = endpoints
+	}
 }
 
-func serviceDependencies(id string) []string {
-	switch id {
-	case "svc-checkout":
-		return []string{"svc-payments", "svc-order", "svc-notifications"}
-	case "svc-search":
-		return []string{"svc-web", "svc-catalog"}
-	case "svc-web":
-		return []string{"svc-realtime"}
-	case "svc-payments":
-		return []string{"svc-identity"}
-	case "svc-notifications":
-		return []string{"svc-analytics"}
-	case "svc-identity":
-		return []string{"svc-web"}
-	case "svc-warehouse":
-		return []string{"svc-analytics"}
-	case "svc-recommendation":
-		return []string{"svc-catalog", "svc-analytics"}
-	case "svc-analytics":
-		return []string{"svc-warehouse"}
-	case "svc-order":
-		return []string{"svc-checkout", "svc-payments"}
-	case "svc-catalog":
-		return []string{"svc-warehouse"}
-	case "svc-shipping":
-		return []string{"svc-order"}
-	case "svc-realtime":
-		return []string{"svc-notifications"}
-	default:
-		return nil
+// Endpoints lists the key endpoints a service exposes with their SLO tiers.
+func (p *Provider) Endpoints(ctx context.Context, service string) ([]mockutil.Endpoint, error) {
+	_ = ctx
+	for _, svc := range p.services {
+		if svc.ID == service {
+			return mockutil.ServiceEndpoints(service), nil
+		}
 	}
+	return nil, orcherr.New("not_found", fmt.Sprintf("service %q not found", service), nil)
+}
+
+// BlastRadius lists the services, teams, and SLOs at risk when a failure
+// domain or service fails; see mockutil.BlastRadius.
+func (p *Provider) BlastRadius(ctx context.Context, component string) (mockutil.BlastRadiusReport, error) {
+	_ = ctx
+	return mockutil.BlastRadius(component)
 }
 
 func serviceSlug(id string) string {
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/opsorch/opsorch-core/schema"
+	"github.com/opsorch/opsorch-mock-adapters/internal/mockutil"
 )
 
 func TestQueryFiltersAndCloning(t *testing.T) {
@@ -129,3 +130,110 @@ func TestServiceURLGeneration(t *testing.T) {
 		}
 	}
 }
+
+func TestFailureDomainTagsAndBlastRadius(t *testing.T) {
+	provAny, _ := New(nil)
+	prov := provAny.(*Provider)
+	ctx := context.Background()
+
+	out, err := prov.Query(ctx, schema.ServiceQuery{Tags: map[string]string{"cluster": "ares"}})
+	if err != nil {
+		t.Fatalf("Query returned error: %v", err)
+	}
+	if len(out) != 1 || out[0].ID != "svc-search" || out[0].Tags["region"] != "us-west-2" {
+		t.Fatalf("expected svc-search on cluster ares in us-west-2, got %+v", out)
+	}
+	if _, ok := out[0].Metadata["failureDomains"]; !ok {
+		t.Fatalf("expected failureDomains metadata, got %+v", out[0].Metadata)
+	}
+
+	report, err := prov.BlastRadius(ctx, "cluster:ares")
+	if err != nil {
+		t.Fatalf("BlastRadius returned error: %v", err)
+	}
+	if len(report.Services) == 0 || report.Services[0].Service != "svc-search" || report.Teams[0] != "team-aurora" {
+		t.Fatalf("unexpected blast radius %+v", report)
+	}
+}
+
+func TestServiceEndpoints(t *testing.T) {
+	provAny, _ := New(nil)
+	prov := provAny.(*Provider)
+	ctx := context.Background()
+
+	endpoints, err := prov.Endpoints(ctx, "svc-checkout")
+	if err != nil {
+		t.Fatalf("Endpoints returned error: %v", err)
+	}
+	if len(endpoints) == 0 || endpoints[0].Operation != "PlaceOrder" || endpoints[0].SLOTier != mockutil.TierCritical {
+		t.Fatalf("unexpected checkout endpoints %+v", endpoints)
+	}
+	out, _ := prov.Query(ctx, schema.ServiceQuery{IDs: []string{"svc-checkout"}})
+	if len(out) != 1 || out[0].Metadata["endpoints"] == nil {
+		t.Fatalf("expected endpoints metadata on svc-checkout, got %+v", out)
+	}
+	if _, err := prov.Endpoints(ctx, "svc-missing"); err == nil || !strings.Contains(err.Error(), "not_found") {
+		t.Fatalf("expected not_found for an unknown service, got %v", err)
+	}
+}
+
+func TestScorecards(t *testing.T) {
+	provAny, _ := New(nil)
+	prov := provAny.(*Provider)
+
+	cards, err := prov.Scorecards(context.Background())
+	if err != nil {
+		t.Fatalf("Scorecards returned error: %v", err)
+	}
+	if len(cards) != len(prov.services) {
+		t.Fatalf("expected a scorecard per service, got %d", len(cards))
+	}
+	checks := func(card Scorecard) map[string]bool {
+		out := map[string]bool{}
+		for _, check := range card.Checks {
+			out[check.ID] = check.Passed
+		}
+		return out
+	}
+	byID := map[string]Scorecard{}
+	for _, card := range cards {
+		byID[card.Service] = card
+	}
+
+	payments := byID["svc-payments"]
+	if !payments.Ready || payments.Score != 100 || payments.Passed != payments.Total {
+		t.Fatalf("expected payments to pass every check, got %+v", payments)
+	}
+	if web := checks(byID["svc-web"]); web[CheckOnCall] || !web[CheckSLO] || !web[CheckMonitored] {
+		t.Fatalf("expected web to fail only on-call among slo/monitored/oncall, got %v", web)
+	}
+	if analytics := checks(byID["svc-analytics"]); analytics[CheckOnCall] || !analytics[CheckRunbook] {
+		t.Fatalf("expected analytics to pass runbook and fail on-call for an unstaffed team, got %v", analytics)
+	}
+	checkout := byID["svc-checkout"]
+	if checks(checkout)[CheckRunbook] || checkout.Score != 75 || checkout.Ready {
+		t.Fatalf("expected checkout to miss a runbook plan, got %+v", checkout)
+	}
+
+	one, err := prov.Scorecard(context.Background(), "svc-payments")
+	if err != nil || one.Service != "svc-payments" {
+		t.Fatalf("expected the payments scorecard, got %+v (%v)", one, err)
+	}
+	if _, err := prov.Scorecard(context.Background(), "svc-missing"); err == nil {
+		t.Fatal("expected an error for an unknown service")
+	}
+}
+
+func TestFlairDisabled(t *testing.T) {
+	provAny, _ := New(map[string]any{"minimal": true})
+	out, err := provAny.Query(context.Background(), schema.ServiceQuery{IDs: []string{"svc-checkout"}})
+	if err != nil || len(out) != 1 {
+		t.Fatalf("expected svc-checkout, got %v (%v)", out, err)
+	}
+	if _, ok := out[0].Metadata["contacts"]; ok {
+		t.Fatalf("expected no contacts with flair off, got %v", out[0].Metadata)
+	}
+	if _, ok := out[0].Tags["cluster"]; ok {
+		t.Fatalf("expected no failure-domain tags with flair off, got %v", out[0].Tags)
+	}
+}
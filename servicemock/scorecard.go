@@ -0,0 +1,232 @@
+package servicemock
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/opsorch/opsorch-core/orcherr"
+	"github.com/opsorch/opsorch-core/schema"
+	"github.com/opsorch/opsorch-mock-adapters/internal/mockutil"
+	"github.com/opsorch/opsorch-mock-adapters/orchestrationmock"
+	"github.com/opsorch/opsorch-mock-adapters/teammock"
+)
+
+// Production-readiness checks, in the order scorecards list them.
+const (
+	CheckRunbook   = "runbook"
+	CheckSLO       = "slo"
+	CheckMonitored = "monitored"
+	CheckOnCall    = "oncall"
+)
+
+// ScorecardDeps are the providers scorecards read. A plugin process holds
+// only the service provider, so any left nil are replaced with fresh mocks on
+// first use; callers holding the other providers pass them in so overrides
+// and new plans count.
+type ScorecardDeps struct {
+	Teams         *teammock.Provider
+	Orchestration *orchestrationmock.Provider
+}
+
+// Scorecard grades one service on the production-readiness checks.
+type Scorecard struct {
+	Service string `json:"service"`
+	Name    string `json:"name"`
+	Owner   string `json:"owner,omitempty"`
+	Tier    int    `json:"tier"`
+	// Score is the share of checks passed, from 0 to 100.
+	Score  int              `json:"score"`
+	Passed int              `json:"passed"`
+	Total  int              `json:"total"`
+	Ready  bool             `json:"ready"`
+	Checks []ScorecardCheck `json:"checks"`
+}
+
+// ScorecardCheck is one pass/fail readiness check and why it came out so.
+type ScorecardCheck struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Passed bool   `json:"passed"`
+	Detail string `json:"detail"`
+	// Evidence lists the records the check found, such as plan or SLO IDs.
+	Evidence []string `json:"evidence,omitempty"`
+}
+
+// SetScorecardDeps sets the providers scorecards read.
+func (p *Provider) SetScorecardDeps(deps ScorecardDeps) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.scorecard = deps
+}
+
+// Scorecard grades one service.
+func (p *Provider) Scorecard(ctx context.Context, service string) (Scorecard, error) {
+	for _, svc := range p.services {
+		if svc.ID == service {
+			deps, err := p.scorecardDeps()
+			if err != nil {
+				return Scorecard{}, err
+			}
+			return scoreService(ctx, deps, svc), nil
+		}
+	}
+	return Scorecard{}, orcherr.New("not_found", fmt.Sprintf("service %q not found", service), nil)
+}
+
+// Scorecards grades every service, in seed order.
+func (p *Provider) Scorecards(ctx context.Context) ([]Scorecard, error) {
+	deps, err := p.scorecardDeps()
+	if err != nil {
+		return nil, err
+	}
+	out := make([]Scorecard, 0, len(p.services))
+	for _, svc := range p.services {
+		out = append(out, scoreService(ctx, deps, svc))
+	}
+	return out, nil
+}
+
+// scorecardDeps returns the configured providers, building mocks for any unset.
+func (p *Provider) scorecardDeps() (ScorecardDeps, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.scorecard.Teams == nil {
+		prov, err := teammock.New(nil)
+		if err != nil {
+			return ScorecardDeps{}, err
+		}
+		p.scorecard.Teams = prov.(*teammock.Provider)
+	}
+	if p.scorecard.Orchestration == nil {
+		prov, err := orchestrationmock.New(nil)
+		if err != nil {
+			return ScorecardDeps{}, err
+		}
+		p.scorecard.Orchestration = prov.(*orchestrationmock.Provider)
+	}
+	return p.scorecard, nil
+}
+
+func scoreService(ctx context.Context, deps ScorecardDeps, svc schema.Service) Scorecard {
+	card := Scorecard{
+		Service: svc.ID,
+		Name:    svc.Name,
+		Owner:   svc.Tags["owner"],
+		Tier:    mockutil.ServiceTier(svc.ID),
+		Checks: []ScorecardCheck{
+			runbookCheck(ctx, deps.Orchestration, svc),
+			sloCheck(svc),
+			monitoredCheck(svc),
+			onCallCheck(ctx, deps.Teams, svc),
+		},
+	}
+	card.Total = len(card.Checks)
+	for _, check := range card.Checks {
+		if check.Passed {
+			card.Passed++
+		}
+	}
+	card.Score = card.Passed * 100 / card.Total
+	card.Ready = card.Passed == card.Total
+	return card
+}
+
+// runbookCheck passes when an orchestration plan is tagged with the service,
+// so responders have a procedure they can run rather than only a wiki link.
+// A wiki link alone is reported in the detail but does not pass.
+func runbookCheck(ctx context.Context, orch *orchestrationmock.Provider, svc schema.Service) ScorecardCheck {
+	check := ScorecardCheck{ID: CheckRunbook, Name: "Has runbook"}
+	plans, err := orch.QueryPlans(ctx, schema.OrchestrationPlanQuery{Tags: map[string]string{"service": svc.ID}})
+	if err != nil {
+		check.Detail = err.Error()
+		return check
+	}
+	for _, plan := range plans {
+		check.Evidence = append(check.Evidence, plan.ID)
+	}
+	check.Passed = len(plans) > 0
+	if check.Passed {
+		check.Detail = fmt.Sprintf("%d orchestration plan(s) tagged with %s", len(plans), svc.ID)
+	} else if wiki := runbookLink(svc); wiki != "" {
+		check.Detail = "only a wiki runbook (" + wiki + "); no orchestration plan is tagged with this service"
+	} else {
+		check.Detail = "no orchestration plan is tagged with this service"
+	}
+	return check
+}
+
+func runbookLink(svc schema.Service) string {
+	links, _ := svc.Metadata["links"].([]string)
+	for _, link := range links {
+		if strings.Contains(link, "runbook") {
+			return link
+		}
+	}
+	return ""
+}
+
+// sloCheck passes when the service has an availability SLO and, on tier 1, a
+// latency SLO as well.
+func sloCheck(svc schema.Service) ScorecardCheck {
+	check := ScorecardCheck{ID: CheckSLO, Name: "Has SLO"}
+	hasLatency := false
+	for _, slo := range mockutil.ServiceSLOs(svc.ID) {
+		check.Evidence = append(check.Evidence, slo.ID)
+		hasLatency = hasLatency || strings.HasSuffix(slo.ID, "-latency")
+	}
+	switch {
+	case len(check.Evidence) == 0:
+		check.Detail = "no SLO is defined"
+	case mockutil.ServiceTier(svc.ID) == 1 && !hasLatency:
+		check.Detail = "tier 1 services need a latency SLO"
+	default:
+		check.Passed = true
+		check.Detail = fmt.Sprintf("%d SLO(s) defined", len(check.Evidence))
+	}
+	return check
+}
+
+// monitoredCheck passes when the service links a dashboard and exposes
+// endpoints the metric mocks instrument.
+func monitoredCheck(svc schema.Service) ScorecardCheck {
+	check := ScorecardCheck{ID: CheckMonitored, Name: "Monitored"}
+	links, _ := svc.Metadata["links"].([]string)
+	for _, link := range links {
+		if strings.Contains(link, "grafana") {
+			check.Evidence = append(check.Evidence, link)
+		}
+	}
+	endpoints := mockutil.ServiceEndpoints(svc.ID)
+	switch {
+	case len(check.Evidence) == 0:
+		check.Detail = "no dashboard is linked"
+	case len(endpoints) == 0:
+		check.Detail = "no instrumented endpoints"
+	default:
+		check.Passed = true
+		check.Detail = fmt.Sprintf("dashboard linked and %d endpoint(s) instrumented", len(endpoints))
+	}
+	return check
+}
+
+// onCallCheck passes when the service names a paging route and its owning
+// team has someone on call now.
+func onCallCheck(ctx context.Context, teams *teammock.Provider, svc schema.Service) ScorecardCheck {
+	check := ScorecardCheck{ID: CheckOnCall, Name: "On-call configured"}
+	route, _ := svc.Metadata["oncall"].(string)
+	if route == "" {
+		check.Detail = "no paging route is configured"
+		return check
+	}
+	check.Evidence = append(check.Evidence, route)
+	owner := svc.Tags["owner"]
+	status, err := teams.OnCall(ctx, owner)
+	if err != nil {
+		check.Detail = fmt.Sprintf("owning team %q has no on-call rotation", owner)
+		return check
+	}
+	check.Passed = true
+	check.Detail = fmt.Sprintf("%s is on call for %s", status.Responder.Name, owner)
+	return check
+}
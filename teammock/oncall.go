@@ -0,0 +1,316 @@
+package teammock
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/opsorch/opsorch-core/orcherr"
+	"github.com/opsorch/opsorch-core/schema"
+)
+
+// rotationEpoch anchors the weekly rotation so the computed schedule is stable across restarts.
+var rotationEpoch = time.Date(2024, time.January, 1, 9, 0, 0, 0, time.UTC)
+
+const rotationLength = 7 * 24 * time.Hour
+
+// OnCallOverride temporarily hands a team's on-call shift to another member.
+type OnCallOverride struct {
+	ID        string    `json:"id"`
+	TeamID    string    `json:"teamId"`
+	MemberID  string    `json:"memberId"`
+	Start     time.Time `json:"start"`
+	End       time.Time `json:"end"`
+	Reason    string    `json:"reason,omitempty"`
+	CreatedBy string    `json:"createdBy,omitempty"`
+}
+
+// OutOfOffice marks a member unavailable; the rotation skips them while active.
+type OutOfOffice struct {
+	ID       string    `json:"id"`
+	MemberID string    `json:"memberId"`
+	Start    time.Time `json:"start"`
+	End      time.Time `json:"end"`
+	Note     string    `json:"note,omitempty"`
+}
+
+// OnCallStatus describes who is actually on call for a team at a point in time.
+type OnCallStatus struct {
+	TeamID     string            `json:"teamId"`
+	At         time.Time         `json:"at"`
+	Responder  schema.TeamMember `json:"responder"`
+	Source     string            `json:"source"`
+	Scheduled  string            `json:"scheduledMemberId,omitempty"`
+	OverrideID string            `json:"overrideId,omitempty"`
+	Skipped    []string          `json:"skipped,omitempty"`
+	ShiftEnd   time.Time         `json:"shiftEnd"`
+}
+
+// OnCall returns the current on-call responder for a team.
+func (p *Provider) OnCall(ctx context.Context, teamID string) (OnCallStatus, error) {
+	return p.OnCallAt(ctx, teamID, p.now())
+}
+
+// OnCallAt resolves the on-call responder at a given time. Active overrides win;
+// otherwise the weekly rotation is used, skipping members who are out of office.
+// When every member is out, the shift escalates to the parent team.
+func (p *Provider) OnCallAt(ctx context.Context, teamID string, at time.Time) (OnCallStatus, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.onCallLocked(teamID, at, map[string]bool{})
+}
+
+func (p *Provider) onCallLocked(teamID string, at time.Time, visited map[string]bool) (OnCallStatus, error) {
+	team, ok := p.teamByID(teamID)
+	if !ok {
+		return OnCallStatus{}, orcherr.New("not_found", fmt.Sprintf("team not found: %s", teamID), nil)
+	}
+	visited[teamID] = true
+
+	status := OnCallStatus{TeamID: teamID, At: at}
+
+	for _, ov := range p.overrides {
+		if ov.TeamID == teamID && !at.Before(ov.Start) && at.Before(ov.End) {
+			if member, ok := p.memberByID(ov.MemberID); ok {
+				status.Responder = cloneTeamMember(member)
+				status.Source = "override"
+				status.OverrideID = ov.ID
+				status.ShiftEnd = ov.End
+				return status, nil
+			}
+		}
+	}
+
+	members := p.members[teamID]
+	shift := int(at.Sub(rotationEpoch) / rotationLength)
+	if at.Before(rotationEpoch) {
+		shift = 0
+	}
+	status.ShiftEnd = rotationEpoch.Add(time.Duration(shift+1) * rotationLength)
+
+	for i := 0; i < len(members); i++ {
+		member := members[(shift+i)%len(members)]
+		if i == 0 {
+			status.Scheduled = member.ID
+		}
+		if p.isOutOfOfficeLocked(member.ID, at) {
+			status.Skipped = append(status.Skipped, member.ID)
+			continue
+		}
+		status.Responder = cloneTeamMember(member)
+		status.Source = "schedule"
+		return status, nil
+	}
+
+	if team.Parent != "" && !visited[team.Parent] {
+		parent, err := p.onCallLocked(team.Parent, at, visited)
+		if err == nil {
+			parent.TeamID = teamID
+			parent.Source = "escalation"
+			parent.Scheduled = status.Scheduled
+			parent.Skipped = append(status.Skipped, parent.Skipped...)
+			return parent, nil
+		}
+	}
+	return OnCallStatus{}, orcherr.New("not_found", fmt.Sprintf("no available on-call responder for team %s", teamID), nil)
+}
+
+// Shift is a stretch of time with a single on-call responder for a team.
+type Shift struct {
+	TeamID     string            `json:"teamId"`
+	Start      time.Time         `json:"start"`
+	End        time.Time         `json:"end"`
+	Responder  schema.TeamMember `json:"responder"`
+	Source     string            `json:"source"`
+	Scheduled  string            `json:"scheduledMemberId,omitempty"`
+	OverrideID string            `json:"overrideId,omitempty"`
+}
+
+// Shifts lays out who is on call for a team between start and end. Shifts break
+// at rotation handoffs and wherever an override or out-of-office window starts
+// or ends, so each one resolves exactly as OnCallAt would inside it.
+func (p *Provider) Shifts(ctx context.Context, teamID string, start, end time.Time) ([]Shift, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, ok := p.teamByID(teamID); !ok {
+		return nil, orcherr.New("not_found", fmt.Sprintf("team not found: %s", teamID), nil)
+	}
+	if !end.After(start) {
+		return nil, orcherr.New("bad_request", "shift range end must be after start", nil)
+	}
+
+	cuts := []time.Time{start, end}
+	addCut := func(t time.Time) {
+		if t.After(start) && t.Before(end) {
+			cuts = append(cuts, t)
+		}
+	}
+	handoff := rotationEpoch
+	if start.After(rotationEpoch) {
+		handoff = rotationEpoch.Add(time.Duration(start.Sub(rotationEpoch)/rotationLength) * rotationLength)
+	}
+	for ; handoff.Before(end); handoff = handoff.Add(rotationLength) {
+		addCut(handoff)
+	}
+	for _, ov := range p.overrides {
+		addCut(ov.Start)
+		addCut(ov.End)
+	}
+	for _, ooo := range p.outOfOffice {
+		addCut(ooo.Start)
+		addCut(ooo.End)
+	}
+	sort.Slice(cuts, func(i, j int) bool { return cuts[i].Before(cuts[j]) })
+
+	out := make([]Shift, 0)
+	for i := 0; i+1 < len(cuts); i++ {
+		from, to := cuts[i], cuts[i+1]
+		if !to.After(from) {
+			continue
+		}
+		status, err := p.onCallLocked(teamID, from, map[string]bool{})
+		if err != nil {
+			continue
+		}
+		if n := len(out); n > 0 {
+			last := &out[n-1]
+			if last.End.Equal(from) && last.Responder.ID == status.Responder.ID && last.Source == status.Source && last.OverrideID == status.OverrideID && last.Scheduled == status.Scheduled {
+				last.End = to
+				continue
+			}
+		}
+		out = append(out, Shift{
+			TeamID:     teamID,
+			Start:      from,
+			End:        to,
+			Responder:  status.Responder,
+			Source:     status.Source,
+			Scheduled:  status.Scheduled,
+			OverrideID: status.OverrideID,
+		})
+	}
+	return out, nil
+}
+
+// CreateOverride records a schedule override for a team.
+func (p *Provider) CreateOverride(ctx context.Context, in OnCallOverride) (OnCallOverride, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, ok := p.teamByID(in.TeamID); !ok {
+		return OnCallOverride{}, orcherr.New("not_found", fmt.Sprintf("team not found: %s", in.TeamID), nil)
+	}
+	if _, ok := p.memberByID(in.MemberID); !ok {
+		return OnCallOverride{}, orcherr.New("not_found", fmt.Sprintf("member not found: %s", in.MemberID), nil)
+	}
+	if in.Start.IsZero() {
+		in.Start = p.now()
+	}
+	if in.End.IsZero() {
+		in.End = in.Start.Add(8 * time.Hour)
+	}
+	if !in.End.After(in.Start) {
+		return OnCallOverride{}, orcherr.New("bad_request", "override end must be after start", nil)
+	}
+
+	p.nextOverrideID++
+	in.ID = fmt.Sprintf("ovr-%03d", p.nextOverrideID)
+	p.overrides = append(p.overrides, in)
+	return in, nil
+}
+
+// Overrides lists overrides for a team ordered by start time.
+func (p *Provider) Overrides(ctx context.Context, teamID string) ([]OnCallOverride, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	out := make([]OnCallOverride, 0)
+	for _, ov := range p.overrides {
+		if teamID == "" || ov.TeamID == teamID {
+			out = append(out, ov)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Start.Before(out[j].Start) })
+	return out, nil
+}
+
+// MarkOutOfOffice records an out-of-office window for a member.
+func (p *Provider) MarkOutOfOffice(ctx context.Context, in OutOfOffice) (OutOfOffice, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, ok := p.memberByID(in.MemberID); !ok {
+		return OutOfOffice{}, orcherr.New("not_found", fmt.Sprintf("member not found: %s", in.MemberID), nil)
+	}
+	if in.Start.IsZero() {
+		in.Start = p.now()
+	}
+	if !in.End.After(in.Start) {
+		return OutOfOffice{}, orcherr.New("bad_request", "out-of-office end must be after start", nil)
+	}
+
+	p.nextOutOfOfficeID++
+	in.ID = fmt.Sprintf("ooo-%03d", p.nextOutOfOfficeID)
+	p.outOfOffice = append(p.outOfOffice, in)
+	return in, nil
+}
+
+func (p *Provider) isOutOfOfficeLocked(memberID string, at time.Time) bool {
+	for _, ooo := range p.outOfOffice {
+		if ooo.MemberID == memberID && !at.Before(ooo.Start) && at.Before(ooo.End) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *Provider) teamByID(id string) (schema.Team, bool) {
+	for _, team := range p.teams {
+		if team.ID == id {
+			return team, true
+		}
+	}
+	return schema.Team{}, false
+}
+
+func (p *Provider) memberByID(id string) (schema.TeamMember, bool) {
+	for _, members := range p.members {
+		for _, member := range members {
+			if member.ID == id {
+				return member, true
+			}
+		}
+	}
+	return schema.TeamMember{}, false
+}
+
+func (p *Provider) now() time.Time {
+	if p.clock != nil {
+		return p.clock()
+	}
+	return time.Now().UTC()
+}
+
+// seedOnCall adds demo availability data: Charlie is on vacation this week, so
+// Velocity's shift falls through to Diana, and Aurora has an upcoming override.
+func (p *Provider) seedOnCall(now time.Time) {
+	p.outOfOffice = append(p.outOfOffice, OutOfOffice{
+		ID:       "ooo-seed-001",
+		MemberID: "charlie.brown@opsorch.com",
+		Start:    now.Add(-48 * time.Hour),
+		End:      now.Add(72 * time.Hour),
+		Note:     "Vacation",
+	})
+	p.overrides = append(p.overrides, OnCallOverride{
+		ID:        "ovr-seed-001",
+		TeamID:    "team-aurora",
+		MemberID:  "alice.johnson@opsorch.com",
+		Start:     now.Add(24 * time.Hour),
+		End:       now.Add(36 * time.Hour),
+		Reason:    "Covering while Eve travels to a conference",
+		CreatedBy: "eve.wilson@opsorch.com",
+	})
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/opsorch/opsorch-core/schema"
 	coreteam "github.com/opsorch/opsorch-core/team"
@@ -24,13 +26,26 @@ type Provider struct {
 	cfg     Config
 	teams   []schema.Team
 	members map[string][]schema.TeamMember
+
+	mu                sync.Mutex
+	overrides         []OnCallOverride
+	outOfOffice       []OutOfOffice
+	nextOverrideID    int
+	nextOutOfOfficeID int
+	clock             func() time.Time
+
+	workload WorkloadDeps
 }
 
 // New constructs the mock team provider.
 func New(cfg map[string]any) (coreteam.Provider, error) {
 	parsed := parseConfig(cfg)
 	teams, members := seedTeams(parsed)
-	return &Provider{cfg: parsed, teams: teams, members: members}, nil
+	p := &Provider{cfg: parsed, teams: teams, members: members}
+	p.seedOnCall(p.now())
+	mockutil.RegisterResolver(mockutil.RefTeam, func(ctx context.Context, id string) (any, error) { return p.Get(ctx, id) })
+	mockutil.RegisterSearchSource(mockutil.RefTeam, p.SearchDocs)
+	return p, nil
 }
 
 func init() {
@@ -341,6 +356,7 @@ func seedTeams(cfg Config) ([]schema.Team, map[string][]schema.TeamMember) {
 					"location":  "San Francisco, CA",
 					"timezone":  "America/Los_Angeles",
 					"github":    "alice-johnson",
+					"expertise": []string{"incident-command", "escalation", "communications"},
 					"joined_at": "2022-06-01T09:00:00Z",
 				},
 			},
@@ -357,6 +373,7 @@ func seedTeams(cfg Config) ([]schema.Team, map[string][]schema.TeamMember) {
 					"location":  "New York, NY",
 					"timezone":  "America/New_York",
 					"github":    "charlie-brown",
+					"expertise": []string{"checkout", "latency", "deployment", "frontend"},
 					"languages": []string{"Go", "TypeScript", "React"},
 					"services":  []string{"svc-checkout", "svc-web", "svc-order"},
 					"joined_at": "2023-01-15T09:00:00Z",
@@ -373,6 +390,7 @@ func seedTeams(cfg Config) ([]schema.Team, map[string][]schema.TeamMember) {
 					"location":  "Seattle, WA",
 					"timezone":  "America/Los_Angeles",
 					"github":    "diana-prince",
+					"expertise": []string{"frontend", "web-performance", "cdn"},
 					"languages": []string{"TypeScript", "React", "Vue"},
 					"services":  []string{"svc-web"},
 					"joined_at": "2023-03-01T09:00:00Z",
@@ -391,6 +409,7 @@ func seedTeams(cfg Config) ([]schema.Team, map[string][]schema.TeamMember) {
 					"location":  "Portland, OR",
 					"timezone":  "America/Los_Angeles",
 					"github":    "eve-wilson",
+					"expertise": []string{"search", "latency", "capacity", "kafka"},
 					"languages": []string{"Java", "Elasticsearch", "Kafka"},
 					"services":  []string{"svc-search"},
 					"joined_at": "2023-02-01T09:00:00Z",
@@ -409,6 +428,7 @@ func seedTeams(cfg Config) ([]schema.Team, map[string][]schema.TeamMember) {
 					"location":  "Denver, CO",
 					"timezone":  "America/Denver",
 					"github":    "frank-miller",
+					"expertise": []string{"payments", "database", "third-party", "errors"},
 					"languages": []string{"Go", "PostgreSQL"},
 					"services":  []string{"svc-payments"},
 					"joined_at": "2023-04-15T09:00:00Z",
@@ -427,6 +447,7 @@ func seedTeams(cfg Config) ([]schema.Team, map[string][]schema.TeamMember) {
 					"location":  "Boston, MA",
 					"timezone":  "America/New_York",
 					"github":    "grace-hopper",
+					"expertise": []string{"notifications", "queueing", "errors"},
 					"languages": []string{"Kotlin", "RabbitMQ", "Redis"},
 					"services":  []string{"svc-notifications"},
 					"joined_at": "2022-12-01T09:00:00Z",
@@ -445,6 +466,7 @@ func seedTeams(cfg Config) ([]schema.Team, map[string][]schema.TeamMember) {
 					"location":       "Los Angeles, CA",
 					"timezone":       "America/Los_Angeles",
 					"github":         "henry-ford",
+					"expertise":      []string{"security", "auth", "certificates"},
 					"languages":      []string{"Go", "OAuth", "JWT"},
 					"services":       []string{"svc-identity"},
 					"certifications": []string{"CISSP", "CEH"},
@@ -464,6 +486,7 @@ func seedTeams(cfg Config) ([]schema.Team, map[string][]schema.TeamMember) {
 					"location":  "Remote",
 					"timezone":  "America/Los_Angeles",
 					"github":    "iris-chang",
+					"expertise": []string{"database", "data-pipeline", "capacity"},
 					"languages": []string{"Python", "Spark", "Airflow"},
 					"services":  []string{"svc-warehouse", "svc-analytics"},
 					"joined_at": "2022-11-15T09:00:00Z",
@@ -482,6 +505,7 @@ func seedTeams(cfg Config) ([]schema.Team, map[string][]schema.TeamMember) {
 					"location":  "Austin, TX",
 					"timezone":  "America/Chicago",
 					"github":    "jack-sparrow",
+					"expertise": []string{"ml", "data-quality"},
 					"languages": []string{"Python", "TensorFlow", "Scala"},
 					"services":  []string{"svc-recommendation"},
 					"joined_at": "2023-03-15T09:00:00Z",
@@ -500,6 +524,7 @@ func seedTeams(cfg Config) ([]schema.Team, map[string][]schema.TeamMember) {
 					"location":  "Chicago, IL",
 					"timezone":  "America/Chicago",
 					"github":    "kate-bishop",
+					"expertise": []string{"database", "data-quality", "caching"},
 					"languages": []string{"Rust", "PostgreSQL", "Redis"},
 					"services":  []string{"svc-catalog"},
 					"joined_at": "2023-04-01T09:00:00Z",
@@ -518,6 +543,7 @@ func seedTeams(cfg Config) ([]schema.Team, map[string][]schema.TeamMember) {
 					"location":  "Miami, FL",
 					"timezone":  "America/New_York",
 					"github":    "luke-cage",
+					"expertise": []string{"third-party", "api", "errors"},
 					"languages": []string{"Go", "GraphQL"},
 					"services":  []string{"svc-shipping"},
 					"joined_at": "2023-04-15T09:00:00Z",
@@ -536,6 +562,7 @@ func seedTeams(cfg Config) ([]schema.Team, map[string][]schema.TeamMember) {
 					"location":  "Phoenix, AZ",
 					"timezone":  "America/Phoenix",
 					"github":    "maria-hill",
+					"expertise": []string{"realtime", "networking", "latency"},
 					"languages": []string{"Go", "WebSocket", "Redis"},
 					"services":  []string{"svc-realtime"},
 					"joined_at": "2023-05-01T09:00:00Z",
@@ -544,9 +571,29 @@ func seedTeams(cfg Config) ([]schema.Team, map[string][]schema.TeamMember) {
 		},
 	}
 
+	for _, list := range members {
+		for i := range list {
+			resolveMember(&list[i])
+		}
+	}
 	return teams, members
 }
 
+// resolveMember takes a seeded member's name and email from the shared actor
+// registry, by handle, and adds the actor's avatar to its metadata.
+func resolveMember(m *schema.TeamMember) {
+	a, ok := mockutil.LookupActor(m.Handle)
+	if !ok {
+		return
+	}
+	m.Name = a.DisplayName
+	m.Email = a.Email
+	if m.Metadata == nil {
+		m.Metadata = map[string]any{}
+	}
+	m.Metadata["avatar"] = a.Avatar
+}
+
 func cloneTeam(in schema.Team) schema.Team {
 	// Generate URL if not already present
 	url := in.URL
@@ -628,3 +675,14 @@ func matchesScope(scope schema.QueryScope, team schema.Team) bool {
 
 	return true
 }
+
+// SearchDocs indexes team names and descriptions for global search.
+func (p *Provider) SearchDocs(ctx context.Context) []mockutil.SearchDoc {
+	_ = ctx
+	docs := make([]mockutil.SearchDoc, 0, len(p.teams))
+	for _, team := range p.teams {
+		description, _ := team.Metadata["description"].(string)
+		docs = append(docs, mockutil.SearchDoc{Kind: mockutil.RefTeam, ID: team.ID, Title: team.Name, Body: description})
+	}
+	return docs
+}
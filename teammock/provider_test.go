@@ -4,9 +4,13 @@ import (
 	"context"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/opsorch/opsorch-core/schema"
 	"github.com/opsorch/opsorch-core/team"
+	"github.com/opsorch/opsorch-mock-adapters/alertmock"
+	"github.com/opsorch/opsorch-mock-adapters/internal/mockutil"
+	"github.com/opsorch/opsorch-mock-adapters/ticketmock"
 )
 
 func TestTeamMockProvider(t *testing.T) {
@@ -309,3 +313,204 @@ func TestTeamURLGeneration(t *testing.T) {
 		t.Errorf("team %s has incorrect URL: got %s, want %s", team.ID, team.URL, expectedURL)
 	}
 }
+
+func TestMembersResolveThroughActorRegistry(t *testing.T) {
+	provAny, _ := New(map[string]any{})
+	prov := provAny.(*Provider)
+
+	members, err := prov.Members(context.Background(), "team-velocity")
+	if err != nil {
+		t.Fatalf("Members returned error: %v", err)
+	}
+	actor, _ := mockutil.LookupActor("charlie.brown")
+	for _, m := range members {
+		if m.Handle != "charlie.brown" {
+			continue
+		}
+		if m.Name != actor.DisplayName || m.Email != actor.Email || m.Metadata["avatar"] != actor.Avatar {
+			t.Fatalf("expected member to match its registry actor %+v, got %+v", actor, m)
+		}
+		return
+	}
+	t.Fatalf("expected charlie.brown on team-velocity, got %+v", members)
+}
+
+func TestOnCallOverridesAndOutOfOffice(t *testing.T) {
+	provAny, err := New(map[string]any{})
+	if err != nil {
+		t.Fatalf("failed to create provider: %v", err)
+	}
+	p := provAny.(*Provider)
+	ctx := context.Background()
+
+	// Charlie is seeded as out of office, so Velocity resolves to Diana.
+	status, err := p.OnCall(ctx, "team-velocity")
+	if err != nil {
+		t.Fatalf("OnCall failed: %v", err)
+	}
+	if status.Responder.ID != "diana.prince@opsorch.com" || status.Source != "schedule" {
+		t.Errorf("expected Diana from schedule, got %s (%s)", status.Responder.ID, status.Source)
+	}
+
+	// An active override takes precedence over the rotation.
+	now := time.Now().UTC()
+	ov, err := p.CreateOverride(ctx, OnCallOverride{
+		TeamID:   "team-velocity",
+		MemberID: "alice.johnson@opsorch.com",
+		Start:    now.Add(-time.Minute),
+		End:      now.Add(time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("CreateOverride failed: %v", err)
+	}
+	status, _ = p.OnCall(ctx, "team-velocity")
+	if status.Source != "override" || status.OverrideID != ov.ID || status.Responder.ID != "alice.johnson@opsorch.com" {
+		t.Errorf("expected override responder, got %+v", status)
+	}
+	after, _ := p.OnCallAt(ctx, "team-velocity", now.Add(2*time.Hour))
+	if after.Source == "override" {
+		t.Errorf("expected override to expire, got %+v", after)
+	}
+
+	// A single-member team with its member out escalates to the parent team.
+	if _, err := p.MarkOutOfOffice(ctx, OutOfOffice{MemberID: "eve.wilson@opsorch.com", Start: now.Add(-time.Minute), End: now.Add(time.Hour)}); err != nil {
+		t.Fatalf("MarkOutOfOffice failed: %v", err)
+	}
+	status, err = p.OnCall(ctx, "team-aurora")
+	if err != nil {
+		t.Fatalf("OnCall failed: %v", err)
+	}
+	if status.Source != "escalation" || status.Responder.ID != "alice.johnson@opsorch.com" {
+		t.Errorf("expected escalation to engineering, got %+v", status)
+	}
+
+	if _, err := p.CreateOverride(ctx, OnCallOverride{TeamID: "team-velocity", MemberID: "nobody"}); err == nil {
+		t.Error("expected error for unknown member")
+	}
+	if _, err := p.OnCall(ctx, "missing-team"); err == nil {
+		t.Error("expected error for unknown team")
+	}
+}
+
+func TestShiftsFollowRotationAndOverrides(t *testing.T) {
+	provAny, _ := New(map[string]any{})
+	p := provAny.(*Provider)
+	ctx := context.Background()
+	now := time.Now().UTC()
+
+	shifts, err := p.Shifts(ctx, "team-aurora", now, now.Add(3*24*time.Hour))
+	if err != nil {
+		t.Fatalf("Shifts failed: %v", err)
+	}
+	var override *Shift
+	for i := range shifts {
+		if i > 0 && !shifts[i].Start.Equal(shifts[i-1].End) {
+			t.Fatalf("expected contiguous shifts, got gap before %+v", shifts[i])
+		}
+		if shifts[i].OverrideID == "ovr-seed-001" {
+			override = &shifts[i]
+		}
+		status, _ := p.OnCallAt(ctx, "team-aurora", shifts[i].Start)
+		if status.Responder.ID != shifts[i].Responder.ID {
+			t.Fatalf("shift %+v disagrees with OnCallAt %+v", shifts[i], status)
+		}
+	}
+	if override == nil || override.Responder.ID != "alice.johnson@opsorch.com" || override.End.Sub(override.Start) != 12*time.Hour {
+		t.Fatalf("expected the seeded 12h override, got %+v", shifts)
+	}
+
+	if _, err := p.Shifts(ctx, "team-aurora", now, now); err == nil {
+		t.Error("expected error for empty range")
+	}
+}
+
+func TestRecommendResponders(t *testing.T) {
+	provAny, _ := New(map[string]any{})
+	p := provAny.(*Provider)
+	ctx := context.Background()
+
+	recs, err := p.RecommendResponders(ctx, RecommendRequest{Service: "svc-checkout", Category: "latency", Limit: 5})
+	if err != nil {
+		t.Fatalf("RecommendResponders failed: %v", err)
+	}
+	if len(recs) < 2 {
+		t.Fatalf("expected several candidates, got %d", len(recs))
+	}
+	// Charlie owns checkout but is out of office, so on-call Diana ranks first.
+	top := recs[0]
+	if top.Member.ID != "diana.prince@opsorch.com" || !top.OnCall || top.TeamID != "team-velocity" {
+		t.Fatalf("expected on-call Diana first, got %+v", top)
+	}
+	for i, rec := range recs {
+		if rec.Member.ID == "charlie.brown@opsorch.com" {
+			t.Fatal("out-of-office member should not be recommended")
+		}
+		if len(rec.Reasons) == 0 {
+			t.Fatalf("recommendation %s has no reasons", rec.Member.ID)
+		}
+		if i > 0 && rec.Score > recs[i-1].Score {
+			t.Fatalf("recommendations not ranked by score: %v after %v", rec.Score, recs[i-1].Score)
+		}
+	}
+
+	security, err := p.RecommendResponders(ctx, RecommendRequest{Category: "security"})
+	if err != nil || len(security) == 0 || security[0].Member.ID != "henry.ford@opsorch.com" {
+		t.Fatalf("expected Henry for security, got %+v (%v)", security, err)
+	}
+	if len(security) > defaultRecommendSize {
+		t.Fatalf("expected default limit of %d, got %d", defaultRecommendSize, len(security))
+	}
+
+	if _, err := p.RecommendResponders(ctx, RecommendRequest{}); err == nil || !strings.Contains(err.Error(), "bad_request") {
+		t.Fatalf("expected bad_request without service or category, got %v", err)
+	}
+}
+
+func TestWorkload(t *testing.T) {
+	provAny, _ := New(nil)
+	prov := provAny.(*Provider)
+	alertsAny, _ := alertmock.New(nil)
+	alerts := alertsAny.(*alertmock.Provider)
+	tickets, _ := ticketmock.New(nil)
+	ctx := context.Background()
+
+	if _, err := alerts.Fire(ctx, alertmock.FireRequest{Rule: "high-latency", Service: "svc-checkout"}); err != nil {
+		t.Fatalf("Fire returned error: %v", err)
+	}
+	prov.SetWorkloadDeps(WorkloadDeps{Alerts: alerts, Tickets: tickets})
+
+	report, err := prov.Workload(ctx, "team-velocity")
+	if err != nil {
+		t.Fatalf("Workload returned error: %v", err)
+	}
+	byID := map[string]MemberWorkload{}
+	for _, m := range report.Members {
+		if m.TeamID != "team-velocity" {
+			t.Fatalf("expected only team-velocity members, got %+v", m)
+		}
+		byID[m.ID] = m
+	}
+	// Charlie is out of office, so Diana is on call and takes the page.
+	diana := byID["diana.prince"]
+	if diana.MemberID != "diana.prince@opsorch.com" || diana.Pages < 1 || diana.OnCallHours <= 0 {
+		t.Fatalf("expected diana paged while on call, got %+v", diana)
+	}
+	if diana.NightPages > diana.Pages {
+		t.Fatalf("night pages exceed pages: %+v", diana)
+	}
+	// Responders outside the directory are counted by their actor ID.
+	alex, ok := byID["alex"]
+	if !ok || alex.OpenTickets == 0 || alex.Tickets[0] != "TCK-001" || alex.IncidentsHandled != 0 {
+		t.Fatalf("expected alex's open tickets and no incident data, got %+v", alex)
+	}
+
+	if _, err := prov.Workload(ctx, "team-missing"); err == nil || !strings.Contains(err.Error(), "not_found") {
+		t.Fatalf("expected not_found for an unknown team, got %v", err)
+	}
+
+	m := MemberWorkload{IncidentsHandled: 3, Pages: 4, NightPages: 3, OpenTickets: 1}
+	scoreWorkload(&m)
+	if m.Risk != RiskHigh || len(m.Reasons) != 2 || m.Load != 3*3+3*2+1+1 {
+		t.Fatalf("expected a high-risk score, got %+v", m)
+	}
+}
@@ -0,0 +1,165 @@
+package teammock
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/opsorch/opsorch-core/orcherr"
+	"github.com/opsorch/opsorch-core/schema"
+)
+
+// RecommendRequest asks for responders suited to an incident. At least one of
+// Service and Category is required.
+type RecommendRequest struct {
+	Service string `json:"service"`
+	// Category is the incident category, e.g. "latency", "database", "security".
+	Category string `json:"category"`
+	Limit    int    `json:"limit"`
+}
+
+// ResponderRecommendation is one ranked candidate with the reasons behind its score.
+type ResponderRecommendation struct {
+	Member  schema.TeamMember `json:"member"`
+	TeamID  string            `json:"teamId"`
+	Score   float64           `json:"score"`
+	OnCall  bool              `json:"onCall"`
+	Reasons []string          `json:"reasons"`
+}
+
+// Scoring weights for responder recommendations.
+const (
+	weightExpertise      = 3
+	weightRelatedSkill   = 1.5
+	weightServiceOwner   = 3
+	weightTeamOwnsSvc    = 2
+	weightOnCall         = 2
+	defaultRecommendSize = 3
+)
+
+// relatedSkills lists expertise tags that help with a category without being
+// the category itself.
+var relatedSkills = map[string][]string{
+	"latency":    {"web-performance", "caching", "capacity", "cdn"},
+	"errors":     {"deployment", "third-party", "api"},
+	"database":   {"caching", "data-pipeline"},
+	"security":   {"auth", "certificates"},
+	"capacity":   {"queueing", "latency"},
+	"deployment": {"errors", "frontend"},
+	"network":    {"networking", "cdn", "realtime"},
+	"data":       {"data-pipeline", "data-quality", "database"},
+}
+
+// RecommendResponders ranks members for an incident on a service and category
+// by expertise tags, service ownership, and whether they are on call now.
+// Members who are out of office are never recommended.
+func (p *Provider) RecommendResponders(ctx context.Context, req RecommendRequest) ([]ResponderRecommendation, error) {
+	service := strings.TrimSpace(req.Service)
+	category := strings.ToLower(strings.TrimSpace(req.Category))
+	if service == "" && category == "" {
+		return nil, orcherr.New("bad_request", "service or category is required", nil)
+	}
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultRecommendSize
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	now := p.now()
+
+	teamIDs := make([]string, 0, len(p.members))
+	for id := range p.members {
+		teamIDs = append(teamIDs, id)
+	}
+	sort.Strings(teamIDs)
+
+	out := []ResponderRecommendation{}
+	for _, teamID := range teamIDs {
+		team, _ := p.teamByID(teamID)
+		onCallID := ""
+		if status, err := p.onCallLocked(teamID, now, map[string]bool{}); err == nil && status.Source != "escalation" {
+			onCallID = status.Responder.ID
+		}
+		for _, member := range p.members[teamID] {
+			if p.isOutOfOfficeLocked(member.ID, now) {
+				continue
+			}
+			rec := ResponderRecommendation{Member: cloneTeamMember(member), TeamID: teamID}
+			if category != "" {
+				expertise := metadataStrings(member.Metadata, "expertise")
+				if containsString(expertise, category) {
+					rec.Score += weightExpertise
+					rec.Reasons = append(rec.Reasons, fmt.Sprintf("expertise in %s", category))
+				} else if skill := firstShared(expertise, relatedSkills[category]); skill != "" {
+					rec.Score += weightRelatedSkill
+					rec.Reasons = append(rec.Reasons, fmt.Sprintf("related expertise in %s", skill))
+				}
+			}
+			if service != "" {
+				if containsString(metadataStrings(member.Metadata, "services"), service) {
+					rec.Score += weightServiceOwner
+					rec.Reasons = append(rec.Reasons, fmt.Sprintf("works on %s", service))
+				} else if containsString(metadataStrings(team.Metadata, "services"), service) && team.Parent != "" {
+					rec.Score += weightTeamOwnsSvc
+					rec.Reasons = append(rec.Reasons, fmt.Sprintf("%s owns %s", team.Name, service))
+				}
+			}
+			if rec.Score == 0 {
+				continue
+			}
+			if member.ID == onCallID {
+				rec.OnCall = true
+				rec.Score += weightOnCall
+				rec.Reasons = append(rec.Reasons, fmt.Sprintf("on call for %s", team.Name))
+			}
+			out = append(out, rec)
+		}
+	}
+
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].Score != out[j].Score {
+			return out[i].Score > out[j].Score
+		}
+		return out[i].Member.Name < out[j].Member.Name
+	})
+	if len(out) > limit {
+		out = out[:limit]
+	}
+	return out, nil
+}
+
+func metadataStrings(meta map[string]any, key string) []string {
+	switch v := meta[key].(type) {
+	case []string:
+		return v
+	case []any:
+		out := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				out = append(out, s)
+			}
+		}
+		return out
+	}
+	return nil
+}
+
+func containsString(list []string, want string) bool {
+	for _, s := range list {
+		if s == want {
+			return true
+		}
+	}
+	return false
+}
+
+func firstShared(have, want []string) string {
+	for _, w := range want {
+		if containsString(have, w) {
+			return w
+		}
+	}
+	return ""
+}
@@ -0,0 +1,332 @@
+package teammock
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/opsorch/opsorch-core/alert"
+	"github.com/opsorch/opsorch-core/incident"
+	"github.com/opsorch/opsorch-core/orcherr"
+	"github.com/opsorch/opsorch-core/schema"
+	"github.com/opsorch/opsorch-core/ticket"
+	"github.com/opsorch/opsorch-mock-adapters/internal/mockutil"
+)
+
+// workloadWindow is how far back workload stats look.
+const workloadWindow = 7 * 24 * time.Hour
+
+// Night hours, in the paged member's time zone: from nightStart until
+// nightEnd the next morning.
+const (
+	nightStart = 22
+	nightEnd   = 7
+)
+
+// Burnout thresholds: a member at or over one of these over the window gets
+// a reason in their workload, and their risk rises with each.
+const (
+	burnoutIncidents   = 3
+	burnoutNightPages  = 3
+	burnoutOpenTickets = 4
+)
+
+// Workload risk levels, by how many burnout thresholds a member crosses.
+const (
+	RiskLow      = "low"
+	RiskElevated = "elevated"
+	RiskHigh     = "high"
+)
+
+// pagingSeverities are the alert severities that page the on-call responder.
+var pagingSeverities = map[string]bool{"critical": true, "error": true}
+
+// closedTicketStatuses are the ticket statuses that no longer count as open
+// work.
+var closedTicketStatuses = map[string]bool{"done": true, "closed": true, "resolved": true}
+
+// WorkloadDeps are the providers workload stats read. A plugin process holds
+// only the team provider, so the team plugin passes in fresh mocks; callers
+// holding the other providers pass them in so new incidents, pages, and
+// tickets count. Any left nil contribute nothing.
+type WorkloadDeps struct {
+	Incidents incident.Provider
+	Alerts    alert.Provider
+	Tickets   ticket.Provider
+}
+
+// SetWorkloadDeps sets the providers Workload reads.
+func (p *Provider) SetWorkloadDeps(deps WorkloadDeps) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.workload = deps
+}
+
+// MemberWorkload is one person's load over the window. People are keyed by
+// the actor ID other providers record them under, the directory handle for
+// directory members.
+type MemberWorkload struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	TeamID string `json:"teamId"`
+	// MemberID is the directory member ID, empty for responders who are not
+	// in the directory.
+	MemberID string `json:"memberId,omitempty"`
+
+	IncidentsHandled int      `json:"incidentsHandled"`
+	Incidents        []string `json:"incidents"`
+	Pages            int      `json:"pages"`
+	NightPages       int      `json:"nightPages"`
+	OnCallHours      float64  `json:"onCallHours"`
+	OpenTickets      int      `json:"openTickets"`
+	Tickets          []string `json:"tickets"`
+
+	// Load weighs the stats into one number for ranking: three per incident,
+	// two per night page, and one per other page and open ticket.
+	Load    int      `json:"load"`
+	Risk    string   `json:"risk"`
+	Reasons []string `json:"reasons,omitempty"`
+}
+
+// WorkloadReport lists member workloads over a window, by team and then by
+// load, heaviest first.
+type WorkloadReport struct {
+	Start   time.Time        `json:"start"`
+	End     time.Time        `json:"end"`
+	Members []MemberWorkload `json:"members"`
+}
+
+// Workload computes each member's load over the last week for one team, or
+// every team when teamID is empty: incidents they worked (any timeline entry
+// by them), pages (critical and error alerts on the team's services while
+// they were on call, and how many fell at night in their time zone), on-call
+// hours, and open tickets assigned to them. Members crossing the burnout
+// thresholds are flagged elevated or high risk with the reasons.
+func (p *Provider) Workload(ctx context.Context, teamID string) (WorkloadReport, error) {
+	p.mu.Lock()
+	deps := p.workload
+	end := p.now()
+	p.mu.Unlock()
+	start := end.Add(-workloadWindow)
+
+	teamIDs := make([]string, 0)
+	for _, team := range p.teams {
+		if team.Parent == "" {
+			continue
+		}
+		if teamID == "" || team.ID == teamID {
+			teamIDs = append(teamIDs, team.ID)
+		}
+	}
+	if len(teamIDs) == 0 {
+		return WorkloadReport{}, orcherr.New("not_found", fmt.Sprintf("team not found: %s", teamID), nil)
+	}
+
+	handled, err := incidentsHandled(ctx, deps.Incidents, start, end)
+	if err != nil {
+		return WorkloadReport{}, err
+	}
+	assigned, err := openTickets(ctx, deps.Tickets)
+	if err != nil {
+		return WorkloadReport{}, err
+	}
+	pages, err := teamPages(ctx, deps.Alerts, start, end)
+	if err != nil {
+		return WorkloadReport{}, err
+	}
+
+	report := WorkloadReport{Start: start, End: end, Members: []MemberWorkload{}}
+	for _, id := range teamIDs {
+		shifts, err := p.Shifts(ctx, id, start, end)
+		if err != nil {
+			return WorkloadReport{}, err
+		}
+		members := p.workloadRoster(id)
+		index := map[string]int{}
+		for i, m := range members {
+			index[m.ID] = i
+		}
+		for _, shift := range shifts {
+			if i, ok := index[shift.Responder.Handle]; ok {
+				members[i].OnCallHours += shift.End.Sub(shift.Start).Hours()
+			}
+		}
+		for _, page := range pages[id] {
+			for _, shift := range shifts {
+				if page.Before(shift.Start) || !page.Before(shift.End) {
+					continue
+				}
+				if i, ok := index[shift.Responder.Handle]; ok {
+					members[i].Pages++
+					if isNight(page, shift.Responder) {
+						members[i].NightPages++
+					}
+				}
+				break
+			}
+		}
+		for i := range members {
+			m := &members[i]
+			m.Incidents = sortedKeys(handled[m.ID])
+			m.IncidentsHandled = len(m.Incidents)
+			m.Tickets = append([]string{}, assigned[m.ID]...)
+			m.OpenTickets = len(m.Tickets)
+			m.OnCallHours = float64(int(m.OnCallHours*10)) / 10
+			scoreWorkload(m)
+		}
+		sort.SliceStable(members, func(i, j int) bool {
+			if members[i].Load != members[j].Load {
+				return members[i].Load > members[j].Load
+			}
+			return members[i].ID < members[j].ID
+		})
+		report.Members = append(report.Members, members...)
+	}
+	return report, nil
+}
+
+// workloadRoster lists the people on a team: its directory members, then the
+// responders the actor registry places on it.
+func (p *Provider) workloadRoster(teamID string) []MemberWorkload {
+	out := make([]MemberWorkload, 0)
+	seen := map[string]bool{}
+	for _, member := range p.members[teamID] {
+		out = append(out, MemberWorkload{ID: member.Handle, Name: member.Name, TeamID: teamID, MemberID: member.ID})
+		seen[member.Handle] = true
+	}
+	for _, actor := range mockutil.Actors(mockutil.ActorHuman) {
+		if actor.Team == teamID && !seen[actor.ID] {
+			out = append(out, MemberWorkload{ID: actor.ID, Name: actor.DisplayName, TeamID: teamID})
+		}
+	}
+	return out
+}
+
+// scoreWorkload sets a member's load, risk, and reasons from their stats.
+func scoreWorkload(m *MemberWorkload) {
+	m.Load = m.IncidentsHandled*3 + m.NightPages*2 + (m.Pages - m.NightPages) + m.OpenTickets
+	if m.IncidentsHandled >= burnoutIncidents {
+		m.Reasons = append(m.Reasons, fmt.Sprintf("worked %d incidents this week", m.IncidentsHandled))
+	}
+	if m.NightPages >= burnoutNightPages {
+		m.Reasons = append(m.Reasons, fmt.Sprintf("paged %d times at night", m.NightPages))
+	}
+	if m.OpenTickets >= burnoutOpenTickets {
+		m.Reasons = append(m.Reasons, fmt.Sprintf("%d open tickets", m.OpenTickets))
+	}
+	switch len(m.Reasons) {
+	case 0:
+		m.Risk = RiskLow
+	case 1:
+		m.Risk = RiskElevated
+	default:
+		m.Risk = RiskHigh
+	}
+}
+
+// incidentsHandled maps each actor ID to the incidents they added a timeline
+// entry to between start and end.
+func incidentsHandled(ctx context.Context, incidents incident.Provider, start, end time.Time) (map[string]map[string]bool, error) {
+	out := map[string]map[string]bool{}
+	if incidents == nil {
+		return out, nil
+	}
+	list, err := incidents.Query(ctx, schema.IncidentQuery{})
+	if err != nil {
+		return nil, err
+	}
+	for _, inc := range list {
+		if inc.UpdatedAt.Before(start) && inc.CreatedAt.Before(start) {
+			continue
+		}
+		entries, err := incidents.GetTimeline(ctx, inc.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range entries {
+			if entry.At.Before(start) || entry.At.After(end) {
+				continue
+			}
+			id, _ := entry.Actor["id"].(string)
+			if id == "" {
+				id, _ = entry.Actor["name"].(string)
+			}
+			if id == "" {
+				continue
+			}
+			if out[id] == nil {
+				out[id] = map[string]bool{}
+			}
+			out[id][inc.ID] = true
+		}
+	}
+	return out, nil
+}
+
+// openTickets maps each assignee to their open ticket IDs.
+func openTickets(ctx context.Context, tickets ticket.Provider) (map[string][]string, error) {
+	out := map[string][]string{}
+	if tickets == nil {
+		return out, nil
+	}
+	list, err := tickets.Query(ctx, schema.TicketQuery{})
+	if err != nil {
+		return nil, err
+	}
+	for _, tk := range list {
+		if closedTicketStatuses[tk.Status] {
+			continue
+		}
+		for _, assignee := range tk.Assignees {
+			out[assignee] = append(out[assignee], tk.ID)
+		}
+	}
+	return out, nil
+}
+
+// teamPages maps each team to the times its services paged between start and
+// end.
+func teamPages(ctx context.Context, alerts alert.Provider, start, end time.Time) (map[string][]time.Time, error) {
+	out := map[string][]time.Time{}
+	if alerts == nil {
+		return out, nil
+	}
+	list, err := alerts.Query(ctx, schema.AlertQuery{})
+	if err != nil {
+		return nil, err
+	}
+	for _, al := range list {
+		if !pagingSeverities[al.Severity] || al.CreatedAt.Before(start) || al.CreatedAt.After(end) {
+			continue
+		}
+		team, _ := al.Fields["team"].(string)
+		if team == "" {
+			team = mockutil.GetTeamForService(al.Service)
+		}
+		out[team] = append(out[team], al.CreatedAt)
+	}
+	return out, nil
+}
+
+// isNight reports whether t falls in the night hours where member lives,
+// falling back to UTC when their time zone is unknown.
+func isNight(t time.Time, member schema.TeamMember) bool {
+	loc := time.UTC
+	if tz, ok := member.Metadata["timezone"].(string); ok {
+		if l, err := time.LoadLocation(tz); err == nil {
+			loc = l
+		}
+	}
+	hour := t.In(loc).Hour()
+	return hour >= nightStart || hour < nightEnd
+}
+
+func sortedKeys(set map[string]bool) []string {
+	out := make([]string, 0, len(set))
+	for k := range set {
+		out = append(out, k)
+	}
+	sort.Strings(out)
+	return out
+}
@@ -0,0 +1,107 @@
+package ticketmock
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/opsorch/opsorch-core/schema"
+	"github.com/opsorch/opsorch-mock-adapters/internal/mockutil"
+)
+
+// atRiskWindow is how close to its due date an open ticket is flagged atRisk.
+const atRiskWindow = 24 * time.Hour
+
+// closedStatuses stop the due-date clock.
+var closedStatuses = map[string]bool{"done": true, "closed": true, "resolved": true}
+
+// agingActor is recorded on audit entries written when an overdue ticket
+// escalates.
+var agingActor = mockutil.ActorRef("sla-policy")
+
+// WithOverdueOnly restricts Query to open tickets past their due date.
+func WithOverdueOnly(ctx context.Context) context.Context {
+	return context.WithValue(ctx, overdueOnlyKey{}, true)
+}
+
+type overdueOnlyKey struct{}
+
+func overdueOnly(ctx context.Context) bool {
+	if ctx == nil {
+		return false
+	}
+	v, _ := ctx.Value(overdueOnlyKey{}).(bool)
+	return v
+}
+
+// ageLocked escalates every open P1 ticket that is past its due date to P0
+// and records an audit note stamped at the due date, so advancing the clock
+// past a due date escalates the ticket as of when it went overdue. Callers
+// must hold p.mu.
+func (p *Provider) ageLocked(now time.Time) {
+	if !p.cfg.Features.Lifecycle {
+		return
+	}
+	for id, tk := range p.tickets {
+		if closedStatuses[tk.Status] {
+			continue
+		}
+		due, ok := dueDate(tk)
+		if !ok || !now.After(due) {
+			continue
+		}
+		if priority, _ := tk.Fields["priority"].(string); priority != "P1" {
+			continue
+		}
+		tk.Fields = mockutil.CloneMap(tk.Fields)
+		tk.Fields["priority"] = "P0"
+		tk.Fields["escalatedAt"] = due.Format(time.RFC3339)
+		audit, _ := tk.Fields["auditLog"].([]map[string]any)
+		tk.Fields["auditLog"] = append(append([]map[string]any(nil), audit...), map[string]any{
+			"at":    due.Format(time.RFC3339),
+			"actor": agingActor,
+			"field": "priority",
+			"from":  "P1",
+			"to":    "P0",
+			"note":  fmt.Sprintf("Priority escalated from P1 to P0: overdue since %s", due.Format(time.RFC3339)),
+		})
+		if due.After(tk.UpdatedAt) {
+			tk.UpdatedAt = due
+		}
+		p.tickets[id] = tk
+	}
+}
+
+// applyAging stamps derived due-date fields on an outgoing ticket copy.
+func applyAging(tk *schema.Ticket, now time.Time) {
+	due, ok := dueDate(*tk)
+	if !ok || closedStatuses[tk.Status] {
+		return
+	}
+	overdue := now.After(due)
+	tk.Fields["overdue"] = overdue
+	tk.Fields["atRisk"] = !overdue && due.Sub(now) <= atRiskWindow
+	if overdue {
+		tk.Fields["overdueBy"] = now.Sub(due).Round(time.Minute).String()
+	}
+}
+
+// isOverdue reports whether an open ticket is past its due date at now.
+func isOverdue(tk schema.Ticket, now time.Time) bool {
+	due, ok := dueDate(tk)
+	return ok && !closedStatuses[tk.Status] && now.After(due)
+}
+
+// dueDate reads Fields["dueDate"], whether seeded as a time.Time or set by a
+// client as an RFC 3339 string.
+func dueDate(tk schema.Ticket) (time.Time, bool) {
+	switch v := tk.Fields["dueDate"].(type) {
+	case time.Time:
+		return v, !v.IsZero()
+	case string:
+		t, err := time.Parse(time.RFC3339, v)
+		return t, err == nil
+	default:
+		return time.Time{}, false
+	}
+}
@@ -0,0 +1,146 @@
+package ticketmock
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/opsorch/opsorch-core/schema"
+)
+
+// historySprints is how many completed sprints the seeded backlog covers.
+// Sprints follow the seed naming: "2024-12-a" runs from the 1st to the 14th,
+// "2024-12-b" from the 15th to the end of the month.
+const historySprints = 12
+
+// historyTeam is a team whose completed work is seeded, with the service it
+// owns, its members, and its usual throughput per sprint.
+type historyTeam struct {
+	team      string
+	service   string
+	members   []string
+	perSprint int
+}
+
+var historyTeams = []historyTeam{
+	{team: "team-velocity", service: "svc-checkout", members: []string{"alex", "kim", "jordan"}, perSprint: 5},
+	{team: "team-revenue", service: "svc-payments", members: []string{"sam", "casey"}, perSprint: 4},
+	{team: "team-aurora", service: "svc-search", members: []string{"jamie", "taylor"}, perSprint: 3},
+	{team: "team-signal", service: "svc-notifications", members: []string{"lee", "taylor"}, perSprint: 3},
+	{team: "team-guardian", service: "svc-identity", members: []string{"devon"}, perSprint: 2},
+	{team: "team-orion", service: "svc-recommendation", members: []string{"riley", "milo"}, perSprint: 3},
+	{team: "team-foundry", service: "svc-warehouse", members: []string{"morgan"}, perSprint: 2},
+	{team: "team-nova", service: "svc-realtime", members: []string{"samir"}, perSprint: 2},
+	{team: "team-lumen", service: "svc-analytics", members: []string{"maya"}, perSprint: 2},
+}
+
+// historyWork are the title formats of completed work items; %s is the service.
+var historyWork = []struct {
+	title    string
+	kind     string
+	priority string
+}{
+	{"Add p99 latency alert for %s", "task", "P2"},
+	{"Fix flaky integration test in %s", "bug", "P3"},
+	{"Document %s failover runbook", "task", "P3"},
+	{"Tune %s autoscaling thresholds", "task", "P2"},
+	{"Add tracing spans to %s request handlers", "story", "P2"},
+	{"Remove deprecated v1 endpoints from %s", "story", "P3"},
+	{"Rotate %s service credentials", "task", "P1"},
+	{"Investigate %s memory growth", "bug", "P1"},
+	{"Add SLO panels to %s dashboard", "task", "P3"},
+	{"Add canary analysis to %s deploy pipeline", "story", "P2"},
+	{"Fix retry storm in %s client", "bug", "P1"},
+	{"Upgrade %s base image", "task", "P3"},
+	{"Cache hot lookups in %s", "story", "P2"},
+	{"Reduce %s cold start time", "story", "P2"},
+	{"Follow-up actions from %s postmortem", "task", "P1"},
+}
+
+// storyPointScale holds the point sizes a team estimates with.
+var storyPointScale = []int{1, 2, 3, 5, 8}
+
+// sprintWindow returns the name, start, and end of the sprint containing t.
+func sprintWindow(t time.Time) (string, time.Time, time.Time) {
+	t = t.UTC()
+	if t.Day() < 15 {
+		start := time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+		return fmt.Sprintf("%04d-%02d-a", t.Year(), t.Month()), start, start.AddDate(0, 0, 14)
+	}
+	start := time.Date(t.Year(), t.Month(), 15, 0, 0, 0, 0, time.UTC)
+	return fmt.Sprintf("%04d-%02d-b", t.Year(), t.Month()), start, time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+}
+
+// historicalTickets builds completed tickets for the sprints before the one
+// containing now. Each carries its sprint, story points, and cycle time (from
+// start of work to completion) so velocity and throughput can be charted.
+// About one in nine is closed without being done.
+func historicalTickets(source string, now time.Time) []schema.Ticket {
+	var out []schema.Ticket
+	_, sprintStart, _ := sprintWindow(now)
+	n := 0
+	for s := 0; s < historySprints; s++ {
+		sprint, start, end := sprintWindow(sprintStart.Add(-time.Hour))
+		sprintStart = start
+		span := end.Sub(start)
+		for ti, team := range historyTeams {
+			// Throughput drifts by a ticket or two from sprint to sprint.
+			count := team.perSprint + (s+ti)%3 - 1
+			for k := 0; k < count; k++ {
+				n++
+				work := historyWork[(n*7+ti)%len(historyWork)]
+				points := storyPointScale[(n*3+s)%len(storyPointScale)]
+				assignee := team.members[(n+k)%len(team.members)]
+
+				// Bigger items take longer; cycle time is roughly a working day
+				// per point with some spread.
+				cycle := time.Duration(points*8+(n*13)%20) * time.Hour
+				completedAt := start.Add(span * time.Duration(3+(n*5)%7) / 10).Add(time.Duration(9+n%8) * time.Hour)
+				if completedAt.After(end) {
+					completedAt = end.Add(-time.Hour)
+				}
+				startedAt := completedAt.Add(-cycle)
+				createdAt := startedAt.Add(-time.Duration(1+(n*11)%14) * 24 * time.Hour)
+
+				status, resolution := "done", "done"
+				if n%9 == 0 {
+					status = "closed"
+					resolution = []string{"won't do", "duplicate", "cannot reproduce"}[(n/9)%3]
+				}
+				id := fmt.Sprintf("TCK-HIST-%03d", n)
+				out = append(out, schema.Ticket{
+					ID:          id,
+					Key:         id,
+					Title:       fmt.Sprintf(work.title, team.service),
+					Description: fmt.Sprintf("Planned for sprint %s by %s.", sprint, team.team),
+					Status:      status,
+					Assignees:   []string{assignee},
+					Reporter:    team.members[0],
+					CreatedAt:   createdAt,
+					UpdatedAt:   completedAt,
+					Fields: map[string]any{
+						"service":        team.service,
+						"environment":    "prod",
+						"team":           team.team,
+						"priority":       work.priority,
+						"type":           work.kind,
+						"sprint":         sprint,
+						"storyPoints":    points,
+						"resolution":     resolution,
+						"startedAt":      startedAt.Format(time.RFC3339),
+						"completedAt":    completedAt.Format(time.RFC3339),
+						"cycleTimeHours": int(cycle.Hours()),
+						"historical":     true,
+					},
+					Metadata: map[string]any{"source": source},
+				})
+			}
+		}
+	}
+	return out
+}
+
+// isHistoricalTicket reports whether tk is part of the completed backlog.
+func isHistoricalTicket(tk schema.Ticket) bool {
+	historical, _ := tk.Fields["historical"].(bool)
+	return historical
+}
@@ -0,0 +1,54 @@
+package ticketmock
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/opsorch/opsorch-core/schema"
+	"github.com/opsorch/opsorch-mock-adapters/internal/mockutil"
+)
+
+// RegisterOperatorTasks registers the provider's operator tasks on op.
+func (p *Provider) RegisterOperatorTasks(op *mockutil.Operator) {
+	op.Register("ticket.close", p.operatorClose)
+}
+
+// operatorClose is the simulated operator's ticket task: close the ticket
+// that has sat in review the longest, as a reviewer working down the queue
+// would.
+func (p *Provider) operatorClose(ctx context.Context, actor string) (mockutil.OperatorAction, bool, error) {
+	p.mu.Lock()
+	candidates := make([]schema.Ticket, 0)
+	for _, tk := range p.tickets {
+		if tk.Status == "in_review" {
+			candidates = append(candidates, tk)
+		}
+	}
+	p.mu.Unlock()
+	if len(candidates) == 0 {
+		return mockutil.OperatorAction{}, false, nil
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if !candidates[i].UpdatedAt.Equal(candidates[j].UpdatedAt) {
+			return candidates[i].UpdatedAt.Before(candidates[j].UpdatedAt)
+		}
+		return candidates[i].ID < candidates[j].ID
+	})
+
+	tk := candidates[0]
+	status := "done"
+	fields := mockutil.CloneMap(tk.Fields)
+	if fields == nil {
+		fields = map[string]any{}
+	}
+	fields["resolution"] = "done"
+	fields["closedBy"] = actor
+	if _, err := p.Update(ctx, tk.ID, schema.UpdateTicketInput{Status: &status, Fields: fields}); err != nil {
+		return mockutil.OperatorAction{}, false, err
+	}
+	return mockutil.OperatorAction{
+		Ref:     mockutil.FormatRef(mockutil.RefTicket, tk.ID),
+		Summary: fmt.Sprintf("Closed %s: %s", tk.ID, tk.Title),
+	}, true, nil
+}
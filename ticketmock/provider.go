@@ -3,7 +3,6 @@ package ticketmock
 import (
 	"context"
 	"fmt"
-	"reflect"
 	"sort"
 	"strings"
 	"sync"
@@ -12,6 +11,7 @@ import (
 	"github.com/opsorch/opsorch-core/orcherr"
 	"github.com/opsorch/opsorch-core/schema"
 	coreticket "github.com/opsorch/opsorch-core/ticket"
+	"github.com/opsorch/opsorch-mock-adapters/internal/bundle"
 	"github.com/opsorch/opsorch-mock-adapters/internal/mockutil"
 )
 
@@ -21,6 +21,12 @@ const ProviderName = "mock"
 // Config controls mock ticket metadata.
 type Config struct {
 	Source string
+	// Warmup generates the completed-ticket backlog in New instead of on
+	// first access.
+	Warmup bool
+	// Features switches realism behaviours off individually; see
+	// mockutil.Features.
+	Features mockutil.Features
 }
 
 // Provider holds in-memory tickets to support demo flows.
@@ -29,13 +35,34 @@ type Provider struct {
 	mu      sync.Mutex
 	nextID  int
 	tickets map[string]schema.Ticket
+
+	// history generates the completed backlog on first access, relative to
+	// seededAt.
+	history  *mockutil.LazySeed
+	seededAt time.Time
+
+	clock func() time.Time
 }
 
 // New constructs the mock ticket provider with seeded work items.
 func New(cfg map[string]any) (coreticket.Provider, error) {
 	parsed := parseConfig(cfg)
 	p := &Provider{cfg: parsed, tickets: map[string]schema.Ticket{}}
-	p.seed()
+	p.history = mockutil.NewLazySeed("ticket", "history", p.seedHistoryLocked)
+	mockutil.TimeSeed("ticket", "tickets", false, func() int {
+		p.seed()
+		return len(p.tickets)
+	})
+	snapshot, err := bundle.FromConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	p.restore(snapshot)
+	if parsed.Warmup {
+		p.history.Warm()
+	}
+	mockutil.RegisterResolver(mockutil.RefTicket, func(ctx context.Context, id string) (any, error) { return p.Get(ctx, id) })
+	mockutil.RegisterSearchSource(mockutil.RefTicket, p.SearchDocs)
 	return p, nil
 }
 
@@ -84,7 +111,13 @@ func isScenarioTicket(metadata map[string]any, fields map[string]any) bool {
 
 // Query returns tickets that match the provided filters.
 func (p *Provider) Query(ctx context.Context, query schema.TicketQuery) ([]schema.Ticket, error) {
-	_ = ctx
+	filter, err := mockutil.FilterFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	filter = filter.And(mockutil.MetadataFilter(query.Metadata))
+
+	onlyOverdue := overdueOnly(ctx)
 
 	p.mu.Lock()
 	defer p.mu.Unlock()
@@ -96,14 +129,30 @@ func (p *Provider) Query(ctx context.Context, query schema.TicketQuery) ([]schem
 		p.tickets[st.ID] = st
 	}
 
+	if len(query.Statuses) > 0 {
+		p.history.Ensure()
+	}
+	now = p.now()
+	p.ageLocked(now)
 	ids := sortedTicketIDs(p.tickets)
 	results := make([]schema.Ticket, 0, len(p.tickets))
 	for _, id := range ids {
 		tk := p.tickets[id]
-		if !matchesTicket(query, tk) {
+		// The completed backlog would swamp active work, so it is only
+		// listed (and generated) when the query asks for statuses explicitly.
+		if isHistoricalTicket(tk) && len(query.Statuses) == 0 {
+			continue
+		}
+		if !matchesTicket(query, tk) || !filter.Match(tk) {
+			continue
+		}
+		if onlyOverdue && !isOverdue(tk, now) {
 			continue
 		}
-		results = append(results, cloneTicket(tk))
+		out := cloneTicket(tk)
+		applyAging(&out, now)
+		applyAssigneeActors(&out)
+		results = append(results, out)
 		if query.Limit > 0 && len(results) >= query.Limit {
 			break
 		}
@@ -117,11 +166,16 @@ func (p *Provider) Get(ctx context.Context, id string) (schema.Ticket, error) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
-	tk, ok := p.tickets[id]
+	now := p.now()
+	p.ageLocked(now)
+	tk, ok := p.ticketLocked(id)
 	if !ok {
 		return schema.Ticket{}, orcherr.New("not_found", "ticket not found", nil)
 	}
-	return cloneTicket(tk), nil
+	out := cloneTicket(tk)
+	applyAging(&out, now)
+	applyAssigneeActors(&out)
+	return out, nil
 }
 
 // Create inserts a new ticket.
@@ -129,9 +183,13 @@ func (p *Provider) Create(ctx context.Context, in schema.CreateTicketInput) (sch
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
+	return p.createLocked(in, time.Now().UTC()), nil
+}
+
+// createLocked stores a new ticket. Callers must hold p.mu.
+func (p *Provider) createLocked(in schema.CreateTicketInput, now time.Time) schema.Ticket {
 	p.nextID++
 	id := fmt.Sprintf("TCK-%03d", p.nextID)
-	now := time.Now().UTC()
 
 	tk := schema.Ticket{
 		ID:          id,
@@ -148,9 +206,10 @@ func (p *Provider) Create(ctx context.Context, in schema.CreateTicketInput) (sch
 		tk.Metadata = map[string]any{}
 	}
 	tk.Metadata["source"] = p.cfg.Source
+	mockutil.LinkRefs(tk.Metadata, tk.Fields)
 
 	p.tickets[id] = tk
-	return cloneTicket(tk), nil
+	return cloneTicket(tk)
 }
 
 // Update mutates ticket fields.
@@ -158,7 +217,7 @@ func (p *Provider) Update(ctx context.Context, id string, in schema.UpdateTicket
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
-	tk, ok := p.tickets[id]
+	tk, ok := p.ticketLocked(id)
 	if !ok {
 		return schema.Ticket{}, orcherr.New("not_found", "ticket not found", nil)
 	}
@@ -182,6 +241,7 @@ func (p *Provider) Update(ctx context.Context, id string, in schema.UpdateTicket
 		tk.Metadata = mockutil.CloneMap(in.Metadata)
 	}
 	tk.UpdatedAt = time.Now().UTC()
+	mockutil.LinkRefs(tk.Metadata, tk.Fields)
 
 	p.tickets[id] = tk
 	return cloneTicket(tk), nil
@@ -379,12 +439,45 @@ func (p *Provider) seed() {
 	}
 
 	for _, tk := range seed {
-		applyTicketFlair(&tk, now)
+		if p.cfg.Features.Flair {
+			applyTicketFlair(&tk, now)
+		}
+		linkExternal(&tk)
 		p.tickets[tk.ID] = tk
 		if n, err := fmt.Sscanf(tk.ID, "TCK-%d", &p.nextID); n == 1 && err == nil {
 			// keep last parsed id
 		}
 	}
+	p.seededAt = now
+}
+
+// seedHistoryLocked adds the completed backlog and returns how many tickets it
+// added. Callers must hold p.mu once the provider is shared.
+func (p *Provider) seedHistoryLocked() int {
+	history := historicalTickets(p.cfg.Source, p.seededAt)
+	for _, tk := range history {
+		p.tickets[tk.ID] = tk
+	}
+	return len(history)
+}
+
+// ticketLocked looks up a ticket, generating the backlog first when id is not
+// among the eagerly seeded tickets. Callers must hold p.mu.
+func (p *Provider) ticketLocked(id string) (schema.Ticket, bool) {
+	tk, ok := p.tickets[id]
+	if !ok {
+		p.history.Ensure()
+		tk, ok = p.tickets[id]
+	}
+	return tk, ok
+}
+
+// now returns the provider's current time, used for due-date aging.
+func (p *Provider) now() time.Time {
+	if p.clock != nil {
+		return p.clock()
+	}
+	return time.Now().UTC()
 }
 
 func parseConfig(cfg map[string]any) Config {
@@ -392,6 +485,8 @@ func parseConfig(cfg map[string]any) Config {
 	if v, ok := cfg["source"].(string); ok && v != "" {
 		out.Source = v
 	}
+	out.Warmup = mockutil.ParseWarmup(cfg)
+	out.Features = mockutil.ParseFeatures(cfg)
 	return out
 }
 
@@ -418,6 +513,7 @@ func applyTicketFlair(tk *schema.Ticket, now time.Time) {
 	if len(tk.Assignees) > 0 {
 		tk.Metadata["lastUpdatedBy"] = tk.Assignees[0]
 	}
+	mockutil.LinkRefs(tk.Metadata, tk.Fields)
 }
 
 func serviceLinks(service string) []string {
@@ -497,6 +593,18 @@ func ticketServiceKey(service string) string {
 	return strings.TrimPrefix(service, "svc-")
 }
 
+// applyAssigneeActors resolves assignee handles to their registry identities
+// in Fields["assigneeActors"] on an outgoing ticket copy.
+func applyAssigneeActors(tk *schema.Ticket) {
+	if len(tk.Assignees) == 0 {
+		return
+	}
+	if tk.Fields == nil {
+		tk.Fields = map[string]any{}
+	}
+	tk.Fields["assigneeActors"] = mockutil.ActorRefs(tk.Assignees)
+}
+
 func cloneTicket(in schema.Ticket) schema.Ticket {
 	// Generate URL if not already present
 	url := in.URL
@@ -546,9 +654,6 @@ func matchesTicket(query schema.TicketQuery, tk schema.Ticket) bool {
 	if query.Reporter != "" && query.Reporter != tk.Reporter {
 		return false
 	}
-	if len(query.Metadata) > 0 && !matchesMetadata(query.Metadata, tk.Metadata) {
-		return false
-	}
 
 	return true
 }
@@ -597,21 +702,6 @@ func matchesAssignees(filter []string, assignees []string) bool {
 	return false
 }
 
-func matchesMetadata(filter map[string]any, metadata map[string]any) bool {
-	if len(filter) == 0 {
-		return true
-	}
-	if len(metadata) == 0 {
-		return false
-	}
-	for k, v := range filter {
-		if !reflect.DeepEqual(metadata[k], v) {
-			return false
-		}
-	}
-	return true
-}
-
 func matchesQuery(filter string, tk schema.Ticket) bool {
 	if filter == "" {
 		return true
@@ -635,7 +725,7 @@ func matchesQuery(filter string, tk schema.Ticket) bool {
 
 // getScenarioTickets returns static scenario-themed tickets
 func getScenarioTickets(now time.Time) []schema.Ticket {
-	return []schema.Ticket{
+	tickets := []schema.Ticket{
 		{
 			ID:          "TCK-SCENARIO-001",
 			Key:         "TCK-SCENARIO-001",
@@ -870,6 +960,29 @@ func getScenarioTickets(now time.Time) []schema.Ticket {
 			},
 		},
 	}
+	for i := range tickets {
+		mockutil.LinkRefs(tickets[i].Metadata, tickets[i].Fields)
+	}
+	return tickets
 }
 
 var _ coreticket.Provider = (*Provider)(nil)
+
+// SearchDocs indexes ticket titles and descriptions for global search,
+// including the generated backlog.
+func (p *Provider) SearchDocs(ctx context.Context) []mockutil.SearchDoc {
+	_ = ctx
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.history.Ensure()
+	docs := make([]mockutil.SearchDoc, 0, len(p.tickets))
+	for _, tk := range p.tickets {
+		service, _ := tk.Fields["service"].(string)
+		docs = append(docs, mockutil.SearchDoc{
+			Kind: mockutil.RefTicket, ID: tk.ID, Title: tk.Title, Body: tk.Description,
+			Service: service, Status: tk.Status, UpdatedAt: tk.UpdatedAt,
+		})
+	}
+	return docs
+}
@@ -2,11 +2,15 @@ package ticketmock
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/opsorch/opsorch-core/schema"
+	"github.com/opsorch/opsorch-mock-adapters/internal/mockutil"
+	"github.com/opsorch/opsorch-mock-adapters/internal/pluginrpc"
 )
 
 func TestGetSeededTickets(t *testing.T) {
@@ -390,3 +394,326 @@ func TestTicketURLGeneration(t *testing.T) {
 		}
 	}
 }
+
+func TestCreateFromIncidentTemplates(t *testing.T) {
+	provAny, err := New(map[string]any{})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	prov := provAny.(*Provider)
+
+	inc := schema.Incident{
+		ID:       "inc-003",
+		Title:    "Payments webhook timeouts from Stripe",
+		Severity: "sev1",
+		Service:  "svc-payments",
+		URL:      "https://pagerduty.demo.com/incidents/inc-003",
+		Fields:   map[string]any{"team": "team-revenue", "environment": "prod"},
+	}
+
+	tk, err := prov.CreateFromIncident(context.Background(), "postmortem", inc)
+	if err != nil {
+		t.Fatalf("CreateFromIncident returned error: %v", err)
+	}
+	if tk.Title != "Postmortem: Payments webhook timeouts from Stripe" {
+		t.Fatalf("unexpected title %q", tk.Title)
+	}
+	if tk.Fields["service"] != "svc-payments" || tk.Fields["team"] != "team-revenue" || tk.Fields["priority"] != "P0" {
+		t.Fatalf("fields not pre-filled from incident: %v", tk.Fields)
+	}
+	related, _ := tk.Metadata["relatedIncidents"].([]string)
+	if len(related) != 1 || related[0] != "inc-003" {
+		t.Fatalf("expected related incident link, got %v", tk.Metadata["relatedIncidents"])
+	}
+	if tk.Metadata["source"] == nil {
+		t.Fatalf("expected source metadata on created ticket")
+	}
+
+	remediation, err := prov.CreateFromIncident(context.Background(), "remediation", schema.Incident{ID: "inc-004", Service: "svc-notifications"})
+	if err != nil {
+		t.Fatalf("CreateFromIncident returned error: %v", err)
+	}
+	if remediation.Fields["team"] != "team-signal" {
+		t.Fatalf("expected team inferred from service, got %v", remediation.Fields["team"])
+	}
+	if remediation.ID == tk.ID {
+		t.Fatalf("expected distinct ticket IDs")
+	}
+
+	if _, err := prov.CreateFromIncident(context.Background(), "unknown", inc); err == nil {
+		t.Fatalf("expected error for unknown template")
+	}
+	if len(Templates()) != 2 {
+		t.Fatalf("expected 2 templates, got %d", len(Templates()))
+	}
+}
+
+func TestQueryFilterExpression(t *testing.T) {
+	provAny, _ := New(nil)
+	prov := provAny.(*Provider)
+
+	ctx := mockutil.WithFilter(context.Background(), `metadata.epic == "PAY-121" && fields.priority in ["P0","P1"]`)
+	tickets, err := prov.Query(ctx, schema.TicketQuery{})
+	if err != nil {
+		t.Fatalf("Query returned error: %v", err)
+	}
+	if len(tickets) == 0 {
+		t.Fatal("expected PAY-121 tickets")
+	}
+	for _, tk := range tickets {
+		if tk.Metadata["epic"] != "PAY-121" || (tk.Fields["priority"] != "P0" && tk.Fields["priority"] != "P1") {
+			t.Fatalf("ticket %s does not match filter: %v %v", tk.ID, tk.Metadata, tk.Fields["priority"])
+		}
+	}
+
+	// The Metadata map is evaluated by the same engine, so JSON numbers match ints.
+	byMap, err := prov.Query(context.Background(), schema.TicketQuery{Metadata: map[string]any{"epic": "PAY-121"}})
+	if err != nil || len(byMap) < len(tickets) {
+		t.Fatalf("expected metadata map to match at least %d tickets, got %d (%v)", len(tickets), len(byMap), err)
+	}
+
+	if _, err := prov.Query(mockutil.WithFilter(context.Background(), `metadata.epic ==`), schema.TicketQuery{}); err == nil || !strings.Contains(err.Error(), "bad_request") {
+		t.Fatalf("expected bad_request for malformed filter, got %v", err)
+	}
+}
+
+func TestHistoricalTickets(t *testing.T) {
+	provAny, err := New(map[string]any{})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	prov := provAny.(*Provider)
+	ctx := context.Background()
+
+	active, err := prov.Query(ctx, schema.TicketQuery{})
+	if err != nil {
+		t.Fatalf("Query returned error: %v", err)
+	}
+	for _, tk := range active {
+		if isHistoricalTicket(tk) {
+			t.Fatalf("historical ticket %s listed without a status filter", tk.ID)
+		}
+	}
+
+	done, err := prov.Query(ctx, schema.TicketQuery{Statuses: []string{"done", "closed"}})
+	if err != nil {
+		t.Fatalf("Query returned error: %v", err)
+	}
+	if len(done) < 200 {
+		t.Fatalf("expected a few hundred completed tickets, got %d", len(done))
+	}
+	currentSprint, _, _ := sprintWindow(time.Now())
+	sprints := map[string]int{}
+	closed := 0
+	for _, tk := range done {
+		sprint, _ := tk.Fields["sprint"].(string)
+		if sprint == "" || sprint == currentSprint {
+			t.Fatalf("ticket %s not in a past sprint: %q", tk.ID, sprint)
+		}
+		completedAt, err := time.Parse(time.RFC3339, tk.Fields["completedAt"].(string))
+		if err != nil {
+			t.Fatalf("ticket %s has bad completedAt: %v", tk.ID, err)
+		}
+		if name, _, _ := sprintWindow(completedAt); name != sprint {
+			t.Fatalf("ticket %s completed at %s outside sprint %s", tk.ID, completedAt, sprint)
+		}
+		if tk.Fields["cycleTimeHours"].(int) <= 0 || tk.Fields["storyPoints"].(int) <= 0 || !tk.CreatedAt.Before(completedAt) {
+			t.Fatalf("ticket %s has implausible cycle data: %+v", tk.ID, tk.Fields)
+		}
+		if tk.Status == "closed" {
+			closed++
+		}
+		sprints[sprint]++
+	}
+	if len(sprints) != historySprints || closed == 0 {
+		t.Fatalf("expected %d sprints and some closed tickets, got %d sprints, %d closed", historySprints, len(sprints), closed)
+	}
+
+	if _, err := prov.Get(ctx, done[0].ID); err != nil {
+		t.Fatalf("Get on historical ticket returned error: %v", err)
+	}
+}
+
+func TestDueDateAgingEscalatesOverdueP1(t *testing.T) {
+	provAny, err := New(map[string]any{})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	prov := provAny.(*Provider)
+	ctx := context.Background()
+	start := prov.seededAt
+
+	// TCK-009 is an in_review P1 due 12h after seeding.
+	prov.clock = func() time.Time { return start }
+	tk, err := prov.Get(ctx, "TCK-009")
+	if err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+	if tk.Fields["atRisk"] != true || tk.Fields["overdue"] != false || tk.Fields["priority"] != "P1" {
+		t.Fatalf("expected at-risk P1 before the due date, got %+v", tk.Fields)
+	}
+	overdue, err := prov.Query(WithOverdueOnly(ctx), schema.TicketQuery{})
+	if err != nil {
+		t.Fatalf("Query returned error: %v", err)
+	}
+	if len(overdue) != 0 {
+		t.Fatalf("expected no overdue tickets at seeding, got %d", len(overdue))
+	}
+
+	prov.clock = func() time.Time { return start.Add(13 * time.Hour) }
+	overdue, err = prov.Query(WithOverdueOnly(ctx), schema.TicketQuery{})
+	if err != nil {
+		t.Fatalf("Query returned error: %v", err)
+	}
+	ids := map[string]schema.Ticket{}
+	for _, tk := range overdue {
+		if tk.Fields["overdue"] != true || tk.Fields["atRisk"] != false {
+			t.Fatalf("ticket %s listed as overdue without the flag: %+v", tk.ID, tk.Fields)
+		}
+		ids[tk.ID] = tk
+	}
+	// TCK-002 and TCK-006 are the in_review P2s; they go overdue without escalating.
+	if len(ids) != 3 || ids["TCK-002"].Fields["priority"] != "P2" {
+		t.Fatalf("expected the three in_review tickets overdue, got %v", ids)
+	}
+	escalated := ids["TCK-009"]
+	if escalated.Fields["priority"] != "P0" {
+		t.Fatalf("expected overdue P1 escalated to P0, got %v", escalated.Fields["priority"])
+	}
+	audit, _ := escalated.Fields["auditLog"].([]map[string]any)
+	if len(audit) != 1 || audit[0]["to"] != "P0" || !strings.Contains(audit[0]["note"].(string), "overdue") {
+		t.Fatalf("expected one escalation audit note, got %v", escalated.Fields["auditLog"])
+	}
+
+	// Escalation is recorded once, however often the ticket is read.
+	again, err := prov.Get(ctx, "TCK-009")
+	if err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+	if audit, _ := again.Fields["auditLog"].([]map[string]any); len(audit) != 1 {
+		t.Fatalf("expected a single audit note after re-reading, got %d", len(audit))
+	}
+}
+
+func TestFeatureSwitches(t *testing.T) {
+	ctx := context.Background()
+	plainAny, _ := New(map[string]any{"flair": false})
+	tk, err := plainAny.Get(ctx, "TCK-009")
+	if err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+	for _, key := range []string{"links", "checklist", "dueDate", "overdue"} {
+		if _, ok := tk.Fields[key]; ok {
+			t.Fatalf("expected no %s with flair off, got %v", key, tk.Fields[key])
+		}
+	}
+
+	provAny, _ := New(map[string]any{"lifecycle": false})
+	prov := provAny.(*Provider)
+	start := prov.seededAt
+	prov.clock = func() time.Time { return start.Add(13 * time.Hour) }
+	tk, _ = prov.Get(ctx, "TCK-009")
+	if tk.Fields["overdue"] != true || tk.Fields["priority"] != "P1" {
+		t.Fatalf("expected an overdue P1 left unescalated with lifecycle off, got %v / %v", tk.Fields["overdue"], tk.Fields["priority"])
+	}
+}
+
+// BenchmarkQuery lists 1k, 10k, and 100k tickets cloned from a seed and
+// encodes them the way a plugin returns them.
+func BenchmarkQuery(b *testing.B) {
+	for _, n := range []int{1000, 10000, 100000} {
+		b.Run(fmt.Sprintf("tickets=%d", n), func(b *testing.B) {
+			provAny, _ := New(nil)
+			prov := provAny.(*Provider)
+			base := prov.tickets["TCK-001"]
+			for i := 0; i < n; i++ {
+				tk := cloneTicket(base)
+				tk.ID = fmt.Sprintf("TCK-B%06d", i)
+				tk.Key = tk.ID
+				prov.tickets[tk.ID] = tk
+			}
+			ctx := context.Background()
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				out, err := prov.Query(ctx, schema.TicketQuery{})
+				if err != nil {
+					b.Fatalf("Query returned error: %v", err)
+				}
+				if _, err := json.Marshal(pluginrpc.Response{Result: out}); err != nil {
+					b.Fatalf("marshal: %v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestSyncWithExternalTracker(t *testing.T) {
+	provAny, err := New(nil)
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	prov := provAny.(*Provider)
+	ctx := context.Background()
+
+	tk, _ := prov.Get(ctx, "TCK-003")
+	if tk.Fields["externalKey"] != "OPS-403" || tk.Fields["syncStatus"] != SyncStatusSynced {
+		t.Fatalf("expected TCK-003 mirrored as OPS-403, got %v", tk.Fields)
+	}
+	result, err := prov.Sync(ctx, SyncRequest{})
+	if err != nil {
+		t.Fatalf("Sync returned error: %v", err)
+	}
+	if len(result.Synced) != 10 || len(result.Pushed) != 0 || len(result.Conflicts) != 0 {
+		t.Fatalf("expected a clean sync of the seeded tickets, got %+v", result)
+	}
+	tk, _ = prov.Get(ctx, "TCK-003")
+
+	title := "Checkout circuit breaker postmortem (draft)"
+	if _, err := prov.Update(ctx, "TCK-001", schema.UpdateTicketInput{Title: &title}); err != nil {
+		t.Fatalf("Update returned error: %v", err)
+	}
+	events := mockutil.EventsSince(0, EventSyncConflict)
+	var after int64
+	if len(events) > 0 {
+		after = events[len(events)-1].Seq
+	}
+	result, err = prov.Sync(ctx, SyncRequest{ConflictIDs: []string{"TCK-003"}})
+	if err != nil {
+		t.Fatalf("Sync returned error: %v", err)
+	}
+	if len(result.Pushed) != 1 || result.Pushed[0] != "TCK-001" {
+		t.Fatalf("expected the local edit pushed, got %+v", result.Pushed)
+	}
+	if len(result.Conflicts) != 1 || result.Conflicts[0].TicketID != "TCK-003" || result.Conflicts[0].Local == result.Conflicts[0].Remote {
+		t.Fatalf("expected one injected conflict on TCK-003, got %+v", result.Conflicts)
+	}
+	if got := mockutil.EventsSince(after, EventSyncConflict); len(got) != 1 {
+		t.Fatalf("expected one %s event, got %d", EventSyncConflict, len(got))
+	}
+
+	// The conflict sticks until the ticket is updated locally.
+	conflicted, _ := prov.Get(ctx, "TCK-003")
+	if conflicted.Fields["syncStatus"] != SyncStatusConflict || conflicted.Fields["lastSyncedAt"] != tk.Fields["lastSyncedAt"] {
+		t.Fatalf("expected TCK-003 in conflict since its last sync, got %v", conflicted.Fields)
+	}
+	if result, _ := prov.Sync(ctx, SyncRequest{IDs: []string{"TCK-003"}}); len(result.Conflicts) != 1 {
+		t.Fatalf("expected the conflict to persist, got %+v", result)
+	}
+	status := "in_review"
+	if _, err := prov.Update(ctx, "TCK-003", schema.UpdateTicketInput{Status: &status}); err != nil {
+		t.Fatalf("Update returned error: %v", err)
+	}
+	result, _ = prov.Sync(ctx, SyncRequest{IDs: []string{"TCK-003"}})
+	if len(result.Conflicts) != 0 || len(result.Pushed) != 1 {
+		t.Fatalf("expected the local update to resolve the conflict, got %+v", result)
+	}
+
+	if result, _ := prov.Sync(ctx, SyncRequest{Conflicts: 2}); len(result.Conflicts) != 2 || result.Conflicts[0].TicketID != "TCK-001" {
+		t.Fatalf("expected two conflicts from the first tickets, got %+v", result.Conflicts)
+	}
+	created, _ := prov.Create(ctx, schema.CreateTicketInput{Title: "Local only"})
+	if _, err := prov.Sync(ctx, SyncRequest{IDs: []string{created.ID}}); err == nil || !strings.Contains(err.Error(), "bad_request") {
+		t.Fatalf("expected bad_request syncing an unlinked ticket, got %v", err)
+	}
+}
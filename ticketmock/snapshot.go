@@ -0,0 +1,50 @@
+package ticketmock
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/opsorch/opsorch-core/schema"
+	"github.com/opsorch/opsorch-mock-adapters/internal/bundle"
+)
+
+// Snapshot returns the provider's tickets sorted by ID for export.
+func (p *Provider) Snapshot() []schema.Ticket {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.history.Ensure()
+	out := make([]schema.Ticket, 0, len(p.tickets))
+	for _, tk := range p.tickets {
+		out = append(out, cloneTicket(tk))
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return out
+}
+
+// Restore replaces the provider's tickets with a bundle's while it is
+// running, as the snapshot config does at startup. Sections the bundle leaves
+// empty keep their current records.
+func (p *Provider) Restore(b *bundle.Bundle) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.restore(b)
+}
+
+// restore replaces the seeded tickets with a bundle's and continues ticket
+// numbering after the highest restored ID.
+func (p *Provider) restore(b *bundle.Bundle) {
+	if b == nil || len(b.Tickets) == 0 {
+		return
+	}
+	p.history.Skip()
+	p.tickets = make(map[string]schema.Ticket, len(b.Tickets))
+	p.nextID = 0
+	for _, tk := range b.Tickets {
+		p.tickets[tk.ID] = cloneTicket(tk)
+		var n int
+		if _, err := fmt.Sscanf(tk.ID, "TCK-%d", &n); err == nil && n > p.nextID {
+			p.nextID = n
+		}
+	}
+}
@@ -0,0 +1,26 @@
+package ticketmock
+
+import (
+	"context"
+
+	"github.com/opsorch/opsorch-core/schema"
+	"github.com/opsorch/opsorch-mock-adapters/internal/mockutil"
+)
+
+// Stats counts the tickets Query matches, ignoring query.Limit, by status,
+// priority (reported as the severity), service, and team.
+func (p *Provider) Stats(ctx context.Context, query schema.TicketQuery) (mockutil.Counts, error) {
+	query.Limit = 0
+	tickets, err := p.Query(ctx, query)
+	if err != nil {
+		return mockutil.Counts{}, err
+	}
+	counts := mockutil.NewCounts()
+	for _, tk := range tickets {
+		priority, _ := tk.Fields["priority"].(string)
+		service, _ := tk.Fields["service"].(string)
+		team, _ := tk.Fields["team"].(string)
+		counts.Add(tk.Status, priority, service, team)
+	}
+	return counts, nil
+}
@@ -0,0 +1,228 @@
+package ticketmock
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"strings"
+	"time"
+
+	"github.com/opsorch/opsorch-core/orcherr"
+	"github.com/opsorch/opsorch-core/schema"
+	"github.com/opsorch/opsorch-mock-adapters/internal/mockutil"
+)
+
+// Sync statuses recorded in Fields["syncStatus"].
+const (
+	SyncStatusSynced   = "synced"
+	SyncStatusConflict = "conflict"
+)
+
+// EventSyncConflict is published on the mock event bus for every conflict a
+// sync finds.
+const EventSyncConflict = "ticket.syncConflict"
+
+// externalSystem is the tracker seeded tickets are mirrored to.
+const externalSystem = "linear"
+
+// syncConflictFields are the fields a simulated conflict can land on.
+var syncConflictFields = []string{"status", "priority", "title"}
+
+// SyncConflict is a field both sides changed since the last sync.
+type SyncConflict struct {
+	TicketID        string    `json:"ticketId"`
+	ExternalKey     string    `json:"externalKey"`
+	Field           string    `json:"field"`
+	Local           any       `json:"local"`
+	Remote          any       `json:"remote"`
+	RemoteUpdatedAt time.Time `json:"remoteUpdatedAt"`
+	RemoteUpdatedBy string    `json:"remoteUpdatedBy"`
+}
+
+// SyncRequest triggers a simulated sync with the external tracker.
+type SyncRequest struct {
+	// IDs limits the sync to these tickets; empty syncs every linked ticket.
+	IDs []string `json:"ids,omitempty"`
+	// ConflictIDs are tickets the tracker has also changed, so they come back
+	// in conflict.
+	ConflictIDs []string `json:"conflictIds,omitempty"`
+	// Conflicts injects conflicts on this many more synced tickets, picked in
+	// ID order.
+	Conflicts int `json:"conflicts,omitempty"`
+}
+
+// SyncResult is the outcome of one sync.
+type SyncResult struct {
+	SyncedAt time.Time `json:"syncedAt"`
+	// Synced tickets match the tracker; Pushed is the subset whose local
+	// changes were sent to it.
+	Synced    []string       `json:"synced"`
+	Pushed    []string       `json:"pushed"`
+	Conflicts []SyncConflict `json:"conflicts"`
+}
+
+// linkExternal mirrors a seeded ticket to the external tracker, as synced
+// when it was last updated.
+func linkExternal(tk *schema.Ticket) {
+	var n int
+	if _, err := fmt.Sscanf(tk.ID, "TCK-%d", &n); err != nil {
+		return
+	}
+	if tk.Fields == nil {
+		tk.Fields = map[string]any{}
+	}
+	key := fmt.Sprintf("OPS-%d", 400+n)
+	tk.Fields["externalSystem"] = externalSystem
+	tk.Fields["externalKey"] = key
+	tk.Fields["externalUrl"] = "https://linear.app/demo/issue/" + key
+	tk.Fields["lastSyncedAt"] = tk.UpdatedAt.Format(time.RFC3339Nano)
+	tk.Fields["syncStatus"] = SyncStatusSynced
+}
+
+// Sync simulates a two-way sync with the external tracker. Linked tickets
+// changed locally since lastSyncedAt are pushed; tickets named in ConflictIDs
+// (or picked by Conflicts) come back with the tracker holding a different
+// value for a field changed on both sides, and keep their lastSyncedAt until
+// resolved. A ticket already in conflict stays so until it is updated
+// locally, which resolves the conflict in its favour on the next sync.
+// Conflicts are recorded in Fields["syncConflicts"] and published as
+// EventSyncConflict.
+func (p *Provider) Sync(ctx context.Context, req SyncRequest) (SyncResult, error) {
+	_ = ctx
+	if req.Conflicts < 0 {
+		return SyncResult{}, orcherr.New("bad_request", "conflicts must not be negative", nil)
+	}
+
+	p.mu.Lock()
+	// Sync times compare against UpdatedAt, which Update stamps from the wall
+	// clock.
+	now := time.Now().UTC()
+	ids := append([]string(nil), req.IDs...)
+	if len(ids) == 0 {
+		for _, id := range sortedTicketIDs(p.tickets) {
+			if _, ok := p.tickets[id].Fields["externalKey"].(string); ok {
+				ids = append(ids, id)
+			}
+		}
+	}
+	inject := map[string]bool{}
+	for _, id := range req.ConflictIDs {
+		if !inject[id] && !containsID(ids, id) {
+			ids = append(ids, id)
+		}
+		inject[id] = true
+	}
+	for _, id := range ids {
+		if req.Conflicts == 0 {
+			break
+		}
+		if !inject[id] {
+			inject[id] = true
+			req.Conflicts--
+		}
+	}
+
+	result := SyncResult{SyncedAt: now, Synced: []string{}, Pushed: []string{}, Conflicts: []SyncConflict{}}
+	for _, id := range ids {
+		tk, ok := p.ticketLocked(id)
+		if !ok {
+			p.mu.Unlock()
+			return SyncResult{}, orcherr.New("not_found", fmt.Sprintf("ticket %s not found", id), nil)
+		}
+		key, ok := tk.Fields["externalKey"].(string)
+		if !ok {
+			p.mu.Unlock()
+			return SyncResult{}, orcherr.New("bad_request", fmt.Sprintf("ticket %s is not linked to %s", id, externalSystem), nil)
+		}
+		lastSynced, _ := time.Parse(time.RFC3339Nano, fmt.Sprint(tk.Fields["lastSyncedAt"]))
+		changed := tk.UpdatedAt.After(lastSynced)
+
+		tk.Fields = mockutil.CloneMap(tk.Fields)
+		conflicts := pendingConflicts(tk)
+		if len(conflicts) > 0 && changed {
+			// Updated since the conflict was found: the local value wins.
+			conflicts = nil
+		}
+		if inject[id] {
+			conflicts = append(conflicts, injectConflict(tk, key, now))
+		}
+		if len(conflicts) > 0 {
+			tk.Fields["syncStatus"] = SyncStatusConflict
+			tk.Fields["syncConflicts"] = conflicts
+			result.Conflicts = append(result.Conflicts, conflicts...)
+		} else {
+			delete(tk.Fields, "syncConflicts")
+			tk.Fields["syncStatus"] = SyncStatusSynced
+			tk.Fields["lastSyncedAt"] = now.Format(time.RFC3339Nano)
+			result.Synced = append(result.Synced, id)
+			if changed {
+				result.Pushed = append(result.Pushed, id)
+			}
+		}
+		p.tickets[id] = tk
+	}
+	p.mu.Unlock()
+
+	for _, conflict := range result.Conflicts {
+		mockutil.PublishEvent(mockutil.Event{Type: EventSyncConflict, Source: externalSystem, At: now, Data: conflict})
+	}
+	return result, nil
+}
+
+// pendingConflicts returns the conflicts a ticket is still holding from an
+// earlier sync.
+func pendingConflicts(tk schema.Ticket) []SyncConflict {
+	conflicts, _ := tk.Fields["syncConflicts"].([]SyncConflict)
+	return append([]SyncConflict(nil), conflicts...)
+}
+
+// injectConflict makes up a tracker-side change to one of the ticket's
+// fields, picked from a hash of its ID, by the ticket's reporter.
+func injectConflict(tk schema.Ticket, key string, now time.Time) SyncConflict {
+	h := fnv.New32a()
+	h.Write([]byte(tk.ID))
+	field := syncConflictFields[h.Sum32()%uint32(len(syncConflictFields))]
+
+	conflict := SyncConflict{
+		TicketID:        tk.ID,
+		ExternalKey:     key,
+		Field:           field,
+		RemoteUpdatedAt: now.Add(-time.Duration(2+h.Sum32()%20) * time.Minute),
+		RemoteUpdatedBy: fallbackString(tk.Reporter, "triage-bot"),
+	}
+	switch field {
+	case "status":
+		conflict.Local = tk.Status
+		conflict.Remote = "in_progress"
+		if tk.Status == "in_progress" {
+			conflict.Remote = "blocked"
+		}
+	case "priority":
+		local, _ := tk.Fields["priority"].(string)
+		conflict.Local = local
+		conflict.Remote = "P1"
+		if strings.HasPrefix(local, "P") && local > "P0" {
+			conflict.Remote = fmt.Sprintf("P%c", local[1]-1)
+		}
+	default:
+		conflict.Local = tk.Title
+		conflict.Remote = "[Blocked] " + tk.Title
+	}
+	return conflict
+}
+
+func containsID(ids []string, want string) bool {
+	for _, id := range ids {
+		if id == want {
+			return true
+		}
+	}
+	return false
+}
+
+func fallbackString(val, def string) string {
+	if strings.TrimSpace(val) != "" {
+		return val
+	}
+	return def
+}
@@ -0,0 +1,150 @@
+package ticketmock
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/opsorch/opsorch-core/orcherr"
+	"github.com/opsorch/opsorch-core/schema"
+	"github.com/opsorch/opsorch-mock-adapters/internal/mockutil"
+)
+
+// TicketTemplate describes a follow-up ticket shape that can be pre-filled from an incident.
+type TicketTemplate struct {
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	TitleFormat string   `json:"titleFormat"`
+	Description string   `json:"description"`
+	Labels      []string `json:"labels"`
+	Checklist   []string `json:"checklist"`
+	DueInHours  int      `json:"dueInHours"`
+}
+
+var ticketTemplates = map[string]TicketTemplate{
+	"postmortem": {
+		ID:          "postmortem",
+		Name:        "Postmortem",
+		TitleFormat: "Postmortem: %s",
+		Description: "Write the blameless postmortem for %s: timeline, contributing factors, customer impact, and follow-up actions.",
+		Labels:      []string{"postmortem", "incident-follow-up"},
+		Checklist: []string{
+			"Draft timeline from incident channel",
+			"Identify contributing factors",
+			"Quantify customer impact",
+			"Schedule review meeting",
+			"File remediation action items",
+		},
+		DueInHours: 120,
+	},
+	"remediation": {
+		ID:          "remediation",
+		Name:        "Remediation action item",
+		TitleFormat: "Remediate: %s",
+		Description: "Implement the long-term fix identified during %s so the failure mode cannot recur.",
+		Labels:      []string{"action-item", "incident-follow-up"},
+		Checklist: []string{
+			"Agree on fix with owning team",
+			"Implement and review change",
+			"Add alerting or test coverage",
+			"Link change to postmortem",
+		},
+		DueInHours: 336,
+	},
+}
+
+// Templates lists the available ticket templates ordered by ID.
+func Templates() []TicketTemplate {
+	out := make([]TicketTemplate, 0, len(ticketTemplates))
+	for _, tmpl := range ticketTemplates {
+		tmpl.Labels = mockutil.CloneStringSlice(tmpl.Labels)
+		tmpl.Checklist = mockutil.CloneStringSlice(tmpl.Checklist)
+		out = append(out, tmpl)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return out
+}
+
+// CreateFromIncident creates a follow-up ticket from a template, pre-filling
+// service, team, environment, priority, and the related incident link.
+func (p *Provider) CreateFromIncident(ctx context.Context, templateID string, inc schema.Incident) (schema.Ticket, error) {
+	tmpl, ok := ticketTemplates[templateID]
+	if !ok {
+		return schema.Ticket{}, orcherr.New("bad_request", fmt.Sprintf("unknown ticket template %q", templateID), nil)
+	}
+	if inc.ID == "" {
+		return schema.Ticket{}, orcherr.New("bad_request", "incident id is required", nil)
+	}
+
+	subject := inc.Title
+	if subject == "" {
+		subject = inc.ID
+	}
+	service := inc.Service
+	if service == "" {
+		service, _ = inc.Fields["service"].(string)
+	}
+	team, _ := inc.Fields["team"].(string)
+	if team == "" && service != "" {
+		team = mockutil.GetTeamForService(service)
+	}
+	environment, _ := inc.Fields["environment"].(string)
+	if environment == "" {
+		environment = "prod"
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now().UTC()
+	checklist := make([]map[string]any, 0, len(tmpl.Checklist))
+	for _, item := range tmpl.Checklist {
+		checklist = append(checklist, map[string]any{"label": item, "done": false})
+	}
+	fields := map[string]any{
+		"service":     service,
+		"environment": environment,
+		"team":        team,
+		"priority":    priorityForSeverity(inc.Severity),
+		"incident_id": inc.ID,
+		"template":    tmpl.ID,
+		"labels":      mockutil.CloneStringSlice(tmpl.Labels),
+		"checklist":   checklist,
+		"dueDate":     now.Add(time.Duration(tmpl.DueInHours) * time.Hour),
+		"links":       serviceLinks(service),
+	}
+	metadata := map[string]any{
+		"incident_id":      inc.ID,
+		"relatedIncidents": []string{inc.ID},
+		"template":         tmpl.ID,
+	}
+	if inc.URL != "" {
+		metadata["incidentUrl"] = inc.URL
+	}
+
+	tk := p.createLocked(schema.CreateTicketInput{
+		Title:       fmt.Sprintf(tmpl.TitleFormat, subject),
+		Description: fmt.Sprintf(tmpl.Description, inc.ID),
+		Fields:      fields,
+		Metadata:    metadata,
+	}, now)
+
+	stored := p.tickets[tk.ID]
+	stored.Reporter = "incident-manager"
+	p.tickets[tk.ID] = stored
+	return cloneTicket(stored), nil
+}
+
+func priorityForSeverity(severity string) string {
+	switch severity {
+	case "sev1", "critical":
+		return "P0"
+	case "sev2", "error":
+		return "P1"
+	case "sev3", "warning":
+		return "P2"
+	default:
+		return "P3"
+	}
+}
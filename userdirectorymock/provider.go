@@ -0,0 +1,235 @@
+package userdirectorymock
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/opsorch/opsorch-core/orcherr"
+	"github.com/opsorch/opsorch-mock-adapters/internal/mockutil"
+)
+
+// ProviderName identifies the mock user directory provider.
+const ProviderName = "mock"
+
+// User kinds.
+const (
+	KindPerson = "person"
+	KindBot    = "bot"
+)
+
+// Config controls mock user directory data.
+type Config struct {
+	Source string
+}
+
+// Provider serves user profiles for the people and bots referenced by the
+// other mock providers so mentions and assignees resolve to a profile.
+type Provider struct {
+	cfg   Config
+	users []User
+	index map[string]int
+}
+
+// User is a directory profile. ManagerChain lists managers from the direct
+// manager up to the top of the organization.
+type User struct {
+	ID           string         `json:"id"`
+	Name         string         `json:"name"`
+	Email        string         `json:"email"`
+	Handle       string         `json:"handle"`
+	Kind         string         `json:"kind"`
+	Avatar       string         `json:"avatar,omitempty"`
+	Title        string         `json:"title"`
+	Team         string         `json:"team"`
+	Manager      string         `json:"manager,omitempty"`
+	ManagerChain []string       `json:"managerChain,omitempty"`
+	Reports      []string       `json:"reports,omitempty"`
+	Groups       []string       `json:"groups,omitempty"`
+	Location     string         `json:"location,omitempty"`
+	Timezone     string         `json:"timezone,omitempty"`
+	Aliases      []string       `json:"aliases,omitempty"`
+	Metadata     map[string]any `json:"metadata,omitempty"`
+}
+
+// UserQuery filters users. Query matches the handle, name, email, or title as
+// a case-insensitive substring.
+type UserQuery struct {
+	Query string `json:"query,omitempty"`
+	Team  string `json:"team,omitempty"`
+	Group string `json:"group,omitempty"`
+	Kind  string `json:"kind,omitempty"`
+	Limit int    `json:"limit,omitempty"`
+}
+
+// New constructs the mock user directory.
+func New(cfg map[string]any) (*Provider, error) {
+	p := &Provider{cfg: parseConfig(cfg)}
+	p.users, p.index = p.seed()
+	return p, nil
+}
+
+// Get resolves a user by ID, handle (with or without a leading @), email,
+// alias, or full name.
+func (p *Provider) Get(ctx context.Context, ref string) (User, error) {
+	_ = ctx
+
+	key := strings.ToLower(strings.TrimPrefix(strings.TrimSpace(ref), "@"))
+	if i, ok := p.index[key]; ok {
+		return cloneUser(p.users[i]), nil
+	}
+	for _, user := range p.users {
+		if strings.EqualFold(user.Name, key) {
+			return cloneUser(user), nil
+		}
+	}
+	return User{}, orcherr.New("not_found", fmt.Sprintf("user not found: %s", ref), nil)
+}
+
+// Search returns matching users. With a query, handle and name prefix matches
+// rank ahead of other matches so mention autocomplete finds the right person.
+func (p *Provider) Search(ctx context.Context, query UserQuery) ([]User, error) {
+	_ = ctx
+
+	needle := strings.ToLower(strings.TrimPrefix(strings.TrimSpace(query.Query), "@"))
+	type match struct {
+		user User
+		rank int
+	}
+	matches := make([]match, 0)
+	for _, user := range p.users {
+		if query.Team != "" && user.Team != query.Team {
+			continue
+		}
+		if query.Group != "" && !contains(user.Groups, query.Group) {
+			continue
+		}
+		if query.Kind != "" && user.Kind != query.Kind {
+			continue
+		}
+		rank := 0
+		if needle != "" {
+			rank = matchRank(user, needle)
+			if rank < 0 {
+				continue
+			}
+		}
+		matches = append(matches, match{user: user, rank: rank})
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].rank != matches[j].rank {
+			return matches[i].rank < matches[j].rank
+		}
+		return matches[i].user.Name < matches[j].user.Name
+	})
+	if query.Limit > 0 && len(matches) > query.Limit {
+		matches = matches[:query.Limit]
+	}
+	out := make([]User, 0, len(matches))
+	for _, m := range matches {
+		out = append(out, cloneUser(m.user))
+	}
+	return out, nil
+}
+
+// matchRank scores a query match: 0 for a handle prefix, 1 for a name or last
+// name prefix, 2 for any other substring, and -1 for no match.
+func matchRank(user User, needle string) int {
+	if strings.HasPrefix(user.Handle, needle) {
+		return 0
+	}
+	name := strings.ToLower(user.Name)
+	if strings.HasPrefix(name, needle) || strings.Contains(name, " "+needle) {
+		return 1
+	}
+	for _, field := range []string{name, strings.ToLower(user.Email), strings.ToLower(user.Title)} {
+		if strings.Contains(field, needle) {
+			return 2
+		}
+	}
+	return -1
+}
+
+func (p *Provider) seed() ([]User, map[string]int) {
+	users := make([]User, 0, len(seedUsers))
+	byID := make(map[string]seedUser, len(seedUsers))
+	reports := make(map[string][]string)
+	for _, s := range seedUsers {
+		byID[s.id] = s
+		if s.manager != "" {
+			reports[s.manager] = append(reports[s.manager], s.id)
+		}
+	}
+
+	for _, s := range seedUsers {
+		actor, _ := mockutil.LookupActor(s.id)
+		user := User{
+			ID:       s.id,
+			Name:     actor.DisplayName,
+			Email:    actor.Email,
+			Handle:   s.id,
+			Kind:     KindPerson,
+			Avatar:   actor.Avatar,
+			Title:    s.title,
+			Team:     actor.Team,
+			Manager:  s.manager,
+			Reports:  mockutil.CloneStringSlice(reports[s.id]),
+			Location: s.location,
+			Timezone: s.timezone,
+			Aliases:  mockutil.CloneStringSlice(s.aliases),
+			Metadata: map[string]any{"source": p.cfg.Source},
+		}
+		if actor.Type != mockutil.ActorHuman {
+			user.Kind = KindBot
+		}
+		// Everyone belongs to their team's group; engineering is the root team.
+		user.Groups = append([]string{actor.Team}, s.groups...)
+		if actor.Team != "engineering" {
+			user.Groups = append(user.Groups, "engineering")
+		}
+		sort.Strings(user.Groups)
+		user.Metadata["slack_channel"] = mockutil.GetChannelForTeam(actor.Team)
+		for m := s.manager; m != ""; m = byID[m].manager {
+			user.ManagerChain = append(user.ManagerChain, m)
+		}
+		users = append(users, user)
+	}
+
+	index := make(map[string]int, len(users)*3)
+	for i, user := range users {
+		index[strings.ToLower(user.ID)] = i
+		index[strings.ToLower(user.Email)] = i
+		for _, alias := range user.Aliases {
+			index[strings.ToLower(alias)] = i
+		}
+	}
+	return users, index
+}
+
+func contains(values []string, want string) bool {
+	for _, v := range values {
+		if v == want {
+			return true
+		}
+	}
+	return false
+}
+
+func cloneUser(in User) User {
+	out := in
+	out.ManagerChain = mockutil.CloneStringSlice(in.ManagerChain)
+	out.Reports = mockutil.CloneStringSlice(in.Reports)
+	out.Groups = mockutil.CloneStringSlice(in.Groups)
+	out.Aliases = mockutil.CloneStringSlice(in.Aliases)
+	out.Metadata = mockutil.CloneMap(in.Metadata)
+	return out
+}
+
+func parseConfig(cfg map[string]any) Config {
+	out := Config{Source: "mock"}
+	if v, ok := cfg["source"].(string); ok && v != "" {
+		out.Source = v
+	}
+	return out
+}
@@ -0,0 +1,111 @@
+package userdirectorymock
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/opsorch/opsorch-mock-adapters/internal/mockutil"
+)
+
+func TestSeedActorsResolve(t *testing.T) {
+	prov, err := New(map[string]any{"source": "test"})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+
+	// Names used as actors, assignees, and participants across the other seeds.
+	actors := []string{"alex", "jordan", "priya", "sam", "devon", "rosa", "morgan", "casey", "taylor", "riley",
+		"jamie", "lee", "kim", "alexis", "samir", "maya", "deploy-bot"}
+	for _, name := range actors {
+		user, err := prov.Get(context.Background(), name)
+		if err != nil {
+			t.Fatalf("expected %s to resolve: %v", name, err)
+		}
+		if user.Email == "" || user.Team == "" || user.Metadata["source"] != "test" {
+			t.Fatalf("incomplete profile for %s: %+v", name, user)
+		}
+	}
+
+	// teammock member IDs are email addresses.
+	lead, err := prov.Get(context.Background(), "charlie.brown@opsorch.com")
+	if err != nil || lead.Team != "team-velocity" {
+		t.Fatalf("expected teammock owner to resolve by email, got %+v, %v", lead, err)
+	}
+	for _, ref := range []string{"@sam", "Sam Okafor", "SAM.OKAFOR@opsorch.com"} {
+		if user, err := prov.Get(context.Background(), ref); err != nil || user.ID != "sam" {
+			t.Fatalf("expected %q to resolve to sam, got %+v, %v", ref, user, err)
+		}
+	}
+	if _, err := prov.Get(context.Background(), "nobody"); err == nil || !strings.Contains(err.Error(), "not_found") {
+		t.Fatalf("expected not_found, got %v", err)
+	}
+}
+
+func TestManagerChainAndReports(t *testing.T) {
+	prov, _ := New(nil)
+
+	rosa, _ := prov.Get(context.Background(), "rosa")
+	if !reflect.DeepEqual(rosa.ManagerChain, []string{"priya", "alice.johnson"}) {
+		t.Fatalf("unexpected manager chain: %v", rosa.ManagerChain)
+	}
+	vp, _ := prov.Get(context.Background(), "alice.johnson")
+	if vp.Manager != "" || len(vp.ManagerChain) != 0 || len(vp.Reports) == 0 {
+		t.Fatalf("expected VP at the top with reports, got %+v", vp)
+	}
+	frank, _ := prov.Get(context.Background(), "frank.miller")
+	if !reflect.DeepEqual(frank.Reports, []string{"sam", "kim"}) {
+		t.Fatalf("unexpected reports: %v", frank.Reports)
+	}
+}
+
+func TestSearch(t *testing.T) {
+	prov, _ := New(nil)
+
+	// Handle prefix beats a substring elsewhere in the profile.
+	got, _ := prov.Search(context.Background(), UserQuery{Query: "@sam"})
+	if len(got) < 2 || got[0].ID != "sam" || got[1].ID != "samir" {
+		t.Fatalf("expected sam then samir, got %+v", got)
+	}
+
+	commanders, _ := prov.Search(context.Background(), UserQuery{Group: "incident-commanders"})
+	for _, user := range commanders {
+		if !contains(user.Groups, "incident-commanders") {
+			t.Fatalf("group filter ignored: %+v", user)
+		}
+	}
+	if len(commanders) < 4 {
+		t.Fatalf("expected incident commanders, got %d", len(commanders))
+	}
+
+	team, _ := prov.Search(context.Background(), UserQuery{Team: "team-revenue"})
+	if len(team) != 3 {
+		t.Fatalf("expected three revenue users, got %+v", team)
+	}
+	bots, _ := prov.Search(context.Background(), UserQuery{Kind: KindBot, Limit: 1})
+	if len(bots) != 1 || bots[0].Kind != KindBot {
+		t.Fatalf("expected one bot, got %+v", bots)
+	}
+}
+
+func TestDirectoryUsesActorRegistry(t *testing.T) {
+	prov, _ := New(nil)
+	for _, s := range seedUsers {
+		actor, ok := mockutil.LookupActor(s.id)
+		if !ok {
+			t.Fatalf("directory user %s is missing from the actor registry", s.id)
+		}
+		user, err := prov.Get(context.Background(), s.id)
+		if err != nil {
+			t.Fatalf("expected %s to resolve: %v", s.id, err)
+		}
+		if user.Name != actor.DisplayName || user.Email != actor.Email || user.Team != actor.Team || user.Avatar != actor.Avatar {
+			t.Fatalf("directory profile %+v disagrees with registry %+v", user, actor)
+		}
+	}
+	bot, _ := prov.Get(context.Background(), "deploy-bot")
+	if bot.Kind != KindBot {
+		t.Fatalf("expected deploy-bot to be a bot, got %q", bot.Kind)
+	}
+}
@@ -0,0 +1,94 @@
+package userdirectorymock
+
+// seedUser is a directory entry before derived fields are filled in. Names,
+// emails, teams, and kinds come from the shared actor registry.
+type seedUser struct {
+	id       string
+	title    string
+	manager  string
+	groups   []string
+	location string
+	timezone string
+	aliases  []string
+}
+
+// seedUsers covers the team leads listed by teammock plus every person who
+// appears as an actor, assignee, or participant in the other seeds. Short-name
+// actors keep their first name as the handle so existing references resolve.
+var seedUsers = []seedUser{
+	{id: "alice.johnson", title: "VP of Engineering",
+		groups: []string{"engineering-leads", "incident-commanders"}, location: "San Francisco, CA", timezone: "America/Los_Angeles",
+		aliases: []string{"alice@demo.com"}},
+
+	{id: "charlie.brown", title: "Senior Full-Stack Engineer", manager: "alice.johnson",
+		groups: []string{"engineering-leads", "checkout-oncall"}, location: "New York, NY", timezone: "America/New_York"},
+	{id: "diana.prince", title: "Frontend Engineer", manager: "charlie.brown",
+		groups: []string{"checkout-oncall"}, location: "Seattle, WA", timezone: "America/Los_Angeles"},
+	{id: "alex", title: "Senior Site Reliability Engineer", manager: "charlie.brown",
+		groups: []string{"checkout-oncall", "incident-commanders", "sre"}, location: "New York, NY", timezone: "America/New_York"},
+
+	{id: "eve.wilson", title: "Senior Search Engineer", manager: "alice.johnson",
+		groups: []string{"engineering-leads", "search-oncall"}, location: "Portland, OR", timezone: "America/Los_Angeles"},
+	{id: "jamie", title: "Search Infrastructure Engineer", manager: "eve.wilson",
+		groups: []string{"search-oncall"}, location: "Portland, OR", timezone: "America/Los_Angeles"},
+
+	{id: "frank.miller", title: "Senior Payments Engineer", manager: "alice.johnson",
+		groups: []string{"engineering-leads", "payments-oncall"}, location: "Denver, CO", timezone: "America/Denver"},
+	{id: "sam", title: "Staff Payments Engineer", manager: "frank.miller",
+		groups: []string{"payments-oncall", "incident-commanders"}, location: "Denver, CO", timezone: "America/Denver"},
+	{id: "kim", title: "Payments Engineer", manager: "frank.miller",
+		groups: []string{"payments-oncall"}, location: "Remote", timezone: "America/Chicago"},
+
+	{id: "grace.hopper", title: "Senior Backend Engineer", manager: "alice.johnson",
+		groups: []string{"engineering-leads"}, location: "Boston, MA", timezone: "America/New_York"},
+	{id: "lee", title: "Messaging Platform Engineer", manager: "grace.hopper",
+		location: "Boston, MA", timezone: "America/New_York"},
+	{id: "taylor", title: "Backend Engineer", manager: "grace.hopper",
+		location: "Remote", timezone: "America/New_York"},
+
+	{id: "henry.ford", title: "Security Engineer", manager: "alice.johnson",
+		groups: []string{"engineering-leads", "security"}, location: "Los Angeles, CA", timezone: "America/Los_Angeles"},
+	{id: "devon", title: "Identity Engineer", manager: "henry.ford",
+		// Moved over from payments and is still pulled in as a payments SME.
+		groups: []string{"security", "payments-oncall"}, location: "Los Angeles, CA", timezone: "America/Los_Angeles"},
+
+	{id: "iris.chang", title: "Data Platform Lead", manager: "alice.johnson",
+		groups: []string{"engineering-leads", "data-oncall"}, location: "Remote", timezone: "America/Los_Angeles"},
+	{id: "morgan", title: "Database Reliability Engineer", manager: "iris.chang",
+		groups: []string{"data-oncall", "incident-commanders", "sre"}, location: "Remote", timezone: "America/Chicago"},
+	{id: "maya", title: "Analytics Engineer", manager: "iris.chang",
+		groups: []string{"data-oncall"}, location: "Remote", timezone: "America/Los_Angeles"},
+
+	{id: "jack.sparrow", title: "ML Engineer", manager: "alice.johnson",
+		groups: []string{"engineering-leads"}, location: "Austin, TX", timezone: "America/Chicago"},
+	{id: "riley", title: "ML Platform Engineer", manager: "jack.sparrow",
+		location: "Austin, TX", timezone: "America/Chicago"},
+
+	{id: "kate.bishop", title: "Data Engineer", manager: "alice.johnson",
+		groups: []string{"engineering-leads"}, location: "Chicago, IL", timezone: "America/Chicago"},
+	{id: "casey", title: "Catalog Engineer", manager: "kate.bishop",
+		location: "Chicago, IL", timezone: "America/Chicago"},
+
+	{id: "luke.cage", title: "Logistics Engineer", manager: "alice.johnson",
+		groups: []string{"engineering-leads"}, location: "Miami, FL", timezone: "America/New_York"},
+	{id: "alexis", title: "Shipping Engineer", manager: "luke.cage",
+		location: "Miami, FL", timezone: "America/New_York"},
+
+	{id: "maria.hill", title: "Real-time Systems Engineer", manager: "alice.johnson",
+		groups: []string{"engineering-leads", "realtime-oncall"}, location: "Phoenix, AZ", timezone: "America/Phoenix"},
+	{id: "samir", title: "Real-time Engineer", manager: "maria.hill",
+		groups: []string{"realtime-oncall"}, location: "Phoenix, AZ", timezone: "America/Phoenix"},
+
+	// Platform SRE and incident communications report straight to the VP.
+	{id: "jordan", title: "SRE Lead", manager: "alice.johnson",
+		groups: []string{"engineering-leads", "sre", "incident-commanders"}, location: "San Francisco, CA", timezone: "America/Los_Angeles",
+		aliases: []string{"sre-lead"}},
+	{id: "priya", title: "Incident Manager", manager: "alice.johnson",
+		groups: []string{"incident-commanders", "incident-comms"}, location: "San Francisco, CA", timezone: "America/Los_Angeles",
+		aliases: []string{"incident-manager"}},
+	{id: "rosa", title: "Incident Communications Specialist", manager: "priya",
+		groups: []string{"incident-comms"}, location: "Madrid, ES", timezone: "Europe/Madrid"},
+
+	{id: "deploy-bot", title: "Deployment automation", manager: "jordan"},
+	{id: "sre-bot", title: "Incident automation", manager: "jordan"},
+}